@@ -7,31 +7,166 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/aske/go_fi_chart/internal/api"
 	"github.com/aske/go_fi_chart/internal/config"
 	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/audit"
+	"github.com/aske/go_fi_chart/internal/domain/budget"
+	"github.com/aske/go_fi_chart/internal/domain/category"
+	"github.com/aske/go_fi_chart/internal/domain/drift"
 	"github.com/aske/go_fi_chart/internal/domain/gamification"
+	"github.com/aske/go_fi_chart/internal/domain/goal"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+	"github.com/aske/go_fi_chart/internal/domain/networth"
+	"github.com/aske/go_fi_chart/internal/domain/payoff"
+	"github.com/aske/go_fi_chart/internal/domain/rebalance"
+	"github.com/aske/go_fi_chart/internal/domain/recurring"
+	"github.com/aske/go_fi_chart/internal/domain/report"
+	"github.com/aske/go_fi_chart/internal/domain/statement"
+	"github.com/aske/go_fi_chart/internal/infrastructure/alerting"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
 	chi "github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
+const (
+	// recurringInterval 정기 거래 스케줄러의 실행 간격입니다.
+	recurringInterval = time.Minute
+	// budgetInterval 모든 예산을 다시 평가하는 간격입니다. 가져오기처럼 이벤트 없이 기록된 지출도 이 주기로 알립니다.
+	budgetInterval = 5 * time.Minute
+	// driftInterval 모든 포트폴리오의 비중 차이를 다시 평가하는 간격입니다. 이벤트 없이 바뀐 자산도 이 주기로 기록합니다.
+	driftInterval = 15 * time.Minute
+)
+
 func main() {
 	log.Println("FIN-RPG 서버 시작 중...")
 
 	// 설정 로드
 	cfg := config.NewDefaultConfig()
+	if driver := os.Getenv("DB_DRIVER"); driver != "" {
+		cfg.Database.Driver = driver
+	}
+	if path := os.Getenv("DB_PATH"); path != "" {
+		cfg.Database.Path = path
+	}
+	if host := os.Getenv("DB_HOST"); host != "" {
+		cfg.Database.Host = host
+	}
+	if port, err := strconv.Atoi(os.Getenv("DB_PORT")); err == nil {
+		cfg.Database.Port = port
+	}
+	if name := os.Getenv("DB_NAME"); name != "" {
+		cfg.Database.Name = name
+	}
+	if user := os.Getenv("DB_USER"); user != "" {
+		cfg.Database.User = user
+	}
+	if password := os.Getenv("DB_PASSWORD"); password != "" {
+		cfg.Database.Password = password
+	}
 
 	// 저장소 생성
-	assetRepo := asset.NewMemoryAssetRepository()
-	transactionRepo := asset.NewMemoryTransactionRepository()
-	portfolioRepo := asset.NewMemoryPortfolioRepository()
-	gamificationRepo := gamification.NewMemoryRepository()
+	repos, err := newRepositories(cfg.Database)
+	if err != nil {
+		log.Fatalf("저장소 생성 실패: %v", err)
+	}
+	defer func() {
+		if err := repos.close(); err != nil {
+			log.Printf("저장소 종료 실패: %v", err)
+		}
+	}()
+
+	// 자산, 거래, 포트폴리오의 쓰기는 감사 로그로 기록
+	recorder := audit.NewRecorder(repos.audit)
+	assetRepo := audit.NewAssetRepository(repos.assets, recorder)
+	transactionRepo := audit.NewTransactionRepository(repos.transactions, recorder)
+	portfolioRepo := audit.NewPortfolioRepository(repos.portfolios, recorder)
+
+	// 거래 생성과 가져오기에서 사용자의 분류 규칙 적용
+	categorizer := category.NewEngine(repos.categories, transactionRepo)
+
+	// 거래 생성, 가져오기, 정기 거래는 원장에 복식부기 분개로 함께 전기하여 이체 금액이 입금 자산에 남도록 함
+	generalLedger := ledger.NewLedger(repos.ledger)
+
+	// 예산과 포트폴리오 드리프트 알림은 모니터링 서비스의 알림 처리자로 전달
+	alertNotifier := alerting.NewNotifier()
+
+	// 정기 거래는 도래한 회차를 주기적으로 기록하고 기록할 때마다 이벤트를 발행
+	eventBus := memory.NewEventBus()
+	scheduler := recurring.NewScheduler(repos.recurrences, assetRepo, transactionRepo, recurring.WithEventBus(eventBus), recurring.WithLedger(generalLedger))
+
+	// 예산은 거래가 기록될 때마다 평가하여 기준을 넘으면 alert.triggered 이벤트를 발행하고 알림 처리자로 전달
+	budgetService := budget.NewService(repos.budgets, assetRepo, transactionRepo, budget.WithTaxonomy(categorizer))
+	budgetMonitor := budget.NewMonitor(budgetService, eventBus)
+	if err := eventBus.Subscribe(budgetMonitor); err != nil {
+		log.Fatalf("예산 모니터 등록 실패: %v", err)
+	}
+	if err := eventBus.Subscribe(alerting.NewForwarder(alertNotifier)); err != nil {
+		log.Fatalf("알림 전달자 등록 실패: %v", err)
+	}
+
+	// 순자산은 자산 금액이 바뀔 때마다 그날의 스냅샷을 갱신
+	netWorthTracker := networth.NewTracker(repos.networth, assetRepo, transactionRepo)
+	if err := eventBus.Subscribe(netWorthTracker); err != nil {
+		log.Fatalf("순자산 기록기 등록 실패: %v", err)
+	}
+
+	// 부채 상환 계획기는 부채 잔액이 바뀔 때마다 빚 청산 목표의 진행률을 갱신
+	payoffPlanner := payoff.NewPlanner(assetRepo)
+	if err := eventBus.Subscribe(payoffPlanner); err != nil {
+		log.Fatalf("부채 상환 계획기 등록 실패: %v", err)
+	}
+
+	// 목표 전망은 자산 금액이 바뀔 때마다 다시 계산하고, 전망이 바뀌면 게임화 보상을 지급
+	goalProjector := goal.NewProjector(assetRepo, transactionRepo, eventBus)
+	if err := eventBus.Subscribe(goalProjector); err != nil {
+		log.Fatalf("목표 전망 계산기 등록 실패: %v", err)
+	}
+	if err := eventBus.Subscribe(gamification.NewGoalRewarder(repos.gamification)); err != nil {
+		log.Fatalf("목표 보상 지급기 등록 실패: %v", err)
+	}
+
+	// 시장 가격이 갱신되면 같은 심볼을 보유한 자산을 다시 평가하고 금액 변경 이벤트를 발행
+	if err := eventBus.Subscribe(asset.NewRevaluer(assetRepo, eventBus)); err != nil {
+		log.Fatalf("자산 재평가기 등록 실패: %v", err)
+	}
+
+	// 포트폴리오 비중은 자산 금액이 바뀔 때마다 목표 비중과 비교해 기록하고, 한도를 넘으면 모니터링 알림 처리자로 알림
+	rebalancer := rebalance.NewRebalancer(portfolioRepo, assetRepo, transactionRepo, eventBus, rebalance.WithLedger(generalLedger))
+	driftMonitor := drift.NewMonitor(repos.drift, portfolioRepo, rebalancer, alertNotifier)
+	if err := eventBus.Subscribe(driftMonitor); err != nil {
+		log.Fatalf("포트폴리오 드리프트 모니터 등록 실패: %v", err)
+	}
+
+	// 보고서는 REPORT_RATES("KRW=1,USD=1350")의 환율로 다른 통화의 거래를 기준 통화로 환산
+	rates, err := report.ParseRateTable(os.Getenv("REPORT_RATES"))
+	if err != nil {
+		log.Fatalf("환율 설정 오류: %v", err)
+	}
+	reportGenerator := report.NewGenerator(assetRepo, transactionRepo, report.WithRates(rates))
 
 	// API 핸들러 생성
-	apiHandler := api.NewHandler(assetRepo, transactionRepo, portfolioRepo, gamificationRepo)
+	apiHandler := api.NewHandler(assetRepo, transactionRepo, portfolioRepo, repos.gamification, api.WithCategorizer(categorizer), api.WithLedger(generalLedger), api.WithEventBus(eventBus))
+	auditHandler := api.NewAuditHandler(recorder)
+	categoryHandler := api.NewCategoryHandler(categorizer, assetRepo, transactionRepo)
+	sink, book := newStatementBackends(assetRepo, transactionRepo, categorizer, generalLedger)
+	importHandler := api.NewImportHandler(statement.NewImporter(repos.imports, sink, statement.WithDuplicateDetection(book)))
+	reconcileHandler := api.NewReconcileHandler(statement.NewReconciler(book, repos.imports, repos.reconciliations))
+	recurringHandler := api.NewRecurringHandler(scheduler, repos.recurrences, assetRepo)
+	budgetHandler := api.NewBudgetHandler(budgetService, budgetMonitor)
+	reportHandler := api.NewReportHandler(reportGenerator)
+	netWorthHandler := api.NewNetWorthHandler(netWorthTracker)
+	liabilityHandler := api.NewLiabilityHandler(assetRepo, transactionRepo, generalLedger, eventBus)
+	priceHandler := api.NewPriceHandler(assetRepo, eventBus)
+	payoffHandler := api.NewPayoffHandler(payoffPlanner, assetRepo)
+	goalHandler := api.NewGoalHandler(goalProjector, assetRepo)
+	rebalanceHandler := api.NewRebalanceHandler(rebalancer)
+	driftHandler := api.NewDriftHandler(driftMonitor)
+	ledgerHandler := api.NewLedgerHandler(generalLedger, assetRepo)
 
 	// 라우터 설정
 	r := chi.NewRouter()
@@ -51,7 +186,23 @@ func main() {
 
 	// API 라우터 그룹
 	r.Route("/api", func(r chi.Router) {
+		r.Use(api.ActorMiddleware)
 		apiHandler.RegisterRoutes(r)
+		auditHandler.RegisterRoutes(r)
+		categoryHandler.RegisterRoutes(r)
+		importHandler.RegisterRoutes(r)
+		reconcileHandler.RegisterRoutes(r)
+		recurringHandler.RegisterRoutes(r)
+		budgetHandler.RegisterRoutes(r)
+		reportHandler.RegisterRoutes(r)
+		netWorthHandler.RegisterRoutes(r)
+		liabilityHandler.RegisterRoutes(r)
+		priceHandler.RegisterRoutes(r)
+		payoffHandler.RegisterRoutes(r)
+		goalHandler.RegisterRoutes(r)
+		rebalanceHandler.RegisterRoutes(r)
+		driftHandler.RegisterRoutes(r)
+		ledgerHandler.RegisterRoutes(r)
 	})
 
 	// 서버 설정
@@ -75,15 +226,35 @@ func main() {
 		}
 	}()
 
+	// 서버가 멈춰 있던 동안 밀린 정기 거래도 시작하자마자 기록
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	go scheduler.Start(schedulerCtx, recurringInterval)
+	go budgetMonitor.Start(schedulerCtx, budgetInterval)
+	go driftMonitor.Start(schedulerCtx, driftInterval)
+
 	<-done
 	log.Println("서버 종료 중...")
+	stopScheduler()
 
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("서버 종료 실패: %v", err)
+		log.Printf("서버 종료 실패: %v", err)
 	}
 
 	log.Println("서버가 정상적으로 종료되었습니다.")
 }
+
+// newStatementBackends 거래 내역 가져오기의 거래 생성기와 대사 장부를 만듭니다.
+// TRANSACTION_SERVICE_URL이 지정되면 포트폴리오 대상 거래는 거래 서비스에서 생성하고 대사합니다.
+// 자산 대상 거래는 파일에 분류가 없으면 categorizer로 분류를 정하고 원장에 함께 전기합니다.
+func newStatementBackends(assets asset.Repository, transactions asset.TransactionRepository, categorizer statement.Categorizer, l *ledger.Ledger) (statement.Sink, statement.Book) {
+	sink := statement.TargetSink{Assets: statement.NewAssetSink(assets, transactions, statement.WithCategorizer(categorizer), statement.WithLedger(l))}
+	book := statement.TargetBook{Assets: statement.NewAssetBook(assets, transactions)}
+	if url := os.Getenv("TRANSACTION_SERVICE_URL"); url != "" {
+		sink.Portfolio = statement.NewTransactionServiceSink(url, nil)
+		book.Portfolio = statement.NewTransactionServiceBook(url, nil)
+	}
+	return sink, book
+}
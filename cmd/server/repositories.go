@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	_ "github.com/lib/pq"
+
+	"github.com/aske/go_fi_chart/internal/config"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/audit"
+	"github.com/aske/go_fi_chart/internal/domain/budget"
+	"github.com/aske/go_fi_chart/internal/domain/category"
+	"github.com/aske/go_fi_chart/internal/domain/drift"
+	"github.com/aske/go_fi_chart/internal/domain/gamification"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+	"github.com/aske/go_fi_chart/internal/domain/networth"
+	"github.com/aske/go_fi_chart/internal/domain/recurring"
+	"github.com/aske/go_fi_chart/internal/domain/statement"
+	"github.com/aske/go_fi_chart/internal/storage/postgres"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	driverMemory   = "memory"
+	driverEmbedded = "embedded"
+	driverPostgres = "postgres"
+)
+
+// repositories 서버가 사용하는 저장소 묶음입니다.
+type repositories struct {
+	assets          asset.Repository
+	transactions    asset.TransactionRepository
+	portfolios      asset.PortfolioRepository
+	gamification    gamification.Repository
+	audit           audit.Repository
+	imports         statement.Registry
+	reconciliations statement.ReconciliationRepository
+	categories      category.Repository
+	recurrences     recurring.Repository
+	budgets         budget.Repository
+	networth        networth.Repository
+	drift           drift.Repository
+	ledger          ledger.Repository
+	close           func() error
+}
+
+// newRepositories 데이터베이스 설정의 Driver에 따라 저장소를 생성합니다.
+func newRepositories(cfg config.DatabaseConfig) (*repositories, error) {
+	switch cfg.Driver {
+	case driverEmbedded:
+		return newEmbeddedRepositories(cfg.Path)
+	case driverPostgres:
+		return newPostgresRepositories(cfg)
+	case driverMemory:
+		return newMemoryRepositories(), nil
+	default:
+		log.Printf("지원하지 않는 데이터베이스 드라이버입니다(%s). 인메모리 저장소를 사용합니다.", cfg.Driver)
+		return newMemoryRepositories(), nil
+	}
+}
+
+func newMemoryRepositories() *repositories {
+	return &repositories{
+		assets:          asset.NewMemoryAssetRepository(),
+		transactions:    asset.NewMemoryTransactionRepository(),
+		portfolios:      asset.NewMemoryPortfolioRepository(),
+		gamification:    gamification.NewMemoryRepository(),
+		audit:           audit.NewMemoryRepository(),
+		imports:         statement.NewMemoryRegistry(),
+		reconciliations: statement.NewMemoryReconciliationRepository(),
+		categories:      category.NewMemoryRepository(),
+		recurrences:     recurring.NewMemoryRepository(),
+		budgets:         budget.NewMemoryRepository(),
+		networth:        networth.NewMemoryRepository(),
+		drift:           drift.NewMemoryRepository(),
+		ledger:          ledger.NewMemoryRepository(),
+		close:           func() error { return nil },
+	}
+}
+
+func newEmbeddedRepositories(path string) (*repositories, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, fmt.Errorf("데이터 디렉터리 생성 실패: %w", err)
+		}
+	}
+
+	db, err := kv.Open(path, &kv.Options{SyncWrites: true})
+	if err != nil {
+		return nil, err
+	}
+
+	assetRepo, err := asset.NewEmbeddedAssetRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	transactionRepo, err := asset.NewEmbeddedTransactionRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	portfolioRepo, err := asset.NewEmbeddedPortfolioRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	gamificationRepo, err := gamification.NewEmbeddedRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	auditRepo, err := audit.NewEmbeddedRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	categoryRepo, err := category.NewEmbeddedRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	recurringRepo, err := recurring.NewEmbeddedRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	budgetRepo, err := budget.NewEmbeddedRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	networthRepo, err := networth.NewEmbeddedRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	driftRepo, err := drift.NewEmbeddedRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	ledgerRepo, err := ledger.NewEmbeddedRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &repositories{
+		assets:          assetRepo,
+		transactions:    transactionRepo,
+		portfolios:      portfolioRepo,
+		gamification:    gamificationRepo,
+		audit:           auditRepo,
+		imports:         statement.NewEmbeddedRegistry(db),
+		reconciliations: statement.NewEmbeddedReconciliationRepository(db),
+		categories:      categoryRepo,
+		recurrences:     recurringRepo,
+		budgets:         budgetRepo,
+		networth:        networthRepo,
+		drift:           driftRepo,
+		ledger:          ledgerRepo,
+		close:           db.Close,
+	}, nil
+}
+
+// newPostgresRepositories PostgreSQL에 연결해 스키마 마이그레이션을 적용한 뒤 저장소를 생성합니다.
+func newPostgresRepositories(cfg config.DatabaseConfig) (*repositories, error) {
+	ctx := context.Background()
+	db, err := postgres.Open(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := postgres.Migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("스키마 마이그레이션 실패: %w", err)
+	}
+
+	return &repositories{
+		assets:          asset.NewPostgresAssetRepository(db),
+		transactions:    asset.NewPostgresTransactionRepository(db),
+		portfolios:      asset.NewPostgresPortfolioRepository(db),
+		gamification:    gamification.NewPostgresRepository(db),
+		audit:           audit.NewPostgresRepository(db),
+		imports:         statement.NewPostgresRegistry(db),
+		reconciliations: statement.NewPostgresReconciliationRepository(db),
+		categories:      category.NewPostgresRepository(db),
+		recurrences:     recurring.NewPostgresRepository(db),
+		budgets:         budget.NewPostgresRepository(db),
+		networth:        networth.NewPostgresRepository(db),
+		drift:           drift.NewPostgresRepository(db),
+		ledger:          ledger.NewPostgresRepository(db),
+		close:           db.Close,
+	}, nil
+}
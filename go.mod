@@ -3,17 +3,24 @@ module github.com/aske/go_fi_chart
 go 1.24.0
 
 require (
+	github.com/aske/go_fi_chart/pkg v0.0.0
+	github.com/aske/go_fi_chart/services/monitoring v0.0.0
 	github.com/go-chi/chi/v5 v5.2.1
 	github.com/google/uuid v1.6.0
+	github.com/lib/pq v1.12.3
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/text v0.17.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/kr/pretty v0.3.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/rogpeppe/go-internal v1.13.1 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
-	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace (
+	github.com/aske/go_fi_chart/pkg => ./pkg
+	github.com/aske/go_fi_chart/services/monitoring => ./services/monitoring
+)
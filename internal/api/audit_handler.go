@@ -0,0 +1,154 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/audit"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// ActorHeader 요청한 사용자를 감사 로그의 행위자로 전달하는 헤더입니다.
+const ActorHeader = "X-User-ID"
+
+// ActorMiddleware ActorHeader 값을 감사 로그의 행위자로 요청 컨텍스트에 담습니다.
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(ActorHeader); actor != "" {
+			r = r.WithContext(audit.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AuditChangeResponse 필드 변경 응답
+type AuditChangeResponse struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEntryResponse 감사 로그 항목 응답
+type AuditEntryResponse struct {
+	ID         string                `json:"id"`
+	EntityType string                `json:"entityType"`
+	EntityID   string                `json:"entityId"`
+	Action     string                `json:"action"`
+	Actor      string                `json:"actor"`
+	Changes    []AuditChangeResponse `json:"changes"`
+	RecordedAt time.Time             `json:"recordedAt"`
+}
+
+// AuditStateResponse 특정 시점의 엔티티 상태 응답
+type AuditStateResponse struct {
+	EntityType string                 `json:"entityType"`
+	EntityID   string                 `json:"entityId"`
+	At         time.Time              `json:"at"`
+	RecordedAt time.Time              `json:"recordedAt"`
+	State      map[string]interface{} `json:"state"`
+}
+
+// AuditHandler 감사 로그 조회 API 핸들러입니다.
+type AuditHandler struct {
+	recorder *audit.Recorder
+}
+
+// NewAuditHandler 새로운 감사 로그 API 핸들러를 생성합니다.
+func NewAuditHandler(recorder *audit.Recorder) *AuditHandler {
+	return &AuditHandler{recorder: recorder}
+}
+
+// RegisterRoutes 라우터에 감사 로그 API를 등록합니다.
+func (h *AuditHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/audit/{entityType}/{id}", func(r chi.Router) {
+		r.Get("/", h.GetHistory)
+		r.Get("/state", h.GetState)
+	})
+}
+
+// entityParams 경로의 엔티티 종류와 ID를 검증합니다.
+func entityParams(w http.ResponseWriter, r *http.Request) (audit.EntityType, string, bool) {
+	entityType := audit.EntityType(chi.URLParam(r, "entityType"))
+	if !audit.IsValidEntityType(entityType) {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "지원하지 않는 엔티티 종류입니다")
+		return "", "", false
+	}
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "엔티티 ID가 필요합니다")
+		return "", "", false
+	}
+	return entityType, id, true
+}
+
+// GetHistory 엔티티의 변경 이력을 기록 시각 순으로 반환합니다.
+func (h *AuditHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	entityType, id, ok := entityParams(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := h.recorder.History(r.Context(), entityType, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "변경 이력 조회 중 오류가 발생했습니다")
+		return
+	}
+
+	response := make([]AuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		changes := make([]AuditChangeResponse, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			changes = append(changes, AuditChangeResponse{Field: change.Field, Before: change.Before, After: change.After})
+		}
+		response = append(response, AuditEntryResponse{
+			ID:         entry.ID,
+			EntityType: string(entry.EntityType),
+			EntityID:   entry.EntityID,
+			Action:     string(entry.Action),
+			Actor:      entry.Actor,
+			Changes:    changes,
+			RecordedAt: entry.RecordedAt,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetState at 파라미터(RFC3339, 기본값은 현재 시각) 시점의 엔티티 상태를 반환합니다.
+func (h *AuditHandler) GetState(w http.ResponseWriter, r *http.Request) {
+	entityType, id, ok := entityParams(w, r)
+	if !ok {
+		return
+	}
+
+	at := time.Now()
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "at은 RFC3339 형식이어야 합니다")
+			return
+		}
+		at = parsed
+	}
+
+	entry, err := h.recorder.StateAt(r.Context(), entityType, id, at)
+	if err != nil {
+		var domainErr domain.Error
+		if errors.As(err, &domainErr) && domainErr.Code() == domain.ErrCodeNotFound {
+			respondError(w, http.StatusNotFound, ErrNotFound, "해당 시점의 엔티티 상태가 없습니다")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "엔티티 상태 조회 중 오류가 발생했습니다")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, AuditStateResponse{
+		EntityType: string(entityType),
+		EntityID:   id,
+		At:         at,
+		RecordedAt: entry.RecordedAt,
+		State:      entry.State,
+	})
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/audit"
+)
+
+type auditedAccount struct {
+	Balance float64
+}
+
+func newAuditTestRouter(t *testing.T) (*chi.Mux, time.Time) {
+	t.Helper()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	recorder := audit.NewRecorder(audit.NewMemoryRepository(), audit.WithClock(func() time.Time {
+		now = now.Add(time.Hour)
+		return now
+	}))
+
+	ctx := audit.WithActor(context.Background(), "user-1")
+	require.NoError(t, recorder.Record(ctx, audit.EntityAsset, "a1", audit.ActionCreate, auditedAccount{Balance: 1000}))
+	require.NoError(t, recorder.Record(ctx, audit.EntityAsset, "a1", audit.ActionUpdate, auditedAccount{Balance: 2000}))
+
+	r := chi.NewRouter()
+	NewAuditHandler(recorder).RegisterRoutes(r)
+	return r, start
+}
+
+func TestGetAuditHistory(t *testing.T) {
+	r, _ := newAuditTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/audit/asset/a1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []AuditEntryResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Len(t, response, 2)
+	assert.Equal(t, "CREATE", response[0].Action)
+	assert.Equal(t, "user-1", response[1].Actor)
+	assert.Equal(t, []AuditChangeResponse{{Field: "Balance", Before: 1000.0, After: 2000.0}}, response[1].Changes)
+}
+
+func TestGetAuditState(t *testing.T) {
+	r, start := newAuditTestRouter(t)
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		status  int
+		balance float64
+	}{
+		{name: "생성 직후", at: start.Add(90 * time.Minute), status: http.StatusOK, balance: 1000},
+		{name: "수정 이후", at: start.Add(3 * time.Hour), status: http.StatusOK, balance: 2000},
+		{name: "생성 이전", at: start, status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/audit/asset/a1/state?at="+url.QueryEscape(tt.at.Format(time.RFC3339)), nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status != http.StatusOK {
+				return
+			}
+			var response AuditStateResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+			assert.Equal(t, tt.balance, response.State["Balance"])
+		})
+	}
+}
+
+func TestGetAuditState_InvalidRequest(t *testing.T) {
+	r, _ := newAuditTestRouter(t)
+
+	for _, path := range []string{"/audit/unknown/a1", "/audit/asset/a1/state?at=yesterday"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
+
+func TestActorMiddleware(t *testing.T) {
+	var actor string
+	handler := ActorMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		actor = audit.ActorFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(ActorHeader, "user-42")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "user-42", actor)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, audit.DefaultActor, actor)
+}
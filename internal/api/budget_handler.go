@@ -0,0 +1,287 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/budget"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// CreateBudgetRequest 예산 생성 요청
+// Start를 생략하면 현재 기간부터 적용합니다.
+type CreateBudgetRequest struct {
+	Category string    `json:"category"`
+	Period   string    `json:"period"`
+	Amount   float64   `json:"amount"`
+	Currency string    `json:"currency"`
+	Rollover bool      `json:"rollover"`
+	Start    time.Time `json:"start"`
+}
+
+// UpdateBudgetRequest 예산 수정 요청
+type UpdateBudgetRequest struct {
+	Amount   float64 `json:"amount"`
+	Rollover bool    `json:"rollover"`
+}
+
+// BudgetResponse 예산 응답
+type BudgetResponse struct {
+	ID        string    `json:"id"`
+	Category  string    `json:"category"`
+	Period    string    `json:"period"`
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	Rollover  bool      `json:"rollover"`
+	Start     time.Time `json:"start"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// BudgetStatusResponse 예산 대비 실제 지출 응답
+type BudgetStatusResponse struct {
+	Budget       BudgetResponse `json:"budget"`
+	PeriodStart  time.Time      `json:"periodStart"`
+	PeriodEnd    time.Time      `json:"periodEnd"`
+	Carried      float64        `json:"carried"`
+	Available    float64        `json:"available"`
+	Spent        float64        `json:"spent"`
+	Remaining    float64        `json:"remaining"`
+	Ratio        float64        `json:"ratio"`
+	Exceeded     bool           `json:"exceeded"`
+	Transactions int            `json:"transactions"`
+}
+
+// BudgetAlertResponse 예산 알림 응답
+type BudgetAlertResponse struct {
+	ID        string            `json:"id"`
+	Level     string            `json:"level"`
+	Source    string            `json:"source"`
+	Message   string            `json:"message"`
+	Timestamp time.Time         `json:"timestamp"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+// BudgetHandler 예산 API 핸들러입니다.
+// 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리합니다.
+type BudgetHandler struct {
+	service *budget.Service
+	monitor *budget.Monitor
+	now     func() time.Time
+}
+
+// NewBudgetHandler 새로운 예산 API 핸들러를 생성합니다.
+func NewBudgetHandler(service *budget.Service, monitor *budget.Monitor) *BudgetHandler {
+	return &BudgetHandler{service: service, monitor: monitor, now: time.Now}
+}
+
+// RegisterRoutes 라우터에 예산 API를 등록합니다.
+func (h *BudgetHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/budgets", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Get("/analysis", h.Analyze)
+		r.Post("/evaluate", h.Evaluate)
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+	})
+}
+
+// List 사용자의 예산을 조회합니다.
+func (h *BudgetHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	budgets, err := h.service.List(r.Context(), userID)
+	if err != nil {
+		respondBudgetError(w, err)
+		return
+	}
+	response := make([]BudgetResponse, len(budgets))
+	for i, b := range budgets {
+		response[i] = newBudgetResponse(b)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Create 분류 예산을 생성합니다.
+func (h *BudgetHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req CreateBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	start := req.Start
+	if start.IsZero() {
+		start = h.now()
+	}
+	amount := asset.Money{Amount: req.Amount, Currency: req.Currency}
+	b, err := budget.NewBudget(userID, req.Category, budget.Period(req.Period), amount, req.Rollover, start)
+	if err != nil {
+		respondBudgetError(w, err)
+		return
+	}
+	if err := h.service.Create(r.Context(), b); err != nil {
+		respondBudgetError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, newBudgetResponse(b))
+}
+
+// Get 예산과 현재 기간의 지출 현황을 조회합니다.
+func (h *BudgetHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	b, err := h.service.Get(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		respondBudgetError(w, err)
+		return
+	}
+	status, err := h.service.Status(r.Context(), b, h.now())
+	if err != nil {
+		respondBudgetError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newBudgetStatusResponse(status))
+}
+
+// Update 예산 금액과 이월 여부를 수정합니다.
+func (h *BudgetHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req UpdateBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+	b, err := h.service.Update(r.Context(), userID, chi.URLParam(r, "id"), req.Amount, req.Rollover)
+	if err != nil {
+		respondBudgetError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newBudgetResponse(b))
+}
+
+// Delete 예산을 삭제합니다.
+func (h *BudgetHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.Delete(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		respondBudgetError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Analyze at(RFC3339, 기본값 현재)이 속한 기간의 예산 대비 실제 지출을 조회합니다.
+func (h *BudgetHandler) Analyze(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	at := h.now()
+	if value := r.URL.Query().Get("at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "at은 RFC3339 형식이어야 합니다")
+			return
+		}
+		at = parsed
+	}
+	statuses, err := h.service.Analyze(r.Context(), userID, at)
+	if err != nil {
+		respondBudgetError(w, err)
+		return
+	}
+	response := make([]BudgetStatusResponse, len(statuses))
+	for i, status := range statuses {
+		response[i] = newBudgetStatusResponse(status)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Evaluate 사용자의 예산을 즉시 평가하고 새로 발생한 알림을 반환합니다.
+func (h *BudgetHandler) Evaluate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	alerts, err := h.monitor.Evaluate(r.Context(), userID)
+	if err != nil {
+		respondBudgetError(w, err)
+		return
+	}
+	response := make([]BudgetAlertResponse, len(alerts))
+	for i, alert := range alerts {
+		response[i] = BudgetAlertResponse{
+			ID:        alert.ID,
+			Level:     string(alert.Level),
+			Source:    alert.Source,
+			Message:   alert.Message,
+			Timestamp: alert.Timestamp,
+			Metadata:  alert.Metadata,
+		}
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+func respondBudgetError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code() {
+		case domain.ErrCodeNotFound:
+			respondError(w, http.StatusNotFound, ErrNotFound, domainErr.Error())
+			return
+		case domain.ErrCodeInvalidArgument:
+			respondError(w, http.StatusBadRequest, ErrValidation, domainErr.Error())
+			return
+		}
+	}
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "예산 처리 중 오류가 발생했습니다")
+}
+
+func newBudgetResponse(b *budget.Budget) BudgetResponse {
+	return BudgetResponse{
+		ID:        b.ID,
+		Category:  b.Category,
+		Period:    string(b.Period),
+		Amount:    b.Amount.Amount,
+		Currency:  b.Amount.Currency,
+		Rollover:  b.Rollover,
+		Start:     b.Start,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
+
+func newBudgetStatusResponse(status budget.Status) BudgetStatusResponse {
+	return BudgetStatusResponse{
+		Budget:       newBudgetResponse(status.Budget),
+		PeriodStart:  status.PeriodStart,
+		PeriodEnd:    status.PeriodEnd,
+		Carried:      status.Carried,
+		Available:    status.Available,
+		Spent:        status.Spent,
+		Remaining:    status.Remaining,
+		Ratio:        status.Ratio,
+		Exceeded:     status.Exceeded(),
+		Transactions: status.Transactions,
+	}
+}
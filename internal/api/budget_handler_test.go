@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/budget"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+type alertRecorder struct {
+	alerts []budget.Alert
+}
+
+func (h *alertRecorder) HandleEvent(_ context.Context, evt event.Event) error {
+	if alert, ok := evt.Payload().(budget.Alert); ok && evt.EventType() == event.TypeAlertTriggered {
+		h.alerts = append(h.alerts, alert)
+	}
+	return nil
+}
+
+func (h *alertRecorder) HandlerName() string {
+	return "alert-recorder"
+}
+
+func doAs(t *testing.T, r http.Handler, method, path, user string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	if user != "" {
+		req.Header.Set(ActorHeader, user)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestBudgets(t *testing.T) {
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	account, err := asset.NewAsset("user-1", asset.Cash, "입출금", 1000000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), account))
+
+	bus := memory.NewEventBus()
+	recorder := &alertRecorder{}
+	service := budget.NewService(budget.NewMemoryRepository(), assets, transactions)
+	monitor := budget.NewMonitor(service, bus)
+	require.NoError(t, bus.Subscribe(recorder))
+	require.NoError(t, bus.Subscribe(monitor))
+
+	r := chi.NewRouter()
+	NewHandler(assets, transactions, asset.NewMemoryPortfolioRepository(), nil, WithEventBus(bus)).RegisterRoutes(r)
+	NewBudgetHandler(service, monitor).RegisterRoutes(r)
+
+	var created BudgetResponse
+	t.Run("예산 생성", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/budgets", "user-1", CreateBudgetRequest{
+			Category: "식비", Period: string(budget.Monthly), Amount: 100000, Currency: "KRW",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+		assert.Equal(t, "식비", created.Category)
+		assert.Equal(t, 1, created.Start.Day())
+	})
+
+	t.Run("잘못된 요청", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/budgets", "", CreateBudgetRequest{Category: "식비"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doAs(t, r, http.MethodPost, "/budgets", "user-1", CreateBudgetRequest{
+			Category: "식비", Period: "DAILY", Amount: 100000, Currency: "KRW",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doAs(t, r, http.MethodGet, "/budgets/"+created.ID, "user-2", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("거래 기록 시 기준 알림", func(t *testing.T) {
+		for _, amount := range []float64{50000, 35000} {
+			w := doAs(t, r, http.MethodPost, "/transactions", "user-1", CreateTransactionRequest{
+				AssetID: account.ID, Type: string(asset.Expense), Amount: amount, Currency: "KRW", Category: "식비",
+			})
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		}
+		require.Len(t, recorder.alerts, 1)
+		assert.Equal(t, budget.LevelWarning, recorder.alerts[0].Level)
+		assert.Equal(t, created.ID, recorder.alerts[0].Metadata["budgetID"])
+
+		w := doAs(t, r, http.MethodPost, "/budgets/evaluate", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var alerts []BudgetAlertResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&alerts))
+		assert.Empty(t, alerts)
+	})
+
+	t.Run("예산 대비 지출 조회", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/budgets/analysis", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var statuses []BudgetStatusResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&statuses))
+		require.Len(t, statuses, 1)
+		assert.Equal(t, 85000.0, statuses[0].Spent)
+		assert.Equal(t, 15000.0, statuses[0].Remaining)
+		assert.Equal(t, 2, statuses[0].Transactions)
+
+		w = doAs(t, r, http.MethodGet, "/budgets/analysis?at=yesterday", "user-1", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("예산 수정과 삭제", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPut, "/budgets/"+created.ID, "user-1", UpdateBudgetRequest{Amount: 80000, Rollover: true})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doAs(t, r, http.MethodGet, "/budgets/"+created.ID, "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var status BudgetStatusResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&status))
+		assert.True(t, status.Exceeded)
+		assert.True(t, status.Budget.Rollover)
+
+		w = doAs(t, r, http.MethodDelete, "/budgets/"+created.ID, "user-1", nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w = doAs(t, r, http.MethodGet, "/budgets", "user-1", nil)
+		var budgets []BudgetResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&budgets))
+		assert.Empty(t, budgets)
+	})
+}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/category"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// CreateCategoryRequest 분류 생성 요청
+type CreateCategoryRequest struct {
+	Name     string `json:"name"`
+	ParentID string `json:"parentId,omitempty"`
+}
+
+// CategoryResponse 분류 응답
+type CategoryResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ParentID  string    `json:"parentId,omitempty"`
+	Path      []string  `json:"path"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RuleRequest 분류 규칙 생성/수정 요청
+// Enabled를 생략하면 활성 규칙이 되고, 제안된 규칙을 채택할 때는 Source에 LEARNED를 지정합니다.
+type RuleRequest struct {
+	Name       string              `json:"name"`
+	CategoryID string              `json:"categoryId"`
+	Priority   int                 `json:"priority"`
+	Conditions category.Conditions `json:"conditions"`
+	Enabled    *bool               `json:"enabled,omitempty"`
+	Source     string              `json:"source,omitempty"`
+}
+
+// RuleResponse 분류 규칙 응답
+type RuleResponse struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	CategoryID string              `json:"categoryId"`
+	Priority   int                 `json:"priority"`
+	Conditions category.Conditions `json:"conditions"`
+	Enabled    bool                `json:"enabled"`
+	Source     string              `json:"source"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+}
+
+// RecategorizeRequest 일괄 재분류 요청
+// Overwrite가 false이면 분류 체계에 없는 분류를 가진 거래만 바꿉니다.
+type RecategorizeRequest struct {
+	AssetID   string `json:"assetId"`
+	Overwrite bool   `json:"overwrite"`
+	DryRun    bool   `json:"dryRun"`
+}
+
+// CategoryChangeResponse 재분류로 바뀐 거래 응답
+type CategoryChangeResponse struct {
+	TransactionID string `json:"transactionId"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	RuleID        string `json:"ruleId"`
+}
+
+// RecategorizeResponse 일괄 재분류 응답
+type RecategorizeResponse struct {
+	DryRun  bool                     `json:"dryRun"`
+	Changes []CategoryChangeResponse `json:"changes"`
+}
+
+// CategoryHandler 거래 분류 체계와 분류 규칙 API 핸들러입니다.
+// 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리합니다.
+type CategoryHandler struct {
+	engine          *category.Engine
+	assetRepo       asset.Repository
+	transactionRepo asset.TransactionRepository
+}
+
+// NewCategoryHandler 새로운 분류 API 핸들러를 생성합니다.
+func NewCategoryHandler(engine *category.Engine, assetRepo asset.Repository, transactionRepo asset.TransactionRepository) *CategoryHandler {
+	return &CategoryHandler{engine: engine, assetRepo: assetRepo, transactionRepo: transactionRepo}
+}
+
+// RegisterRoutes 라우터에 분류 API를 등록합니다.
+func (h *CategoryHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/categories", func(r chi.Router) {
+		r.Get("/", h.ListCategories)
+		r.Post("/", h.CreateCategory)
+		r.Delete("/{id}", h.DeleteCategory)
+		r.Post("/recategorize", h.Recategorize)
+
+		r.Route("/rules", func(r chi.Router) {
+			r.Get("/", h.ListRules)
+			r.Post("/", h.CreateRule)
+			r.Get("/suggestions", h.SuggestRules)
+			r.Put("/{id}", h.UpdateRule)
+			r.Delete("/{id}", h.DeleteRule)
+		})
+	})
+}
+
+// ListCategories 사용자의 분류 체계를 조회합니다.
+func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	categories, err := h.engine.Categories(r.Context(), userID)
+	if err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	taxonomy := category.NewTaxonomy(categories)
+	response := make([]CategoryResponse, len(categories))
+	for i, c := range categories {
+		response[i] = newCategoryResponse(taxonomy, c)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// CreateCategory 분류를 생성합니다. parentId를 지정하면 하위 분류가 됩니다.
+func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	c, err := h.engine.CreateCategory(r.Context(), userID, req.Name, req.ParentID)
+	if err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	taxonomy, err := h.engine.Taxonomy(r.Context(), userID)
+	if err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, newCategoryResponse(taxonomy, c))
+}
+
+// DeleteCategory 분류를 삭제합니다.
+func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	if err := h.engine.DeleteCategory(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListRules 사용자의 분류 규칙을 평가 순서로 조회합니다.
+func (h *CategoryHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	rules, err := h.engine.Rules(r.Context(), userID)
+	if err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newRuleResponses(rules))
+}
+
+// CreateRule 분류 규칙을 생성합니다.
+func (h *CategoryHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req RuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	rule, err := category.NewRule(userID, req.Name, req.CategoryID, req.Priority, req.Conditions)
+	if err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	if !applyRuleRequest(w, rule, req) {
+		return
+	}
+	if err := h.engine.SaveRule(r.Context(), rule); err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, newRuleResponse(rule))
+}
+
+// UpdateRule 분류 규칙을 수정합니다.
+func (h *CategoryHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req RuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	rule, err := h.engine.Rule(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	rule.Name = req.Name
+	rule.CategoryID = req.CategoryID
+	rule.Priority = req.Priority
+	rule.Conditions = req.Conditions
+	if !applyRuleRequest(w, rule, req) {
+		return
+	}
+	if err := h.engine.SaveRule(r.Context(), rule); err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newRuleResponse(rule))
+}
+
+// DeleteRule 분류 규칙을 삭제합니다.
+func (h *CategoryHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	if err := h.engine.DeleteRule(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SuggestRules 분류 수정 내역에서 제안하는 규칙을 조회합니다. minSupport로 필요한 최소 수정 건수를 바꿀 수 있습니다.
+func (h *CategoryHandler) SuggestRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	minSupport := category.DefaultMinSupport
+	if value := r.URL.Query().Get("minSupport"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "minSupport는 1 이상의 정수여야 합니다")
+			return
+		}
+		minSupport = n
+	}
+
+	rules, err := h.engine.SuggestRules(r.Context(), userID, minSupport)
+	if err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newRuleResponses(rules))
+}
+
+// Recategorize 자산의 거래에 분류 규칙을 다시 적용합니다.
+func (h *CategoryHandler) Recategorize(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req RecategorizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AssetID == "" {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "자산 ID가 필요합니다")
+		return
+	}
+
+	target, err := h.assetRepo.FindByID(r.Context(), req.AssetID)
+	if err != nil || target.UserID != userID {
+		respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
+		return
+	}
+	transactions, err := h.transactionRepo.FindByAssetID(r.Context(), req.AssetID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "거래 내역 조회 실패")
+		return
+	}
+
+	changes, err := h.engine.Recategorize(r.Context(), userID, transactions, req.Overwrite, req.DryRun)
+	if err != nil {
+		respondCategoryError(w, err)
+		return
+	}
+	response := RecategorizeResponse{DryRun: req.DryRun, Changes: make([]CategoryChangeResponse, len(changes))}
+	for i, c := range changes {
+		response.Changes[i] = CategoryChangeResponse{TransactionID: c.TransactionID, From: c.From, To: c.To, RuleID: c.RuleID}
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// requireUser X-User-ID 헤더의 사용자를 반환합니다. 헤더가 없으면 400으로 응답합니다.
+func requireUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := r.Header.Get(ActorHeader)
+	if userID == "" {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, ActorHeader+" 헤더가 필요합니다")
+		return "", false
+	}
+	return userID, true
+}
+
+// applyRuleRequest 요청의 활성 여부와 출처를 규칙에 반영합니다.
+func applyRuleRequest(w http.ResponseWriter, rule *category.Rule, req RuleRequest) bool {
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	switch category.RuleSource(req.Source) {
+	case "":
+	case category.SourceUser, category.SourceLearned:
+		rule.Source = category.RuleSource(req.Source)
+	default:
+		respondError(w, http.StatusBadRequest, ErrValidation, "source는 USER 또는 LEARNED여야 합니다")
+		return false
+	}
+	return true
+}
+
+func respondCategoryError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code() {
+		case domain.ErrCodeNotFound:
+			respondError(w, http.StatusNotFound, ErrNotFound, domainErr.Error())
+			return
+		case domain.ErrCodeInvalidArgument:
+			respondError(w, http.StatusBadRequest, ErrValidation, domainErr.Error())
+			return
+		case domain.ErrCodeAlreadyExists, domain.ErrCodeInvalidOperation:
+			respondError(w, http.StatusConflict, ErrValidation, domainErr.Error())
+			return
+		}
+	}
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "분류 처리 중 오류가 발생했습니다")
+}
+
+func newCategoryResponse(taxonomy *category.Taxonomy, c *category.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:        c.ID,
+		Name:      c.Name,
+		ParentID:  c.ParentID,
+		Path:      taxonomy.Path(c.ID),
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func newRuleResponse(rule *category.Rule) RuleResponse {
+	return RuleResponse{
+		ID:         rule.ID,
+		Name:       rule.Name,
+		CategoryID: rule.CategoryID,
+		Priority:   rule.Priority,
+		Conditions: rule.Conditions,
+		Enabled:    rule.Enabled,
+		Source:     string(rule.Source),
+		CreatedAt:  rule.CreatedAt,
+		UpdatedAt:  rule.UpdatedAt,
+	}
+}
+
+func newRuleResponses(rules []*category.Rule) []RuleResponse {
+	response := make([]RuleResponse, len(rules))
+	for i, rule := range rules {
+		response[i] = newRuleResponse(rule)
+	}
+	return response
+}
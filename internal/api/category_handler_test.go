@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/category"
+)
+
+type categoryTestServer struct {
+	router       *chi.Mux
+	transactions asset.TransactionRepository
+	assetID      string
+}
+
+func newCategoryTestServer(t *testing.T) *categoryTestServer {
+	t.Helper()
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	account, err := asset.NewAsset("user-1", asset.Cash, "입출금", 100000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), account))
+
+	engine := category.NewEngine(category.NewMemoryRepository(), transactions)
+	r := chi.NewRouter()
+	NewHandler(assets, transactions, asset.NewMemoryPortfolioRepository(), nil, WithCategorizer(engine)).RegisterRoutes(r)
+	NewCategoryHandler(engine, assets, transactions).RegisterRoutes(r)
+	return &categoryTestServer{router: r, transactions: transactions, assetID: account.ID}
+}
+
+func (s *categoryTestServer) do(t *testing.T, method, path, user string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	if user != "" {
+		req.Header.Set(ActorHeader, user)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *categoryTestServer) createCategory(t *testing.T, name, parentID string) CategoryResponse {
+	t.Helper()
+	w := s.do(t, http.MethodPost, "/categories", "user-1", CreateCategoryRequest{Name: name, ParentID: parentID})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var response CategoryResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	return response
+}
+
+func (s *categoryTestServer) createTransaction(t *testing.T, description string) TransactionResponse {
+	t.Helper()
+	w := s.do(t, http.MethodPost, "/transactions", "", CreateTransactionRequest{
+		AssetID: s.assetID, Type: string(asset.Expense), Amount: 5000, Currency: "KRW", Description: description,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var response TransactionResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	return response
+}
+
+func TestCategoryTaxonomy(t *testing.T) {
+	s := newCategoryTestServer(t)
+	food := s.createCategory(t, "식비", "")
+	cafe := s.createCategory(t, "카페", food.ID)
+	assert.Equal(t, []string{"식비", "카페"}, cafe.Path)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		user   string
+		body   any
+		status int
+	}{
+		{name: "사용자 헤더 없음", method: http.MethodGet, path: "/categories", status: http.StatusBadRequest},
+		{name: "중복 이름", method: http.MethodPost, path: "/categories", user: "user-1", body: CreateCategoryRequest{Name: "카페"}, status: http.StatusConflict},
+		{name: "없는 상위 분류", method: http.MethodPost, path: "/categories", user: "user-1", body: CreateCategoryRequest{Name: "기타", ParentID: "missing"}, status: http.StatusBadRequest},
+		{name: "하위 분류가 있는 분류 삭제", method: http.MethodDelete, path: "/categories/" + food.ID, user: "user-1", status: http.StatusConflict},
+		{name: "다른 사용자의 분류 삭제", method: http.MethodDelete, path: "/categories/" + cafe.ID, user: "user-2", status: http.StatusNotFound},
+		{name: "하위 분류 삭제", method: http.MethodDelete, path: "/categories/" + cafe.ID, user: "user-1", status: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := s.do(t, tt.method, tt.path, tt.user, tt.body)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	w := s.do(t, http.MethodGet, "/categories", "user-1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response []CategoryResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Len(t, response, 1)
+	assert.Equal(t, "식비", response[0].Name)
+}
+
+func TestCategoryRules(t *testing.T) {
+	s := newCategoryTestServer(t)
+	cafe := s.createCategory(t, "카페", "")
+	transport := s.createCategory(t, "교통", "")
+
+	t.Run("잘못된 규칙", func(t *testing.T) {
+		w := s.do(t, http.MethodPost, "/categories/rules", "user-1", RuleRequest{CategoryID: cafe.ID, Conditions: category.Conditions{Description: "("}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = s.do(t, http.MethodPost, "/categories/rules", "user-1", RuleRequest{CategoryID: "missing", Conditions: category.Conditions{Counterparty: "커피"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	var rule RuleResponse
+	t.Run("규칙 생성 후 거래 생성 시 분류 적용", func(t *testing.T) {
+		w := s.do(t, http.MethodPost, "/categories/rules", "user-1", RuleRequest{
+			Name: "커피", CategoryID: cafe.ID, Priority: 10, Conditions: category.Conditions{Description: "(?i)coffee|커피"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&rule))
+		assert.Equal(t, "USER", rule.Source)
+
+		assert.Equal(t, "카페", s.createTransaction(t, "Blue Bottle Coffee").Category)
+		assert.Empty(t, s.createTransaction(t, "편의점").Category)
+	})
+
+	t.Run("규칙 수정과 일괄 재분류", func(t *testing.T) {
+		w := s.do(t, http.MethodPut, "/categories/rules/"+rule.ID, "user-1", RuleRequest{
+			Name: "편의점", CategoryID: transport.ID, Conditions: category.Conditions{Counterparty: "편의점"},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = s.do(t, http.MethodPost, "/categories/recategorize", "user-1", RecategorizeRequest{AssetID: s.assetID, DryRun: true})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var preview RecategorizeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&preview))
+		require.Len(t, preview.Changes, 1)
+		assert.Equal(t, "교통", preview.Changes[0].To)
+
+		w = s.do(t, http.MethodPost, "/categories/recategorize", "user-2", RecategorizeRequest{AssetID: s.assetID})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("규칙 삭제", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, s.do(t, http.MethodDelete, "/categories/rules/"+rule.ID, "user-2", nil).Code)
+		assert.Equal(t, http.StatusNoContent, s.do(t, http.MethodDelete, "/categories/rules/"+rule.ID, "user-1", nil).Code)
+		w := s.do(t, http.MethodGet, "/categories/rules", "user-1", nil)
+		assert.JSONEq(t, "[]", w.Body.String())
+	})
+}
+
+func TestCategoryCorrectionsAndSuggestions(t *testing.T) {
+	s := newCategoryTestServer(t)
+	cafe := s.createCategory(t, "카페", "")
+
+	for _, description := range []string{"스타벅스 강남", "스타벅스 역삼"} {
+		tx := s.createTransaction(t, description)
+		w := s.do(t, http.MethodPut, "/transactions/"+tx.ID+"/category", "", UpdateTransactionCategoryRequest{Category: "카페"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	t.Run("없는 분류로 수정", func(t *testing.T) {
+		tx := s.createTransaction(t, "편의점")
+		w := s.do(t, http.MethodPut, "/transactions/"+tx.ID+"/category", "", UpdateTransactionCategoryRequest{Category: "없음"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("수정 내역에서 규칙 제안", func(t *testing.T) {
+		w := s.do(t, http.MethodGet, "/categories/rules/suggestions", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var suggestions []RuleResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&suggestions))
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, cafe.ID, suggestions[0].CategoryID)
+		assert.Equal(t, "스타벅스", suggestions[0].Conditions.Counterparty)
+		assert.Equal(t, "LEARNED", suggestions[0].Source)
+
+		w = s.do(t, http.MethodGet, "/categories/rules/suggestions?minSupport=0", "user-1", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/drift"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// 드리프트 기록 기본 조회 기간(일)
+const defaultDriftDays = 30
+
+// DriftThresholdsRequest 드리프트 한도 설정 요청
+// absolute는 목표 비중과의 차이(%p), relative는 목표 비중 대비 차이(%) 한도이며 0이면 보지 않습니다.
+// hysteresis는 알림 해제 비율로, 차이가 한도 × hysteresis 이하로 줄어야 알림이 해제됩니다.
+type DriftThresholdsRequest struct {
+	Absolute   float64 `json:"absolute"`
+	Relative   float64 `json:"relative"`
+	Hysteresis float64 `json:"hysteresis"`
+}
+
+// DriftThresholdsResponse 드리프트 한도 응답
+type DriftThresholdsResponse struct {
+	Absolute   float64 `json:"absolute"`
+	Relative   float64 `json:"relative"`
+	Hysteresis float64 `json:"hysteresis"`
+}
+
+// DriftPositionResponse 자산별 비중 차이 응답
+type DriftPositionResponse struct {
+	AssetID  string  `json:"assetId"`
+	Name     string  `json:"name"`
+	Target   float64 `json:"target"`
+	Weight   float64 `json:"weight"`
+	Absolute float64 `json:"absolute"`
+	Relative float64 `json:"relative"`
+	Alerting bool    `json:"alerting"`
+}
+
+// DriftSampleResponse 하루의 드리프트 기록 응답
+type DriftSampleResponse struct {
+	Date        string                  `json:"date"`
+	At          time.Time               `json:"at"`
+	MaxAbsolute float64                 `json:"maxAbsolute"`
+	MaxRelative float64                 `json:"maxRelative"`
+	Positions   []DriftPositionResponse `json:"positions"`
+}
+
+// DriftHistoryResponse 드리프트 기록 응답
+type DriftHistoryResponse struct {
+	From    string                `json:"from"`
+	To      string                `json:"to"`
+	Samples []DriftSampleResponse `json:"samples"`
+}
+
+// DriftAlertResponse 드리프트 알림 응답
+type DriftAlertResponse struct {
+	Level   string `json:"level"`
+	AssetID string `json:"assetId"`
+	Message string `json:"message"`
+}
+
+// DriftEvaluationResponse 드리프트 평가 응답
+type DriftEvaluationResponse struct {
+	Sample DriftSampleResponse  `json:"sample"`
+	Alerts []DriftAlertResponse `json:"alerts"`
+}
+
+// DriftHandler 포트폴리오 드리프트 API 핸들러입니다.
+// 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리하며, 날짜는 YYYY-MM-DD(UTC) 형식입니다.
+type DriftHandler struct {
+	monitor *drift.Monitor
+	now     func() time.Time
+}
+
+// NewDriftHandler 새로운 드리프트 API 핸들러를 생성합니다.
+func NewDriftHandler(monitor *drift.Monitor) *DriftHandler {
+	return &DriftHandler{monitor: monitor, now: time.Now}
+}
+
+// RegisterRoutes 라우터에 드리프트 API를 등록합니다.
+func (h *DriftHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/drift", func(r chi.Router) {
+		r.Get("/", h.History)
+		r.Post("/evaluate", h.Evaluate)
+		r.Get("/thresholds", h.GetThresholds)
+		r.Put("/thresholds", h.SetThresholds)
+	})
+}
+
+// History from부터 to까지(기본값 최근 30일) 날마다의 드리프트 기록을 조회합니다.
+func (h *DriftHandler) History(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	now := h.now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -(defaultDriftDays - 1))
+	query := r.URL.Query()
+	if value := query.Get("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "to는 YYYY-MM-DD 형식이어야 합니다")
+			return
+		}
+		to = parsed
+		from = to.AddDate(0, 0, -(defaultDriftDays - 1))
+	}
+	if value := query.Get("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "from은 YYYY-MM-DD 형식이어야 합니다")
+			return
+		}
+		from = parsed
+	}
+
+	samples, err := h.monitor.History(r.Context(), userID, from, to)
+	if err != nil {
+		respondDriftError(w, err)
+		return
+	}
+	response := DriftHistoryResponse{
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		Samples: make([]DriftSampleResponse, len(samples)),
+	}
+	for i, sample := range samples {
+		response.Samples[i] = newDriftSampleResponse(sample)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Evaluate 지금의 비중 차이를 계산해 기록하고 보낸 알림을 반환합니다.
+func (h *DriftHandler) Evaluate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	sample, alerts, err := h.monitor.Evaluate(r.Context(), userID)
+	if err != nil {
+		respondDriftError(w, err)
+		return
+	}
+	response := DriftEvaluationResponse{
+		Sample: newDriftSampleResponse(sample),
+		Alerts: make([]DriftAlertResponse, len(alerts)),
+	}
+	for i, alert := range alerts {
+		response.Alerts[i] = DriftAlertResponse{
+			Level:   string(alert.Level),
+			AssetID: alert.Metadata["assetID"],
+			Message: alert.Message,
+		}
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetThresholds 포트폴리오의 드리프트 한도를 조회합니다.
+func (h *DriftHandler) GetThresholds(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	thresholds, err := h.monitor.Thresholds(r.Context(), userID)
+	if err != nil {
+		respondDriftError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newDriftThresholdsResponse(thresholds))
+}
+
+// SetThresholds 포트폴리오의 드리프트 한도를 설정합니다.
+func (h *DriftHandler) SetThresholds(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req DriftThresholdsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+	thresholds, err := h.monitor.SetThresholds(r.Context(), userID, drift.Thresholds{
+		Absolute:   req.Absolute,
+		Relative:   req.Relative,
+		Hysteresis: req.Hysteresis,
+	})
+	if err != nil {
+		respondDriftError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newDriftThresholdsResponse(thresholds))
+}
+
+func respondDriftError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code() {
+		case domain.ErrCodeInvalidArgument:
+			respondError(w, http.StatusBadRequest, ErrValidation, domainErr.Error())
+			return
+		case domain.ErrCodeNotFound:
+			respondError(w, http.StatusNotFound, ErrNotFound, "포트폴리오를 찾을 수 없습니다")
+			return
+		}
+	}
+	log.Printf("드리프트 처리 실패: %v", err)
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "드리프트 처리 중 오류가 발생했습니다")
+}
+
+func newDriftThresholdsResponse(t drift.Thresholds) DriftThresholdsResponse {
+	return DriftThresholdsResponse{Absolute: t.Absolute, Relative: t.Relative, Hysteresis: t.Hysteresis}
+}
+
+func newDriftSampleResponse(sample *drift.Sample) DriftSampleResponse {
+	response := DriftSampleResponse{
+		Date:        sample.Date.Format("2006-01-02"),
+		At:          sample.At,
+		MaxAbsolute: sample.MaxAbsolute,
+		MaxRelative: sample.MaxRelative,
+		Positions:   make([]DriftPositionResponse, len(sample.Positions)),
+	}
+	for i, p := range sample.Positions {
+		response.Positions[i] = DriftPositionResponse{
+			AssetID:  p.AssetID,
+			Name:     p.Name,
+			Target:   p.Target,
+			Weight:   p.Weight,
+			Absolute: p.Absolute,
+			Relative: p.Relative,
+			Alerting: p.Alerting,
+		}
+	}
+	return response
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/drift"
+	"github.com/aske/go_fi_chart/internal/domain/rebalance"
+)
+
+func TestDrift(t *testing.T) {
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	assets := asset.NewMemoryAssetRepository()
+	stocks, err := asset.NewAsset("user-1", asset.Stock, "주식", 70000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), stocks))
+	bonds, err := asset.NewAsset("user-1", asset.Bond, "채권", 30000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), bonds))
+
+	portfolios := asset.NewMemoryPortfolioRepository()
+	rebalancer := rebalance.NewRebalancer(portfolios, assets, asset.NewMemoryTransactionRepository(), nil)
+	monitor := drift.NewMonitor(drift.NewMemoryRepository(), portfolios, rebalancer, nil, drift.WithClock(func() time.Time { return now }))
+	r := chi.NewRouter()
+	handler := NewDriftHandler(monitor)
+	handler.now = func() time.Time { return now }
+	handler.RegisterRoutes(r)
+
+	t.Run("포트폴리오 없음", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/drift/thresholds", "user-1", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	_, err = rebalancer.SetTargets(context.Background(), "user-1", []asset.PortfolioAsset{
+		{AssetID: stocks.ID, Weight: 50}, {AssetID: bonds.ID, Weight: 50},
+	})
+	require.NoError(t, err)
+
+	t.Run("한도 설정", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/drift/thresholds", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var thresholds DriftThresholdsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&thresholds))
+		assert.Equal(t, drift.DefaultThresholds.Absolute, thresholds.Absolute)
+
+		w = doAs(t, r, http.MethodPut, "/drift/thresholds", "user-1", DriftThresholdsRequest{Absolute: 10, Hysteresis: 0.5})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doAs(t, r, http.MethodPut, "/drift/thresholds", "user-1", DriftThresholdsRequest{Absolute: 10})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("평가와 기록 조회", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/drift/evaluate", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var evaluation DriftEvaluationResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&evaluation))
+		assert.InDelta(t, 20, evaluation.Sample.MaxAbsolute, 1e-9)
+		require.Len(t, evaluation.Alerts, 2)
+		assert.Equal(t, "WARNING", evaluation.Alerts[0].Level)
+		assert.Equal(t, stocks.ID, evaluation.Alerts[0].AssetID)
+
+		w = doAs(t, r, http.MethodGet, "/drift", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var history DriftHistoryResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&history))
+		assert.Equal(t, "2024-05-05", history.From)
+		require.Len(t, history.Samples, 1)
+		assert.True(t, history.Samples[0].Positions[0].Alerting)
+
+		w = doAs(t, r, http.MethodGet, "/drift?from=2024-06", "user-1", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/goal"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// CreateGoalRequest 목표 생성 요청
+// type은 SAVING, INVESTING 중 하나이며 expectedReturn은 연 기대 수익률(%)입니다.
+type CreateGoalRequest struct {
+	AssetID        string    `json:"assetId"`
+	Type           string    `json:"type"`
+	Target         float64   `json:"target"`
+	Deadline       time.Time `json:"deadline"`
+	ExpectedReturn float64   `json:"expectedReturn"`
+}
+
+// GoalProjectionResponse 목표 달성 전망 응답
+type GoalProjectionResponse struct {
+	AssetID             string     `json:"assetId"`
+	AssetName           string     `json:"assetName"`
+	GoalID              string     `json:"goalId"`
+	Type                string     `json:"type"`
+	Currency            string     `json:"currency"`
+	Current             float64    `json:"current"`
+	Target              float64    `json:"target"`
+	Progress            float64    `json:"progress"`
+	Deadline            time.Time  `json:"deadline"`
+	MonthsLeft          int        `json:"monthsLeft"`
+	RequiredMonthly     float64    `json:"requiredMonthly"`
+	MonthlyContribution float64    `json:"monthlyContribution"`
+	ProjectedDate       *time.Time `json:"projectedDate"`
+	Status              string     `json:"status"`
+}
+
+// GoalHandler 저축·투자 목표 API 핸들러입니다.
+// 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리합니다.
+type GoalHandler struct {
+	projector *goal.Projector
+	assetRepo asset.Repository
+}
+
+// NewGoalHandler 새로운 목표 API 핸들러를 생성합니다.
+func NewGoalHandler(projector *goal.Projector, assetRepo asset.Repository) *GoalHandler {
+	return &GoalHandler{projector: projector, assetRepo: assetRepo}
+}
+
+// RegisterRoutes 라우터에 목표 API를 등록합니다.
+func (h *GoalHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/goals", func(r chi.Router) {
+		r.Get("/", h.ListGoals)
+		r.Post("/", h.CreateGoal)
+	})
+}
+
+// ListGoals 사용자의 저축·투자 목표와 달성 전망을 조회합니다.
+func (h *GoalHandler) ListGoals(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	projections, err := h.projector.Project(r.Context(), userID)
+	if err != nil {
+		log.Printf("목표 전망 계산 실패: %v", err)
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "목표 전망 계산 실패")
+		return
+	}
+	response := make([]GoalProjectionResponse, len(projections))
+	for i, projection := range projections {
+		response[i] = newGoalProjectionResponse(projection)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// CreateGoal 자산에 저축·투자 목표를 추가하고 달성 전망을 계산합니다.
+// 빚 청산 목표는 /payoff/goals로 등록합니다.
+func (h *GoalHandler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req CreateGoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+	goalType := asset.GoalType(req.Type)
+	switch {
+	case goalType != asset.GoalTypeSaving && goalType != asset.GoalTypeInvesting:
+		respondError(w, http.StatusBadRequest, ErrValidation, "목표 유형은 SAVING, INVESTING 중 하나여야 합니다")
+		return
+	case req.Target <= 0:
+		respondError(w, http.StatusBadRequest, ErrValidation, "목표 금액은 0보다 커야 합니다")
+		return
+	case req.Deadline.IsZero():
+		respondError(w, http.StatusBadRequest, ErrValidation, "목표 기한이 필요합니다")
+		return
+	case req.ExpectedReturn < 0:
+		respondError(w, http.StatusBadRequest, ErrValidation, "기대 수익률은 음수일 수 없습니다")
+		return
+	}
+
+	owner, err := h.assetRepo.FindByID(r.Context(), req.AssetID)
+	if err != nil || owner.UserID != userID {
+		respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
+		return
+	}
+	created := owner.AddGoal(goalType, asset.Money{Amount: req.Target, Currency: owner.Amount.Currency}, req.Deadline)
+	created.ExpectedReturn = req.ExpectedReturn
+	if err := h.assetRepo.Update(r.Context(), owner); err != nil {
+		respondUpdateError(w, err, "목표 저장 실패")
+		return
+	}
+	if _, err := h.projector.Refresh(r.Context(), userID); err != nil {
+		log.Printf("목표 상태 갱신 실패: %v", err)
+	}
+
+	projections, err := h.projector.Project(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "목표 전망 계산 실패")
+		return
+	}
+	for _, projection := range projections {
+		if projection.GoalID == created.ID {
+			respondJSON(w, http.StatusCreated, newGoalProjectionResponse(projection))
+			return
+		}
+	}
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "목표 전망 계산 실패")
+}
+
+func newGoalProjectionResponse(p goal.Projection) GoalProjectionResponse {
+	return GoalProjectionResponse{
+		AssetID:             p.AssetID,
+		AssetName:           p.AssetName,
+		GoalID:              p.GoalID,
+		Type:                string(p.Type),
+		Currency:            p.Target.Currency,
+		Current:             p.Current.Amount,
+		Target:              p.Target.Amount,
+		Progress:            p.Progress,
+		Deadline:            p.Deadline,
+		MonthsLeft:          p.MonthsLeft,
+		RequiredMonthly:     p.RequiredMonthly.Amount,
+		MonthlyContribution: p.MonthlyContribution.Amount,
+		ProjectedDate:       p.ProjectedDate,
+		Status:              string(p.Status),
+	}
+}
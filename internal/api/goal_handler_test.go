@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/goal"
+)
+
+func TestGoals(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	assets := asset.NewMemoryAssetRepository()
+	stock, err := asset.NewAsset("user-1", asset.Stock, "ETF", 0, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), stock))
+
+	projector := goal.NewProjector(assets, asset.NewMemoryTransactionRepository(), nil, goal.WithClock(func() time.Time { return now }))
+	r := chi.NewRouter()
+	NewGoalHandler(projector, assets).RegisterRoutes(r)
+
+	t.Run("목표 등록", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/goals", "user-1", CreateGoalRequest{
+			AssetID: stock.ID, Type: "INVESTING", Target: 1200000, Deadline: now.AddDate(1, 0, 0), ExpectedReturn: 12,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var projection GoalProjectionResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&projection))
+		assert.Equal(t, 12, projection.MonthsLeft)
+		assert.Equal(t, 94618.55, projection.RequiredMonthly)
+		assert.Equal(t, "BEHIND", projection.Status)
+		assert.Nil(t, projection.ProjectedDate)
+
+		saved, err := assets.FindByID(context.Background(), stock.ID)
+		require.NoError(t, err)
+		require.Len(t, saved.Goals, 1)
+		assert.Equal(t, asset.GoalStatusBehind, saved.Goals[0].Status)
+		assert.Equal(t, 12.0, saved.Goals[0].ExpectedReturn)
+	})
+
+	t.Run("목표 목록", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/goals", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var projections []GoalProjectionResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&projections))
+		require.Len(t, projections, 1)
+		assert.Equal(t, "ETF", projections[0].AssetName)
+	})
+
+	t.Run("잘못된 요청", func(t *testing.T) {
+		for name, req := range map[string]CreateGoalRequest{
+			"빚 청산 목표":  {AssetID: stock.ID, Type: "DEBT_FREE", Target: 100, Deadline: now},
+			"목표 금액 없음": {AssetID: stock.ID, Type: "SAVING", Deadline: now},
+			"기한 없음":    {AssetID: stock.ID, Type: "SAVING", Target: 100},
+		} {
+			w := doAs(t, r, http.MethodPost, "/goals", "user-1", req)
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+		}
+		w := doAs(t, r, http.MethodPost, "/goals", "user-2", CreateGoalRequest{AssetID: stock.ID, Type: "SAVING", Target: 100, Deadline: now})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
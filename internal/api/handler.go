@@ -1,12 +1,23 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aske/go_fi_chart/internal/domain"
 	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/category"
+	"github.com/aske/go_fi_chart/internal/domain/event"
 	"github.com/aske/go_fi_chart/internal/domain/gamification"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+	"github.com/aske/go_fi_chart/internal/domain/query"
 	chi "github.com/go-chi/chi/v5"
 )
 
@@ -50,6 +61,10 @@ const (
 	ErrInternalServer = "INTERNAL_SERVER_ERROR"
 	ErrValidation     = "VALIDATION_ERROR"
 	ErrNotImplemented = "NOT_IMPLEMENTED"
+	// ErrPreconditionFailed If-Match로 보낸 버전이 현재 버전과 다릅니다
+	ErrPreconditionFailed = "PRECONDITION_FAILED"
+	// ErrConflict 요청을 처리하는 동안 다른 요청이 같은 엔티티를 먼저 변경했습니다
+	ErrConflict = "CONFLICT"
 )
 
 // Handler API 핸들러입니다.
@@ -58,6 +73,34 @@ type Handler struct {
 	transactionRepo  asset.TransactionRepository
 	portfolioRepo    asset.PortfolioRepository
 	gamificationRepo gamification.Repository
+	categorizer      *category.Engine
+	ledger           *ledger.Ledger
+	bus              event.Bus
+}
+
+// HandlerOption API 핸들러 설정 함수입니다.
+type HandlerOption func(*Handler)
+
+// WithCategorizer 분류 없이 생성하는 거래에 자산 소유자의 분류 규칙을 적용하고,
+// 거래 분류 수정 API를 활성화합니다.
+func WithCategorizer(engine *category.Engine) HandlerOption {
+	return func(h *Handler) {
+		h.categorizer = engine
+	}
+}
+
+// WithLedger 거래를 자산에 반영할 때 원장에 복식부기 분개로 함께 전기합니다.
+func WithLedger(l *ledger.Ledger) HandlerOption {
+	return func(h *Handler) {
+		h.ledger = l
+	}
+}
+
+// WithEventBus 자산을 생성, 변경, 삭제하거나 거래를 기록한 뒤 이벤트를 발행합니다.
+func WithEventBus(bus event.Bus) HandlerOption {
+	return func(h *Handler) {
+		h.bus = bus
+	}
 }
 
 // NewHandler 새로운 API 핸들러를 생성합니다.
@@ -66,13 +109,18 @@ func NewHandler(
 	transactionRepo asset.TransactionRepository,
 	portfolioRepo asset.PortfolioRepository,
 	gamificationRepo gamification.Repository,
+	opts ...HandlerOption,
 ) *Handler {
-	return &Handler{
+	h := &Handler{
 		assetRepo:        assetRepo,
 		transactionRepo:  transactionRepo,
 		portfolioRepo:    portfolioRepo,
 		gamificationRepo: gamificationRepo,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // RegisterRoutes 라우터에 API 핸들러를 등록합니다.
@@ -91,6 +139,7 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Get("/", h.ListTransactions)
 		r.Post("/", h.CreateTransaction)
 		r.Get("/{id}", h.GetTransaction)
+		r.Put("/{id}/category", h.UpdateTransactionCategory)
 	})
 
 	// 포트폴리오 API
@@ -108,6 +157,17 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 	})
 }
 
+// apiError 작업 단위 안에서 발생한 에러를 HTTP 응답으로 전달하기 위한 타입입니다.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
 // 응답 헬퍼 함수
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -126,6 +186,77 @@ func respondError(w http.ResponseWriter, status int, code string, message string
 	})
 }
 
+// etag 엔티티 버전을 강한 ETag 값으로 변환합니다.
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersion 요청의 If-Match 헤더에서 기대 버전을 추출합니다.
+// 헤더가 없거나 "*"이면 ok가 false이며, 버전으로 해석할 수 없는 값이면 에러를 반환합니다.
+func ifMatchVersion(r *http.Request) (version int64, ok bool, err error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, false, nil
+	}
+
+	// 약한 비교자(W/)는 버전 비교에 영향을 주지 않으므로 제거합니다
+	value = strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+	version, err = strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("잘못된 If-Match 헤더입니다: %q", r.Header.Get("If-Match"))
+	}
+	return version, true, nil
+}
+
+// checkIfMatch If-Match 헤더가 있으면 현재 버전과 비교합니다.
+// 일치하지 않으면 412 응답을 쓰고 false를 반환합니다.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, version int64) bool {
+	expected, ok, err := ifMatchVersion(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
+		return false
+	}
+	if ok && expected != version {
+		respondError(w, http.StatusPreconditionFailed, ErrPreconditionFailed, "버전이 일치하지 않습니다. 다시 조회한 뒤 시도하세요")
+		return false
+	}
+	return true
+}
+
+// respondUpdateError 저장 실패를 응답합니다. 버전 충돌이면 409, 그 외에는 message와 함께 500을 응답합니다.
+func respondUpdateError(w http.ResponseWriter, err error, message string) {
+	if asset.IsVersionConflict(err) {
+		respondError(w, http.StatusConflict, ErrConflict, "다른 요청이 먼저 변경했습니다. 다시 시도하세요")
+		return
+	}
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, message)
+}
+
+// filterByQuery 요청의 q 파라미터가 있으면 필터 쿼리로 목록을 거릅니다.
+func filterByQuery[T any](r *http.Request, items []T) ([]T, error) {
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		return items, nil
+	}
+
+	criteria, err := query.Parse(q)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		ok, err := criteria.Match(item)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
 // 요청/응답 구조체
 type UpdateAssetRequest struct {
 	Name     string  `json:"name,omitempty"`
@@ -140,6 +271,7 @@ type UpdateAssetRequest struct {
 // @Accept json
 // @Produce json
 // @Param userId query string true "사용자 ID"
+// @Param q query string false "필터 쿼리 (예: type in (STOCK,BOND) and amount.amount > 1000)"
 // @Success 200 {array} AssetResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -157,6 +289,12 @@ func (h *Handler) ListAssets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	assets, err = filterByQuery(r, assets)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
+		return
+	}
+
 	response := make([]AssetResponse, len(assets))
 	for i, asset := range assets {
 		response[i] = AssetResponse{
@@ -191,6 +329,7 @@ func (h *Handler) CreateAsset(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 생성 실패")
 		return
 	}
+	h.publish(r.Context(), []event.Event{assetEvent(event.TypeAssetCreated, newAsset)})
 
 	response := AssetResponse{
 		ID:        newAsset.ID,
@@ -203,6 +342,7 @@ func (h *Handler) CreateAsset(w http.ResponseWriter, r *http.Request) {
 		UpdatedAt: newAsset.UpdatedAt,
 	}
 
+	w.Header().Set("ETag", etag(newAsset.Version))
 	respondJSON(w, http.StatusCreated, response)
 }
 
@@ -230,6 +370,7 @@ func (h *Handler) GetAsset(w http.ResponseWriter, r *http.Request) {
 		UpdatedAt: asset.UpdatedAt,
 	}
 
+	w.Header().Set("ETag", etag(asset.Version))
 	respondJSON(w, http.StatusOK, response)
 }
 
@@ -246,39 +387,51 @@ func (h *Handler) UpdateAsset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	asset, err := h.assetRepo.FindByID(r.Context(), id)
+	target, err := h.assetRepo.FindByID(r.Context(), id)
 	if err != nil {
 		respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
 		return
 	}
 
+	if !checkIfMatch(w, r, target.Version) {
+		return
+	}
+
 	if req.Name != "" {
-		asset.Name = req.Name
+		target.Name = req.Name
 	}
 	if req.Amount != 0 {
-		asset.Amount.Amount = req.Amount
+		target.Amount.Amount = req.Amount
 	}
 	if req.Currency != "" {
-		asset.Amount.Currency = req.Currency
+		target.Amount.Currency = req.Currency
 	}
-	asset.UpdatedAt = time.Now()
+	target.UpdatedAt = time.Now()
 
-	if err := h.assetRepo.Update(r.Context(), asset); err != nil {
+	if err := h.assetRepo.Update(r.Context(), target); err != nil {
+		if asset.IsVersionConflict(err) {
+			respondError(w, http.StatusPreconditionFailed, ErrPreconditionFailed, "다른 요청이 먼저 자산을 변경했습니다")
+			return
+		}
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 업데이트 실패")
 		return
 	}
+	if req.Amount != 0 || req.Currency != "" {
+		h.publish(r.Context(), []event.Event{assetEvent(event.TypeAssetAmountChanged, target)})
+	}
 
 	response := AssetResponse{
-		ID:        asset.ID,
-		UserID:    asset.UserID,
-		Type:      string(asset.Type),
-		Name:      asset.Name,
-		Amount:    asset.Amount.Amount,
-		Currency:  asset.Amount.Currency,
-		CreatedAt: asset.CreatedAt,
-		UpdatedAt: asset.UpdatedAt,
+		ID:        target.ID,
+		UserID:    target.UserID,
+		Type:      string(target.Type),
+		Name:      target.Name,
+		Amount:    target.Amount.Amount,
+		Currency:  target.Amount.Currency,
+		CreatedAt: target.CreatedAt,
+		UpdatedAt: target.UpdatedAt,
 	}
 
+	w.Header().Set("ETag", etag(target.Version))
 	respondJSON(w, http.StatusOK, response)
 }
 
@@ -290,40 +443,48 @@ func (h *Handler) DeleteAsset(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 자산이 존재하는지 확인
-	_, err := h.assetRepo.FindByID(r.Context(), id)
+	target, err := h.assetRepo.FindByID(r.Context(), id)
 	if err != nil {
 		respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
 		return
 	}
 
+	if !checkIfMatch(w, r, target.Version) {
+		return
+	}
+
 	if err := h.assetRepo.Delete(r.Context(), id); err != nil {
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 삭제 중 오류가 발생했습니다")
 		return
 	}
+	h.publish(r.Context(), []event.Event{assetEvent(event.TypeAssetDeleted, target)})
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
 // 거래 내역 요청/응답 구조체
+// 이체(TRANSFER)는 counterAssetId로 입금 자산을 지정해야 합니다.
 type CreateTransactionRequest struct {
-	AssetID     string  `json:"assetId"`
-	Type        string  `json:"type"`
-	Amount      float64 `json:"amount"`
-	Currency    string  `json:"currency"`
-	Category    string  `json:"category"`
-	Description string  `json:"description"`
+	AssetID        string  `json:"assetId"`
+	CounterAssetID string  `json:"counterAssetId,omitempty"`
+	Type           string  `json:"type"`
+	Amount         float64 `json:"amount"`
+	Currency       string  `json:"currency"`
+	Category       string  `json:"category"`
+	Description    string  `json:"description"`
 }
 
 type TransactionResponse struct {
-	ID          string    `json:"id"`
-	AssetID     string    `json:"assetId"`
-	Type        string    `json:"type"`
-	Amount      float64   `json:"amount"`
-	Currency    string    `json:"currency"`
-	Category    string    `json:"category"`
-	Description string    `json:"description"`
-	Date        time.Time `json:"date"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID             string    `json:"id"`
+	AssetID        string    `json:"assetId"`
+	CounterAssetID string    `json:"counterAssetId,omitempty"`
+	Type           string    `json:"type"`
+	Amount         float64   `json:"amount"`
+	Currency       string    `json:"currency"`
+	Category       string    `json:"category"`
+	Description    string    `json:"description"`
+	Date           time.Time `json:"date"`
+	CreatedAt      time.Time `json:"createdAt"`
 }
 
 func (h *Handler) ListTransactions(w http.ResponseWriter, r *http.Request) {
@@ -339,18 +500,25 @@ func (h *Handler) ListTransactions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	transactions, err = filterByQuery(r, transactions)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
+		return
+	}
+
 	response := make([]TransactionResponse, len(transactions))
 	for i, tx := range transactions {
 		response[i] = TransactionResponse{
-			ID:          tx.ID,
-			AssetID:     tx.AssetID,
-			Type:        string(tx.Type),
-			Amount:      tx.Amount.Amount,
-			Currency:    tx.Amount.Currency,
-			Category:    tx.Category,
-			Description: tx.Description,
-			Date:        tx.Date,
-			CreatedAt:   tx.CreatedAt,
+			ID:             tx.ID,
+			AssetID:        tx.AssetID,
+			CounterAssetID: tx.CounterAssetID,
+			Type:           string(tx.Type),
+			Amount:         tx.Amount.Amount,
+			Currency:       tx.Amount.Currency,
+			Category:       tx.Category,
+			Description:    tx.Description,
+			Date:           tx.Date,
+			CreatedAt:      tx.CreatedAt,
 		}
 	}
 
@@ -364,13 +532,6 @@ func (h *Handler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 자산 존재 여부 확인
-	targetAsset, err := h.assetRepo.FindByID(r.Context(), req.AssetID)
-	if err != nil {
-		respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
-		return
-	}
-
 	// 거래 생성
 	money, err := asset.NewMoney(req.Amount, req.Currency)
 	if err != nil {
@@ -388,47 +549,125 @@ func (h *Handler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
 		return
 	}
-
-	// 거래 유효성 검증
-	if err := targetAsset.ValidateTransaction(tx); err != nil {
-		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
-		return
+	if tx.Type == asset.Transfer {
+		if req.CounterAssetID == "" {
+			respondError(w, http.StatusBadRequest, ErrValidation, "이체에는 입금 자산 ID가 필요합니다")
+			return
+		}
+		tx.CounterAssetID = req.CounterAssetID
 	}
 
-	// 거래 처리 및 자산 업데이트
-	if err := targetAsset.ProcessTransaction(tx); err != nil {
-		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
-		return
-	}
+	// 자산 갱신과 거래 저장을 하나의 작업 단위로 처리하여 부분 반영을 막습니다
+	var recorded []event.Event
+	err = h.assetRepo.WithTransaction(r.Context(), func(ctx context.Context) error {
+		// 자산 존재 여부 확인
+		targetAsset, err := h.assetRepo.FindByID(ctx, req.AssetID)
+		if err != nil {
+			return &apiError{status: http.StatusNotFound, code: ErrNotFound, message: "자산을 찾을 수 없습니다"}
+		}
+		pending := len(targetAsset.GetUncommittedEvents())
 
-	// 트랜잭션 저장
-	if err := h.transactionRepo.Save(r.Context(), tx); err != nil {
-		respondError(w, http.StatusInternalServerError, ErrInternalServer, "거래 생성 실패")
-		return
-	}
+		// 분류가 없으면 자산 소유자의 분류 규칙 적용
+		if h.categorizer != nil {
+			if err := h.categorizer.Apply(ctx, targetAsset.UserID, tx); err != nil {
+				return err
+			}
+		}
+
+		// 이체는 같은 사용자의 입금 자산에도 반영
+		var counter *asset.Asset
+		counterPending := 0
+		if tx.Type == asset.Transfer {
+			counter, err = h.assetRepo.FindByID(ctx, tx.CounterAssetID)
+			if err != nil {
+				return &apiError{status: http.StatusNotFound, code: ErrNotFound, message: "입금 자산을 찾을 수 없습니다"}
+			}
+			if counter.UserID != targetAsset.UserID {
+				return &apiError{status: http.StatusBadRequest, code: ErrValidation, message: "다른 사용자의 자산으로는 이체할 수 없습니다"}
+			}
+			counterPending = len(counter.GetUncommittedEvents())
+		}
+
+		// 거래 처리 및 자산 업데이트. 원장이 있으면 분개를 함께 전기
+		if err := applyTransaction(ctx, h.ledger, tx, targetAsset, counter); err != nil {
+			return err
+		}
+
+		// 트랜잭션 저장
+		if err := h.transactionRepo.Save(ctx, tx); err != nil {
+			return &apiError{status: http.StatusInternalServerError, code: ErrInternalServer, message: "거래 생성 실패"}
+		}
 
-	// 자산 상태 저장
-	if err := h.assetRepo.Update(r.Context(), targetAsset); err != nil {
-		// 롤백 처리가 필요할 수 있음
-		respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 상태 업데이트 실패")
+		// 자산 상태 저장. 버전 충돌은 그대로 돌려보내 409로 응답합니다
+		if err := h.assetRepo.Update(ctx, targetAsset); err != nil {
+			if asset.IsVersionConflict(err) {
+				return err
+			}
+			return &apiError{status: http.StatusInternalServerError, code: ErrInternalServer, message: "자산 상태 업데이트 실패"}
+		}
+		recorded = targetAsset.GetUncommittedEvents()[pending:]
+		if counter != nil {
+			if err := h.assetRepo.Update(ctx, counter); err != nil {
+				if asset.IsVersionConflict(err) {
+					return err
+				}
+				return &apiError{status: http.StatusInternalServerError, code: ErrInternalServer, message: "자산 상태 업데이트 실패"}
+			}
+			recorded = append(recorded, counter.GetUncommittedEvents()[counterPending:]...)
+		}
+		return nil
+	})
+	if err != nil {
+		var apiErr *apiError
+		if errors.As(err, &apiErr) {
+			respondError(w, apiErr.status, apiErr.code, apiErr.message)
+			return
+		}
+		respondUpdateError(w, err, "거래 생성 실패")
 		return
 	}
+	h.publish(r.Context(), recorded)
 
 	response := TransactionResponse{
-		ID:          tx.ID,
-		AssetID:     tx.AssetID,
-		Type:        string(tx.Type),
-		Amount:      tx.Amount.Amount,
-		Currency:    tx.Amount.Currency,
-		Category:    tx.Category,
-		Description: tx.Description,
-		Date:        tx.Date,
-		CreatedAt:   tx.CreatedAt,
+		ID:             tx.ID,
+		AssetID:        tx.AssetID,
+		CounterAssetID: tx.CounterAssetID,
+		Type:           string(tx.Type),
+		Amount:         tx.Amount.Amount,
+		Currency:       tx.Amount.Currency,
+		Category:       tx.Category,
+		Description:    tx.Description,
+		Date:           tx.Date,
+		CreatedAt:      tx.CreatedAt,
 	}
 
 	respondJSON(w, http.StatusCreated, response)
 }
 
+// applyTransaction 거래를 자산에 반영합니다. 원장이 있으면 원장이 자산 반영과 분개 전기를 함께 처리합니다.
+func applyTransaction(ctx context.Context, generalLedger *ledger.Ledger, tx *asset.Transaction, target, counter *asset.Asset) error {
+	if generalLedger != nil {
+		if _, err := generalLedger.Record(ctx, tx, target, counter); err != nil {
+			var domainErr domain.Error
+			if errors.As(err, &domainErr) && domainErr.Code() == domain.ErrCodeInvalidArgument {
+				return &apiError{status: http.StatusBadRequest, code: ErrValidation, message: domainErr.Error()}
+			}
+			return err
+		}
+		return nil
+	}
+
+	if err := target.ProcessTransaction(tx); err != nil {
+		return &apiError{status: http.StatusBadRequest, code: ErrValidation, message: err.Error()}
+	}
+	if counter != nil {
+		if err := counter.ReceiveTransfer(tx); err != nil {
+			return &apiError{status: http.StatusBadRequest, code: ErrValidation, message: err.Error()}
+		}
+	}
+	return nil
+}
+
 func (h *Handler) GetTransaction(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
@@ -442,21 +681,106 @@ func (h *Handler) GetTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", etag(tx.Version))
 	response := TransactionResponse{
-		ID:          tx.ID,
-		AssetID:     tx.AssetID,
-		Type:        string(tx.Type),
-		Amount:      tx.Amount.Amount,
-		Currency:    tx.Amount.Currency,
-		Category:    tx.Category,
-		Description: tx.Description,
-		Date:        tx.Date,
-		CreatedAt:   tx.CreatedAt,
+		ID:             tx.ID,
+		AssetID:        tx.AssetID,
+		CounterAssetID: tx.CounterAssetID,
+		Type:           string(tx.Type),
+		Amount:         tx.Amount.Amount,
+		Currency:       tx.Amount.Currency,
+		Category:       tx.Category,
+		Description:    tx.Description,
+		Date:           tx.Date,
+		CreatedAt:      tx.CreatedAt,
 	}
 
 	respondJSON(w, http.StatusOK, response)
 }
 
+// UpdateTransactionCategoryRequest 거래 분류 수정 요청
+type UpdateTransactionCategoryRequest struct {
+	Category string `json:"category"`
+}
+
+// assetEvent 자산 소유자를 메타데이터에 담은 자산 이벤트를 생성합니다.
+func assetEvent(eventType event.Type, a *asset.Asset) event.Event {
+	return event.NewEvent(
+		eventType,
+		a.ID,
+		"asset",
+		map[string]interface{}{
+			"amount": a.Amount,
+		},
+		map[string]string{
+			"userID": a.UserID,
+		},
+		1,
+	)
+}
+
+// publish 커밋된 변경의 이벤트를 발행합니다. 발행에 실패해도 이미 저장한 변경은 되돌리지 않습니다.
+func (h *Handler) publish(ctx context.Context, events []event.Event) {
+	if h.bus == nil {
+		return
+	}
+	for _, evt := range events {
+		if err := h.bus.Publish(ctx, evt); err != nil {
+			log.Printf("이벤트 발행 실패(%s): %v", evt.AggregateID(), err)
+		}
+	}
+}
+
+// UpdateTransactionCategory 거래의 분류를 고칩니다.
+// 수정 내역은 분류 규칙 제안에 사용되며, 분류는 자산 소유자의 분류 체계에 있어야 합니다.
+func (h *Handler) UpdateTransactionCategory(w http.ResponseWriter, r *http.Request) {
+	if h.categorizer == nil {
+		respondError(w, http.StatusNotImplemented, ErrNotImplemented, "분류 기능이 설정되지 않았습니다")
+		return
+	}
+	var req UpdateTransactionCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Category == "" {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "분류가 필요합니다")
+		return
+	}
+
+	tx, err := h.transactionRepo.FindByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, ErrNotFound, "거래를 찾을 수 없습니다")
+		return
+	}
+	if !checkIfMatch(w, r, tx.Version) {
+		return
+	}
+	owner, err := h.assetRepo.FindByID(r.Context(), tx.AssetID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
+		return
+	}
+	if _, err := h.categorizer.RecordCorrection(r.Context(), owner.UserID, tx, req.Category); err != nil {
+		if asset.IsVersionConflict(err) {
+			respondError(w, http.StatusPreconditionFailed, ErrPreconditionFailed, "다른 요청이 먼저 거래를 변경했습니다")
+			return
+		}
+		respondCategoryError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(tx.Version))
+	respondJSON(w, http.StatusOK, TransactionResponse{
+		ID:             tx.ID,
+		AssetID:        tx.AssetID,
+		CounterAssetID: tx.CounterAssetID,
+		Type:           string(tx.Type),
+		Amount:         tx.Amount.Amount,
+		Currency:       tx.Amount.Currency,
+		Category:       tx.Category,
+		Description:    tx.Description,
+		Date:           tx.Date,
+		CreatedAt:      tx.CreatedAt,
+	})
+}
+
 // GetPortfolio godoc
 // @Summary 포트폴리오를 조회합니다
 // @Description 사용자의 포트폴리오 정보를 반환합니다
@@ -518,6 +842,11 @@ func (h *Handler) UpdateAssetAmount(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 금액 업데이트 실패")
 		return
 	}
+	if h.bus != nil {
+		if updated, err := h.assetRepo.FindByID(r.Context(), id); err == nil {
+			h.publish(r.Context(), []event.Event{assetEvent(event.TypeAssetAmountChanged, updated)})
+		}
+	}
 
 	respondJSON(w, http.StatusOK, nil)
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -59,6 +60,11 @@ func (m *mockAssetRepository) FindByType(ctx context.Context, assetType asset.Ty
 	return args.Get(0).([]*asset.Asset), args.Error(1)
 }
 
+func (m *mockAssetRepository) FindBySymbol(ctx context.Context, symbol string) ([]*asset.Asset, error) {
+	args := m.Called(ctx, symbol)
+	return args.Get(0).([]*asset.Asset), args.Error(1)
+}
+
 func (m *mockAssetRepository) UpdateAmount(ctx context.Context, id string, amount asset.Money) error {
 	args := m.Called(ctx, id, amount)
 	return args.Error(0)
@@ -141,6 +147,38 @@ func TestListAssets(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestListAssets_WithQuery(t *testing.T) {
+	handler, mockRepo := setupTestHandler()
+
+	testUserID := "test-user"
+	testAssets := []*asset.Asset{
+		{ID: "asset-1", UserID: testUserID, Type: asset.Stock, Name: "삼성전자", Amount: asset.Money{Amount: 1000000, Currency: "KRW"}},
+		{ID: "asset-2", UserID: testUserID, Type: asset.Cash, Name: "예금", Amount: asset.Money{Amount: 500, Currency: "KRW"}},
+	}
+	mockRepo.On("FindByUserID", mock.Anything, testUserID).Return(testAssets, nil)
+
+	q := url.QueryEscape(`type in (STOCK,BOND) and amount.amount > 1000 and name ~ "삼성"`)
+	req := httptest.NewRequest("GET", "/assets?userId="+testUserID+"&q="+q, nil)
+	w := httptest.NewRecorder()
+
+	handler.ListAssets(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []AssetResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, "asset-1", response[0].ID)
+
+	// 잘못된 쿼리는 400을 반환합니다
+	req = httptest.NewRequest("GET", "/assets?userId="+testUserID+"&q="+url.QueryEscape("amount >"), nil)
+	w = httptest.NewRecorder()
+
+	handler.ListAssets(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestCreateAsset(t *testing.T) {
 	handler, mockRepo := setupTestHandler()
 
@@ -434,3 +472,117 @@ func TestDeleteAsset_NotFound(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+// failingTransactionRepository 거래 저장에 항상 실패하는 저장소입니다.
+type failingTransactionRepository struct {
+	*asset.MemoryTransactionRepository
+}
+
+func (r *failingTransactionRepository) Save(_ context.Context, _ *asset.Transaction) error {
+	return fmt.Errorf("저장소 오류")
+}
+
+func TestCreateTransaction_RollsBackAssetOnFailure(t *testing.T) {
+	assetRepo := asset.NewMemoryAssetRepository()
+	txRepo := &failingTransactionRepository{asset.NewMemoryTransactionRepository()}
+	handler := NewHandler(assetRepo, txRepo, asset.NewMemoryPortfolioRepository(), new(gamification.MockRepository))
+
+	target, err := asset.NewAsset("test-user", asset.Cash, "예금", 1000, "KRW")
+	assert.NoError(t, err)
+	assert.NoError(t, assetRepo.Save(context.Background(), target))
+
+	reqBody, _ := json.Marshal(CreateTransactionRequest{
+		AssetID:  target.ID,
+		Type:     string(asset.Expense),
+		Amount:   300,
+		Currency: "KRW",
+	})
+	req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(reqBody))
+	w := httptest.NewRecorder()
+
+	handler.CreateTransaction(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	found, err := assetRepo.FindByID(context.Background(), target.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.0, found.Amount.Amount)
+}
+
+func TestCreateTransaction_AssetNotFound(t *testing.T) {
+	handler := NewHandler(asset.NewMemoryAssetRepository(), asset.NewMemoryTransactionRepository(), asset.NewMemoryPortfolioRepository(), new(gamification.MockRepository))
+
+	reqBody, _ := json.Marshal(CreateTransactionRequest{AssetID: "missing", Type: string(asset.Income), Amount: 1, Currency: "KRW"})
+	req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(reqBody))
+	w := httptest.NewRecorder()
+
+	handler.CreateTransaction(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdateAsset_IfMatch(t *testing.T) {
+	newRouter := func() (chi.Router, *asset.Asset) {
+		assetRepo := asset.NewMemoryAssetRepository()
+		handler := NewHandler(assetRepo, asset.NewMemoryTransactionRepository(), asset.NewMemoryPortfolioRepository(), new(gamification.MockRepository))
+		target, err := asset.NewAsset("test-user", asset.Cash, "예금", 1000, "KRW")
+		assert.NoError(t, err)
+		assert.NoError(t, assetRepo.Save(context.Background(), target))
+		r := chi.NewRouter()
+		handler.RegisterRoutes(r)
+		return r, target
+	}
+	update := func(r chi.Router, id, ifMatch string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(UpdateAssetRequest{Name: "적금"})
+		req := httptest.NewRequest("PUT", "/assets/"+id, bytes.NewBuffer(body))
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("조회 응답의 ETag로 수정하면 새 ETag를 돌려준다", func(t *testing.T) {
+		r, target := newRouter()
+		get := httptest.NewRecorder()
+		r.ServeHTTP(get, httptest.NewRequest("GET", "/assets/"+target.ID, nil))
+		assert.Equal(t, `"1"`, get.Header().Get("ETag"))
+
+		w := update(r, target.ID, get.Header().Get("ETag"))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("오래된 ETag로 수정하면 412를 반환한다", func(t *testing.T) {
+		r, target := newRouter()
+		assert.Equal(t, http.StatusOK, update(r, target.ID, `"1"`).Code)
+
+		w := update(r, target.ID, `"1"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		var resp ErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, ErrPreconditionFailed, resp.Code)
+	})
+
+	t.Run("해석할 수 없는 If-Match는 400을 반환한다", func(t *testing.T) {
+		r, target := newRouter()
+
+		w := update(r, target.ID, "abc")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("오래된 ETag로 삭제하면 412를 반환한다", func(t *testing.T) {
+		r, target := newRouter()
+		assert.Equal(t, http.StatusOK, update(r, target.ID, "").Code)
+
+		req := httptest.NewRequest("DELETE", "/assets/"+target.ID, nil)
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+}
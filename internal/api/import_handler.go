@@ -0,0 +1,200 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/statement"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// ImportRequest 거래 내역 가져오기 요청
+// Data는 파일 원본(base64)이며, 텍스트 파일은 Content로 그대로 보낼 수도 있습니다.
+type ImportRequest struct {
+	Format  string            `json:"format"`
+	Data    []byte            `json:"data,omitempty"`
+	Content string            `json:"content,omitempty"`
+	Options statement.Options `json:"options"`
+	Target  statement.Target  `json:"target"`
+	// SkipDuplicateCheck 기존 거래와 비슷한 거래도 생성합니다
+	SkipDuplicateCheck bool `json:"skipDuplicateCheck,omitempty"`
+}
+
+// ImportRecordResponse 파일에서 읽은 거래 응답
+type ImportRecordResponse struct {
+	ExternalID  string    `json:"externalId,omitempty"`
+	Fingerprint string    `json:"fingerprint"`
+	Date        time.Time `json:"date"`
+	Amount      float64   `json:"amount"`
+	Currency    string    `json:"currency"`
+	Payee       string    `json:"payee,omitempty"`
+	Memo        string    `json:"memo,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	Symbol      string    `json:"symbol,omitempty"`
+	Quantity    float64   `json:"quantity,omitempty"`
+	Price       float64   `json:"price,omitempty"`
+}
+
+// ImportRowResponse 행별 가져오기 결과 응답
+type ImportRowResponse struct {
+	Line          int                   `json:"line"`
+	Status        string                `json:"status"`
+	TransactionID string                `json:"transactionId,omitempty"`
+	Error         string                `json:"error,omitempty"`
+	Score         float64               `json:"score,omitempty"`
+	Record        *ImportRecordResponse `json:"record,omitempty"`
+}
+
+// ImportReportResponse 가져오기 결과 응답
+type ImportReportResponse struct {
+	DryRun     bool                `json:"dryRun"`
+	Encoding   string              `json:"encoding"`
+	Total      int                 `json:"total"`
+	New        int                 `json:"new"`
+	Created    int                 `json:"created"`
+	Duplicates int                 `json:"duplicates"`
+	Ignored    int                 `json:"ignored"`
+	Possible   int                 `json:"possibleDuplicates"`
+	Invalid    int                 `json:"invalid"`
+	Failed     int                 `json:"failed"`
+	Rows       []ImportRowResponse `json:"rows"`
+}
+
+// ImportHandler 거래 내역 가져오기 API 핸들러입니다.
+type ImportHandler struct {
+	importer *statement.Importer
+}
+
+// NewImportHandler 새로운 거래 내역 가져오기 API 핸들러를 생성합니다.
+func NewImportHandler(importer *statement.Importer) *ImportHandler {
+	return &ImportHandler{importer: importer}
+}
+
+// RegisterRoutes 라우터에 거래 내역 가져오기 API를 등록합니다.
+func (h *ImportHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/imports", func(r chi.Router) {
+		r.Post("/", h.Import)
+		r.Post("/preview", h.Preview)
+	})
+}
+
+// Preview 거래를 생성하지 않고 가져오기 결과를 반환합니다.
+func (h *ImportHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeImportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.importer.Preview(r.Context(), req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, newImportReportResponse(report, true))
+}
+
+// Import 파일의 거래를 생성합니다. 이미 가져온 거래는 DUPLICATE로 표시하고 건너뜁니다.
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeImportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.importer.Import(r.Context(), req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, newImportReportResponse(report, false))
+}
+
+// decodeImportRequest 요청 본문을 읽어 가져오기 요청으로 변환합니다.
+func decodeImportRequest(w http.ResponseWriter, r *http.Request) (statement.Request, bool) {
+	var body ImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return statement.Request{}, false
+	}
+	return validateImportRequest(w, body)
+}
+
+// validateImportRequest 가져오기 요청을 검증해 도메인 요청으로 변환합니다.
+func validateImportRequest(w http.ResponseWriter, body ImportRequest) (statement.Request, bool) {
+
+	format, err := statement.ParseFormat(body.Format)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
+		return statement.Request{}, false
+	}
+	data := body.Data
+	if len(data) == 0 {
+		data = []byte(body.Content)
+	}
+	if len(data) == 0 {
+		respondError(w, http.StatusBadRequest, ErrValidation, "파일 내용이 필요합니다")
+		return statement.Request{}, false
+	}
+	if body.Target.AssetID == "" && body.Target.PortfolioID == "" {
+		respondError(w, http.StatusBadRequest, ErrValidation, "가져올 대상 자산이 필요합니다")
+		return statement.Request{}, false
+	}
+
+	return statement.Request{
+		Format:             format,
+		Data:               data,
+		Options:            body.Options,
+		Target:             body.Target,
+		SkipDuplicateCheck: body.SkipDuplicateCheck,
+	}, true
+}
+
+func newImportRecordResponse(rec statement.Record) ImportRecordResponse {
+	return ImportRecordResponse{
+		ExternalID:  rec.ExternalID,
+		Fingerprint: rec.Fingerprint(),
+		Date:        rec.Date,
+		Amount:      rec.Amount,
+		Currency:    rec.Currency,
+		Payee:       rec.Payee,
+		Memo:        rec.Memo,
+		Category:    rec.Category,
+		Type:        rec.Type,
+		Symbol:      rec.Symbol,
+		Quantity:    rec.Quantity,
+		Price:       rec.Price,
+	}
+}
+
+func newImportReportResponse(report *statement.Report, dryRun bool) ImportReportResponse {
+	rows := make([]ImportRowResponse, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		resp := ImportRowResponse{
+			Line:          row.Line,
+			Status:        string(row.Status),
+			TransactionID: row.TransactionID,
+			Error:         row.Error,
+			Score:         row.Score,
+		}
+		if row.Record != nil {
+			record := newImportRecordResponse(*row.Record)
+			resp.Record = &record
+		}
+		rows = append(rows, resp)
+	}
+
+	return ImportReportResponse{
+		DryRun:     dryRun,
+		Encoding:   report.Encoding,
+		Total:      report.Total,
+		New:        report.New,
+		Created:    report.Created,
+		Duplicates: report.Duplicates,
+		Ignored:    report.Ignored,
+		Possible:   report.Possible,
+		Invalid:    report.Invalid,
+		Failed:     report.Failed,
+		Rows:       rows,
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/korean"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/statement"
+)
+
+func newImportTestRouter(t *testing.T) (*chi.Mux, asset.Repository, string) {
+	t.Helper()
+	assets := asset.NewMemoryAssetRepository()
+	account, err := asset.NewAsset("user-1", asset.Cash, "입출금", 10000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), account))
+
+	sink := statement.NewAssetSink(assets, asset.NewMemoryTransactionRepository())
+	r := chi.NewRouter()
+	NewImportHandler(statement.NewImporter(statement.NewMemoryRegistry(), sink)).RegisterRoutes(r)
+	return r, assets, account.ID
+}
+
+func postImport(t *testing.T, r http.Handler, path string, body ImportRequest) (*httptest.ResponseRecorder, ImportReportResponse) {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var report ImportReportResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	}
+	return w, report
+}
+
+func TestImportStatement(t *testing.T) {
+	r, assets, assetID := newImportTestRouter(t)
+	data, err := korean.EUCKR.NewEncoder().Bytes([]byte("거래일자,내용,금액\n2024-03-01,이자,500\n2024-03-02,편의점,-2000\n"))
+	require.NoError(t, err)
+	body := ImportRequest{
+		Format:  "csv",
+		Data:    data,
+		Options: statement.Options{Currency: "KRW", CSV: statement.CSVMapping{Date: "거래일자", Payee: "내용", Amount: "금액"}},
+		Target:  statement.Target{AssetID: assetID},
+	}
+
+	t.Run("미리보기는 거래를 생성하지 않음", func(t *testing.T) {
+		w, report := postImport(t, r, "/imports/preview", body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, "EUC-KR", report.Encoding)
+		assert.Equal(t, 2, report.New)
+		require.Len(t, report.Rows, 2)
+		assert.Equal(t, "편의점", report.Rows[1].Record.Payee)
+		found, err := assets.FindByID(context.Background(), assetID)
+		require.NoError(t, err)
+		assert.Equal(t, 10000.0, found.Amount.Amount)
+	})
+
+	t.Run("가져오기는 거래를 생성함", func(t *testing.T) {
+		w, report := postImport(t, r, "/imports", body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2, report.Created)
+		assert.NotEmpty(t, report.Rows[0].TransactionID)
+		found, err := assets.FindByID(context.Background(), assetID)
+		require.NoError(t, err)
+		assert.Equal(t, 8500.0, found.Amount.Amount)
+	})
+
+	t.Run("다시 가져오면 중복으로 건너뜀", func(t *testing.T) {
+		w, report := postImport(t, r, "/imports", body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 0, report.Created)
+		assert.Equal(t, 2, report.Duplicates)
+		assert.Equal(t, "DUPLICATE", report.Rows[0].Status)
+	})
+}
+
+func TestImportStatement_InvalidRequest(t *testing.T) {
+	r, _, assetID := newImportTestRouter(t)
+	tests := []struct {
+		name string
+		body ImportRequest
+	}{
+		{name: "지원하지 않는 형식", body: ImportRequest{Format: "xlsx", Content: "a", Target: statement.Target{AssetID: assetID}}},
+		{name: "파일 내용 없음", body: ImportRequest{Format: "qif", Target: statement.Target{AssetID: assetID}}},
+		{name: "대상 자산 없음", body: ImportRequest{Format: "qif", Content: "!Type:Bank\n"}},
+		{name: "잘못된 CSV 매핑", body: ImportRequest{Format: "csv", Content: "a,b\n", Target: statement.Target{AssetID: assetID}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := postImport(t, r, "/imports/preview", tt.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// TrialBalanceLineResponse 시산표의 계정별 합계 응답
+type TrialBalanceLineResponse struct {
+	AccountID string  `json:"accountId"`
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	Currency  string  `json:"currency"`
+	Debits    float64 `json:"debits"`
+	Credits   float64 `json:"credits"`
+	Balance   float64 `json:"balance"`
+}
+
+// TrialBalanceResponse 시산표 응답
+type TrialBalanceResponse struct {
+	Balanced     bool                       `json:"balanced"`
+	Lines        []TrialBalanceLineResponse `json:"lines"`
+	TotalDebits  map[string]float64         `json:"totalDebits"`
+	TotalCredits map[string]float64         `json:"totalCredits"`
+}
+
+// DiscrepancyResponse 자산 금액과 원장 잔액의 차이 응답
+type DiscrepancyResponse struct {
+	AssetID  string  `json:"assetId"`
+	Currency string  `json:"currency"`
+	Recorded float64 `json:"recorded"`
+	Derived  float64 `json:"derived"`
+}
+
+// LedgerCheckResponse 원장 일관성 검사 응답
+type LedgerCheckResponse struct {
+	Consistent    bool                  `json:"consistent"`
+	Discrepancies []DiscrepancyResponse `json:"discrepancies"`
+}
+
+// LedgerSyncResponse 원장 잔액 동기화 응답
+type LedgerSyncResponse struct {
+	Updated int `json:"updated"`
+}
+
+// LedgerHandler 복식부기 원장 API 핸들러입니다.
+// 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리합니다.
+type LedgerHandler struct {
+	ledger    *ledger.Ledger
+	assetRepo asset.Repository
+}
+
+// NewLedgerHandler 새로운 원장 API 핸들러를 생성합니다.
+func NewLedgerHandler(l *ledger.Ledger, assetRepo asset.Repository) *LedgerHandler {
+	return &LedgerHandler{ledger: l, assetRepo: assetRepo}
+}
+
+// RegisterRoutes 라우터에 원장 API를 등록합니다.
+func (h *LedgerHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/ledger", func(r chi.Router) {
+		r.Get("/trial-balance", h.TrialBalance)
+		r.Get("/check", h.Check)
+		r.Post("/sync", h.Sync)
+	})
+}
+
+// TrialBalance 사용자의 시산표를 조회합니다.
+func (h *LedgerHandler) TrialBalance(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	tb, err := h.ledger.TrialBalance(r.Context(), userID)
+	if err != nil {
+		respondLedgerError(w, err)
+		return
+	}
+	response := TrialBalanceResponse{
+		Balanced:     tb.IsBalanced(),
+		Lines:        make([]TrialBalanceLineResponse, len(tb.Lines)),
+		TotalDebits:  tb.TotalDebits,
+		TotalCredits: tb.TotalCredits,
+	}
+	for i, line := range tb.Lines {
+		response.Lines[i] = TrialBalanceLineResponse{
+			AccountID: line.AccountID,
+			Name:      line.Name,
+			Type:      string(line.Type),
+			Currency:  line.Currency,
+			Debits:    line.Debits,
+			Credits:   line.Credits,
+			Balance:   line.Balance,
+		}
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Check 자산 금액을 원장 잔액과 비교해 차이를 조회합니다.
+func (h *LedgerHandler) Check(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	owned, err := h.assetRepo.FindByUserID(r.Context(), userID)
+	if err != nil {
+		respondLedgerError(w, err)
+		return
+	}
+	discrepancies, err := h.ledger.Check(r.Context(), userID, owned)
+	if err != nil {
+		respondLedgerError(w, err)
+		return
+	}
+	response := LedgerCheckResponse{
+		Consistent:    len(discrepancies) == 0,
+		Discrepancies: make([]DiscrepancyResponse, len(discrepancies)),
+	}
+	for i, d := range discrepancies {
+		response.Discrepancies[i] = DiscrepancyResponse{
+			AssetID:  d.AssetID,
+			Currency: d.Derived.Currency,
+			Recorded: d.Recorded.Amount,
+			Derived:  d.Derived.Amount,
+		}
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Sync 계정이 있는 자산의 금액을 원장 잔액으로 맞춥니다.
+func (h *LedgerHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	updated, err := h.ledger.SyncAssets(r.Context(), userID, h.assetRepo)
+	if err != nil {
+		respondLedgerError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, LedgerSyncResponse{Updated: updated})
+}
+
+func respondLedgerError(w http.ResponseWriter, err error) {
+	log.Printf("원장 처리 실패: %v", err)
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "원장 처리 중 오류가 발생했습니다")
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+)
+
+func TestLedger(t *testing.T) {
+	ctx := context.Background()
+	assets := asset.NewMemoryAssetRepository()
+	checking, err := asset.NewAsset("user-1", asset.Cash, "입출금", 1000000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(ctx, checking))
+	savings, err := asset.NewAsset("user-1", asset.Cash, "적금", 200000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(ctx, savings))
+	card, err := asset.NewAsset("user-1", asset.CreditCard, "신용카드", 100000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(ctx, card))
+
+	l := ledger.NewLedger(ledger.NewMemoryRepository())
+	r := chi.NewRouter()
+	NewHandler(assets, asset.NewMemoryTransactionRepository(), asset.NewMemoryPortfolioRepository(), nil, WithLedger(l)).RegisterRoutes(r)
+	NewLedgerHandler(l, assets).RegisterRoutes(r)
+
+	netWorth := func(t *testing.T) float64 {
+		t.Helper()
+		owned, err := assets.FindByUserID(ctx, "user-1")
+		require.NoError(t, err)
+		var total float64
+		for _, a := range owned {
+			if a.Type.IsLiability() {
+				total -= a.Amount.Amount
+			} else {
+				total += a.Amount.Amount
+			}
+		}
+		return total
+	}
+
+	t.Run("입금 자산 없는 이체", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/transactions", "user-1", CreateTransactionRequest{
+			AssetID: checking.ID, Type: string(asset.Transfer), Amount: 1000, Currency: "KRW",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("이체는 합계를 바꾸지 않음", func(t *testing.T) {
+		before := netWorth(t)
+
+		w := doAs(t, r, http.MethodPost, "/transactions", "user-1", CreateTransactionRequest{
+			AssetID: checking.ID, CounterAssetID: savings.ID, Type: string(asset.Transfer),
+			Amount: 300000, Currency: "KRW", Description: "적금 이체",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		w = doAs(t, r, http.MethodPost, "/transactions", "user-1", CreateTransactionRequest{
+			AssetID: checking.ID, CounterAssetID: card.ID, Type: string(asset.Transfer),
+			Amount: 50000, Currency: "KRW", Description: "카드 대금",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		assert.Equal(t, before, netWorth(t))
+		saved, err := assets.FindByID(ctx, savings.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 500000.0, saved.Amount.Amount)
+		saved, err = assets.FindByID(ctx, card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 50000.0, saved.Amount.Amount)
+	})
+
+	t.Run("원장과 자산 일치", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/transactions", "user-1", CreateTransactionRequest{
+			AssetID: checking.ID, Type: string(asset.Expense), Amount: 20000, Currency: "KRW", Category: "식비",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = doAs(t, r, http.MethodGet, "/ledger/check", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var check LedgerCheckResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&check))
+		assert.True(t, check.Consistent, check.Discrepancies)
+
+		w = doAs(t, r, http.MethodGet, "/ledger/trial-balance", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var tb TrialBalanceResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&tb))
+		assert.True(t, tb.Balanced)
+		assert.Equal(t, 1670000.0, tb.TotalDebits["KRW"])
+	})
+}
@@ -0,0 +1,365 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// CreateLiabilityRequest 부채 생성 요청
+// 상환 주기와 방식을 생략하면 매월 원리금 균등 상환으로, 상환 횟수가 0이면 만기가 없는 부채로 등록합니다.
+type CreateLiabilityRequest struct {
+	Type       string    `json:"type"`
+	Name       string    `json:"name"`
+	Principal  float64   `json:"principal"`
+	Currency   string    `json:"currency"`
+	AnnualRate float64   `json:"annualRate"`
+	Term       int       `json:"term"`
+	Frequency  string    `json:"frequency"`
+	Method     string    `json:"method"`
+	StartDate  time.Time `json:"startDate"`
+}
+
+// InstallmentResponse 상환 회차 응답
+type InstallmentResponse struct {
+	Number    int       `json:"number"`
+	DueDate   time.Time `json:"dueDate"`
+	Payment   float64   `json:"payment"`
+	Interest  float64   `json:"interest"`
+	Principal float64   `json:"principal"`
+	Balance   float64   `json:"balance"`
+}
+
+// LiabilityResponse 부채 응답
+type LiabilityResponse struct {
+	ID          string               `json:"id"`
+	UserID      string               `json:"userId"`
+	Type        string               `json:"type"`
+	Name        string               `json:"name"`
+	Balance     float64              `json:"balance"`
+	Currency    string               `json:"currency"`
+	Principal   float64              `json:"principal"`
+	AnnualRate  float64              `json:"annualRate"`
+	Term        int                  `json:"term"`
+	Frequency   string               `json:"frequency,omitempty"`
+	Method      string               `json:"method,omitempty"`
+	StartDate   *time.Time           `json:"startDate,omitempty"`
+	NextPayment *InstallmentResponse `json:"nextPayment,omitempty"`
+	CreatedAt   time.Time            `json:"createdAt"`
+}
+
+// ScheduleResponse 상환 일정 응답
+type ScheduleResponse struct {
+	LiabilityID   string                `json:"liabilityId"`
+	Method        string                `json:"method"`
+	TotalPayment  float64               `json:"totalPayment"`
+	TotalInterest float64               `json:"totalInterest"`
+	Installments  []InstallmentResponse `json:"installments"`
+}
+
+// RepaymentRequest 부채 상환 요청
+// 상환 금액은 부채와 같은 통화의 출금 자산(SourceAssetID)에서 나갑니다.
+type RepaymentRequest struct {
+	SourceAssetID string  `json:"sourceAssetId"`
+	Amount        float64 `json:"amount"`
+}
+
+// RepaymentResponse 부채 상환 결과 응답
+type RepaymentResponse struct {
+	Payment        float64  `json:"payment"`
+	Interest       float64  `json:"interest"`
+	Principal      float64  `json:"principal"`
+	Balance        float64  `json:"balance"`
+	Currency       string   `json:"currency"`
+	SourceBalance  float64  `json:"sourceBalance"`
+	TransactionIDs []string `json:"transactionIds"`
+}
+
+// LiabilityHandler 대출, 주택담보대출, 신용카드 같은 부채 API 핸들러입니다.
+// 부채는 부채 유형의 자산으로 저장되며, 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리합니다.
+type LiabilityHandler struct {
+	assetRepo       asset.Repository
+	transactionRepo asset.TransactionRepository
+	ledger          *ledger.Ledger
+	bus             event.Bus
+	now             func() time.Time
+}
+
+// NewLiabilityHandler 새로운 부채 API 핸들러를 생성합니다.
+// generalLedger가 nil이면 상환 거래를 분개 없이 자산에만 반영하고, bus가 nil이면 상환 후 이벤트를 발행하지 않습니다.
+func NewLiabilityHandler(assetRepo asset.Repository, transactionRepo asset.TransactionRepository, generalLedger *ledger.Ledger, bus event.Bus) *LiabilityHandler {
+	return &LiabilityHandler{assetRepo: assetRepo, transactionRepo: transactionRepo, ledger: generalLedger, bus: bus, now: time.Now}
+}
+
+// RegisterRoutes 라우터에 부채 API를 등록합니다.
+func (h *LiabilityHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/liabilities", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Get("/{id}", h.Get)
+		r.Get("/{id}/schedule", h.Schedule)
+		r.Post("/{id}/payments", h.Repay)
+	})
+}
+
+// List 사용자의 부채를 조회합니다.
+func (h *LiabilityHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	owned, err := h.assetRepo.FindByUserID(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "부채 조회 중 오류가 발생했습니다")
+		return
+	}
+	response := make([]LiabilityResponse, 0)
+	for _, a := range owned {
+		if a.Type.IsLiability() {
+			response = append(response, h.newLiabilityResponse(a))
+		}
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Create 상환 조건과 함께 부채를 등록합니다.
+func (h *LiabilityHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req CreateLiabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	terms, err := asset.NewLiability(
+		asset.Money{Amount: req.Principal, Currency: req.Currency},
+		req.AnnualRate,
+		req.Term,
+		asset.PaymentFrequency(req.Frequency),
+		asset.AmortizationMethod(req.Method),
+		req.StartDate,
+	)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
+		return
+	}
+	liability, err := asset.NewLiabilityAsset(userID, asset.Type(req.Type), req.Name, terms)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
+		return
+	}
+	if err := h.assetRepo.Save(r.Context(), liability); err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "부채 생성 실패")
+		return
+	}
+	h.publish(r, []event.Event{assetEvent(event.TypeAssetCreated, liability)})
+
+	respondJSON(w, http.StatusCreated, h.newLiabilityResponse(liability))
+}
+
+// Get 부채와 다음 상환 회차를 조회합니다.
+func (h *LiabilityHandler) Get(w http.ResponseWriter, r *http.Request) {
+	liability, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, h.newLiabilityResponse(liability))
+}
+
+// Schedule 상환 일정을 조회합니다. remaining=true이면 현재 잔액으로 남은 일정을 다시 계산합니다.
+func (h *LiabilityHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	liability, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+	if liability.Liability == nil {
+		respondError(w, http.StatusBadRequest, ErrValidation, "상환 조건이 없는 부채입니다")
+		return
+	}
+
+	installments := liability.Liability.Schedule()
+	if r.URL.Query().Get("remaining") == "true" {
+		installments = liability.Liability.Remaining(liability.Amount.Amount, h.now())
+	}
+	response := ScheduleResponse{
+		LiabilityID:  liability.ID,
+		Method:       string(liability.Liability.Method),
+		Installments: make([]InstallmentResponse, len(installments)),
+	}
+	for i, installment := range installments {
+		response.TotalPayment += installment.Payment
+		response.TotalInterest += installment.Interest
+		response.Installments[i] = newInstallmentResponse(installment)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Repay 상환 금액을 이자와 원금으로 나누어 출금 자산에서 냅니다. 금액의 통화는 부채의 통화를 따릅니다.
+// 원금은 출금 자산에서 부채로의 이체로, 이자는 출금 자산의 이자 지출로 기록하며
+// 원장이 있으면 두 거래를 분개로 전기합니다. 거래 저장과 자산 갱신은 하나의 작업 단위로 처리합니다.
+func (h *LiabilityHandler) Repay(w http.ResponseWriter, r *http.Request) {
+	liability, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+	var req RepaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+	if req.SourceAssetID == "" {
+		respondError(w, http.StatusBadRequest, ErrValidation, "출금 자산 ID가 필요합니다")
+		return
+	}
+
+	var repayment *asset.Repayment
+	var source *asset.Asset
+	var recorded []event.Event
+	err := h.assetRepo.WithTransaction(r.Context(), func(ctx context.Context) error {
+		var err error
+		// 작업 단위 안에서 다시 조회해 동시에 반영된 상환과 겹치지 않게 합니다
+		liability, err = h.assetRepo.FindByID(ctx, liability.ID)
+		if err != nil {
+			return &apiError{status: http.StatusNotFound, code: ErrNotFound, message: "부채를 찾을 수 없습니다"}
+		}
+		source, err = h.assetRepo.FindByID(ctx, req.SourceAssetID)
+		if err != nil || source.UserID != liability.UserID {
+			return &apiError{status: http.StatusNotFound, code: ErrNotFound, message: "출금 자산을 찾을 수 없습니다"}
+		}
+		if source.Type.IsLiability() {
+			return &apiError{status: http.StatusBadRequest, code: ErrValidation, message: "부채 자산에서는 상환할 수 없습니다"}
+		}
+		if source.Amount.Currency != liability.Amount.Currency {
+			return &apiError{status: http.StatusBadRequest, code: ErrValidation, message: "출금 자산과 부채의 통화가 다릅니다"}
+		}
+		pending, liabilityPending := len(source.GetUncommittedEvents()), len(liability.GetUncommittedEvents())
+
+		repayment, err = liability.PlanRepayment(source.ID, asset.Money{Amount: req.Amount, Currency: liability.Amount.Currency})
+		if err != nil {
+			return &apiError{status: http.StatusBadRequest, code: ErrValidation, message: err.Error()}
+		}
+		for _, tx := range repayment.Transactions() {
+			var counter *asset.Asset
+			if tx.Type == asset.Transfer {
+				counter = liability
+			}
+			if err := applyTransaction(ctx, h.ledger, tx, source, counter); err != nil {
+				return err
+			}
+			if err := h.transactionRepo.Save(ctx, tx); err != nil {
+				return &apiError{status: http.StatusInternalServerError, code: ErrInternalServer, message: "상환 거래 저장 실패"}
+			}
+		}
+
+		for _, a := range []*asset.Asset{source, liability} {
+			if err := h.assetRepo.Update(ctx, a); err != nil {
+				if asset.IsVersionConflict(err) {
+					return err
+				}
+				return &apiError{status: http.StatusInternalServerError, code: ErrInternalServer, message: "부채 상환 반영 실패"}
+			}
+		}
+		recorded = append(recorded, source.GetUncommittedEvents()[pending:]...)
+		recorded = append(recorded, liability.GetUncommittedEvents()[liabilityPending:]...)
+		return nil
+	})
+	if err != nil {
+		var apiErr *apiError
+		if errors.As(err, &apiErr) {
+			respondError(w, apiErr.status, apiErr.code, apiErr.message)
+			return
+		}
+		respondUpdateError(w, err, "부채 상환 반영 실패")
+		return
+	}
+	h.publish(r, recorded)
+
+	split := repayment.Split
+	response := RepaymentResponse{
+		Payment:        split.Payment.Amount,
+		Interest:       split.Interest.Amount,
+		Principal:      split.Principal.Amount,
+		Balance:        liability.Amount.Amount,
+		Currency:       liability.Amount.Currency,
+		SourceBalance:  source.Amount.Amount,
+		TransactionIDs: make([]string, 0, 2),
+	}
+	for _, tx := range repayment.Transactions() {
+		response.TransactionIDs = append(response.TransactionIDs, tx.ID)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// find 경로의 부채를 조회합니다. 다른 사용자의 자산이거나 부채가 아니면 404로 응답합니다.
+func (h *LiabilityHandler) find(w http.ResponseWriter, r *http.Request) (*asset.Asset, bool) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return nil, false
+	}
+	liability, err := h.assetRepo.FindByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil || liability.UserID != userID || !liability.Type.IsLiability() {
+		respondError(w, http.StatusNotFound, ErrNotFound, "부채를 찾을 수 없습니다")
+		return nil, false
+	}
+	return liability, true
+}
+
+func (h *LiabilityHandler) publish(r *http.Request, events []event.Event) {
+	if h.bus == nil {
+		return
+	}
+	for _, evt := range events {
+		if err := h.bus.Publish(r.Context(), evt); err != nil {
+			log.Printf("이벤트 발행 실패(%s): %v", evt.AggregateID(), err)
+		}
+	}
+}
+
+func (h *LiabilityHandler) newLiabilityResponse(a *asset.Asset) LiabilityResponse {
+	response := LiabilityResponse{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		Type:      string(a.Type),
+		Name:      a.Name,
+		Balance:   a.Amount.Amount,
+		Currency:  a.Amount.Currency,
+		Principal: a.Amount.Amount,
+		CreatedAt: a.CreatedAt,
+	}
+	if terms := a.Liability; terms != nil {
+		startDate := terms.StartDate
+		response.Principal = terms.Principal.Amount
+		response.AnnualRate = terms.AnnualRate
+		response.Term = terms.Term
+		response.Frequency = string(terms.Frequency)
+		response.Method = string(terms.Method)
+		response.StartDate = &startDate
+		if remaining := terms.Remaining(a.Amount.Amount, h.now()); len(remaining) > 0 {
+			next := newInstallmentResponse(remaining[0])
+			response.NextPayment = &next
+		}
+	}
+	return response
+}
+
+func newInstallmentResponse(i asset.Installment) InstallmentResponse {
+	return InstallmentResponse{
+		Number:    i.Number,
+		DueDate:   i.DueDate,
+		Payment:   i.Payment,
+		Interest:  i.Interest,
+		Principal: i.Principal,
+		Balance:   i.Balance,
+	}
+}
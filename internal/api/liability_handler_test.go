@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+	"github.com/aske/go_fi_chart/internal/domain/networth"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+func TestLiabilities(t *testing.T) {
+	now := time.Date(2024, 4, 20, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	generalLedger := ledger.NewLedger(ledger.NewMemoryRepository())
+	tracker := networth.NewTracker(networth.NewMemoryRepository(), assets, transactions, networth.WithClock(func() time.Time { return now }))
+	bus := memory.NewEventBus()
+	require.NoError(t, bus.Subscribe(tracker))
+	cash, err := asset.NewAsset("user-1", asset.Cash, "입출금", 2000000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(ctx, cash))
+	dollars, err := asset.NewAsset("user-1", asset.Cash, "외화", 1000, "USD")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(ctx, dollars))
+
+	handler := NewLiabilityHandler(assets, transactions, generalLedger, bus)
+	handler.now = func() time.Time { return now }
+	r := chi.NewRouter()
+	handler.RegisterRoutes(r)
+
+	var loan LiabilityResponse
+	t.Run("대출 등록", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/liabilities", "user-1", CreateLiabilityRequest{
+			Type: "LOAN", Name: "신용대출", Principal: 1200000, Currency: "KRW", AnnualRate: 12, Term: 12,
+			Method: "EQUAL_PRINCIPAL", StartDate: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&loan))
+		assert.Equal(t, 1200000.0, loan.Balance)
+		assert.Equal(t, "MONTHLY", loan.Frequency)
+		require.NotNil(t, loan.NextPayment)
+		assert.Equal(t, 4, loan.NextPayment.Number)
+
+		snapshot, err := tracker.Timeline(ctx, "user-1", now, now)
+		require.NoError(t, err)
+		require.Len(t, snapshot, 1)
+		assert.Equal(t, 800000.0, snapshot[0].NetWorth["KRW"])
+	})
+
+	t.Run("상환 일정", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/liabilities/"+loan.ID+"/schedule", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var schedule ScheduleResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&schedule))
+		require.Len(t, schedule.Installments, 12)
+		assert.InDelta(t, 78000, schedule.TotalInterest, 1e-6)
+		assert.InDelta(t, 1278000, schedule.TotalPayment, 1e-6)
+
+		w = doAs(t, r, http.MethodGet, "/liabilities/"+loan.ID+"/schedule?remaining=true", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&schedule))
+		assert.Len(t, schedule.Installments, 9)
+	})
+
+	t.Run("상환", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/liabilities/"+loan.ID+"/payments", "user-1", RepaymentRequest{SourceAssetID: cash.ID, Amount: 112000})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var payment RepaymentResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&payment))
+		assert.Equal(t, 12000.0, payment.Interest)
+		assert.Equal(t, 100000.0, payment.Principal)
+		assert.Equal(t, 1100000.0, payment.Balance)
+		assert.Equal(t, 1888000.0, payment.SourceBalance)
+		require.Len(t, payment.TransactionIDs, 2)
+
+		// 원금은 출금 자산에서 부채로의 이체, 이자는 출금 자산의 지출로 기록됩니다
+		recorded, err := transactions.FindByAssetID(ctx, cash.ID)
+		require.NoError(t, err)
+		require.Len(t, recorded, 2)
+		byType := map[asset.TransactionType]*asset.Transaction{}
+		for _, tx := range recorded {
+			byType[tx.Type] = tx
+		}
+		require.Contains(t, byType, asset.Transfer)
+		assert.Equal(t, loan.ID, byType[asset.Transfer].CounterAssetID)
+		assert.Equal(t, 100000.0, byType[asset.Transfer].Amount.Amount)
+		require.Contains(t, byType, asset.Expense)
+		assert.Equal(t, asset.InterestCategory, byType[asset.Expense].Category)
+		assert.Equal(t, 12000.0, byType[asset.Expense].Amount.Amount)
+
+		saved, err := assets.FindByID(ctx, cash.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1888000.0, saved.Amount.Amount)
+		balances, err := generalLedger.AssetBalances(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1888000.0, balances[cash.ID].Amount)
+		trial, err := generalLedger.TrialBalance(ctx, "user-1")
+		require.NoError(t, err)
+		var interest float64
+		for _, line := range trial.Lines {
+			if line.Type == ledger.AccountExpense && line.Name == asset.InterestCategory {
+				interest = line.Balance
+			}
+		}
+		assert.Equal(t, 12000.0, interest)
+		assert.Equal(t, trial.TotalDebits["KRW"], trial.TotalCredits["KRW"])
+
+		snapshot, err := tracker.Timeline(ctx, "user-1", now, now)
+		require.NoError(t, err)
+		require.Len(t, snapshot, 1)
+		assert.Equal(t, 788000.0, snapshot[0].NetWorth["KRW"])
+
+		w = doAs(t, r, http.MethodGet, "/liabilities", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var list []LiabilityResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+		require.Len(t, list, 1)
+		assert.Equal(t, 1100000.0, list[0].Balance)
+	})
+
+	t.Run("잘못된 요청", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/liabilities", "user-1", CreateLiabilityRequest{Type: "CASH", Name: "현금", Principal: 1000, Currency: "KRW"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doAs(t, r, http.MethodPost, "/liabilities", "user-1", CreateLiabilityRequest{Type: "LOAN", Name: "대출", Principal: 1000, Currency: "KRW", Frequency: "DAILY"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doAs(t, r, http.MethodPost, "/liabilities/"+loan.ID+"/payments", "user-1", RepaymentRequest{SourceAssetID: cash.ID, Amount: 5000000})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doAs(t, r, http.MethodPost, "/liabilities/"+loan.ID+"/payments", "user-1", RepaymentRequest{Amount: 112000})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doAs(t, r, http.MethodPost, "/liabilities/"+loan.ID+"/payments", "user-1", RepaymentRequest{SourceAssetID: dollars.ID, Amount: 112000})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doAs(t, r, http.MethodPost, "/liabilities/"+loan.ID+"/payments", "user-1", RepaymentRequest{SourceAssetID: loan.ID, Amount: 112000})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doAs(t, r, http.MethodPost, "/liabilities/"+loan.ID+"/payments", "user-2", RepaymentRequest{SourceAssetID: cash.ID, Amount: 112000})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = doAs(t, r, http.MethodGet, "/liabilities/"+loan.ID, "user-2", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = doAs(t, r, http.MethodGet, "/liabilities", "", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/networth"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// 순자산 추이 기본 조회 기간(일)
+const defaultNetWorthDays = 30
+
+// NetWorthLineResponse 유형과 통화별 합계 응답
+type NetWorthLineResponse struct {
+	Type      string  `json:"type"`
+	Currency  string  `json:"currency"`
+	Amount    float64 `json:"amount"`
+	Liability bool    `json:"liability"`
+}
+
+// NetWorthPointResponse 하루의 순자산 응답
+type NetWorthPointResponse struct {
+	Date        string                 `json:"date"`
+	Assets      map[string]float64     `json:"assets"`
+	Liabilities map[string]float64     `json:"liabilities"`
+	NetWorth    map[string]float64     `json:"netWorth"`
+	ByType      []NetWorthLineResponse `json:"byType"`
+	Backfilled  bool                   `json:"backfilled"`
+}
+
+// NetWorthTimelineResponse 순자산 추이 응답
+type NetWorthTimelineResponse struct {
+	From   string                  `json:"from"`
+	To     string                  `json:"to"`
+	Points []NetWorthPointResponse `json:"points"`
+}
+
+// BackfillRequest 순자산 되돌려 채우기 요청
+type BackfillRequest struct {
+	From string `json:"from"`
+}
+
+// BackfillResponse 순자산 되돌려 채우기 응답
+type BackfillResponse struct {
+	Written int `json:"written"`
+}
+
+// NetWorthHandler 순자산 추이 API 핸들러입니다.
+// 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리하며, 날짜는 YYYY-MM-DD(UTC) 형식입니다.
+type NetWorthHandler struct {
+	tracker *networth.Tracker
+	now     func() time.Time
+}
+
+// NewNetWorthHandler 새로운 순자산 API 핸들러를 생성합니다.
+func NewNetWorthHandler(tracker *networth.Tracker) *NetWorthHandler {
+	return &NetWorthHandler{tracker: tracker, now: time.Now}
+}
+
+// RegisterRoutes 라우터에 순자산 API를 등록합니다.
+func (h *NetWorthHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/networth", func(r chi.Router) {
+		r.Get("/", h.Timeline)
+		r.Post("/snapshots", h.Capture)
+		r.Post("/backfill", h.Backfill)
+	})
+}
+
+// Timeline from부터 to까지(기본값 최근 30일) 날마다의 순자산을 조회합니다.
+func (h *NetWorthHandler) Timeline(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	to := networth.Day(h.now())
+	from := to.AddDate(0, 0, -(defaultNetWorthDays - 1))
+	query := r.URL.Query()
+	if value := query.Get("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "to는 YYYY-MM-DD 형식이어야 합니다")
+			return
+		}
+		to = parsed
+		from = to.AddDate(0, 0, -(defaultNetWorthDays - 1))
+	}
+	if value := query.Get("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "from은 YYYY-MM-DD 형식이어야 합니다")
+			return
+		}
+		from = parsed
+	}
+
+	snapshots, err := h.tracker.Timeline(r.Context(), userID, from, to)
+	if err != nil {
+		respondNetWorthError(w, err)
+		return
+	}
+	response := NetWorthTimelineResponse{
+		From:   networth.DateKey(from),
+		To:     networth.DateKey(to),
+		Points: make([]NetWorthPointResponse, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		response.Points[i] = newNetWorthPointResponse(snapshot)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Capture 현재 자산과 부채로 오늘 스냅샷을 기록합니다.
+func (h *NetWorthHandler) Capture(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	snapshot, err := h.tracker.Capture(r.Context(), userID)
+	if err != nil {
+		respondNetWorthError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, newNetWorthPointResponse(snapshot))
+}
+
+// Backfill from부터 어제까지 스냅샷이 없는 날을 거래 기록으로 채웁니다.
+func (h *NetWorthHandler) Backfill(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req BackfillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식입니다")
+		return
+	}
+	from, err := time.Parse("2006-01-02", req.From)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrValidation, "from은 YYYY-MM-DD 형식이어야 합니다")
+		return
+	}
+
+	written, err := h.tracker.Backfill(r.Context(), userID, from)
+	if err != nil {
+		respondNetWorthError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, BackfillResponse{Written: written})
+}
+
+func respondNetWorthError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) && domainErr.Code() == domain.ErrCodeInvalidArgument {
+		respondError(w, http.StatusBadRequest, ErrValidation, domainErr.Error())
+		return
+	}
+	log.Printf("순자산 처리 실패: %v", err)
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "순자산 처리 중 오류가 발생했습니다")
+}
+
+func newNetWorthPointResponse(s *networth.Snapshot) NetWorthPointResponse {
+	lines := make([]NetWorthLineResponse, len(s.ByType))
+	for i, line := range s.ByType {
+		lines[i] = NetWorthLineResponse{Type: line.Type, Currency: line.Currency, Amount: line.Amount, Liability: line.Liability}
+	}
+	return NetWorthPointResponse{
+		Date:        networth.DateKey(s.Date),
+		Assets:      s.Assets,
+		Liabilities: s.Liabilities,
+		NetWorth:    s.NetWorth,
+		ByType:      lines,
+		Backfilled:  s.Backfilled,
+	}
+}
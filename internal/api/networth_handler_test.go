@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/networth"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+func TestNetWorth(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	tracker := networth.NewTracker(networth.NewMemoryRepository(), assets, transactions, networth.WithClock(func() time.Time { return now }))
+
+	bus := memory.NewEventBus()
+	require.NoError(t, bus.Subscribe(tracker))
+	r := chi.NewRouter()
+	NewHandler(assets, transactions, asset.NewMemoryPortfolioRepository(), nil, WithEventBus(bus)).RegisterRoutes(r)
+	handler := NewNetWorthHandler(tracker)
+	handler.now = func() time.Time { return now }
+	handler.RegisterRoutes(r)
+
+	t.Run("자산 생성과 수정이 오늘 스냅샷에 반영됨", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/assets", "", CreateAssetRequest{UserID: "user-1", Type: "CASH", Name: "입출금", Amount: 100000, Currency: "KRW"})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created AssetResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+
+		w = doAs(t, r, http.MethodPut, "/assets/"+created.ID, "", UpdateAssetRequest{Amount: 250000})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doAs(t, r, http.MethodGet, "/networth", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response NetWorthTimelineResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "2024-02-10", response.From)
+		require.Len(t, response.Points, 1)
+		assert.Equal(t, "2024-03-10", response.Points[0].Date)
+		assert.Equal(t, 250000.0, response.Points[0].NetWorth["KRW"])
+		require.Len(t, response.Points[0].ByType, 1)
+		assert.Equal(t, "CASH", response.Points[0].ByType[0].Type)
+	})
+
+	t.Run("스냅샷 기록과 되돌려 채우기", func(t *testing.T) {
+		owned, err := assets.FindByUserID(context.Background(), "user-1")
+		require.NoError(t, err)
+		owned[0].CreatedAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, assets.Update(context.Background(), owned[0]))
+
+		w := doAs(t, r, http.MethodPost, "/networth/snapshots", "user-1", nil)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = doAs(t, r, http.MethodPost, "/networth/backfill", "user-1", BackfillRequest{From: "2024-03-01"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var backfill BackfillResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&backfill))
+		assert.Equal(t, 9, backfill.Written)
+
+		w = doAs(t, r, http.MethodGet, "/networth?from=2024-03-01&to=2024-03-10", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response NetWorthTimelineResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		require.Len(t, response.Points, 10)
+		assert.True(t, response.Points[0].Backfilled)
+		assert.False(t, response.Points[9].Backfilled)
+	})
+
+	t.Run("잘못된 요청", func(t *testing.T) {
+		for _, path := range []string{
+			"/networth?from=2024/03/01",
+			"/networth?from=2024-03-10&to=2024-03-01",
+		} {
+			w := doAs(t, r, http.MethodGet, path, "user-1", nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		}
+		w := doAs(t, r, http.MethodPost, "/networth/backfill", "user-1", BackfillRequest{From: "1990-01-01"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = doAs(t, r, http.MethodGet, "/networth", "", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
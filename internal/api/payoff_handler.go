@@ -0,0 +1,259 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/payoff"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// PayoffRequest 부채 상환 계획 요청
+// strategy는 SNOWBALL, AVALANCHE, CUSTOM 중 하나이며 CUSTOM은 order의 부채 자산 ID 순서로 갚습니다.
+type PayoffRequest struct {
+	Budget   float64  `json:"budget"`
+	Currency string   `json:"currency"`
+	Strategy string   `json:"strategy"`
+	Order    []string `json:"order,omitempty"`
+}
+
+// PayoffPaymentResponse 부채별 월 상환금 응답
+type PayoffPaymentResponse struct {
+	AssetID   string  `json:"assetId"`
+	Payment   float64 `json:"payment"`
+	Interest  float64 `json:"interest"`
+	Principal float64 `json:"principal"`
+	Balance   float64 `json:"balance"`
+}
+
+// PayoffMonthResponse 월별 상환 내역 응답
+type PayoffMonthResponse struct {
+	Month    int                     `json:"month"`
+	Date     string                  `json:"date"`
+	Payment  float64                 `json:"payment"`
+	Interest float64                 `json:"interest"`
+	Balance  float64                 `json:"balance"`
+	Payments []PayoffPaymentResponse `json:"payments"`
+}
+
+// PayoffDebtResponse 계획에 포함된 부채 응답
+type PayoffDebtResponse struct {
+	AssetID    string  `json:"assetId"`
+	Name       string  `json:"name"`
+	Type       string  `json:"type"`
+	Balance    float64 `json:"balance"`
+	AnnualRate float64 `json:"annualRate"`
+	Minimum    float64 `json:"minimum"`
+	Interest   float64 `json:"interest"`
+	PaidOffAt  string  `json:"paidOffAt"`
+}
+
+// DebtFreeGoalResponse 빚 청산 목표 응답
+type DebtFreeGoalResponse struct {
+	AssetID  string    `json:"assetId"`
+	GoalID   string    `json:"goalId"`
+	Progress float64   `json:"progress"`
+	Deadline time.Time `json:"deadline"`
+	OnTrack  bool      `json:"onTrack"`
+}
+
+// PayoffPlanResponse 부채 상환 계획 응답
+type PayoffPlanResponse struct {
+	Strategy      string                 `json:"strategy"`
+	Budget        float64                `json:"budget"`
+	Currency      string                 `json:"currency"`
+	Months        int                    `json:"months"`
+	PayoffDate    string                 `json:"payoffDate"`
+	TotalPayment  float64                `json:"totalPayment"`
+	TotalInterest float64                `json:"totalInterest"`
+	Debts         []PayoffDebtResponse   `json:"debts"`
+	Goals         []DebtFreeGoalResponse `json:"goals"`
+	Excluded      []string               `json:"excluded"`
+	Schedule      []PayoffMonthResponse  `json:"schedule,omitempty"`
+}
+
+// CreateDebtFreeGoalRequest 빚 청산 목표 생성 요청
+// 부채 자산에 두면 그 부채의, 그 밖의 자산에 두면 같은 통화 부채 전체의 상환 비율이 진행률이 됩니다.
+type CreateDebtFreeGoalRequest struct {
+	AssetID  string    `json:"assetId"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// PayoffHandler 부채 상환 계획 API 핸들러입니다.
+// 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리합니다.
+type PayoffHandler struct {
+	planner   *payoff.Planner
+	assetRepo asset.Repository
+}
+
+// NewPayoffHandler 새로운 부채 상환 계획 API 핸들러를 생성합니다.
+func NewPayoffHandler(planner *payoff.Planner, assetRepo asset.Repository) *PayoffHandler {
+	return &PayoffHandler{planner: planner, assetRepo: assetRepo}
+}
+
+// RegisterRoutes 라우터에 부채 상환 계획 API를 등록합니다.
+func (h *PayoffHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/payoff", func(r chi.Router) {
+		r.Post("/plan", h.Plan)
+		r.Post("/compare", h.Compare)
+		r.Post("/goals", h.CreateGoal)
+	})
+}
+
+// Plan 월 예산과 전략으로 부채를 모두 갚는 월별 상환 계획을 세웁니다.
+func (h *PayoffHandler) Plan(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+	plan, err := h.planner.Plan(r.Context(), req)
+	if err != nil {
+		respondPayoffError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newPayoffPlanResponse(plan, true))
+}
+
+// Compare 스노볼, 애벌랜치, (순서를 지정했다면) 사용자 지정 전략의 상환 완료일과 총 이자를 비교합니다.
+func (h *PayoffHandler) Compare(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+	plans, err := h.planner.Compare(r.Context(), req)
+	if err != nil {
+		respondPayoffError(w, err)
+		return
+	}
+	response := make([]PayoffPlanResponse, len(plans))
+	for i, plan := range plans {
+		response[i] = newPayoffPlanResponse(plan, false)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// CreateGoal 자산에 빚 청산 목표를 추가하고 현재 상환 현황으로 진행률을 계산합니다.
+func (h *PayoffHandler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req CreateDebtFreeGoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+	owner, err := h.assetRepo.FindByID(r.Context(), req.AssetID)
+	if err != nil || owner.UserID != userID {
+		respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
+		return
+	}
+
+	goal := owner.AddGoal(asset.GoalTypeDebtFree, asset.Money{Currency: owner.Amount.Currency}, req.Deadline)
+	if err := h.assetRepo.Update(r.Context(), owner); err != nil {
+		respondUpdateError(w, err, "목표 저장 실패")
+		return
+	}
+	if _, err := h.planner.SyncGoals(r.Context(), userID); err != nil {
+		respondPayoffError(w, err)
+		return
+	}
+	saved, err := h.assetRepo.FindByID(r.Context(), owner.ID)
+	if err != nil {
+		respondPayoffError(w, err)
+		return
+	}
+	for _, g := range saved.Goals {
+		if g.ID == goal.ID {
+			goal = g
+		}
+	}
+	respondJSON(w, http.StatusCreated, DebtFreeGoalResponse{
+		AssetID:  owner.ID,
+		GoalID:   goal.ID,
+		Progress: goal.Progress,
+		Deadline: goal.Deadline,
+	})
+}
+
+func (h *PayoffHandler) decode(w http.ResponseWriter, r *http.Request) (payoff.Request, bool) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return payoff.Request{}, false
+	}
+	var req PayoffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return payoff.Request{}, false
+	}
+	return payoff.Request{
+		UserID:   userID,
+		Budget:   asset.Money{Amount: req.Budget, Currency: req.Currency},
+		Strategy: payoff.Strategy(req.Strategy),
+		Order:    req.Order,
+	}, true
+}
+
+func respondPayoffError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) && domainErr.Code() == domain.ErrCodeInvalidArgument {
+		respondError(w, http.StatusBadRequest, ErrValidation, domainErr.Error())
+		return
+	}
+	log.Printf("상환 계획 처리 실패: %v", err)
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "상환 계획 처리 중 오류가 발생했습니다")
+}
+
+func newPayoffPlanResponse(plan *payoff.Plan, withSchedule bool) PayoffPlanResponse {
+	response := PayoffPlanResponse{
+		Strategy:      string(plan.Strategy),
+		Budget:        plan.Budget.Amount,
+		Currency:      plan.Budget.Currency,
+		Months:        len(plan.Months),
+		PayoffDate:    plan.PayoffDate.Format("2006-01-02"),
+		TotalPayment:  plan.TotalPayment,
+		TotalInterest: plan.TotalInterest,
+		Debts:         make([]PayoffDebtResponse, len(plan.Debts)),
+		Goals:         make([]DebtFreeGoalResponse, len(plan.Goals)),
+		Excluded:      append([]string{}, plan.Excluded...),
+	}
+	for i, d := range plan.Debts {
+		response.Debts[i] = PayoffDebtResponse{
+			AssetID:    d.AssetID,
+			Name:       d.Name,
+			Type:       string(d.Type),
+			Balance:    d.Balance,
+			AnnualRate: d.AnnualRate,
+			Minimum:    d.Minimum,
+			Interest:   d.Interest,
+			PaidOffAt:  d.PaidOffAt.Format("2006-01-02"),
+		}
+	}
+	for i, g := range plan.Goals {
+		response.Goals[i] = DebtFreeGoalResponse{AssetID: g.AssetID, GoalID: g.GoalID, Progress: g.Progress, Deadline: g.Deadline, OnTrack: g.OnTrack}
+	}
+	if !withSchedule {
+		return response
+	}
+	response.Schedule = make([]PayoffMonthResponse, len(plan.Months))
+	for i, m := range plan.Months {
+		month := PayoffMonthResponse{
+			Month:    m.Number,
+			Date:     m.Date.Format("2006-01-02"),
+			Payment:  m.Payment,
+			Interest: m.Interest,
+			Balance:  m.Balance,
+			Payments: make([]PayoffPaymentResponse, len(m.Payments)),
+		}
+		for j, p := range m.Payments {
+			month.Payments[j] = PayoffPaymentResponse{AssetID: p.AssetID, Payment: p.Payment, Interest: p.Interest, Principal: p.Principal, Balance: p.Balance}
+		}
+		response.Schedule[i] = month
+	}
+	return response
+}
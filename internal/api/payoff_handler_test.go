@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/payoff"
+)
+
+func TestPayoff(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	assets := asset.NewMemoryAssetRepository()
+	for _, d := range []struct {
+		kind      asset.Type
+		name      string
+		principal float64
+		rate      float64
+	}{
+		{asset.CreditCard, "신용카드", 1500000, 18},
+		{asset.Loan, "학자금 대출", 1000000, 3},
+	} {
+		terms, err := asset.NewLiability(asset.Money{Amount: d.principal, Currency: "KRW"}, d.rate, 0, asset.Monthly, asset.EqualInstallment, start)
+		require.NoError(t, err)
+		a, err := asset.NewLiabilityAsset("user-1", d.kind, d.name, terms)
+		require.NoError(t, err)
+		require.NoError(t, assets.Save(context.Background(), a))
+	}
+	cash, err := asset.NewAsset("user-1", asset.Cash, "입출금", 500000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), cash))
+
+	planner := payoff.NewPlanner(assets, payoff.WithClock(func() time.Time { return start }))
+	r := chi.NewRouter()
+	NewPayoffHandler(planner, assets).RegisterRoutes(r)
+
+	t.Run("애벌랜치 상환 계획", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/payoff/plan", "user-1", PayoffRequest{Budget: 200000, Currency: "KRW", Strategy: "AVALANCHE"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var plan PayoffPlanResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&plan))
+		require.Len(t, plan.Debts, 2)
+		assert.Equal(t, "신용카드", plan.Debts[0].Name)
+		assert.Equal(t, plan.Months, len(plan.Schedule))
+		assert.Equal(t, plan.Schedule[len(plan.Schedule)-1].Date, plan.PayoffDate)
+		assert.Positive(t, plan.TotalInterest)
+	})
+
+	t.Run("전략 비교", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/payoff/compare", "user-1", PayoffRequest{Budget: 200000, Currency: "KRW"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var plans []PayoffPlanResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&plans))
+		require.Len(t, plans, 2)
+		assert.Equal(t, "SNOWBALL", plans[0].Strategy)
+		assert.Equal(t, "학자금 대출", plans[0].Debts[0].Name)
+		assert.LessOrEqual(t, plans[1].TotalInterest, plans[0].TotalInterest)
+		assert.Empty(t, plans[0].Schedule)
+	})
+
+	t.Run("예산 부족", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/payoff/plan", "user-1", PayoffRequest{Budget: 1000, Currency: "KRW", Strategy: "SNOWBALL"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("빚 청산 목표 등록", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/payoff/goals", "user-1", CreateDebtFreeGoalRequest{AssetID: cash.ID, Deadline: start.AddDate(3, 0, 0)})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var goal DebtFreeGoalResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&goal))
+		assert.NotEmpty(t, goal.GoalID)
+		assert.Zero(t, goal.Progress)
+
+		w = doAs(t, r, http.MethodPost, "/payoff/plan", "user-1", PayoffRequest{Budget: 200000, Currency: "KRW", Strategy: "AVALANCHE"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var plan PayoffPlanResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&plan))
+		require.Len(t, plan.Goals, 1)
+		assert.True(t, plan.Goals[0].OnTrack)
+	})
+
+	t.Run("다른 사용자의 자산", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/payoff/goals", "user-2", CreateDebtFreeGoalRequest{AssetID: cash.ID, Deadline: start})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("사용자 없음", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/payoff/plan", "", PayoffRequest{Budget: 200000, Currency: "KRW", Strategy: "SNOWBALL"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// HoldingRequest 보유 포지션 설정 요청
+// 원가의 통화는 자산의 통화를 따릅니다.
+type HoldingRequest struct {
+	Symbol    string  `json:"symbol"`
+	Quantity  float64 `json:"quantity"`
+	CostBasis float64 `json:"costBasis"`
+}
+
+// HoldingResponse 보유 포지션 응답
+type HoldingResponse struct {
+	AssetID   string     `json:"assetId"`
+	Symbol    string     `json:"symbol"`
+	Quantity  float64    `json:"quantity"`
+	CostBasis float64    `json:"costBasis"`
+	Price     float64    `json:"price,omitempty"`
+	PricedAt  *time.Time `json:"pricedAt,omitempty"`
+	Amount    float64    `json:"amount"`
+	Currency  string     `json:"currency"`
+}
+
+// PriceRequest 시장 가격 요청
+// 시각을 생략하면 요청을 받은 시각의 가격으로 봅니다.
+type PriceRequest struct {
+	Symbol    string    `json:"symbol"`
+	Price     float64   `json:"price"`
+	Currency  string    `json:"currency"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PriceHandler 자산의 보유 포지션과 시장 가격 API 핸들러입니다.
+// 시장 가격은 price.updated 이벤트로 발행되며, 같은 심볼을 보유한 자산의 재평가는 구독 중인 Revaluer가 수행합니다.
+type PriceHandler struct {
+	assetRepo asset.Repository
+	bus       event.Bus
+	now       func() time.Time
+}
+
+// NewPriceHandler 새로운 가격 API 핸들러를 생성합니다.
+func NewPriceHandler(assetRepo asset.Repository, bus event.Bus) *PriceHandler {
+	return &PriceHandler{assetRepo: assetRepo, bus: bus, now: time.Now}
+}
+
+// RegisterRoutes 라우터에 가격 API를 등록합니다.
+func (h *PriceHandler) RegisterRoutes(r chi.Router) {
+	r.Put("/assets/{id}/holding", h.SetHolding)
+	r.Post("/prices", h.PublishPrices)
+}
+
+// SetHolding 자산의 보유 포지션을 설정하고 평가 금액을 다시 계산합니다.
+func (h *PriceHandler) SetHolding(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req HoldingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	target, err := h.assetRepo.FindByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil || target.UserID != userID {
+		respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
+		return
+	}
+	holding, err := asset.NewHolding(req.Symbol, req.Quantity, asset.Money{Amount: req.CostBasis, Currency: target.Amount.Currency})
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
+		return
+	}
+
+	target.ClearEvents()
+	if err := target.SetHolding(holding); err != nil {
+		respondError(w, http.StatusBadRequest, ErrValidation, err.Error())
+		return
+	}
+	if err := h.assetRepo.Update(r.Context(), target); err != nil {
+		respondUpdateError(w, err, "보유 포지션 반영 실패")
+		return
+	}
+	h.publish(r, target.GetUncommittedEvents())
+	target.ClearEvents()
+
+	respondJSON(w, http.StatusOK, newHoldingResponse(target))
+}
+
+// PublishPrices 시장 가격 목록을 검증한 뒤 가격 이벤트로 발행합니다.
+// 이벤트를 모두 발행하면 202를 반환합니다.
+func (h *PriceHandler) PublishPrices(w http.ResponseWriter, r *http.Request) {
+	var reqs []PriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	priceEvents := make([]event.Event, 0, len(reqs))
+	for _, req := range reqs {
+		symbol := strings.TrimSpace(req.Symbol)
+		if symbol == "" {
+			respondError(w, http.StatusBadRequest, ErrValidation, "심볼이 필요합니다")
+			return
+		}
+		if req.Price < 0 || req.Currency == "" {
+			respondError(w, http.StatusBadRequest, ErrValidation, "가격과 통화가 올바르지 않습니다: "+symbol)
+			return
+		}
+		pricedAt := req.Timestamp
+		if pricedAt.IsZero() {
+			pricedAt = h.now()
+		}
+		priceEvents = append(priceEvents, asset.NewPriceUpdatedEvent(symbol, asset.Money{Amount: req.Price, Currency: req.Currency}, pricedAt))
+	}
+
+	if h.bus == nil {
+		respondError(w, http.StatusServiceUnavailable, ErrInternalServer, "가격 이벤트를 발행할 수 없습니다")
+		return
+	}
+	for _, evt := range priceEvents {
+		if err := h.bus.Publish(r.Context(), evt); err != nil {
+			respondError(w, http.StatusInternalServerError, ErrInternalServer, "가격 반영 실패")
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusAccepted, nil)
+}
+
+func (h *PriceHandler) publish(r *http.Request, events []event.Event) {
+	if h.bus == nil {
+		return
+	}
+	for _, evt := range events {
+		if err := h.bus.Publish(r.Context(), evt); err != nil {
+			log.Printf("이벤트 발행 실패(%s): %v", evt.AggregateID(), err)
+		}
+	}
+}
+
+func newHoldingResponse(a *asset.Asset) HoldingResponse {
+	response := HoldingResponse{
+		AssetID:  a.ID,
+		Amount:   a.Amount.Amount,
+		Currency: a.Amount.Currency,
+	}
+	if holding := a.Holding; holding != nil {
+		response.Symbol = holding.Symbol
+		response.Quantity = holding.Quantity
+		response.CostBasis = holding.CostBasis.Amount
+		if holding.IsPriced() {
+			pricedAt := holding.PricedAt
+			response.Price = holding.Price.Amount
+			response.PricedAt = &pricedAt
+		}
+	}
+	return response
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/networth"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+func TestPrices(t *testing.T) {
+	now := time.Date(2024, 4, 20, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	assets := asset.NewMemoryAssetRepository()
+	stock, err := asset.NewAsset("user-1", asset.Stock, "테슬라", 1000, "USD")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(ctx, stock))
+
+	tracker := networth.NewTracker(networth.NewMemoryRepository(), assets, asset.NewMemoryTransactionRepository(), networth.WithClock(func() time.Time { return now }))
+	bus := memory.NewEventBus()
+	require.NoError(t, bus.Subscribe(asset.NewRevaluer(assets, bus)))
+	require.NoError(t, bus.Subscribe(tracker))
+
+	handler := NewPriceHandler(assets, bus)
+	handler.now = func() time.Time { return now }
+	r := chi.NewRouter()
+	handler.RegisterRoutes(r)
+
+	t.Run("보유 포지션 설정", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPut, "/assets/"+stock.ID+"/holding", "user-1", HoldingRequest{Symbol: "TSLA", Quantity: 10, CostBasis: 1000})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var holding HoldingResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&holding))
+		assert.Equal(t, "TSLA", holding.Symbol)
+		assert.Equal(t, 1000.0, holding.Amount)
+		assert.Nil(t, holding.PricedAt)
+	})
+
+	t.Run("다른 사용자의 자산", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPut, "/assets/"+stock.ID+"/holding", "user-2", HoldingRequest{Symbol: "TSLA", Quantity: 1, CostBasis: 100})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("가격 갱신으로 재평가", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/prices", "", []PriceRequest{{Symbol: "TSLA", Price: 120, Currency: "USD"}})
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+		found, err := assets.FindByID(ctx, stock.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1200.0, found.Amount.Amount)
+		require.NotNil(t, found.Holding)
+		assert.Equal(t, now, found.Holding.PricedAt)
+
+		snapshot, err := tracker.Timeline(ctx, "user-1", now, now)
+		require.NoError(t, err)
+		require.Len(t, snapshot, 1)
+		assert.Equal(t, 1200.0, snapshot[0].NetWorth["USD"])
+	})
+
+	t.Run("잘못된 가격", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/prices", "", []PriceRequest{{Symbol: " ", Price: 120, Currency: "USD"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/rebalance"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// TargetWeight 포트폴리오 자산의 목표 비중(%)
+type TargetWeight struct {
+	AssetID string  `json:"assetId"`
+	Weight  float64 `json:"weight"`
+}
+
+// TargetsRequest 목표 비중 설정 요청
+type TargetsRequest struct {
+	Targets []TargetWeight `json:"targets"`
+}
+
+// TargetsResponse 목표 비중 응답
+type TargetsResponse struct {
+	PortfolioID string         `json:"portfolioId"`
+	Targets     []TargetWeight `json:"targets"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// RebalanceRequest 리밸런싱 요청
+// tolerance는 허용 오차(%p), lot은 기본 매매 단위, lots는 심볼별 매매 단위입니다.
+// fundingAssetId는 실행할 때 투입 현금을 내고 매도 대금을 받는 자금 자산으로, 실행에는 필수입니다.
+type RebalanceRequest struct {
+	Tolerance      float64            `json:"tolerance"`
+	Inflow         float64            `json:"inflow"`
+	CashOnly       bool               `json:"cashOnly"`
+	MinTrade       float64            `json:"minTrade"`
+	Lot            float64            `json:"lot"`
+	Lots           map[string]float64 `json:"lots,omitempty"`
+	FundingAssetID string             `json:"fundingAssetId,omitempty"`
+}
+
+// RebalancePositionResponse 자산별 리밸런싱 전후 비중 응답
+type RebalancePositionResponse struct {
+	AssetID string  `json:"assetId"`
+	Name    string  `json:"name"`
+	Symbol  string  `json:"symbol,omitempty"`
+	Value   float64 `json:"value"`
+	Weight  float64 `json:"weight"`
+	Target  float64 `json:"target"`
+	Drift   float64 `json:"drift"`
+	Result  float64 `json:"result"`
+}
+
+// TradeResponse 매매 응답
+type TradeResponse struct {
+	AssetID  string  `json:"assetId"`
+	Symbol   string  `json:"symbol,omitempty"`
+	Side     string  `json:"side"`
+	Amount   float64 `json:"amount"`
+	Quantity float64 `json:"quantity,omitempty"`
+	Price    float64 `json:"price,omitempty"`
+}
+
+// RebalancePlanResponse 리밸런싱 계획 응답
+type RebalancePlanResponse struct {
+	PortfolioID    string                      `json:"portfolioId"`
+	Currency       string                      `json:"currency"`
+	Invested       float64                     `json:"invested"`
+	Inflow         float64                     `json:"inflow"`
+	Cash           float64                     `json:"cash"`
+	MaxDrift       float64                     `json:"maxDrift"`
+	WithinBand     bool                        `json:"withinBand"`
+	Positions      []RebalancePositionResponse `json:"positions"`
+	Trades         []TradeResponse             `json:"trades"`
+	FundingAssetID string                      `json:"fundingAssetId,omitempty"`
+	TransactionIDs []string                    `json:"transactionIds,omitempty"`
+}
+
+// RebalanceHandler 포트폴리오 목표 비중과 리밸런싱 API 핸들러입니다.
+// 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리합니다.
+type RebalanceHandler struct {
+	rebalancer *rebalance.Rebalancer
+}
+
+// NewRebalanceHandler 새로운 리밸런싱 API 핸들러를 생성합니다.
+func NewRebalanceHandler(rebalancer *rebalance.Rebalancer) *RebalanceHandler {
+	return &RebalanceHandler{rebalancer: rebalancer}
+}
+
+// RegisterRoutes 라우터에 리밸런싱 API를 등록합니다.
+func (h *RebalanceHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/rebalance", func(r chi.Router) {
+		r.Post("/", h.Rebalance)
+		r.Post("/plan", h.Plan)
+		r.Get("/targets", h.GetTargets)
+		r.Put("/targets", h.SetTargets)
+	})
+}
+
+// GetTargets 포트폴리오의 목표 비중을 조회합니다.
+func (h *RebalanceHandler) GetTargets(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	portfolio, err := h.rebalancer.Targets(r.Context(), userID)
+	if err != nil {
+		respondRebalanceError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newTargetsResponse(portfolio))
+}
+
+// SetTargets 포트폴리오의 목표 비중을 설정합니다. 포트폴리오가 없으면 새로 만듭니다.
+func (h *RebalanceHandler) SetTargets(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	var req TargetsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+	targets := make([]asset.PortfolioAsset, len(req.Targets))
+	for i, target := range req.Targets {
+		targets[i] = asset.PortfolioAsset{AssetID: target.AssetID, Weight: target.Weight}
+	}
+	portfolio, err := h.rebalancer.SetTargets(r.Context(), userID, targets)
+	if err != nil {
+		respondRebalanceError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newTargetsResponse(portfolio))
+}
+
+// Plan 자산을 바꾸지 않고 리밸런싱 매매 목록을 계산합니다.
+func (h *RebalanceHandler) Plan(w http.ResponseWriter, r *http.Request) {
+	userID, opts, ok := decodeRebalanceRequest(w, r)
+	if !ok {
+		return
+	}
+	plan, err := h.rebalancer.Plan(r.Context(), userID, opts)
+	if err != nil {
+		respondRebalanceError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newRebalancePlanResponse(plan))
+}
+
+// Rebalance 리밸런싱 매매를 자금 자산과의 이체 거래로 기록해 자산에 반영하고 portfolio.rebalanced 이벤트를 발행합니다.
+func (h *RebalanceHandler) Rebalance(w http.ResponseWriter, r *http.Request) {
+	userID, opts, ok := decodeRebalanceRequest(w, r)
+	if !ok {
+		return
+	}
+	plan, err := h.rebalancer.Rebalance(r.Context(), userID, opts)
+	if err != nil {
+		respondRebalanceError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newRebalancePlanResponse(plan))
+}
+
+func decodeRebalanceRequest(w http.ResponseWriter, r *http.Request) (string, rebalance.Options, bool) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return "", rebalance.Options{}, false
+	}
+	var req RebalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return "", rebalance.Options{}, false
+	}
+	return userID, rebalance.Options{
+		Tolerance:      req.Tolerance,
+		Inflow:         req.Inflow,
+		CashOnly:       req.CashOnly,
+		MinTrade:       req.MinTrade,
+		DefaultLot:     req.Lot,
+		Lots:           req.Lots,
+		FundingAssetID: req.FundingAssetID,
+	}, true
+}
+
+func respondRebalanceError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code() {
+		case domain.ErrCodeInvalidArgument:
+			respondError(w, http.StatusBadRequest, ErrValidation, domainErr.Error())
+			return
+		case domain.ErrCodeNotFound:
+			respondError(w, http.StatusNotFound, ErrNotFound, "포트폴리오를 찾을 수 없습니다")
+			return
+		}
+	}
+	log.Printf("리밸런싱 처리 실패: %v", err)
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "리밸런싱 처리 중 오류가 발생했습니다")
+}
+
+func newTargetsResponse(portfolio *asset.Portfolio) TargetsResponse {
+	response := TargetsResponse{
+		PortfolioID: portfolio.ID,
+		Targets:     make([]TargetWeight, len(portfolio.Assets)),
+		UpdatedAt:   portfolio.UpdatedAt,
+	}
+	for i, target := range portfolio.Assets {
+		response.Targets[i] = TargetWeight{AssetID: target.AssetID, Weight: target.Weight}
+	}
+	return response
+}
+
+func newRebalancePlanResponse(plan *rebalance.Plan) RebalancePlanResponse {
+	response := RebalancePlanResponse{
+		PortfolioID:    plan.PortfolioID,
+		Currency:       plan.Currency,
+		Invested:       plan.Invested,
+		Inflow:         plan.Inflow,
+		Cash:           plan.Cash,
+		MaxDrift:       plan.MaxDrift,
+		WithinBand:     plan.WithinBand,
+		Positions:      make([]RebalancePositionResponse, len(plan.Positions)),
+		Trades:         make([]TradeResponse, len(plan.Trades)),
+		FundingAssetID: plan.FundingAssetID,
+		TransactionIDs: plan.TransactionIDs,
+	}
+	for i, p := range plan.Positions {
+		response.Positions[i] = RebalancePositionResponse{
+			AssetID: p.AssetID,
+			Name:    p.Name,
+			Symbol:  p.Symbol,
+			Value:   p.Value,
+			Weight:  p.Weight,
+			Target:  p.Target,
+			Drift:   p.Drift,
+			Result:  p.Result,
+		}
+	}
+	for i, t := range plan.Trades {
+		response.Trades[i] = TradeResponse{
+			AssetID:  t.AssetID,
+			Symbol:   t.Symbol,
+			Side:     string(t.Side),
+			Amount:   t.Amount,
+			Quantity: t.Quantity,
+			Price:    t.Price,
+		}
+	}
+	return response
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/rebalance"
+)
+
+func TestRebalance(t *testing.T) {
+	assets := asset.NewMemoryAssetRepository()
+	stocks, err := asset.NewAsset("user-1", asset.Stock, "주식", 70000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), stocks))
+	bonds, err := asset.NewAsset("user-1", asset.Bond, "채권", 30000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), bonds))
+	checking, err := asset.NewAsset("user-1", asset.Cash, "입출금", 10000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), checking))
+	transactions := asset.NewMemoryTransactionRepository()
+
+	r := chi.NewRouter()
+	NewRebalanceHandler(rebalance.NewRebalancer(asset.NewMemoryPortfolioRepository(), assets, transactions, nil)).RegisterRoutes(r)
+
+	t.Run("포트폴리오 없음", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/rebalance/targets", "user-1", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("목표 비중 설정", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPut, "/rebalance/targets", "user-1", TargetsRequest{Targets: []TargetWeight{
+			{AssetID: stocks.ID, Weight: 50}, {AssetID: bonds.ID, Weight: 50},
+		}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doAs(t, r, http.MethodGet, "/rebalance/targets", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var targets TargetsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&targets))
+		assert.Len(t, targets.Targets, 2)
+
+		w = doAs(t, r, http.MethodPut, "/rebalance/targets", "user-1", TargetsRequest{Targets: []TargetWeight{{AssetID: stocks.ID, Weight: 150}}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("리밸런싱 계획", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/rebalance/plan", "user-1", RebalanceRequest{Tolerance: 5})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var plan RebalancePlanResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&plan))
+		require.Len(t, plan.Trades, 2)
+		assert.Equal(t, "SELL", plan.Trades[0].Side)
+		assert.Equal(t, 20000.0, plan.Trades[0].Amount)
+
+		saved, err := assets.FindByID(context.Background(), stocks.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 70000.0, saved.Amount.Amount)
+	})
+
+	t.Run("자금 자산 없이 실행", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/rebalance", "user-1", RebalanceRequest{Tolerance: 5})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("리밸런싱 실행", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/rebalance", "user-1", RebalanceRequest{Tolerance: 5, Inflow: 4000, FundingAssetID: checking.ID})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var plan RebalancePlanResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&plan))
+		assert.Equal(t, checking.ID, plan.FundingAssetID)
+		require.Len(t, plan.TransactionIDs, 2)
+
+		saved, err := assets.FindByID(context.Background(), stocks.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 52000.0, saved.Amount.Amount)
+		funded, err := assets.FindByID(context.Background(), checking.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 6000.0, funded.Amount.Amount)
+		recorded, err := transactions.FindByAssetID(context.Background(), checking.ID)
+		require.NoError(t, err)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, bonds.ID, recorded[0].CounterAssetID)
+		assert.Equal(t, 22000.0, recorded[0].Amount.Amount)
+	})
+
+	t.Run("잘못된 설정", func(t *testing.T) {
+		w := doAs(t, r, http.MethodPost, "/rebalance/plan", "user-1", RebalanceRequest{Tolerance: -1})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/statement"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// ReconcileRequest 대사 작업 생성 요청
+// ClosingBalance는 파일에 기말 잔액이 없거나 다른 값을 쓰고 싶을 때 지정합니다.
+type ReconcileRequest struct {
+	ImportRequest
+	ClosingBalance *float64 `json:"closingBalance,omitempty"`
+}
+
+// ResolveItemRequest 대사 항목 처리 요청
+type ResolveItemRequest struct {
+	Action        string `json:"action"`
+	TransactionID string `json:"transactionId,omitempty"`
+}
+
+// MatchResponse 후보 거래 응답
+type MatchResponse struct {
+	TransactionID string    `json:"transactionId"`
+	Source        string    `json:"source"`
+	Date          time.Time `json:"date"`
+	Amount        float64   `json:"amount"`
+	Description   string    `json:"description,omitempty"`
+	Reconciled    bool      `json:"reconciled"`
+	Score         float64   `json:"score"`
+}
+
+// ReconcileItemResponse 대사 항목 응답
+type ReconcileItemResponse struct {
+	Line          int                  `json:"line"`
+	Status        string               `json:"status"`
+	TransactionID string               `json:"transactionId,omitempty"`
+	Record        ImportRecordResponse `json:"record"`
+	Suggestions   []MatchResponse      `json:"suggestions"`
+}
+
+// BalanceResponse 잔액 응답
+type BalanceResponse struct {
+	Amount float64   `json:"amount"`
+	AsOf   time.Time `json:"asOf"`
+}
+
+// ReconciliationResponse 대사 작업 응답
+type ReconciliationResponse struct {
+	ID              string                  `json:"id"`
+	Target          statement.Target        `json:"target"`
+	Reconciled      bool                    `json:"reconciled"`
+	Unmatched       int                     `json:"unmatched"`
+	Suggested       int                     `json:"suggested"`
+	Matched         int                     `json:"matched"`
+	Merged          int                     `json:"merged"`
+	Ignored         int                     `json:"ignored"`
+	ClosingBalance  *BalanceResponse        `json:"closingBalance,omitempty"`
+	ComputedBalance *float64                `json:"computedBalance,omitempty"`
+	Difference      *float64                `json:"difference,omitempty"`
+	Items           []ReconcileItemResponse `json:"items"`
+	Errors          []ImportRowResponse     `json:"errors"`
+	CreatedAt       time.Time               `json:"createdAt"`
+	UpdatedAt       time.Time               `json:"updatedAt"`
+}
+
+// ReconcileHandler 거래 내역 대사 API 핸들러입니다.
+type ReconcileHandler struct {
+	reconciler *statement.Reconciler
+}
+
+// NewReconcileHandler 새로운 거래 내역 대사 API 핸들러를 생성합니다.
+func NewReconcileHandler(reconciler *statement.Reconciler) *ReconcileHandler {
+	return &ReconcileHandler{reconciler: reconciler}
+}
+
+// RegisterRoutes 라우터에 거래 내역 대사 API를 등록합니다.
+func (h *ReconcileHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/reconciliations", func(r chi.Router) {
+		r.Post("/", h.Start)
+		r.Get("/{id}", h.Get)
+		r.Post("/{id}/items/{line}", h.Resolve)
+	})
+}
+
+// Start 거래 내역 파일로 대사 작업을 생성합니다.
+func (h *ReconcileHandler) Start(w http.ResponseWriter, r *http.Request) {
+	var body ReconcileRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+	req, ok := validateImportRequest(w, body.ImportRequest)
+	if !ok {
+		return
+	}
+
+	rec, err := h.reconciler.Start(r.Context(), statement.ReconcileRequest{Request: req, ClosingBalance: body.ClosingBalance})
+	if err != nil {
+		respondReconcileError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, newReconciliationResponse(rec))
+}
+
+// Get 대사 작업을 조회합니다.
+func (h *ReconcileHandler) Get(w http.ResponseWriter, r *http.Request) {
+	rec, err := h.reconciler.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondReconcileError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newReconciliationResponse(rec))
+}
+
+// Resolve 대사 항목을 처리합니다. action은 MATCH, MERGE, IGNORE 중 하나입니다.
+func (h *ReconcileHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	line, err := strconv.Atoi(chi.URLParam(r, "line"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "행 번호가 올바르지 않습니다")
+		return
+	}
+	var body ResolveItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	rec, err := h.reconciler.Resolve(r.Context(), chi.URLParam(r, "id"), line, statement.Action(body.Action), body.TransactionID)
+	if err != nil {
+		respondReconcileError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newReconciliationResponse(rec))
+}
+
+// respondReconcileError 도메인 에러 코드에 맞는 상태 코드로 응답합니다.
+func respondReconcileError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code() {
+		case domain.ErrCodeNotFound:
+			respondError(w, http.StatusNotFound, ErrNotFound, domainErr.Error())
+			return
+		case domain.ErrCodeInvalidArgument:
+			respondError(w, http.StatusBadRequest, ErrValidation, domainErr.Error())
+			return
+		case domain.ErrCodeInvalidOperation:
+			respondError(w, http.StatusConflict, ErrValidation, domainErr.Error())
+			return
+		}
+	}
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "대사 처리 중 오류가 발생했습니다")
+}
+
+func newReconciliationResponse(rec *statement.Reconciliation) ReconciliationResponse {
+	items := make([]ReconcileItemResponse, 0, len(rec.Items))
+	for _, item := range rec.Items {
+		suggestions := make([]MatchResponse, 0, len(item.Suggestions))
+		for _, m := range item.Suggestions {
+			suggestions = append(suggestions, MatchResponse{
+				TransactionID: m.Candidate.ID,
+				Source:        m.Candidate.Source,
+				Date:          m.Candidate.Date,
+				Amount:        m.Candidate.Amount,
+				Description:   m.Candidate.Description,
+				Reconciled:    m.Candidate.Reconciled,
+				Score:         m.Score,
+			})
+		}
+		items = append(items, ReconcileItemResponse{
+			Line:          item.Line,
+			Status:        string(item.Status),
+			TransactionID: item.TransactionID,
+			Record:        newImportRecordResponse(item.Record),
+			Suggestions:   suggestions,
+		})
+	}
+	rowErrors := make([]ImportRowResponse, 0, len(rec.Errors))
+	for _, e := range rec.Errors {
+		rowErrors = append(rowErrors, ImportRowResponse{Line: e.Line, Status: string(statement.RowInvalid), Error: e.Message})
+	}
+
+	response := ReconciliationResponse{
+		ID:              rec.ID,
+		Target:          rec.Target,
+		Reconciled:      rec.Reconciled(),
+		Unmatched:       rec.Count(statement.ItemUnmatched),
+		Suggested:       rec.Count(statement.ItemSuggested),
+		Matched:         rec.Count(statement.ItemMatched),
+		Merged:          rec.Count(statement.ItemMerged),
+		Ignored:         rec.Count(statement.ItemIgnored),
+		ComputedBalance: rec.ComputedBalance,
+		Items:           items,
+		Errors:          rowErrors,
+		CreatedAt:       rec.CreatedAt,
+		UpdatedAt:       rec.UpdatedAt,
+	}
+	if rec.ClosingBalance != nil {
+		response.ClosingBalance = &BalanceResponse{Amount: rec.ClosingBalance.Amount, AsOf: rec.ClosingBalance.AsOf}
+	}
+	if diff, ok := rec.Difference(); ok {
+		response.Difference = &diff
+	}
+	return response
+}
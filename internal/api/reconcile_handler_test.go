@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/statement"
+)
+
+func TestReconcileStatement(t *testing.T) {
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	account, err := asset.NewAsset("user-1", asset.Cash, "입출금", 10000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), account))
+	manual, err := asset.NewTransaction(account.ID, asset.Expense, asset.Money{Amount: 2000, Currency: "KRW"}, "식비", "편의점")
+	require.NoError(t, err)
+	require.NoError(t, transactions.Save(context.Background(), manual))
+
+	book := statement.NewAssetBook(assets, transactions)
+	r := chi.NewRouter()
+	NewReconcileHandler(statement.NewReconciler(book, statement.NewMemoryRegistry(), statement.NewMemoryReconciliationRepository())).RegisterRoutes(r)
+
+	send := func(method, path string, body interface{}) (*httptest.ResponseRecorder, ReconciliationResponse) {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewReader(payload)))
+		var response ReconciliationResponse
+		if w.Code < 300 {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		}
+		return w, response
+	}
+
+	closing := 8000.0
+	content := fmt.Sprintf("date,amount,payee\n%s,-2000,편의점\n", manual.Date.Format("2006-01-02"))
+	w, started := send(http.MethodPost, "/reconciliations", ReconcileRequest{
+		ImportRequest: ImportRequest{
+			Format:  "csv",
+			Content: content,
+			Options: statement.Options{Currency: "KRW", CSV: statement.CSVMapping{Date: "date", Amount: "amount", Payee: "payee"}},
+			Target:  statement.Target{AssetID: account.ID},
+		},
+		ClosingBalance: &closing,
+	})
+
+	t.Run("대사 작업 생성", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, started.Suggested)
+		require.Len(t, started.Items, 1)
+		require.Len(t, started.Items[0].Suggestions, 1)
+		assert.Equal(t, manual.ID, started.Items[0].Suggestions[0].TransactionID)
+		assert.False(t, started.Reconciled)
+		require.NotNil(t, started.Difference)
+	})
+
+	t.Run("후보 거래와 일치 처리", func(t *testing.T) {
+		w, resolved := send(http.MethodPost, fmt.Sprintf("/reconciliations/%s/items/2", started.ID), ResolveItemRequest{Action: "MATCH"})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "MATCHED", resolved.Items[0].Status)
+		saved, err := transactions.FindByID(context.Background(), manual.ID)
+		require.NoError(t, err)
+		assert.True(t, saved.Reconciled)
+	})
+
+	t.Run("대사 작업 조회", func(t *testing.T) {
+		w, found := send(http.MethodGet, "/reconciliations/"+started.ID, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, found.Matched)
+	})
+
+	t.Run("이미 처리한 항목", func(t *testing.T) {
+		w, _ := send(http.MethodPost, fmt.Sprintf("/reconciliations/%s/items/2", started.ID), ResolveItemRequest{Action: "IGNORE"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("잘못된 요청", func(t *testing.T) {
+		w, _ := send(http.MethodGet, "/reconciliations/missing", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w, _ = send(http.MethodPost, fmt.Sprintf("/reconciliations/%s/items/abc", started.ID), ResolveItemRequest{Action: "MATCH"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w, _ = send(http.MethodPost, fmt.Sprintf("/reconciliations/%s/items/2", started.ID), ResolveItemRequest{Action: "DELETE"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
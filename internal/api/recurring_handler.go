@@ -0,0 +1,298 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/recurring"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// upcomingOccurrences 정기 거래 응답에 포함하는 다가오는 회차 수입니다.
+const upcomingOccurrences = 5
+
+// CreateRecurrenceRequest 정기 거래 생성 요청
+// 금액의 통화는 자산의 통화를 따릅니다.
+type CreateRecurrenceRequest struct {
+	AssetID     string             `json:"assetId"`
+	Type        string             `json:"type"`
+	Amount      float64            `json:"amount"`
+	Category    string             `json:"category"`
+	Description string             `json:"description"`
+	Schedule    recurring.Schedule `json:"schedule"`
+	Start       time.Time          `json:"start"`
+	End         *time.Time         `json:"end,omitempty"`
+}
+
+// OccurrenceResponse 회차 응답
+type OccurrenceResponse struct {
+	Date          string             `json:"date"`
+	At            time.Time          `json:"at"`
+	Status        string             `json:"status"`
+	TransactionID string             `json:"transactionId,omitempty"`
+	Override      recurring.Override `json:"override"`
+}
+
+// RecurrenceResponse 정기 거래 응답
+type RecurrenceResponse struct {
+	ID            string               `json:"id"`
+	AssetID       string               `json:"assetId"`
+	Type          string               `json:"type"`
+	Amount        float64              `json:"amount"`
+	Currency      string               `json:"currency"`
+	Category      string               `json:"category"`
+	Description   string               `json:"description"`
+	Schedule      recurring.Schedule   `json:"schedule"`
+	Start         time.Time            `json:"start"`
+	End           *time.Time           `json:"end,omitempty"`
+	PostedThrough *time.Time           `json:"postedThrough,omitempty"`
+	Upcoming      []OccurrenceResponse `json:"upcoming"`
+	CreatedAt     time.Time            `json:"createdAt"`
+}
+
+// PostingResponse 회차 처리 결과 응답
+type PostingResponse struct {
+	RecurrenceID  string `json:"recurrenceId"`
+	Date          string `json:"date"`
+	Status        string `json:"status"`
+	TransactionID string `json:"transactionId,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// RunRecurrencesResponse 스케줄러 실행 결과 응답
+type RunRecurrencesResponse struct {
+	Posted   int               `json:"posted"`
+	Skipped  int               `json:"skipped"`
+	Failed   int               `json:"failed"`
+	Postings []PostingResponse `json:"postings"`
+}
+
+// 회차 상태
+const (
+	occurrenceScheduled = "SCHEDULED"
+	occurrencePosted    = "POSTED"
+	occurrenceSkipped   = "SKIPPED"
+)
+
+// RecurringHandler 정기 거래 API 핸들러입니다.
+type RecurringHandler struct {
+	scheduler *recurring.Scheduler
+	repo      recurring.Repository
+	assetRepo asset.Repository
+	now       func() time.Time
+}
+
+// NewRecurringHandler 새로운 정기 거래 API 핸들러를 생성합니다.
+func NewRecurringHandler(scheduler *recurring.Scheduler, repo recurring.Repository, assetRepo asset.Repository) *RecurringHandler {
+	return &RecurringHandler{scheduler: scheduler, repo: repo, assetRepo: assetRepo, now: time.Now}
+}
+
+// RegisterRoutes 라우터에 정기 거래 API를 등록합니다.
+func (h *RecurringHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/recurrences", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Post("/run", h.Run)
+		r.Get("/{id}", h.Get)
+		r.Delete("/{id}", h.Delete)
+		r.Get("/{id}/occurrences", h.ListOccurrences)
+		r.Put("/{id}/occurrences/{date}", h.UpdateOccurrence)
+	})
+}
+
+// List 자산의 정기 거래를 조회합니다.
+func (h *RecurringHandler) List(w http.ResponseWriter, r *http.Request) {
+	assetID := r.URL.Query().Get("assetId")
+	if assetID == "" {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "자산 ID가 필요합니다")
+		return
+	}
+	recurrences, err := h.repo.FindByAssetID(r.Context(), assetID)
+	if err != nil {
+		respondRecurringError(w, err)
+		return
+	}
+	response := make([]RecurrenceResponse, len(recurrences))
+	for i, rec := range recurrences {
+		response[i] = h.newRecurrenceResponse(rec)
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Create 자산에 정기 거래를 등록합니다. 시작일이 지난 회차는 다음 스케줄러 실행에서 기록됩니다.
+func (h *RecurringHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateRecurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	owner, err := h.assetRepo.FindByID(r.Context(), req.AssetID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
+		return
+	}
+	amount := asset.Money{Amount: req.Amount, Currency: owner.Amount.Currency}
+	rec, err := recurring.NewRecurrence(owner, asset.TransactionType(req.Type), amount, req.Category, req.Description, req.Schedule, req.Start, req.End)
+	if err != nil {
+		respondRecurringError(w, err)
+		return
+	}
+	if err := h.scheduler.Create(r.Context(), rec); err != nil {
+		respondRecurringError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, h.newRecurrenceResponse(rec))
+}
+
+// Get 정기 거래를 조회합니다.
+func (h *RecurringHandler) Get(w http.ResponseWriter, r *http.Request) {
+	rec, err := h.repo.FindByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondRecurringError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, h.newRecurrenceResponse(rec))
+}
+
+// Delete 정기 거래를 삭제합니다. 이미 기록된 거래는 남습니다.
+func (h *RecurringHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.scheduler.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		respondRecurringError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListOccurrences from~to(YYYY-MM-DD) 기간의 회차와 처리 상태를 조회합니다.
+func (h *RecurringHandler) ListOccurrences(w http.ResponseWriter, r *http.Request) {
+	rec, err := h.repo.FindByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondRecurringError(w, err)
+		return
+	}
+	from, err := time.ParseInLocation("2006-01-02", r.URL.Query().Get("from"), rec.Start.Location())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "from은 YYYY-MM-DD 형식이어야 합니다")
+		return
+	}
+	to, err := time.ParseInLocation("2006-01-02", r.URL.Query().Get("to"), rec.Start.Location())
+	if err != nil || to.Before(from) {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "to는 from 이후의 YYYY-MM-DD 형식이어야 합니다")
+		return
+	}
+	respondJSON(w, http.StatusOK, newOccurrenceResponses(rec, rec.Occurrences(from, to.AddDate(0, 0, 1).Add(-time.Nanosecond))))
+}
+
+// UpdateOccurrence 회차를 건너뛰거나 금액, 분류, 설명을 바꿉니다. 빈 요청은 변경 사항을 지웁니다.
+func (h *RecurringHandler) UpdateOccurrence(w http.ResponseWriter, r *http.Request) {
+	var override recurring.Override
+	if err := json.NewDecoder(r.Body).Decode(&override); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+	date := chi.URLParam(r, "date")
+	rec, err := h.scheduler.SetOverride(r.Context(), chi.URLParam(r, "id"), date, override)
+	if err != nil {
+		respondRecurringError(w, err)
+		return
+	}
+	at, _ := rec.Occurrence(date)
+	respondJSON(w, http.StatusOK, newOccurrenceResponses(rec, []time.Time{at})[0])
+}
+
+// Run 도래한 회차를 즉시 기록합니다.
+func (h *RecurringHandler) Run(w http.ResponseWriter, r *http.Request) {
+	report, err := h.scheduler.RunDue(r.Context())
+	if err != nil {
+		respondRecurringError(w, err)
+		return
+	}
+	response := RunRecurrencesResponse{
+		Posted:   report.Posted,
+		Skipped:  report.Skipped,
+		Failed:   report.Failed,
+		Postings: make([]PostingResponse, len(report.Postings)),
+	}
+	for i, p := range report.Postings {
+		response.Postings[i] = PostingResponse{
+			RecurrenceID:  p.RecurrenceID,
+			Date:          recurring.DateKey(p.Date),
+			Status:        string(p.Status),
+			TransactionID: p.TransactionID,
+			Error:         p.Error,
+		}
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+func respondRecurringError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code() {
+		case domain.ErrCodeNotFound:
+			respondError(w, http.StatusNotFound, ErrNotFound, domainErr.Error())
+			return
+		case domain.ErrCodeInvalidArgument:
+			respondError(w, http.StatusBadRequest, ErrValidation, domainErr.Error())
+			return
+		case domain.ErrCodeInvalidOperation:
+			respondError(w, http.StatusConflict, ErrValidation, domainErr.Error())
+			return
+		}
+	}
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "정기 거래 처리 중 오류가 발생했습니다")
+}
+
+func (h *RecurringHandler) newRecurrenceResponse(rec *recurring.Recurrence) RecurrenceResponse {
+	from := h.now()
+	if !rec.PostedThrough.IsZero() && rec.PostedThrough.After(from) {
+		from = rec.PostedThrough.Add(time.Nanosecond)
+	}
+	upcoming := rec.Occurrences(from, from.AddDate(1, 0, 0))
+	if len(upcoming) > upcomingOccurrences {
+		upcoming = upcoming[:upcomingOccurrences]
+	}
+
+	response := RecurrenceResponse{
+		ID:          rec.ID,
+		AssetID:     rec.AssetID,
+		Type:        string(rec.Type),
+		Amount:      rec.Amount.Amount,
+		Currency:    rec.Amount.Currency,
+		Category:    rec.Category,
+		Description: rec.Description,
+		Schedule:    rec.Schedule,
+		Start:       rec.Start,
+		End:         rec.End,
+		Upcoming:    newOccurrenceResponses(rec, upcoming),
+		CreatedAt:   rec.CreatedAt,
+	}
+	if !rec.PostedThrough.IsZero() {
+		postedThrough := rec.PostedThrough
+		response.PostedThrough = &postedThrough
+	}
+	return response
+}
+
+func newOccurrenceResponses(rec *recurring.Recurrence, occurrences []time.Time) []OccurrenceResponse {
+	response := make([]OccurrenceResponse, len(occurrences))
+	for i, at := range occurrences {
+		key := recurring.DateKey(at)
+		override := rec.Overrides[key]
+		item := OccurrenceResponse{Date: key, At: at, Status: occurrenceScheduled, Override: override}
+		switch {
+		case rec.Posted(at) && override.Skip:
+			item.Status = occurrenceSkipped
+		case rec.Posted(at):
+			item.Status = occurrencePosted
+			item.TransactionID = recurring.OccurrenceTransactionID(rec.ID, at)
+		}
+		response[i] = item
+	}
+	return response
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/recurring"
+)
+
+func newRecurringTestRouter(t *testing.T, now time.Time) (*chi.Mux, asset.Repository, string) {
+	t.Helper()
+	assets := asset.NewMemoryAssetRepository()
+	account, err := asset.NewAsset("user-1", asset.Cash, "입출금", 0, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(context.Background(), account))
+
+	repo := recurring.NewMemoryRepository()
+	scheduler := recurring.NewScheduler(repo, assets, asset.NewMemoryTransactionRepository(), recurring.WithClock(func() time.Time { return now }))
+	handler := NewRecurringHandler(scheduler, repo, assets)
+	handler.now = func() time.Time { return now }
+
+	r := chi.NewRouter()
+	handler.RegisterRoutes(r)
+	return r, assets, account.ID
+}
+
+func sendJSON(t *testing.T, r http.Handler, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRecurrences(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	r, assets, assetID := newRecurringTestRouter(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	var created RecurrenceResponse
+	t.Run("정기 거래 생성", func(t *testing.T) {
+		w := sendJSON(t, r, http.MethodPost, "/recurrences", CreateRecurrenceRequest{
+			AssetID:  assetID,
+			Type:     string(asset.Income),
+			Amount:   3000000,
+			Category: "급여",
+			Schedule: recurring.Schedule{Frequency: recurring.Monthly, LastBusinessDay: true},
+			Start:    start,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+		assert.Equal(t, "KRW", created.Currency)
+		require.Len(t, created.Upcoming, 5)
+		assert.Equal(t, "2024-03-29", created.Upcoming[0].Date)
+	})
+
+	t.Run("잘못된 요청", func(t *testing.T) {
+		w := sendJSON(t, r, http.MethodPost, "/recurrences", CreateRecurrenceRequest{AssetID: "missing"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = sendJSON(t, r, http.MethodPost, "/recurrences", CreateRecurrenceRequest{
+			AssetID: assetID, Type: string(asset.Income), Amount: 1, Schedule: recurring.Schedule{Frequency: "HOURLY"}, Start: start,
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("회차 건너뛰기", func(t *testing.T) {
+		w := sendJSON(t, r, http.MethodPut, "/recurrences/"+created.ID+"/occurrences/2024-02-29", recurring.Override{Skip: true})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = sendJSON(t, r, http.MethodPut, "/recurrences/"+created.ID+"/occurrences/2024-02-28", recurring.Override{Skip: true})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("밀린 회차 기록", func(t *testing.T) {
+		w := sendJSON(t, r, http.MethodPost, "/recurrences/run", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var report RunRecurrencesResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+		assert.Equal(t, 1, report.Posted)
+		assert.Equal(t, 1, report.Skipped)
+
+		found, err := assets.FindByID(context.Background(), assetID)
+		require.NoError(t, err)
+		assert.Equal(t, 3000000.0, found.Amount.Amount)
+	})
+
+	t.Run("회차 상태 조회", func(t *testing.T) {
+		w := sendJSON(t, r, http.MethodGet, "/recurrences/"+created.ID+"/occurrences?from=2024-01-01&to=2024-03-31", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var occurrences []OccurrenceResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&occurrences))
+		require.Len(t, occurrences, 3)
+		assert.Equal(t, []string{"POSTED", "SKIPPED", "SCHEDULED"}, []string{occurrences[0].Status, occurrences[1].Status, occurrences[2].Status})
+		assert.NotEmpty(t, occurrences[0].TransactionID)
+
+		w = sendJSON(t, r, http.MethodPut, "/recurrences/"+created.ID+"/occurrences/2024-01-31", recurring.Override{Skip: true})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("정기 거래 삭제", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, sendJSON(t, r, http.MethodDelete, "/recurrences/"+created.ID, nil).Code)
+		assert.Equal(t, http.StatusNotFound, sendJSON(t, r, http.MethodGet, "/recurrences/"+created.ID, nil).Code)
+	})
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/report"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	chi "github.com/go-chi/chi/v5"
+)
+
+// 보고서 내보내기 형식
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+	reportFormatXLSX = "xlsx"
+)
+
+// TotalsResponse 수입/지출 합계 응답
+type TotalsResponse struct {
+	Income      float64 `json:"income"`
+	Expense     float64 `json:"expense"`
+	Transfer    float64 `json:"transfer"`
+	Net         float64 `json:"net"`
+	SavingsRate float64 `json:"savingsRate"`
+	Count       int     `json:"count"`
+}
+
+// PeriodReportResponse 기간별 합계 응답
+type PeriodReportResponse struct {
+	Period string    `json:"period"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	TotalsResponse
+	IncomeChange      float64  `json:"incomeChange"`
+	ExpenseChange     float64  `json:"expenseChange"`
+	NetChange         float64  `json:"netChange"`
+	IncomeChangeRate  *float64 `json:"incomeChangeRate"`
+	ExpenseChangeRate *float64 `json:"expenseChangeRate"`
+}
+
+// BreakdownResponse 항목별 합계 응답
+type BreakdownResponse struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	TotalsResponse
+	Share float64 `json:"share"`
+}
+
+// MerchantResponse 거래처별 지출 응답
+type MerchantResponse struct {
+	Name    string  `json:"name"`
+	Expense float64 `json:"expense"`
+	Count   int     `json:"count"`
+}
+
+// ReportResponse 수입/지출 보고서 응답
+type ReportResponse struct {
+	From        time.Time              `json:"from"`
+	To          time.Time              `json:"to"`
+	Currency    string                 `json:"currency"`
+	Granularity string                 `json:"granularity"`
+	Totals      TotalsResponse         `json:"totals"`
+	Periods     []PeriodReportResponse `json:"periods"`
+	Categories  []BreakdownResponse    `json:"categories"`
+	Assets      []BreakdownResponse    `json:"assets"`
+	Types       []BreakdownResponse    `json:"types"`
+	Merchants   []MerchantResponse     `json:"merchants"`
+	Excluded    int                    `json:"excluded"`
+	GeneratedAt time.Time              `json:"generatedAt"`
+}
+
+// CategoryReportResponse 분류별 보고서 응답
+type CategoryReportResponse struct {
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Currency   string              `json:"currency"`
+	Totals     TotalsResponse      `json:"totals"`
+	Categories []BreakdownResponse `json:"categories"`
+	Excluded   int                 `json:"excluded"`
+}
+
+// ReportHandler 수입/지출 보고서 API 핸들러입니다.
+// 모든 요청은 X-User-ID 헤더의 사용자를 기준으로 처리하며, format 파라미터로 json(기본값), csv, xlsx를 고릅니다.
+// currency를 생략하면 사용자 자산에 가장 많이 쓰인 통화로 계산합니다.
+type ReportHandler struct {
+	generator *report.Generator
+	now       func() time.Time
+}
+
+// NewReportHandler 새로운 보고서 API 핸들러를 생성합니다.
+func NewReportHandler(generator *report.Generator) *ReportHandler {
+	return &ReportHandler{generator: generator, now: time.Now}
+}
+
+// RegisterRoutes 라우터에 보고서 API를 등록합니다.
+func (h *ReportHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/reports", func(r chi.Router) {
+		r.Get("/monthly", h.Monthly)
+		r.Get("/yearly", h.Yearly)
+		r.Get("/category", h.Category)
+		r.Get("/custom", h.Custom)
+	})
+}
+
+// Monthly month(YYYY-MM, 기본값 이번 달)의 보고서를 직전 달과 비교하여 조회합니다.
+func (h *ReportHandler) Monthly(w http.ResponseWriter, r *http.Request) {
+	now := h.now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if value := r.URL.Query().Get("month"); value != "" {
+		parsed, err := time.Parse("2006-01", value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "month는 YYYY-MM 형식이어야 합니다")
+			return
+		}
+		start = parsed
+	}
+	h.respondReport(w, r, start.Format("2006-01"), start, start.AddDate(0, 1, 0), report.Monthly)
+}
+
+// Yearly year(YYYY, 기본값 올해)의 월별 보고서를 조회합니다.
+func (h *ReportHandler) Yearly(w http.ResponseWriter, r *http.Request) {
+	year := h.now().Year()
+	if value := r.URL.Query().Get("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "year는 YYYY 형식이어야 합니다")
+			return
+		}
+		year = parsed
+	}
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	h.respondReport(w, r, strconv.Itoa(year), start, start.AddDate(1, 0, 0), report.Monthly)
+}
+
+// Custom from~to(YYYY-MM-DD) 기간의 보고서를 granularity(DAILY, MONTHLY, YEARLY, 기본값 MONTHLY) 단위로 조회합니다.
+func (h *ReportHandler) Custom(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := parseReportRange(w, r)
+	if !ok {
+		return
+	}
+	granularity := report.Granularity(strings.ToUpper(r.URL.Query().Get("granularity")))
+	if granularity == "" {
+		granularity = report.Monthly
+	}
+	h.respondReport(w, r, from.Format("20060102")+"-"+to.AddDate(0, 0, -1).Format("20060102"), from, to, granularity)
+}
+
+// Category from~to(YYYY-MM-DD) 기간의 분류별 수입/지출을 조회합니다.
+func (h *ReportHandler) Category(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := parseReportRange(w, r)
+	if !ok {
+		return
+	}
+	rep, format, ok := h.generate(w, r, from, to, report.Monthly)
+	if !ok {
+		return
+	}
+
+	if format == reportFormatJSON {
+		respondJSON(w, http.StatusOK, CategoryReportResponse{
+			From:       rep.Range.Start,
+			To:         rep.Range.End,
+			Currency:   rep.Currency,
+			Totals:     newTotalsResponse(rep.Totals),
+			Categories: newBreakdownResponses(rep.Categories),
+			Excluded:   rep.Excluded,
+		})
+		return
+	}
+	var tables []report.Table
+	for _, table := range report.Tables(rep) {
+		if table.Name == "요약" || table.Name == "분류" {
+			tables = append(tables, table)
+		}
+	}
+	writeReportFile(w, format, "category-"+from.Format("20060102")+"-"+to.AddDate(0, 0, -1).Format("20060102"), tables)
+}
+
+func (h *ReportHandler) respondReport(w http.ResponseWriter, r *http.Request, name string, from, to time.Time, granularity report.Granularity) {
+	rep, format, ok := h.generate(w, r, from, to, granularity)
+	if !ok {
+		return
+	}
+	if format == reportFormatJSON {
+		respondJSON(w, http.StatusOK, newReportResponse(rep))
+		return
+	}
+	writeReportFile(w, format, "report-"+name, report.Tables(rep))
+}
+
+// generate [from, to) 기간의 보고서를 만듭니다. 실패하면 응답을 쓰고 false를 반환합니다.
+func (h *ReportHandler) generate(w http.ResponseWriter, r *http.Request, from, to time.Time, granularity report.Granularity) (*report.Report, string, bool) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return nil, "", false
+	}
+	query := r.URL.Query()
+	format := strings.ToLower(query.Get("format"))
+	if format == "" {
+		format = reportFormatJSON
+	}
+	if format != reportFormatJSON && format != reportFormatCSV && format != reportFormatXLSX {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "format은 json, csv, xlsx 중 하나여야 합니다")
+		return nil, "", false
+	}
+	top := 0
+	if value := query.Get("top"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "top은 1 이상의 정수여야 합니다")
+			return nil, "", false
+		}
+		top = parsed
+	}
+	timeRange, err := valueobjects.NewTimeRange(from, to.Add(-time.Nanosecond))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "보고서 기간이 올바르지 않습니다")
+		return nil, "", false
+	}
+
+	rep, err := h.generator.Generate(r.Context(), report.Request{
+		UserID:       userID,
+		Range:        timeRange,
+		Currency:     strings.ToUpper(query.Get("currency")),
+		Granularity:  granularity,
+		TopMerchants: top,
+	})
+	if err != nil {
+		respondReportError(w, err)
+		return nil, "", false
+	}
+	return rep, format, true
+}
+
+// parseReportRange from, to(YYYY-MM-DD)를 읽어 [from, to 다음 날) 범위를 반환합니다.
+func parseReportRange(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	from, err := time.Parse("2006-01-02", r.URL.Query().Get("from"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "from은 YYYY-MM-DD 형식이어야 합니다")
+		return time.Time{}, time.Time{}, false
+	}
+	to, err := time.Parse("2006-01-02", r.URL.Query().Get("to"))
+	if err != nil || to.Before(from) {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "to는 from 이후의 YYYY-MM-DD 형식이어야 합니다")
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to.AddDate(0, 0, 1), true
+}
+
+func writeReportFile(w http.ResponseWriter, format, name string, tables []report.Table) {
+	write := report.WriteCSV
+	contentType := "text/csv; charset=utf-8"
+	if format == reportFormatXLSX {
+		write = report.WriteXLSX
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	w.WriteHeader(http.StatusOK)
+	// 헤더를 이미 보냈으므로 실패해도 상태 코드를 바꿀 수 없습니다
+	if err := write(w, tables); err != nil {
+		log.Printf("보고서 파일 작성 실패(%s): %v", name, err)
+	}
+}
+
+func respondReportError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) && domainErr.Code() == domain.ErrCodeInvalidArgument {
+		respondError(w, http.StatusBadRequest, ErrValidation, domainErr.Error())
+		return
+	}
+	respondError(w, http.StatusInternalServerError, ErrInternalServer, "보고서 생성 중 오류가 발생했습니다")
+}
+
+func newTotalsResponse(t report.Totals) TotalsResponse {
+	return TotalsResponse{
+		Income:      t.Income,
+		Expense:     t.Expense,
+		Transfer:    t.Transfer,
+		Net:         t.Net,
+		SavingsRate: t.SavingsRate,
+		Count:       t.Count,
+	}
+}
+
+func newBreakdownResponses(items []report.Breakdown) []BreakdownResponse {
+	response := make([]BreakdownResponse, len(items))
+	for i, item := range items {
+		response[i] = BreakdownResponse{Key: item.Key, Name: item.Name, TotalsResponse: newTotalsResponse(item.Totals), Share: item.Share}
+	}
+	return response
+}
+
+func newReportResponse(rep *report.Report) ReportResponse {
+	response := ReportResponse{
+		From:        rep.Range.Start,
+		To:          rep.Range.End,
+		Currency:    rep.Currency,
+		Granularity: string(rep.Granularity),
+		Totals:      newTotalsResponse(rep.Totals),
+		Periods:     make([]PeriodReportResponse, len(rep.Periods)),
+		Categories:  newBreakdownResponses(rep.Categories),
+		Assets:      newBreakdownResponses(rep.Assets),
+		Types:       newBreakdownResponses(rep.Types),
+		Merchants:   make([]MerchantResponse, len(rep.Merchants)),
+		Excluded:    rep.Excluded,
+		GeneratedAt: rep.GeneratedAt,
+	}
+	for i, p := range rep.Periods {
+		response.Periods[i] = PeriodReportResponse{
+			Period:            p.Key,
+			Start:             p.Start,
+			End:               p.End,
+			TotalsResponse:    newTotalsResponse(p.Totals),
+			IncomeChange:      p.Change.Income,
+			ExpenseChange:     p.Change.Expense,
+			NetChange:         p.Change.Net,
+			IncomeChangeRate:  p.Change.IncomeRate,
+			ExpenseChangeRate: p.Change.ExpenseRate,
+		}
+	}
+	for i, m := range rep.Merchants {
+		response.Merchants[i] = MerchantResponse{Name: m.Name, Expense: m.Expense, Count: m.Count}
+	}
+	return response
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/report"
+)
+
+func newReportTestRouter(t *testing.T) *chi.Mux {
+	t.Helper()
+	ctx := context.Background()
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	account, err := asset.NewAsset("user-1", asset.Cash, "입출금", 0, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(ctx, account))
+
+	for _, item := range []struct {
+		txType   asset.TransactionType
+		amount   float64
+		category string
+		at       time.Time
+	}{
+		{asset.Income, 3000000, "급여", time.Date(2024, 2, 25, 0, 0, 0, 0, time.UTC)},
+		{asset.Expense, 500000, "식비", time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)},
+		{asset.Income, 3000000, "급여", time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{asset.Expense, 750000, "식비", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{asset.Expense, 250000, "교통", time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC)},
+	} {
+		tx, err := asset.NewTransaction(account.ID, item.txType, asset.Money{Amount: item.amount, Currency: "KRW"}, item.category, "마트")
+		require.NoError(t, err)
+		tx.Date = item.at
+		require.NoError(t, transactions.Save(ctx, tx))
+	}
+
+	handler := NewReportHandler(report.NewGenerator(assets, transactions))
+	handler.now = func() time.Time { return time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC) }
+	r := chi.NewRouter()
+	handler.RegisterRoutes(r)
+	return r
+}
+
+func TestReports(t *testing.T) {
+	r := newReportTestRouter(t)
+
+	t.Run("월간 보고서", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/reports/monthly", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response ReportResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "KRW", response.Currency)
+		assert.Equal(t, 1000000.0, response.Totals.Expense)
+		assert.InDelta(t, 2.0/3, response.Totals.SavingsRate, 1e-9)
+		require.Len(t, response.Periods, 1)
+		assert.Equal(t, 500000.0, response.Periods[0].ExpenseChange)
+		require.NotNil(t, response.Periods[0].ExpenseChangeRate)
+		assert.InDelta(t, 1.0, *response.Periods[0].ExpenseChangeRate, 1e-9)
+		require.Len(t, response.Merchants, 1)
+		assert.Equal(t, "마트", response.Merchants[0].Name)
+	})
+
+	t.Run("연간 보고서", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/reports/yearly?year=2024", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response ReportResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Len(t, response.Periods, 12)
+		assert.Equal(t, 6000000.0, response.Totals.Income)
+	})
+
+	t.Run("분류 보고서 CSV", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/reports/category?from=2024-03-01&to=2024-03-31&format=csv", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "category-20240301-20240331.csv")
+		reader := csv.NewReader(w.Body)
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		require.NoError(t, err)
+		assert.Contains(t, records, []string{"분류"})
+		assert.Contains(t, records, []string{"교통", "0", "250000", "0", "-250000", "1", "0.25"})
+		assert.NotContains(t, records, []string{"거래처"})
+	})
+
+	t.Run("사용자 지정 보고서 XLSX", func(t *testing.T) {
+		w := doAs(t, r, http.MethodGet, "/reports/custom?from=2024-02-01&to=2024-03-31&granularity=daily&format=xlsx", "user-1", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		body := w.Body.Bytes()
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		require.NoError(t, err)
+		assert.Len(t, archive.File, 4+6)
+	})
+
+	t.Run("잘못된 요청", func(t *testing.T) {
+		for _, path := range []string{
+			"/reports/monthly?month=2024-13",
+			"/reports/yearly?year=abc",
+			"/reports/custom?from=2024-03-01&to=2024-02-01",
+			"/reports/custom?from=2024-03-01&to=2024-03-31&granularity=WEEKLY",
+			"/reports/monthly?format=pdf",
+		} {
+			w := doAs(t, r, http.MethodGet, path, "user-1", nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		}
+		w := doAs(t, r, http.MethodGet, "/reports/monthly", "", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
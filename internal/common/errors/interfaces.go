@@ -21,6 +21,9 @@ const (
 	ErrorCodeUnauthorized  = "ERROR_UNAUTHORIZED"
 	ErrorCodeForbidden     = "ERROR_FORBIDDEN"
 
+	// 동시성 제어 관련 에러 코드
+	ErrorCodeVersionConflict = "ERROR_VERSION_CONFLICT"
+
 	// 포트폴리오 관련 에러 코드
 	ErrorCodePortfolioNotFound   = "ERROR_PORTFOLIO_NOT_FOUND"
 	ErrorCodeDuplicateAsset      = "ERROR_DUPLICATE_ASSET"
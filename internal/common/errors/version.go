@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// VersionConflictError는 낙관적 잠금 검사에서 저장된 버전과 요청한 버전이 다를 때 반환됩니다.
+type VersionConflictError struct {
+	Entity   string // 엔티티 종류 (예: "asset", "portfolio")
+	ID       string // 엔티티 ID
+	Expected int64  // 요청이 기준으로 삼은 버전
+	Actual   int64  // 저장소에 기록된 현재 버전
+}
+
+// Error는 에러 메시지를 반환합니다.
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %s version conflict: expected %d, actual %d", e.Entity, e.ID, e.Expected, e.Actual)
+}
+
+// Code는 에러 코드를 반환합니다.
+func (e *VersionConflictError) Code() string {
+	return ErrorCodeVersionConflict
+}
+
+// StatusCode는 HTTP 상태 코드를 반환합니다.
+// If-Match 전제 조건이 맞지 않은 것과 같으므로 412를 사용합니다.
+func (e *VersionConflictError) StatusCode() int {
+	return http.StatusPreconditionFailed
+}
+
+// NewVersionConflictError는 새로운 버전 충돌 에러를 생성합니다.
+func NewVersionConflictError(entity, id string, expected, actual int64) error {
+	return &VersionConflictError{Entity: entity, ID: id, Expected: expected, Actual: actual}
+}
+
+// IsVersionConflict는 에러가 버전 충돌 에러인지 확인합니다.
+func IsVersionConflict(err error) bool {
+	var conflictErr *VersionConflictError
+	return As(err, &conflictErr)
+}
+
+// ETag는 엔티티 버전을 강한 ETag 값으로 변환합니다.
+func ETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// IfMatchVersion은 요청의 If-Match 헤더에서 기대 버전을 추출합니다.
+// 헤더가 없거나 "*"이면 ok가 false이며, 버전으로 해석할 수 없는 값이면 에러를 반환합니다.
+func IfMatchVersion(r *http.Request) (version int64, ok bool, err error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, false, nil
+	}
+
+	// 약한 비교자(W/)는 버전 비교에 영향을 주지 않으므로 제거합니다
+	value = strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+	version, err = strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid If-Match header %q", r.Header.Get("If-Match"))
+	}
+	return version, true, nil
+}
@@ -1,5 +1,7 @@
 package repository
 
+import "sort"
+
 // FindOption 조회 옵션 인터페이스
 type FindOption interface {
 	Apply(options *FindOptions)
@@ -151,3 +153,40 @@ type paginationOption struct {
 func (o paginationOption) Apply(options *FindOptions) {
 	options.Pagination = &o.pagination
 }
+
+// sortFields는 정렬에 사용할 필드를 우선순위 순으로 반환합니다.
+// 마지막으로 지정한 SortBy가 첫 번째 기준이 되고, 나머지 Sort 항목은 결정적인 결과를 위해 필드 이름 순으로 이어집니다.
+func (opts *FindOptions) sortFields() []string {
+	var fields []string
+	if opts.SortBy != "" {
+		fields = append(fields, opts.SortBy)
+	}
+	var rest []string
+	for field := range opts.Sort {
+		if field != opts.SortBy {
+			rest = append(rest, field)
+		}
+	}
+	sort.Strings(rest)
+	return append(fields, rest...)
+}
+
+// sortOrder는 필드의 정렬 순서를 반환합니다. Sort 맵에 없으면 SortOrder를 사용합니다.
+func (opts *FindOptions) sortOrder(field string) SortOrder {
+	if order, ok := opts.Sort[field]; ok {
+		return order
+	}
+	return opts.SortOrder
+}
+
+// window는 조회할 개수와 건너뛸 개수를 반환합니다. Pagination이 지정되면 Limit/Offset보다 우선합니다.
+func (opts *FindOptions) window() (limit, offset int) {
+	if opts.Pagination != nil && opts.Pagination.PageSize > 0 {
+		offset = 0
+		if opts.Pagination.Page > 1 {
+			offset = (opts.Pagination.Page - 1) * opts.Pagination.PageSize
+		}
+		return opts.Pagination.PageSize, offset
+	}
+	return opts.Limit, opts.Offset
+}
@@ -1,41 +1,166 @@
 package repository
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// mongoFilterKey는 WithMongoFilter로 지정한 필터가 ExtraFilters에 저장되는 키입니다.
+const mongoFilterKey = "mongodb_filter"
+
+// mongoOperators는 Filter.Operator를 MongoDB 비교 연산자로 변환합니다.
+var mongoOperators = map[string]string{
+	"":    "$eq",
+	"eq":  "$eq",
+	"ne":  "$ne",
+	"gt":  "$gt",
+	"gte": "$gte",
+	"lt":  "$lt",
+	"lte": "$lte",
+	"in":  "$in",
+	"nin": "$nin",
+}
+
 // ToMongoOptions는 FindOptions를 MongoDB의 FindOptions로 변환합니다.
+// Pagination이 지정되면 Limit/Offset보다 우선하며, 정렬은 SortBy를 첫 번째 기준으로 합니다.
 func (opts *FindOptions) ToMongoOptions() *options.FindOptions {
 	mongoOpts := options.Find()
 
 	// 페이지네이션 적용
-	if opts.Limit > 0 {
-		mongoOpts.SetLimit(int64(opts.Limit))
-		mongoOpts.SetSkip(int64(opts.Offset))
+	if limit, offset := opts.window(); limit > 0 {
+		mongoOpts.SetLimit(int64(limit))
+		mongoOpts.SetSkip(int64(offset))
 	}
 
-	// 정렬 적용
-	if len(opts.Sort) > 0 {
-		sort := bson.D{}
-		for field, order := range opts.Sort {
+	// 정렬 적용 (bson.D는 순서를 보존하므로 다중 필드 정렬의 우선순위가 유지됩니다)
+	if fields := opts.sortFields(); len(fields) > 0 {
+		sort := make(bson.D, 0, len(fields))
+		for _, field := range fields {
 			// MongoDB 정렬 방식: 1은 오름차순, -1은 내림차순
-			var sortOrder int
-			if order == SortAscending {
-				sortOrder = 1
-			} else {
+			sortOrder := 1
+			if opts.sortOrder(field) == SortDescending {
 				sortOrder = -1
 			}
 			sort = append(sort, bson.E{Key: field, Value: sortOrder})
 		}
-		if len(sort) > 0 {
-			mongoOpts.SetSort(sort)
-		}
+		mongoOpts.SetSort(sort)
 	}
 
 	return mongoOpts
 }
 
+// ToMongoFilter는 Filters, FilterList, WithMongoFilter 조건을 하나의 MongoDB 필터로 결합합니다.
+// 같은 필드에 서로 다른 연산자를 지정하면 하나의 연산자 문서로 합쳐집니다(예: {"amount": {"$gte": 1, "$lt": 10}}).
+// 같은 필드의 같은 연산자처럼 합치면 앞선 조건을 덮어쓰게 되는 조건과, WithMongoFilter에서 이미 쓰인 필드를 다시 지정한 조건은
+// $and로 묶어 모든 조건이 적용되도록 합니다.
+// "like" 연산자는 대소문자를 구분하지 않는 부분 문자열 검색($regex)으로 변환됩니다.
+func (opts *FindOptions) ToMongoFilter() (bson.M, error) {
+	filter := bson.M{}
+	// conflicts는 filter에 합칠 수 없어 $and로 따로 적용할 조건입니다
+	var conflicts []bson.M
+
+	for field, value := range opts.Filters {
+		filter[field] = value
+	}
+
+	for _, f := range opts.FilterList {
+		operator := strings.ToLower(f.Operator)
+
+		var expr bson.M
+		if operator == "like" {
+			expr = bson.M{
+				"$regex":   regexp.QuoteMeta(fmt.Sprint(f.Value)),
+				"$options": "i",
+			}
+		} else {
+			op, ok := mongoOperators[operator]
+			if !ok {
+				return nil, fmt.Errorf("%w: unsupported operator %q", ErrInvalidEntity, f.Operator)
+			}
+			expr = bson.M{op: f.Value}
+		}
+
+		existing, ok := filter[f.Field]
+		if !ok {
+			filter[f.Field] = expr
+			continue
+		}
+		doc, isDoc := existing.(bson.M)
+		if isDoc && !isOperatorDocument(doc) {
+			// 내장 문서와의 동등 비교는 연산자와 합칠 수 없습니다
+			conflicts = append(conflicts, bson.M{f.Field: expr})
+			continue
+		}
+		if !isDoc {
+			// 동등 비교 필터와 연산자 필터를 함께 쓰면 $eq로 합칩니다
+			doc = bson.M{"$eq": existing}
+		}
+		if sharesOperator(doc, expr) {
+			conflicts = append(conflicts, bson.M{f.Field: expr})
+			continue
+		}
+		// 호출자가 넘긴 필터 문서를 바꾸지 않도록 복사한 뒤 합칩니다
+		merged := make(bson.M, len(doc)+len(expr))
+		for k, v := range doc {
+			merged[k] = v
+		}
+		for k, v := range expr {
+			merged[k] = v
+		}
+		filter[f.Field] = merged
+	}
+
+	if extra, ok := opts.ExtraFilters[mongoFilterKey].(bson.M); ok {
+		fields := make([]string, 0, len(extra))
+		for field := range extra {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			if _, exists := filter[field]; exists {
+				conflicts = append(conflicts, bson.M{field: extra[field]})
+				continue
+			}
+			filter[field] = extra[field]
+		}
+	}
+
+	if len(conflicts) > 0 {
+		if existing, ok := filter["$and"]; ok {
+			conflicts = append([]bson.M{{"$and": existing}}, conflicts...)
+		}
+		filter["$and"] = conflicts
+	}
+
+	return filter, nil
+}
+
+// sharesOperator는 두 연산자 문서에 같은 연산자가 있는지 확인합니다.
+// "like"가 만드는 $regex와 $options는 함께 쓰이므로 하나라도 겹치면 충돌로 봅니다.
+func sharesOperator(doc, expr bson.M) bool {
+	for key := range expr {
+		if _, ok := doc[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isOperatorDocument는 모든 키가 $로 시작하는 연산자 문서인지 확인합니다.
+func isOperatorDocument(doc bson.M) bool {
+	for key := range doc {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
+
 // WithMongoFilter는 MongoDB에 특화된 필터를 추가하는 옵션을 생성합니다.
 func WithMongoFilter(filter bson.M) FindOption {
 	return mongoFilterOption{filter: filter}
@@ -49,5 +174,5 @@ func (o mongoFilterOption) Apply(options *FindOptions) {
 	if options.ExtraFilters == nil {
 		options.ExtraFilters = make(map[string]interface{})
 	}
-	options.ExtraFilters["mongodb_filter"] = o.filter
+	options.ExtraFilters[mongoFilterKey] = o.filter
 }
@@ -0,0 +1,124 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/aske/go_fi_chart/internal/common/repository"
+)
+
+func TestFindOptions_ToMongoFilter(t *testing.T) {
+	options := buildOptions(
+		repository.WithFilter("user_id", "user1"),
+		repository.WithComplexFilter("amount", "gte", 1000),
+		repository.WithComplexFilter("amount", "lt", 5000),
+		repository.WithComplexFilter("type", "in", []string{"STOCK", "BOND"}),
+		repository.WithComplexFilter("name", "like", "a.b"),
+		repository.WithMongoFilter(bson.M{"is_deleted": false}),
+	)
+
+	filter, err := options.ToMongoFilter()
+	require.NoError(t, err)
+
+	assert.Equal(t, bson.M{
+		"user_id":    "user1",
+		"amount":     bson.M{"$gte": 1000, "$lt": 5000},
+		"type":       bson.M{"$in": []string{"STOCK", "BOND"}},
+		"name":       bson.M{"$regex": `a\.b`, "$options": "i"},
+		"is_deleted": false,
+	}, filter)
+}
+
+func TestFindOptions_ToMongoFilter_DoesNotMutateCallerFilter(t *testing.T) {
+	amount := bson.M{"$gte": 1000}
+	options := buildOptions(
+		repository.WithFilter("amount", amount),
+		repository.WithComplexFilter("amount", "lt", 5000),
+	)
+
+	filter, err := options.ToMongoFilter()
+	require.NoError(t, err)
+
+	assert.Equal(t, bson.M{"amount": bson.M{"$gte": 1000, "$lt": 5000}}, filter)
+	assert.Equal(t, bson.M{"$gte": 1000}, amount)
+
+	again, err := options.ToMongoFilter()
+	require.NoError(t, err)
+	assert.Equal(t, filter, again)
+}
+
+func TestFindOptions_ToMongoFilter_CombinesConflictingConditionsWithAnd(t *testing.T) {
+	options := buildOptions(
+		repository.WithComplexFilter("amount", "gte", 1000),
+		repository.WithComplexFilter("amount", "gte", 2000),
+		repository.WithComplexFilter("name", "like", "foo"),
+		repository.WithComplexFilter("name", "like", "bar"),
+		repository.WithComplexFilter("status", "ne", "CLOSED"),
+		repository.WithMongoFilter(bson.M{"status": "ACTIVE", "is_deleted": false}),
+	)
+
+	filter, err := options.ToMongoFilter()
+	require.NoError(t, err)
+
+	assert.Equal(t, bson.M{
+		"amount":     bson.M{"$gte": 1000},
+		"name":       bson.M{"$regex": "foo", "$options": "i"},
+		"status":     bson.M{"$ne": "CLOSED"},
+		"is_deleted": false,
+		"$and": []bson.M{
+			{"amount": bson.M{"$gte": 2000}},
+			{"name": bson.M{"$regex": "bar", "$options": "i"}},
+			{"status": "ACTIVE"},
+		},
+	}, filter)
+}
+
+func TestFindOptions_ToMongoFilter_KeepsCallerAndConditions(t *testing.T) {
+	callerAnd := []bson.M{{"tags": "a"}}
+	options := buildOptions(
+		repository.WithFilter("user_id", "user1"),
+		repository.WithComplexFilter("user_id", "eq", "user2"),
+		repository.WithMongoFilter(bson.M{"$and": callerAnd}),
+	)
+
+	filter, err := options.ToMongoFilter()
+	require.NoError(t, err)
+
+	assert.Equal(t, bson.M{
+		"user_id": "user1",
+		"$and": []bson.M{
+			{"$and": callerAnd},
+			{"user_id": bson.M{"$eq": "user2"}},
+		},
+	}, filter)
+}
+
+func TestFindOptions_ToMongoFilter_RejectsUnknownOperator(t *testing.T) {
+	_, err := buildOptions(repository.WithComplexFilter("amount", "between", 1)).ToMongoFilter()
+	assert.True(t, errors.Is(err, repository.ErrInvalidEntity))
+}
+
+func TestFindOptions_ToMongoOptions(t *testing.T) {
+	options := buildOptions(
+		repository.WithSort("created_at", repository.SortDescending),
+		repository.WithSort("amount", repository.SortDescending),
+		repository.WithSort("name", repository.SortAscending),
+		repository.WithPagination(3, 20),
+	)
+
+	mongoOpts := options.ToMongoOptions()
+
+	require.NotNil(t, mongoOpts.Limit)
+	require.NotNil(t, mongoOpts.Skip)
+	assert.Equal(t, int64(20), *mongoOpts.Limit)
+	assert.Equal(t, int64(40), *mongoOpts.Skip)
+	assert.Equal(t, bson.D{
+		{Key: "name", Value: 1},
+		{Key: "amount", Value: -1},
+		{Key: "created_at", Value: -1},
+	}, mongoOpts.Sort)
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SQLQuery는 FindOptions에서 생성한 SQL 절과 바인딩 인자입니다.
+// 각 절은 키워드를 포함하며(예: "WHERE ..."), 해당 조건이 없으면 빈 문자열입니다.
+type SQLQuery struct {
+	Where   string
+	OrderBy string
+	Limit   string
+	Args    []interface{}
+}
+
+// Build는 기본 SELECT 문 뒤에 절을 이어 붙인 SQL을 반환합니다.
+func (q SQLQuery) Build(base string) string {
+	parts := []string{base}
+	for _, clause := range []string{q.Where, q.OrderBy, q.Limit} {
+		if clause != "" {
+			parts = append(parts, clause)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// sqlOperators는 Filter.Operator를 SQL 연산자로 변환합니다.
+var sqlOperators = map[string]string{
+	"":     "=",
+	"eq":   "=",
+	"ne":   "<>",
+	"gt":   ">",
+	"gte":  ">=",
+	"lt":   "<",
+	"lte":  "<=",
+	"like": "ILIKE",
+	"in":   "= ANY",
+	"nin":  "<> ALL",
+}
+
+// ToSQL은 FindOptions를 PostgreSQL 스타일($1, $2 ...) 플레이스홀더를 사용하는 SQL 절로 변환합니다.
+// columns는 허용된 필드 이름을 컬럼 이름으로 매핑하며, 매핑에 없는 필드는 에러를 반환합니다.
+// Filters(동등 비교)와 FilterList(연산자 비교)는 AND로 결합되고,
+// Pagination이 지정되면 Limit/Offset보다 우선합니다.
+func (opts *FindOptions) ToSQL(columns map[string]string) (SQLQuery, error) {
+	var query SQLQuery
+	var conditions []string
+
+	bind := func(value interface{}) string {
+		query.Args = append(query.Args, value)
+		return fmt.Sprintf("$%d", len(query.Args))
+	}
+	column := func(field string) (string, error) {
+		col, ok := columns[field]
+		if !ok {
+			return "", fmt.Errorf("%w: unknown field %q", ErrInvalidEntity, field)
+		}
+		return col, nil
+	}
+
+	// 맵 기반 필터는 순서가 없으므로 필드 이름 순으로 정렬해 결정적인 SQL을 만듭니다
+	fields := make([]string, 0, len(opts.Filters))
+	for field := range opts.Filters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		col, err := column(field)
+		if err != nil {
+			return SQLQuery{}, err
+		}
+		conditions = append(conditions, fmt.Sprintf("%s = %s", col, bind(opts.Filters[field])))
+	}
+
+	for _, filter := range opts.FilterList {
+		col, err := column(filter.Field)
+		if err != nil {
+			return SQLQuery{}, err
+		}
+		op, ok := sqlOperators[strings.ToLower(filter.Operator)]
+		if !ok {
+			return SQLQuery{}, fmt.Errorf("%w: unsupported operator %q", ErrInvalidEntity, filter.Operator)
+		}
+
+		switch op {
+		case "= ANY", "<> ALL":
+			conditions = append(conditions, fmt.Sprintf("%s %s(%s)", col, op, bind(filter.Value)))
+		case "ILIKE":
+			conditions = append(conditions, fmt.Sprintf("%s ILIKE %s", col, bind("%"+escapeLike(fmt.Sprint(filter.Value))+"%")))
+		default:
+			conditions = append(conditions, fmt.Sprintf("%s %s %s", col, op, bind(filter.Value)))
+		}
+	}
+	if len(conditions) > 0 {
+		query.Where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	orderBy, err := opts.sqlOrderBy(column)
+	if err != nil {
+		return SQLQuery{}, err
+	}
+	query.OrderBy = orderBy
+
+	if limit, offset := opts.window(); limit > 0 {
+		query.Limit = fmt.Sprintf("LIMIT %s OFFSET %s", bind(limit), bind(offset))
+	}
+
+	return query, nil
+}
+
+// sqlOrderBy는 sortFields 순서대로 ORDER BY 절을 만듭니다.
+func (opts *FindOptions) sqlOrderBy(column func(string) (string, error)) (string, error) {
+	fields := opts.sortFields()
+	terms := make([]string, 0, len(fields))
+	for _, field := range fields {
+		col, err := column(field)
+		if err != nil {
+			return "", err
+		}
+		direction := "ASC"
+		if opts.sortOrder(field) == SortDescending {
+			direction = "DESC"
+		}
+		terms = append(terms, col+" "+direction)
+	}
+	if len(terms) == 0 {
+		return "", nil
+	}
+	return "ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// escapeLike는 LIKE 패턴의 와일드카드 문자를 이스케이프합니다.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/common/repository"
+)
+
+var testColumns = map[string]string{
+	"userId":    "user_id",
+	"type":      "type",
+	"name":      "name",
+	"amount":    "amount",
+	"createdAt": "created_at",
+}
+
+func buildOptions(opts ...repository.FindOption) *repository.FindOptions {
+	options := repository.NewFindOptions()
+	for _, opt := range opts {
+		opt.Apply(options)
+	}
+	return options
+}
+
+func TestFindOptions_ToSQL(t *testing.T) {
+	options := buildOptions(
+		repository.WithFilter("userId", "user1"),
+		repository.WithFilter("type", "STOCK"),
+		repository.WithComplexFilter("amount", "gte", 1000),
+		repository.WithComplexFilter("name", "like", "50%_"),
+		repository.WithSort("createdAt", repository.SortDescending),
+		repository.WithSort("name", repository.SortAscending),
+		repository.WithLimit(10),
+		repository.WithOffset(20),
+	)
+
+	query, err := options.ToSQL(testColumns)
+	require.NoError(t, err)
+
+	assert.Equal(t, "WHERE type = $1 AND user_id = $2 AND amount >= $3 AND name ILIKE $4", query.Where)
+	assert.Equal(t, "ORDER BY name ASC, created_at DESC", query.OrderBy)
+	assert.Equal(t, "LIMIT $5 OFFSET $6", query.Limit)
+	assert.Equal(t, []interface{}{"STOCK", "user1", 1000, `%50\%\_%`, 10, 20}, query.Args)
+	assert.Equal(t,
+		"SELECT * FROM assets WHERE type = $1 AND user_id = $2 AND amount >= $3 AND name ILIKE $4 ORDER BY name ASC, created_at DESC LIMIT $5 OFFSET $6",
+		query.Build("SELECT * FROM assets"))
+}
+
+func TestFindOptions_ToSQL_PaginationAndIn(t *testing.T) {
+	options := buildOptions(
+		repository.WithComplexFilter("type", "in", []string{"STOCK", "BOND"}),
+		repository.WithPagination(3, 25),
+	)
+
+	query, err := options.ToSQL(testColumns)
+	require.NoError(t, err)
+
+	assert.Equal(t, "WHERE type = ANY($1)", query.Where)
+	assert.Empty(t, query.OrderBy)
+	assert.Equal(t, "LIMIT $2 OFFSET $3", query.Limit)
+	assert.Equal(t, []interface{}{[]string{"STOCK", "BOND"}, 25, 50}, query.Args)
+}
+
+func TestFindOptions_ToSQL_RejectsUnknownFieldsAndOperators(t *testing.T) {
+	_, err := buildOptions(repository.WithFilter("password; DROP TABLE assets", "x")).ToSQL(testColumns)
+	assert.True(t, errors.Is(err, repository.ErrInvalidEntity))
+
+	_, err = buildOptions(repository.WithComplexFilter("amount", "between", 1)).ToSQL(testColumns)
+	assert.True(t, errors.Is(err, repository.ErrInvalidEntity))
+
+	_, err = buildOptions(repository.WithSort("unknown", repository.SortAscending)).ToSQL(testColumns)
+	assert.Error(t, err)
+}
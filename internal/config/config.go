@@ -30,6 +30,7 @@ type MetricsConfig struct {
 // DatabaseConfig 데이터베이스 설정입니다.
 type DatabaseConfig struct {
 	Driver          string        `yaml:"driver"`
+	Path            string        `yaml:"path"`
 	Host            string        `yaml:"host"`
 	Port            int           `yaml:"port"`
 	Name            string        `yaml:"name"`
@@ -60,6 +61,7 @@ func NewDefaultConfig() *Config {
 		},
 		Database: DatabaseConfig{
 			Driver:          "postgres",
+			Path:            "data/fin-rpg.kv",
 			Host:            "localhost",
 			Port:            5432,
 			ConnectTimeout:  5 * time.Second,
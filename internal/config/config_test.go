@@ -34,6 +34,7 @@ func TestNewDefaultConfig(t *testing.T) {
 
 		// 데이터베이스 설정 검증
 		assert.Equal(t, "postgres", config.Database.Driver)
+		assert.Equal(t, "data/fin-rpg.kv", config.Database.Path)
 		assert.Equal(t, "localhost", config.Database.Host)
 		assert.Equal(t, 5432, config.Database.Port)
 		assert.Equal(t, 5*time.Second, config.Database.ConnectTimeout)
@@ -76,6 +77,7 @@ func TestConfig_Validation(t *testing.T) {
 	t.Run("DatabaseConfig YAML 태그가 올바르게 설정되어야 함", func(t *testing.T) {
 		config := &DatabaseConfig{}
 		assert.Equal(t, "driver", getStructTags(config, "Driver"))
+		assert.Equal(t, "path", getStructTags(config, "Path"))
 		assert.Equal(t, "host", getStructTags(config, "Host"))
 		assert.Equal(t, "port", getStructTags(config, "Port"))
 		assert.Equal(t, "name", getStructTags(config, "Name"))
@@ -0,0 +1,386 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	assetBucket       = "assets"
+	transactionBucket = "transactions"
+	portfolioBucket   = "portfolios"
+
+	indexUserID  = "user_id"
+	indexType    = "type"
+	indexAssetID = "asset_id"
+	indexSymbol  = "symbol"
+)
+
+// EmbeddedRepository 임베디드 키-값 저장소 기반의 저장소 구현체입니다.
+// 같은 kv.DB를 공유하는 저장소들은 WithTransaction 안에서 하나의 배치로 커밋됩니다.
+type EmbeddedRepository[T domain.Entity] struct {
+	items *kv.Collection[T]
+}
+
+// NewEmbeddedRepository 버킷 이름으로 임베디드 저장소를 생성합니다.
+func NewEmbeddedRepository[T domain.Entity](db *kv.DB, bucket string) *EmbeddedRepository[T] {
+	return &EmbeddedRepository[T]{
+		items: kv.NewCollection[T](db, bucket),
+	}
+}
+
+// Save 엔티티를 저장합니다.
+func (r *EmbeddedRepository[T]) Save(ctx context.Context, entity T) error {
+	err := r.items.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.items.Exists(tx, entity.GetID())
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("entity with ID %s already exists", entity.GetID())
+		}
+		initVersion(entity)
+		return r.items.Put(tx, entity.GetID(), entity)
+	})
+	if err != nil {
+		return domain.NewRepositoryError("Save", err)
+	}
+	return nil
+}
+
+// FindByID ID로 엔티티를 조회합니다.
+func (r *EmbeddedRepository[T]) FindByID(ctx context.Context, id string) (T, error) {
+	var entity T
+	err := r.items.View(ctx, func(tx *kv.Tx) error {
+		found, exists, err := r.items.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("entity with ID %s not found", id)
+		}
+		entity = found
+		return nil
+	})
+	if err != nil {
+		var zero T
+		return zero, domain.NewRepositoryError("FindByID", err)
+	}
+	return entity, nil
+}
+
+// Update 엔티티를 업데이트합니다. 엔티티의 버전이 저장된 버전과 다르면 버전 충돌 에러를 반환합니다.
+func (r *EmbeddedRepository[T]) Update(ctx context.Context, entity T) error {
+	version, _ := versionOf(entity)
+	var conflict error
+	err := r.items.Update(ctx, func(tx *kv.Tx) error {
+		stored, exists, err := r.items.Get(tx, entity.GetID())
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("entity with ID %s not found", entity.GetID())
+		}
+		if conflict = nextVersion(entity, stored); conflict != nil {
+			return conflict
+		}
+		return r.items.Put(tx, entity.GetID(), entity)
+	})
+	if err != nil {
+		if v, ok := any(entity).(versioned); ok {
+			v.setVersion(version)
+		}
+		if conflict != nil {
+			return conflict
+		}
+		return domain.NewRepositoryError("Update", err)
+	}
+	return nil
+}
+
+// Delete ID로 엔티티를 삭제합니다.
+func (r *EmbeddedRepository[T]) Delete(ctx context.Context, id string) error {
+	err := r.items.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.items.Exists(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("entity with ID %s not found", id)
+		}
+		return r.items.Delete(tx, id)
+	})
+	if err != nil {
+		return domain.NewRepositoryError("Delete", err)
+	}
+	return nil
+}
+
+// FindAll 검색 조건에 맞는 모든 엔티티를 조회합니다.
+func (r *EmbeddedRepository[T]) FindAll(ctx context.Context, criteria domain.SearchCriteria) ([]T, error) {
+	var result []T
+	err := r.items.View(ctx, func(tx *kv.Tx) error {
+		entities, err := r.items.All(tx)
+		if err != nil {
+			return err
+		}
+		for _, entity := range entities {
+			ok, err := matches(criteria, entity)
+			if err != nil {
+				return err
+			}
+			if ok {
+				result = append(result, entity)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindAll", err)
+	}
+	return result, nil
+}
+
+// FindOne 검색 조건에 맞는 하나의 엔티티를 조회합니다.
+func (r *EmbeddedRepository[T]) FindOne(ctx context.Context, criteria domain.SearchCriteria) (T, error) {
+	var zero T
+	if _, ok := criteria.(domain.Matcher); !ok {
+		return zero, domain.NewRepositoryError("FindOne", fmt.Errorf("not implemented"))
+	}
+
+	result, err := r.FindAll(ctx, criteria)
+	if err != nil {
+		return zero, err
+	}
+	if len(result) == 0 {
+		return zero, domain.NewRepositoryError("FindOne", fmt.Errorf("no entity matches criteria"))
+	}
+	return result[0], nil
+}
+
+// WithTransaction 쓰기 트랜잭션 안에서 fn을 실행합니다.
+// 중첩 호출은 세이브포인트로 동작합니다.
+func (r *EmbeddedRepository[T]) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.items.DB().UpdateContext(ctx, fn)
+}
+
+// lookup 보조 인덱스로 엔티티 목록을 조회합니다.
+func (r *EmbeddedRepository[T]) lookup(ctx context.Context, index, value string) ([]T, error) {
+	var result []T
+	err := r.items.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.items.Lookup(tx, index, value)
+		result = found
+		return err
+	})
+	return result, err
+}
+
+// modify 엔티티를 읽어 fn으로 변경한 뒤 같은 트랜잭션에서 다시 기록합니다.
+func (r *EmbeddedRepository[T]) modify(ctx context.Context, id string, fn func(entity T)) error {
+	return r.items.Update(ctx, func(tx *kv.Tx) error {
+		entity, exists, err := r.items.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("entity with ID %s not found", id)
+		}
+		fn(entity)
+		return r.items.Put(tx, id, entity)
+	})
+}
+
+// EmbeddedAssetRepository Asset 도메인의 임베디드 저장소 구현체입니다.
+type EmbeddedAssetRepository struct {
+	*EmbeddedRepository[*Asset]
+}
+
+// NewEmbeddedAssetRepository 사용자 ID, 유형, 보유 심볼 인덱스를 가진 임베디드 Asset 저장소를 생성합니다.
+func NewEmbeddedAssetRepository(db *kv.DB) (*EmbeddedAssetRepository, error) {
+	repo := NewEmbeddedRepository[*Asset](db, assetBucket)
+	if err := repo.items.Index(indexUserID, func(a *Asset) []string { return []string{a.UserID} }); err != nil {
+		return nil, err
+	}
+	if err := repo.items.Index(indexType, func(a *Asset) []string { return []string{string(a.Type)} }); err != nil {
+		return nil, err
+	}
+	err := repo.items.Index(indexSymbol, func(a *Asset) []string {
+		if a.Holding == nil {
+			return nil
+		}
+		return []string{a.Holding.Symbol}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EmbeddedAssetRepository{EmbeddedRepository: repo}, nil
+}
+
+// FindByUserID 사용자 ID로 Asset 목록을 조회합니다.
+func (r *EmbeddedAssetRepository) FindByUserID(ctx context.Context, userID string) ([]*Asset, error) {
+	assets, err := r.lookup(ctx, indexUserID, userID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByUserID", err)
+	}
+	return assets, nil
+}
+
+// FindByType Asset 유형으로 Asset 목록을 조회합니다.
+func (r *EmbeddedAssetRepository) FindByType(ctx context.Context, assetType Type) ([]*Asset, error) {
+	assets, err := r.lookup(ctx, indexType, string(assetType))
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByType", err)
+	}
+	return assets, nil
+}
+
+// FindBySymbol 보유 포지션의 심볼로 Asset 목록을 조회합니다.
+func (r *EmbeddedAssetRepository) FindBySymbol(ctx context.Context, symbol string) ([]*Asset, error) {
+	assets, err := r.lookup(ctx, indexSymbol, symbol)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindBySymbol", err)
+	}
+	return assets, nil
+}
+
+// UpdateAmount 자산의 금액을 업데이트합니다.
+func (r *EmbeddedAssetRepository) UpdateAmount(ctx context.Context, id string, amount Money) error {
+	err := r.modify(ctx, id, func(asset *Asset) {
+		asset.Amount = amount
+		asset.UpdatedAt = time.Now()
+		asset.Version++
+	})
+	if err != nil {
+		return domain.NewRepositoryError("UpdateAmount", err)
+	}
+	return nil
+}
+
+// EmbeddedTransactionRepository Transaction 도메인의 임베디드 저장소 구현체입니다.
+type EmbeddedTransactionRepository struct {
+	*EmbeddedRepository[*Transaction]
+}
+
+// NewEmbeddedTransactionRepository 자산 ID 인덱스를 가진 임베디드 Transaction 저장소를 생성합니다.
+func NewEmbeddedTransactionRepository(db *kv.DB) (*EmbeddedTransactionRepository, error) {
+	repo := NewEmbeddedRepository[*Transaction](db, transactionBucket)
+	if err := repo.items.Index(indexAssetID, func(t *Transaction) []string { return []string{t.AssetID} }); err != nil {
+		return nil, err
+	}
+	return &EmbeddedTransactionRepository{EmbeddedRepository: repo}, nil
+}
+
+// FindByAssetID AssetID로 Transaction 목록을 조회합니다.
+func (r *EmbeddedTransactionRepository) FindByAssetID(ctx context.Context, assetID string) ([]*Transaction, error) {
+	transactions, err := r.lookup(ctx, indexAssetID, assetID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByAssetID", err)
+	}
+	return transactions, nil
+}
+
+// FindByDateRange 날짜 범위로 Transaction 목록을 조회합니다.
+func (r *EmbeddedTransactionRepository) FindByDateRange(ctx context.Context, start, end time.Time) ([]*Transaction, error) {
+	all, err := r.FindAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Transaction
+	for _, tx := range all {
+		if (tx.Date.Equal(start) || tx.Date.After(start)) &&
+			(tx.Date.Equal(end) || tx.Date.Before(end)) {
+			result = append(result, tx)
+		}
+	}
+	return result, nil
+}
+
+// GetTotalAmount 자산의 수입과 지출을 합산한 총 거래 금액을 계산합니다.
+func (r *EmbeddedTransactionRepository) GetTotalAmount(ctx context.Context, assetID string) (Money, error) {
+	transactions, err := r.FindByAssetID(ctx, assetID)
+	if err != nil {
+		return Money{}, err
+	}
+
+	total := Money{Amount: 0, Currency: "KRW"}
+	for _, tx := range transactions {
+		switch tx.Type {
+		case Income:
+			result, err := total.Add(tx.Amount)
+			if err != nil {
+				return Money{}, err
+			}
+			total = result
+		case Expense:
+			result, err := total.Subtract(tx.Amount)
+			if err != nil {
+				return Money{}, err
+			}
+			total = result
+		}
+	}
+	return total, nil
+}
+
+// EmbeddedPortfolioRepository Portfolio 도메인의 임베디드 저장소 구현체입니다.
+type EmbeddedPortfolioRepository struct {
+	*EmbeddedRepository[*Portfolio]
+}
+
+// NewEmbeddedPortfolioRepository 사용자 ID와 구성 자산 ID 인덱스를 가진 임베디드 Portfolio 저장소를 생성합니다.
+func NewEmbeddedPortfolioRepository(db *kv.DB) (*EmbeddedPortfolioRepository, error) {
+	repo := NewEmbeddedRepository[*Portfolio](db, portfolioBucket)
+	if err := repo.items.Index(indexUserID, func(p *Portfolio) []string { return []string{p.UserID} }); err != nil {
+		return nil, err
+	}
+	err := repo.items.Index(indexAssetID, func(p *Portfolio) []string {
+		ids := make([]string, 0, len(p.Assets))
+		for _, asset := range p.Assets {
+			ids = append(ids, asset.AssetID)
+		}
+		return ids
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EmbeddedPortfolioRepository{EmbeddedRepository: repo}, nil
+}
+
+// FindByUserID 사용자 ID로 Portfolio를 조회합니다.
+func (r *EmbeddedPortfolioRepository) FindByUserID(ctx context.Context, userID string) (*Portfolio, error) {
+	portfolios, err := r.lookup(ctx, indexUserID, userID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByUserID", err)
+	}
+	if len(portfolios) == 0 {
+		return nil, domain.NewRepositoryError("FindByUserID", fmt.Errorf("portfolio for user %s not found", userID))
+	}
+	return portfolios[0], nil
+}
+
+// FindByAssetID 자산을 구성에 포함한 Portfolio 목록을 조회합니다.
+func (r *EmbeddedPortfolioRepository) FindByAssetID(ctx context.Context, assetID string) ([]*Portfolio, error) {
+	portfolios, err := r.lookup(ctx, indexAssetID, assetID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByAssetID", err)
+	}
+	return portfolios, nil
+}
+
+// UpdateAssets Portfolio의 자산 구성을 업데이트합니다.
+func (r *EmbeddedPortfolioRepository) UpdateAssets(ctx context.Context, id string, assets []PortfolioAsset) error {
+	err := r.modify(ctx, id, func(portfolio *Portfolio) {
+		portfolio.Assets = assets
+		portfolio.UpdatedAt = time.Now()
+		portfolio.Version++
+	})
+	if err != nil {
+		return domain.NewRepositoryError("UpdateAssets", err)
+	}
+	return nil
+}
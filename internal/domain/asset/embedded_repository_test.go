@@ -0,0 +1,200 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/aske/go_fi_chart/internal/domain/query"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type embeddedRepositories struct {
+	db           *kv.DB
+	assets       *EmbeddedAssetRepository
+	transactions *EmbeddedTransactionRepository
+	portfolios   *EmbeddedPortfolioRepository
+}
+
+func openEmbeddedRepositories(t *testing.T, path string) *embeddedRepositories {
+	t.Helper()
+	db, err := kv.Open(path, nil)
+	require.NoError(t, err)
+
+	assets, err := NewEmbeddedAssetRepository(db)
+	require.NoError(t, err)
+	transactions, err := NewEmbeddedTransactionRepository(db)
+	require.NoError(t, err)
+	portfolios, err := NewEmbeddedPortfolioRepository(db)
+	require.NoError(t, err)
+
+	return &embeddedRepositories{db: db, assets: assets, transactions: transactions, portfolios: portfolios}
+}
+
+func Test_embedded_repository_should_persist_entities_across_restart(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data.kv")
+	repos := openEmbeddedRepositories(t, path)
+
+	asset, _ := NewAsset("user-1", Stock, "삼성전자", 1000, "KRW")
+	tx, _ := NewTransaction(asset.ID, Income, NewTestMoney(500, "KRW"), "배당", "")
+	portfolio := NewPortfolio("user-1", []PortfolioAsset{{AssetID: asset.ID, Weight: 100}})
+	require.NoError(t, repos.assets.Save(ctx, asset))
+	require.NoError(t, repos.transactions.Save(ctx, tx))
+	require.NoError(t, repos.portfolios.Save(ctx, portfolio))
+	require.NoError(t, repos.db.Close())
+
+	// When
+	reopened := openEmbeddedRepositories(t, path)
+	defer reopened.db.Close()
+
+	// Then
+	found, err := reopened.assets.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, asset.Name, found.Name)
+	assert.Equal(t, asset.Amount, found.Amount)
+
+	byAsset, err := reopened.transactions.FindByAssetID(ctx, asset.ID)
+	require.NoError(t, err)
+	require.Len(t, byAsset, 1)
+	assert.Equal(t, tx.ID, byAsset[0].ID)
+
+	byUser, err := reopened.portfolios.FindByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, portfolio.ID, byUser.ID)
+}
+
+func Test_embedded_repository_should_keep_secondary_indexes_in_sync(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repos := openEmbeddedRepositories(t, filepath.Join(t.TempDir(), "data.kv"))
+	defer repos.db.Close()
+
+	stock, _ := NewAsset("user-1", Stock, "주식", 1000, "KRW")
+	cash, _ := NewAsset("user-1", Cash, "예금", 2000, "KRW")
+	other, _ := NewAsset("user-2", Stock, "채권형", 3000, "KRW")
+	for _, a := range []*Asset{stock, cash, other} {
+		require.NoError(t, repos.assets.Save(ctx, a))
+	}
+
+	// When
+	stock.Type = Bond
+	require.NoError(t, repos.assets.Update(ctx, stock))
+	require.NoError(t, repos.assets.Delete(ctx, cash.ID))
+
+	// Then
+	byUser, err := repos.assets.FindByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, byUser, 1)
+	assert.Equal(t, stock.ID, byUser[0].ID)
+
+	stocks, err := repos.assets.FindByType(ctx, Stock)
+	require.NoError(t, err)
+	require.Len(t, stocks, 1)
+	assert.Equal(t, other.ID, stocks[0].ID)
+
+	bonds, err := repos.assets.FindByType(ctx, Bond)
+	require.NoError(t, err)
+	assert.Len(t, bonds, 1)
+}
+
+func Test_embedded_repository_should_roll_back_transaction_on_error(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repos := openEmbeddedRepositories(t, filepath.Join(t.TempDir(), "data.kv"))
+	defer repos.db.Close()
+
+	asset, _ := NewAsset("user-1", Cash, "예금", 1000, "KRW")
+	require.NoError(t, repos.assets.Save(ctx, asset))
+	boom := errors.New("boom")
+
+	// When
+	err := repos.assets.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := repos.assets.UpdateAmount(ctx, asset.ID, NewTestMoney(5000, "KRW")); err != nil {
+			return err
+		}
+		tx, _ := NewTransaction(asset.ID, Income, NewTestMoney(4000, "KRW"), "급여", "")
+		if err := repos.transactions.Save(ctx, tx); err != nil {
+			return err
+		}
+
+		found, err := repos.assets.FindByID(ctx, asset.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5000.0, found.Amount.Amount)
+		return boom
+	})
+
+	// Then
+	assert.ErrorIs(t, err, boom)
+	found, err := repos.assets.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, found.Amount.Amount)
+	transactions, err := repos.transactions.FindByAssetID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Empty(t, transactions)
+}
+
+func Test_embedded_repository_should_filter_by_query_criteria(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repos := openEmbeddedRepositories(t, filepath.Join(t.TempDir(), "data.kv"))
+	defer repos.db.Close()
+
+	small, _ := NewAsset("user-1", Stock, "소형주", 100, "KRW")
+	large, _ := NewAsset("user-1", Stock, "대형주", 5000, "KRW")
+	require.NoError(t, repos.assets.Save(ctx, small))
+	require.NoError(t, repos.assets.Save(ctx, large))
+
+	// When
+	result, err := repos.assets.FindAll(ctx, query.MustParse("amount.amount > 1000"))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, large.ID, result[0].ID)
+}
+
+func Test_embedded_repository_should_reject_duplicate_and_missing_entities(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repos := openEmbeddedRepositories(t, filepath.Join(t.TempDir(), "data.kv"))
+	defer repos.db.Close()
+	asset, _ := NewAsset("user-1", Cash, "예금", 1000, "KRW")
+	require.NoError(t, repos.assets.Save(ctx, asset))
+
+	// When & Then
+	assert.Error(t, repos.assets.Save(ctx, asset))
+	assert.Error(t, repos.assets.Delete(ctx, "missing"))
+	assert.Error(t, repos.assets.UpdateAmount(ctx, "missing", NewTestMoney(1, "KRW")))
+	_, err := repos.assets.FindByID(ctx, "missing")
+	assert.Error(t, err)
+}
+
+func Test_embedded_repository_should_reject_update_with_stale_version(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repos := openEmbeddedRepositories(t, filepath.Join(t.TempDir(), "data.kv"))
+	defer repos.db.Close()
+	asset, _ := NewAsset("user-1", Cash, "예금", 1000, "KRW")
+	require.NoError(t, repos.assets.Save(ctx, asset))
+	first, _ := repos.assets.FindByID(ctx, asset.ID)
+	second, _ := repos.assets.FindByID(ctx, asset.ID)
+	first.Name = "먼저"
+	require.NoError(t, repos.assets.Update(ctx, first))
+
+	// When
+	second.Name = "나중"
+	err := repos.assets.Update(ctx, second)
+
+	// Then
+	assert.True(t, IsVersionConflict(err))
+	assert.Equal(t, int64(1), second.Version)
+	found, err := repos.assets.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "먼저", found.Name)
+	assert.Equal(t, int64(2), found.Version)
+}
@@ -0,0 +1,141 @@
+package asset
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// GoalStatus 목표의 기한 내 달성 전망입니다.
+type GoalStatus string
+
+const (
+	GoalStatusOnTrack  GoalStatus = "ON_TRACK" // 기한이 있는 달에 달성 예상
+	GoalStatusAhead    GoalStatus = "AHEAD"    // 기한보다 한 달 이상 먼저 달성 예상
+	GoalStatusBehind   GoalStatus = "BEHIND"   // 기한 안에 달성할 수 없음
+	GoalStatusAchieved GoalStatus = "ACHIEVED" // 이미 달성
+)
+
+// MaxProjectionMonths 달성 예상일을 찾는 최대 개월 수입니다. 그 안에 닿지 못하면 달성할 수 없다고 봅니다.
+const MaxProjectionMonths = 1200
+
+// GoalProjection 현재 금액과 월 납입액으로 계산한 목표의 달성 전망입니다.
+type GoalProjection struct {
+	Current             Money
+	Target              Money
+	MonthsLeft          int        // 기한까지 남은 개월 수
+	RequiredMonthly     Money      // 기한 안에 목표에 닿으려면 매월 넣어야 하는 금액
+	MonthlyContribution Money      // 지금까지의 월평균 납입액
+	ProjectedDate       *time.Time // 지금 납입 속도로 목표에 닿는 날. 닿을 수 없으면 nil
+	Status              GoalStatus
+}
+
+// Project 현재 금액 current와 월 납입액 monthly로 목표의 달성 전망을 계산합니다.
+// 금액은 매월 기대 수익률의 1/12만큼 복리로 불어나고 월말에 납입한다고 가정합니다.
+func (g *Goal) Project(current Money, monthly float64, at time.Time) (GoalProjection, error) {
+	if g.Target.Amount <= 0 {
+		return GoalProjection{}, fmt.Errorf("목표 금액은 0보다 커야 합니다")
+	}
+	if current.Currency != g.Target.Currency {
+		return GoalProjection{}, fmt.Errorf("목표와 현재 금액의 통화가 다릅니다: %s, %s", g.Target.Currency, current.Currency)
+	}
+
+	rate := g.ExpectedReturn / 100 / 12
+	months := monthsUntil(at, g.Deadline)
+	projection := GoalProjection{
+		Current:             current,
+		Target:              g.Target,
+		MonthsLeft:          months,
+		RequiredMonthly:     Money{Amount: roundMoney(requiredMonthly(current.Amount, g.Target.Amount, rate, months)), Currency: g.Target.Currency},
+		MonthlyContribution: Money{Amount: roundMoney(monthly), Currency: g.Target.Currency},
+	}
+
+	if current.Amount >= g.Target.Amount {
+		projection.ProjectedDate = &at
+		projection.Status = GoalStatusAchieved
+		return projection, nil
+	}
+	reached := -1
+	for m := 1; m <= MaxProjectionMonths; m++ {
+		if futureValue(current.Amount, monthly, rate, m) >= g.Target.Amount-0.005 {
+			reached = m
+			break
+		}
+	}
+	switch {
+	case reached < 0:
+		projection.Status = GoalStatusBehind
+		return projection, nil
+	case reached < months:
+		projection.Status = GoalStatusAhead
+	case reached == months:
+		projection.Status = GoalStatusOnTrack
+	default:
+		projection.Status = GoalStatusBehind
+	}
+	date := at.AddDate(0, reached, 0)
+	projection.ProjectedDate = &date
+	return projection, nil
+}
+
+// Contribution 목표를 만든 뒤 at까지 목표 자산(assetID)에 넣은 거래의 월평균 순납입액을 계산합니다.
+// 수입과 다른 자산에서 들어온 이체는 납입으로, 지출과 이체(출금)는 인출로 보며 한 달이 안 됐으면 한 달로 나눕니다.
+// 목표 자산과 관계없는 거래는 건너뜁니다.
+func (g *Goal) Contribution(assetID string, txs []*Transaction, at time.Time) float64 {
+	var total float64
+	for _, tx := range txs {
+		if tx.Date.Before(g.CreatedAt) || tx.Date.After(at) || tx.Amount.Currency != g.Target.Currency {
+			continue
+		}
+		switch {
+		case tx.AssetID == assetID && tx.Type == Income:
+			total += tx.Amount.Amount
+		case tx.AssetID == assetID:
+			total -= tx.Amount.Amount
+		case tx.Type == Transfer && tx.CounterAssetID == assetID:
+			total += tx.Amount.Amount
+		}
+	}
+	elapsed := at.Sub(g.CreatedAt).Hours() / 24 / (365.25 / 12)
+	return total / math.Max(elapsed, 1)
+}
+
+// Track 달성 전망으로 목표의 진행률과 상태를 갱신하고 이전 상태와 변경 여부를 반환합니다.
+func (g *Goal) Track(projection GoalProjection) (GoalStatus, bool) {
+	previous := g.Status
+	g.UpdateProgress(projection.Current)
+	g.Status = projection.Status
+	return previous, previous != projection.Status
+}
+
+// monthsUntil from에서 to까지 온전히 지난 개월 수입니다. to가 지났으면 0입니다.
+func monthsUntil(from, to time.Time) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	return max(months, 0)
+}
+
+func futureValue(current, monthly, rate float64, months int) float64 {
+	if rate == 0 {
+		return current + monthly*float64(months)
+	}
+	growth := math.Pow(1+rate, float64(months))
+	return current*growth + monthly*(growth-1)/rate
+}
+
+func requiredMonthly(current, target, rate float64, months int) float64 {
+	if months == 0 {
+		return math.Max(target-current, 0)
+	}
+	growth := math.Pow(1+rate, float64(months))
+	need := target - current*growth
+	if need <= 0 {
+		return 0
+	}
+	if rate == 0 {
+		return need / float64(months)
+	}
+	return need * rate / (growth - 1)
+}
@@ -0,0 +1,122 @@
+package asset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var goalStart = time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+func newSavingGoal(expectedReturn float64) *Goal {
+	return &Goal{
+		Type:           GoalTypeSaving,
+		Target:         NewTestMoney(1200000, "KRW"),
+		Deadline:       goalStart.AddDate(1, 0, 0),
+		ExpectedReturn: expectedReturn,
+		CreatedAt:      goalStart,
+	}
+}
+
+func Test_Goal_Project_should_compare_contribution_with_required_amount(t *testing.T) {
+	// Given
+	goal := newSavingGoal(0)
+	empty := NewTestMoney(0, "KRW")
+
+	// When
+	onTrack, err := goal.Project(empty, 100000, goalStart)
+	require.NoError(t, err)
+	ahead, err := goal.Project(empty, 150000, goalStart)
+	require.NoError(t, err)
+	behind, err := goal.Project(empty, 50000, goalStart)
+	require.NoError(t, err)
+	stalled, err := goal.Project(empty, 0, goalStart)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, 12, onTrack.MonthsLeft)
+	assert.Equal(t, 100000.0, onTrack.RequiredMonthly.Amount)
+	assert.Equal(t, GoalStatusOnTrack, onTrack.Status)
+	assert.Equal(t, goal.Deadline, *onTrack.ProjectedDate)
+	assert.Equal(t, GoalStatusAhead, ahead.Status)
+	assert.Equal(t, goalStart.AddDate(0, 8, 0), *ahead.ProjectedDate)
+	assert.Equal(t, GoalStatusBehind, behind.Status)
+	assert.Equal(t, goalStart.AddDate(0, 24, 0), *behind.ProjectedDate)
+	assert.Equal(t, GoalStatusBehind, stalled.Status)
+	assert.Nil(t, stalled.ProjectedDate)
+}
+
+func Test_Goal_Project_should_compound_expected_return(t *testing.T) {
+	// Given
+	goal := newSavingGoal(12)
+
+	// When
+	projection, err := goal.Project(NewTestMoney(0, "KRW"), 94618.55, goalStart)
+	require.NoError(t, err)
+	achieved, err := goal.Project(NewTestMoney(1300000, "KRW"), 0, goalStart)
+	require.NoError(t, err)
+	overdue, err := goal.Project(NewTestMoney(200000, "KRW"), 0, goalStart.AddDate(2, 0, 0))
+	require.NoError(t, err)
+	_, mismatched := goal.Project(NewTestMoney(100, "USD"), 0, goalStart)
+
+	// Then
+	assert.Equal(t, 94618.55, projection.RequiredMonthly.Amount)
+	assert.Equal(t, GoalStatusOnTrack, projection.Status)
+	assert.Equal(t, GoalStatusAchieved, achieved.Status)
+	assert.Zero(t, achieved.RequiredMonthly.Amount)
+	assert.Zero(t, overdue.MonthsLeft)
+	assert.Equal(t, 1000000.0, overdue.RequiredMonthly.Amount)
+	assert.Error(t, mismatched)
+}
+
+func Test_Goal_Contribution_should_average_net_deposits_since_creation(t *testing.T) {
+	// Given
+	goal := newSavingGoal(0)
+	transaction := func(txType TransactionType, amount float64, date time.Time) *Transaction {
+		tx, err := NewTransaction("asset-1", txType, NewTestMoney(amount, "KRW"), "저축", "")
+		require.NoError(t, err)
+		tx.Date = date
+		return tx
+	}
+	txs := []*Transaction{
+		transaction(Income, 500000, goalStart.AddDate(0, 0, -1)), // 목표 이전
+		transaction(Income, 300000, goalStart.AddDate(0, 0, 5)),
+		transaction(Income, 300000, goalStart.AddDate(0, 1, 5)),
+		transaction(Expense, 100000, goalStart.AddDate(0, 1, 20)),
+		transaction(Transfer, 50000, goalStart.AddDate(0, 1, 25)),
+	}
+	// 다른 자산에서 들어온 이체는 납입이고, 목표 자산과 관계없는 거래는 건너뜁니다
+	inbound := transaction(Transfer, 200000, goalStart.AddDate(0, 1, 26))
+	inbound.AssetID, inbound.CounterAssetID = "asset-2", "asset-1"
+	unrelated := transaction(Income, 900000, goalStart.AddDate(0, 1, 27))
+	unrelated.AssetID = "asset-2"
+	txs = append(txs, inbound, unrelated)
+
+	// When
+	twoMonths := goal.Contribution("asset-1", txs, goalStart.Add(2*365.25/12*24*time.Hour))
+	firstWeek := goal.Contribution("asset-1", txs, goalStart.AddDate(0, 0, 7))
+
+	// Then
+	assert.InDelta(t, 325000, twoMonths, 1e-6)
+	assert.Equal(t, 300000.0, firstWeek)
+}
+
+func Test_Goal_Track_should_report_status_changes(t *testing.T) {
+	// Given
+	goal := newSavingGoal(0)
+	projection, err := goal.Project(NewTestMoney(600000, "KRW"), 50000, goalStart)
+	require.NoError(t, err)
+
+	// When
+	previous, changed := goal.Track(projection)
+	_, again := goal.Track(projection)
+
+	// Then
+	assert.Equal(t, GoalStatus(""), previous)
+	assert.True(t, changed)
+	assert.False(t, again)
+	assert.Equal(t, GoalStatusOnTrack, goal.Status)
+	assert.Equal(t, 50.0, goal.Progress)
+}
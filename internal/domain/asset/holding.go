@@ -0,0 +1,260 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/event"
+)
+
+// Holding 시장 가격으로 평가되는 자산의 보유 포지션입니다.
+// 가격이 반영되기 전에는 취득 원가로 평가합니다.
+type Holding struct {
+	Symbol    string
+	Quantity  float64
+	CostBasis Money
+	Price     Money
+	PricedAt  time.Time
+}
+
+// NewHolding 보유 포지션을 생성합니다.
+func NewHolding(symbol string, quantity float64, costBasis Money) (*Holding, error) {
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return nil, fmt.Errorf("심볼은 비어있을 수 없습니다")
+	}
+	if quantity < 0 {
+		return nil, fmt.Errorf("수량은 음수가 될 수 없습니다: %f", quantity)
+	}
+	if costBasis.Currency == "" {
+		return nil, fmt.Errorf("통화는 비어있을 수 없습니다")
+	}
+	return &Holding{
+		Symbol:    symbol,
+		Quantity:  quantity,
+		CostBasis: costBasis,
+	}, nil
+}
+
+// IsPriced 시장 가격이 반영되었는지 확인합니다.
+func (h *Holding) IsPriced() bool {
+	return !h.PricedAt.IsZero()
+}
+
+// MarketValue 수량 × 시장 가격으로 평가 금액을 계산합니다.
+func (h *Holding) MarketValue() (Money, error) {
+	if !h.IsPriced() {
+		return h.CostBasis, nil
+	}
+	return h.Price.Multiply(h.Quantity)
+}
+
+// SetHolding 자산의 보유 포지션을 설정하고 평가 금액을 다시 계산합니다.
+// nil을 전달하면 보유 포지션을 제거하며 금액은 그대로 유지됩니다.
+func (a *Asset) SetHolding(holding *Holding) error {
+	a.Holding = holding
+	a.UpdatedAt = time.Now()
+	if holding == nil {
+		return nil
+	}
+
+	value, err := holding.MarketValue()
+	if err != nil {
+		return err
+	}
+	a.changeAmount(value)
+	return nil
+}
+
+// Revalue 시장 가격으로 보유 포지션을 다시 평가합니다.
+// 이미 반영된 가격보다 오래된 가격은 무시하며, 금액이 바뀌었는지 반환합니다.
+func (a *Asset) Revalue(price Money, pricedAt time.Time) (bool, error) {
+	if a.Holding == nil {
+		return false, fmt.Errorf("보유 포지션이 없는 자산입니다: %s", a.ID)
+	}
+	if price.Currency != a.Holding.CostBasis.Currency {
+		return false, fmt.Errorf("통화가 일치하지 않습니다: %s != %s", price.Currency, a.Holding.CostBasis.Currency)
+	}
+	if pricedAt.Before(a.Holding.PricedAt) {
+		return false, nil
+	}
+
+	holding := *a.Holding
+	holding.Price = price
+	holding.PricedAt = pricedAt
+	value, err := holding.MarketValue()
+	if err != nil {
+		return false, err
+	}
+
+	a.Holding = &holding
+	return a.changeAmount(value), nil
+}
+
+// ApplyTrade 매매 결과를 자산에 반영합니다. amount(자산 통화)와 quantity는 매수면 양수, 매도면 음수입니다.
+// 보유 포지션이 있으면 수량과 취득원가를 조정하고(매도분은 평균 단가로 원가를 덜어냅니다) 평가 금액을 다시 계산하며,
+// 없으면 금액만 조정합니다.
+func (a *Asset) ApplyTrade(amount, quantity float64) error {
+	if a.Holding == nil {
+		if a.Amount.Amount+amount < 0 {
+			return fmt.Errorf("매도 금액이 자산 금액보다 큽니다: %s", a.ID)
+		}
+		a.changeAmount(Money{Amount: a.Amount.Amount + amount, Currency: a.Amount.Currency})
+		return nil
+	}
+	return a.adjustHolding(amount, quantity)
+}
+
+// SettleTrade 이체 거래로 금액이 이미 반영된 매매를 보유 포지션에 반영합니다. amount와 quantity는 ApplyTrade와 같습니다.
+// 보유 포지션이 있으면 수량과 취득원가를 조정하고 평가 금액을 다시 계산하며, 없으면 바꾸지 않습니다.
+func (a *Asset) SettleTrade(amount, quantity float64) error {
+	if a.Holding == nil {
+		return nil
+	}
+	return a.adjustHolding(amount, quantity)
+}
+
+// adjustHolding 매매한 수량과 금액으로 보유 포지션을 조정하고 평가 금액을 다시 계산합니다.
+func (a *Asset) adjustHolding(amount, quantity float64) error {
+	holding := *a.Holding
+	if holding.Quantity+quantity < 0 {
+		return fmt.Errorf("매도 수량이 보유 수량보다 큽니다: %s", holding.Symbol)
+	}
+	if quantity < 0 && holding.Quantity > 0 {
+		holding.CostBasis.Amount *= (holding.Quantity + quantity) / holding.Quantity
+	} else {
+		holding.CostBasis.Amount += amount
+	}
+	holding.Quantity += quantity
+	value, err := holding.MarketValue()
+	if err != nil {
+		return err
+	}
+	a.Holding = &holding
+	a.changeAmount(value)
+	return nil
+}
+
+// changeAmount 금액과 성과의 현재 가치를 갱신하고 금액 변경 이벤트를 추가합니다.
+func (a *Asset) changeAmount(amount Money) bool {
+	if a.Amount.Equals(amount) {
+		return false
+	}
+
+	prevAmount := a.Amount
+	now := time.Now()
+	a.Amount = amount
+	a.UpdatedAt = now
+	if a.Performance != nil {
+		a.Performance.CurrentValue = amount
+		a.Performance.LastUpdateTime = now
+		if start := a.Performance.StartValue; start.Currency == amount.Currency && !start.IsZero() {
+			a.Performance.GrowthRate = (amount.Amount - start.Amount) / start.Amount * 100
+		}
+	}
+
+	a.AddEvent(event.NewEvent(
+		event.TypeAssetAmountChanged,
+		a.ID,
+		"asset",
+		map[string]interface{}{
+			"amount":     amount,
+			"prevAmount": prevAmount,
+		},
+		map[string]string{
+			"userID": a.UserID,
+		},
+		1,
+	))
+	return true
+}
+
+// PriceUpdate 가격 갱신 이벤트의 페이로드입니다.
+type PriceUpdate struct {
+	Symbol   string
+	Price    Money
+	PricedAt time.Time
+}
+
+// NewPriceUpdatedEvent 심볼의 시장 가격이 갱신되었음을 알리는 이벤트를 생성합니다.
+func NewPriceUpdatedEvent(symbol string, price Money, pricedAt time.Time) event.Event {
+	return event.NewEvent(
+		event.TypePriceUpdated,
+		symbol,
+		"price",
+		PriceUpdate{Symbol: symbol, Price: price, PricedAt: pricedAt},
+		nil,
+		1,
+	)
+}
+
+// Revaluer 가격 갱신 이벤트를 받아 같은 심볼의 보유 자산을 다시 평가하는 이벤트 핸들러입니다.
+type Revaluer struct {
+	repo Repository
+	bus  event.Bus
+}
+
+// NewRevaluer Revaluer를 생성합니다. bus가 nil이면 금액 변경 이벤트를 발행하지 않습니다.
+func NewRevaluer(repo Repository, bus event.Bus) *Revaluer {
+	return &Revaluer{repo: repo, bus: bus}
+}
+
+// HandlerName 핸들러의 이름을 반환합니다.
+func (r *Revaluer) HandlerName() string {
+	return "asset.revaluer"
+}
+
+// HandleEvent 가격 갱신 이벤트만 처리하고 나머지 이벤트는 무시합니다.
+func (r *Revaluer) HandleEvent(ctx context.Context, evt event.Event) error {
+	if evt.EventType() != event.TypePriceUpdated {
+		return nil
+	}
+	update, ok := evt.Payload().(PriceUpdate)
+	if !ok {
+		return fmt.Errorf("알 수 없는 가격 이벤트 페이로드입니다: %T", evt.Payload())
+	}
+	_, err := r.Revalue(ctx, update.Symbol, update.Price, update.PricedAt)
+	return err
+}
+
+// Revalue 심볼을 보유한 자산을 모두 다시 평가하고 금액이 바뀐 자산 수를 반환합니다.
+// 한 자산의 실패가 다른 자산의 평가를 막지 않도록 에러는 모아서 반환합니다.
+func (r *Revaluer) Revalue(ctx context.Context, symbol string, price Money, pricedAt time.Time) (int, error) {
+	assets, err := r.repo.FindBySymbol(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	var revalued int
+	var errs []error
+	for _, a := range assets {
+		a.ClearEvents()
+		changed, err := a.Revalue(price, pricedAt)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !changed {
+			continue
+		}
+		if err := r.repo.Update(ctx, a); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		revalued++
+		r.publish(ctx, a)
+	}
+	return revalued, errors.Join(errs...)
+}
+
+func (r *Revaluer) publish(ctx context.Context, a *Asset) {
+	if r.bus != nil {
+		for _, evt := range a.GetUncommittedEvents() {
+			_ = r.bus.Publish(ctx, evt)
+		}
+	}
+	a.ClearEvents()
+}
@@ -0,0 +1,193 @@
+package asset
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHoldingAsset(t *testing.T, symbol string, quantity, costBasis float64) *Asset {
+	t.Helper()
+	asset, err := NewAsset("user-1", Stock, symbol, costBasis, "USD")
+	require.NoError(t, err)
+	holding, err := NewHolding(symbol, quantity, NewTestMoney(costBasis, "USD"))
+	require.NoError(t, err)
+	require.NoError(t, asset.SetHolding(holding))
+	asset.ClearEvents()
+	return asset
+}
+
+func Test_NewHolding_should_reject_invalid_position(t *testing.T) {
+	_, err := NewHolding(" ", 10, NewTestMoney(1000, "USD"))
+	assert.Error(t, err)
+
+	_, err = NewHolding("TSLA", -1, NewTestMoney(1000, "USD"))
+	assert.Error(t, err)
+
+	holding, err := NewHolding(" TSLA ", 10, NewTestMoney(1000, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, "TSLA", holding.Symbol)
+	assert.False(t, holding.IsPriced())
+}
+
+func Test_Asset_Revalue_should_value_holding_at_quantity_times_price(t *testing.T) {
+	// Given
+	asset := newHoldingAsset(t, "TSLA", 10, 1000)
+
+	// When
+	changed, err := asset.Revalue(NewTestMoney(150, "USD"), time.Now())
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, NewTestMoney(1500, "USD"), asset.Amount)
+	assert.Equal(t, NewTestMoney(1500, "USD"), asset.Performance.CurrentValue)
+	assert.InDelta(t, 50.0, asset.Performance.GrowthRate, 1e-9)
+
+	events := asset.GetUncommittedEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, event.TypeAssetAmountChanged, events[0].EventType())
+}
+
+func Test_Asset_Revalue_should_ignore_stale_price(t *testing.T) {
+	// Given
+	asset := newHoldingAsset(t, "TSLA", 10, 1000)
+	now := time.Now()
+	_, err := asset.Revalue(NewTestMoney(150, "USD"), now)
+	require.NoError(t, err)
+
+	// When
+	changed, err := asset.Revalue(NewTestMoney(90, "USD"), now.Add(-time.Minute))
+
+	// Then
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, 1500.0, asset.Amount.Amount)
+}
+
+func Test_Asset_Revalue_should_fail_without_holding_or_on_currency_mismatch(t *testing.T) {
+	plain, _ := NewAsset("user-1", Cash, "예금", 1000, "USD")
+	_, err := plain.Revalue(NewTestMoney(150, "USD"), time.Now())
+	assert.Error(t, err)
+
+	asset := newHoldingAsset(t, "TSLA", 10, 1000)
+	_, err = asset.Revalue(NewTestMoney(150, "KRW"), time.Now())
+	assert.Error(t, err)
+}
+
+func Test_Asset_ApplyTrade_should_adjust_quantity_and_cost_basis(t *testing.T) {
+	// Given
+	stock := newHoldingAsset(t, "AAPL", 10, 1500)
+	_, err := stock.Revalue(NewTestMoney(200, "USD"), time.Now())
+	require.NoError(t, err)
+	cash, err := NewAsset("user-1", Cash, "예수금", 1000, "USD")
+	require.NoError(t, err)
+	stock.ClearEvents()
+
+	// When
+	require.NoError(t, stock.ApplyTrade(-400, -2))
+	require.NoError(t, stock.ApplyTrade(600, 3))
+	require.NoError(t, cash.ApplyTrade(-300, 0))
+	oversold := stock.ApplyTrade(-4000, -20)
+	overdrawn := cash.ApplyTrade(-1000, 0)
+
+	// Then
+	assert.Equal(t, 11.0, stock.Holding.Quantity)
+	assert.Equal(t, 1800.0, stock.Holding.CostBasis.Amount)
+	assert.Equal(t, 2200.0, stock.Amount.Amount)
+	assert.Len(t, stock.GetUncommittedEvents(), 2)
+	assert.Equal(t, 700.0, cash.Amount.Amount)
+	assert.Error(t, oversold)
+	assert.Error(t, overdrawn)
+}
+
+func Test_Asset_SettleTrade_should_adjust_holding_after_transfer(t *testing.T) {
+	// Given
+	stock := newHoldingAsset(t, "AAPL", 10, 1500)
+	_, err := stock.Revalue(NewTestMoney(200, "USD"), time.Now())
+	require.NoError(t, err)
+	cash, err := NewAsset("user-1", Cash, "예수금", 1000, "USD")
+	require.NoError(t, err)
+	sale, err := NewTransaction(stock.ID, Transfer, NewTestMoney(400, "USD"), "", "매도")
+	require.NoError(t, err)
+	sale.CounterAssetID = cash.ID
+	require.NoError(t, stock.ProcessTransaction(sale))
+	require.NoError(t, cash.ReceiveTransfer(sale))
+
+	// When
+	require.NoError(t, stock.SettleTrade(-400, -2))
+	require.NoError(t, cash.SettleTrade(400, 0))
+
+	// Then
+	assert.Equal(t, 8.0, stock.Holding.Quantity)
+	assert.Equal(t, 1200.0, stock.Holding.CostBasis.Amount)
+	assert.Equal(t, 1600.0, stock.Amount.Amount)
+	assert.Equal(t, 1400.0, cash.Amount.Amount)
+	assert.Error(t, stock.SettleTrade(-4000, -20))
+}
+
+func Test_Revaluer_should_revalue_assets_holding_updated_symbol(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryAssetRepository()
+	tesla := newHoldingAsset(t, "TSLA", 10, 1000)
+	apple := newHoldingAsset(t, "AAPL", 5, 1000)
+	plain, _ := NewAsset("user-1", Cash, "예금", 1000, "USD")
+	for _, a := range []*Asset{tesla, apple, plain} {
+		require.NoError(t, repo.Save(ctx, a))
+	}
+	bus := memory.NewEventBus()
+	defer bus.Close()
+	revaluer := NewRevaluer(repo, bus)
+	require.NoError(t, bus.Subscribe(revaluer))
+
+	// When
+	err := bus.Publish(ctx, NewPriceUpdatedEvent("TSLA", NewTestMoney(120, "USD"), time.Now()))
+
+	// Then
+	require.NoError(t, err)
+	found, err := repo.FindByID(ctx, tesla.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1200.0, found.Amount.Amount)
+	assert.Equal(t, 120.0, found.Holding.Price.Amount)
+
+	untouched, err := repo.FindByID(ctx, apple.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, untouched.Amount.Amount)
+}
+
+func Test_embedded_repository_should_find_assets_by_holding_symbol(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repos := openEmbeddedRepositories(t, filepath.Join(t.TempDir(), "data.kv"))
+	defer repos.db.Close()
+
+	tesla := newHoldingAsset(t, "TSLA", 10, 1000)
+	plain, _ := NewAsset("user-1", Cash, "예금", 1000, "USD")
+	require.NoError(t, repos.assets.Save(ctx, tesla))
+	require.NoError(t, repos.assets.Save(ctx, plain))
+
+	// When
+	revalued, err := NewRevaluer(repos.assets, nil).Revalue(ctx, "TSLA", NewTestMoney(120, "USD"), time.Now())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, revalued)
+	found, err := repos.assets.FindBySymbol(ctx, "TSLA")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, 1200.0, found[0].Amount.Amount)
+	assert.True(t, found[0].Holding.IsPriced())
+
+	require.NoError(t, found[0].SetHolding(nil))
+	require.NoError(t, repos.assets.Update(ctx, found[0]))
+	found, err = repos.assets.FindBySymbol(ctx, "TSLA")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
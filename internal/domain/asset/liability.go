@@ -0,0 +1,360 @@
+package asset
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// PaymentFrequency 부채의 상환 주기를 나타냅니다.
+type PaymentFrequency string
+
+const (
+	Monthly  PaymentFrequency = "MONTHLY"
+	BiWeekly PaymentFrequency = "BIWEEKLY"
+	Weekly   PaymentFrequency = "WEEKLY"
+)
+
+// PeriodsPerYear 1년 동안의 상환 횟수를 반환합니다.
+func (f PaymentFrequency) PeriodsPerYear() int {
+	switch f {
+	case Monthly:
+		return 12
+	case BiWeekly:
+		return 26
+	case Weekly:
+		return 52
+	default:
+		return 0
+	}
+}
+
+// dueDate start로부터 n번째 상환일을 계산합니다.
+// 매월 상환일은 실행일과 같은 날이며, 그 날이 없는 달(예: 1월 31일 실행의 2월)은 말일로 당깁니다.
+func (f PaymentFrequency) dueDate(start time.Time, n int) time.Time {
+	switch f {
+	case BiWeekly:
+		return start.AddDate(0, 0, 14*n)
+	case Weekly:
+		return start.AddDate(0, 0, 7*n)
+	}
+
+	first := time.Date(start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	month := first.AddDate(0, n, 0)
+	day := start.Day()
+	if last := month.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return month.AddDate(0, 0, day-1)
+}
+
+// AmortizationMethod 부채의 상환 방식을 나타냅니다.
+type AmortizationMethod string
+
+const (
+	// EqualInstallment 원리금 균등 상환: 매회 같은 금액을 내며 이자 비중이 점점 줄어듭니다
+	EqualInstallment AmortizationMethod = "EQUAL_INSTALLMENT"
+	// EqualPrincipal 원금 균등 상환: 매회 같은 원금에 남은 잔액의 이자를 더해 냅니다
+	EqualPrincipal AmortizationMethod = "EQUAL_PRINCIPAL"
+)
+
+// Liability 대출, 주택담보대출, 신용카드 같은 부채 자산의 상환 조건입니다.
+// 부채 자산의 Amount는 남은 원금(양수)이며, Principal은 최초 원금입니다.
+type Liability struct {
+	Principal  Money
+	AnnualRate float64 // 연 이율(%)
+	Term       int     // 총 상환 횟수, 0이면 만기가 없는 리볼빙 부채(신용카드)입니다
+	Frequency  PaymentFrequency
+	Method     AmortizationMethod
+	StartDate  time.Time // 실행일, 첫 상환일은 한 주기 뒤입니다
+}
+
+// NewLiability 부채 상환 조건을 생성합니다.
+func NewLiability(principal Money, annualRate float64, term int, frequency PaymentFrequency, method AmortizationMethod, startDate time.Time) (*Liability, error) {
+	if principal.Currency == "" {
+		return nil, fmt.Errorf("통화는 비어있을 수 없습니다")
+	}
+	if principal.Amount < 0 {
+		return nil, fmt.Errorf("원금은 음수가 될 수 없습니다: %f", principal.Amount)
+	}
+	if annualRate < 0 || annualRate > 100 {
+		return nil, fmt.Errorf("연 이율은 0에서 100 사이여야 합니다: %f", annualRate)
+	}
+	if term < 0 {
+		return nil, fmt.Errorf("상환 횟수는 음수가 될 수 없습니다: %d", term)
+	}
+	if frequency == "" {
+		frequency = Monthly
+	}
+	if frequency.PeriodsPerYear() == 0 {
+		return nil, fmt.Errorf("지원하지 않는 상환 주기입니다: %s", frequency)
+	}
+	if method == "" {
+		method = EqualInstallment
+	}
+	if method != EqualInstallment && method != EqualPrincipal {
+		return nil, fmt.Errorf("지원하지 않는 상환 방식입니다: %s", method)
+	}
+	if startDate.IsZero() {
+		startDate = time.Now()
+	}
+	return &Liability{
+		Principal:  principal,
+		AnnualRate: annualRate,
+		Term:       term,
+		Frequency:  frequency,
+		Method:     method,
+		StartDate:  startDate,
+	}, nil
+}
+
+// PeriodRate 한 상환 주기의 이율(소수)을 반환합니다.
+func (l *Liability) PeriodRate() float64 {
+	periods := l.Frequency.PeriodsPerYear()
+	if periods == 0 {
+		return 0
+	}
+	return l.AnnualRate / 100 / float64(periods)
+}
+
+// Installment 상환 일정의 한 회차입니다.
+type Installment struct {
+	Number    int
+	DueDate   time.Time
+	Payment   float64
+	Interest  float64
+	Principal float64
+	Balance   float64 // 이번 회차 상환 후 남은 원금
+}
+
+// Schedule 최초 원금과 상환 조건으로 전체 상환 일정을 계산합니다. 만기가 없는 부채는 빈 일정을 반환합니다.
+func (l *Liability) Schedule() []Installment {
+	return l.schedule(l.Principal.Amount, 0)
+}
+
+// Remaining 남은 원금 balance로 at 이후의 상환 일정을 다시 계산합니다.
+// 이미 지난 회차는 상환된 것으로 보고 남은 회차에 잔액을 나누며, 만기가 지났으면 다음 주기에 전액을 상환하는 일정을 반환합니다.
+func (l *Liability) Remaining(balance float64, at time.Time) []Installment {
+	if l.Term == 0 || balance <= 0 {
+		return nil
+	}
+	paid := 0
+	for paid < l.Term && !l.Frequency.dueDate(l.StartDate, paid+1).After(at) {
+		paid++
+	}
+	if paid == l.Term {
+		// 만기가 지난 잔액은 다음 상환일에 한 번에 상환합니다
+		n := l.Term + 1
+		for !l.Frequency.dueDate(l.StartDate, n).After(at) {
+			n++
+		}
+		interest := roundMoney(balance * l.PeriodRate())
+		return []Installment{{
+			Number:    n,
+			DueDate:   l.Frequency.dueDate(l.StartDate, n),
+			Payment:   roundMoney(balance + interest),
+			Interest:  interest,
+			Principal: balance,
+		}}
+	}
+	return l.schedule(balance, paid)
+}
+
+// schedule balance를 paid 회차 이후의 남은 회차에 나누어 상환하는 일정을 계산합니다.
+func (l *Liability) schedule(balance float64, paid int) []Installment {
+	count := l.Term - paid
+	if count <= 0 || balance <= 0 {
+		return nil
+	}
+
+	rate := l.PeriodRate()
+	installment := balance / float64(count)
+	if l.Method == EqualInstallment && rate > 0 {
+		installment = balance * rate / (1 - math.Pow(1+rate, -float64(count)))
+	}
+	installment = roundMoney(installment)
+	principalPart := roundMoney(balance / float64(count))
+
+	schedule := make([]Installment, 0, count)
+	for i := 1; i <= count; i++ {
+		interest := roundMoney(balance * rate)
+		var principal float64
+		switch {
+		case i == count:
+			// 반올림 오차는 마지막 회차에서 정리합니다
+			principal = balance
+		case l.Method == EqualPrincipal:
+			principal = principalPart
+		default:
+			principal = installment - interest
+		}
+		principal = math.Min(roundMoney(principal), balance)
+		balance = roundMoney(balance - principal)
+		schedule = append(schedule, Installment{
+			Number:    paid + i,
+			DueDate:   l.Frequency.dueDate(l.StartDate, paid+i),
+			Payment:   roundMoney(interest + principal),
+			Interest:  interest,
+			Principal: principal,
+			Balance:   balance,
+		})
+	}
+	return schedule
+}
+
+// PaymentSplit 상환 금액을 이자와 원금으로 나눈 결과입니다.
+type PaymentSplit struct {
+	Payment   Money
+	Interest  Money
+	Principal Money
+	Balance   Money // 상환 후 남은 원금
+}
+
+// Split 남은 원금 balance에 대한 상환 금액 payment를 이자와 원금으로 나눕니다.
+// 이번 주기 이자를 먼저 갚고 나머지를 원금에 충당하며, 원금을 넘는 금액은 허용하지 않습니다.
+func (l *Liability) Split(balance, payment Money) (PaymentSplit, error) {
+	if balance.Currency != payment.Currency {
+		return PaymentSplit{}, fmt.Errorf("통화가 일치하지 않습니다: %s != %s", payment.Currency, balance.Currency)
+	}
+	if !payment.IsPositive() {
+		return PaymentSplit{}, fmt.Errorf("상환 금액은 0보다 커야 합니다")
+	}
+
+	interest := math.Min(roundMoney(balance.Amount*l.PeriodRate()), payment.Amount)
+	principal := roundMoney(payment.Amount - interest)
+	if principal > balance.Amount {
+		return PaymentSplit{}, fmt.Errorf("상환 금액이 남은 원금과 이자보다 큽니다: %s", payment)
+	}
+	return PaymentSplit{
+		Payment:   payment,
+		Interest:  Money{Amount: interest, Currency: payment.Currency},
+		Principal: Money{Amount: principal, Currency: payment.Currency},
+		Balance:   Money{Amount: roundMoney(balance.Amount - principal), Currency: payment.Currency},
+	}, nil
+}
+
+// IsLiability 부채 유형인지 확인합니다. 부채 자산의 금액은 순자산에서 뺍니다.
+func (t Type) IsLiability() bool {
+	switch t {
+	case Loan, Mortgage, CreditCard:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewLiabilityAsset 상환 조건을 가진 부채 자산을 생성합니다. 금액은 최초 원금에서 시작합니다.
+func NewLiabilityAsset(userID string, liabilityType Type, name string, liability *Liability) (*Asset, error) {
+	if !liabilityType.IsLiability() {
+		return nil, fmt.Errorf("부채 유형이 아닙니다: %s", liabilityType)
+	}
+	if liability == nil {
+		return nil, fmt.Errorf("상환 조건이 필요합니다")
+	}
+	a, err := NewAsset(userID, liabilityType, name, liability.Principal.Amount, liability.Principal.Currency)
+	if err != nil {
+		return nil, err
+	}
+	a.Liability = liability
+	return a, nil
+}
+
+// InterestCategory 부채 상환 이자를 지출로 기록할 때 쓰는 거래 분류입니다.
+const InterestCategory = "이자"
+
+// Repayment 부채 상환을 출금 자산에서 부채로의 원금 이체와 출금 자산의 이자 지출로 나눈 거래입니다.
+// 원금이나 이자가 0이면 해당 거래는 nil입니다.
+type Repayment struct {
+	Split     PaymentSplit
+	Principal *Transaction
+	Interest  *Transaction
+}
+
+// Transactions 반영할 거래를 이자, 원금 순으로 반환합니다.
+func (r *Repayment) Transactions() []*Transaction {
+	txs := make([]*Transaction, 0, 2)
+	for _, tx := range []*Transaction{r.Interest, r.Principal} {
+		if tx != nil {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
+
+// PlanRepayment 부채 자산의 상환 금액을 이자와 원금으로 나누고, sourceID 자산에서 내는 거래를 만듭니다.
+// 원금은 부채로의 이체(Transfer)이며 이자는 출금 자산의 지출(Expense)입니다.
+// 자산은 바꾸지 않으므로 호출자가 거래를 반영하고(원장이 있으면 Ledger.Record) 저장해야 합니다.
+func (a *Asset) PlanRepayment(sourceID string, payment Money) (*Repayment, error) {
+	if !a.Type.IsLiability() {
+		return nil, fmt.Errorf("부채 자산이 아닙니다: %s", a.ID)
+	}
+	if sourceID == a.ID {
+		return nil, fmt.Errorf("같은 자산으로는 상환할 수 없습니다")
+	}
+	terms := a.Liability
+	if terms == nil {
+		// 상환 조건이 없으면 이자 없이 원금만 상환합니다
+		terms = &Liability{Principal: a.OriginalPrincipal()}
+	}
+	split, err := terms.Split(a.Amount, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	repayment := &Repayment{Split: split}
+	if split.Principal.IsPositive() {
+		repayment.Principal, err = NewTransaction(sourceID, Transfer, split.Principal, "", fmt.Sprintf("%s 원금 상환", a.Name))
+		if err != nil {
+			return nil, err
+		}
+		repayment.Principal.CounterAssetID = a.ID
+	}
+	if split.Interest.IsPositive() {
+		repayment.Interest, err = NewTransaction(sourceID, Expense, split.Interest, InterestCategory, fmt.Sprintf("%s 이자", a.Name))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return repayment, nil
+}
+
+// OriginalPrincipal 부채 자산의 최초 원금입니다. 상환 조건이 없으면 생성 시점의 금액을 사용합니다.
+func (a *Asset) OriginalPrincipal() Money {
+	if a.Liability != nil {
+		return a.Liability.Principal
+	}
+	if a.Performance != nil {
+		return a.Performance.StartValue
+	}
+	return a.Amount
+}
+
+// PaidOffProgress 최초 원금 대비 상환한 원금 비율(0~100)입니다.
+// 부채 자산이 아니거나 최초 원금이 없으면, 또는 통화가 다르면 false를 반환합니다.
+func (a *Asset) PaidOffProgress() (float64, bool) {
+	principal := a.OriginalPrincipal()
+	if !a.Type.IsLiability() || !principal.IsPositive() || principal.Currency != a.Amount.Currency {
+		return 0, false
+	}
+	paid := (principal.Amount - a.Amount.Amount) / principal.Amount * 100
+	return math.Min(100, math.Max(0, paid)), true
+}
+
+// SetDebtFreeProgress 빚 청산(DEBT_FREE) 목표의 진행률을 갱신하고 바뀐 목표가 있는지 반환합니다.
+func (a *Asset) SetDebtFreeProgress(progress float64) bool {
+	changed := false
+	for _, goal := range a.Goals {
+		if goal.Type != GoalTypeDebtFree || goal.Progress == progress {
+			continue
+		}
+		goal.Progress = progress
+		goal.UpdatedAt = time.Now()
+		changed = true
+	}
+	return changed
+}
+
+// roundMoney 금액을 소수점 둘째 자리로 반올림합니다.
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,229 @@
+package asset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var loanStart = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+func newLoan(t *testing.T, method AmortizationMethod) *Liability {
+	t.Helper()
+	liability, err := NewLiability(NewTestMoney(1200000, "KRW"), 12, 12, Monthly, method, loanStart)
+	require.NoError(t, err)
+	return liability
+}
+
+func Test_Liability_Schedule_should_pay_equal_installments(t *testing.T) {
+	// When
+	schedule := newLoan(t, EqualInstallment).Schedule()
+
+	// Then
+	require.Len(t, schedule, 12)
+	assert.Equal(t, time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), schedule[0].DueDate)
+	assert.Equal(t, 106618.55, schedule[0].Payment)
+	assert.Equal(t, 12000.0, schedule[0].Interest)
+	assert.Equal(t, 94618.55, schedule[0].Principal)
+	assert.Equal(t, 1105381.45, schedule[0].Balance)
+	var principal, interest float64
+	for _, installment := range schedule {
+		principal += installment.Principal
+		interest += installment.Interest
+		assert.InDelta(t, 106618.55, installment.Payment, 0.05)
+	}
+	assert.InDelta(t, 1200000, principal, 1e-6)
+	assert.InDelta(t, 79422.56, interest, 1e-6)
+	assert.Zero(t, schedule[11].Balance)
+}
+
+func Test_Liability_Schedule_should_pay_equal_principal(t *testing.T) {
+	// When
+	schedule := newLoan(t, EqualPrincipal).Schedule()
+
+	// Then
+	require.Len(t, schedule, 12)
+	assert.Equal(t, 100000.0, schedule[0].Principal)
+	assert.Equal(t, 12000.0, schedule[0].Interest)
+	assert.Equal(t, 112000.0, schedule[0].Payment)
+	assert.Equal(t, 100000.0, schedule[11].Principal)
+	assert.Equal(t, 1000.0, schedule[11].Interest)
+	assert.Zero(t, schedule[11].Balance)
+}
+
+func Test_Liability_Schedule_should_clamp_monthly_due_dates_to_month_end(t *testing.T) {
+	// Given
+	liability, err := NewLiability(NewTestMoney(1200000, "KRW"), 12, 4, Monthly, EqualPrincipal, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	// When
+	schedule := liability.Schedule()
+
+	// Then
+	require.Len(t, schedule, 4)
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), schedule[0].DueDate)
+	assert.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), schedule[1].DueDate)
+	assert.Equal(t, time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), schedule[2].DueDate)
+	assert.Equal(t, time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), schedule[3].DueDate)
+}
+
+func Test_Liability_Remaining_should_spread_balance_over_remaining_installments(t *testing.T) {
+	// Given
+	liability := newLoan(t, EqualPrincipal)
+
+	// When
+	remaining := liability.Remaining(600000, time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC))
+	overdue := liability.Remaining(50000, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	// Then
+	require.Len(t, remaining, 9)
+	assert.Equal(t, 4, remaining[0].Number)
+	assert.Equal(t, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), remaining[0].DueDate)
+	assert.InDelta(t, 66666.67, remaining[0].Principal, 1e-6)
+	require.Len(t, overdue, 1)
+	assert.Equal(t, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), overdue[0].DueDate)
+	assert.Equal(t, 50500.0, overdue[0].Payment)
+	assert.Empty(t, liability.Remaining(0, loanStart))
+}
+
+func Test_Liability_Split_should_pay_interest_before_principal(t *testing.T) {
+	// Given
+	liability := newLoan(t, EqualInstallment)
+	balance := NewTestMoney(1000000, "KRW")
+
+	// When
+	split, err := liability.Split(balance, NewTestMoney(50000, "KRW"))
+	require.NoError(t, err)
+	interestOnly, err := liability.Split(balance, NewTestMoney(4000, "KRW"))
+	require.NoError(t, err)
+	_, overpaid := liability.Split(balance, NewTestMoney(1020000, "KRW"))
+	_, mismatched := liability.Split(balance, NewTestMoney(100, "USD"))
+
+	// Then
+	assert.Equal(t, 10000.0, split.Interest.Amount)
+	assert.Equal(t, 40000.0, split.Principal.Amount)
+	assert.Equal(t, 960000.0, split.Balance.Amount)
+	assert.Equal(t, 4000.0, interestOnly.Interest.Amount)
+	assert.Zero(t, interestOnly.Principal.Amount)
+	assert.Error(t, overpaid)
+	assert.Error(t, mismatched)
+}
+
+func Test_NewLiability_should_validate_terms(t *testing.T) {
+	principal := NewTestMoney(1000, "KRW")
+	for name, err := range map[string]error{
+		"음수 이율":     second(NewLiability(principal, -1, 12, Monthly, EqualInstallment, loanStart)),
+		"음수 회차":     second(NewLiability(principal, 5, -1, Monthly, EqualInstallment, loanStart)),
+		"알 수 없는 주기": second(NewLiability(principal, 5, 12, "DAILY", EqualInstallment, loanStart)),
+		"알 수 없는 방식": second(NewLiability(principal, 5, 12, Monthly, "BULLET", loanStart)),
+		"통화 없음":     second(NewLiability(Money{Amount: 1000}, 5, 12, Monthly, EqualInstallment, loanStart)),
+	} {
+		assert.Error(t, err, name)
+	}
+
+	liability, err := NewLiability(principal, 5, 12, "", "", loanStart)
+	require.NoError(t, err)
+	assert.Equal(t, Monthly, liability.Frequency)
+	assert.Equal(t, EqualInstallment, liability.Method)
+	assert.Equal(t, 26, BiWeekly.PeriodsPerYear())
+}
+
+func Test_Asset_PlanRepayment_should_split_payment_into_principal_transfer_and_interest_expense(t *testing.T) {
+	// Given
+	loan, err := NewLiabilityAsset("user-1", Loan, "신용대출", newLoan(t, EqualInstallment))
+	require.NoError(t, err)
+	cash, err := NewAsset("user-1", Cash, "입출금", 1000000, "KRW")
+	require.NoError(t, err)
+
+	// When
+	repayment, err := loan.PlanRepayment(cash.ID, NewTestMoney(106618.55, "KRW"))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 94618.55, repayment.Split.Principal.Amount)
+	require.NotNil(t, repayment.Principal)
+	assert.Equal(t, Transfer, repayment.Principal.Type)
+	assert.Equal(t, cash.ID, repayment.Principal.AssetID)
+	assert.Equal(t, loan.ID, repayment.Principal.CounterAssetID)
+	assert.Equal(t, 94618.55, repayment.Principal.Amount.Amount)
+	require.NotNil(t, repayment.Interest)
+	assert.Equal(t, Expense, repayment.Interest.Type)
+	assert.Equal(t, cash.ID, repayment.Interest.AssetID)
+	assert.Equal(t, InterestCategory, repayment.Interest.Category)
+	assert.Equal(t, 12000.0, repayment.Interest.Amount.Amount)
+	assert.Equal(t, []*Transaction{repayment.Interest, repayment.Principal}, repayment.Transactions())
+	// 계획만 세우고 자산은 바꾸지 않습니다
+	assert.Equal(t, 1200000.0, loan.Amount.Amount)
+
+	_, err = cash.PlanRepayment(loan.ID, NewTestMoney(100, "KRW"))
+	assert.Error(t, err)
+	_, err = loan.PlanRepayment(loan.ID, NewTestMoney(100, "KRW"))
+	assert.Error(t, err)
+	_, err = NewLiabilityAsset("user-1", Cash, "입출금", newLoan(t, EqualInstallment))
+	assert.Error(t, err)
+}
+
+func Test_Asset_ReceiveTransfer_should_reduce_liability_and_track_debt_free_goal(t *testing.T) {
+	// Given
+	loan, err := NewLiabilityAsset("user-1", Loan, "신용대출", newLoan(t, EqualInstallment))
+	require.NoError(t, err)
+	goal := loan.AddGoal(GoalTypeDebtFree, NewTestMoney(0, "KRW"), loanStart.AddDate(1, 0, 0))
+	cash, err := NewAsset("user-1", Cash, "입출금", 1000000, "KRW")
+	require.NoError(t, err)
+	repayment, err := loan.PlanRepayment(cash.ID, NewTestMoney(106618.55, "KRW"))
+	require.NoError(t, err)
+
+	// When
+	err = loan.ReceiveTransfer(repayment.Principal)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1105381.45, loan.Amount.Amount)
+	assert.InDelta(t, 7.88, goal.Progress, 0.01)
+	events := loan.GetUncommittedEvents()
+	require.NotEmpty(t, events)
+	assert.Equal(t, event.TypeTransactionRecorded, events[len(events)-1].EventType())
+}
+
+func Test_Asset_ProcessTransaction_should_grow_liability_on_spending(t *testing.T) {
+	// Given
+	card, err := NewAsset("user-1", CreditCard, "신용카드", 0, "KRW")
+	require.NoError(t, err)
+	purchase, err := NewTransaction(card.ID, Expense, NewTestMoney(30000, "KRW"), "식비", "마트")
+	require.NoError(t, err)
+	payment, err := NewTransaction(card.ID, Income, NewTestMoney(10000, "KRW"), "카드대금", "결제")
+	require.NoError(t, err)
+	overpayment, err := NewTransaction(card.ID, Income, NewTestMoney(50000, "KRW"), "카드대금", "결제")
+	require.NoError(t, err)
+
+	// When
+	require.NoError(t, card.ProcessTransaction(purchase))
+	require.NoError(t, card.ProcessTransaction(payment))
+	err = card.ProcessTransaction(overpayment)
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, 20000.0, card.Amount.Amount)
+	assert.True(t, card.Type.IsLiability())
+	assert.False(t, Cash.IsLiability())
+}
+
+func Test_Asset_Clone_should_copy_liability_terms(t *testing.T) {
+	// Given
+	loan, err := NewLiabilityAsset("user-1", Mortgage, "주택담보대출", newLoan(t, EqualPrincipal))
+	require.NoError(t, err)
+
+	// When
+	clone := loan.Clone()
+	clone.Liability.AnnualRate = 3
+
+	// Then
+	assert.Equal(t, 12.0, loan.Liability.AnnualRate)
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
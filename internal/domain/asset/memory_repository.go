@@ -10,7 +10,9 @@ import (
 )
 
 // MemoryRepository 인메모리 저장소 구현체
+// WithTransaction 안에서의 쓰기는 작업 단위에 스테이징되었다가 커밋 시 한 번에 반영됩니다.
 type MemoryRepository[T any] struct {
+	id    uint64
 	data  map[string]T
 	mutex sync.RWMutex
 }
@@ -18,34 +20,218 @@ type MemoryRepository[T any] struct {
 // NewMemoryRepository 새로운 인메모리 저장소를 생성합니다.
 func NewMemoryRepository[T any]() *MemoryRepository[T] {
 	return &MemoryRepository[T]{
+		id:   storeSeq.Add(1),
 		data: make(map[string]T),
 	}
 }
 
-// Save 엔티티를 저장합니다.
-func (r *MemoryRepository[T]) Save(_ context.Context, entity interface{}) error {
+func (r *MemoryRepository[T]) storeID() uint64 {
+	return r.id
+}
+
+func (r *MemoryRepository[T]) lock() {
 	r.mutex.Lock()
-	defer r.mutex.Unlock()
+}
 
-	if e, ok := entity.(domain.Entity); ok {
-		if _, exists := r.data[e.GetID()]; exists {
-			return domain.NewRepositoryError("Save", fmt.Errorf("entity with ID %s already exists", e.GetID()))
+func (r *MemoryRepository[T]) unlock() {
+	r.mutex.Unlock()
+}
+
+func (r *MemoryRepository[T]) validate(staged any) error {
+	for id, write := range staged.(overlay[T]) {
+		if write.base == 0 {
+			continue
+		}
+		var actual int64
+		if stored, exists := r.data[id]; exists {
+			actual, _ = versionOf(stored)
+		}
+		if actual != write.base {
+			return versionConflict(id, write.base, actual)
 		}
+	}
+	return nil
+}
 
-		if t, ok := entity.(T); ok {
-			r.data[e.GetID()] = t
-			return nil
+func (r *MemoryRepository[T]) apply(staged any) {
+	for id, write := range staged.(overlay[T]) {
+		if write.deleted {
+			delete(r.data, id)
+			continue
 		}
+		r.data[id] = write.value
 	}
-	return domain.NewRepositoryError("Save", fmt.Errorf("invalid entity type"))
 }
 
-// FindByID ID로 엔티티를 조회합니다.
-func (r *MemoryRepository[T]) FindByID(_ context.Context, id string) (T, error) {
+func (r *MemoryRepository[T]) merge(parent, child any) any {
+	var merged overlay[T]
+	if parent == nil {
+		merged = make(overlay[T])
+	} else {
+		merged = parent.(overlay[T])
+	}
+	for id, write := range child.(overlay[T]) {
+		merged[id] = write
+	}
+	return merged
+}
+
+// get 작업 단위의 오버레이를 안쪽부터 확인한 뒤 저장된 엔티티를 조회합니다.
+// 호출자가 고쳐도 저장된 엔티티가 바뀌지 않도록 복사본을 반환합니다.
+func (r *MemoryRepository[T]) get(ctx context.Context, id string) (T, bool) {
+	var zero T
+	for tx := currentTx(ctx); tx != nil; tx = tx.parent {
+		tx.mu.Lock()
+		staged, ok := tx.staged[r]
+		var write stagedWrite[T]
+		var found bool
+		if ok {
+			write, found = staged.(overlay[T])[id]
+		}
+		tx.mu.Unlock()
+		if found {
+			if write.deleted {
+				return zero, false
+			}
+			return cloneEntity(write.value), true
+		}
+	}
+
+	r.mutex.RLock()
+	entity, exists := r.data[id]
+	r.mutex.RUnlock()
+	if exists {
+		entity = cloneEntity(entity)
+	}
+	return entity, exists
+}
+
+// base 작업 단위에서 엔티티를 쓸 때 기준이 되는 저장된 버전을 찾습니다.
+// 이미 스테이징된 쓰기가 있으면 그 쓰기의 기준 버전을 이어받습니다.
+func (r *MemoryRepository[T]) base(ctx context.Context, id string) int64 {
+	for tx := currentTx(ctx); tx != nil; tx = tx.parent {
+		tx.mu.Lock()
+		var write stagedWrite[T]
+		var found bool
+		if staged, ok := tx.staged[r]; ok {
+			write, found = staged.(overlay[T])[id]
+		}
+		tx.mu.Unlock()
+		if found {
+			return write.base
+		}
+	}
+
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
+	if stored, exists := r.data[id]; exists {
+		version, _ := versionOf(stored)
+		return version
+	}
+	return 0
+}
+
+// all 작업 단위의 오버레이를 반영한 전체 엔티티 목록을 반환합니다.
+func (r *MemoryRepository[T]) all(ctx context.Context) []T {
+	r.mutex.RLock()
+	view := make(map[string]T, len(r.data))
+	for id, entity := range r.data {
+		view[id] = entity
+	}
+	r.mutex.RUnlock()
 
-	if entity, exists := r.data[id]; exists {
+	var chain []*memoryTx
+	for tx := currentTx(ctx); tx != nil; tx = tx.parent {
+		chain = append(chain, tx)
+	}
+	// 바깥쪽 작업 단위부터 덮어써야 안쪽 세이브포인트의 쓰기가 우선합니다
+	for i := len(chain) - 1; i >= 0; i-- {
+		tx := chain[i]
+		tx.mu.Lock()
+		if staged, ok := tx.staged[r]; ok {
+			for id, write := range staged.(overlay[T]) {
+				if write.deleted {
+					delete(view, id)
+				} else {
+					view[id] = write.value
+				}
+			}
+		}
+		tx.mu.Unlock()
+	}
+
+	result := make([]T, 0, len(view))
+	for _, entity := range view {
+		result = append(result, cloneEntity(entity))
+	}
+	return result
+}
+
+// put 엔티티의 복사본을 기록합니다. 트랜잭션 안에서는 가장 안쪽 작업 단위에 스테이징합니다.
+func (r *MemoryRepository[T]) put(ctx context.Context, id string, entity T) {
+	entity = cloneEntity(entity)
+	if tx := currentTx(ctx); tx != nil {
+		base := r.base(ctx, id)
+		tx.mu.Lock()
+		overlayFor[T](tx, r)[id] = stagedWrite[T]{value: entity, base: base}
+		tx.mu.Unlock()
+		return
+	}
+
+	r.mutex.Lock()
+	r.data[id] = entity
+	r.mutex.Unlock()
+}
+
+// remove 엔티티를 삭제합니다. 트랜잭션 안에서는 삭제 표시를 스테이징합니다.
+func (r *MemoryRepository[T]) remove(ctx context.Context, id string) {
+	if tx := currentTx(ctx); tx != nil {
+		base := r.base(ctx, id)
+		tx.mu.Lock()
+		overlayFor[T](tx, r)[id] = stagedWrite[T]{deleted: true, base: base}
+		tx.mu.Unlock()
+		return
+	}
+
+	r.mutex.Lock()
+	delete(r.data, id)
+	r.mutex.Unlock()
+}
+
+// Save 엔티티를 저장합니다.
+func (r *MemoryRepository[T]) Save(ctx context.Context, entity interface{}) error {
+	e, ok := entity.(domain.Entity)
+	if !ok {
+		return domain.NewRepositoryError("Save", fmt.Errorf("invalid entity type"))
+	}
+	t, ok := entity.(T)
+	if !ok {
+		return domain.NewRepositoryError("Save", fmt.Errorf("invalid entity type"))
+	}
+
+	if currentTx(ctx) == nil {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		if _, exists := r.data[e.GetID()]; exists {
+			return domain.NewRepositoryError("Save", fmt.Errorf("entity with ID %s already exists", e.GetID()))
+		}
+		initVersion(t)
+		r.data[e.GetID()] = cloneEntity(t)
+		return nil
+	}
+
+	if _, exists := r.get(ctx, e.GetID()); exists {
+		return domain.NewRepositoryError("Save", fmt.Errorf("entity with ID %s already exists", e.GetID()))
+	}
+	initVersion(t)
+	r.put(ctx, e.GetID(), t)
+	return nil
+}
+
+// FindByID ID로 엔티티를 조회합니다.
+func (r *MemoryRepository[T]) FindByID(ctx context.Context, id string) (T, error) {
+	if entity, exists := r.get(ctx, id); exists {
 		return entity, nil
 	}
 
@@ -54,74 +240,137 @@ func (r *MemoryRepository[T]) FindByID(_ context.Context, id string) (T, error)
 }
 
 // Update 엔티티를 업데이트합니다.
-func (r *MemoryRepository[T]) Update(_ context.Context, entity interface{}) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+func (r *MemoryRepository[T]) Update(ctx context.Context, entity interface{}) error {
+	e, ok := entity.(domain.Entity)
+	if !ok {
+		return domain.NewRepositoryError("Update", fmt.Errorf("invalid entity type"))
+	}
+	t, ok := entity.(T)
+	if !ok {
+		return domain.NewRepositoryError("Update", fmt.Errorf("invalid entity type"))
+	}
 
-	if e, ok := entity.(domain.Entity); ok {
-		if _, exists := r.data[e.GetID()]; !exists {
+	if currentTx(ctx) == nil {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		stored, exists := r.data[e.GetID()]
+		if !exists {
 			return domain.NewRepositoryError("Update", fmt.Errorf("entity with ID %s not found", e.GetID()))
 		}
-
-		if t, ok := entity.(T); ok {
-			r.data[e.GetID()] = t
-			return nil
+		if err := nextVersion(t, stored); err != nil {
+			return err
 		}
+		r.data[e.GetID()] = cloneEntity(t)
+		return nil
+	}
+
+	stored, exists := r.get(ctx, e.GetID())
+	if !exists {
+		return domain.NewRepositoryError("Update", fmt.Errorf("entity with ID %s not found", e.GetID()))
+	}
+	if err := nextVersion(t, stored); err != nil {
+		return err
+	}
+	r.put(ctx, e.GetID(), t)
+	return nil
+}
+
+// nextVersion 엔티티의 버전이 저장된 버전과 같은지 확인하고 엔티티의 버전을 올립니다.
+func nextVersion(entity, stored interface{}) error {
+	v, ok := entity.(versioned)
+	if !ok {
+		return nil
+	}
+	actual, _ := versionOf(stored)
+	if v.GetVersion() != actual {
+		return versionConflict(entity.(domain.Entity).GetID(), v.GetVersion(), actual)
 	}
-	return domain.NewRepositoryError("Update", fmt.Errorf("invalid entity type"))
+	v.setVersion(actual + 1)
+	return nil
 }
 
 // Delete ID로 엔티티를 삭제합니다.
-func (r *MemoryRepository[T]) Delete(_ context.Context, id string) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+func (r *MemoryRepository[T]) Delete(ctx context.Context, id string) error {
+	if currentTx(ctx) == nil {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
 
-	if _, exists := r.data[id]; !exists {
-		return domain.NewRepositoryError("Delete", fmt.Errorf("entity with ID %s not found", id))
+		if _, exists := r.data[id]; !exists {
+			return domain.NewRepositoryError("Delete", fmt.Errorf("entity with ID %s not found", id))
+		}
+		delete(r.data, id)
+		return nil
 	}
 
-	delete(r.data, id)
+	if _, exists := r.get(ctx, id); !exists {
+		return domain.NewRepositoryError("Delete", fmt.Errorf("entity with ID %s not found", id))
+	}
+	r.remove(ctx, id)
 	return nil
 }
 
 // FindAll 검색 조건에 맞는 모든 엔티티를 조회합니다.
-func (r *MemoryRepository[T]) FindAll(_ context.Context, _ domain.SearchCriteria) ([]T, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
+func (r *MemoryRepository[T]) FindAll(ctx context.Context, criteria domain.SearchCriteria) ([]T, error) {
 	var result []T
-	for _, entity := range r.data {
-		result = append(result, entity)
+	for _, entity := range r.all(ctx) {
+		ok, err := matches(criteria, entity)
+		if err != nil {
+			return nil, domain.NewRepositoryError("FindAll", err)
+		}
+		if ok {
+			result = append(result, entity)
+		}
 	}
 	return result, nil
 }
 
 // FindOne 검색 조건에 맞는 하나의 엔티티를 조회합니다.
-func (r *MemoryRepository[T]) FindOne(_ context.Context, _ domain.SearchCriteria) (T, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
+func (r *MemoryRepository[T]) FindOne(ctx context.Context, criteria domain.SearchCriteria) (T, error) {
 	var zero T
-	return zero, domain.NewRepositoryError("FindOne", fmt.Errorf("not implemented"))
+	if _, ok := criteria.(domain.Matcher); !ok {
+		return zero, domain.NewRepositoryError("FindOne", fmt.Errorf("not implemented"))
+	}
+
+	for _, entity := range r.all(ctx) {
+		ok, err := matches(criteria, entity)
+		if err != nil {
+			return zero, domain.NewRepositoryError("FindOne", err)
+		}
+		if ok {
+			return entity, nil
+		}
+	}
+	return zero, domain.NewRepositoryError("FindOne", fmt.Errorf("no entity matches criteria"))
 }
 
-// WithTransaction 트랜잭션을 실행합니다.
+// matches 검색 조건이 Matcher를 구현하면 엔티티를 평가하고, 그렇지 않으면 모든 엔티티를 허용합니다.
+func matches(criteria domain.SearchCriteria, entity interface{}) (bool, error) {
+	matcher, ok := criteria.(domain.Matcher)
+	if !ok {
+		return true, nil
+	}
+	return matcher.Match(entity)
+}
+
+// WithTransaction 작업 단위 안에서 fn을 실행합니다.
+// fn이 성공하면 모든 인메모리 저장소의 쓰기가 원자적으로 커밋되고, 실패하면 롤백됩니다.
+// 중첩 호출은 세이브포인트로 동작하여 안쪽 실패가 바깥쪽 쓰기를 되돌리지 않습니다.
 func (r *MemoryRepository[T]) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	return fn(ctx)
+	return runInTransaction(ctx, fn)
 }
 
 // MemoryAssetRepository Asset 도메인의 인메모리 저장소 구현체입니다.
 type MemoryAssetRepository struct {
 	repo         *MemoryRepository[*Asset]
-	transactions map[string]*Transaction
-	mutex        sync.RWMutex
+	transactions *MemoryRepository[*Transaction]
 }
 
 // NewMemoryAssetRepository 새로운 MemoryAssetRepository를 생성합니다.
 func NewMemoryAssetRepository() *MemoryAssetRepository {
 	return &MemoryAssetRepository{
 		repo:         NewMemoryRepository[*Asset](),
-		transactions: make(map[string]*Transaction),
+		transactions: NewMemoryRepository[*Transaction](),
 	}
 }
 
@@ -146,12 +395,9 @@ func (r *MemoryAssetRepository) Delete(ctx context.Context, id string) error {
 }
 
 // FindByUserID 사용자 ID로 Asset 목록을 조회합니다.
-func (r *MemoryAssetRepository) FindByUserID(_ context.Context, userID string) ([]*Asset, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
+func (r *MemoryAssetRepository) FindByUserID(ctx context.Context, userID string) ([]*Asset, error) {
 	var result []*Asset
-	for _, asset := range r.repo.data {
+	for _, asset := range r.repo.all(ctx) {
 		if asset.UserID == userID {
 			result = append(result, asset)
 		}
@@ -160,12 +406,9 @@ func (r *MemoryAssetRepository) FindByUserID(_ context.Context, userID string) (
 }
 
 // FindByType Asset 유형으로 Asset 목록을 조회합니다.
-func (r *MemoryAssetRepository) FindByType(_ context.Context, assetType Type) ([]*Asset, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
+func (r *MemoryAssetRepository) FindByType(ctx context.Context, assetType Type) ([]*Asset, error) {
 	var result []*Asset
-	for _, asset := range r.repo.data {
+	for _, asset := range r.repo.all(ctx) {
 		if asset.Type == assetType {
 			result = append(result, asset)
 		}
@@ -173,33 +416,35 @@ func (r *MemoryAssetRepository) FindByType(_ context.Context, assetType Type) ([
 	return result, nil
 }
 
-// UpdateAmount 자산의 금액을 업데이트합니다.
-func (r *MemoryAssetRepository) UpdateAmount(_ context.Context, id string, amount Money) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+// FindBySymbol 보유 포지션의 심볼로 Asset 목록을 조회합니다.
+func (r *MemoryAssetRepository) FindBySymbol(ctx context.Context, symbol string) ([]*Asset, error) {
+	var result []*Asset
+	for _, asset := range r.repo.all(ctx) {
+		if asset.Holding != nil && asset.Holding.Symbol == symbol {
+			result = append(result, asset)
+		}
+	}
+	return result, nil
+}
 
-	asset, exists := r.repo.data[id]
+// UpdateAmount 자산의 금액을 업데이트합니다.
+func (r *MemoryAssetRepository) UpdateAmount(ctx context.Context, id string, amount Money) error {
+	asset, exists := r.repo.get(ctx, id)
 	if !exists {
 		return domain.NewRepositoryError("UpdateAmount", fmt.Errorf("asset with ID %s not found", id))
 	}
 
 	asset.Amount = amount
 	asset.UpdatedAt = time.Now()
-	r.repo.data[id] = asset
+	asset.Version++
+	r.repo.put(ctx, id, asset)
 
 	return nil
 }
 
 // FindAll 검색 조건에 맞는 모든 Asset을 조회합니다.
-func (r *MemoryAssetRepository) FindAll(_ context.Context, _ domain.SearchCriteria) ([]*Asset, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	var result []*Asset
-	for _, asset := range r.repo.data {
-		result = append(result, asset)
-	}
-	return result, nil
+func (r *MemoryAssetRepository) FindAll(ctx context.Context, criteria domain.SearchCriteria) ([]*Asset, error) {
+	return r.repo.FindAll(ctx, criteria)
 }
 
 // FindOne 검색 조건에 맞는 하나의 Asset을 조회합니다.
@@ -213,20 +458,15 @@ func (r *MemoryAssetRepository) WithTransaction(ctx context.Context, fn func(ctx
 }
 
 // SaveTransaction 거래를 저장합니다.
-func (r *MemoryAssetRepository) SaveTransaction(_ context.Context, tx *Transaction) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	r.transactions[tx.ID] = tx
+func (r *MemoryAssetRepository) SaveTransaction(ctx context.Context, tx *Transaction) error {
+	initVersion(tx)
+	r.transactions.put(ctx, tx.ID, tx)
 	return nil
 }
 
 // FindTransactionByID ID로 거래를 찾습니다.
-func (r *MemoryAssetRepository) FindTransactionByID(_ context.Context, id string) (*Transaction, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	tx, exists := r.transactions[id]
+func (r *MemoryAssetRepository) FindTransactionByID(ctx context.Context, id string) (*Transaction, error) {
+	tx, exists := r.transactions.get(ctx, id)
 	if !exists {
 		return nil, domain.NewRepositoryError("FindTransactionByID", fmt.Errorf("transaction with ID %s not found", id))
 	}
@@ -234,12 +474,9 @@ func (r *MemoryAssetRepository) FindTransactionByID(_ context.Context, id string
 }
 
 // FindTransactionsByDateRange 날짜 범위로 거래를 찾습니다.
-func (r *MemoryAssetRepository) FindTransactionsByDateRange(_ context.Context, start, end time.Time) ([]*Transaction, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
+func (r *MemoryAssetRepository) FindTransactionsByDateRange(ctx context.Context, start, end time.Time) ([]*Transaction, error) {
 	var result []*Transaction
-	for _, tx := range r.transactions {
+	for _, tx := range r.transactions.all(ctx) {
 		if (tx.Date.Equal(start) || tx.Date.After(start)) && (tx.Date.Equal(end) || tx.Date.Before(end)) {
 			result = append(result, tx)
 		}
@@ -248,12 +485,9 @@ func (r *MemoryAssetRepository) FindTransactionsByDateRange(_ context.Context, s
 }
 
 // CalculateTotalAmount 특정 통화의 총 금액을 계산합니다.
-func (r *MemoryAssetRepository) CalculateTotalAmount(_ context.Context, currency string) (Money, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
+func (r *MemoryAssetRepository) CalculateTotalAmount(ctx context.Context, currency string) (Money, error) {
 	total := NewTestMoney(0, currency)
-	for _, tx := range r.transactions {
+	for _, tx := range r.transactions.all(ctx) {
 		if tx.Amount.Currency == currency {
 			switch tx.Type {
 			case Income:
@@ -307,12 +541,9 @@ func (r *MemoryTransactionRepository) Delete(ctx context.Context, id string) err
 }
 
 // FindByAssetID AssetID로 Transaction 목록을 조회합니다.
-func (r *MemoryTransactionRepository) FindByAssetID(_ context.Context, assetID string) ([]*Transaction, error) {
-	r.repo.mutex.RLock()
-	defer r.repo.mutex.RUnlock()
-
+func (r *MemoryTransactionRepository) FindByAssetID(ctx context.Context, assetID string) ([]*Transaction, error) {
 	var result []*Transaction
-	for _, tx := range r.repo.data {
+	for _, tx := range r.repo.all(ctx) {
 		if tx.AssetID == assetID {
 			result = append(result, tx)
 		}
@@ -321,12 +552,9 @@ func (r *MemoryTransactionRepository) FindByAssetID(_ context.Context, assetID s
 }
 
 // FindByDateRange 날짜 범위로 Transaction 목록을 조회합니다.
-func (r *MemoryTransactionRepository) FindByDateRange(_ context.Context, start, end time.Time) ([]*Transaction, error) {
-	r.repo.mutex.RLock()
-	defer r.repo.mutex.RUnlock()
-
+func (r *MemoryTransactionRepository) FindByDateRange(ctx context.Context, start, end time.Time) ([]*Transaction, error) {
 	var result []*Transaction
-	for _, tx := range r.repo.data {
+	for _, tx := range r.repo.all(ctx) {
 		if (tx.Date.Equal(start) || tx.Date.After(start)) &&
 			(tx.Date.Equal(end) || tx.Date.Before(end)) {
 			result = append(result, tx)
@@ -336,12 +564,9 @@ func (r *MemoryTransactionRepository) FindByDateRange(_ context.Context, start,
 }
 
 // GetTotalAmount 특정 기간 동안의 총 거래 금액을 계산합니다.
-func (r *MemoryTransactionRepository) GetTotalAmount(_ context.Context, assetID string) (Money, error) {
-	r.repo.mutex.RLock()
-	defer r.repo.mutex.RUnlock()
-
+func (r *MemoryTransactionRepository) GetTotalAmount(ctx context.Context, assetID string) (Money, error) {
 	total := Money{Amount: 0, Currency: "KRW"}
-	for _, tx := range r.repo.data {
+	for _, tx := range r.repo.all(ctx) {
 		if tx.AssetID == assetID {
 			switch tx.Type {
 			case Income:
@@ -363,15 +588,8 @@ func (r *MemoryTransactionRepository) GetTotalAmount(_ context.Context, assetID
 }
 
 // FindAll 검색 조건에 맞는 모든 Transaction을 조회합니다.
-func (r *MemoryTransactionRepository) FindAll(_ context.Context, _ domain.SearchCriteria) ([]*Transaction, error) {
-	r.repo.mutex.RLock()
-	defer r.repo.mutex.RUnlock()
-
-	var result []*Transaction
-	for _, tx := range r.repo.data {
-		result = append(result, tx)
-	}
-	return result, nil
+func (r *MemoryTransactionRepository) FindAll(ctx context.Context, criteria domain.SearchCriteria) ([]*Transaction, error) {
+	return r.repo.FindAll(ctx, criteria)
 }
 
 // FindOne 검색 조건에 맞는 하나의 Transaction을 조회합니다.
@@ -417,11 +635,8 @@ func (r *MemoryPortfolioRepository) Delete(ctx context.Context, id string) error
 }
 
 // FindByUserID 사용자 ID로 Portfolio를 조회합니다.
-func (r *MemoryPortfolioRepository) FindByUserID(_ context.Context, userID string) (*Portfolio, error) {
-	r.repo.mutex.RLock()
-	defer r.repo.mutex.RUnlock()
-
-	for _, portfolio := range r.repo.data {
+func (r *MemoryPortfolioRepository) FindByUserID(ctx context.Context, userID string) (*Portfolio, error) {
+	for _, portfolio := range r.repo.all(ctx) {
 		if portfolio.UserID == userID {
 			return portfolio, nil
 		}
@@ -430,32 +645,23 @@ func (r *MemoryPortfolioRepository) FindByUserID(_ context.Context, userID strin
 }
 
 // UpdateAssets Portfolio의 자산 구성을 업데이트합니다.
-func (r *MemoryPortfolioRepository) UpdateAssets(_ context.Context, id string, assets []PortfolioAsset) error {
-	r.repo.mutex.Lock()
-	defer r.repo.mutex.Unlock()
-
-	portfolio, ok := r.repo.data[id]
+func (r *MemoryPortfolioRepository) UpdateAssets(ctx context.Context, id string, assets []PortfolioAsset) error {
+	portfolio, ok := r.repo.get(ctx, id)
 	if !ok {
 		return domain.NewRepositoryError("UpdateAssets", fmt.Errorf("portfolio with ID %s not found", id))
 	}
 
 	portfolio.Assets = assets
 	portfolio.UpdatedAt = time.Now()
-	r.repo.data[id] = portfolio
+	portfolio.Version++
+	r.repo.put(ctx, id, portfolio)
 
 	return nil
 }
 
 // FindAll 검색 조건에 맞는 모든 Portfolio를 조회합니다.
-func (r *MemoryPortfolioRepository) FindAll(_ context.Context, _ domain.SearchCriteria) ([]*Portfolio, error) {
-	r.repo.mutex.RLock()
-	defer r.repo.mutex.RUnlock()
-
-	var result []*Portfolio
-	for _, portfolio := range r.repo.data {
-		result = append(result, portfolio)
-	}
-	return result, nil
+func (r *MemoryPortfolioRepository) FindAll(ctx context.Context, criteria domain.SearchCriteria) ([]*Portfolio, error) {
+	return r.repo.FindAll(ctx, criteria)
 }
 
 // FindOne 검색 조건에 맞는 하나의 Portfolio를 조회합니다.
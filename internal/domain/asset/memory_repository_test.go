@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aske/go_fi_chart/internal/domain/query"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -243,3 +244,58 @@ func Test_should_update_portfolio_assets(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, newAssets, found.Assets)
 }
+
+func Test_memory_repo_should_filter_assets_by_query_criteria(t *testing.T) {
+	// Given
+	repo := NewMemoryAssetRepository()
+	ctx := context.Background()
+	stock, _ := NewAsset("test-user", Stock, "삼성전자", 2000000, "KRW")
+	bond, _ := NewAsset("test-user", Bond, "국고채", 500, "KRW")
+	cash, _ := NewAsset("test-user", Cash, "현금", 3000000, "KRW")
+	for _, a := range []*Asset{stock, bond, cash} {
+		assert.NoError(t, repo.Save(ctx, a))
+	}
+	criteria := query.MustParse(`type in (STOCK,BOND) and amount.amount > 1000 and name ~ "삼성"`)
+
+	// When
+	found, err := repo.FindAll(ctx, criteria)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []*Asset{stock}, found)
+
+	// When
+	one, err := repo.FindOne(ctx, query.MustParse(`type = CASH`))
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, cash, one)
+
+	// When
+	_, err = repo.FindOne(ctx, query.MustParse(`type = CRYPTO`))
+
+	// Then
+	assert.Error(t, err)
+}
+
+func Test_memory_repo_should_reject_update_with_stale_version(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryPortfolioRepository()
+	portfolio := NewPortfolio("user-1", []PortfolioAsset{{AssetID: "a", Weight: 100}})
+	assert.NoError(t, repo.Save(ctx, portfolio))
+	first, _ := repo.FindByID(ctx, portfolio.ID)
+	second, _ := repo.FindByID(ctx, portfolio.ID)
+	first.Assets = []PortfolioAsset{{AssetID: "a", Weight: 60}, {AssetID: "b", Weight: 40}}
+	assert.NoError(t, repo.Update(ctx, first))
+
+	// When
+	second.Assets = []PortfolioAsset{{AssetID: "a", Weight: 50}, {AssetID: "c", Weight: 50}}
+	err := repo.Update(ctx, second)
+
+	// Then
+	assert.True(t, IsVersionConflict(err))
+	found, _ := repo.FindByID(ctx, portfolio.ID)
+	assert.Equal(t, int64(2), found.Version)
+	assert.Equal(t, first.Assets, found.Assets)
+}
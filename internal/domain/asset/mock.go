@@ -49,6 +49,14 @@ func (m *MockRepository) FindByType(ctx context.Context, assetType Type) ([]*Ass
 	return args.Get(0).([]*Asset), args.Error(1)
 }
 
+func (m *MockRepository) FindBySymbol(ctx context.Context, symbol string) ([]*Asset, error) {
+	args := m.Called(ctx, symbol)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Asset), args.Error(1)
+}
+
 func (m *MockRepository) Update(ctx context.Context, asset *Asset) error {
 	args := m.Called(ctx, asset)
 	return args.Error(0)
@@ -116,14 +116,16 @@ type Performance struct {
 
 // Goal 재무 목표를 나타냅니다.
 type Goal struct {
-	ID        string
-	Type      GoalType
-	Target    Money
-	Deadline  time.Time
-	Progress  float64
-	Rewards   []Reward
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID             string
+	Type           GoalType
+	Target         Money
+	Deadline       time.Time
+	Progress       float64
+	ExpectedReturn float64    // 연 기대 수익률(%)
+	Status         GoalStatus // 마지막으로 계산한 달성 전망
+	Rewards        []Reward
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 // GoalType 목표의 유형을 나타냅니다.
@@ -249,11 +251,14 @@ type Asset struct {
 	Type         Type
 	Name         string
 	Amount       Money
+	Holding      *Holding   // 시장 가격으로 평가되는 보유 포지션, 없으면 Amount가 고정 금액입니다
+	Liability    *Liability // 부채 자산의 상환 조건, 부채 자산의 Amount는 남은 원금입니다
 	Performance  *Performance
 	Goals        []*Goal
 	Achievements []*Achievement
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	Version      int64         // 낙관적 잠금 버전, 저장할 때 1부터 시작해 업데이트마다 1씩 증가합니다
 	events       []event.Event // 미발행 이벤트 저장
 }
 
@@ -272,6 +277,54 @@ func (a Asset) GetUpdatedAt() time.Time {
 	return a.UpdatedAt
 }
 
+// GetVersion 자산의 낙관적 잠금 버전을 반환합니다.
+func (a Asset) GetVersion() int64 {
+	return a.Version
+}
+
+func (a *Asset) setVersion(version int64) {
+	a.Version = version
+}
+
+// Clone 자산의 복사본을 생성합니다.
+// 보유 포지션, 상환 조건, 목표, 업적, 성과와 미발행 이벤트도 함께 복사되어 원본과 상태를 공유하지 않습니다.
+func (a *Asset) Clone() *Asset {
+	clone := *a
+	if a.Holding != nil {
+		holding := *a.Holding
+		clone.Holding = &holding
+	}
+	if a.Liability != nil {
+		liability := *a.Liability
+		clone.Liability = &liability
+	}
+	if a.Performance != nil {
+		performance := *a.Performance
+		clone.Performance = &performance
+	}
+	if a.Goals != nil {
+		clone.Goals = make([]*Goal, len(a.Goals))
+		for i, goal := range a.Goals {
+			g := *goal
+			g.Rewards = append([]Reward(nil), goal.Rewards...)
+			clone.Goals[i] = &g
+		}
+	}
+	if a.Achievements != nil {
+		clone.Achievements = make([]*Achievement, len(a.Achievements))
+		for i, achievement := range a.Achievements {
+			ac := *achievement
+			ac.Conditions = append([]Condition(nil), achievement.Conditions...)
+			ac.Rewards = append([]Reward(nil), achievement.Rewards...)
+			clone.Achievements[i] = &ac
+		}
+	}
+	if a.events != nil {
+		clone.events = append([]event.Event(nil), a.events...)
+	}
+	return &clone
+}
+
 // Type 자산의 유형을 나타냅니다.
 type Type string
 
@@ -281,6 +334,9 @@ const (
 	Bond       Type = "BOND"
 	RealEstate Type = "REAL_ESTATE"
 	Crypto     Type = "CRYPTO"
+	Loan       Type = "LOAN"
+	Mortgage   Type = "MORTGAGE"
+	CreditCard Type = "CREDIT_CARD"
 )
 
 // NewAsset 새로운 자산을 생성합니다.
@@ -395,6 +451,10 @@ type Transaction struct {
 	Description string
 	Date        time.Time
 	CreatedAt   time.Time
+	Reconciled  bool // 은행 거래 내역과 대사가 끝난 거래입니다
+	// CounterAssetID 이체의 입금 자산 ID입니다. 이체는 출금 자산과 입금 자산에 함께 반영해야 금액이 보존됩니다
+	CounterAssetID string
+	Version        int64 // 낙관적 잠금 버전
 }
 
 // NewTransaction 새로운 Transaction 값 객체를 생성합니다.
@@ -444,6 +504,21 @@ func (t *Transaction) GetUpdatedAt() time.Time {
 	return t.Date
 }
 
+// GetVersion 거래의 낙관적 잠금 버전을 반환합니다.
+func (t *Transaction) GetVersion() int64 {
+	return t.Version
+}
+
+func (t *Transaction) setVersion(version int64) {
+	t.Version = version
+}
+
+// Clone 거래의 복사본을 생성합니다.
+func (t *Transaction) Clone() *Transaction {
+	clone := *t
+	return &clone
+}
+
 // Portfolio 포트폴리오를 나타냅니다.
 type Portfolio struct {
 	ID        string
@@ -451,6 +526,7 @@ type Portfolio struct {
 	Assets    []PortfolioAsset
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	Version   int64 // 낙관적 잠금 버전
 }
 
 // GetID 포트폴리오의 ID를 반환합니다.
@@ -468,6 +544,24 @@ func (p *Portfolio) GetUpdatedAt() time.Time {
 	return p.UpdatedAt
 }
 
+// GetVersion 포트폴리오의 낙관적 잠금 버전을 반환합니다.
+func (p *Portfolio) GetVersion() int64 {
+	return p.Version
+}
+
+func (p *Portfolio) setVersion(version int64) {
+	p.Version = version
+}
+
+// Clone 포트폴리오의 복사본을 생성합니다.
+func (p *Portfolio) Clone() *Portfolio {
+	clone := *p
+	if p.Assets != nil {
+		clone.Assets = append([]PortfolioAsset(nil), p.Assets...)
+	}
+	return &clone
+}
+
 // PortfolioAsset 포트폴리오의 자산 구성을 나타냅니다.
 type PortfolioAsset struct {
 	AssetID string
@@ -509,20 +603,34 @@ func (a *Asset) ProcessTransaction(tx *Transaction) error {
 		return err
 	}
 
-	switch tx.Type {
-	case Income:
+	switch {
+	case a.Type.IsLiability():
+		// 부채 자산은 지출과 이체(현금 서비스)로 잔액이 늘고, 입금(상환)으로 잔액이 줄어듭니다
+		var result Money
+		var err error
+		if tx.Type == Income {
+			result, err = a.Amount.Subtract(tx.Amount)
+		} else {
+			result, err = a.Amount.Add(tx.Amount)
+		}
+		if err != nil {
+			return err
+		}
+		a.Amount = result
+	case tx.Type == Income:
 		result, err := a.Amount.Add(tx.Amount)
 		if err != nil {
 			return err
 		}
 		a.Amount = result
-	case Expense:
+	case tx.Type == Expense:
 		result, err := a.Amount.Subtract(tx.Amount)
 		if err != nil {
 			return err
 		}
 		a.Amount = result
-	case Transfer:
+	case tx.Type == Transfer:
+		// 출금 측을 반영합니다. 입금 자산은 ReceiveTransfer로 반영합니다
 		result, err := a.Amount.Subtract(tx.Amount)
 		if err != nil {
 			return err
@@ -551,6 +659,57 @@ func (a *Asset) ProcessTransaction(tx *Transaction) error {
 	return nil
 }
 
+// ReceiveTransfer 이체의 입금 측을 반영합니다. 부채 자산으로의 이체는 상환이므로 잔액이 줄어듭니다.
+func (a *Asset) ReceiveTransfer(tx *Transaction) error {
+	switch {
+	case tx.Type != Transfer:
+		return fmt.Errorf("이체 거래가 아닙니다: %s", tx.Type)
+	case tx.CounterAssetID != a.ID:
+		return fmt.Errorf("입금 자산이 일치하지 않습니다: %s != %s", tx.CounterAssetID, a.ID)
+	case tx.AssetID == a.ID:
+		return fmt.Errorf("같은 자산으로는 이체할 수 없습니다")
+	case tx.Amount.IsZero() || tx.Amount.IsNegative():
+		return fmt.Errorf("거래 금액은 양수여야 합니다")
+	}
+
+	var result Money
+	var err error
+	if a.Type.IsLiability() {
+		if a.Amount.Amount < tx.Amount.Amount {
+			return fmt.Errorf("상환 금액이 부채 잔액보다 큽니다")
+		}
+		result, err = a.Amount.Subtract(tx.Amount)
+	} else {
+		result, err = a.Amount.Add(tx.Amount)
+	}
+	if err != nil {
+		return err
+	}
+	a.Amount = result
+	a.UpdatedAt = time.Now()
+	// 부채 상환은 빚 청산(DEBT_FREE) 목표의 진행률을 최초 원금 대비 상환한 원금 비율로 갱신합니다
+	if progress, ok := a.PaidOffProgress(); ok {
+		a.SetDebtFreeProgress(progress)
+	}
+
+	a.AddEvent(event.NewEvent(
+		event.TypeTransactionRecorded,
+		a.ID,
+		"asset",
+		map[string]interface{}{
+			"transactionID": tx.ID,
+			"type":          tx.Type,
+			"amount":        tx.Amount,
+		},
+		map[string]string{
+			"userID": a.UserID,
+		},
+		1,
+	))
+
+	return nil
+}
+
 // ValidateTransaction 거래가 유효한지 검증합니다.
 func (a *Asset) ValidateTransaction(tx *Transaction) error {
 	if tx.Amount.IsZero() {
@@ -561,6 +720,13 @@ func (a *Asset) ValidateTransaction(tx *Transaction) error {
 		return fmt.Errorf("거래 금액은 음수가 될 수 없습니다")
 	}
 
+	if a.Type.IsLiability() {
+		if tx.Type == Income && a.Amount.Amount < tx.Amount.Amount {
+			return fmt.Errorf("상환 금액이 부채 잔액보다 큽니다")
+		}
+		return nil
+	}
+
 	if tx.Type == Expense && a.Amount.Amount < tx.Amount.Amount {
 		return fmt.Errorf("잔액이 부족합니다")
 	}
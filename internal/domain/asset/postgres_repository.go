@@ -0,0 +1,516 @@
+package asset
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// rowScanner *sql.Row와 *sql.Rows가 공통으로 제공하는 Scan 메서드입니다.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// pgTable 엔티티와 테이블 행 사이의 매핑입니다. 첫 번째 컬럼은 항상 id이고,
+// version 컬럼은 낙관적 잠금 버전으로 Update가 조건과 증가에 사용합니다.
+type pgTable[T domain.Entity] struct {
+	name    string
+	columns []string
+	values  func(entity T) ([]interface{}, error)
+	scan    func(row rowScanner) (T, error)
+}
+
+// PostgresRepository database/sql 기반의 저장소 구현체입니다.
+// 같은 *sql.DB를 공유하는 저장소들은 WithTransaction 안에서 하나의 트랜잭션으로 커밋됩니다.
+type PostgresRepository[T domain.Entity] struct {
+	db    *sql.DB
+	table pgTable[T]
+}
+
+func newPostgresRepository[T domain.Entity](db *sql.DB, table pgTable[T]) *PostgresRepository[T] {
+	return &PostgresRepository[T]{db: db, table: table}
+}
+
+func (r *PostgresRepository[T]) selectSQL() string {
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(r.table.columns, ", "), r.table.name)
+}
+
+// Save 엔티티를 저장합니다.
+func (r *PostgresRepository[T]) Save(ctx context.Context, entity T) error {
+	initVersion(entity)
+	values, err := r.table.values(entity)
+	if err != nil {
+		return domain.NewRepositoryError("Save", err)
+	}
+
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (id) DO NOTHING",
+		r.table.name, strings.Join(r.table.columns, ", "), strings.Join(placeholders, ", "))
+
+	affected, err := r.exec(ctx, query, values...)
+	if err != nil {
+		return domain.NewRepositoryError("Save", err)
+	}
+	if affected == 0 {
+		return domain.NewRepositoryError("Save", fmt.Errorf("entity with ID %s already exists", entity.GetID()))
+	}
+	return nil
+}
+
+// FindByID ID로 엔티티를 조회합니다.
+func (r *PostgresRepository[T]) FindByID(ctx context.Context, id string) (T, error) {
+	row := sqldb.Conn(ctx, r.db).QueryRowContext(ctx, r.selectSQL()+" WHERE id = $1", id)
+	entity, err := r.table.scan(row)
+	if err != nil {
+		var zero T
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("entity with ID %s not found", id)
+		}
+		return zero, domain.NewRepositoryError("FindByID", err)
+	}
+	return entity, nil
+}
+
+// Update 엔티티를 업데이트합니다.
+// 저장된 버전이 엔티티의 버전과 같은 행만 갱신하며, 다르면 버전 충돌 에러를 반환합니다.
+func (r *PostgresRepository[T]) Update(ctx context.Context, entity T) error {
+	values, err := r.table.values(entity)
+	if err != nil {
+		return domain.NewRepositoryError("Update", err)
+	}
+
+	assignments := make([]string, 0, len(r.table.columns)-1)
+	conditions := []string{"id = $1"}
+	for i, column := range r.table.columns[1:] {
+		if column == "version" {
+			// 요청한 버전은 조건으로만 쓰고, 저장된 버전은 한 단계 올립니다
+			assignments = append(assignments, "version = version + 1")
+			conditions = append(conditions, fmt.Sprintf("version = $%d", i+2))
+			continue
+		}
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, i+2))
+	}
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s",
+		r.table.name, strings.Join(assignments, ", "), strings.Join(conditions, " AND "))
+
+	affected, err := r.exec(ctx, query, values...)
+	if err != nil {
+		return domain.NewRepositoryError("Update", err)
+	}
+	if affected == 0 {
+		return r.updateMissed(ctx, entity)
+	}
+	if v, ok := any(entity).(versioned); ok {
+		v.setVersion(v.GetVersion() + 1)
+	}
+	return nil
+}
+
+// updateMissed 갱신된 행이 없을 때 행이 없는지, 버전이 달랐는지 구분해 에러를 만듭니다.
+func (r *PostgresRepository[T]) updateMissed(ctx context.Context, entity T) error {
+	expected, ok := versionOf(entity)
+	if !ok {
+		return domain.NewRepositoryError("Update", fmt.Errorf("entity with ID %s not found", entity.GetID()))
+	}
+	var actual int64
+	err := sqldb.Conn(ctx, r.db).QueryRowContext(ctx,
+		fmt.Sprintf("SELECT version FROM %s WHERE id = $1", r.table.name), entity.GetID()).Scan(&actual)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.NewRepositoryError("Update", fmt.Errorf("entity with ID %s not found", entity.GetID()))
+	}
+	if err != nil {
+		return domain.NewRepositoryError("Update", err)
+	}
+	return versionConflict(entity.GetID(), expected, actual)
+}
+
+// Delete ID로 엔티티를 삭제합니다.
+func (r *PostgresRepository[T]) Delete(ctx context.Context, id string) error {
+	affected, err := r.exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = $1", r.table.name), id)
+	if err != nil {
+		return domain.NewRepositoryError("Delete", err)
+	}
+	if affected == 0 {
+		return domain.NewRepositoryError("Delete", fmt.Errorf("entity with ID %s not found", id))
+	}
+	return nil
+}
+
+// FindAll 검색 조건에 맞는 모든 엔티티를 조회합니다. 조건은 criteria.ToQuery()로 WHERE 절이 됩니다.
+func (r *PostgresRepository[T]) FindAll(ctx context.Context, criteria domain.SearchCriteria) ([]T, error) {
+	result, err := r.findWhere(ctx, criteria, "")
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindAll", err)
+	}
+	return result, nil
+}
+
+// FindOne 검색 조건에 맞는 하나의 엔티티를 조회합니다.
+func (r *PostgresRepository[T]) FindOne(ctx context.Context, criteria domain.SearchCriteria) (T, error) {
+	var zero T
+	result, err := r.findWhere(ctx, criteria, " LIMIT 1")
+	if err != nil {
+		return zero, domain.NewRepositoryError("FindOne", err)
+	}
+	if len(result) == 0 {
+		return zero, domain.NewRepositoryError("FindOne", fmt.Errorf("no entity matches criteria"))
+	}
+	return result[0], nil
+}
+
+// WithTransaction 트랜잭션 안에서 fn을 실행합니다.
+// 중첩 호출은 세이브포인트로 동작합니다.
+func (r *PostgresRepository[T]) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return sqldb.WithTx(ctx, r.db, fn)
+}
+
+func (r *PostgresRepository[T]) findWhere(ctx context.Context, criteria domain.SearchCriteria, suffix string) ([]T, error) {
+	var where string
+	var args []interface{}
+	if criteria != nil {
+		where, args = criteria.ToQuery()
+	}
+	if where != "" {
+		where = " WHERE " + where
+	}
+	return r.query(ctx, where+" ORDER BY created_at, id"+suffix, args...)
+}
+
+// query 기본 SELECT 문 뒤에 clause를 붙여 실행하고 결과 행을 엔티티로 변환합니다.
+func (r *PostgresRepository[T]) query(ctx context.Context, clause string, args ...interface{}) ([]T, error) {
+	rows, err := sqldb.Conn(ctx, r.db).QueryContext(ctx, r.selectSQL()+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []T
+	for rows.Next() {
+		entity, err := r.table.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entity)
+	}
+	return result, rows.Err()
+}
+
+// exec 문장을 실행하고 영향받은 행 수를 반환합니다.
+func (r *PostgresRepository[T]) exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	res, err := sqldb.Conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// PostgresAssetRepository Asset 도메인의 PostgreSQL 저장소 구현체입니다.
+type PostgresAssetRepository struct {
+	*PostgresRepository[*Asset]
+}
+
+// NewPostgresAssetRepository assets 테이블을 사용하는 Asset 저장소를 생성합니다.
+func NewPostgresAssetRepository(db *sql.DB) *PostgresAssetRepository {
+	return &PostgresAssetRepository{
+		PostgresRepository: newPostgresRepository(db, pgTable[*Asset]{
+			name: "assets",
+			columns: []string{
+				"id", "user_id", "type", "name", "amount_amount", "amount_currency",
+				"performance", "goals", "achievements", "created_at", "updated_at",
+				"holding_symbol", "holding", "liability", "version",
+			},
+			values: assetValues,
+			scan:   scanAsset,
+		}),
+	}
+}
+
+func assetValues(a *Asset) ([]interface{}, error) {
+	performance, err := json.Marshal(a.Performance)
+	if err != nil {
+		return nil, err
+	}
+	goals, err := marshalList(a.Goals)
+	if err != nil {
+		return nil, err
+	}
+	achievements, err := marshalList(a.Achievements)
+	if err != nil {
+		return nil, err
+	}
+	// 보유 포지션이 없으면 두 컬럼 모두 NULL로 저장합니다
+	var holdingSymbol, holding interface{}
+	if a.Holding != nil {
+		data, err := json.Marshal(a.Holding)
+		if err != nil {
+			return nil, err
+		}
+		holdingSymbol, holding = a.Holding.Symbol, data
+	}
+	var liability interface{}
+	if a.Liability != nil {
+		data, err := json.Marshal(a.Liability)
+		if err != nil {
+			return nil, err
+		}
+		liability = data
+	}
+	return []interface{}{
+		a.ID, a.UserID, string(a.Type), a.Name, a.Amount.Amount, a.Amount.Currency,
+		performance, goals, achievements, a.CreatedAt, a.UpdatedAt,
+		holdingSymbol, holding, liability, a.Version,
+	}, nil
+}
+
+func scanAsset(row rowScanner) (*Asset, error) {
+	var a Asset
+	var assetType string
+	var performance, goals, achievements, holding, liability []byte
+	var holdingSymbol sql.NullString
+	err := row.Scan(&a.ID, &a.UserID, &assetType, &a.Name, &a.Amount.Amount, &a.Amount.Currency,
+		&performance, &goals, &achievements, &a.CreatedAt, &a.UpdatedAt,
+		&holdingSymbol, &holding, &liability, &a.Version)
+	if err != nil {
+		return nil, err
+	}
+	a.Type = Type(assetType)
+
+	if len(performance) > 0 {
+		if err := json.Unmarshal(performance, &a.Performance); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(goals, &a.Goals); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(achievements, &a.Achievements); err != nil {
+		return nil, err
+	}
+	if len(holding) > 0 {
+		if err := json.Unmarshal(holding, &a.Holding); err != nil {
+			return nil, err
+		}
+	}
+	if len(liability) > 0 {
+		if err := json.Unmarshal(liability, &a.Liability); err != nil {
+			return nil, err
+		}
+	}
+	return &a, nil
+}
+
+// FindByUserID 사용자 ID로 Asset 목록을 조회합니다.
+func (r *PostgresAssetRepository) FindByUserID(ctx context.Context, userID string) ([]*Asset, error) {
+	assets, err := r.query(ctx, " WHERE user_id = $1 ORDER BY created_at, id", userID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByUserID", err)
+	}
+	return assets, nil
+}
+
+// FindByType Asset 유형으로 Asset 목록을 조회합니다.
+func (r *PostgresAssetRepository) FindByType(ctx context.Context, assetType Type) ([]*Asset, error) {
+	assets, err := r.query(ctx, " WHERE type = $1 ORDER BY created_at, id", string(assetType))
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByType", err)
+	}
+	return assets, nil
+}
+
+// FindBySymbol 보유 포지션의 심볼로 Asset 목록을 조회합니다.
+func (r *PostgresAssetRepository) FindBySymbol(ctx context.Context, symbol string) ([]*Asset, error) {
+	assets, err := r.query(ctx, " WHERE holding_symbol = $1 ORDER BY created_at, id", symbol)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindBySymbol", err)
+	}
+	return assets, nil
+}
+
+// UpdateAmount 자산의 금액을 업데이트합니다.
+func (r *PostgresAssetRepository) UpdateAmount(ctx context.Context, id string, amount Money) error {
+	affected, err := r.exec(ctx,
+		"UPDATE assets SET amount_amount = $2, amount_currency = $3, updated_at = $4, version = version + 1 WHERE id = $1",
+		id, amount.Amount, amount.Currency, time.Now())
+	if err != nil {
+		return domain.NewRepositoryError("UpdateAmount", err)
+	}
+	if affected == 0 {
+		return domain.NewRepositoryError("UpdateAmount", fmt.Errorf("entity with ID %s not found", id))
+	}
+	return nil
+}
+
+// PostgresTransactionRepository Transaction 도메인의 PostgreSQL 저장소 구현체입니다.
+type PostgresTransactionRepository struct {
+	*PostgresRepository[*Transaction]
+}
+
+// NewPostgresTransactionRepository transactions 테이블을 사용하는 Transaction 저장소를 생성합니다.
+func NewPostgresTransactionRepository(db *sql.DB) *PostgresTransactionRepository {
+	return &PostgresTransactionRepository{
+		PostgresRepository: newPostgresRepository(db, pgTable[*Transaction]{
+			name: "transactions",
+			columns: []string{
+				"id", "asset_id", "type", "amount_amount", "amount_currency",
+				"category", "description", "date", "created_at", "reconciled", "counter_asset_id", "version",
+			},
+			values: func(t *Transaction) ([]interface{}, error) {
+				return []interface{}{
+					t.ID, t.AssetID, string(t.Type), t.Amount.Amount, t.Amount.Currency,
+					t.Category, t.Description, t.Date, t.CreatedAt, t.Reconciled, t.CounterAssetID, t.Version,
+				}, nil
+			},
+			scan: func(row rowScanner) (*Transaction, error) {
+				var t Transaction
+				var txType string
+				err := row.Scan(&t.ID, &t.AssetID, &txType, &t.Amount.Amount, &t.Amount.Currency,
+					&t.Category, &t.Description, &t.Date, &t.CreatedAt, &t.Reconciled, &t.CounterAssetID, &t.Version)
+				if err != nil {
+					return nil, err
+				}
+				t.Type = TransactionType(txType)
+				return &t, nil
+			},
+		}),
+	}
+}
+
+// FindByAssetID AssetID로 Transaction 목록을 조회합니다.
+func (r *PostgresTransactionRepository) FindByAssetID(ctx context.Context, assetID string) ([]*Transaction, error) {
+	transactions, err := r.query(ctx, " WHERE asset_id = $1 ORDER BY date, id", assetID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByAssetID", err)
+	}
+	return transactions, nil
+}
+
+// FindByDateRange 날짜 범위로 Transaction 목록을 조회합니다.
+func (r *PostgresTransactionRepository) FindByDateRange(ctx context.Context, start, end time.Time) ([]*Transaction, error) {
+	transactions, err := r.query(ctx, " WHERE date BETWEEN $1 AND $2 ORDER BY date, id", start, end)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByDateRange", err)
+	}
+	return transactions, nil
+}
+
+// GetTotalAmount 자산의 수입과 지출을 합산한 총 거래 금액을 계산합니다.
+func (r *PostgresTransactionRepository) GetTotalAmount(ctx context.Context, assetID string) (Money, error) {
+	transactions, err := r.FindByAssetID(ctx, assetID)
+	if err != nil {
+		return Money{}, err
+	}
+
+	total := Money{Amount: 0, Currency: "KRW"}
+	for _, tx := range transactions {
+		switch tx.Type {
+		case Income:
+			result, err := total.Add(tx.Amount)
+			if err != nil {
+				return Money{}, err
+			}
+			total = result
+		case Expense:
+			result, err := total.Subtract(tx.Amount)
+			if err != nil {
+				return Money{}, err
+			}
+			total = result
+		}
+	}
+	return total, nil
+}
+
+// PostgresPortfolioRepository Portfolio 도메인의 PostgreSQL 저장소 구현체입니다.
+type PostgresPortfolioRepository struct {
+	*PostgresRepository[*Portfolio]
+}
+
+// NewPostgresPortfolioRepository portfolios 테이블을 사용하는 Portfolio 저장소를 생성합니다.
+// 구성 자산은 JSONB 컬럼에 저장됩니다.
+func NewPostgresPortfolioRepository(db *sql.DB) *PostgresPortfolioRepository {
+	return &PostgresPortfolioRepository{
+		PostgresRepository: newPostgresRepository(db, pgTable[*Portfolio]{
+			name:    "portfolios",
+			columns: []string{"id", "user_id", "assets", "created_at", "updated_at", "version"},
+			values: func(p *Portfolio) ([]interface{}, error) {
+				assets, err := marshalList(p.Assets)
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{p.ID, p.UserID, assets, p.CreatedAt, p.UpdatedAt, p.Version}, nil
+			},
+			scan: func(row rowScanner) (*Portfolio, error) {
+				var p Portfolio
+				var assets []byte
+				if err := row.Scan(&p.ID, &p.UserID, &assets, &p.CreatedAt, &p.UpdatedAt, &p.Version); err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(assets, &p.Assets); err != nil {
+					return nil, err
+				}
+				return &p, nil
+			},
+		}),
+	}
+}
+
+// FindByUserID 사용자 ID로 Portfolio를 조회합니다.
+func (r *PostgresPortfolioRepository) FindByUserID(ctx context.Context, userID string) (*Portfolio, error) {
+	portfolios, err := r.query(ctx, " WHERE user_id = $1 ORDER BY created_at, id LIMIT 1", userID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByUserID", err)
+	}
+	if len(portfolios) == 0 {
+		return nil, domain.NewRepositoryError("FindByUserID", fmt.Errorf("portfolio for user %s not found", userID))
+	}
+	return portfolios[0], nil
+}
+
+// FindByAssetID 자산을 구성에 포함한 Portfolio 목록을 조회합니다.
+func (r *PostgresPortfolioRepository) FindByAssetID(ctx context.Context, assetID string) ([]*Portfolio, error) {
+	// Weight까지 포함하면 포함 관계가 성립하지 않으므로 AssetID 키만 비교합니다
+	contains, err := json.Marshal([]map[string]string{{"AssetID": assetID}})
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByAssetID", err)
+	}
+
+	portfolios, err := r.query(ctx, " WHERE assets @> $1::jsonb ORDER BY created_at, id", contains)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByAssetID", err)
+	}
+	return portfolios, nil
+}
+
+// UpdateAssets Portfolio의 자산 구성을 업데이트합니다.
+func (r *PostgresPortfolioRepository) UpdateAssets(ctx context.Context, id string, assets []PortfolioAsset) error {
+	encoded, err := marshalList(assets)
+	if err != nil {
+		return domain.NewRepositoryError("UpdateAssets", err)
+	}
+	affected, err := r.exec(ctx, "UPDATE portfolios SET assets = $2, updated_at = $3, version = version + 1 WHERE id = $1", id, encoded, time.Now())
+	if err != nil {
+		return domain.NewRepositoryError("UpdateAssets", err)
+	}
+	if affected == 0 {
+		return domain.NewRepositoryError("UpdateAssets", fmt.Errorf("entity with ID %s not found", id))
+	}
+	return nil
+}
+
+// marshalList 슬라이스를 JSON 배열로 직렬화합니다. nil 슬라이스는 빈 배열이 됩니다.
+func marshalList[E any](items []E) ([]byte, error) {
+	if items == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(items)
+}
@@ -0,0 +1,194 @@
+package asset
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/query"
+	"github.com/aske/go_fi_chart/internal/storage/postgres"
+	"github.com/aske/go_fi_chart/pkg/storage/migrate"
+)
+
+// openTestDatabase POSTGRES_TEST_DSN이 가리키는 데이터베이스에 스키마를 새로 만들어 반환합니다.
+// 로컬 PostgreSQL(또는 호환 서버)이 없으면 테스트를 건너뜁니다.
+func openTestDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN이 설정되지 않아 PostgreSQL 통합 테스트를 건너뜁니다")
+	}
+
+	db, err := sql.Open(postgres.DriverName, dsn)
+	require.NoError(t, err)
+
+	migrations, err := postgres.Migrations()
+	require.NoError(t, err)
+	migrator := migrate.New(db, migrations)
+
+	ctx := context.Background()
+	require.NoError(t, migrator.Down(ctx, 0))
+	require.NoError(t, migrator.Up(ctx))
+	t.Cleanup(func() {
+		_ = migrator.Down(context.Background(), 0)
+		_ = db.Close()
+	})
+	return db
+}
+
+func Test_postgres_repository_should_round_trip_entities(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	db := openTestDatabase(t)
+	assets := NewPostgresAssetRepository(db)
+	transactions := NewPostgresTransactionRepository(db)
+	portfolios := NewPostgresPortfolioRepository(db)
+
+	asset, _ := NewAsset("user-1", Stock, "삼성전자", 1000, "KRW")
+	asset.Goals = []*Goal{{ID: "goal-1", Type: GoalTypeInvesting, Target: NewTestMoney(5000, "KRW")}}
+	tx, _ := NewTransaction(asset.ID, Income, NewTestMoney(500, "KRW"), "배당", "")
+	portfolio := NewPortfolio("user-1", []PortfolioAsset{{AssetID: asset.ID, Weight: 100}})
+
+	// When
+	require.NoError(t, assets.Save(ctx, asset))
+	require.NoError(t, transactions.Save(ctx, tx))
+	require.NoError(t, portfolios.Save(ctx, portfolio))
+
+	// Then
+	found, err := assets.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, asset.Amount, found.Amount)
+	require.Len(t, found.Goals, 1)
+	assert.Equal(t, GoalTypeInvesting, found.Goals[0].Type)
+
+	byAsset, err := transactions.FindByAssetID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Len(t, byAsset, 1)
+
+	total, err := transactions.GetTotalAmount(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 500.0, total.Amount)
+
+	containing, err := portfolios.FindByAssetID(ctx, asset.ID)
+	require.NoError(t, err)
+	require.Len(t, containing, 1)
+	assert.Equal(t, portfolio.ID, containing[0].ID)
+
+	err = assets.Save(ctx, asset)
+	assert.Error(t, err)
+}
+
+func Test_postgres_repository_should_find_by_criteria(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	db := openTestDatabase(t)
+	repo := NewPostgresAssetRepository(db)
+
+	cash, _ := NewAsset("user-1", Cash, "비상금", 300, "KRW")
+	stock, _ := NewAsset("user-1", Stock, "삼성전자", 2000, "KRW")
+	require.NoError(t, repo.Save(ctx, cash))
+	require.NoError(t, repo.Save(ctx, stock))
+
+	// When
+	found, err := repo.FindAll(ctx, query.MustParse(`type = "STOCK" AND amount.amount >= 1000`))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, stock.ID, found[0].ID)
+}
+
+func Test_postgres_repository_should_roll_back_failed_transaction(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	db := openTestDatabase(t)
+	assets := NewPostgresAssetRepository(db)
+	transactions := NewPostgresTransactionRepository(db)
+
+	asset, _ := NewAsset("user-1", Cash, "현금", 100, "KRW")
+	failure := errors.New("중단")
+
+	// When
+	err := assets.WithTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, assets.Save(ctx, asset))
+		tx, _ := NewTransaction(asset.ID, Expense, NewTestMoney(50, "KRW"), "식비", "")
+		require.NoError(t, transactions.Save(ctx, tx))
+
+		// 중첩 트랜잭션의 실패는 세이브포인트까지만 되돌립니다
+		inner := assets.WithTransaction(ctx, func(ctx context.Context) error {
+			require.NoError(t, assets.UpdateAmount(ctx, asset.ID, NewTestMoney(0, "KRW")))
+			return failure
+		})
+		assert.ErrorIs(t, inner, failure)
+
+		found, err := assets.FindByID(ctx, asset.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 100.0, found.Amount.Amount)
+		return failure
+	})
+
+	// Then
+	assert.ErrorIs(t, err, failure)
+	_, err = assets.FindByID(ctx, asset.ID)
+	assert.Error(t, err)
+	found, err := transactions.FindByDateRange(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func Test_postgres_repository_should_find_assets_by_holding_symbol(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	db := openTestDatabase(t)
+	repo := NewPostgresAssetRepository(db)
+
+	tesla := newHoldingAsset(t, "TSLA", 10, 1000)
+	plain, _ := NewAsset("user-1", Cash, "예금", 1000, "USD")
+	require.NoError(t, repo.Save(ctx, tesla))
+	require.NoError(t, repo.Save(ctx, plain))
+
+	// When
+	revalued, err := NewRevaluer(repo, nil).Revalue(ctx, "TSLA", NewTestMoney(120, "USD"), time.Now())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, revalued)
+	found, err := repo.FindBySymbol(ctx, "TSLA")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, 1200.0, found[0].Amount.Amount)
+	assert.Equal(t, 10.0, found[0].Holding.Quantity)
+	assert.True(t, found[0].Holding.IsPriced())
+}
+
+func Test_postgres_repository_should_reject_update_with_stale_version(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	assets := NewPostgresAssetRepository(openTestDatabase(t))
+	asset, _ := NewAsset("user-1", Cash, "예금", 1000, "KRW")
+	require.NoError(t, assets.Save(ctx, asset))
+	first, err := assets.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	second, err := assets.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	first.Name = "먼저"
+	require.NoError(t, assets.Update(ctx, first))
+
+	// When
+	second.Name = "나중"
+	err = assets.Update(ctx, second)
+
+	// Then
+	assert.True(t, IsVersionConflict(err))
+	found, err := assets.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "먼저", found.Name)
+	assert.Equal(t, int64(2), found.Version)
+}
@@ -2,12 +2,50 @@ package asset
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/aske/go_fi_chart/internal/domain"
 )
 
+// versioned 낙관적 잠금 버전을 가진 엔티티입니다.
+// 저장소는 Save에서 버전을 1로 시작하고, Update에서 저장된 버전과 엔티티의 버전이 같을 때만 기록한 뒤 버전을 올립니다.
+type versioned interface {
+	GetVersion() int64
+	setVersion(version int64)
+}
+
+// versionOf 엔티티가 버전을 가지면 그 값을 반환합니다.
+func versionOf(entity interface{}) (int64, bool) {
+	v, ok := entity.(versioned)
+	if !ok {
+		return 0, false
+	}
+	return v.GetVersion(), true
+}
+
+// initVersion 처음 저장하는 엔티티의 버전을 1로 설정합니다.
+func initVersion(entity interface{}) {
+	if v, ok := entity.(versioned); ok && v.GetVersion() == 0 {
+		v.setVersion(1)
+	}
+}
+
+// versionConflict 엔티티의 버전이 저장된 버전과 다를 때 반환하는 에러를 생성합니다.
+func versionConflict(id string, expected, actual int64) error {
+	return domain.NewError("asset", domain.ErrCodeConflict,
+		fmt.Sprintf("entity with ID %s version conflict: expected %d, actual %d", id, expected, actual))
+}
+
+// IsVersionConflict 에러가 낙관적 잠금 버전 충돌인지 확인합니다.
+func IsVersionConflict(err error) bool {
+	var domainErr domain.Error
+	return errors.As(err, &domainErr) && domainErr.Code() == domain.ErrCodeConflict
+}
+
 // Repository 자산 저장소 인터페이스입니다.
+// Update는 자산의 Version이 저장된 버전과 같을 때만 기록하며, 다르면 버전 충돌 에러를 반환합니다.
 type Repository interface {
 	Save(ctx context.Context, asset *Asset) error
 	FindByID(ctx context.Context, id string) (*Asset, error)
@@ -15,6 +53,7 @@ type Repository interface {
 	Delete(ctx context.Context, id string) error
 	FindByUserID(ctx context.Context, userID string) ([]*Asset, error)
 	FindByType(ctx context.Context, assetType Type) ([]*Asset, error)
+	FindBySymbol(ctx context.Context, symbol string) ([]*Asset, error)
 	UpdateAmount(ctx context.Context, id string, amount Money) error
 	FindAll(ctx context.Context, criteria domain.SearchCriteria) ([]*Asset, error)
 	FindOne(ctx context.Context, criteria domain.SearchCriteria) (*Asset, error)
@@ -22,6 +61,7 @@ type Repository interface {
 }
 
 // TransactionRepository 거래 내역 저장소 인터페이스입니다.
+// Update는 Repository와 같은 방식으로 버전을 검사합니다.
 type TransactionRepository interface {
 	Save(ctx context.Context, tx *Transaction) error
 	FindByID(ctx context.Context, id string) (*Transaction, error)
@@ -36,6 +76,7 @@ type TransactionRepository interface {
 }
 
 // PortfolioRepository 포트폴리오 저장소 인터페이스입니다.
+// Update는 Repository와 같은 방식으로 버전을 검사합니다.
 type PortfolioRepository interface {
 	Save(ctx context.Context, portfolio *Portfolio) error
 	FindByID(ctx context.Context, id string) (*Portfolio, error)
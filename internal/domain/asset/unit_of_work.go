@@ -0,0 +1,150 @@
+package asset
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// txKey 컨텍스트에 진행 중인 작업 단위를 저장하기 위한 키입니다.
+type txKey struct{}
+
+// storeSeq 저장소마다 고유한 순번을 부여해 커밋 시 잠금 순서를 고정합니다.
+var storeSeq atomic.Uint64
+
+// txStore 작업 단위에 참여하는 저장소가 구현하는 인터페이스입니다.
+type txStore interface {
+	storeID() uint64
+	lock()
+	unlock()
+	// validate 잠금을 획득한 상태에서 스테이징된 쓰기가 기준으로 삼은 버전이 그대로인지 확인합니다.
+	validate(staged any) error
+	// apply 잠금을 획득한 상태에서 스테이징된 쓰기를 반영합니다.
+	apply(staged any)
+	// merge 세이브포인트의 쓰기를 부모 트랜잭션의 쓰기 위에 덮어씁니다.
+	merge(parent, child any) any
+}
+
+// memoryTx 인메모리 저장소 간에 공유되는 copy-on-write 작업 단위(Unit of Work)입니다.
+// 쓰기는 커밋 전까지 저장소별 오버레이에 스테이징되며,
+// 중첩된 WithTransaction 호출은 부모 작업 단위를 가리키는 세이브포인트가 됩니다.
+type memoryTx struct {
+	parent *memoryTx
+	mu     sync.Mutex
+	staged map[txStore]any
+}
+
+func newMemoryTx(parent *memoryTx) *memoryTx {
+	return &memoryTx{
+		parent: parent,
+		staged: make(map[txStore]any),
+	}
+}
+
+// currentTx 컨텍스트에서 진행 중인 작업 단위를 찾습니다.
+func currentTx(ctx context.Context) *memoryTx {
+	if ctx == nil {
+		return nil
+	}
+	tx, _ := ctx.Value(txKey{}).(*memoryTx)
+	return tx
+}
+
+// runInTransaction fn을 작업 단위 안에서 실행합니다.
+// fn이 에러를 반환하거나 panic이 발생하면 스테이징된 쓰기는 모두 버려집니다.
+// 최상위 작업 단위는 참여한 모든 저장소를 잠근 뒤 한 번에 커밋하고,
+// 중첩된 작업 단위(세이브포인트)는 성공 시 부모에게 쓰기를 넘깁니다.
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	parent := currentTx(ctx)
+	tx := newMemoryTx(parent)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if parent != nil {
+		parent.absorb(tx)
+		return nil
+	}
+	return tx.commit()
+}
+
+// absorb 세이브포인트의 쓰기를 현재 작업 단위로 가져옵니다.
+func (tx *memoryTx) absorb(child *memoryTx) {
+	child.mu.Lock()
+	defer child.mu.Unlock()
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	for store, staged := range child.staged {
+		tx.staged[store] = store.merge(tx.staged[store], staged)
+	}
+}
+
+// commit 참여한 모든 저장소를 고정된 순서로 잠근 뒤 쓰기를 원자적으로 반영합니다.
+// 작업 단위가 읽은 뒤 다른 요청이 먼저 커밋해 버전이 바뀐 엔티티가 있으면 아무것도 반영하지 않고 버전 충돌 에러를 반환합니다.
+func (tx *memoryTx) commit() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	stores := make([]txStore, 0, len(tx.staged))
+	for store := range tx.staged {
+		stores = append(stores, store)
+	}
+	sort.Slice(stores, func(i, j int) bool {
+		return stores[i].storeID() < stores[j].storeID()
+	})
+
+	for _, store := range stores {
+		store.lock()
+	}
+	defer func() {
+		for i := len(stores) - 1; i >= 0; i-- {
+			stores[i].unlock()
+		}
+	}()
+
+	for _, store := range stores {
+		if err := store.validate(tx.staged[store]); err != nil {
+			return err
+		}
+	}
+	for _, store := range stores {
+		store.apply(tx.staged[store])
+	}
+	return nil
+}
+
+// stagedWrite 작업 단위 안에서 기록된 하나의 쓰기입니다.
+// base는 쓰기가 기준으로 삼은 저장된 엔티티의 버전이며, 0이면 커밋할 때 버전을 확인하지 않습니다.
+type stagedWrite[T any] struct {
+	value   T
+	deleted bool
+	base    int64
+}
+
+// overlay 저장소 하나에 대해 스테이징된 쓰기 집합입니다.
+type overlay[T any] map[string]stagedWrite[T]
+
+// overlayFor 작업 단위에서 저장소의 오버레이를 가져오고, 없으면 생성합니다.
+func overlayFor[T any](tx *memoryTx, store txStore) overlay[T] {
+	if staged, ok := tx.staged[store]; ok {
+		return staged.(overlay[T])
+	}
+	o := make(overlay[T])
+	tx.staged[store] = o
+	return o
+}
+
+// cloneEntity 엔티티가 Clone을 제공하면 복사본을 반환합니다.
+// 트랜잭션 안에서 읽은 엔티티를 직접 수정해도 커밋 전까지 저장소에 반영되지 않도록 합니다.
+func cloneEntity[T any](v T) T {
+	if c, ok := any(v).(interface{ Clone() T }); ok {
+		return c.Clone()
+	}
+	return v
+}
@@ -0,0 +1,199 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_unit_of_work_should_commit_writes_across_repositories(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	assetRepo := NewMemoryAssetRepository()
+	txRepo := NewMemoryTransactionRepository()
+	portfolioRepo := NewMemoryPortfolioRepository()
+	asset, _ := NewAsset("user-1", Cash, "예금", 1000, "KRW")
+	require.NoError(t, assetRepo.Save(ctx, asset))
+
+	// When
+	err := assetRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		found, err := assetRepo.FindByID(ctx, asset.ID)
+		if err != nil {
+			return err
+		}
+		tx, _ := NewTransaction(asset.ID, Income, NewTestMoney(500, "KRW"), "급여", "")
+		if err := found.ProcessTransaction(tx); err != nil {
+			return err
+		}
+		if err := txRepo.Save(ctx, tx); err != nil {
+			return err
+		}
+		if err := portfolioRepo.Save(ctx, NewPortfolio("user-1", []PortfolioAsset{{AssetID: asset.ID, Weight: 100}})); err != nil {
+			return err
+		}
+
+		// 커밋 전에는 작업 단위 밖에서 보이지 않습니다
+		outside, _ := assetRepo.FindByID(context.Background(), asset.ID)
+		assert.Equal(t, 1000.0, outside.Amount.Amount)
+		all, _ := txRepo.FindAll(context.Background(), nil)
+		assert.Empty(t, all)
+
+		return assetRepo.Update(ctx, found)
+	})
+
+	// Then
+	require.NoError(t, err)
+	found, _ := assetRepo.FindByID(ctx, asset.ID)
+	assert.Equal(t, 1500.0, found.Amount.Amount)
+	txs, _ := txRepo.FindByAssetID(ctx, asset.ID)
+	assert.Len(t, txs, 1)
+	portfolio, err := portfolioRepo.FindByUserID(ctx, "user-1")
+	assert.NoError(t, err)
+	assert.NotNil(t, portfolio)
+}
+
+func Test_unit_of_work_should_roll_back_all_writes_on_error(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	assetRepo := NewMemoryAssetRepository()
+	txRepo := NewMemoryTransactionRepository()
+	asset, _ := NewAsset("user-1", Cash, "예금", 1000, "KRW")
+	require.NoError(t, assetRepo.Save(ctx, asset))
+	saveErr := errors.New("거래 저장 실패")
+
+	// When
+	err := assetRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		found, _ := assetRepo.FindByID(ctx, asset.ID)
+		tx, _ := NewTransaction(asset.ID, Expense, NewTestMoney(300, "KRW"), "식비", "")
+		require.NoError(t, found.ProcessTransaction(tx))
+		require.NoError(t, assetRepo.Update(ctx, found))
+		require.NoError(t, txRepo.Save(ctx, tx))
+		return saveErr
+	})
+
+	// Then
+	assert.ErrorIs(t, err, saveErr)
+	found, _ := assetRepo.FindByID(ctx, asset.ID)
+	assert.Equal(t, 1000.0, found.Amount.Amount)
+	all, _ := txRepo.FindAll(ctx, nil)
+	assert.Empty(t, all)
+}
+
+func Test_unit_of_work_should_roll_back_only_failed_savepoint(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryAssetRepository()
+	first, _ := NewAsset("user-1", Cash, "첫번째", 100, "KRW")
+	second, _ := NewAsset("user-1", Cash, "두번째", 200, "KRW")
+
+	// When
+	err := repo.WithTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, repo.Save(ctx, first))
+
+		innerErr := repo.WithTransaction(ctx, func(ctx context.Context) error {
+			require.NoError(t, repo.Save(ctx, second))
+			require.NoError(t, repo.Delete(ctx, first.ID))
+			return errors.New("세이브포인트 실패")
+		})
+		assert.Error(t, innerErr)
+
+		// 세이브포인트 롤백 후에도 바깥 쓰기는 유지됩니다
+		_, err := repo.FindByID(ctx, first.ID)
+		assert.NoError(t, err)
+		_, err = repo.FindByID(ctx, second.ID)
+		assert.Error(t, err)
+
+		return repo.WithTransaction(ctx, func(ctx context.Context) error {
+			return repo.UpdateAmount(ctx, first.ID, NewTestMoney(150, "KRW"))
+		})
+	})
+
+	// Then
+	require.NoError(t, err)
+	found, err := repo.FindByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, found.Amount.Amount)
+	_, err = repo.FindByID(ctx, second.ID)
+	assert.Error(t, err)
+}
+
+func Test_unit_of_work_should_stage_deletes_and_duplicate_checks(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryPortfolioRepository()
+	portfolio := NewPortfolio("user-1", nil)
+	require.NoError(t, repo.Save(ctx, portfolio))
+
+	// When
+	err := repo.WithTransaction(ctx, func(ctx context.Context) error {
+		assert.Error(t, repo.Save(ctx, portfolio))
+		require.NoError(t, repo.Delete(ctx, portfolio.ID))
+		assert.Error(t, repo.UpdateAssets(ctx, portfolio.ID, nil))
+
+		all, _ := repo.FindAll(ctx, nil)
+		assert.Empty(t, all)
+		return nil
+	})
+
+	// Then
+	require.NoError(t, err)
+	_, err = repo.FindByID(ctx, portfolio.ID)
+	assert.Error(t, err)
+}
+
+func Test_asset_clone_should_not_share_state(t *testing.T) {
+	// Given
+	asset, _ := NewAsset("user-1", Cash, "예금", 1000, "KRW")
+	asset.AddGoal(GoalTypeSaving, NewTestMoney(5000, "KRW"), asset.CreatedAt)
+
+	// When
+	clone := asset.Clone()
+	clone.Amount = NewTestMoney(1, "KRW")
+	clone.Goals[0].Progress = 50
+	clone.Performance.GrowthRate = 3
+
+	// Then
+	assert.Equal(t, 1000.0, asset.Amount.Amount)
+	assert.Equal(t, 0.0, asset.Goals[0].Progress)
+	assert.Equal(t, 0.0, asset.Performance.GrowthRate)
+}
+
+func Test_unit_of_work_should_fail_commit_when_entity_changed_after_read(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	assetRepo := NewMemoryAssetRepository()
+	txRepo := NewMemoryTransactionRepository()
+	asset, _ := NewAsset("user-1", Cash, "예금", 1000, "KRW")
+	require.NoError(t, assetRepo.Save(ctx, asset))
+
+	// When
+	err := assetRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		found, err := assetRepo.FindByID(ctx, asset.ID)
+		if err != nil {
+			return err
+		}
+		found.Name = "작업 단위"
+		if err := assetRepo.Update(ctx, found); err != nil {
+			return err
+		}
+		tx, _ := NewTransaction(asset.ID, Income, NewTestMoney(500, "KRW"), "급여", "")
+		if err := txRepo.Save(ctx, tx); err != nil {
+			return err
+		}
+
+		// 작업 단위가 커밋되기 전에 다른 요청이 같은 자산을 먼저 변경합니다
+		concurrent, _ := assetRepo.FindByID(context.Background(), asset.ID)
+		concurrent.Name = "다른 요청"
+		return assetRepo.Update(context.Background(), concurrent)
+	})
+
+	// Then
+	assert.True(t, IsVersionConflict(err))
+	found, _ := assetRepo.FindByID(ctx, asset.ID)
+	assert.Equal(t, "다른 요청", found.Name)
+	txs, _ := txRepo.FindAll(ctx, nil)
+	assert.Empty(t, txs)
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	entryBucket = "audit_entries"
+	indexEntity = "entity"
+)
+
+// EmbeddedRepository 감사 로그의 임베디드 키-값 저장소 구현체입니다.
+type EmbeddedRepository struct {
+	entries *kv.Collection[*Entry]
+}
+
+// NewEmbeddedRepository 엔티티 인덱스를 가진 임베디드 감사 로그 저장소를 생성합니다.
+func NewEmbeddedRepository(db *kv.DB) (*EmbeddedRepository, error) {
+	entries := kv.NewCollection[*Entry](db, entryBucket)
+	if err := entries.Index(indexEntity, func(e *Entry) []string {
+		return []string{entityKey(e.EntityType, e.EntityID)}
+	}); err != nil {
+		return nil, err
+	}
+	return &EmbeddedRepository{entries: entries}, nil
+}
+
+// storageError 도메인 에러는 그대로 두고, 저장소 에러는 내부 에러로 감쌉니다.
+func storageError(err error) error {
+	var domainErr domain.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewError("audit", domain.ErrCodeInternal, err.Error())
+}
+
+// Append 감사 로그 항목을 추가합니다.
+func (r *EmbeddedRepository) Append(ctx context.Context, entry *Entry) error {
+	return storageError(r.entries.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.entries.Exists(tx, entry.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domain.NewError("audit", domain.ErrCodeAlreadyExists, fmt.Sprintf("audit entry with ID %s already exists", entry.ID))
+		}
+		return r.entries.Put(tx, entry.ID, entry)
+	}))
+}
+
+// FindByEntity 엔티티의 감사 로그를 기록 시각 순으로 조회합니다.
+func (r *EmbeddedRepository) FindByEntity(ctx context.Context, entityType EntityType, entityID string) ([]*Entry, error) {
+	var entries []*Entry
+	err := r.entries.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.entries.Lookup(tx, indexEntity, entityKey(entityType, entityID))
+		entries = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if entries == nil {
+		entries = []*Entry{}
+	}
+	sortEntries(entries)
+	return entries, nil
+}
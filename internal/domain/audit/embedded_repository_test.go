@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+func Test_EmbeddedRepository_should_find_entries_by_entity_in_recorded_order(t *testing.T) {
+	// Given
+	db := kv.OpenMemory()
+	repo, err := NewEmbeddedRepository(db)
+	require.NoError(t, err)
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// When
+	require.NoError(t, repo.Append(ctx, &Entry{ID: "z", EntityType: EntityAsset, EntityID: "a1", Action: ActionCreate, RecordedAt: now}))
+	require.NoError(t, repo.Append(ctx, &Entry{ID: "a", EntityType: EntityAsset, EntityID: "a1", Action: ActionUpdate, RecordedAt: now.Add(time.Second)}))
+	require.NoError(t, repo.Append(ctx, &Entry{ID: "m", EntityType: EntityPortfolio, EntityID: "a1", Action: ActionCreate, RecordedAt: now}))
+
+	// Then
+	entries, err := repo.FindByEntity(ctx, EntityAsset, "a1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "z", entries[0].ID)
+	assert.Equal(t, "a", entries[1].ID)
+	assert.Error(t, repo.Append(ctx, &Entry{ID: "z", EntityType: EntityAsset, EntityID: "a1"}))
+}
+
+func Test_EmbeddedRepository_should_persist_entries_across_reopen(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "audit.db")
+	db, err := kv.Open(path, nil)
+	require.NoError(t, err)
+	repo, err := NewEmbeddedRepository(db)
+	require.NoError(t, err)
+	recorder := NewRecorder(repo)
+	ctx := context.Background()
+	require.NoError(t, recorder.Record(ctx, EntityTransaction, "t1", ActionCreate, account{Balance: 10}))
+	require.NoError(t, db.Close())
+
+	// When
+	db, err = kv.Open(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	repo, err = NewEmbeddedRepository(db)
+	require.NoError(t, err)
+
+	// Then
+	state, err := NewRecorder(repo).StateAt(ctx, EntityTransaction, "t1", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, state.State["Balance"])
+}
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+)
+
+// MemoryRepository 감사 로그의 인메모리 저장소 구현체입니다.
+type MemoryRepository struct {
+	entries map[string][]*Entry
+	ids     map[string]struct{}
+	mutex   sync.RWMutex
+}
+
+// NewMemoryRepository 새로운 인메모리 감사 로그 저장소를 생성합니다.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		entries: make(map[string][]*Entry),
+		ids:     make(map[string]struct{}),
+	}
+}
+
+func entityKey(entityType EntityType, entityID string) string {
+	return string(entityType) + "/" + entityID
+}
+
+// Append 감사 로그 항목을 추가합니다.
+func (r *MemoryRepository) Append(_ context.Context, entry *Entry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.ids[entry.ID]; exists {
+		return domain.NewError("audit", domain.ErrCodeAlreadyExists, fmt.Sprintf("audit entry with ID %s already exists", entry.ID))
+	}
+
+	key := entityKey(entry.EntityType, entry.EntityID)
+	r.ids[entry.ID] = struct{}{}
+	r.entries[key] = append(r.entries[key], entry)
+	return nil
+}
+
+// FindByEntity 엔티티의 감사 로그를 기록 시각 순으로 조회합니다.
+func (r *MemoryRepository) FindByEntity(_ context.Context, entityType EntityType, entityID string) ([]*Entry, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entries := append([]*Entry{}, r.entries[entityKey(entityType, entityID)]...)
+	sortEntries(entries)
+	return entries, nil
+}
+
+// sortEntries 항목을 기록 시각 순으로 정렬합니다. 같은 시각이면 추가된 순서를 유지합니다.
+func sortEntries(entries []*Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].RecordedAt.Before(entries[j].RecordedAt)
+	})
+}
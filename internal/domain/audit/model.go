@@ -0,0 +1,123 @@
+// Package audit 자산, 거래, 포트폴리오의 변경 이력을 기록하고 특정 시점의 상태를 복원합니다.
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// EntityType 감사 대상 엔티티의 종류입니다.
+type EntityType string
+
+const (
+	EntityAsset       EntityType = "asset"
+	EntityTransaction EntityType = "transaction"
+	EntityPortfolio   EntityType = "portfolio"
+)
+
+// IsValidEntityType 감사 대상 엔티티 종류인지 확인합니다.
+func IsValidEntityType(entityType EntityType) bool {
+	switch entityType {
+	case EntityAsset, EntityTransaction, EntityPortfolio:
+		return true
+	}
+	return false
+}
+
+// Action 엔티티에 가해진 변경의 종류입니다.
+type Action string
+
+const (
+	ActionCreate Action = "CREATE"
+	ActionUpdate Action = "UPDATE"
+	ActionDelete Action = "DELETE"
+)
+
+// Change 하나의 필드에 대한 변경 전후 값입니다.
+// Field는 중첩 필드를 점으로 이은 경로입니다(예: "Amount.Amount").
+type Change struct {
+	Field  string
+	Before interface{}
+	After  interface{}
+}
+
+// Entry 감사 로그의 한 항목입니다.
+// State는 변경 직후 엔티티의 전체 상태이며, 삭제 항목이면 nil입니다.
+type Entry struct {
+	ID         string
+	EntityType EntityType
+	EntityID   string
+	Action     Action
+	Actor      string
+	Changes    []Change
+	State      map[string]interface{}
+	RecordedAt time.Time
+}
+
+// Snapshot 엔티티를 JSON 표현 기준의 맵으로 변환합니다.
+// 공개 필드만 포함되므로 미발행 이벤트 같은 내부 상태는 기록되지 않습니다.
+func Snapshot(entity interface{}) (map[string]interface{}, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var state map[string]interface{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Diff 두 상태를 필드 단위로 비교해 변경 목록을 필드 경로 순으로 반환합니다.
+// 한쪽에만 있는 필드는 다른 쪽 값이 nil인 변경으로 표현됩니다.
+func Diff(before, after map[string]interface{}) []Change {
+	flatBefore := flatten(before)
+	flatAfter := flatten(after)
+
+	fields := make([]string, 0, len(flatBefore)+len(flatAfter))
+	for field := range flatBefore {
+		fields = append(fields, field)
+	}
+	for field := range flatAfter {
+		if _, ok := flatBefore[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := make([]Change, 0)
+	for _, field := range fields {
+		b, a := flatBefore[field], flatAfter[field]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes = append(changes, Change{Field: field, Before: b, After: a})
+	}
+	return changes
+}
+
+// flatten 중첩 맵을 점으로 이은 경로의 평탄한 맵으로 변환합니다.
+// 배열과 빈 맵은 하나의 값으로 취급합니다.
+func flatten(state map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		nested, ok := value.(map[string]interface{})
+		if !ok || len(nested) == 0 {
+			flat[prefix] = value
+			return
+		}
+		for key, v := range nested {
+			walk(prefix+"."+key, v)
+		}
+	}
+	for key, value := range state {
+		walk(key, value)
+	}
+	return flat
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Snapshot_should_include_only_exported_fields(t *testing.T) {
+	// Given
+	type sample struct {
+		Name   string
+		Amount struct{ Value float64 }
+		hidden string
+	}
+	entity := sample{Name: "예금", hidden: "secret"}
+	entity.Amount.Value = 1000
+
+	// When
+	state, err := Snapshot(entity)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"Name":   "예금",
+		"Amount": map[string]interface{}{"Value": 1000.0},
+	}, state)
+}
+
+func Test_Diff_should_report_changed_fields_with_dotted_paths(t *testing.T) {
+	// Given
+	before := map[string]interface{}{
+		"Name":   "예금",
+		"Amount": map[string]interface{}{"Amount": 1000.0, "Currency": "KRW"},
+		"Tags":   []interface{}{"a"},
+	}
+	after := map[string]interface{}{
+		"Name":   "예금",
+		"Amount": map[string]interface{}{"Amount": 2000.0, "Currency": "KRW"},
+		"Tags":   []interface{}{"a", "b"},
+		"Memo":   "추가",
+	}
+
+	// When
+	changes := Diff(before, after)
+
+	// Then
+	assert.Equal(t, []Change{
+		{Field: "Amount.Amount", Before: 1000.0, After: 2000.0},
+		{Field: "Memo", Before: nil, After: "추가"},
+		{Field: "Tags", Before: []interface{}{"a"}, After: []interface{}{"a", "b"}},
+	}, changes)
+}
+
+func Test_Diff_should_return_empty_changes_for_equal_states(t *testing.T) {
+	// Given
+	state := map[string]interface{}{"Name": "예금"}
+
+	// When
+	changes := Diff(state, map[string]interface{}{"Name": "예금"})
+
+	// Then
+	assert.Empty(t, changes)
+}
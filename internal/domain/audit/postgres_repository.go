@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// PostgresRepository audit_entries 테이블을 사용하는 감사 로그 저장소 구현체입니다.
+// 진행 중인 트랜잭션이 있으면 같은 트랜잭션 안에서 기록합니다.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository 새로운 PostgreSQL 감사 로그 저장소를 생성합니다.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// Append 감사 로그 항목을 추가합니다.
+func (r *PostgresRepository) Append(ctx context.Context, entry *Entry) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return domain.NewRepositoryError("Append", err)
+	}
+	var state []byte
+	if entry.State != nil {
+		if state, err = json.Marshal(entry.State); err != nil {
+			return domain.NewRepositoryError("Append", err)
+		}
+	}
+
+	res, err := sqldb.Conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO audit_entries (id, entity_type, entity_id, action, actor, changes, state, recorded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (id) DO NOTHING`,
+		entry.ID, string(entry.EntityType), entry.EntityID, string(entry.Action), entry.Actor,
+		changes, state, entry.RecordedAt)
+	if err != nil {
+		return domain.NewRepositoryError("Append", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return domain.NewRepositoryError("Append", err)
+	}
+	if affected == 0 {
+		return domain.NewError("audit", domain.ErrCodeAlreadyExists, fmt.Sprintf("audit entry with ID %s already exists", entry.ID))
+	}
+	return nil
+}
+
+// FindByEntity 엔티티의 감사 로그를 기록 시각 순으로 조회합니다.
+func (r *PostgresRepository) FindByEntity(ctx context.Context, entityType EntityType, entityID string) ([]*Entry, error) {
+	rows, err := sqldb.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id, entity_type, entity_id, action, actor, changes, state, recorded_at
+		FROM audit_entries WHERE entity_type = $1 AND entity_id = $2 ORDER BY recorded_at, id`,
+		string(entityType), entityID)
+	if err != nil {
+		return nil, domain.NewRepositoryError("FindByEntity", err)
+	}
+	defer rows.Close()
+
+	entries := []*Entry{}
+	for rows.Next() {
+		var (
+			entry          Entry
+			changes, state []byte
+		)
+		if err := rows.Scan(&entry.ID, &entry.EntityType, &entry.EntityID, &entry.Action, &entry.Actor,
+			&changes, &state, &entry.RecordedAt); err != nil {
+			return nil, domain.NewRepositoryError("FindByEntity", err)
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, domain.NewRepositoryError("FindByEntity", err)
+		}
+		if state != nil {
+			if err := json.Unmarshal(state, &entry.State); err != nil {
+				return nil, domain.NewRepositoryError("FindByEntity", err)
+			}
+		}
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.NewRepositoryError("FindByEntity", err)
+	}
+	return entries, nil
+}
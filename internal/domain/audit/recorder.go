@@ -0,0 +1,208 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+)
+
+// DefaultActor 요청에 행위자 정보가 없을 때 기록되는 행위자입니다.
+const DefaultActor = "system"
+
+type actorKey struct{}
+
+type bufferKey struct{}
+
+// WithActor 행위자를 담은 컨텍스트를 반환합니다.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext 컨텍스트에 담긴 행위자를 반환합니다. 없으면 DefaultActor입니다.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return DefaultActor
+}
+
+// buffer Atomic 안에서 기록된 항목을 fn이 성공할 때까지 모아 둡니다.
+type buffer struct {
+	parent  *buffer
+	mu      sync.Mutex
+	entries []*Entry
+}
+
+func bufferFrom(ctx context.Context) *buffer {
+	buf, _ := ctx.Value(bufferKey{}).(*buffer)
+	return buf
+}
+
+func (b *buffer) add(entries ...*Entry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = append(b.entries, entries...)
+}
+
+// latest 버퍼 체인에서 엔티티의 마지막 항목을 안쪽 버퍼부터 찾습니다.
+func (b *buffer) latest(entityType EntityType, entityID string) *Entry {
+	for buf := b; buf != nil; buf = buf.parent {
+		buf.mu.Lock()
+		for i := len(buf.entries) - 1; i >= 0; i-- {
+			if e := buf.entries[i]; e.EntityType == entityType && e.EntityID == entityID {
+				buf.mu.Unlock()
+				return e
+			}
+		}
+		buf.mu.Unlock()
+	}
+	return nil
+}
+
+// Recorder 엔티티 변경을 감사 로그로 기록하고, 이력과 특정 시점의 상태를 조회합니다.
+// 변경 내역은 직전 항목의 상태와 새 상태를 비교해 계산하므로 저장소를 다시 읽지 않습니다.
+type Recorder struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// RecorderOption Recorder 설정 옵션입니다.
+type RecorderOption func(*Recorder)
+
+// WithClock 기록 시각을 구하는 함수를 지정합니다.
+func WithClock(now func() time.Time) RecorderOption {
+	return func(r *Recorder) {
+		r.now = now
+	}
+}
+
+// NewRecorder 새로운 Recorder를 생성합니다.
+func NewRecorder(repo Repository, opts ...RecorderOption) *Recorder {
+	r := &Recorder{repo: repo, now: time.Now}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Record 엔티티의 변경을 기록합니다.
+// 삭제가 아니면 entity의 현재 상태를 함께 저장하며, 바뀐 필드가 없는 수정은 기록하지 않습니다.
+// Atomic 안에서 호출되면 fn이 성공할 때까지 기록을 미룹니다.
+func (r *Recorder) Record(ctx context.Context, entityType EntityType, entityID string, action Action, entity interface{}) error {
+	var state map[string]interface{}
+	if action != ActionDelete {
+		snapshot, err := Snapshot(entity)
+		if err != nil {
+			return domain.NewError("audit", domain.ErrCodeInternal, fmt.Sprintf("failed to snapshot %s %s: %v", entityType, entityID, err))
+		}
+		state = snapshot
+	}
+
+	last, err := r.latest(ctx, entityType, entityID)
+	if err != nil {
+		return err
+	}
+	var before map[string]interface{}
+	var lastAt time.Time
+	if last != nil {
+		before = last.State
+		lastAt = last.RecordedAt
+	}
+
+	changes := Diff(before, state)
+	if action == ActionUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	// 같은 엔티티의 항목은 기록 시각이 항상 증가하도록 보정합니다 (PostgreSQL 정밀도는 마이크로초)
+	recordedAt := r.now().UTC().Truncate(time.Microsecond)
+	if !recordedAt.After(lastAt) {
+		recordedAt = lastAt.Add(time.Microsecond)
+	}
+
+	entry := &Entry{
+		ID:         uuid.New().String(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      ActorFromContext(ctx),
+		Changes:    changes,
+		State:      state,
+		RecordedAt: recordedAt,
+	}
+	if buf := bufferFrom(ctx); buf != nil {
+		buf.add(entry)
+		return nil
+	}
+	return r.repo.Append(ctx, entry)
+}
+
+// latest 아직 저장되지 않은 항목까지 포함해 엔티티의 마지막 항목을 반환합니다.
+func (r *Recorder) latest(ctx context.Context, entityType EntityType, entityID string) (*Entry, error) {
+	if buf := bufferFrom(ctx); buf != nil {
+		if entry := buf.latest(entityType, entityID); entry != nil {
+			return entry, nil
+		}
+	}
+	entries, err := r.repo.FindByEntity(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[len(entries)-1], nil
+}
+
+// Atomic fn 안에서 기록된 항목을 모아 두었다가 fn이 성공하면 저장합니다.
+// 저장소 트랜잭션 안에서 호출하면 항목도 같은 트랜잭션으로 저장되고,
+// fn이 실패하면 항목은 모두 버려집니다. 중첩 호출은 성공 시 바깥 호출에 항목을 넘깁니다.
+func (r *Recorder) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	parent := bufferFrom(ctx)
+	buf := &buffer{parent: parent}
+	if err := fn(context.WithValue(ctx, bufferKey{}, buf)); err != nil {
+		return err
+	}
+	if parent != nil {
+		parent.add(buf.entries...)
+		return nil
+	}
+	for _, entry := range buf.entries {
+		if err := r.repo.Append(ctx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// History 엔티티의 감사 로그를 기록 시각 순으로 반환합니다.
+func (r *Recorder) History(ctx context.Context, entityType EntityType, entityID string) ([]*Entry, error) {
+	return r.repo.FindByEntity(ctx, entityType, entityID)
+}
+
+// StateAt at 시점에 유효했던 엔티티의 상태를 결정한 항목을 반환합니다.
+// 그 시점에 엔티티가 없었거나 이미 삭제되었으면 not found 에러를 반환합니다.
+func (r *Recorder) StateAt(ctx context.Context, entityType EntityType, entityID string, at time.Time) (*Entry, error) {
+	entries, err := r.repo.FindByEntity(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Entry
+	for _, entry := range entries {
+		if entry.RecordedAt.After(at) {
+			break
+		}
+		found = entry
+	}
+	if found == nil || found.Action == ActionDelete {
+		return nil, domain.NewError("audit", domain.ErrCodeNotFound,
+			fmt.Sprintf("%s %s did not exist at %s", entityType, entityID, at.Format(time.RFC3339Nano)))
+	}
+	return found, nil
+}
@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+)
+
+type account struct {
+	Name    string
+	Balance float64
+}
+
+// fixedClock 호출할 때마다 step만큼 증가하는 시각을 반환합니다.
+func fixedClock(start time.Time, step time.Duration) func() time.Time {
+	current := start.Add(-step)
+	return func() time.Time {
+		current = current.Add(step)
+		return current
+	}
+}
+
+func Test_Recorder_should_record_changes_against_previous_state(t *testing.T) {
+	// Given
+	ctx := WithActor(context.Background(), "user-1")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder := NewRecorder(NewMemoryRepository(), WithClock(fixedClock(start, time.Minute)))
+
+	// When
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionCreate, account{Name: "예금", Balance: 1000}))
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionUpdate, account{Name: "예금", Balance: 1500}))
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionDelete, nil))
+
+	// Then
+	history, err := recorder.History(ctx, EntityAsset, "a1")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, ActionCreate, history[0].Action)
+	assert.Equal(t, "user-1", history[0].Actor)
+	assert.Equal(t, []Change{{Field: "Balance", Before: 1000.0, After: 1500.0}}, history[1].Changes)
+	assert.Equal(t, ActionDelete, history[2].Action)
+	assert.Nil(t, history[2].State)
+	assert.Len(t, history[2].Changes, 2)
+}
+
+func Test_Recorder_should_skip_update_without_changes(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	recorder := NewRecorder(NewMemoryRepository())
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionCreate, account{Name: "예금"}))
+
+	// When
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionUpdate, account{Name: "예금"}))
+
+	// Then
+	history, err := recorder.History(ctx, EntityAsset, "a1")
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, DefaultActor, history[0].Actor)
+}
+
+func Test_Recorder_should_keep_recorded_at_increasing_for_same_clock(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder := NewRecorder(NewMemoryRepository(), WithClock(func() time.Time { return now }))
+
+	// When
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionCreate, account{Balance: 1}))
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionUpdate, account{Balance: 2}))
+
+	// Then
+	history, err := recorder.History(ctx, EntityAsset, "a1")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.True(t, history[1].RecordedAt.After(history[0].RecordedAt))
+}
+
+func Test_Recorder_should_reconstruct_state_at_timestamp(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder := NewRecorder(NewMemoryRepository(), WithClock(fixedClock(start, time.Hour)))
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionCreate, account{Balance: 1000}))
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionUpdate, account{Balance: 2000}))
+	require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionDelete, nil))
+
+	// When
+	atCreate, errCreate := recorder.StateAt(ctx, EntityAsset, "a1", start.Add(30*time.Minute))
+	atUpdate, errUpdate := recorder.StateAt(ctx, EntityAsset, "a1", start.Add(time.Hour))
+	_, errBefore := recorder.StateAt(ctx, EntityAsset, "a1", start.Add(-time.Second))
+	_, errDeleted := recorder.StateAt(ctx, EntityAsset, "a1", start.Add(3*time.Hour))
+
+	// Then
+	require.NoError(t, errCreate)
+	assert.Equal(t, 1000.0, atCreate.State["Balance"])
+	require.NoError(t, errUpdate)
+	assert.Equal(t, 2000.0, atUpdate.State["Balance"])
+
+	var domainErr domain.Error
+	require.True(t, errors.As(errBefore, &domainErr))
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code())
+	require.True(t, errors.As(errDeleted, &domainErr))
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code())
+}
+
+func Test_Recorder_Atomic_should_discard_entries_when_fn_fails(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	recorder := NewRecorder(repo)
+
+	// When
+	err := recorder.Atomic(ctx, func(ctx context.Context) error {
+		require.NoError(t, recorder.Record(ctx, EntityAsset, "a1", ActionCreate, account{Balance: 1}))
+		return errors.New("rollback")
+	})
+
+	// Then
+	assert.Error(t, err)
+	history, err := recorder.History(ctx, EntityAsset, "a1")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func Test_Recorder_Atomic_should_flush_entries_and_diff_against_pending_state(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	recorder := NewRecorder(NewMemoryRepository())
+
+	// When
+	err := recorder.Atomic(ctx, func(ctx context.Context) error {
+		if err := recorder.Record(ctx, EntityAsset, "a1", ActionCreate, account{Balance: 1}); err != nil {
+			return err
+		}
+		// 실패한 중첩 호출의 기록은 버려집니다
+		_ = recorder.Atomic(ctx, func(ctx context.Context) error {
+			_ = recorder.Record(ctx, EntityAsset, "a1", ActionUpdate, account{Balance: 99})
+			return errors.New("savepoint rollback")
+		})
+		return recorder.Atomic(ctx, func(ctx context.Context) error {
+			return recorder.Record(ctx, EntityAsset, "a1", ActionUpdate, account{Balance: 2})
+		})
+	})
+
+	// Then
+	require.NoError(t, err)
+	history, err := recorder.History(ctx, EntityAsset, "a1")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, []Change{{Field: "Balance", Before: 1.0, After: 2.0}}, history[1].Changes)
+}
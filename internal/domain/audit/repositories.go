@@ -0,0 +1,180 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// AssetRepository 자산 저장소의 쓰기를 감사 로그로 기록하는 데코레이터입니다.
+// 각 쓰기는 감사 기록과 함께 저장소 트랜잭션 안에서 실행됩니다.
+type AssetRepository struct {
+	asset.Repository
+	recorder *Recorder
+}
+
+// NewAssetRepository 감사 로그를 기록하는 자산 저장소를 생성합니다.
+func NewAssetRepository(repo asset.Repository, recorder *Recorder) *AssetRepository {
+	return &AssetRepository{Repository: repo, recorder: recorder}
+}
+
+// Save 자산을 저장하고 생성 기록을 남깁니다.
+func (r *AssetRepository) Save(ctx context.Context, a *asset.Asset) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.Repository.Save(ctx, a); err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityAsset, a.ID, ActionCreate, a)
+	})
+}
+
+// Update 자산을 업데이트하고 변경 기록을 남깁니다.
+func (r *AssetRepository) Update(ctx context.Context, a *asset.Asset) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.Repository.Update(ctx, a); err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityAsset, a.ID, ActionUpdate, a)
+	})
+}
+
+// Delete 자산을 삭제하고 삭제 기록을 남깁니다.
+func (r *AssetRepository) Delete(ctx context.Context, id string) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.Repository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityAsset, id, ActionDelete, nil)
+	})
+}
+
+// UpdateAmount 자산 금액을 업데이트하고 변경된 자산 상태를 기록합니다.
+func (r *AssetRepository) UpdateAmount(ctx context.Context, id string, amount asset.Money) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.Repository.UpdateAmount(ctx, id, amount); err != nil {
+			return err
+		}
+		updated, err := r.Repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityAsset, id, ActionUpdate, updated)
+	})
+}
+
+// WithTransaction 저장소 트랜잭션 안에서 fn을 실행하고, fn이 성공하면 감사 기록도 같은 트랜잭션으로 저장합니다.
+func (r *AssetRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.Repository.WithTransaction(ctx, func(ctx context.Context) error {
+		return r.recorder.Atomic(ctx, fn)
+	})
+}
+
+// TransactionRepository 거래 저장소의 쓰기를 감사 로그로 기록하는 데코레이터입니다.
+type TransactionRepository struct {
+	asset.TransactionRepository
+	recorder *Recorder
+}
+
+// NewTransactionRepository 감사 로그를 기록하는 거래 저장소를 생성합니다.
+func NewTransactionRepository(repo asset.TransactionRepository, recorder *Recorder) *TransactionRepository {
+	return &TransactionRepository{TransactionRepository: repo, recorder: recorder}
+}
+
+// Save 거래를 저장하고 생성 기록을 남깁니다.
+func (r *TransactionRepository) Save(ctx context.Context, tx *asset.Transaction) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.TransactionRepository.Save(ctx, tx); err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityTransaction, tx.ID, ActionCreate, tx)
+	})
+}
+
+// Update 거래를 업데이트하고 변경 기록을 남깁니다.
+func (r *TransactionRepository) Update(ctx context.Context, tx *asset.Transaction) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.TransactionRepository.Update(ctx, tx); err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityTransaction, tx.ID, ActionUpdate, tx)
+	})
+}
+
+// Delete 거래를 삭제하고 삭제 기록을 남깁니다.
+func (r *TransactionRepository) Delete(ctx context.Context, id string) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.TransactionRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityTransaction, id, ActionDelete, nil)
+	})
+}
+
+// WithTransaction 저장소 트랜잭션 안에서 fn을 실행하고, fn이 성공하면 감사 기록도 같은 트랜잭션으로 저장합니다.
+func (r *TransactionRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.TransactionRepository.WithTransaction(ctx, func(ctx context.Context) error {
+		return r.recorder.Atomic(ctx, fn)
+	})
+}
+
+// PortfolioRepository 포트폴리오 저장소의 쓰기를 감사 로그로 기록하는 데코레이터입니다.
+type PortfolioRepository struct {
+	asset.PortfolioRepository
+	recorder *Recorder
+}
+
+// NewPortfolioRepository 감사 로그를 기록하는 포트폴리오 저장소를 생성합니다.
+func NewPortfolioRepository(repo asset.PortfolioRepository, recorder *Recorder) *PortfolioRepository {
+	return &PortfolioRepository{PortfolioRepository: repo, recorder: recorder}
+}
+
+// Save 포트폴리오를 저장하고 생성 기록을 남깁니다.
+func (r *PortfolioRepository) Save(ctx context.Context, p *asset.Portfolio) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.PortfolioRepository.Save(ctx, p); err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityPortfolio, p.ID, ActionCreate, p)
+	})
+}
+
+// Update 포트폴리오를 업데이트하고 변경 기록을 남깁니다.
+func (r *PortfolioRepository) Update(ctx context.Context, p *asset.Portfolio) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.PortfolioRepository.Update(ctx, p); err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityPortfolio, p.ID, ActionUpdate, p)
+	})
+}
+
+// Delete 포트폴리오를 삭제하고 삭제 기록을 남깁니다.
+func (r *PortfolioRepository) Delete(ctx context.Context, id string) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.PortfolioRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityPortfolio, id, ActionDelete, nil)
+	})
+}
+
+// UpdateAssets 포트폴리오 자산 구성을 업데이트하고 변경된 포트폴리오 상태를 기록합니다.
+func (r *PortfolioRepository) UpdateAssets(ctx context.Context, id string, assets []asset.PortfolioAsset) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.PortfolioRepository.UpdateAssets(ctx, id, assets); err != nil {
+			return err
+		}
+		updated, err := r.PortfolioRepository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return r.recorder.Record(ctx, EntityPortfolio, id, ActionUpdate, updated)
+	})
+}
+
+// WithTransaction 저장소 트랜잭션 안에서 fn을 실행하고, fn이 성공하면 감사 기록도 같은 트랜잭션으로 저장합니다.
+func (r *PortfolioRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.PortfolioRepository.WithTransaction(ctx, func(ctx context.Context) error {
+		return r.recorder.Atomic(ctx, fn)
+	})
+}
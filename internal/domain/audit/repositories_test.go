@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+func Test_AssetRepository_should_record_amount_changes(t *testing.T) {
+	// Given
+	ctx := WithActor(context.Background(), "user-1")
+	recorder := NewRecorder(NewMemoryRepository())
+	repo := NewAssetRepository(asset.NewMemoryAssetRepository(), recorder)
+	a, err := asset.NewAsset("user-1", asset.Cash, "예금", 1000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, a))
+
+	// When
+	amount, err := asset.NewMoney(2500, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateAmount(ctx, a.ID, amount))
+
+	// Then
+	history, err := recorder.History(ctx, EntityAsset, a.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, ActionUpdate, history[1].Action)
+	assert.Contains(t, history[1].Changes, Change{Field: "Amount.Amount", Before: 1000.0, After: 2500.0})
+	assert.Equal(t, "user-1", history[1].Actor)
+}
+
+func Test_AssetRepository_should_discard_audit_entries_on_rollback(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	recorder := NewRecorder(NewMemoryRepository())
+	repo := NewAssetRepository(asset.NewMemoryAssetRepository(), recorder)
+	a, err := asset.NewAsset("user-1", asset.Cash, "예금", 1000, "KRW")
+	require.NoError(t, err)
+
+	// When
+	err = repo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := repo.Save(ctx, a); err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+
+	// Then
+	assert.Error(t, err)
+	_, err = repo.FindByID(ctx, a.ID)
+	assert.Error(t, err)
+	history, err := recorder.History(ctx, EntityAsset, a.ID)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func Test_PortfolioRepository_should_record_delete(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	recorder := NewRecorder(NewMemoryRepository())
+	repo := NewPortfolioRepository(asset.NewMemoryPortfolioRepository(), recorder)
+	portfolio := &asset.Portfolio{ID: "p1", UserID: "user-1"}
+	require.NoError(t, repo.Save(ctx, portfolio))
+
+	// When
+	require.NoError(t, repo.Delete(ctx, portfolio.ID))
+
+	// Then
+	history, err := recorder.History(ctx, EntityPortfolio, portfolio.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, ActionDelete, history[1].Action)
+}
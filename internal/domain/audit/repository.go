@@ -0,0 +1,13 @@
+package audit
+
+import "context"
+
+// Repository 감사 로그 저장소 인터페이스입니다.
+// 감사 로그는 추가만 가능하며 수정하거나 삭제하지 않습니다.
+type Repository interface {
+	// Append 감사 로그 항목을 추가합니다.
+	Append(ctx context.Context, entry *Entry) error
+
+	// FindByEntity 엔티티의 감사 로그를 기록 시각 순으로 조회합니다.
+	FindByEntity(ctx context.Context, entityType EntityType, entityID string) ([]*Entry, error)
+}
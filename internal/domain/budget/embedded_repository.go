@@ -0,0 +1,110 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	budgetBucket = "budgets"
+
+	indexUserID = "user_id"
+)
+
+// EmbeddedRepository 예산의 임베디드 키-값 저장소 구현체입니다.
+type EmbeddedRepository struct {
+	budgets *kv.Collection[*Budget]
+}
+
+// NewEmbeddedRepository 사용자 인덱스를 가진 임베디드 예산 저장소를 생성합니다.
+func NewEmbeddedRepository(db *kv.DB) (*EmbeddedRepository, error) {
+	budgets := kv.NewCollection[*Budget](db, budgetBucket)
+	if err := budgets.Index(indexUserID, func(b *Budget) []string { return []string{b.UserID} }); err != nil {
+		return nil, err
+	}
+	return &EmbeddedRepository{budgets: budgets}, nil
+}
+
+// storageError 도메인 에러는 그대로 두고, 저장소 에러는 내부 에러로 감쌉니다.
+func storageError(err error) error {
+	var domainErr domain.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewError("budget", domain.ErrCodeInternal, err.Error())
+}
+
+// Save 예산을 저장합니다.
+func (r *EmbeddedRepository) Save(ctx context.Context, budget *Budget) error {
+	return storageError(r.budgets.Update(ctx, func(tx *kv.Tx) error {
+		return r.budgets.Put(tx, budget.ID, budget)
+	}))
+}
+
+// FindByID ID로 예산을 조회합니다.
+func (r *EmbeddedRepository) FindByID(ctx context.Context, id string) (*Budget, error) {
+	var budget *Budget
+	err := r.budgets.View(ctx, func(tx *kv.Tx) error {
+		found, exists, err := r.budgets.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(fmt.Sprintf("budget with ID %s not found", id))
+		}
+		budget = found
+		return nil
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return budget, nil
+}
+
+// FindByUserID 사용자의 예산을 생성 순으로 조회합니다.
+func (r *EmbeddedRepository) FindByUserID(ctx context.Context, userID string) ([]*Budget, error) {
+	var budgets []*Budget
+	err := r.budgets.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.budgets.Lookup(tx, indexUserID, userID)
+		budgets = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortBudgets(budgets)
+	return budgets, nil
+}
+
+// FindAll 모든 예산을 생성 순으로 조회합니다.
+func (r *EmbeddedRepository) FindAll(ctx context.Context) ([]*Budget, error) {
+	var budgets []*Budget
+	err := r.budgets.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.budgets.All(tx)
+		budgets = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortBudgets(budgets)
+	return budgets, nil
+}
+
+// Delete 예산을 삭제합니다.
+func (r *EmbeddedRepository) Delete(ctx context.Context, id string) error {
+	return storageError(r.budgets.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.budgets.Exists(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(fmt.Sprintf("budget with ID %s not found", id))
+		}
+		return r.budgets.Delete(tx, id)
+	}))
+}
@@ -0,0 +1,42 @@
+package budget
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+func Test_EmbeddedRepository_should_keep_alert_history(t *testing.T) {
+	// Given
+	repo, err := NewEmbeddedRepository(kv.OpenMemory())
+	require.NoError(t, err)
+	ctx := context.Background()
+	b, err := NewBudget("user-1", "식비", Monthly, krw(100000), true, date(2024, 3, 1))
+	require.NoError(t, err)
+	b.Alerted["2024-03"] = 0.8
+	other, err := NewBudget("user-2", "교통", Yearly, krw(50000), false, date(2024, 1, 1))
+	require.NoError(t, err)
+
+	// When
+	require.NoError(t, repo.Save(ctx, b))
+	require.NoError(t, repo.Save(ctx, other))
+
+	// Then
+	found, err := repo.FindByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, 0.8, found[0].Alerted["2024-03"])
+	assert.True(t, found[0].Rollover)
+	assert.True(t, found[0].Start.Equal(b.Start))
+	all, err := repo.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	require.NoError(t, repo.Delete(ctx, b.ID))
+	_, err = repo.FindByID(ctx, b.ID)
+	assert.Error(t, err)
+}
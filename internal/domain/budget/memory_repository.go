@@ -0,0 +1,92 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryRepository 예산의 인메모리 저장소 구현체입니다.
+type MemoryRepository struct {
+	budgets map[string]*Budget
+	mutex   sync.RWMutex
+}
+
+// NewMemoryRepository 새로운 인메모리 예산 저장소를 생성합니다.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{budgets: make(map[string]*Budget)}
+}
+
+// Save 예산을 저장합니다.
+func (r *MemoryRepository) Save(_ context.Context, budget *Budget) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.budgets[budget.ID] = clone(budget)
+	return nil
+}
+
+// FindByID ID로 예산을 조회합니다.
+func (r *MemoryRepository) FindByID(_ context.Context, id string) (*Budget, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	budget, ok := r.budgets[id]
+	if !ok {
+		return nil, notFound(fmt.Sprintf("budget with ID %s not found", id))
+	}
+	return clone(budget), nil
+}
+
+// FindByUserID 사용자의 예산을 생성 순으로 조회합니다.
+func (r *MemoryRepository) FindByUserID(_ context.Context, userID string) ([]*Budget, error) {
+	return r.filter(func(b *Budget) bool { return b.UserID == userID }), nil
+}
+
+// FindAll 모든 예산을 생성 순으로 조회합니다.
+func (r *MemoryRepository) FindAll(_ context.Context) ([]*Budget, error) {
+	return r.filter(func(*Budget) bool { return true }), nil
+}
+
+// Delete 예산을 삭제합니다.
+func (r *MemoryRepository) Delete(_ context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.budgets[id]; !ok {
+		return notFound(fmt.Sprintf("budget with ID %s not found", id))
+	}
+	delete(r.budgets, id)
+	return nil
+}
+
+func (r *MemoryRepository) filter(match func(*Budget) bool) []*Budget {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]*Budget, 0)
+	for _, b := range r.budgets {
+		if match(b) {
+			result = append(result, clone(b))
+		}
+	}
+	sortBudgets(result)
+	return result
+}
+
+// clone 알림 기록을 공유하지 않도록 예산을 복사합니다.
+func clone(budget *Budget) *Budget {
+	c := *budget
+	c.Alerted = make(map[string]float64, len(budget.Alerted))
+	for k, v := range budget.Alerted {
+		c.Alerted[k] = v
+	}
+	return &c
+}
+
+func sortBudgets(budgets []*Budget) {
+	sort.SliceStable(budgets, func(i, j int) bool {
+		return budgets[i].CreatedAt.Before(budgets[j].CreatedAt)
+	})
+}
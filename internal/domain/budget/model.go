@@ -0,0 +1,137 @@
+// Package budget 분류별 월간/연간 예산과 실제 지출을 비교하고, 지출이 기준을 넘으면 알림을 발생시킵니다.
+// 이월을 켠 예산은 봉투 예산처럼 쓰고 남은 금액을 다음 기간으로 넘깁니다.
+package budget
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/google/uuid"
+)
+
+// Period 예산 기간입니다.
+type Period string
+
+const (
+	Monthly Period = "MONTHLY"
+	Yearly  Period = "YEARLY"
+)
+
+// Thresholds 알림을 보내는 지출 비율입니다. 작은 값부터 정렬되어 있습니다.
+var Thresholds = []float64{0.8, 1.0}
+
+// Budget 사용자의 분류별 예산입니다.
+// Category에 하위 분류가 있으면 하위 분류의 지출도 함께 계산합니다.
+type Budget struct {
+	ID       string
+	UserID   string
+	Category string
+	Period   Period
+	Amount   asset.Money
+	// Rollover 쓰고 남은 금액을 다음 기간 예산에 더합니다. 초과 지출은 넘기지 않습니다
+	Rollover bool
+	// Start 예산이 시작되는 기간의 시작 시각입니다. 이월은 이 기간부터 계산합니다
+	Start time.Time
+	// Alerted 기간별로 이미 알린 가장 높은 기준 비율입니다
+	Alerted   map[string]float64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewBudget 새로운 예산을 생성합니다. start가 속한 기간부터 적용합니다.
+func NewBudget(userID, category string, period Period, amount asset.Money, rollover bool, start time.Time) (*Budget, error) {
+	category = strings.TrimSpace(category)
+	if userID == "" {
+		return nil, invalid("user ID is required")
+	}
+	if category == "" {
+		return nil, invalid("category is required")
+	}
+	if period != Monthly && period != Yearly {
+		return nil, invalid(fmt.Sprintf("unsupported period %q", period))
+	}
+	if amount.IsZero() || amount.IsNegative() {
+		return nil, invalid("budget amount must be positive")
+	}
+	if amount.Currency == "" {
+		return nil, invalid("currency is required")
+	}
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	now := time.Now()
+	b := &Budget{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Category:  category,
+		Period:    period,
+		Amount:    amount,
+		Rollover:  rollover,
+		Alerted:   make(map[string]float64),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	b.Start, _ = b.PeriodRange(start)
+	return b, nil
+}
+
+// PeriodRange at이 속한 기간의 시작과 다음 기간의 시작을 반환합니다.
+func (b *Budget) PeriodRange(at time.Time) (time.Time, time.Time) {
+	if b.Period == Yearly {
+		start := time.Date(at.Year(), time.January, 1, 0, 0, 0, 0, at.Location())
+		return start, start.AddDate(1, 0, 0)
+	}
+	start := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, at.Location())
+	return start, start.AddDate(0, 1, 0)
+}
+
+// PeriodKey 기간을 구분하는 문자열입니다.
+func (b *Budget) PeriodKey(periodStart time.Time) string {
+	if b.Period == Yearly {
+		return periodStart.Format("2006")
+	}
+	return periodStart.Format("2006-01")
+}
+
+// Status 한 기간의 예산 대비 실제 지출입니다.
+type Status struct {
+	Budget      *Budget
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	// Carried 이전 기간에서 넘어온 금액입니다
+	Carried   float64
+	Available float64
+	Spent     float64
+	Remaining float64
+	// Ratio 사용 가능 금액 대비 지출 비율입니다
+	Ratio        float64
+	Transactions int
+}
+
+// Exceeded 지출이 사용 가능 금액을 넘었는지 확인합니다.
+func (s Status) Exceeded() bool {
+	return s.Spent > s.Available
+}
+
+// crossed 지출 비율이 넘은 가장 높은 알림 기준입니다. 넘은 기준이 없으면 0입니다.
+func (s Status) crossed() float64 {
+	var highest float64
+	for _, threshold := range Thresholds {
+		if s.Ratio >= threshold {
+			highest = threshold
+		}
+	}
+	return highest
+}
+
+func invalid(msg string) error {
+	return domain.NewError("budget", domain.ErrCodeInvalidArgument, msg)
+}
+
+func notFound(msg string) error {
+	return domain.NewError("budget", domain.ErrCodeNotFound, msg)
+}
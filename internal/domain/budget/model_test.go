@@ -0,0 +1,66 @@
+package budget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func krw(amount float64) asset.Money {
+	return asset.Money{Amount: amount, Currency: "KRW"}
+}
+
+func Test_NewBudget_should_start_at_period_start(t *testing.T) {
+	// When
+	monthly, err := NewBudget("user-1", " 식비 ", Monthly, krw(500000), false, date(2024, 3, 17))
+	require.NoError(t, err)
+	yearly, err := NewBudget("user-1", "여행", Yearly, krw(3000000), true, date(2024, 3, 17))
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, "식비", monthly.Category)
+	assert.True(t, monthly.Start.Equal(date(2024, 3, 1)))
+	assert.Equal(t, "2024-03", monthly.PeriodKey(monthly.Start))
+	assert.True(t, yearly.Start.Equal(date(2024, 1, 1)))
+	assert.Equal(t, "2024", yearly.PeriodKey(yearly.Start))
+	_, next := yearly.PeriodRange(date(2024, 12, 31))
+	assert.True(t, next.Equal(date(2025, 1, 1)))
+}
+
+func Test_NewBudget_should_reject_invalid_input(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		category string
+		period   Period
+		amount   asset.Money
+	}{
+		{name: "사용자 없음", category: "식비", period: Monthly, amount: krw(1000)},
+		{name: "분류 없음", userID: "user-1", category: " ", period: Monthly, amount: krw(1000)},
+		{name: "지원하지 않는 기간", userID: "user-1", category: "식비", period: "WEEKLY", amount: krw(1000)},
+		{name: "금액 0", userID: "user-1", category: "식비", period: Monthly, amount: krw(0)},
+		{name: "통화 없음", userID: "user-1", category: "식비", period: Monthly, amount: asset.Money{Amount: 1000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBudget(tt.userID, tt.category, tt.period, tt.amount, false, date(2024, 1, 1))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_Status_should_report_highest_crossed_threshold(t *testing.T) {
+	assert.Equal(t, 0.0, Status{Ratio: 0.79}.crossed())
+	assert.Equal(t, 0.8, Status{Ratio: 0.8}.crossed())
+	assert.Equal(t, 1.0, Status{Ratio: 1.5}.crossed())
+	assert.True(t, Status{Spent: 101, Available: 100}.Exceeded())
+	assert.False(t, Status{Spent: 100, Available: 100}.Exceeded())
+}
@@ -0,0 +1,190 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/google/uuid"
+)
+
+// AlertLevel 예산 알림의 심각도입니다. 모니터링 서비스의 알림 수준과 같은 값을 씁니다.
+type AlertLevel string
+
+const (
+	LevelWarning  AlertLevel = "WARNING"  // 80% 도달
+	LevelCritical AlertLevel = "CRITICAL" // 100% 도달
+)
+
+// alertSource 예산 알림의 출처입니다.
+const alertSource = "budget"
+
+// Alert 예산 알림입니다. 모니터링 서비스의 Alert와 같은 구조이므로 알림 처리자가 그대로 전달할 수 있습니다.
+type Alert struct {
+	ID        string
+	Level     AlertLevel
+	Source    string
+	Message   string
+	Timestamp time.Time
+	Metadata  map[string]string
+}
+
+// Monitor 예산의 지출 비율을 평가하여 80%, 100% 기준을 처음 넘을 때 alert.triggered 이벤트를 발행합니다.
+// 같은 기간에는 기준마다 한 번만 알리며, 한 번에 여러 기준을 넘으면 가장 높은 기준만 알립니다.
+// 거래 기록 이벤트를 구독하여 해당 사용자의 예산을 평가하고, 주기적으로 모든 예산을 평가합니다.
+type Monitor struct {
+	service *Service
+	repo    Repository
+	bus     event.Bus
+	now     func() time.Time
+	mutex   sync.Mutex
+}
+
+// MonitorOption 예산 모니터 설정 함수입니다.
+type MonitorOption func(*Monitor)
+
+// WithClock 현재 시각 함수를 지정합니다.
+func WithClock(now func() time.Time) MonitorOption {
+	return func(m *Monitor) {
+		m.now = now
+	}
+}
+
+// NewMonitor 새로운 예산 모니터를 생성합니다.
+func NewMonitor(service *Service, bus event.Bus, opts ...MonitorOption) *Monitor {
+	m := &Monitor{service: service, repo: service.repo, bus: bus, now: time.Now}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// HandlerName 이벤트 핸들러 이름입니다.
+func (m *Monitor) HandlerName() string {
+	return "budget-monitor"
+}
+
+// HandleEvent 거래가 기록되면 거래 사용자의 예산을 평가합니다.
+func (m *Monitor) HandleEvent(ctx context.Context, evt event.Event) error {
+	switch evt.EventType() {
+	case event.TypeTransactionRecorded, event.TypeRecurringPosted:
+	default:
+		return nil
+	}
+	userID := evt.Metadata()["userID"]
+	if userID == "" {
+		return nil
+	}
+	budgets, err := m.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = m.evaluate(ctx, budgets)
+	return err
+}
+
+// Evaluate 사용자의 예산을 평가하고 발행한 알림을 반환합니다.
+func (m *Monitor) Evaluate(ctx context.Context, userID string) ([]Alert, error) {
+	budgets, err := m.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return m.evaluate(ctx, budgets)
+}
+
+// Start interval마다 모든 예산을 평가합니다. 이벤트 없이 기록된 거래(가져오기 등)의 지출도 이 평가에서 알립니다.
+func (m *Monitor) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		budgets, err := m.repo.FindAll(ctx)
+		if err == nil {
+			_, err = m.evaluate(ctx, budgets)
+		}
+		if err != nil {
+			log.Printf("예산 평가 실패: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) evaluate(ctx context.Context, budgets []*Budget) ([]Alert, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := m.now()
+	var alerts []Alert
+	for _, b := range budgets {
+		status, err := m.service.Status(ctx, b, now)
+		if err != nil {
+			return alerts, err
+		}
+		key := b.PeriodKey(status.PeriodStart)
+		crossed := status.crossed()
+		if crossed <= b.Alerted[key] {
+			continue
+		}
+
+		if b.Alerted == nil {
+			b.Alerted = make(map[string]float64)
+		}
+		b.Alerted[key] = crossed
+		if err := m.repo.Save(ctx, b); err != nil {
+			return alerts, err
+		}
+		alert := newAlert(status, key, crossed, now)
+		alerts = append(alerts, alert)
+		m.publish(ctx, b, alert)
+	}
+	return alerts, nil
+}
+
+func (m *Monitor) publish(ctx context.Context, b *Budget, alert Alert) {
+	if m.bus == nil {
+		return
+	}
+	evt := event.NewEvent(event.TypeAlertTriggered, b.ID, "budget", alert, map[string]string{"userID": b.UserID}, 1)
+	if err := m.bus.Publish(ctx, evt); err != nil {
+		log.Printf("예산 알림 발행 실패(%s): %v", b.ID, err)
+	}
+}
+
+func newAlert(status Status, period string, threshold float64, now time.Time) Alert {
+	b := status.Budget
+	level := LevelWarning
+	message := fmt.Sprintf("%s 예산의 %.0f%%를 사용했습니다 (%.0f / %.0f %s)", b.Category, status.Ratio*100, status.Spent, status.Available, b.Amount.Currency)
+	if threshold >= 1 {
+		level = LevelCritical
+		message = fmt.Sprintf("%s 예산을 %.0f %s 초과했습니다", b.Category, -status.Remaining, b.Amount.Currency)
+		if status.Remaining == 0 {
+			message = fmt.Sprintf("%s 예산을 모두 사용했습니다", b.Category)
+		}
+	}
+	return Alert{
+		ID:        uuid.New().String(),
+		Level:     level,
+		Source:    alertSource,
+		Message:   message,
+		Timestamp: now,
+		Metadata: map[string]string{
+			"budgetID":  b.ID,
+			"userID":    b.UserID,
+			"category":  b.Category,
+			"period":    period,
+			"threshold": strconv.FormatFloat(threshold, 'f', -1, 64),
+			"spent":     strconv.FormatFloat(status.Spent, 'f', -1, 64),
+			"available": strconv.FormatFloat(status.Available, 'f', -1, 64),
+			"currency":  b.Amount.Currency,
+		},
+	}
+}
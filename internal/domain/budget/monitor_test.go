@@ -0,0 +1,129 @@
+package budget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+type recordingHandler struct {
+	events []event.Event
+}
+
+func (h *recordingHandler) HandleEvent(_ context.Context, evt event.Event) error {
+	if evt.EventType() == event.TypeAlertTriggered {
+		h.events = append(h.events, evt)
+	}
+	return nil
+}
+
+func (h *recordingHandler) HandlerName() string {
+	return "recording"
+}
+
+type monitorFixture struct {
+	*serviceFixture
+	now     time.Time
+	alerts  *recordingHandler
+	bus     *memory.EventBus
+	monitor *Monitor
+}
+
+func newMonitorFixture(t *testing.T) *monitorFixture {
+	t.Helper()
+	f := &monitorFixture{
+		serviceFixture: newServiceFixture(t),
+		now:            date(2024, 3, 20),
+		alerts:         &recordingHandler{},
+		bus:            memory.NewEventBus(),
+	}
+	f.monitor = NewMonitor(f.service, f.bus, WithClock(func() time.Time { return f.now }))
+	require.NoError(t, f.bus.Subscribe(f.alerts))
+	require.NoError(t, f.bus.Subscribe(f.monitor))
+	return f
+}
+
+func Test_Monitor_should_alert_each_threshold_once_per_period(t *testing.T) {
+	// Given
+	f := newMonitorFixture(t)
+	f.budget(t, "식비", Monthly, 100000, false, date(2024, 3, 1))
+
+	// When
+	f.record(t, f.account.ID, asset.Expense, krw(50000), "식비", date(2024, 3, 2))
+	first, err := f.monitor.Evaluate(f.ctx, "user-1")
+	require.NoError(t, err)
+	f.record(t, f.account.ID, asset.Expense, krw(35000), "식비", date(2024, 3, 3))
+	second, err := f.monitor.Evaluate(f.ctx, "user-1")
+	require.NoError(t, err)
+	again, err := f.monitor.Evaluate(f.ctx, "user-1")
+	require.NoError(t, err)
+	f.record(t, f.account.ID, asset.Expense, krw(20000), "식비", date(2024, 3, 4))
+	third, err := f.monitor.Evaluate(f.ctx, "user-1")
+	require.NoError(t, err)
+
+	// Then
+	assert.Empty(t, first)
+	require.Len(t, second, 1)
+	assert.Equal(t, LevelWarning, second[0].Level)
+	assert.Equal(t, "0.8", second[0].Metadata["threshold"])
+	assert.Empty(t, again)
+	require.Len(t, third, 1)
+	assert.Equal(t, LevelCritical, third[0].Level)
+	assert.Equal(t, "2024-03", third[0].Metadata["period"])
+	assert.Len(t, f.alerts.events, 2)
+}
+
+func Test_Monitor_should_alert_only_highest_threshold_and_again_next_period(t *testing.T) {
+	// Given
+	f := newMonitorFixture(t)
+	f.budget(t, "식비", Monthly, 100000, false, date(2024, 3, 1))
+	f.record(t, f.account.ID, asset.Expense, krw(120000), "식비", date(2024, 3, 2))
+
+	// When
+	march, err := f.monitor.Evaluate(f.ctx, "user-1")
+	require.NoError(t, err)
+	f.now = date(2024, 4, 10)
+	f.record(t, f.account.ID, asset.Expense, krw(90000), "식비", date(2024, 4, 2))
+	april, err := f.monitor.Evaluate(f.ctx, "user-1")
+	require.NoError(t, err)
+
+	// Then
+	require.Len(t, march, 1)
+	assert.Equal(t, LevelCritical, march[0].Level)
+	require.Len(t, april, 1)
+	assert.Equal(t, LevelWarning, april[0].Level)
+	assert.Equal(t, "2024-04", april[0].Metadata["period"])
+}
+
+func Test_Monitor_should_evaluate_on_transaction_recorded_event(t *testing.T) {
+	// Given
+	f := newMonitorFixture(t)
+	b := f.budget(t, "식비", Monthly, 100000, false, date(2024, 3, 1))
+	tx, err := asset.NewTransaction(f.account.ID, asset.Expense, krw(90000), "식비", "")
+	require.NoError(t, err)
+	tx.Date = date(2024, 3, 5)
+	require.NoError(t, f.account.ProcessTransaction(tx))
+	require.NoError(t, f.transactions.Save(f.ctx, tx))
+
+	// When
+	for _, evt := range f.account.GetUncommittedEvents() {
+		require.NoError(t, f.bus.Publish(f.ctx, evt))
+	}
+
+	// Then
+	require.Len(t, f.alerts.events, 1)
+	alertEvent := f.alerts.events[0]
+	assert.Equal(t, b.ID, alertEvent.AggregateID())
+	assert.Equal(t, "user-1", alertEvent.Metadata()["userID"])
+	alert, ok := alertEvent.Payload().(Alert)
+	require.True(t, ok)
+	assert.Equal(t, "budget", alert.Source)
+	assert.Equal(t, LevelWarning, alert.Level)
+}
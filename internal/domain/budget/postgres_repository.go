@@ -0,0 +1,72 @@
+package budget
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// PostgresRepository budgets 테이블을 사용하는 예산 저장소 구현체입니다.
+// 예산은 data 컬럼에 JSON으로 저장하고, 사용자 ID를 조회용 컬럼으로 함께 저장합니다.
+type PostgresRepository struct {
+	budgets *sqldb.Documents[*Budget]
+}
+
+// NewPostgresRepository 새로운 PostgreSQL 예산 저장소를 생성합니다.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{
+		budgets: sqldb.NewDocuments(db, "budgets", []string{"id"}, []string{"user_id"},
+			func(b *Budget) []interface{} { return []interface{}{b.ID, b.UserID} }),
+	}
+}
+
+// Save 예산을 저장합니다.
+func (r *PostgresRepository) Save(ctx context.Context, budget *Budget) error {
+	return storageError(r.budgets.Put(ctx, budget))
+}
+
+// FindByID ID로 예산을 조회합니다.
+func (r *PostgresRepository) FindByID(ctx context.Context, id string) (*Budget, error) {
+	budget, exists, err := r.budgets.Get(ctx, id)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if !exists {
+		return nil, notFound(fmt.Sprintf("budget with ID %s not found", id))
+	}
+	return budget, nil
+}
+
+// FindByUserID 사용자의 예산을 생성 순으로 조회합니다.
+func (r *PostgresRepository) FindByUserID(ctx context.Context, userID string) ([]*Budget, error) {
+	budgets, err := r.budgets.Find(ctx, "user_id = $1", userID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortBudgets(budgets)
+	return budgets, nil
+}
+
+// FindAll 모든 예산을 생성 순으로 조회합니다.
+func (r *PostgresRepository) FindAll(ctx context.Context) ([]*Budget, error) {
+	budgets, err := r.budgets.Find(ctx, "")
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortBudgets(budgets)
+	return budgets, nil
+}
+
+// Delete 예산을 삭제합니다.
+func (r *PostgresRepository) Delete(ctx context.Context, id string) error {
+	deleted, err := r.budgets.Delete(ctx, id)
+	if err != nil {
+		return storageError(err)
+	}
+	if !deleted {
+		return notFound(fmt.Sprintf("budget with ID %s not found", id))
+	}
+	return nil
+}
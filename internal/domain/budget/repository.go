@@ -0,0 +1,14 @@
+package budget
+
+import "context"
+
+// Repository 예산 저장소입니다.
+type Repository interface {
+	// Save 예산을 저장합니다. 같은 ID가 있으면 덮어씁니다.
+	Save(ctx context.Context, budget *Budget) error
+	FindByID(ctx context.Context, id string) (*Budget, error)
+	FindByUserID(ctx context.Context, userID string) ([]*Budget, error)
+	// FindAll 주기적인 알림 평가를 위해 모든 예산을 조회합니다.
+	FindAll(ctx context.Context) ([]*Budget, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,210 @@
+package budget
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/category"
+)
+
+// TaxonomySource 사용자의 분류 체계를 제공합니다. category.Engine이 구현합니다.
+type TaxonomySource interface {
+	Taxonomy(ctx context.Context, userID string) (*category.Taxonomy, error)
+}
+
+// Service 예산을 관리하고 실제 지출을 계산합니다.
+// 지출은 사용자 자산의 지출(Expense) 거래 중 예산과 통화가 같은 거래만 합산합니다.
+type Service struct {
+	repo         Repository
+	assets       asset.Repository
+	transactions asset.TransactionRepository
+	taxonomy     TaxonomySource
+}
+
+// ServiceOption 예산 서비스 설정 함수입니다.
+type ServiceOption func(*Service)
+
+// WithTaxonomy 예산 분류의 하위 분류 지출도 함께 계산합니다.
+func WithTaxonomy(taxonomy TaxonomySource) ServiceOption {
+	return func(s *Service) {
+		s.taxonomy = taxonomy
+	}
+}
+
+// NewService 새로운 예산 서비스를 생성합니다.
+func NewService(repo Repository, assets asset.Repository, transactions asset.TransactionRepository, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo, assets: assets, transactions: transactions}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Create 예산을 저장합니다.
+func (s *Service) Create(ctx context.Context, b *Budget) error {
+	return s.repo.Save(ctx, b)
+}
+
+// Get 사용자의 예산을 조회합니다.
+func (s *Service) Get(ctx context.Context, userID, id string) (*Budget, error) {
+	b, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b.UserID != userID {
+		return nil, notFound("budget with ID " + id + " not found")
+	}
+	return b, nil
+}
+
+// List 사용자의 예산을 조회합니다.
+func (s *Service) List(ctx context.Context, userID string) ([]*Budget, error) {
+	return s.repo.FindByUserID(ctx, userID)
+}
+
+// Update 예산 금액과 이월 여부를 바꿉니다. 금액이 바뀌면 지출 비율도 달라지므로 알림 기록을 초기화합니다.
+func (s *Service) Update(ctx context.Context, userID, id string, amount float64, rollover bool) (*Budget, error) {
+	b, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, invalid("budget amount must be positive")
+	}
+	b.Amount.Amount = amount
+	b.Rollover = rollover
+	b.Alerted = make(map[string]float64)
+	b.UpdatedAt = time.Now()
+	if err := s.repo.Save(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Delete 사용자의 예산을 삭제합니다.
+func (s *Service) Delete(ctx context.Context, userID, id string) error {
+	if _, err := s.Get(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Status at이 속한 기간의 예산 대비 실제 지출을 계산합니다.
+// 이월을 켠 예산은 시작 기간부터 기간마다 남은 금액을 다음 기간으로 넘깁니다.
+func (s *Service) Status(ctx context.Context, b *Budget, at time.Time) (Status, error) {
+	periodStart, periodEnd := b.PeriodRange(at.In(b.Start.Location()))
+	from := periodStart
+	if b.Rollover && b.Start.Before(periodStart) {
+		from = b.Start
+	}
+	spent, counts, err := s.spending(ctx, b, from, periodEnd)
+	if err != nil {
+		return Status{}, err
+	}
+
+	var carried float64
+	if b.Rollover {
+		for p := b.Start; p.Before(periodStart); _, p = b.PeriodRange(p) {
+			if unused := b.Amount.Amount + carried - spent[b.PeriodKey(p)]; unused > 0 {
+				carried = unused
+			} else {
+				carried = 0
+			}
+		}
+	}
+
+	key := b.PeriodKey(periodStart)
+	status := Status{
+		Budget:       b,
+		PeriodStart:  periodStart,
+		PeriodEnd:    periodEnd,
+		Carried:      carried,
+		Available:    b.Amount.Amount + carried,
+		Spent:        spent[key],
+		Transactions: counts[key],
+	}
+	status.Remaining = status.Available - status.Spent
+	if status.Available > 0 {
+		status.Ratio = status.Spent / status.Available
+	}
+	return status, nil
+}
+
+// Analyze 사용자의 모든 예산에 대해 at이 속한 기간의 예산 대비 실제 지출을 계산합니다.
+func (s *Service) Analyze(ctx context.Context, userID string, at time.Time) ([]Status, error) {
+	budgets, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(budgets))
+	for _, b := range budgets {
+		status, err := s.Status(ctx, b, at)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// spending from부터 to 이전까지 예산에 해당하는 지출을 기간별로 합산합니다.
+func (s *Service) spending(ctx context.Context, b *Budget, from, to time.Time) (map[string]float64, map[string]int, error) {
+	owned, err := s.assets.FindByUserID(ctx, b.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	assetIDs := make(map[string]bool, len(owned))
+	for _, a := range owned {
+		assetIDs[a.ID] = true
+	}
+	categories, err := s.categories(ctx, b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txs, err := s.transactions.FindByDateRange(ctx, from, to.Add(-time.Nanosecond))
+	if err != nil {
+		return nil, nil, err
+	}
+	spent := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, tx := range txs {
+		if tx.Type != asset.Expense || !assetIDs[tx.AssetID] || tx.Amount.Currency != b.Amount.Currency {
+			continue
+		}
+		if !categories[strings.ToLower(tx.Category)] {
+			continue
+		}
+		start, _ := b.PeriodRange(tx.Date.In(from.Location()))
+		key := b.PeriodKey(start)
+		spent[key] += tx.Amount.Amount
+		counts[key]++
+	}
+	return spent, counts, nil
+}
+
+// categories 예산에 포함되는 분류 이름(소문자)입니다. 분류 체계에 있으면 하위 분류를 모두 포함합니다.
+func (s *Service) categories(ctx context.Context, b *Budget) (map[string]bool, error) {
+	names := map[string]bool{strings.ToLower(b.Category): true}
+	if s.taxonomy == nil {
+		return names, nil
+	}
+	taxonomy, err := s.taxonomy.Taxonomy(ctx, b.UserID)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := taxonomy.FindByName(b.Category)
+	if !ok {
+		return names, nil
+	}
+	pending := []*category.Category{root}
+	for len(pending) > 0 {
+		c := pending[0]
+		pending = pending[1:]
+		names[strings.ToLower(c.Name)] = true
+		pending = append(pending, taxonomy.Children(c.ID)...)
+	}
+	return names, nil
+}
@@ -0,0 +1,150 @@
+package budget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/category"
+)
+
+type serviceFixture struct {
+	ctx          context.Context
+	assets       *asset.MemoryAssetRepository
+	transactions *asset.MemoryTransactionRepository
+	repo         *MemoryRepository
+	service      *Service
+	account      *asset.Asset
+}
+
+func newServiceFixture(t *testing.T, opts ...ServiceOption) *serviceFixture {
+	t.Helper()
+	f := &serviceFixture{
+		ctx:          context.Background(),
+		assets:       asset.NewMemoryAssetRepository(),
+		transactions: asset.NewMemoryTransactionRepository(),
+		repo:         NewMemoryRepository(),
+	}
+	f.service = NewService(f.repo, f.assets, f.transactions, opts...)
+
+	var err error
+	f.account, err = asset.NewAsset("user-1", asset.Cash, "입출금", 10000000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, f.account))
+	return f
+}
+
+func (f *serviceFixture) budget(t *testing.T, category string, period Period, amount float64, rollover bool, start time.Time) *Budget {
+	t.Helper()
+	b, err := NewBudget("user-1", category, period, krw(amount), rollover, start)
+	require.NoError(t, err)
+	require.NoError(t, f.service.Create(f.ctx, b))
+	return b
+}
+
+func (f *serviceFixture) record(t *testing.T, assetID string, txType asset.TransactionType, amount asset.Money, category string, at time.Time) {
+	t.Helper()
+	tx, err := asset.NewTransaction(assetID, txType, amount, category, "")
+	require.NoError(t, err)
+	tx.Date = at
+	require.NoError(t, f.transactions.Save(f.ctx, tx))
+}
+
+func Test_Service_should_sum_only_matching_expenses(t *testing.T) {
+	// Given
+	f := newServiceFixture(t)
+	other, err := asset.NewAsset("user-2", asset.Cash, "다른 사용자", 1000000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, other))
+	b := f.budget(t, "식비", Monthly, 500000, false, date(2024, 3, 1))
+
+	f.record(t, f.account.ID, asset.Expense, krw(120000), "식비", date(2024, 3, 5))
+	f.record(t, f.account.ID, asset.Expense, krw(80000), "식비", date(2024, 3, 31).Add(23*time.Hour))
+	f.record(t, f.account.ID, asset.Income, krw(50000), "식비", date(2024, 3, 6))
+	f.record(t, f.account.ID, asset.Expense, krw(30000), "교통", date(2024, 3, 7))
+	f.record(t, f.account.ID, asset.Expense, asset.Money{Amount: 10, Currency: "USD"}, "식비", date(2024, 3, 8))
+	f.record(t, f.account.ID, asset.Expense, krw(70000), "식비", date(2024, 4, 1))
+	f.record(t, other.ID, asset.Expense, krw(90000), "식비", date(2024, 3, 9))
+
+	// When
+	status, err := f.service.Status(f.ctx, b, date(2024, 3, 20))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 200000.0, status.Spent)
+	assert.Equal(t, 2, status.Transactions)
+	assert.Equal(t, 300000.0, status.Remaining)
+	assert.InDelta(t, 0.4, status.Ratio, 1e-9)
+	assert.True(t, status.PeriodEnd.Equal(date(2024, 4, 1)))
+}
+
+func Test_Service_should_roll_over_unused_amounts_but_not_overspending(t *testing.T) {
+	// Given
+	f := newServiceFixture(t)
+	b := f.budget(t, "식비", Monthly, 100000, true, date(2024, 1, 1))
+	f.record(t, f.account.ID, asset.Expense, krw(60000), "식비", date(2024, 1, 10))  // 40,000 이월
+	f.record(t, f.account.ID, asset.Expense, krw(150000), "식비", date(2024, 2, 10)) // 140,000 중 초과
+	f.record(t, f.account.ID, asset.Expense, krw(30000), "식비", date(2024, 3, 10))
+
+	// When
+	february, err := f.service.Status(f.ctx, b, date(2024, 2, 15))
+	require.NoError(t, err)
+	march, err := f.service.Status(f.ctx, b, date(2024, 3, 15))
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, 40000.0, february.Carried)
+	assert.Equal(t, 140000.0, february.Available)
+	assert.True(t, february.Exceeded())
+	assert.Equal(t, 0.0, march.Carried)
+	assert.Equal(t, 70000.0, march.Remaining)
+}
+
+func Test_Service_should_include_child_categories_from_taxonomy(t *testing.T) {
+	// Given
+	engine := category.NewEngine(category.NewMemoryRepository(), asset.NewMemoryTransactionRepository())
+	ctx := context.Background()
+	food, err := engine.CreateCategory(ctx, "user-1", "식비", "")
+	require.NoError(t, err)
+	_, err = engine.CreateCategory(ctx, "user-1", "외식", food.ID)
+	require.NoError(t, err)
+
+	f := newServiceFixture(t, WithTaxonomy(engine))
+	f.budget(t, "식비", Monthly, 300000, false, date(2024, 3, 1))
+	f.record(t, f.account.ID, asset.Expense, krw(50000), "식비", date(2024, 3, 2))
+	f.record(t, f.account.ID, asset.Expense, krw(70000), "외식", date(2024, 3, 3))
+
+	// When
+	statuses, err := f.service.Analyze(f.ctx, "user-1", date(2024, 3, 31))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, 120000.0, statuses[0].Spent)
+}
+
+func Test_Service_should_reset_alerts_and_hide_other_users_budgets(t *testing.T) {
+	// Given
+	f := newServiceFixture(t)
+	b := f.budget(t, "식비", Monthly, 100000, false, date(2024, 3, 1))
+	b.Alerted["2024-03"] = 1
+	require.NoError(t, f.repo.Save(f.ctx, b))
+
+	// When
+	updated, err := f.service.Update(f.ctx, "user-1", b.ID, 200000, true)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 200000.0, updated.Amount.Amount)
+	assert.True(t, updated.Rollover)
+	assert.Empty(t, updated.Alerted)
+	_, err = f.service.Get(f.ctx, "user-2", b.ID)
+	assert.Error(t, err)
+	assert.Error(t, f.service.Delete(f.ctx, "user-2", b.ID))
+	_, err = f.service.Update(f.ctx, "user-1", b.ID, 0, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	categoryBucket   = "categories"
+	ruleBucket       = "category_rules"
+	correctionBucket = "category_corrections"
+
+	indexUserID = "user_id"
+)
+
+// EmbeddedRepository 분류의 임베디드 키-값 저장소 구현체입니다.
+type EmbeddedRepository struct {
+	categories  *kv.Collection[*Category]
+	rules       *kv.Collection[*Rule]
+	corrections *kv.Collection[*Correction]
+}
+
+// NewEmbeddedRepository 사용자 인덱스를 가진 임베디드 분류 저장소를 생성합니다.
+func NewEmbeddedRepository(db *kv.DB) (*EmbeddedRepository, error) {
+	categories := kv.NewCollection[*Category](db, categoryBucket)
+	if err := categories.Index(indexUserID, func(c *Category) []string { return []string{c.UserID} }); err != nil {
+		return nil, err
+	}
+	rules := kv.NewCollection[*Rule](db, ruleBucket)
+	if err := rules.Index(indexUserID, func(r *Rule) []string { return []string{r.UserID} }); err != nil {
+		return nil, err
+	}
+	corrections := kv.NewCollection[*Correction](db, correctionBucket)
+	if err := corrections.Index(indexUserID, func(c *Correction) []string { return []string{c.UserID} }); err != nil {
+		return nil, err
+	}
+	return &EmbeddedRepository{categories: categories, rules: rules, corrections: corrections}, nil
+}
+
+// storageError 도메인 에러는 그대로 두고, 저장소 에러는 내부 에러로 감쌉니다.
+func storageError(err error) error {
+	var domainErr domain.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewError("category", domain.ErrCodeInternal, err.Error())
+}
+
+// SaveCategory 분류를 저장합니다.
+func (r *EmbeddedRepository) SaveCategory(ctx context.Context, category *Category) error {
+	return storageError(r.categories.Update(ctx, func(tx *kv.Tx) error {
+		return r.categories.Put(tx, category.ID, category)
+	}))
+}
+
+// FindCategories 사용자의 분류를 생성 순으로 조회합니다.
+func (r *EmbeddedRepository) FindCategories(ctx context.Context, userID string) ([]*Category, error) {
+	var categories []*Category
+	err := r.categories.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.categories.Lookup(tx, indexUserID, userID)
+		categories = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortCategories(categories)
+	return categories, nil
+}
+
+// DeleteCategory 분류를 삭제합니다.
+func (r *EmbeddedRepository) DeleteCategory(ctx context.Context, id string) error {
+	return storageError(r.categories.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.categories.Exists(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(fmt.Sprintf("category with ID %s not found", id))
+		}
+		return r.categories.Delete(tx, id)
+	}))
+}
+
+// SaveRule 규칙을 저장합니다.
+func (r *EmbeddedRepository) SaveRule(ctx context.Context, rule *Rule) error {
+	return storageError(r.rules.Update(ctx, func(tx *kv.Tx) error {
+		return r.rules.Put(tx, rule.ID, rule)
+	}))
+}
+
+// FindRule ID로 규칙을 조회합니다.
+func (r *EmbeddedRepository) FindRule(ctx context.Context, id string) (*Rule, error) {
+	var rule *Rule
+	err := r.rules.View(ctx, func(tx *kv.Tx) error {
+		found, exists, err := r.rules.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(fmt.Sprintf("rule with ID %s not found", id))
+		}
+		rule = found
+		return nil
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return rule, nil
+}
+
+// FindRules 사용자의 규칙을 평가 순서로 조회합니다.
+func (r *EmbeddedRepository) FindRules(ctx context.Context, userID string) ([]*Rule, error) {
+	var rules []*Rule
+	err := r.rules.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.rules.Lookup(tx, indexUserID, userID)
+		rules = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortRules(rules)
+	return rules, nil
+}
+
+// DeleteRule 규칙을 삭제합니다.
+func (r *EmbeddedRepository) DeleteRule(ctx context.Context, id string) error {
+	return storageError(r.rules.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.rules.Exists(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(fmt.Sprintf("rule with ID %s not found", id))
+		}
+		return r.rules.Delete(tx, id)
+	}))
+}
+
+// AppendCorrection 분류 수정 기록을 추가합니다.
+func (r *EmbeddedRepository) AppendCorrection(ctx context.Context, correction *Correction) error {
+	return storageError(r.corrections.Update(ctx, func(tx *kv.Tx) error {
+		return r.corrections.Put(tx, correction.ID, correction)
+	}))
+}
+
+// FindCorrections 사용자의 분류 수정 기록을 시간 순으로 조회합니다.
+func (r *EmbeddedRepository) FindCorrections(ctx context.Context, userID string) ([]*Correction, error) {
+	var corrections []*Correction
+	err := r.corrections.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.corrections.Lookup(tx, indexUserID, userID)
+		corrections = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sort.SliceStable(corrections, func(i, j int) bool {
+		return corrections[i].CorrectedAt.Before(corrections[j].CorrectedAt)
+	})
+	return corrections, nil
+}
@@ -0,0 +1,57 @@
+package category
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+func Test_EmbeddedRepository_should_persist_rules_in_priority_order(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "category.db")
+	db, err := kv.Open(path, nil)
+	require.NoError(t, err)
+	repo, err := NewEmbeddedRepository(db)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	late, err := NewRule("user-1", "나중", "c1", 20, Conditions{Counterparty: "마트"})
+	require.NoError(t, err)
+	early, err := NewRule("user-1", "먼저", "c1", 10, Conditions{Description: "(?i)coffee"})
+	require.NoError(t, err)
+	other, err := NewRule("user-2", "다른 사용자", "c2", 0, Conditions{Counterparty: "마트"})
+	require.NoError(t, err)
+
+	// When
+	require.NoError(t, repo.SaveCategory(ctx, &Category{ID: "c1", UserID: "user-1", Name: "식비", CreatedAt: time.Now()}))
+	for _, rule := range []*Rule{late, early, other} {
+		require.NoError(t, repo.SaveRule(ctx, rule))
+	}
+	require.NoError(t, db.Close())
+	db, err = kv.Open(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	repo, err = NewEmbeddedRepository(db)
+	require.NoError(t, err)
+
+	// Then
+	rules, err := repo.FindRules(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, early.ID, rules[0].ID)
+	assert.True(t, rules[0].Matches(Subject{Description: "COFFEE BEAN"}))
+	categories, err := repo.FindCategories(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, categories, 1)
+
+	require.NoError(t, repo.DeleteRule(ctx, early.ID))
+	_, err = repo.FindRule(ctx, early.ID)
+	assert.Error(t, err)
+	assert.Error(t, repo.DeleteCategory(ctx, "missing"))
+}
@@ -0,0 +1,423 @@
+package category
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultMinSupport 규칙을 제안하기 위해 필요한 최소 수정 건수입니다.
+	DefaultMinSupport = 2
+	// LearnedPriority 제안된 규칙의 우선순위입니다. 사용자가 직접 만든 규칙보다 나중에 평가되도록 큰 값을 씁니다.
+	LearnedPriority = 100
+)
+
+// Engine 분류 체계와 규칙으로 거래의 분류를 정합니다.
+type Engine struct {
+	repo         Repository
+	transactions asset.TransactionRepository
+	now          func() time.Time
+}
+
+// NewEngine 새로운 분류 엔진을 생성합니다.
+func NewEngine(repo Repository, transactions asset.TransactionRepository) *Engine {
+	return &Engine{repo: repo, transactions: transactions, now: time.Now}
+}
+
+// Categories 사용자의 분류를 생성 순으로 조회합니다.
+func (e *Engine) Categories(ctx context.Context, userID string) ([]*Category, error) {
+	return e.repo.FindCategories(ctx, userID)
+}
+
+// Taxonomy 사용자의 분류 체계를 조회합니다.
+func (e *Engine) Taxonomy(ctx context.Context, userID string) (*Taxonomy, error) {
+	categories, err := e.repo.FindCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaxonomy(categories), nil
+}
+
+// CreateCategory 분류 체계에 새 분류를 추가합니다.
+func (e *Engine) CreateCategory(ctx context.Context, userID, name, parentID string) (*Category, error) {
+	c, err := NewCategory(userID, name, parentID)
+	if err != nil {
+		return nil, err
+	}
+	taxonomy, err := e.Taxonomy(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := taxonomy.Validate(c); err != nil {
+		return nil, err
+	}
+	if err := e.repo.SaveCategory(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DeleteCategory 분류를 삭제합니다. 하위 분류나 규칙이 남아 있으면 삭제할 수 없습니다.
+func (e *Engine) DeleteCategory(ctx context.Context, userID, id string) error {
+	taxonomy, err := e.Taxonomy(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if _, ok := taxonomy.Get(id); !ok {
+		return notFound(fmt.Sprintf("category with ID %s not found", id))
+	}
+	if len(taxonomy.Children(id)) > 0 {
+		return invalidOperation("category has child categories")
+	}
+	rules, err := e.repo.FindRules(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.CategoryID == id {
+			return invalidOperation(fmt.Sprintf("category is used by rule %s", rule.ID))
+		}
+	}
+	return e.repo.DeleteCategory(ctx, id)
+}
+
+// Rules 사용자의 규칙을 평가 순서로 조회합니다.
+func (e *Engine) Rules(ctx context.Context, userID string) ([]*Rule, error) {
+	return e.repo.FindRules(ctx, userID)
+}
+
+// Rule 사용자의 규칙을 조회합니다.
+func (e *Engine) Rule(ctx context.Context, userID, id string) (*Rule, error) {
+	rule, err := e.repo.FindRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule.UserID != userID {
+		return nil, notFound(fmt.Sprintf("rule with ID %s not found", id))
+	}
+	return rule, nil
+}
+
+// SaveRule 규칙을 검증하고 저장합니다. 규칙의 분류는 사용자의 분류 체계에 있어야 합니다.
+func (e *Engine) SaveRule(ctx context.Context, rule *Rule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	taxonomy, err := e.Taxonomy(ctx, rule.UserID)
+	if err != nil {
+		return err
+	}
+	if _, ok := taxonomy.Get(rule.CategoryID); !ok {
+		return invalid(fmt.Sprintf("category %s not found", rule.CategoryID))
+	}
+	rule.UpdatedAt = e.now()
+	return e.repo.SaveRule(ctx, rule)
+}
+
+// DeleteRule 사용자의 규칙을 삭제합니다.
+func (e *Engine) DeleteRule(ctx context.Context, userID, id string) error {
+	if _, err := e.Rule(ctx, userID, id); err != nil {
+		return err
+	}
+	return e.repo.DeleteRule(ctx, id)
+}
+
+// Categorize 우선순위 순서로 규칙을 평가하여 처음 만족한 규칙의 분류를 반환합니다.
+// 만족하는 규칙이 없으면 nil을 반환합니다.
+func (e *Engine) Categorize(ctx context.Context, userID string, s Subject) (*Category, *Rule, error) {
+	taxonomy, err := e.Taxonomy(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules, err := e.repo.FindRules(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, rule := categorize(taxonomy, rules, s)
+	return c, rule, nil
+}
+
+func categorize(taxonomy *Taxonomy, rules []*Rule, s Subject) (*Category, *Rule) {
+	for _, rule := range rules {
+		if !rule.Matches(s) {
+			continue
+		}
+		// 분류가 삭제된 규칙은 건너뜁니다
+		if c, ok := taxonomy.Get(rule.CategoryID); ok {
+			return c, rule
+		}
+	}
+	return nil, nil
+}
+
+// Apply 분류가 없는 거래에 규칙으로 정한 분류 이름을 지정합니다.
+// 거래 생성과 가져오기에서 저장 전에 호출합니다.
+func (e *Engine) Apply(ctx context.Context, userID string, tx *asset.Transaction) error {
+	if tx.Category != "" {
+		return nil
+	}
+	c, _, err := e.Categorize(ctx, userID, SubjectOf(tx))
+	if err != nil {
+		return err
+	}
+	if c != nil {
+		tx.Category = c.Name
+	}
+	return nil
+}
+
+// Change 일괄 재분류로 바뀌는 거래의 분류입니다.
+type Change struct {
+	TransactionID string
+	From          string
+	To            string
+	RuleID        string
+}
+
+// Recategorize 거래에 규칙을 다시 적용합니다.
+// overwrite가 false이면 분류 체계에 없는 분류를 가진 거래만 바꾸고, dryRun이면 저장하지 않고 바뀔 내용만 반환합니다.
+func (e *Engine) Recategorize(ctx context.Context, userID string, txs []*asset.Transaction, overwrite, dryRun bool) ([]Change, error) {
+	taxonomy, err := e.Taxonomy(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := e.repo.FindRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	var updates []*asset.Transaction
+	for _, tx := range txs {
+		if _, categorized := taxonomy.FindByName(tx.Category); categorized && !overwrite {
+			continue
+		}
+		c, rule := categorize(taxonomy, rules, SubjectOf(tx))
+		if c == nil || c.Name == tx.Category {
+			continue
+		}
+		changes = append(changes, Change{TransactionID: tx.ID, From: tx.Category, To: c.Name, RuleID: rule.ID})
+		updated := *tx
+		updated.Category = c.Name
+		updates = append(updates, &updated)
+	}
+	if dryRun || len(updates) == 0 {
+		return changes, nil
+	}
+
+	err = e.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, tx := range updates {
+			if err := e.transactions.Update(ctx, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// RecordCorrection 거래의 분류를 사용자가 고친 분류로 바꾸고 수정 내역을 남깁니다.
+// 수정 내역은 SuggestRules에서 새 규칙을 제안하는 데 사용합니다.
+func (e *Engine) RecordCorrection(ctx context.Context, userID string, tx *asset.Transaction, categoryName string) (*Category, error) {
+	taxonomy, err := e.Taxonomy(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := taxonomy.FindByName(categoryName)
+	if !ok {
+		return nil, invalid(fmt.Sprintf("category %s not found", categoryName))
+	}
+
+	tx.Category = c.Name
+	if err := e.transactions.Update(ctx, tx); err != nil {
+		return nil, err
+	}
+	correction := &Correction{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		TransactionID: tx.ID,
+		Subject:       SubjectOf(tx),
+		CategoryID:    c.ID,
+		CorrectedAt:   e.now(),
+	}
+	if err := e.repo.AppendCorrection(ctx, correction); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SuggestRules 분류 수정 내역에서 새 규칙을 제안합니다.
+// 한 분류로 minSupport건 이상 고친 거래에 공통으로 나오고 다른 분류로 고친 거래에는 나오지 않는 단어를
+// 거래처 조건으로 하는 규칙을 만듭니다. 이미 기존 규칙이 같은 분류로 정하는 거래는 제안하지 않습니다.
+// 제안된 규칙은 저장되지 않으며 사용자가 채택하면 SaveRule로 저장합니다.
+func (e *Engine) SuggestRules(ctx context.Context, userID string, minSupport int) ([]*Rule, error) {
+	if minSupport < 1 {
+		minSupport = DefaultMinSupport
+	}
+	taxonomy, err := e.Taxonomy(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := e.repo.FindRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	corrections, err := e.repo.FindCorrections(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 같은 거래를 여러 번 고쳤으면 마지막 수정만 사용하고, 이미 규칙이 맞히는 수정은 제외합니다
+	latest := make(map[string]*Correction)
+	for _, c := range corrections {
+		latest[c.TransactionID] = c
+	}
+	pending := make(map[string][]*Correction)
+	owners := make(map[string]map[string]bool)
+	for _, c := range latest {
+		if _, ok := taxonomy.Get(c.CategoryID); !ok {
+			continue
+		}
+		for _, token := range tokens(c.Subject) {
+			if owners[token] == nil {
+				owners[token] = make(map[string]bool)
+			}
+			owners[token][c.CategoryID] = true
+		}
+		if matched, _ := categorize(taxonomy, rules, c.Subject); matched != nil && matched.ID == c.CategoryID {
+			continue
+		}
+		pending[c.CategoryID] = append(pending[c.CategoryID], c)
+	}
+
+	suggestions := make([]*Rule, 0)
+	for categoryID, group := range pending {
+		for _, token := range coveringTokens(group, owners, minSupport) {
+			now := e.now()
+			conditions := Conditions{Counterparty: token, Type: commonType(group, token)}
+			suggestions = append(suggestions, &Rule{
+				ID:         uuid.New().String(),
+				UserID:     userID,
+				Name:       fmt.Sprintf("%s → %s", token, taxonomy.byID[categoryID].Name),
+				CategoryID: categoryID,
+				Priority:   LearnedPriority,
+				Conditions: conditions,
+				Enabled:    true,
+				Source:     SourceLearned,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			})
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].Name < suggestions[j].Name })
+	return suggestions, nil
+}
+
+// coveringTokens 분류의 수정 내역을 가장 많이 덮는 단어부터 고릅니다.
+// 다른 분류에도 나오는 단어와, 이미 고른 단어가 덮은 수정을 빼면 minSupport건에 못 미치는 단어는 버립니다.
+func coveringTokens(group []*Correction, owners map[string]map[string]bool, minSupport int) []string {
+	support := make(map[string][]int)
+	for i, c := range group {
+		for _, token := range tokens(c.Subject) {
+			if len(owners[token]) == 1 {
+				support[token] = append(support[token], i)
+			}
+		}
+	}
+	candidates := make([]string, 0, len(support))
+	for token, covered := range support {
+		if len(covered) >= minSupport {
+			candidates = append(candidates, token)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(support[candidates[i]]) != len(support[candidates[j]]) {
+			return len(support[candidates[i]]) > len(support[candidates[j]])
+		}
+		return candidates[i] < candidates[j]
+	})
+
+	var chosen []string
+	done := make(map[int]bool)
+	for _, token := range candidates {
+		fresh := 0
+		for _, i := range support[token] {
+			if !done[i] {
+				fresh++
+			}
+		}
+		if fresh < minSupport {
+			continue
+		}
+		for _, i := range support[token] {
+			done[i] = true
+		}
+		chosen = append(chosen, token)
+	}
+	return chosen
+}
+
+// commonType 단어가 나오는 수정 내역의 거래 유형이 모두 같으면 그 유형을 반환합니다.
+func commonType(group []*Correction, token string) asset.TransactionType {
+	var common asset.TransactionType
+	for _, c := range group {
+		if !containsToken(tokens(c.Subject), token) {
+			continue
+		}
+		if common != "" && common != c.Subject.Type {
+			return ""
+		}
+		common = c.Subject.Type
+	}
+	return common
+}
+
+// tokens 설명과 거래처를 소문자 단어로 나눕니다. 두 글자 미만의 단어와 숫자는 제외합니다.
+func tokens(s Subject) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s.Description+" "+s.Counterparty), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]bool, len(fields))
+	result := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if len([]rune(f)) < 2 || isNumber(f) || seen[f] {
+			continue
+		}
+		seen[f] = true
+		result = append(result, f)
+	}
+	return result
+}
+
+func containsToken(tokens []string, token string) bool {
+	for _, t := range tokens {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func invalidOperation(msg string) error {
+	return domain.NewError("category", domain.ErrCodeInvalidOperation, msg)
+}
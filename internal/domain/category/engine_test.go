@@ -0,0 +1,173 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+type engineFixture struct {
+	ctx          context.Context
+	engine       *Engine
+	transactions asset.TransactionRepository
+	food         *Category
+	cafe         *Category
+	transport    *Category
+}
+
+func newEngineFixture(t *testing.T) *engineFixture {
+	t.Helper()
+	f := &engineFixture{ctx: context.Background(), transactions: asset.NewMemoryTransactionRepository()}
+	f.engine = NewEngine(NewMemoryRepository(), f.transactions)
+
+	var err error
+	f.food, err = f.engine.CreateCategory(f.ctx, "user-1", "식비", "")
+	require.NoError(t, err)
+	f.cafe, err = f.engine.CreateCategory(f.ctx, "user-1", "카페", f.food.ID)
+	require.NoError(t, err)
+	f.transport, err = f.engine.CreateCategory(f.ctx, "user-1", "교통", "")
+	require.NoError(t, err)
+	return f
+}
+
+func (f *engineFixture) saveRule(t *testing.T, name string, c *Category, priority int, conditions Conditions) *Rule {
+	t.Helper()
+	rule, err := NewRule("user-1", name, c.ID, priority, conditions)
+	require.NoError(t, err)
+	require.NoError(t, f.engine.SaveRule(f.ctx, rule))
+	return rule
+}
+
+func (f *engineFixture) saveTransaction(t *testing.T, category, description string, value float64) *asset.Transaction {
+	t.Helper()
+	money, err := asset.NewMoney(value, "KRW")
+	require.NoError(t, err)
+	tx, err := asset.NewTransaction("a1", asset.Expense, money, category, description)
+	require.NoError(t, err)
+	require.NoError(t, f.transactions.Save(f.ctx, tx))
+	return tx
+}
+
+func Test_Engine_should_apply_first_matching_rule_by_priority(t *testing.T) {
+	// Given
+	f := newEngineFixture(t)
+	f.saveRule(t, "식비 전체", f.food, 20, Conditions{Description: "(?i)starbucks|맥도날드"})
+	cafeRule := f.saveRule(t, "카페", f.cafe, 10, Conditions{Description: "(?i)starbucks"})
+
+	// When
+	tx := &asset.Transaction{Description: "STARBUCKS 역삼", Amount: asset.Money{Amount: 5600, Currency: "KRW"}, Type: asset.Expense}
+	require.NoError(t, f.engine.Apply(f.ctx, "user-1", tx))
+	c, rule, err := f.engine.Categorize(f.ctx, "user-1", Subject{Description: "맥도날드"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "카페", tx.Category)
+	assert.Equal(t, f.food.ID, c.ID)
+	assert.NotEqual(t, cafeRule.ID, rule.ID)
+
+	manual := &asset.Transaction{Description: "STARBUCKS", Category: "접대비"}
+	require.NoError(t, f.engine.Apply(f.ctx, "user-1", manual))
+	assert.Equal(t, "접대비", manual.Category)
+	other := &asset.Transaction{Description: "STARBUCKS"}
+	require.NoError(t, f.engine.Apply(f.ctx, "user-2", other))
+	assert.Empty(t, other.Category)
+}
+
+func Test_Engine_should_reject_deleting_categories_in_use(t *testing.T) {
+	// Given
+	f := newEngineFixture(t)
+	rule := f.saveRule(t, "카페", f.cafe, 10, Conditions{Counterparty: "커피"})
+
+	// When
+	parentErr := f.engine.DeleteCategory(f.ctx, "user-1", f.food.ID)
+	ruleErr := f.engine.DeleteCategory(f.ctx, "user-1", f.cafe.ID)
+
+	// Then
+	var domainErr domain.Error
+	require.True(t, errors.As(parentErr, &domainErr))
+	assert.Equal(t, domain.ErrCodeInvalidOperation, domainErr.Code())
+	assert.Error(t, ruleErr)
+
+	require.NoError(t, f.engine.DeleteRule(f.ctx, "user-1", rule.ID))
+	require.NoError(t, f.engine.DeleteCategory(f.ctx, "user-1", f.cafe.ID))
+	require.NoError(t, f.engine.DeleteCategory(f.ctx, "user-1", f.food.ID))
+	assert.Error(t, f.engine.DeleteRule(f.ctx, "user-2", rule.ID))
+}
+
+func Test_Engine_should_recategorize_in_bulk(t *testing.T) {
+	// Given
+	f := newEngineFixture(t)
+	f.saveRule(t, "택시", f.transport, 0, Conditions{Counterparty: "택시"})
+	imported := f.saveTransaction(t, "가져오기", "카카오 택시", 12000)
+	manual := f.saveTransaction(t, "식비", "택시 안 간식", 3000)
+	f.saveTransaction(t, "", "편의점", 2000)
+	txs, err := f.transactions.FindByAssetID(f.ctx, "a1")
+	require.NoError(t, err)
+
+	// When
+	preview, err := f.engine.Recategorize(f.ctx, "user-1", txs, false, true)
+	require.NoError(t, err)
+	changes, err := f.engine.Recategorize(f.ctx, "user-1", txs, true, false)
+	require.NoError(t, err)
+
+	// Then
+	require.Len(t, preview, 1)
+	assert.Equal(t, Change{TransactionID: imported.ID, From: "가져오기", To: "교통", RuleID: preview[0].RuleID}, preview[0])
+	assert.Len(t, changes, 2)
+	saved, err := f.transactions.FindByID(f.ctx, manual.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "교통", saved.Category)
+}
+
+func Test_Engine_should_suggest_rules_from_corrections(t *testing.T) {
+	// Given
+	f := newEngineFixture(t)
+	for _, description := range []string{"STARBUCKS 강남", "Starbucks 역삼", "스타벅스 강남"} {
+		tx := f.saveTransaction(t, "", description, 5000)
+		_, err := f.engine.RecordCorrection(f.ctx, "user-1", tx, "카페")
+		require.NoError(t, err)
+	}
+	tx := f.saveTransaction(t, "", "강남 주차장", 3000)
+	_, err := f.engine.RecordCorrection(f.ctx, "user-1", tx, "교통")
+	require.NoError(t, err)
+
+	// When
+	suggestions, err := f.engine.SuggestRules(f.ctx, "user-1", 2)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	suggestion := suggestions[0]
+	assert.Equal(t, f.cafe.ID, suggestion.CategoryID)
+	assert.Equal(t, Conditions{Counterparty: "starbucks", Type: asset.Expense}, suggestion.Conditions)
+	assert.Equal(t, SourceLearned, suggestion.Source)
+	saved, err := f.transactions.FindByID(f.ctx, tx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "교통", saved.Category)
+
+	require.NoError(t, f.engine.SaveRule(f.ctx, suggestion))
+	suggestions, err = f.engine.SuggestRules(f.ctx, "user-1", 2)
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
+
+func Test_Engine_should_reject_corrections_to_unknown_categories(t *testing.T) {
+	// Given
+	f := newEngineFixture(t)
+	tx := f.saveTransaction(t, "", "편의점", 2000)
+
+	// When
+	_, err := f.engine.RecordCorrection(f.ctx, "user-1", tx, "없는 분류")
+
+	// Then
+	assert.Error(t, err)
+	suggestions, err := f.engine.SuggestRules(f.ctx, "user-1", 1)
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
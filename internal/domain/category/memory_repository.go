@@ -0,0 +1,160 @@
+package category
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryRepository 분류의 인메모리 저장소 구현체입니다.
+type MemoryRepository struct {
+	categories  map[string]*Category
+	rules       map[string]*Rule
+	corrections []*Correction
+	mutex       sync.RWMutex
+}
+
+// NewMemoryRepository 새로운 인메모리 분류 저장소를 생성합니다.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		categories: make(map[string]*Category),
+		rules:      make(map[string]*Rule),
+	}
+}
+
+// SaveCategory 분류를 저장합니다.
+func (r *MemoryRepository) SaveCategory(_ context.Context, category *Category) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	clone := *category
+	r.categories[category.ID] = &clone
+	return nil
+}
+
+// FindCategories 사용자의 분류를 생성 순으로 조회합니다.
+func (r *MemoryRepository) FindCategories(_ context.Context, userID string) ([]*Category, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	categories := make([]*Category, 0)
+	for _, c := range r.categories {
+		if c.UserID == userID {
+			clone := *c
+			categories = append(categories, &clone)
+		}
+	}
+	sortCategories(categories)
+	return categories, nil
+}
+
+// DeleteCategory 분류를 삭제합니다.
+func (r *MemoryRepository) DeleteCategory(_ context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.categories[id]; !ok {
+		return notFound(fmt.Sprintf("category with ID %s not found", id))
+	}
+	delete(r.categories, id)
+	return nil
+}
+
+// SaveRule 규칙을 저장합니다.
+func (r *MemoryRepository) SaveRule(_ context.Context, rule *Rule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.rules[rule.ID] = cloneRule(rule)
+	return nil
+}
+
+// FindRule ID로 규칙을 조회합니다.
+func (r *MemoryRepository) FindRule(_ context.Context, id string) (*Rule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, notFound(fmt.Sprintf("rule with ID %s not found", id))
+	}
+	return cloneRule(rule), nil
+}
+
+// FindRules 사용자의 규칙을 평가 순서로 조회합니다.
+func (r *MemoryRepository) FindRules(_ context.Context, userID string) ([]*Rule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rules := make([]*Rule, 0)
+	for _, rule := range r.rules {
+		if rule.UserID == userID {
+			rules = append(rules, cloneRule(rule))
+		}
+	}
+	sortRules(rules)
+	return rules, nil
+}
+
+// DeleteRule 규칙을 삭제합니다.
+func (r *MemoryRepository) DeleteRule(_ context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.rules[id]; !ok {
+		return notFound(fmt.Sprintf("rule with ID %s not found", id))
+	}
+	delete(r.rules, id)
+	return nil
+}
+
+// AppendCorrection 분류 수정 기록을 추가합니다.
+func (r *MemoryRepository) AppendCorrection(_ context.Context, correction *Correction) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	clone := *correction
+	r.corrections = append(r.corrections, &clone)
+	return nil
+}
+
+// FindCorrections 사용자의 분류 수정 기록을 시간 순으로 조회합니다.
+func (r *MemoryRepository) FindCorrections(_ context.Context, userID string) ([]*Correction, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	corrections := make([]*Correction, 0)
+	for _, c := range r.corrections {
+		if c.UserID == userID {
+			clone := *c
+			corrections = append(corrections, &clone)
+		}
+	}
+	return corrections, nil
+}
+
+// cloneRule 준비된 조건을 공유하지 않도록 규칙을 복사합니다.
+func cloneRule(rule *Rule) *Rule {
+	clone := &Rule{
+		ID:         rule.ID,
+		UserID:     rule.UserID,
+		Name:       rule.Name,
+		CategoryID: rule.CategoryID,
+		Priority:   rule.Priority,
+		Conditions: rule.Conditions,
+		Enabled:    rule.Enabled,
+		Source:     rule.Source,
+		CreatedAt:  rule.CreatedAt,
+		UpdatedAt:  rule.UpdatedAt,
+	}
+	clone.Conditions.Weekdays = append([]time.Weekday(nil), rule.Conditions.Weekdays...)
+	return clone
+}
+
+func sortCategories(categories []*Category) {
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].CreatedAt.Before(categories[j].CreatedAt)
+	})
+}
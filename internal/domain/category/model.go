@@ -0,0 +1,347 @@
+// Package category 사용자 정의 규칙으로 거래의 분류를 자동으로 정합니다.
+// 분류는 상위/하위 관계를 가진 분류 체계로 관리하며, 사용자가 고친 분류에서 새 규칙을 제안합니다.
+package category
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/google/uuid"
+)
+
+// Category 분류 체계의 분류입니다. ParentID가 비어 있으면 최상위 분류입니다.
+// 거래의 Category에는 분류 이름이 기록되므로 이름은 사용자별로 유일합니다.
+type Category struct {
+	ID        string
+	UserID    string
+	Name      string
+	ParentID  string
+	CreatedAt time.Time
+}
+
+// NewCategory 새로운 분류를 생성합니다.
+func NewCategory(userID, name, parentID string) (*Category, error) {
+	name = strings.TrimSpace(name)
+	if userID == "" {
+		return nil, invalid("user ID is required")
+	}
+	if name == "" {
+		return nil, invalid("category name is required")
+	}
+	return &Category{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		ParentID:  parentID,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Taxonomy 사용자의 분류 체계입니다.
+type Taxonomy struct {
+	byID   map[string]*Category
+	byName map[string]*Category
+}
+
+// NewTaxonomy 분류 목록으로 분류 체계를 만듭니다.
+func NewTaxonomy(categories []*Category) *Taxonomy {
+	t := &Taxonomy{
+		byID:   make(map[string]*Category, len(categories)),
+		byName: make(map[string]*Category, len(categories)),
+	}
+	for _, c := range categories {
+		t.byID[c.ID] = c
+		t.byName[strings.ToLower(c.Name)] = c
+	}
+	return t
+}
+
+// Get ID로 분류를 조회합니다.
+func (t *Taxonomy) Get(id string) (*Category, bool) {
+	c, ok := t.byID[id]
+	return c, ok
+}
+
+// FindByName 이름으로 분류를 조회합니다. 대소문자는 구분하지 않습니다.
+func (t *Taxonomy) FindByName(name string) (*Category, bool) {
+	c, ok := t.byName[strings.ToLower(strings.TrimSpace(name))]
+	return c, ok
+}
+
+// Path 최상위 분류부터 해당 분류까지의 이름 목록입니다.
+func (t *Taxonomy) Path(id string) []string {
+	var path []string
+	for c, ok := t.byID[id]; ok && len(path) <= len(t.byID); c, ok = t.byID[c.ParentID] {
+		path = append([]string{c.Name}, path...)
+	}
+	return path
+}
+
+// Children 분류의 바로 아래 분류를 이름 순으로 반환합니다. id가 비어 있으면 최상위 분류입니다.
+func (t *Taxonomy) Children(id string) []*Category {
+	children := make([]*Category, 0)
+	for _, c := range t.byID {
+		if c.ParentID == id {
+			children = append(children, c)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	return children
+}
+
+// IsDescendant 분류가 ancestor이거나 그 하위 분류인지 확인합니다.
+func (t *Taxonomy) IsDescendant(id, ancestor string) bool {
+	steps := 0
+	for c, ok := t.byID[id]; ok && steps <= len(t.byID); c, ok = t.byID[c.ParentID] {
+		if c.ID == ancestor {
+			return true
+		}
+		steps++
+	}
+	return false
+}
+
+// Validate 새 분류를 체계에 추가할 수 있는지 확인합니다.
+func (t *Taxonomy) Validate(c *Category) error {
+	if existing, ok := t.FindByName(c.Name); ok && existing.ID != c.ID {
+		return domain.NewError("category", domain.ErrCodeAlreadyExists, fmt.Sprintf("category %s already exists", c.Name))
+	}
+	if c.ParentID == "" {
+		return nil
+	}
+	if _, ok := t.byID[c.ParentID]; !ok {
+		return invalid(fmt.Sprintf("parent category %s not found", c.ParentID))
+	}
+	if c.ParentID == c.ID || t.IsDescendant(c.ParentID, c.ID) {
+		return invalid("category cannot be its own ancestor")
+	}
+	return nil
+}
+
+// Subject 분류를 정할 거래의 정보입니다. Amount는 부호 없는 금액입니다.
+type Subject struct {
+	Description  string
+	Counterparty string
+	Amount       float64
+	Type         asset.TransactionType
+	Time         time.Time
+}
+
+// SubjectOf 자산 거래의 분류 대상 정보를 만듭니다. 거래처는 설명에 함께 기록되어 있습니다.
+func SubjectOf(tx *asset.Transaction) Subject {
+	return Subject{
+		Description: tx.Description,
+		Amount:      tx.Amount.Amount,
+		Type:        tx.Type,
+		Time:        tx.Date,
+	}
+}
+
+// RuleSource 규칙을 만든 주체입니다.
+type RuleSource string
+
+const (
+	SourceUser    RuleSource = "USER"
+	SourceLearned RuleSource = "LEARNED" // 분류 수정 내역에서 제안되어 사용자가 채택한 규칙
+)
+
+// Conditions 규칙의 조건입니다. 지정한 조건을 모두 만족해야 규칙이 적용됩니다.
+type Conditions struct {
+	// Description 설명에 대한 정규식입니다
+	Description string `json:"description,omitempty"`
+	// Counterparty 거래처나 설명에 포함된 문자열입니다. 대소문자를 구분하지 않습니다
+	Counterparty string                `json:"counterparty,omitempty"`
+	MinAmount    *float64              `json:"minAmount,omitempty"`
+	MaxAmount    *float64              `json:"maxAmount,omitempty"`
+	Type         asset.TransactionType `json:"type,omitempty"`
+	Weekdays     []time.Weekday        `json:"weekdays,omitempty"`
+	// TimeFrom, TimeTo 거래 시각 범위(HH:MM)입니다. TimeFrom이 TimeTo보다 늦으면 자정을 넘는 범위입니다
+	TimeFrom string `json:"timeFrom,omitempty"`
+	TimeTo   string `json:"timeTo,omitempty"`
+}
+
+// Rule 거래에 분류를 지정하는 규칙입니다. Priority가 작을수록 먼저 평가하며
+// 처음으로 조건을 만족한 규칙의 분류를 사용합니다.
+type Rule struct {
+	ID         string
+	UserID     string
+	Name       string
+	CategoryID string
+	Priority   int
+	Conditions Conditions
+	Enabled    bool
+	Source     RuleSource
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+
+	// 저장소에서 읽은 규칙은 처음 평가할 때 조건을 준비합니다
+	compiled bool
+	pattern  *regexp.Regexp
+	from, to int
+}
+
+// NewRule 새로운 규칙을 생성합니다.
+func NewRule(userID, name, categoryID string, priority int, conditions Conditions) (*Rule, error) {
+	now := time.Now()
+	rule := &Rule{
+		ID:         uuid.New().String(),
+		UserID:     userID,
+		Name:       strings.TrimSpace(name),
+		CategoryID: categoryID,
+		Priority:   priority,
+		Conditions: conditions,
+		Enabled:    true,
+		Source:     SourceUser,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Validate 규칙의 필수 값과 조건 형식을 확인하고 정규식을 준비합니다.
+func (r *Rule) Validate() error {
+	if r.UserID == "" {
+		return invalid("user ID is required")
+	}
+	if r.CategoryID == "" {
+		return invalid("category is required")
+	}
+	c := r.Conditions
+	if c.Description == "" && c.Counterparty == "" && c.MinAmount == nil && c.MaxAmount == nil &&
+		c.Type == "" && len(c.Weekdays) == 0 && c.TimeFrom == "" && c.TimeTo == "" {
+		return invalid("rule needs at least one condition")
+	}
+	if c.MinAmount != nil && c.MaxAmount != nil && *c.MinAmount > *c.MaxAmount {
+		return invalid("minimum amount is greater than maximum amount")
+	}
+	for _, day := range c.Weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return invalid(fmt.Sprintf("invalid weekday %d", day))
+		}
+	}
+	return r.compile()
+}
+
+// compile 설명 정규식과 시각 범위를 준비합니다.
+func (r *Rule) compile() error {
+	c := r.Conditions
+	r.pattern = nil
+	r.compiled = false
+	if c.Description != "" {
+		pattern, err := regexp.Compile(c.Description)
+		if err != nil {
+			return invalid(fmt.Sprintf("invalid description pattern: %v", err))
+		}
+		r.pattern = pattern
+	}
+	if (c.TimeFrom == "") != (c.TimeTo == "") {
+		return invalid("time range needs both start and end")
+	}
+	if c.TimeFrom != "" {
+		var err error
+		if r.from, err = parseClock(c.TimeFrom); err != nil {
+			return err
+		}
+		if r.to, err = parseClock(c.TimeTo); err != nil {
+			return err
+		}
+	}
+	r.compiled = true
+	return nil
+}
+
+// Matches 거래가 규칙의 조건을 모두 만족하는지 확인합니다.
+func (r *Rule) Matches(s Subject) bool {
+	if !r.Enabled {
+		return false
+	}
+	if !r.compiled {
+		if err := r.compile(); err != nil {
+			return false
+		}
+	}
+	c := r.Conditions
+	if r.pattern != nil && !r.pattern.MatchString(s.Description) {
+		return false
+	}
+	if c.Counterparty != "" {
+		needle := strings.ToLower(c.Counterparty)
+		if !strings.Contains(strings.ToLower(s.Counterparty), needle) && !strings.Contains(strings.ToLower(s.Description), needle) {
+			return false
+		}
+	}
+	if c.MinAmount != nil && s.Amount < *c.MinAmount {
+		return false
+	}
+	if c.MaxAmount != nil && s.Amount > *c.MaxAmount {
+		return false
+	}
+	if c.Type != "" && c.Type != s.Type {
+		return false
+	}
+	if len(c.Weekdays) > 0 && !containsWeekday(c.Weekdays, s.Time.Weekday()) {
+		return false
+	}
+	if c.TimeFrom != "" {
+		minute := s.Time.Hour()*60 + s.Time.Minute()
+		if r.from <= r.to {
+			return minute >= r.from && minute < r.to
+		}
+		return minute >= r.from || minute < r.to
+	}
+	return true
+}
+
+// sortRules 규칙을 평가 순서로 정렬합니다. 우선순위가 같으면 먼저 만든 규칙이 앞섭니다.
+func sortRules(rules []*Rule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+}
+
+// Correction 사용자가 거래의 분류를 고친 기록입니다.
+type Correction struct {
+	ID            string
+	UserID        string
+	TransactionID string
+	Subject       Subject
+	CategoryID    string
+	CorrectedAt   time.Time
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock HH:MM을 자정부터의 분으로 변환합니다.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, invalid(fmt.Sprintf("invalid time %q, expected HH:MM", s))
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func invalid(msg string) error {
+	return domain.NewError("category", domain.ErrCodeInvalidArgument, msg)
+}
+
+func notFound(msg string) error {
+	return domain.NewError("category", domain.ErrCodeNotFound, msg)
+}
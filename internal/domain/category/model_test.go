@@ -0,0 +1,104 @@
+package category
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+func amount(v float64) *float64 {
+	return &v
+}
+
+func Test_Rule_should_match_all_conditions(t *testing.T) {
+	// Given
+	rule, err := NewRule("user-1", "평일 점심", "c1", 10, Conditions{
+		Description: `(?i)^(starbucks|스타벅스)`,
+		MinAmount:   amount(3000),
+		MaxAmount:   amount(20000),
+		Type:        asset.Expense,
+		Weekdays:    []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		TimeFrom:    "11:00",
+		TimeTo:      "14:00",
+	})
+	require.NoError(t, err)
+	lunch := time.Date(2024, 1, 8, 12, 30, 0, 0, time.UTC) // 월요일
+
+	tests := []struct {
+		name    string
+		subject Subject
+		want    bool
+	}{
+		{name: "모든 조건 만족", subject: Subject{Description: "STARBUCKS 강남", Amount: 5600, Type: asset.Expense, Time: lunch}, want: true},
+		{name: "설명 불일치", subject: Subject{Description: "이디야", Amount: 5600, Type: asset.Expense, Time: lunch}},
+		{name: "금액 미만", subject: Subject{Description: "스타벅스", Amount: 2500, Type: asset.Expense, Time: lunch}},
+		{name: "유형 불일치", subject: Subject{Description: "스타벅스", Amount: 5600, Type: asset.Income, Time: lunch}},
+		{name: "주말", subject: Subject{Description: "스타벅스", Amount: 5600, Type: asset.Expense, Time: lunch.AddDate(0, 0, 5)}},
+		{name: "시간 범위 밖", subject: Subject{Description: "스타벅스", Amount: 5600, Type: asset.Expense, Time: lunch.Add(3 * time.Hour)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rule.Matches(tt.subject))
+		})
+	}
+}
+
+func Test_Rule_should_support_time_ranges_across_midnight(t *testing.T) {
+	// Given
+	rule, err := NewRule("user-1", "심야 택시", "c1", 0, Conditions{Counterparty: "taxi", TimeFrom: "22:00", TimeTo: "04:00"})
+	require.NoError(t, err)
+	night := time.Date(2024, 1, 8, 23, 10, 0, 0, time.UTC)
+
+	// Then
+	assert.True(t, rule.Matches(Subject{Counterparty: "Kakao Taxi", Time: night}))
+	assert.True(t, rule.Matches(Subject{Description: "kakao TAXI", Time: night.Add(3 * time.Hour)}))
+	assert.False(t, rule.Matches(Subject{Counterparty: "Kakao Taxi", Time: night.Add(6 * time.Hour)}))
+}
+
+func Test_Rule_should_reject_invalid_conditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions Conditions
+	}{
+		{name: "조건 없음", conditions: Conditions{}},
+		{name: "잘못된 정규식", conditions: Conditions{Description: "("}},
+		{name: "금액 범위 역전", conditions: Conditions{MinAmount: amount(10), MaxAmount: amount(1)}},
+		{name: "시각 한쪽만 지정", conditions: Conditions{TimeFrom: "09:00"}},
+		{name: "잘못된 시각", conditions: Conditions{TimeFrom: "9시", TimeTo: "10:00"}},
+		{name: "잘못된 요일", conditions: Conditions{Weekdays: []time.Weekday{7}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRule("user-1", "규칙", "c1", 0, tt.conditions)
+
+			var domainErr domain.Error
+			require.True(t, errors.As(err, &domainErr))
+			assert.Equal(t, domain.ErrCodeInvalidArgument, domainErr.Code())
+		})
+	}
+}
+
+func Test_Taxonomy_should_build_paths_and_reject_cycles(t *testing.T) {
+	// Given
+	food := &Category{ID: "food", Name: "식비"}
+	cafe := &Category{ID: "cafe", Name: "카페", ParentID: "food"}
+	taxonomy := NewTaxonomy([]*Category{food, cafe})
+
+	// Then
+	assert.Equal(t, []string{"식비", "카페"}, taxonomy.Path("cafe"))
+	assert.Equal(t, []*Category{cafe}, taxonomy.Children("food"))
+	assert.True(t, taxonomy.IsDescendant("cafe", "food"))
+	assert.NoError(t, taxonomy.Validate(&Category{ID: "bakery", Name: "베이커리", ParentID: "food"}))
+	assert.Error(t, taxonomy.Validate(&Category{ID: "food", Name: "식비", ParentID: "cafe"}))
+	assert.Error(t, taxonomy.Validate(&Category{ID: "x", Name: "기타", ParentID: "missing"}))
+
+	var domainErr domain.Error
+	require.True(t, errors.As(taxonomy.Validate(&Category{ID: "x", Name: "카페"}), &domainErr))
+	assert.Equal(t, domain.ErrCodeAlreadyExists, domainErr.Code())
+}
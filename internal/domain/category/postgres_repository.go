@@ -0,0 +1,113 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// PostgresRepository categories, category_rules, category_corrections 테이블을 사용하는 분류 저장소 구현체입니다.
+// 각 항목은 data 컬럼에 JSON으로 저장하고, 사용자 ID를 조회용 컬럼으로 함께 저장합니다.
+type PostgresRepository struct {
+	categories  *sqldb.Documents[*Category]
+	rules       *sqldb.Documents[*Rule]
+	corrections *sqldb.Documents[*Correction]
+}
+
+// NewPostgresRepository 새로운 PostgreSQL 분류 저장소를 생성합니다.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{
+		categories: sqldb.NewDocuments(db, "categories", []string{"id"}, []string{"user_id"},
+			func(c *Category) []interface{} { return []interface{}{c.ID, c.UserID} }),
+		rules: sqldb.NewDocuments(db, "category_rules", []string{"id"}, []string{"user_id"},
+			func(r *Rule) []interface{} { return []interface{}{r.ID, r.UserID} }),
+		corrections: sqldb.NewDocuments(db, "category_corrections", []string{"id"}, []string{"user_id"},
+			func(c *Correction) []interface{} { return []interface{}{c.ID, c.UserID} }),
+	}
+}
+
+// SaveCategory 분류를 저장합니다.
+func (r *PostgresRepository) SaveCategory(ctx context.Context, category *Category) error {
+	return storageError(r.categories.Put(ctx, category))
+}
+
+// FindCategories 사용자의 분류를 생성 순으로 조회합니다.
+func (r *PostgresRepository) FindCategories(ctx context.Context, userID string) ([]*Category, error) {
+	categories, err := r.categories.Find(ctx, "user_id = $1", userID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortCategories(categories)
+	return categories, nil
+}
+
+// DeleteCategory 분류를 삭제합니다.
+func (r *PostgresRepository) DeleteCategory(ctx context.Context, id string) error {
+	deleted, err := r.categories.Delete(ctx, id)
+	if err != nil {
+		return storageError(err)
+	}
+	if !deleted {
+		return notFound(fmt.Sprintf("category with ID %s not found", id))
+	}
+	return nil
+}
+
+// SaveRule 규칙을 저장합니다.
+func (r *PostgresRepository) SaveRule(ctx context.Context, rule *Rule) error {
+	return storageError(r.rules.Put(ctx, rule))
+}
+
+// FindRule ID로 규칙을 조회합니다.
+func (r *PostgresRepository) FindRule(ctx context.Context, id string) (*Rule, error) {
+	rule, exists, err := r.rules.Get(ctx, id)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if !exists {
+		return nil, notFound(fmt.Sprintf("rule with ID %s not found", id))
+	}
+	return rule, nil
+}
+
+// FindRules 사용자의 규칙을 평가 순서로 조회합니다.
+func (r *PostgresRepository) FindRules(ctx context.Context, userID string) ([]*Rule, error) {
+	rules, err := r.rules.Find(ctx, "user_id = $1", userID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortRules(rules)
+	return rules, nil
+}
+
+// DeleteRule 규칙을 삭제합니다.
+func (r *PostgresRepository) DeleteRule(ctx context.Context, id string) error {
+	deleted, err := r.rules.Delete(ctx, id)
+	if err != nil {
+		return storageError(err)
+	}
+	if !deleted {
+		return notFound(fmt.Sprintf("rule with ID %s not found", id))
+	}
+	return nil
+}
+
+// AppendCorrection 분류 수정 기록을 추가합니다.
+func (r *PostgresRepository) AppendCorrection(ctx context.Context, correction *Correction) error {
+	return storageError(r.corrections.Put(ctx, correction))
+}
+
+// FindCorrections 사용자의 분류 수정 기록을 시간 순으로 조회합니다.
+func (r *PostgresRepository) FindCorrections(ctx context.Context, userID string) ([]*Correction, error) {
+	corrections, err := r.corrections.Find(ctx, "user_id = $1", userID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sort.SliceStable(corrections, func(i, j int) bool {
+		return corrections[i].CorrectedAt.Before(corrections[j].CorrectedAt)
+	})
+	return corrections, nil
+}
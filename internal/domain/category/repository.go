@@ -0,0 +1,19 @@
+package category
+
+import "context"
+
+// Repository 분류 체계, 규칙, 분류 수정 기록 저장소입니다.
+type Repository interface {
+	SaveCategory(ctx context.Context, category *Category) error
+	FindCategories(ctx context.Context, userID string) ([]*Category, error)
+	DeleteCategory(ctx context.Context, id string) error
+
+	// SaveRule 규칙을 저장합니다. 같은 ID가 있으면 덮어씁니다.
+	SaveRule(ctx context.Context, rule *Rule) error
+	FindRule(ctx context.Context, id string) (*Rule, error)
+	FindRules(ctx context.Context, userID string) ([]*Rule, error)
+	DeleteRule(ctx context.Context, id string) error
+
+	AppendCorrection(ctx context.Context, correction *Correction) error
+	FindCorrections(ctx context.Context, userID string) ([]*Correction, error)
+}
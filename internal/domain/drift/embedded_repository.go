@@ -0,0 +1,94 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	sampleBucket = "drift_samples"
+	watchBucket  = "drift_watches"
+
+	indexPortfolioID = "portfolio_id"
+)
+
+// EmbeddedRepository 드리프트 기록과 감시 설정의 임베디드 키-값 저장소 구현체입니다.
+// 기록은 "포트폴리오 ID/날짜" 키로, 감시 설정은 포트폴리오 ID 키로 저장합니다.
+type EmbeddedRepository struct {
+	samples *kv.Collection[*Sample]
+	watches *kv.Collection[*Watch]
+}
+
+// NewEmbeddedRepository 포트폴리오 인덱스를 가진 임베디드 드리프트 저장소를 생성합니다.
+func NewEmbeddedRepository(db *kv.DB) (*EmbeddedRepository, error) {
+	samples := kv.NewCollection[*Sample](db, sampleBucket)
+	if err := samples.Index(indexPortfolioID, func(s *Sample) []string { return []string{s.PortfolioID} }); err != nil {
+		return nil, err
+	}
+	return &EmbeddedRepository{samples: samples, watches: kv.NewCollection[*Watch](db, watchBucket)}, nil
+}
+
+// storageError 도메인 에러는 그대로 두고, 저장소 에러는 내부 에러로 감쌉니다.
+func storageError(err error) error {
+	var domainErr domain.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewError("drift", domain.ErrCodeInternal, err.Error())
+}
+
+// SaveSample 드리프트 기록을 저장합니다.
+func (r *EmbeddedRepository) SaveSample(ctx context.Context, sample *Sample) error {
+	return storageError(r.samples.Update(ctx, func(tx *kv.Tx) error {
+		return r.samples.Put(tx, sample.Key(), sample)
+	}))
+}
+
+// FindSamples 포트폴리오의 드리프트 기록을 날짜 순으로 조회합니다.
+func (r *EmbeddedRepository) FindSamples(ctx context.Context, portfolioID string) ([]*Sample, error) {
+	var samples []*Sample
+	err := r.samples.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.samples.Lookup(tx, indexPortfolioID, portfolioID)
+		samples = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortSamples(samples)
+	return samples, nil
+}
+
+// SaveWatch 감시 설정을 저장합니다.
+func (r *EmbeddedRepository) SaveWatch(ctx context.Context, watch *Watch) error {
+	return storageError(r.watches.Update(ctx, func(tx *kv.Tx) error {
+		return r.watches.Put(tx, watch.PortfolioID, watch)
+	}))
+}
+
+// FindWatch 포트폴리오의 감시 설정을 조회합니다.
+func (r *EmbeddedRepository) FindWatch(ctx context.Context, portfolioID string) (*Watch, error) {
+	var watch *Watch
+	err := r.watches.View(ctx, func(tx *kv.Tx) error {
+		found, ok, err := r.watches.Get(tx, portfolioID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return domain.NewError("drift", domain.ErrCodeNotFound, fmt.Sprintf("watch for portfolio %s not found", portfolioID))
+		}
+		watch = found
+		return nil
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if watch.Alerting == nil {
+		watch.Alerting = make(map[string]bool)
+	}
+	return watch, nil
+}
@@ -0,0 +1,53 @@
+package drift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+func Test_EmbeddedRepository_should_overwrite_daily_samples_and_keep_watch(t *testing.T) {
+	// Given
+	repo, err := NewEmbeddedRepository(kv.OpenMemory())
+	require.NoError(t, err)
+	ctx := context.Background()
+	day := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	samples := []*Sample{
+		{PortfolioID: "p-1", Date: day.AddDate(0, 0, 1), MaxAbsolute: 3},
+		{PortfolioID: "p-1", Date: day, MaxAbsolute: 1},
+		{PortfolioID: "p-1", Date: day, MaxAbsolute: 2, Positions: []PositionDrift{{AssetID: "a-1", Alerting: true}}},
+		{PortfolioID: "p-2", Date: day, MaxAbsolute: 9},
+	}
+	watch := NewWatch("p-1", "user-1", day)
+	watch.Alerting["a-1"] = true
+
+	// When
+	for _, s := range samples {
+		require.NoError(t, repo.SaveSample(ctx, s))
+	}
+	require.NoError(t, repo.SaveWatch(ctx, watch))
+
+	// Then
+	found, err := repo.FindSamples(ctx, "p-1")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, 2.0, found[0].MaxAbsolute)
+	assert.True(t, found[0].Positions[0].Alerting)
+	assert.Equal(t, 3.0, found[1].MaxAbsolute)
+
+	foundWatch, err := repo.FindWatch(ctx, "p-1")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultThresholds, foundWatch.Thresholds)
+	assert.True(t, foundWatch.Alerting["a-1"])
+
+	_, err = repo.FindWatch(ctx, "p-2")
+	var domainErr domain.Error
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code())
+}
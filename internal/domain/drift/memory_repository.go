@@ -0,0 +1,88 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+)
+
+// MemoryRepository 드리프트 기록과 감시 설정의 인메모리 저장소 구현체입니다.
+type MemoryRepository struct {
+	samples map[string]*Sample
+	watches map[string]*Watch
+	mutex   sync.RWMutex
+}
+
+// NewMemoryRepository 새로운 인메모리 드리프트 저장소를 생성합니다.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{samples: make(map[string]*Sample), watches: make(map[string]*Watch)}
+}
+
+// SaveSample 드리프트 기록을 저장합니다.
+func (r *MemoryRepository) SaveSample(_ context.Context, sample *Sample) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.samples[sample.Key()] = cloneSample(sample)
+	return nil
+}
+
+// FindSamples 포트폴리오의 드리프트 기록을 날짜 순으로 조회합니다.
+func (r *MemoryRepository) FindSamples(_ context.Context, portfolioID string) ([]*Sample, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]*Sample, 0)
+	for _, s := range r.samples {
+		if s.PortfolioID == portfolioID {
+			result = append(result, cloneSample(s))
+		}
+	}
+	sortSamples(result)
+	return result, nil
+}
+
+// SaveWatch 감시 설정을 저장합니다.
+func (r *MemoryRepository) SaveWatch(_ context.Context, watch *Watch) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.watches[watch.PortfolioID] = cloneWatch(watch)
+	return nil
+}
+
+// FindWatch 포트폴리오의 감시 설정을 조회합니다.
+func (r *MemoryRepository) FindWatch(_ context.Context, portfolioID string) (*Watch, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	watch, ok := r.watches[portfolioID]
+	if !ok {
+		return nil, domain.NewError("drift", domain.ErrCodeNotFound, fmt.Sprintf("watch for portfolio %s not found", portfolioID))
+	}
+	return cloneWatch(watch), nil
+}
+
+func cloneSample(sample *Sample) *Sample {
+	c := *sample
+	c.Positions = append([]PositionDrift(nil), sample.Positions...)
+	return &c
+}
+
+func cloneWatch(watch *Watch) *Watch {
+	c := *watch
+	c.Alerting = make(map[string]bool, len(watch.Alerting))
+	for id, alerting := range watch.Alerting {
+		c.Alerting[id] = alerting
+	}
+	return &c
+}
+
+func sortSamples(samples []*Sample) {
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Date.Before(samples[j].Date)
+	})
+}
@@ -0,0 +1,101 @@
+// Package drift 리밸런싱 사이에 포트폴리오의 실제 비중이 목표 비중(PortfolioAsset.Weight)에서 벗어나는 정도를 추적합니다.
+// 자산 금액이 바뀔 때마다 비중 차이를 계산해 날짜별 기록으로 남기고, 포트폴리오별 한도를 넘으면 알림을 보냅니다.
+// 알림은 한도를 넘을 때 한 번 보내고, 차이가 한도보다 충분히 줄어들어야(히스테리시스) 해제되므로
+// 한도 근처에서 오르내려도 알림이 반복되지 않습니다.
+package drift
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+)
+
+// alertSource 드리프트 알림의 출처입니다.
+const alertSource = "portfolio-drift"
+
+// Thresholds 포트폴리오의 드리프트 한도입니다.
+type Thresholds struct {
+	Absolute   float64 // 목표 비중과의 차이(%p) 한도. 0이면 보지 않습니다
+	Relative   float64 // 목표 비중 대비 차이(%) 한도. 0이면 보지 않습니다
+	Hysteresis float64 // 알림 해제 비율. 차이가 한도 × Hysteresis 이하로 줄어야 알림이 해제됩니다
+}
+
+// DefaultThresholds 한도를 설정하지 않은 포트폴리오에 쓰는 한도입니다.
+var DefaultThresholds = Thresholds{Absolute: 5, Relative: 25, Hysteresis: 0.8}
+
+// Validate 한도가 유효한지 확인합니다.
+func (t Thresholds) Validate() error {
+	switch {
+	case t.Absolute < 0 || t.Relative < 0:
+		return invalid("thresholds must not be negative")
+	case t.Absolute == 0 && t.Relative == 0:
+		return invalid("at least one of absolute or relative threshold is required")
+	case t.Hysteresis <= 0 || t.Hysteresis > 1:
+		return invalid("hysteresis must be greater than 0 and at most 1")
+	}
+	return nil
+}
+
+// exceeds 비중 차이가 한도를 넘었는지 확인합니다.
+func (t Thresholds) exceeds(p PositionDrift) bool {
+	return (t.Absolute > 0 && math.Abs(p.Absolute) > t.Absolute) || (t.Relative > 0 && math.Abs(p.Relative) > t.Relative)
+}
+
+// clears 비중 차이가 알림을 해제할 만큼 줄었는지 확인합니다.
+func (t Thresholds) clears(p PositionDrift) bool {
+	return (t.Absolute == 0 || math.Abs(p.Absolute) <= t.Absolute*t.Hysteresis) &&
+		(t.Relative == 0 || math.Abs(p.Relative) <= t.Relative*t.Hysteresis)
+}
+
+// Watch 포트폴리오의 드리프트 한도와 알림 상태입니다.
+type Watch struct {
+	PortfolioID string
+	UserID      string
+	Thresholds  Thresholds
+	Alerting    map[string]bool // 알림 중인 자산 ID
+	UpdatedAt   time.Time
+}
+
+// NewWatch 기본 한도로 새로운 감시 설정을 생성합니다.
+func NewWatch(portfolioID, userID string, now time.Time) *Watch {
+	return &Watch{
+		PortfolioID: portfolioID,
+		UserID:      userID,
+		Thresholds:  DefaultThresholds,
+		Alerting:    make(map[string]bool),
+		UpdatedAt:   now,
+	}
+}
+
+// PositionDrift 자산 하나의 목표 비중과 실제 비중의 차이입니다. 비중은 모두 % 단위입니다.
+type PositionDrift struct {
+	AssetID  string
+	Name     string
+	Target   float64
+	Weight   float64
+	Absolute float64 // Weight - Target (%p)
+	Relative float64 // Absolute / Target × 100 (%). 목표 비중이 0이면 0
+	Alerting bool    // 계산 후 알림 상태
+}
+
+// Sample 한 포트폴리오의 하루 드리프트 기록입니다. 같은 날 다시 계산하면 마지막 값으로 덮어씁니다.
+type Sample struct {
+	PortfolioID string
+	UserID      string
+	Date        time.Time // UTC 자정
+	At          time.Time // 계산 시각
+	Positions   []PositionDrift
+	MaxAbsolute float64
+	MaxRelative float64
+}
+
+// Key 포트폴리오와 날짜로 만든 저장 키입니다.
+func (s *Sample) Key() string {
+	return fmt.Sprintf("%s/%s", s.PortfolioID, s.Date.Format("2006-01-02"))
+}
+
+func invalid(msg string) error {
+	return domain.NewError("drift", domain.ErrCodeInvalidArgument, msg)
+}
@@ -0,0 +1,268 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/domain/rebalance"
+	"github.com/aske/go_fi_chart/services/monitoring/pkg/alerts"
+	"github.com/google/uuid"
+)
+
+// Monitor 포트폴리오의 비중 차이를 계산해 기록하고 한도를 넘거나 돌아오면 모니터링 서비스의 알림 처리자로 알림을 보냅니다.
+type Monitor struct {
+	repo       Repository
+	portfolios asset.PortfolioRepository
+	rebalancer *rebalance.Rebalancer
+	notifier   alerts.Notifier
+	now        func() time.Time
+	mutex      sync.Mutex
+}
+
+// Option Monitor 설정 함수입니다.
+type Option func(*Monitor)
+
+// WithClock 현재 시각 함수를 지정합니다.
+func WithClock(now func() time.Time) Option {
+	return func(m *Monitor) {
+		m.now = now
+	}
+}
+
+// NewMonitor 새로운 드리프트 모니터를 생성합니다. notifier가 nil이면 알림을 보내지 않습니다.
+func NewMonitor(repo Repository, portfolios asset.PortfolioRepository, rebalancer *rebalance.Rebalancer, notifier alerts.Notifier, opts ...Option) *Monitor {
+	m := &Monitor{repo: repo, portfolios: portfolios, rebalancer: rebalancer, notifier: notifier, now: time.Now}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// HandlerName 이벤트 핸들러 이름입니다.
+func (m *Monitor) HandlerName() string {
+	return "drift-monitor"
+}
+
+// HandleEvent 자산 금액이나 구성이 바뀌는 이벤트를 받으면 그 사용자의 포트폴리오를 다시 평가합니다.
+// 가격 갱신은 Revaluer가 발행하는 자산 금액 변경 이벤트로 전달됩니다.
+// 포트폴리오가 없거나 아직 평가할 수 없는 포트폴리오는 무시합니다.
+func (m *Monitor) HandleEvent(ctx context.Context, evt event.Event) error {
+	switch evt.EventType() {
+	case event.TypeAssetAmountChanged, event.TypeAssetDeleted, event.TypeTransactionRecorded,
+		event.TypeRecurringPosted, event.TypePortfolioRebalanced:
+	default:
+		return nil
+	}
+	userID := evt.Metadata()["userID"]
+	if userID == "" {
+		return nil
+	}
+	_, _, err := m.Evaluate(ctx, userID)
+	if skippable(err) {
+		return nil
+	}
+	return err
+}
+
+// Start interval마다 모든 포트폴리오를 평가합니다. ctx가 취소되면 반환합니다.
+func (m *Monitor) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		portfolios, err := m.portfolios.FindAll(ctx, nil)
+		if err != nil {
+			log.Printf("포트폴리오 드리프트 평가 실패: %v", err)
+		}
+		for _, p := range portfolios {
+			if _, _, err := m.Evaluate(ctx, p.UserID); err != nil && !skippable(err) {
+				log.Printf("포트폴리오 드리프트 평가 실패(%s): %v", p.ID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Evaluate 사용자 포트폴리오의 비중 차이를 계산해 오늘의 기록으로 저장하고, 알림 상태가 바뀐 자산마다 알림을 보냅니다.
+// 한도를 넘은 자산은 차이가 한도 × Hysteresis 이하로 줄어들 때까지 다시 알림을 보내지 않습니다.
+func (m *Monitor) Evaluate(ctx context.Context, userID string) (*Sample, []alerts.Alert, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	plan, err := m.rebalancer.Plan(ctx, userID, rebalance.Options{})
+	if err != nil {
+		return nil, nil, err
+	}
+	watch, err := m.watch(ctx, plan.PortfolioID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := m.now()
+	sample := &Sample{
+		PortfolioID: plan.PortfolioID,
+		UserID:      userID,
+		Date:        time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		At:          now,
+		Positions:   make([]PositionDrift, len(plan.Positions)),
+	}
+	alerting := make(map[string]bool, len(plan.Positions))
+	var raised []alerts.Alert
+	for i, p := range plan.Positions {
+		position := PositionDrift{AssetID: p.AssetID, Name: p.Name, Target: p.Target, Weight: p.Weight, Absolute: p.Drift}
+		if p.Target > 0 {
+			position.Relative = p.Drift / p.Target * 100
+		}
+
+		was := watch.Alerting[p.AssetID]
+		switch {
+		case !was && watch.Thresholds.exceeds(position):
+			position.Alerting = true
+			raised = append(raised, newAlert(alerts.LevelWarning, watch, position, now))
+		case was && watch.Thresholds.clears(position):
+			raised = append(raised, newAlert(alerts.LevelInfo, watch, position, now))
+		default:
+			position.Alerting = was
+		}
+		if position.Alerting {
+			alerting[p.AssetID] = true
+		}
+
+		sample.MaxAbsolute = math.Max(sample.MaxAbsolute, math.Abs(position.Absolute))
+		sample.MaxRelative = math.Max(sample.MaxRelative, math.Abs(position.Relative))
+		sample.Positions[i] = position
+	}
+
+	if err := m.repo.SaveSample(ctx, sample); err != nil {
+		return nil, nil, err
+	}
+	// 포트폴리오에서 빠진 자산의 알림 상태는 남기지 않습니다
+	watch.Alerting = alerting
+	watch.UpdatedAt = now
+	if err := m.repo.SaveWatch(ctx, watch); err != nil {
+		return nil, nil, err
+	}
+
+	if m.notifier != nil {
+		for _, alert := range raised {
+			if err := m.notifier.Notify(ctx, alert); err != nil {
+				log.Printf("드리프트 알림 발송 실패(%s): %v", plan.PortfolioID, err)
+			}
+		}
+	}
+	return sample, raised, nil
+}
+
+// Thresholds 사용자 포트폴리오의 드리프트 한도를 조회합니다. 설정하지 않았으면 기본 한도를 반환합니다.
+func (m *Monitor) Thresholds(ctx context.Context, userID string) (Thresholds, error) {
+	portfolio, err := m.rebalancer.Targets(ctx, userID)
+	if err != nil {
+		return Thresholds{}, err
+	}
+	watch, err := m.watch(ctx, portfolio.ID, userID)
+	if err != nil {
+		return Thresholds{}, err
+	}
+	return watch.Thresholds, nil
+}
+
+// SetThresholds 사용자 포트폴리오의 드리프트 한도를 설정합니다. 알림 상태는 다음 평가에서 새 한도로 판단합니다.
+func (m *Monitor) SetThresholds(ctx context.Context, userID string, thresholds Thresholds) (Thresholds, error) {
+	if err := thresholds.Validate(); err != nil {
+		return Thresholds{}, err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	portfolio, err := m.rebalancer.Targets(ctx, userID)
+	if err != nil {
+		return Thresholds{}, err
+	}
+	watch, err := m.watch(ctx, portfolio.ID, userID)
+	if err != nil {
+		return Thresholds{}, err
+	}
+	watch.Thresholds = thresholds
+	watch.UpdatedAt = m.now()
+	if err := m.repo.SaveWatch(ctx, watch); err != nil {
+		return Thresholds{}, err
+	}
+	return thresholds, nil
+}
+
+// History 사용자 포트폴리오의 [from, to] 기간 드리프트 기록을 날짜 순으로 조회합니다.
+func (m *Monitor) History(ctx context.Context, userID string, from, to time.Time) ([]*Sample, error) {
+	if to.Before(from) {
+		return nil, invalid("from must not be after to")
+	}
+	portfolio, err := m.rebalancer.Targets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	samples, err := m.repo.FindSamples(ctx, portfolio.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Sample, 0, len(samples))
+	for _, s := range samples {
+		if !s.Date.Before(from) && !s.Date.After(to) {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// watch 포트폴리오의 감시 설정을 조회하고, 없으면 기본 한도로 새로 만듭니다.
+func (m *Monitor) watch(ctx context.Context, portfolioID, userID string) (*Watch, error) {
+	watch, err := m.repo.FindWatch(ctx, portfolioID)
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) && domainErr.Code() == domain.ErrCodeNotFound {
+		return NewWatch(portfolioID, userID, m.now()), nil
+	}
+	return watch, err
+}
+
+// skippable 평가할 포트폴리오가 없거나 아직 평가할 수 없는 경우인지 확인합니다.
+func skippable(err error) bool {
+	var domainErr domain.Error
+	if !errors.As(err, &domainErr) {
+		return false
+	}
+	return domainErr.Code() == domain.ErrCodeNotFound || domainErr.Code() == domain.ErrCodeInvalidArgument
+}
+
+func newAlert(level alerts.AlertLevel, watch *Watch, p PositionDrift, now time.Time) alerts.Alert {
+	message := fmt.Sprintf("%s 비중이 목표 %.1f%%에서 %+.1f%%p 벗어났습니다 (현재 %.1f%%)", p.Name, p.Target, p.Absolute, p.Weight)
+	if level == alerts.LevelInfo {
+		message = fmt.Sprintf("%s 비중이 목표 비중으로 돌아왔습니다 (목표 %.1f%%, 현재 %.1f%%)", p.Name, p.Target, p.Weight)
+	}
+	return alerts.Alert{
+		ID:        uuid.New().String(),
+		Level:     level,
+		Source:    alertSource,
+		Message:   message,
+		Timestamp: now,
+		Metadata: map[string]string{
+			"portfolioID": watch.PortfolioID,
+			"userID":      watch.UserID,
+			"assetID":     p.AssetID,
+			"target":      fmt.Sprintf("%.2f", p.Target),
+			"weight":      fmt.Sprintf("%.2f", p.Weight),
+			"absolute":    fmt.Sprintf("%.2f", p.Absolute),
+			"relative":    fmt.Sprintf("%.2f", p.Relative),
+		},
+	}
+}
@@ -0,0 +1,201 @@
+package drift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/domain/rebalance"
+	"github.com/aske/go_fi_chart/services/monitoring/pkg/alerts"
+	monitoring "github.com/aske/go_fi_chart/services/monitoring/pkg/domain"
+)
+
+var now = time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+
+type recordingNotifier struct {
+	alerts []alerts.Alert
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, alert alerts.Alert) error {
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+type driftFixture struct {
+	ctx      context.Context
+	assets   *asset.MemoryAssetRepository
+	monitor  *Monitor
+	notifier *recordingNotifier
+	stocks   *asset.Asset
+	bonds    *asset.Asset
+	clock    time.Time
+}
+
+// newDriftFixture 주식 50,000원, 채권 50,000원을 목표 비중 50:50으로 둔 포트폴리오를 만듭니다.
+func newDriftFixture(t *testing.T) *driftFixture {
+	t.Helper()
+	f := &driftFixture{
+		ctx:      context.Background(),
+		assets:   asset.NewMemoryAssetRepository(),
+		notifier: &recordingNotifier{},
+		clock:    now,
+	}
+	portfolios := asset.NewMemoryPortfolioRepository()
+	rebalancer := rebalance.NewRebalancer(portfolios, f.assets, asset.NewMemoryTransactionRepository(), nil)
+	f.monitor = NewMonitor(NewMemoryRepository(), portfolios, rebalancer, f.notifier, WithClock(func() time.Time { return f.clock }))
+
+	f.stocks = f.asset(t, "주식", 50000)
+	f.bonds = f.asset(t, "채권", 50000)
+	_, err := rebalancer.SetTargets(f.ctx, "user-1", []asset.PortfolioAsset{
+		{AssetID: f.stocks.ID, Weight: 50},
+		{AssetID: f.bonds.ID, Weight: 50},
+	})
+	require.NoError(t, err)
+	return f
+}
+
+func (f *driftFixture) asset(t *testing.T, name string, amount float64) *asset.Asset {
+	t.Helper()
+	a, err := asset.NewAsset("user-1", asset.Cash, name, amount, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, a))
+	return a
+}
+
+// setAmount 자산 금액을 바꾸고 바뀐 뒤의 알림만 반환합니다.
+func (f *driftFixture) setAmount(t *testing.T, a *asset.Asset, amount float64) []alerts.Alert {
+	t.Helper()
+	found, err := f.assets.FindByID(f.ctx, a.ID)
+	require.NoError(t, err)
+	require.NoError(t, found.ApplyTrade(amount-found.Amount.Amount, 0))
+	require.NoError(t, f.assets.Update(f.ctx, found))
+
+	_, raised, err := f.monitor.Evaluate(f.ctx, "user-1")
+	require.NoError(t, err)
+	return raised
+}
+
+func levels(raised []alerts.Alert) []alerts.AlertLevel {
+	result := make([]alerts.AlertLevel, len(raised))
+	for i, alert := range raised {
+		result[i] = alert.Level
+	}
+	return result
+}
+
+func Test_Monitor_Evaluate_should_alert_once_per_breach_with_hysteresis(t *testing.T) {
+	// Given
+	f := newDriftFixture(t)
+	sample, raised, err := f.monitor.Evaluate(f.ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, raised)
+	assert.InDelta(t, 0, sample.MaxAbsolute, 1e-9)
+
+	// When: 주식 비중 55.4% (+5.4%p)로 한도 5%p를 넘음
+	breach := f.setAmount(t, f.stocks, 62000)
+	// When: 54.5% (+4.5%p)로 한도 아래지만 해제 기준 4%p보다 큼
+	near := f.setAmount(t, f.stocks, 60000)
+	// When: 51.5% (+1.5%p)로 해제 기준 아래
+	cleared := f.setAmount(t, f.stocks, 53000)
+	// When: 다시 한도를 넘음
+	again := f.setAmount(t, f.stocks, 62000)
+
+	// Then
+	assert.Equal(t, []alerts.AlertLevel{alerts.LevelWarning, alerts.LevelWarning}, levels(breach))
+	assert.Equal(t, f.stocks.ID, breach[0].Metadata["assetID"])
+	assert.Equal(t, "5.36", breach[0].Metadata["absolute"])
+	assert.Equal(t, "-5.36", breach[1].Metadata["absolute"])
+	assert.Equal(t, alertSource, breach[0].Source)
+	assert.Empty(t, near)
+	assert.Equal(t, []alerts.AlertLevel{alerts.LevelInfo, alerts.LevelInfo}, levels(cleared))
+	assert.Equal(t, []alerts.AlertLevel{alerts.LevelWarning, alerts.LevelWarning}, levels(again))
+	assert.Len(t, f.notifier.alerts, 6)
+}
+
+func Test_Monitor_Evaluate_should_use_relative_threshold_and_keep_one_sample_per_day(t *testing.T) {
+	// Given: 절대 한도는 끄고 상대 한도 10%만 봅니다
+	f := newDriftFixture(t)
+	_, err := f.monitor.SetThresholds(f.ctx, "user-1", Thresholds{Relative: 10, Hysteresis: 0.5})
+	require.NoError(t, err)
+
+	// When: 주식 비중 52.4% (목표 대비 +4.8%)
+	small := f.setAmount(t, f.stocks, 55000)
+	// When: 주식 비중 54.5% (목표 대비 +9.1%), 채권은 -9.1%
+	medium := f.setAmount(t, f.stocks, 60000)
+	f.clock = now.Add(24 * time.Hour)
+	// When: 주식 비중 58.3% (목표 대비 +16.7%)
+	large := f.setAmount(t, f.stocks, 70000)
+
+	// Then
+	assert.Empty(t, small)
+	assert.Empty(t, medium)
+	require.Len(t, large, 2)
+	assert.Equal(t, "16.67", large[0].Metadata["relative"])
+
+	history, err := f.monitor.History(f.ctx, "user-1", now.Add(-24*time.Hour), now.Add(48*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.InDelta(t, 100.0/11, history[0].MaxRelative, 1e-9)
+	assert.True(t, history[1].Positions[0].Alerting)
+}
+
+func Test_Monitor_SetThresholds_should_reject_invalid_thresholds(t *testing.T) {
+	// Given
+	f := newDriftFixture(t)
+
+	// When
+	_, err := f.monitor.SetThresholds(f.ctx, "user-1", Thresholds{Absolute: 5, Hysteresis: 1.5})
+
+	// Then
+	var domainErr domain.Error
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeInvalidArgument, domainErr.Code())
+	thresholds, err := f.monitor.Thresholds(f.ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultThresholds, thresholds)
+}
+
+type recordingPublisher struct {
+	events []monitoring.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, evt monitoring.Event) error {
+	p.events = append(p.events, evt)
+	return nil
+}
+
+func (p *recordingPublisher) Subscribe(monitoring.Handler) error { return nil }
+
+func (p *recordingPublisher) Unsubscribe(monitoring.Handler) error { return nil }
+
+func Test_Monitor_HandleEvent_should_deliver_alerts_to_monitoring_notifier(t *testing.T) {
+	// Given
+	f := newDriftFixture(t)
+	publisher := &recordingPublisher{}
+	notifier := alerts.NewSimpleNotifier(publisher)
+	notifier.AddHandler(f.notifier)
+	f.monitor.notifier = notifier
+	found, err := f.assets.FindByID(f.ctx, f.stocks.ID)
+	require.NoError(t, err)
+	require.NoError(t, found.ApplyTrade(30000, 0))
+	require.NoError(t, f.assets.Update(f.ctx, found))
+
+	// When
+	err = f.monitor.HandleEvent(f.ctx, found.GetUncommittedEvents()[0])
+	require.NoError(t, err)
+	err = f.monitor.HandleEvent(f.ctx, event.NewEvent(event.TypeAssetAmountChanged, "x", "asset", nil, map[string]string{"userID": "user-2"}, 1))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, f.notifier.alerts, 2)
+	assert.Equal(t, alerts.LevelWarning, f.notifier.alerts[0].Level)
+	assert.Equal(t, "user-1", f.notifier.alerts[0].Metadata["userID"])
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, monitoring.TypeAlertTriggered, publisher.events[0].Type)
+}
@@ -0,0 +1,62 @@
+package drift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// PostgresRepository drift_samples, drift_watches 테이블을 사용하는 드리프트 저장소 구현체입니다.
+// 기록은 (포트폴리오 ID, 날짜)를, 감시 설정은 포트폴리오 ID를 키로 data 컬럼에 JSON으로 저장합니다.
+type PostgresRepository struct {
+	samples *sqldb.Documents[*Sample]
+	watches *sqldb.Documents[*Watch]
+}
+
+// NewPostgresRepository 새로운 PostgreSQL 드리프트 저장소를 생성합니다.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{
+		samples: sqldb.NewDocuments(db, "drift_samples", []string{"portfolio_id", "sample_date"}, nil,
+			func(s *Sample) []interface{} { return []interface{}{s.PortfolioID, s.Date.Format("2006-01-02")} }),
+		watches: sqldb.NewDocuments(db, "drift_watches", []string{"portfolio_id"}, nil,
+			func(w *Watch) []interface{} { return []interface{}{w.PortfolioID} }),
+	}
+}
+
+// SaveSample 드리프트 기록을 저장합니다.
+func (r *PostgresRepository) SaveSample(ctx context.Context, sample *Sample) error {
+	return storageError(r.samples.Put(ctx, sample))
+}
+
+// FindSamples 포트폴리오의 드리프트 기록을 날짜 순으로 조회합니다.
+func (r *PostgresRepository) FindSamples(ctx context.Context, portfolioID string) ([]*Sample, error) {
+	samples, err := r.samples.Find(ctx, "portfolio_id = $1", portfolioID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortSamples(samples)
+	return samples, nil
+}
+
+// SaveWatch 감시 설정을 저장합니다.
+func (r *PostgresRepository) SaveWatch(ctx context.Context, watch *Watch) error {
+	return storageError(r.watches.Put(ctx, watch))
+}
+
+// FindWatch 포트폴리오의 감시 설정을 조회합니다.
+func (r *PostgresRepository) FindWatch(ctx context.Context, portfolioID string) (*Watch, error) {
+	watch, exists, err := r.watches.Get(ctx, portfolioID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if !exists {
+		return nil, domain.NewError("drift", domain.ErrCodeNotFound, fmt.Sprintf("watch for portfolio %s not found", portfolioID))
+	}
+	if watch.Alerting == nil {
+		watch.Alerting = make(map[string]bool)
+	}
+	return watch, nil
+}
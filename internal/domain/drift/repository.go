@@ -0,0 +1,15 @@
+package drift
+
+import "context"
+
+// Repository 드리프트 기록과 감시 설정 저장소입니다.
+type Repository interface {
+	// SaveSample 드리프트 기록을 저장합니다. 같은 포트폴리오와 날짜의 기록이 있으면 덮어씁니다.
+	SaveSample(ctx context.Context, sample *Sample) error
+	// FindSamples 포트폴리오의 드리프트 기록을 날짜 순으로 조회합니다.
+	FindSamples(ctx context.Context, portfolioID string) ([]*Sample, error)
+	// SaveWatch 감시 설정을 저장합니다.
+	SaveWatch(ctx context.Context, watch *Watch) error
+	// FindWatch 포트폴리오의 감시 설정을 조회합니다. 없으면 NOT_FOUND 에러를 반환합니다.
+	FindWatch(ctx context.Context, portfolioID string) (*Watch, error)
+}
@@ -47,4 +47,5 @@ const (
 	ErrCodeInvalidOperation = "INVALID_OPERATION"
 	ErrCodeNotImplemented   = "NOT_IMPLEMENTED"
 	ErrCodeInternal         = "INTERNAL"
+	ErrCodeConflict         = "CONFLICT" // 낙관적 잠금 버전이 저장된 버전과 다릅니다
 )
@@ -13,10 +13,14 @@ const (
 	TypeAssetCreated        Type = "asset.created"
 	TypeAssetUpdated        Type = "asset.updated"
 	TypeAssetDeleted        Type = "asset.deleted"
+	TypeAssetAmountChanged  Type = "asset.amount_changed"
 	TypeTransactionRecorded Type = "transaction.recorded"
+	TypeRecurringPosted     Type = "recurring.posted"
 	TypePortfolioRebalanced Type = "portfolio.rebalanced"
 	TypeMetricCollected     Type = "metric.collected"
 	TypeAlertTriggered      Type = "alert.triggered"
+	TypePriceUpdated        Type = "price.updated"
+	TypeGoalStatusChanged   Type = "goal.status_changed"
 )
 
 // Event 도메인 이벤트 인터페이스
@@ -0,0 +1,198 @@
+package gamification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	profileBucket = "gamification_profiles"
+	indexUserID   = "user_id"
+)
+
+// EmbeddedRepository 게임화 프로필의 임베디드 키-값 저장소 구현체입니다.
+type EmbeddedRepository struct {
+	profiles *kv.Collection[*Profile]
+}
+
+// NewEmbeddedRepository 사용자 ID 인덱스를 가진 임베디드 저장소를 생성합니다.
+func NewEmbeddedRepository(db *kv.DB) (*EmbeddedRepository, error) {
+	profiles := kv.NewCollection[*Profile](db, profileBucket)
+	if err := profiles.Index(indexUserID, func(p *Profile) []string { return []string{p.UserID} }); err != nil {
+		return nil, err
+	}
+	return &EmbeddedRepository{profiles: profiles}, nil
+}
+
+func notFound(id string) error {
+	return domain.NewError("gamification", domain.ErrCodeNotFound, fmt.Sprintf("profile with ID %s not found", id))
+}
+
+// storageError 도메인 에러는 그대로 두고, 저장소 에러는 내부 에러로 감쌉니다.
+func storageError(err error) error {
+	var domainErr domain.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewError("gamification", domain.ErrCodeInternal, err.Error())
+}
+
+// Save 프로필을 저장합니다.
+func (r *EmbeddedRepository) Save(ctx context.Context, profile *Profile) error {
+	return storageError(r.profiles.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.profiles.Exists(tx, profile.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domain.NewError("gamification", domain.ErrCodeAlreadyExists, fmt.Sprintf("profile with ID %s already exists", profile.ID))
+		}
+		return r.profiles.Put(tx, profile.ID, profile)
+	}))
+}
+
+// FindByID ID로 프로필을 조회합니다.
+func (r *EmbeddedRepository) FindByID(ctx context.Context, id string) (*Profile, error) {
+	var profile *Profile
+	err := r.profiles.View(ctx, func(tx *kv.Tx) error {
+		found, exists, err := r.profiles.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(id)
+		}
+		profile = found
+		return nil
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return profile, nil
+}
+
+// Update 프로필을 업데이트합니다.
+func (r *EmbeddedRepository) Update(ctx context.Context, profile *Profile) error {
+	return storageError(r.profiles.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.profiles.Exists(tx, profile.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(profile.ID)
+		}
+		profile.UpdatedAt = time.Now()
+		return r.profiles.Put(tx, profile.ID, profile)
+	}))
+}
+
+// Delete ID로 프로필을 삭제합니다.
+func (r *EmbeddedRepository) Delete(ctx context.Context, id string) error {
+	return storageError(r.profiles.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.profiles.Exists(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(id)
+		}
+		return r.profiles.Delete(tx, id)
+	}))
+}
+
+// FindAll 검색 조건에 맞는 모든 프로필을 조회합니다.
+func (r *EmbeddedRepository) FindAll(ctx context.Context, _ domain.SearchCriteria) ([]*Profile, error) {
+	var profiles []*Profile
+	err := r.profiles.View(ctx, func(tx *kv.Tx) error {
+		all, err := r.profiles.All(tx)
+		profiles = all
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return profiles, nil
+}
+
+// FindOne 검색 조건에 맞는 하나의 프로필을 조회합니다.
+func (r *EmbeddedRepository) FindOne(_ context.Context, _ domain.SearchCriteria) (*Profile, error) {
+	return nil, domain.NewError("gamification", domain.ErrCodeNotImplemented, "FindOne not implemented")
+}
+
+// WithTransaction 쓰기 트랜잭션 안에서 fn을 실행합니다.
+func (r *EmbeddedRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.profiles.DB().UpdateContext(ctx, fn)
+}
+
+// FindByUserID 사용자 ID로 프로필을 조회합니다.
+func (r *EmbeddedRepository) FindByUserID(ctx context.Context, userID string) (*Profile, error) {
+	var profile *Profile
+	err := r.profiles.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.profiles.Lookup(tx, indexUserID, userID)
+		if err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return domain.NewError("gamification", domain.ErrCodeNotFound, fmt.Sprintf("profile for user %s not found", userID))
+		}
+		profile = found[0]
+		return nil
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return profile, nil
+}
+
+// modify 프로필을 읽어 fn으로 변경한 뒤 같은 트랜잭션에서 다시 기록합니다.
+func (r *EmbeddedRepository) modify(ctx context.Context, id string, fn func(profile *Profile)) error {
+	return storageError(r.profiles.Update(ctx, func(tx *kv.Tx) error {
+		profile, exists, err := r.profiles.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(id)
+		}
+		fn(profile)
+		return r.profiles.Put(tx, id, profile)
+	}))
+}
+
+// UpdateExperience 사용자의 경험치를 업데이트합니다.
+func (r *EmbeddedRepository) UpdateExperience(ctx context.Context, id string, exp int) error {
+	return r.modify(ctx, id, func(profile *Profile) {
+		if profile.AddExperience(exp) {
+			profile.UpdatedAt = time.Now()
+		}
+	})
+}
+
+// UpdateStats 사용자의 통계를 업데이트합니다.
+func (r *EmbeddedRepository) UpdateStats(ctx context.Context, id string, stats Statistics) error {
+	return r.modify(ctx, id, func(profile *Profile) {
+		profile.Stats = stats
+		profile.UpdatedAt = time.Now()
+	})
+}
+
+// AddBadge 사용자에게 뱃지를 추가합니다.
+func (r *EmbeddedRepository) AddBadge(ctx context.Context, id string, badge Badge) error {
+	return r.modify(ctx, id, func(profile *Profile) {
+		profile.Badges = append(profile.Badges, badge)
+		profile.Stats.BadgesEarned++
+		profile.UpdatedAt = time.Now()
+	})
+}
+
+// UpdateStreak 사용자의 연속 달성을 업데이트합니다.
+func (r *EmbeddedRepository) UpdateStreak(ctx context.Context, id string, streakType StreakType) error {
+	return r.modify(ctx, id, func(profile *Profile) {
+		profile.UpdateStreak(streakType)
+	})
+}
@@ -0,0 +1,63 @@
+package gamification
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EmbeddedRepository_should_persist_profile_across_restart(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data.kv")
+	db, err := kv.Open(path, nil)
+	require.NoError(t, err)
+	repo, err := NewEmbeddedRepository(db)
+	require.NoError(t, err)
+
+	profile := NewProfile("test-user")
+	require.NoError(t, repo.Save(ctx, profile))
+	require.NoError(t, repo.UpdateExperience(ctx, profile.ID, 50))
+	require.NoError(t, repo.AddBadge(ctx, profile.ID, Badge{ID: "badge-1", Type: BadgeTypeSaving, Title: "첫 저축"}))
+	require.NoError(t, db.Close())
+
+	// When
+	db, err = kv.Open(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	repo, err = NewEmbeddedRepository(db)
+	require.NoError(t, err)
+	found, err := repo.FindByUserID(ctx, "test-user")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, profile.ID, found.ID)
+	assert.Equal(t, 50, found.Experience)
+	assert.Len(t, found.Badges, 1)
+	assert.Equal(t, 1, found.Stats.BadgesEarned)
+}
+
+func Test_EmbeddedRepository_should_return_domain_errors(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo, err := NewEmbeddedRepository(kv.OpenMemory())
+	require.NoError(t, err)
+	profile := NewProfile("test-user")
+	require.NoError(t, repo.Save(ctx, profile))
+
+	// When
+	saveErr := repo.Save(ctx, profile)
+	_, findErr := repo.FindByID(ctx, "missing")
+
+	// Then
+	var domainErr domain.Error
+	require.ErrorAs(t, saveErr, &domainErr)
+	assert.Equal(t, domain.ErrCodeAlreadyExists, domainErr.Code())
+	require.ErrorAs(t, findErr, &domainErr)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code())
+}
@@ -26,6 +26,7 @@ type Profile struct {
 	Badges       []Badge
 	Streaks      []Streak
 	Stats        Statistics
+	GoalRewards  []GoalReward // 보상을 지급한 목표와 상태
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -52,6 +53,13 @@ type Badge struct {
 	UnlockedAt  time.Time
 }
 
+// GoalReward 목표가 어떤 상태에 이르러 보상을 지급했는지 나타냅니다.
+type GoalReward struct {
+	GoalID     string
+	Status     string
+	RewardedAt time.Time
+}
+
 // BadgeType 뱃지의 유형을 나타냅니다.
 type BadgeType string
 
@@ -119,11 +127,29 @@ func NewProfile(userID string) *Profile {
 		Badges:       make([]Badge, 0),
 		Streaks:      make([]Streak, 0),
 		Stats:        Statistics{},
+		GoalRewards:  make([]GoalReward, 0),
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
 }
 
+// HasGoalReward 목표가 status에 이르러 이미 보상을 받았는지 확인합니다.
+func (p *Profile) HasGoalReward(goalID, status string) bool {
+	for _, reward := range p.GoalRewards {
+		if reward.GoalID == goalID && reward.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordGoalReward 목표가 status에 이르러 보상을 지급했음을 기록합니다.
+func (p *Profile) RecordGoalReward(goalID, status string) {
+	now := time.Now()
+	p.GoalRewards = append(p.GoalRewards, GoalReward{GoalID: goalID, Status: status, RewardedAt: now})
+	p.UpdatedAt = now
+}
+
 // AddExperience 경험치를 추가하고 레벨업 여부를 반환합니다.
 func (p *Profile) AddExperience(exp int) bool {
 	p.Experience += exp
@@ -0,0 +1,157 @@
+package gamification
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// PostgresRepository gamification_profiles 테이블을 사용하는 게임화 프로필 저장소 구현체입니다.
+// 프로필은 data 컬럼에 JSON으로 저장하고, 사용자 ID를 조회용 컬럼으로 함께 저장합니다.
+type PostgresRepository struct {
+	db       *sql.DB
+	profiles *sqldb.Documents[*Profile]
+}
+
+// NewPostgresRepository 새로운 PostgreSQL 게임화 프로필 저장소를 생성합니다.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{
+		db: db,
+		profiles: sqldb.NewDocuments(db, "gamification_profiles", []string{"id"}, []string{"user_id"},
+			func(p *Profile) []interface{} { return []interface{}{p.ID, p.UserID} }),
+	}
+}
+
+// Save 프로필을 저장합니다.
+func (r *PostgresRepository) Save(ctx context.Context, profile *Profile) error {
+	inserted, err := r.profiles.Insert(ctx, profile)
+	if err != nil {
+		return storageError(err)
+	}
+	if !inserted {
+		return domain.NewError("gamification", domain.ErrCodeAlreadyExists, fmt.Sprintf("profile with ID %s already exists", profile.ID))
+	}
+	return nil
+}
+
+// FindByID ID로 프로필을 조회합니다.
+func (r *PostgresRepository) FindByID(ctx context.Context, id string) (*Profile, error) {
+	profile, exists, err := r.profiles.Get(ctx, id)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if !exists {
+		return nil, notFound(id)
+	}
+	return profile, nil
+}
+
+// Update 프로필을 업데이트합니다.
+func (r *PostgresRepository) Update(ctx context.Context, profile *Profile) error {
+	return storageError(sqldb.WithTx(ctx, r.db, func(ctx context.Context) error {
+		_, exists, err := r.profiles.Get(ctx, profile.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(profile.ID)
+		}
+		profile.UpdatedAt = time.Now()
+		return r.profiles.Put(ctx, profile)
+	}))
+}
+
+// Delete ID로 프로필을 삭제합니다.
+func (r *PostgresRepository) Delete(ctx context.Context, id string) error {
+	deleted, err := r.profiles.Delete(ctx, id)
+	if err != nil {
+		return storageError(err)
+	}
+	if !deleted {
+		return notFound(id)
+	}
+	return nil
+}
+
+// FindAll 검색 조건에 맞는 모든 프로필을 조회합니다.
+func (r *PostgresRepository) FindAll(ctx context.Context, _ domain.SearchCriteria) ([]*Profile, error) {
+	profiles, err := r.profiles.Find(ctx, "")
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return profiles, nil
+}
+
+// FindOne 검색 조건에 맞는 하나의 프로필을 조회합니다.
+func (r *PostgresRepository) FindOne(_ context.Context, _ domain.SearchCriteria) (*Profile, error) {
+	return nil, domain.NewError("gamification", domain.ErrCodeNotImplemented, "FindOne not implemented")
+}
+
+// WithTransaction 트랜잭션 안에서 fn을 실행합니다. 중첩 호출은 세이브포인트로 동작합니다.
+func (r *PostgresRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return sqldb.WithTx(ctx, r.db, fn)
+}
+
+// FindByUserID 사용자 ID로 프로필을 조회합니다.
+func (r *PostgresRepository) FindByUserID(ctx context.Context, userID string) (*Profile, error) {
+	profiles, err := r.profiles.Find(ctx, "user_id = $1 LIMIT 1", userID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if len(profiles) == 0 {
+		return nil, domain.NewError("gamification", domain.ErrCodeNotFound, fmt.Sprintf("profile for user %s not found", userID))
+	}
+	return profiles[0], nil
+}
+
+// modify 프로필 행을 잠가 읽고 fn으로 변경한 뒤 같은 트랜잭션에서 다시 기록합니다.
+func (r *PostgresRepository) modify(ctx context.Context, id string, fn func(profile *Profile)) error {
+	return storageError(sqldb.WithTx(ctx, r.db, func(ctx context.Context) error {
+		locked, err := r.profiles.Find(ctx, "id = $1 FOR UPDATE", id)
+		if err != nil {
+			return err
+		}
+		if len(locked) == 0 {
+			return notFound(id)
+		}
+		fn(locked[0])
+		return r.profiles.Put(ctx, locked[0])
+	}))
+}
+
+// UpdateExperience 사용자의 경험치를 업데이트합니다.
+func (r *PostgresRepository) UpdateExperience(ctx context.Context, id string, exp int) error {
+	return r.modify(ctx, id, func(profile *Profile) {
+		if profile.AddExperience(exp) {
+			profile.UpdatedAt = time.Now()
+		}
+	})
+}
+
+// UpdateStats 사용자의 통계를 업데이트합니다.
+func (r *PostgresRepository) UpdateStats(ctx context.Context, id string, stats Statistics) error {
+	return r.modify(ctx, id, func(profile *Profile) {
+		profile.Stats = stats
+		profile.UpdatedAt = time.Now()
+	})
+}
+
+// AddBadge 사용자에게 뱃지를 추가합니다.
+func (r *PostgresRepository) AddBadge(ctx context.Context, id string, badge Badge) error {
+	return r.modify(ctx, id, func(profile *Profile) {
+		profile.Badges = append(profile.Badges, badge)
+		profile.Stats.BadgesEarned++
+		profile.UpdatedAt = time.Now()
+	})
+}
+
+// UpdateStreak 사용자의 연속 달성을 업데이트합니다.
+func (r *PostgresRepository) UpdateStreak(ctx context.Context, id string, streakType StreakType) error {
+	return r.modify(ctx, id, func(profile *Profile) {
+		profile.UpdateStreak(streakType)
+	})
+}
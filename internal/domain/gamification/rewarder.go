@@ -0,0 +1,112 @@
+package gamification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+)
+
+// 목표 달성 전망에 따라 지급하는 경험치입니다.
+const (
+	GoalAheadExperience    = 20
+	GoalAchievedExperience = 100
+)
+
+// GoalRewarder goal.status_changed 이벤트를 구독하여 목표가 앞서가거나 달성되면 사용자에게 보상을 지급합니다.
+// 앞서가면 경험치를, 달성하면 경험치와 목표 유형에 맞는 뱃지를 주고 달성한 목표 수를 늘립니다.
+// 보상은 목표와 상태마다 한 번만 지급하며, 지급 기록은 프로필에 남습니다.
+type GoalRewarder struct {
+	repo Repository
+}
+
+// NewGoalRewarder 새로운 목표 보상 지급기를 생성합니다.
+func NewGoalRewarder(repo Repository) *GoalRewarder {
+	return &GoalRewarder{repo: repo}
+}
+
+// HandlerName 이벤트 핸들러 이름입니다.
+func (r *GoalRewarder) HandlerName() string {
+	return "gamification-goal-rewarder"
+}
+
+// HandleEvent 목표 상태가 AHEAD나 ACHIEVED로 바뀌면 보상을 지급합니다. 프로필이 없으면 새로 만듭니다.
+// 같은 목표가 같은 상태로 다시 바뀌거나 이벤트가 다시 전달되면 보상을 건너뜁니다.
+func (r *GoalRewarder) HandleEvent(ctx context.Context, evt event.Event) error {
+	if evt.EventType() != event.TypeGoalStatusChanged {
+		return nil
+	}
+	metadata := evt.Metadata()
+	userID := metadata["userID"]
+	goalID := evt.AggregateID()
+	status := asset.GoalStatus(metadata["to"])
+	if userID == "" || goalID == "" || (status != asset.GoalStatusAhead && status != asset.GoalStatusAchieved) {
+		return nil
+	}
+
+	// 지급 기록의 확인과 저장을 하나의 트랜잭션으로 처리해 중복 지급을 막습니다
+	return r.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		profile, created, err := r.profile(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if profile.HasGoalReward(goalID, string(status)) {
+			return nil
+		}
+		if status == asset.GoalStatusAhead {
+			profile.AddExperience(GoalAheadExperience)
+		} else {
+			profile.AddExperience(GoalAchievedExperience)
+			profile.Stats.GoalsCompleted++
+			goalType := asset.GoalType(metadata["goalType"])
+			profile.AddBadge(goalBadgeType(goalType), goalBadgeTier(profile.Stats.GoalsCompleted),
+				"목표 달성", fmt.Sprintf("%s 목표를 달성했습니다.", goalType))
+		}
+		profile.RecordGoalReward(goalID, string(status))
+
+		if created {
+			return r.repo.Save(ctx, profile)
+		}
+		return r.repo.Update(ctx, profile)
+	})
+}
+
+func (r *GoalRewarder) profile(ctx context.Context, userID string) (*Profile, bool, error) {
+	profile, err := r.repo.FindByUserID(ctx, userID)
+	if err == nil {
+		return profile, false, nil
+	}
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) && domainErr.Code() == domain.ErrCodeNotFound {
+		return NewProfile(userID), true, nil
+	}
+	return nil, false, err
+}
+
+func goalBadgeType(goalType asset.GoalType) BadgeType {
+	switch goalType {
+	case asset.GoalTypeSaving:
+		return BadgeTypeSaving
+	case asset.GoalTypeInvesting:
+		return BadgeTypeInvesting
+	default:
+		return BadgeTypeChallenge
+	}
+}
+
+// goalBadgeTier 달성한 목표 수가 늘수록 높은 등급의 뱃지를 줍니다.
+func goalBadgeTier(completed int) BadgeTier {
+	switch {
+	case completed >= 20:
+		return BadgeTierDiamond
+	case completed >= 10:
+		return BadgeTierGold
+	case completed >= 3:
+		return BadgeTierSilver
+	default:
+		return BadgeTierBronze
+	}
+}
@@ -0,0 +1,75 @@
+package gamification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+func goalStatusChanged(userID, goalType, to string) event.Event {
+	return goalStatusChangedFor("goal-1", userID, goalType, to)
+}
+
+func goalStatusChangedFor(goalID, userID, goalType, to string) event.Event {
+	return event.NewEvent(event.TypeGoalStatusChanged, goalID, "goal", nil, map[string]string{
+		"userID":   userID,
+		"goalType": goalType,
+		"to":       to,
+	}, 1)
+}
+
+func Test_GoalRewarder_should_reward_ahead_and_achieved_goals(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	rewarder := NewGoalRewarder(repo)
+
+	// When
+	require.NoError(t, rewarder.HandleEvent(ctx, goalStatusChanged("user-1", "INVESTING", "AHEAD")))
+	require.NoError(t, rewarder.HandleEvent(ctx, goalStatusChanged("user-1", "INVESTING", "BEHIND")))
+	require.NoError(t, rewarder.HandleEvent(ctx, goalStatusChanged("user-1", "INVESTING", "ACHIEVED")))
+	require.NoError(t, rewarder.HandleEvent(ctx, goalStatusChanged("user-2", "SAVING", "ON_TRACK")))
+
+	// Then
+	profile, err := repo.FindByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, GoalAheadExperience+GoalAchievedExperience, profile.Experience)
+	assert.Equal(t, 2, profile.Level.Value)
+	assert.Equal(t, 1, profile.Stats.GoalsCompleted)
+	require.Len(t, profile.Badges, 1)
+	assert.Equal(t, BadgeTypeInvesting, profile.Badges[0].Type)
+	assert.Equal(t, BadgeTierBronze, profile.Badges[0].Tier)
+	_, err = repo.FindByUserID(ctx, "user-2")
+	assert.Error(t, err)
+}
+
+func Test_GoalRewarder_should_reward_each_goal_status_once(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo, err := NewEmbeddedRepository(kv.OpenMemory())
+	require.NoError(t, err)
+	rewarder := NewGoalRewarder(repo)
+
+	// When
+	for _, to := range []string{"AHEAD", "BEHIND", "AHEAD", "ACHIEVED", "ACHIEVED"} {
+		require.NoError(t, rewarder.HandleEvent(ctx, goalStatusChangedFor("goal-1", "user-1", "SAVING", to)))
+	}
+	require.NoError(t, rewarder.HandleEvent(ctx, goalStatusChangedFor("goal-2", "user-1", "SAVING", "ACHIEVED")))
+
+	// Then
+	profile, err := repo.FindByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, GoalAheadExperience+2*GoalAchievedExperience, profile.Experience)
+	assert.Equal(t, 2, profile.Stats.GoalsCompleted)
+	assert.Len(t, profile.Badges, 2)
+	require.Len(t, profile.GoalRewards, 3)
+	assert.True(t, profile.HasGoalReward("goal-1", "AHEAD"))
+	assert.True(t, profile.HasGoalReward("goal-1", "ACHIEVED"))
+	assert.True(t, profile.HasGoalReward("goal-2", "ACHIEVED"))
+	assert.False(t, profile.HasGoalReward("goal-2", "AHEAD"))
+}
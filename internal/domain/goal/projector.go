@@ -0,0 +1,244 @@
+// Package goal 저축·투자 목표가 기한 안에 달성될지 전망합니다.
+// 목표 자산의 거래에서 월평균 납입액을 집계하여 필요한 월 납입액, 달성 예상일, 달성 전망 상태를 계산하고
+// 상태가 바뀌면 goal.status_changed 이벤트를 발행하여 게임화 보상이 반응할 수 있게 합니다.
+// 빚 청산(DEBT_FREE) 목표는 부채 상환 계획(payoff 패키지)이 관리하므로 다루지 않습니다.
+package goal
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+)
+
+// Projection 자산에 둔 목표 하나의 달성 전망입니다.
+type Projection struct {
+	AssetID   string
+	AssetName string
+	GoalID    string
+	Type      asset.GoalType
+	Deadline  time.Time
+	Progress  float64
+	asset.GoalProjection
+}
+
+// StatusChange goal.status_changed 이벤트의 페이로드입니다.
+type StatusChange struct {
+	UserID     string
+	AssetID    string
+	GoalID     string
+	Type       asset.GoalType
+	From       asset.GoalStatus // 처음 계산한 목표는 빈 값입니다
+	To         asset.GoalStatus
+	Projection Projection
+	ChangedAt  time.Time
+}
+
+// Projector 사용자의 목표 달성 전망을 계산하고 저장된 목표 상태를 갱신합니다.
+type Projector struct {
+	assets       asset.Repository
+	transactions asset.TransactionRepository
+	bus          event.Bus
+	now          func() time.Time
+	mutex        sync.Mutex
+}
+
+// Option 목표 전망 계산기 설정 함수입니다.
+type Option func(*Projector)
+
+// WithClock 현재 시각 함수를 지정합니다.
+func WithClock(now func() time.Time) Option {
+	return func(p *Projector) {
+		p.now = now
+	}
+}
+
+// NewProjector 새로운 목표 전망 계산기를 생성합니다. bus가 nil이면 상태 변경 이벤트를 발행하지 않습니다.
+func NewProjector(assets asset.Repository, transactions asset.TransactionRepository, bus event.Bus, opts ...Option) *Projector {
+	p := &Projector{assets: assets, transactions: transactions, bus: bus, now: time.Now}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// HandlerName 이벤트 핸들러 이름입니다.
+func (p *Projector) HandlerName() string {
+	return "goal-projector"
+}
+
+// HandleEvent 자산 금액이 바뀌는 이벤트를 받으면 그 사용자의 목표 상태를 갱신합니다.
+func (p *Projector) HandleEvent(ctx context.Context, evt event.Event) error {
+	switch evt.EventType() {
+	case event.TypeAssetAmountChanged, event.TypeTransactionRecorded, event.TypeRecurringPosted:
+	default:
+		return nil
+	}
+	userID := evt.Metadata()["userID"]
+	if userID == "" {
+		return nil
+	}
+	_, err := p.Refresh(ctx, userID)
+	return err
+}
+
+// Project 사용자의 저축·투자 목표의 달성 전망을 계산합니다. 저장된 목표는 바꾸지 않습니다.
+func (p *Projector) Project(ctx context.Context, userID string) ([]Projection, error) {
+	owned, err := p.assets.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := p.now()
+	var projections []Projection
+	for _, a := range owned {
+		assetProjections, err := p.project(ctx, a, now)
+		if err != nil {
+			return nil, err
+		}
+		projections = append(projections, assetProjections...)
+	}
+	return projections, nil
+}
+
+// Refresh 사용자의 목표 진행률과 상태를 갱신하고 상태가 바뀐 목표마다 goal.status_changed 이벤트를 발행합니다.
+func (p *Projector) Refresh(ctx context.Context, userID string) ([]StatusChange, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	owned, err := p.assets.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := p.now()
+	var changes []StatusChange
+	for _, a := range owned {
+		projections, err := p.project(ctx, a, now)
+		if err != nil {
+			return changes, err
+		}
+		if len(projections) == 0 {
+			continue
+		}
+
+		var assetChanges []StatusChange
+		dirty := false
+		for _, projection := range projections {
+			g := findGoal(a, projection.GoalID)
+			progress := g.Progress
+			previous, changed := g.Track(projection.GoalProjection)
+			dirty = dirty || changed || progress != g.Progress
+			if !changed {
+				continue
+			}
+			projection.Progress = g.Progress
+			assetChanges = append(assetChanges, StatusChange{
+				UserID:     userID,
+				AssetID:    a.ID,
+				GoalID:     g.ID,
+				Type:       g.Type,
+				From:       previous,
+				To:         g.Status,
+				Projection: projection,
+				ChangedAt:  now,
+			})
+		}
+		if !dirty {
+			continue
+		}
+		if err := p.assets.Update(ctx, a); err != nil {
+			return changes, err
+		}
+		for _, change := range assetChanges {
+			p.publish(ctx, change)
+		}
+		changes = append(changes, assetChanges...)
+	}
+	return changes, nil
+}
+
+func (p *Projector) project(ctx context.Context, a *asset.Asset, now time.Time) ([]Projection, error) {
+	var txs []*asset.Transaction
+	var projections []Projection
+	for _, g := range a.Goals {
+		// 금액이 없거나 자산과 통화가 다른 목표는 전망할 수 없으므로 건너뜁니다
+		if g.Type == asset.GoalTypeDebtFree || g.Target.Amount <= 0 || g.Target.Currency != a.Amount.Currency {
+			continue
+		}
+		if txs == nil {
+			found, err := p.contributions(ctx, a, now)
+			if err != nil {
+				return nil, err
+			}
+			txs = found
+		}
+		projection, err := g.Project(a.Amount, g.Contribution(a.ID, txs, now), now)
+		if err != nil {
+			return nil, err
+		}
+		projections = append(projections, Projection{
+			AssetID:        a.ID,
+			AssetName:      a.Name,
+			GoalID:         g.ID,
+			Type:           g.Type,
+			Deadline:       g.Deadline,
+			Progress:       g.Progress,
+			GoalProjection: projection,
+		})
+	}
+	return projections, nil
+}
+
+// contributions 자산의 거래와 목표를 만든 뒤 다른 자산에서 이 자산으로 들어온 이체를 조회합니다.
+// 입금 측 이체는 거래 자산으로 색인되지 않으므로 가장 오래된 목표부터 now까지의 거래에서 찾습니다.
+func (p *Projector) contributions(ctx context.Context, a *asset.Asset, now time.Time) ([]*asset.Transaction, error) {
+	found, err := p.transactions.FindByAssetID(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	txs := append([]*asset.Transaction{}, found...)
+
+	since := now
+	for _, g := range a.Goals {
+		if g.CreatedAt.Before(since) {
+			since = g.CreatedAt
+		}
+	}
+	ranged, err := p.transactions.FindByDateRange(ctx, since, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range ranged {
+		if tx.Type == asset.Transfer && tx.CounterAssetID == a.ID && tx.AssetID != a.ID {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+func (p *Projector) publish(ctx context.Context, change StatusChange) {
+	if p.bus == nil {
+		return
+	}
+	evt := event.NewEvent(event.TypeGoalStatusChanged, change.GoalID, "goal", change, map[string]string{
+		"userID":   change.UserID,
+		"assetID":  change.AssetID,
+		"goalType": string(change.Type),
+		"from":     string(change.From),
+		"to":       string(change.To),
+	}, 1)
+	if err := p.bus.Publish(ctx, evt); err != nil {
+		log.Printf("목표 상태 변경 이벤트 발행 실패(%s): %v", change.GoalID, err)
+	}
+}
+
+func findGoal(a *asset.Asset, id string) *asset.Goal {
+	for _, g := range a.Goals {
+		if g.ID == id {
+			return g
+		}
+	}
+	return nil
+}
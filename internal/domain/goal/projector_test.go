@@ -0,0 +1,171 @@
+package goal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/domain/gamification"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+var start = time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+type projectorFixture struct {
+	ctx          context.Context
+	assets       *asset.MemoryAssetRepository
+	transactions *asset.MemoryTransactionRepository
+	bus          event.Bus
+	projector    *Projector
+	savings      *asset.Asset
+	goal         *asset.Goal
+	now          time.Time
+}
+
+func newProjectorFixture(t *testing.T) *projectorFixture {
+	t.Helper()
+	f := &projectorFixture{
+		ctx:          context.Background(),
+		assets:       asset.NewMemoryAssetRepository(),
+		transactions: asset.NewMemoryTransactionRepository(),
+		bus:          memory.NewEventBus(),
+		now:          start,
+	}
+	f.projector = NewProjector(f.assets, f.transactions, f.bus, WithClock(func() time.Time { return f.now }))
+
+	savings, err := asset.NewAsset("user-1", asset.Cash, "적금", 0, "KRW")
+	require.NoError(t, err)
+	f.goal = savings.AddGoal(asset.GoalTypeSaving, asset.Money{Amount: 1200000, Currency: "KRW"}, start.AddDate(1, 0, 0))
+	f.goal.CreatedAt = start
+	savings.AddGoal(asset.GoalTypeDebtFree, asset.Money{Currency: "KRW"}, start.AddDate(1, 0, 0))
+	require.NoError(t, f.assets.Save(f.ctx, savings))
+	f.savings = savings
+	return f
+}
+
+func (f *projectorFixture) deposit(t *testing.T, amount float64, date time.Time) {
+	t.Helper()
+	tx, err := asset.NewTransaction(f.savings.ID, asset.Income, asset.Money{Amount: amount, Currency: "KRW"}, "저축", "")
+	require.NoError(t, err)
+	tx.Date = date
+	require.NoError(t, f.transactions.Save(f.ctx, tx))
+	saved, err := f.assets.FindByID(f.ctx, f.savings.ID)
+	require.NoError(t, err)
+	saved.Amount.Amount += amount
+	require.NoError(t, f.assets.Update(f.ctx, saved))
+}
+
+func Test_Projector_should_project_goals_from_contributions(t *testing.T) {
+	// Given
+	f := newProjectorFixture(t)
+	f.deposit(t, 100000, start.AddDate(0, 0, 1))
+	f.deposit(t, 100000, start.AddDate(0, 1, 1))
+	f.now = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	// When
+	projections, err := f.projector.Project(f.ctx, "user-1")
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, projections, 1)
+	projection := projections[0]
+	assert.Equal(t, f.goal.ID, projection.GoalID)
+	assert.Equal(t, 10, projection.MonthsLeft)
+	assert.Equal(t, 100000.0, projection.RequiredMonthly.Amount)
+	assert.InDelta(t, 200000/(60/(365.25/12)), projection.MonthlyContribution.Amount, 0.01)
+	assert.Equal(t, asset.GoalStatusOnTrack, projection.Status)
+	assert.Equal(t, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), *projection.ProjectedDate)
+
+	saved, err := f.assets.FindByID(f.ctx, f.savings.ID)
+	require.NoError(t, err)
+	assert.Empty(t, saved.Goals[0].Status)
+}
+
+func Test_Projector_should_count_transfers_into_goal_asset_as_contributions(t *testing.T) {
+	// Given
+	f := newProjectorFixture(t)
+	checking, err := asset.NewAsset("user-1", asset.Cash, "입출금", 1000000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, checking))
+	for _, date := range []time.Time{start.AddDate(0, 0, 1), start.AddDate(0, 1, 1)} {
+		tx, err := asset.NewTransaction(checking.ID, asset.Transfer, asset.Money{Amount: 100000, Currency: "KRW"}, "", "적금 이체")
+		require.NoError(t, err)
+		tx.CounterAssetID = f.savings.ID
+		tx.Date = date
+		require.NoError(t, f.transactions.Save(f.ctx, tx))
+	}
+	saved, err := f.assets.FindByID(f.ctx, f.savings.ID)
+	require.NoError(t, err)
+	saved.Amount.Amount = 200000
+	require.NoError(t, f.assets.Update(f.ctx, saved))
+	f.now = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	// When
+	projections, err := f.projector.Project(f.ctx, "user-1")
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, projections, 1)
+	assert.InDelta(t, 200000/(60/(365.25/12)), projections[0].MonthlyContribution.Amount, 0.01)
+	assert.Equal(t, asset.GoalStatusOnTrack, projections[0].Status)
+}
+
+func Test_Projector_should_publish_status_changes_for_rewards(t *testing.T) {
+	// Given
+	f := newProjectorFixture(t)
+	profiles := gamification.NewMemoryRepository()
+	require.NoError(t, f.bus.Subscribe(f.projector))
+	require.NoError(t, f.bus.Subscribe(gamification.NewGoalRewarder(profiles)))
+	var changes []StatusChange
+	require.NoError(t, f.bus.Subscribe(&changeRecorder{changes: &changes}))
+	f.deposit(t, 100000, start.AddDate(0, 0, 1))
+	f.now = start.AddDate(0, 0, 20)
+	amountChanged := event.NewEvent(event.TypeAssetAmountChanged, f.savings.ID, "asset", nil, map[string]string{"userID": "user-1"}, 1)
+
+	// When
+	require.NoError(t, f.bus.Publish(f.ctx, amountChanged))
+	require.NoError(t, f.bus.Publish(f.ctx, amountChanged))
+	f.deposit(t, 1100000, start.AddDate(0, 0, 25))
+	f.now = start.AddDate(0, 0, 26)
+	require.NoError(t, f.bus.Publish(f.ctx, amountChanged))
+
+	// Then
+	require.Len(t, changes, 2)
+	assert.Equal(t, asset.GoalStatus(""), changes[0].From)
+	assert.Equal(t, asset.GoalStatusOnTrack, changes[0].To)
+	assert.Equal(t, asset.GoalStatusOnTrack, changes[1].From)
+	assert.Equal(t, asset.GoalStatusAchieved, changes[1].To)
+
+	saved, err := f.assets.FindByID(f.ctx, f.savings.ID)
+	require.NoError(t, err)
+	assert.Equal(t, asset.GoalStatusAchieved, saved.Goals[0].Status)
+	assert.Equal(t, 100.0, saved.Goals[0].Progress)
+	assert.Empty(t, saved.Goals[1].Status)
+
+	profile, err := profiles.FindByUserID(f.ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, gamification.GoalAchievedExperience, profile.Experience)
+	assert.Equal(t, 1, profile.Stats.GoalsCompleted)
+	require.Len(t, profile.Badges, 1)
+	assert.Equal(t, gamification.BadgeTypeSaving, profile.Badges[0].Type)
+}
+
+type changeRecorder struct {
+	changes *[]StatusChange
+}
+
+func (r *changeRecorder) HandlerName() string {
+	return "change-recorder"
+}
+
+func (r *changeRecorder) HandleEvent(_ context.Context, evt event.Event) error {
+	if change, ok := evt.Payload().(StatusChange); ok {
+		*r.changes = append(*r.changes, change)
+	}
+	return nil
+}
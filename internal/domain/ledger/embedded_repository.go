@@ -0,0 +1,186 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	accountBucket = "ledger_accounts"
+	entryBucket   = "ledger_entries"
+
+	indexUserID    = "user_id"
+	indexAssetID   = "asset_id"
+	indexAccountID = "account_id"
+)
+
+// EmbeddedRepository 원장의 임베디드 키-값 저장소 구현체입니다.
+type EmbeddedRepository struct {
+	accounts *kv.Collection[*Account]
+	entries  *kv.Collection[*JournalEntry]
+}
+
+// NewEmbeddedRepository 사용자, 자산, 계정 인덱스를 가진 임베디드 원장 저장소를 생성합니다.
+func NewEmbeddedRepository(db *kv.DB) (*EmbeddedRepository, error) {
+	accounts := kv.NewCollection[*Account](db, accountBucket)
+	if err := accounts.Index(indexUserID, func(a *Account) []string { return []string{a.UserID} }); err != nil {
+		return nil, err
+	}
+	err := accounts.Index(indexAssetID, func(a *Account) []string {
+		if a.AssetID == "" {
+			return nil
+		}
+		return []string{a.AssetID}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := kv.NewCollection[*JournalEntry](db, entryBucket)
+	if err := entries.Index(indexUserID, func(e *JournalEntry) []string { return []string{e.UserID} }); err != nil {
+		return nil, err
+	}
+	err = entries.Index(indexAccountID, func(e *JournalEntry) []string {
+		ids := make([]string, 0, len(e.Postings))
+		for _, p := range e.Postings {
+			ids = append(ids, p.AccountID)
+		}
+		return ids
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EmbeddedRepository{accounts: accounts, entries: entries}, nil
+}
+
+// storageError 도메인 에러는 그대로 두고, 저장소 에러는 내부 에러로 감쌉니다.
+func storageError(err error) error {
+	var domainErr domain.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewError("ledger", domain.ErrCodeInternal, err.Error())
+}
+
+// SaveAccount 계정을 저장합니다.
+func (r *EmbeddedRepository) SaveAccount(ctx context.Context, account *Account) error {
+	return storageError(r.accounts.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.accounts.Exists(tx, account.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domain.NewError("ledger", domain.ErrCodeAlreadyExists, fmt.Sprintf("account with ID %s already exists", account.ID))
+		}
+		if account.AssetID != "" {
+			mapped, err := r.accounts.Lookup(tx, indexAssetID, account.AssetID)
+			if err != nil {
+				return err
+			}
+			if len(mapped) > 0 {
+				return domain.NewError("ledger", domain.ErrCodeAlreadyExists, fmt.Sprintf("account for asset %s already exists", account.AssetID))
+			}
+		}
+		return r.accounts.Put(tx, account.ID, account)
+	}))
+}
+
+// FindAccount ID로 계정을 조회합니다.
+func (r *EmbeddedRepository) FindAccount(ctx context.Context, id string) (*Account, error) {
+	var account *Account
+	err := r.accounts.View(ctx, func(tx *kv.Tx) error {
+		found, exists, err := r.accounts.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(fmt.Sprintf("account with ID %s not found", id))
+		}
+		account = found
+		return nil
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return account, nil
+}
+
+// FindAccountByAsset 자산에 대응하는 계정을 조회합니다.
+func (r *EmbeddedRepository) FindAccountByAsset(ctx context.Context, assetID string) (*Account, error) {
+	var account *Account
+	err := r.accounts.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.accounts.Lookup(tx, indexAssetID, assetID)
+		if err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return notFound(fmt.Sprintf("account for asset %s not found", assetID))
+		}
+		account = found[0]
+		return nil
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return account, nil
+}
+
+// FindAccountsByUser 사용자의 계정을 조회합니다.
+func (r *EmbeddedRepository) FindAccountsByUser(ctx context.Context, userID string) ([]*Account, error) {
+	var accounts []*Account
+	err := r.accounts.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.accounts.Lookup(tx, indexUserID, userID)
+		accounts = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].CreatedAt.Before(accounts[j].CreatedAt)
+	})
+	return accounts, nil
+}
+
+// AppendEntry 분개를 추가합니다.
+func (r *EmbeddedRepository) AppendEntry(ctx context.Context, entry *JournalEntry) error {
+	return storageError(r.entries.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.entries.Exists(tx, entry.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domain.NewError("ledger", domain.ErrCodeAlreadyExists, fmt.Sprintf("journal entry with ID %s already exists", entry.ID))
+		}
+		return r.entries.Put(tx, entry.ID, entry)
+	}))
+}
+
+// FindEntriesByUser 사용자의 분개를 발생 시각 순으로 조회합니다.
+func (r *EmbeddedRepository) FindEntriesByUser(ctx context.Context, userID string) ([]*JournalEntry, error) {
+	return r.findEntries(ctx, indexUserID, userID)
+}
+
+// FindEntriesByAccount 계정에 전기된 분개를 발생 시각 순으로 조회합니다.
+func (r *EmbeddedRepository) FindEntriesByAccount(ctx context.Context, accountID string) ([]*JournalEntry, error) {
+	return r.findEntries(ctx, indexAccountID, accountID)
+}
+
+func (r *EmbeddedRepository) findEntries(ctx context.Context, index, value string) ([]*JournalEntry, error) {
+	var entries []*JournalEntry
+	err := r.entries.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.entries.Lookup(tx, index, value)
+		entries = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortEntries(entries)
+	return entries, nil
+}
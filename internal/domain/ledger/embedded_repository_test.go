@@ -0,0 +1,73 @@
+package ledger
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EmbeddedRepository_should_persist_accounts_and_entries_across_restart(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data.kv")
+	db, err := kv.Open(path, nil)
+	require.NoError(t, err)
+	repo, err := NewEmbeddedRepository(db)
+	require.NoError(t, err)
+
+	ledger := NewLedger(repo)
+	cash, err := ledger.OpenAssetAccount(ctx, newAsset(t, "현금", 1000))
+	require.NoError(t, err)
+	food := mustAccount(t, "식비", AccountExpense)
+	require.NoError(t, repo.SaveAccount(ctx, food))
+	entry, err := ExpenseEntry(cash, food, krw(300), "점심", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, ledger.Post(ctx, entry))
+	require.NoError(t, db.Close())
+
+	// When
+	db, err = kv.Open(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	repo, err = NewEmbeddedRepository(db)
+	require.NoError(t, err)
+	found, err := repo.FindAccountByAsset(ctx, cash.AssetID)
+	require.NoError(t, err)
+	balance, err := NewLedger(repo).Balance(ctx, found.ID)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, krw(700), balance)
+	accounts, err := repo.FindAccountsByUser(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, accounts, 3)
+}
+
+func Test_EmbeddedRepository_should_reject_duplicate_entry_and_asset_account(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo, err := NewEmbeddedRepository(kv.OpenMemory())
+	require.NoError(t, err)
+	first, err := NewAssetAccount(newAsset(t, "현금", 0))
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveAccount(ctx, first))
+	second := *first
+	second.ID = "other"
+	food := mustAccount(t, "식비", AccountExpense)
+	entry, err := ExpenseEntry(first, food, krw(100), "점심", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.AppendEntry(ctx, entry))
+
+	// When
+	accountErr := repo.SaveAccount(ctx, &second)
+	entryErr := repo.AppendEntry(ctx, entry)
+
+	// Then
+	assert.Error(t, accountErr)
+	assert.Error(t, entryErr)
+}
@@ -0,0 +1,105 @@
+package ledger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// IncomeEntry 수익 계정에서 자산 계정으로 들어온 수입을 분개합니다.
+func IncomeEntry(to, source *Account, amount asset.Money, description string, occurredAt time.Time) (*JournalEntry, error) {
+	return NewJournalEntry(to.UserID, description, occurredAt,
+		DebitOf(to, amount),
+		CreditOf(source, amount),
+	)
+}
+
+// ExpenseEntry 자산 계정에서 비용 계정으로 나간 지출을 분개합니다.
+func ExpenseEntry(from, category *Account, amount asset.Money, description string, occurredAt time.Time) (*JournalEntry, error) {
+	return NewJournalEntry(from.UserID, description, occurredAt,
+		DebitOf(category, amount),
+		CreditOf(from, amount),
+	)
+}
+
+// TransferEntry 한 계정에서 다른 계정으로의 이체를 분개합니다.
+// 출금 계정은 대변, 입금 계정은 차변에 기록되어 이체 금액이 사라지지 않습니다.
+func TransferEntry(from, to *Account, amount asset.Money, description string, occurredAt time.Time) (*JournalEntry, error) {
+	if from.ID == to.ID {
+		return nil, invalid("같은 계정으로는 이체할 수 없습니다")
+	}
+	return NewJournalEntry(from.UserID, description, occurredAt,
+		DebitOf(to, amount),
+		CreditOf(from, amount),
+	)
+}
+
+// BuyEntry 현금 계정으로 증권 계정의 자산을 매수한 거래를 분개합니다.
+// fee가 0보다 크면 수수료 계정에 비용으로 기록하고 현금에서 함께 차감합니다.
+func BuyEntry(cash, security *Account, cost asset.Money, fees *Account, fee asset.Money, description string, occurredAt time.Time) (*JournalEntry, error) {
+	postings := []Posting{DebitOf(security, cost)}
+	paid := cost
+	if fee.IsPositive() {
+		if fees == nil {
+			return nil, invalid("수수료 계정이 필요합니다")
+		}
+		postings = append(postings, DebitOf(fees, fee))
+		total, err := cost.Add(fee)
+		if err != nil {
+			return nil, invalid(err.Error())
+		}
+		paid = total
+	}
+	postings = append(postings, CreditOf(cash, paid))
+	return NewJournalEntry(cash.UserID, description, occurredAt, postings...)
+}
+
+// SellEntry 증권 계정의 자산을 매도해 현금 계정으로 대금을 받은 거래를 분개합니다.
+// 매도 대금과 취득 원가의 차이는 손익 계정에 이익이면 대변, 손실이면 차변으로 기록합니다.
+func SellEntry(cash, security *Account, proceeds, costBasis asset.Money, gains *Account, description string, occurredAt time.Time) (*JournalEntry, error) {
+	if proceeds.Currency != costBasis.Currency {
+		return nil, invalid(fmt.Sprintf("통화가 일치하지 않습니다: %s != %s", proceeds.Currency, costBasis.Currency))
+	}
+	postings := []Posting{
+		DebitOf(cash, proceeds),
+		CreditOf(security, costBasis),
+	}
+	if diff := proceeds.Amount - costBasis.Amount; diff > balanceTolerance || diff < -balanceTolerance {
+		if gains == nil {
+			return nil, invalid("손익 계정이 필요합니다")
+		}
+		if diff > 0 {
+			postings = append(postings, CreditOf(gains, asset.Money{Amount: diff, Currency: proceeds.Currency}))
+		} else {
+			postings = append(postings, DebitOf(gains, asset.Money{Amount: -diff, Currency: proceeds.Currency}))
+		}
+	}
+	return NewJournalEntry(cash.UserID, description, occurredAt, postings...)
+}
+
+// TransactionEntry 자산 거래를 분개로 변환합니다.
+// 수입은 counter(수익 계정)에서, 지출은 counter(비용 계정)로, 이체는 counter(입금 자산 계정)로 기록합니다.
+func TransactionEntry(tx *asset.Transaction, account, counter *Account) (*JournalEntry, error) {
+	if counter == nil {
+		return nil, invalid("상대 계정이 필요합니다")
+	}
+
+	var entry *JournalEntry
+	var err error
+	switch tx.Type {
+	case asset.Income:
+		entry, err = IncomeEntry(account, counter, tx.Amount, tx.Description, tx.Date)
+	case asset.Expense:
+		entry, err = ExpenseEntry(account, counter, tx.Amount, tx.Description, tx.Date)
+	case asset.Transfer:
+		entry, err = TransferEntry(account, counter, tx.Amount, tx.Description, tx.Date)
+	default:
+		return nil, invalid(fmt.Sprintf("지원하지 않는 거래 유형입니다: %s", tx.Type))
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry.Reference = tx.ID
+	return entry, nil
+}
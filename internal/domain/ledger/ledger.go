@@ -0,0 +1,332 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// OpeningBalanceAccountName 자산 계정의 기초 잔액을 상대 기록하는 자본 계정 이름입니다.
+const OpeningBalanceAccountName = "기초 잔액"
+
+// 분류가 없는 수입과 지출을 기록하는 계정 이름입니다.
+const (
+	UncategorizedIncomeName  = "기타 수입"
+	UncategorizedExpenseName = "기타 지출"
+)
+
+// Ledger 분개를 검증해 전기하고, 전기 내역으로 잔액과 시산표를 계산합니다.
+type Ledger struct {
+	repo Repository
+}
+
+// NewLedger 새로운 원장을 생성합니다.
+func NewLedger(repo Repository) *Ledger {
+	return &Ledger{repo: repo}
+}
+
+// Post 분개를 검증한 뒤 전기합니다.
+// 모든 계정이 존재하고 분개 사용자의 계정이어야 하며, 전기 통화는 계정 통화와 같아야 합니다.
+func (l *Ledger) Post(ctx context.Context, entry *JournalEntry) error {
+	if err := l.validate(ctx, entry); err != nil {
+		return err
+	}
+	return l.repo.AppendEntry(ctx, entry)
+}
+
+func (l *Ledger) validate(ctx context.Context, entry *JournalEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	for _, p := range entry.Postings {
+		account, err := l.repo.FindAccount(ctx, p.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.UserID != entry.UserID {
+			return invalid(fmt.Sprintf("계정 %s는 사용자 %s의 계정이 아닙니다", account.ID, entry.UserID))
+		}
+		if account.Currency != p.Amount.Currency {
+			return invalid(fmt.Sprintf("계정 %s의 통화가 일치하지 않습니다: %s != %s", account.ID, p.Amount.Currency, account.Currency))
+		}
+	}
+	return nil
+}
+
+// Record 거래를 자산에 반영하고 균형 잡힌 분개로 전기합니다.
+// 자산 계정이 없으면 거래를 반영하기 전 금액을 기초 잔액으로 열고,
+// 수입과 지출은 거래 분류 이름의 수익·비용 계정을 상대 계정으로 씁니다.
+// 이체는 counter(입금 자산)에도 반영하므로 사용자의 자산 합계가 바뀌지 않습니다.
+// 자산은 바꾸기만 하므로 호출자가 같은 작업 단위 안에서 거래, owner, counter를 저장해야 합니다.
+// 계정과 분개의 쓰기도 ctx의 작업 단위에 참여하므로, 작업 단위가 실패하면 거래와 함께 버려집니다.
+func (l *Ledger) Record(ctx context.Context, tx *asset.Transaction, owner, counter *asset.Asset) (*JournalEntry, error) {
+	if tx.AssetID != owner.ID {
+		return nil, invalid(fmt.Sprintf("거래 자산이 일치하지 않습니다: %s != %s", tx.AssetID, owner.ID))
+	}
+	if tx.Type == asset.Transfer {
+		switch {
+		case counter == nil || tx.CounterAssetID != counter.ID:
+			return nil, invalid("이체에는 입금 자산이 필요합니다")
+		case counter.UserID != owner.UserID:
+			return nil, invalid(fmt.Sprintf("자산 %s는 사용자 %s의 자산이 아닙니다", counter.ID, owner.UserID))
+		}
+	}
+
+	account, err := l.OpenAssetAccount(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	var counterAccount *Account
+	switch tx.Type {
+	case asset.Transfer:
+		counterAccount, err = l.OpenAssetAccount(ctx, counter)
+	case asset.Income:
+		counterAccount, err = l.nominalAccount(ctx, owner.UserID, AccountIncome, categoryName(tx.Category, UncategorizedIncomeName), tx.Amount.Currency)
+	case asset.Expense:
+		counterAccount, err = l.nominalAccount(ctx, owner.UserID, AccountExpense, categoryName(tx.Category, UncategorizedExpenseName), tx.Amount.Currency)
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry, err := TransactionEntry(tx, account, counterAccount)
+	if err != nil {
+		return nil, err
+	}
+	// 자산을 바꾸기 전에 분개를 검증해 전기할 수 없는 거래가 자산에 반영되지 않게 합니다
+	if err := l.validate(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := owner.ProcessTransaction(tx); err != nil {
+		return nil, invalid(err.Error())
+	}
+	if tx.Type == asset.Transfer {
+		if err := counter.ReceiveTransfer(tx); err != nil {
+			return nil, invalid(err.Error())
+		}
+	}
+	if err := l.repo.AppendEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func categoryName(category, fallback string) string {
+	if category == "" {
+		return fallback
+	}
+	return category
+}
+
+// Reverse 분개를 역분개로 취소합니다. 원 분개는 그대로 남습니다.
+func (l *Ledger) Reverse(ctx context.Context, entry *JournalEntry, description string, occurredAt time.Time) (*JournalEntry, error) {
+	reversal := entry.Reverse(description, occurredAt)
+	if err := l.Post(ctx, reversal); err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+// OpenAssetAccount 자산에 대응하는 계정을 반환하고, 없으면 생성합니다.
+// 새로 만든 계정은 자산의 현재 금액을 기초 잔액 자본 계정과의 분개로 기록합니다.
+func (l *Ledger) OpenAssetAccount(ctx context.Context, a *asset.Asset) (*Account, error) {
+	existing, err := l.repo.FindAccountByAsset(ctx, a.ID)
+	if err == nil {
+		return existing, nil
+	}
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	account, err := NewAssetAccount(a)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.repo.SaveAccount(ctx, account); err != nil {
+		return nil, err
+	}
+	if !a.Amount.IsPositive() {
+		return account, nil
+	}
+
+	equity, err := l.openingBalanceAccount(ctx, a.UserID, a.Amount.Currency)
+	if err != nil {
+		return nil, err
+	}
+	// 부채 계정의 기초 잔액은 대변에 기록합니다
+	opening := []Posting{DebitOf(account, a.Amount), CreditOf(equity, a.Amount)}
+	if account.Type == AccountLiability {
+		opening = []Posting{DebitOf(equity, a.Amount), CreditOf(account, a.Amount)}
+	}
+	entry, err := NewJournalEntry(a.UserID, fmt.Sprintf("%s 기초 잔액", a.Name), a.CreatedAt, opening...)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.Post(ctx, entry); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// openingBalanceAccount 사용자의 통화별 기초 잔액 자본 계정을 반환하고, 없으면 생성합니다.
+func (l *Ledger) openingBalanceAccount(ctx context.Context, userID, currency string) (*Account, error) {
+	return l.nominalAccount(ctx, userID, AccountEquity, OpeningBalanceAccountName, currency)
+}
+
+// nominalAccount 자산에 대응하지 않는 사용자의 계정을 종류, 이름, 통화로 찾고, 없으면 생성합니다.
+func (l *Ledger) nominalAccount(ctx context.Context, userID string, accountType AccountType, name, currency string) (*Account, error) {
+	accounts, err := l.repo.FindAccountsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		if account.AssetID == "" && account.Type == accountType && account.Name == name && account.Currency == currency {
+			return account, nil
+		}
+	}
+	account, err := NewAccount(userID, name, accountType, currency)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.repo.SaveAccount(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Balance 계정의 정상 잔액 방향 기준 잔액을 전기 내역으로 계산합니다.
+// 잔액이 정상 방향의 반대이면 음수입니다.
+func (l *Ledger) Balance(ctx context.Context, accountID string) (asset.Money, error) {
+	account, err := l.repo.FindAccount(ctx, accountID)
+	if err != nil {
+		return asset.Money{}, err
+	}
+	entries, err := l.repo.FindEntriesByAccount(ctx, accountID)
+	if err != nil {
+		return asset.Money{}, err
+	}
+	return balanceOf(account, entries), nil
+}
+
+func balanceOf(account *Account, entries []*JournalEntry) asset.Money {
+	normal := account.Type.NormalSide()
+	balance := asset.Money{Currency: account.Currency}
+	for _, entry := range entries {
+		for _, p := range entry.Postings {
+			if p.AccountID == account.ID {
+				balance.Amount += p.signed(normal)
+			}
+		}
+	}
+	return balance
+}
+
+// TrialBalance 사용자의 시산표를 작성합니다.
+func (l *Ledger) TrialBalance(ctx context.Context, userID string) (TrialBalance, error) {
+	accounts, err := l.repo.FindAccountsByUser(ctx, userID)
+	if err != nil {
+		return TrialBalance{}, err
+	}
+	entries, err := l.repo.FindEntriesByUser(ctx, userID)
+	if err != nil {
+		return TrialBalance{}, err
+	}
+	return NewTrialBalance(accounts, entries), nil
+}
+
+// AssetBalances 자산 계정의 잔액을 자산 ID별로 반환합니다.
+func (l *Ledger) AssetBalances(ctx context.Context, userID string) (map[string]asset.Money, error) {
+	accounts, err := l.repo.FindAccountsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := l.repo.FindEntriesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[string]asset.Money)
+	for _, account := range accounts {
+		if account.AssetID != "" {
+			balances[account.AssetID] = balanceOf(account, entries)
+		}
+	}
+	return balances, nil
+}
+
+// Discrepancy 자산에 기록된 금액과 원장에서 도출한 잔액의 차이입니다.
+type Discrepancy struct {
+	AssetID  string
+	Recorded asset.Money
+	Derived  asset.Money
+}
+
+// Check 사용자 원장의 일관성을 검사합니다.
+// 모든 분개가 균형을 이루는지, 시산표의 차변과 대변이 같은지 확인한 뒤
+// 계정이 있는 자산의 금액을 원장 잔액과 비교해 차이를 반환합니다.
+// 시장 가격으로 평가되는 보유 포지션이 있는 자산은 금액이 원장 잔액과 다르므로 비교하지 않습니다.
+func (l *Ledger) Check(ctx context.Context, userID string, assets []*asset.Asset) ([]Discrepancy, error) {
+	entries, err := l.repo.FindEntriesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if err := entry.Validate(); err != nil {
+			return nil, domain.NewError("ledger", domain.ErrCodeInternal, fmt.Sprintf("분개 %s가 유효하지 않습니다: %v", entry.ID, err))
+		}
+	}
+
+	accounts, err := l.repo.FindAccountsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !NewTrialBalance(accounts, entries).IsBalanced() {
+		return nil, domain.NewError("ledger", domain.ErrCodeInternal, "시산표의 차변과 대변 합계가 일치하지 않습니다")
+	}
+
+	byAsset := make(map[string]*Account)
+	for _, account := range accounts {
+		if account.AssetID != "" {
+			byAsset[account.AssetID] = account
+		}
+	}
+	discrepancies := make([]Discrepancy, 0)
+	for _, a := range assets {
+		account, ok := byAsset[a.ID]
+		if !ok || a.Holding != nil {
+			continue
+		}
+		derived := balanceOf(account, entries)
+		if a.Amount.Currency != derived.Currency || math.Abs(a.Amount.Amount-derived.Amount) > balanceTolerance {
+			discrepancies = append(discrepancies, Discrepancy{AssetID: a.ID, Recorded: a.Amount, Derived: derived})
+		}
+	}
+	return discrepancies, nil
+}
+
+// SyncAssets 계정이 있는 자산의 금액을 원장 잔액으로 맞추고, 변경한 자산 수를 반환합니다.
+func (l *Ledger) SyncAssets(ctx context.Context, userID string, assets asset.Repository) (int, error) {
+	owned, err := assets.FindByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	discrepancies, err := l.Check(ctx, userID, owned)
+	if err != nil {
+		return 0, err
+	}
+	for i, d := range discrepancies {
+		if err := assets.UpdateAmount(ctx, d.AssetID, d.Derived); err != nil {
+			return i, err
+		}
+	}
+	return len(discrepancies), nil
+}
+
+func isNotFound(err error) bool {
+	var domainErr domain.Error
+	return errors.As(err, &domainErr) && domainErr.Code() == domain.ErrCodeNotFound
+}
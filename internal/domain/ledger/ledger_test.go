@@ -0,0 +1,266 @@
+package ledger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAsset(t *testing.T, name string, amount float64) *asset.Asset {
+	a, err := asset.NewAsset("user-1", asset.Cash, name, amount, "KRW")
+	require.NoError(t, err)
+	return a
+}
+
+func Test_Ledger_should_keep_transferred_money_in_destination_asset(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ledger := NewLedger(NewMemoryRepository())
+	checking := newAsset(t, "입출금", 1000)
+	savings := newAsset(t, "적금", 0)
+	from, err := ledger.OpenAssetAccount(ctx, checking)
+	require.NoError(t, err)
+	to, err := ledger.OpenAssetAccount(ctx, savings)
+	require.NoError(t, err)
+
+	tx, err := asset.NewTransaction(checking.ID, asset.Transfer, krw(400), "저축", "적금 이체")
+	require.NoError(t, err)
+	entry, err := TransactionEntry(tx, from, to)
+	require.NoError(t, err)
+
+	// When
+	err = ledger.Post(ctx, entry)
+
+	// Then
+	require.NoError(t, err)
+	balances, err := ledger.AssetBalances(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, krw(600), balances[checking.ID])
+	assert.Equal(t, krw(400), balances[savings.ID])
+	assert.Equal(t, tx.ID, entry.Reference)
+}
+
+func Test_Ledger_should_reject_posting_to_account_with_different_currency(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	ledger := NewLedger(repo)
+	cash, err := ledger.OpenAssetAccount(ctx, newAsset(t, "현금", 0))
+	require.NoError(t, err)
+	salary, err := NewAccount("user-1", "급여", AccountIncome, "USD")
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveAccount(ctx, salary))
+	entry, err := NewJournalEntry("user-1", "급여", time.Now(),
+		DebitOf(cash, krw(1000)),
+		CreditOf(salary, krw(1000)),
+	)
+	require.NoError(t, err)
+
+	// When
+	err = ledger.Post(ctx, entry)
+
+	// Then
+	requireInvalidArgument(t, err)
+	entries, err := repo.FindEntriesByUser(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func Test_Ledger_should_keep_original_entry_when_reversed(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	ledger := NewLedger(repo)
+	cash, err := ledger.OpenAssetAccount(ctx, newAsset(t, "현금", 1000))
+	require.NoError(t, err)
+	food := mustAccount(t, "식비", AccountExpense)
+	require.NoError(t, repo.SaveAccount(ctx, food))
+	entry, err := ExpenseEntry(cash, food, krw(300), "점심", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, ledger.Post(ctx, entry))
+
+	// When
+	_, err = ledger.Reverse(ctx, entry, "점심 취소", time.Now())
+
+	// Then
+	require.NoError(t, err)
+	balance, err := ledger.Balance(ctx, cash.ID)
+	require.NoError(t, err)
+	assert.Equal(t, krw(1000), balance)
+	entries, err := repo.FindEntriesByAccount(ctx, food.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func Test_Ledger_should_report_and_sync_assets_that_differ_from_postings(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ledger := NewLedger(NewMemoryRepository())
+	assets := asset.NewMemoryAssetRepository()
+	checking := newAsset(t, "입출금", 1000)
+	savings := newAsset(t, "적금", 0)
+	require.NoError(t, assets.Save(ctx, checking))
+	require.NoError(t, assets.Save(ctx, savings))
+	from, err := ledger.OpenAssetAccount(ctx, checking)
+	require.NoError(t, err)
+	to, err := ledger.OpenAssetAccount(ctx, savings)
+	require.NoError(t, err)
+	entry, err := TransferEntry(from, to, krw(250), "이체", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, ledger.Post(ctx, entry))
+
+	// When
+	discrepancies, checkErr := ledger.Check(ctx, "user-1", []*asset.Asset{checking, savings})
+	synced, syncErr := ledger.SyncAssets(ctx, "user-1", assets)
+
+	// Then
+	require.NoError(t, checkErr)
+	assert.Len(t, discrepancies, 2)
+	require.NoError(t, syncErr)
+	assert.Equal(t, 2, synced)
+	updated, err := assets.FindByID(ctx, savings.ID)
+	require.NoError(t, err)
+	assert.Equal(t, krw(250), updated.Amount)
+	tb, err := ledger.TrialBalance(ctx, "user-1")
+	require.NoError(t, err)
+	assert.True(t, tb.IsBalanced())
+}
+
+func Test_Ledger_should_reduce_liability_balance_when_paying_from_asset(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ledger := NewLedger(NewMemoryRepository())
+	checking := newAsset(t, "입출금", 1000)
+	card, err := asset.NewAsset("user-1", asset.CreditCard, "신용카드", 700, "KRW")
+	require.NoError(t, err)
+	from, err := ledger.OpenAssetAccount(ctx, checking)
+	require.NoError(t, err)
+	to, err := ledger.OpenAssetAccount(ctx, card)
+	require.NoError(t, err)
+
+	tx, err := asset.NewTransaction(checking.ID, asset.Transfer, krw(300), "카드대금", "카드 결제")
+	require.NoError(t, err)
+	entry, err := TransactionEntry(tx, from, to)
+	require.NoError(t, err)
+
+	// When
+	err = ledger.Post(ctx, entry)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, AccountLiability, to.Type)
+	balances, err := ledger.AssetBalances(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, krw(700), balances[checking.ID])
+	assert.Equal(t, krw(400), balances[card.ID])
+	trial, err := ledger.TrialBalance(ctx, "user-1")
+	require.NoError(t, err)
+	assert.True(t, trial.IsBalanced())
+}
+
+func Test_Ledger_Record_should_move_transfer_between_assets_and_match_postings(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ledger := NewLedger(NewMemoryRepository())
+	checking := newAsset(t, "입출금", 1000)
+	savings := newAsset(t, "적금", 200)
+	tx, err := asset.NewTransaction(checking.ID, asset.Transfer, krw(400), "저축", "적금 이체")
+	require.NoError(t, err)
+	tx.CounterAssetID = savings.ID
+	salary, err := asset.NewTransaction(checking.ID, asset.Income, krw(300), "급여", "월급")
+	require.NoError(t, err)
+
+	// When
+	_, err = ledger.Record(ctx, tx, checking, savings)
+	require.NoError(t, err)
+	_, err = ledger.Record(ctx, salary, checking, nil)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, krw(900), checking.Amount)
+	assert.Equal(t, krw(600), savings.Amount)
+	discrepancies, err := ledger.Check(ctx, "user-1", []*asset.Asset{checking, savings})
+	require.NoError(t, err)
+	assert.Empty(t, discrepancies)
+	trial, err := ledger.TrialBalance(ctx, "user-1")
+	require.NoError(t, err)
+	assert.True(t, trial.IsBalanced())
+}
+
+func Test_Ledger_Record_should_leave_assets_untouched_when_entry_cannot_be_posted(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ledger := NewLedger(NewMemoryRepository())
+	checking := newAsset(t, "입출금", 1000)
+	dollars, err := asset.NewAsset("user-1", asset.Cash, "달러 예금", 100, "USD")
+	require.NoError(t, err)
+	tx, err := asset.NewTransaction(checking.ID, asset.Transfer, krw(400), "환전", "달러 이체")
+	require.NoError(t, err)
+	tx.CounterAssetID = dollars.ID
+
+	// When
+	_, err = ledger.Record(ctx, tx, checking, dollars)
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, krw(1000), checking.Amount)
+	assert.Equal(t, 100.0, dollars.Amount.Amount)
+}
+
+func Test_Ledger_Record_should_discard_accounts_and_entries_when_unit_of_work_fails(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	ledger := NewLedger(repo)
+	assets := asset.NewMemoryAssetRepository()
+	checking := newAsset(t, "입출금", 1000)
+	require.NoError(t, assets.Save(ctx, checking))
+	tx, err := asset.NewTransaction(checking.ID, asset.Expense, krw(300), "식비", "점심")
+	require.NoError(t, err)
+
+	// When
+	err = assets.WithTransaction(ctx, func(ctx context.Context) error {
+		owner, err := assets.FindByID(ctx, checking.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := ledger.Record(ctx, tx, owner, nil); err != nil {
+			return err
+		}
+		// 자산을 저장하기 전에 다른 요청이 먼저 자산을 바꿔 커밋이 실패합니다
+		stale := owner.Clone()
+		stale.Version--
+		return assets.Update(ctx, stale)
+	})
+
+	// Then
+	require.Error(t, err)
+	accounts, err := repo.FindAccountsByUser(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, accounts)
+	entries, err := repo.FindEntriesByUser(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// 같은 거래를 다시 기록해도 분개는 한 번만 남습니다
+	err = assets.WithTransaction(ctx, func(ctx context.Context) error {
+		owner, err := assets.FindByID(ctx, checking.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := ledger.Record(ctx, tx, owner, nil); err != nil {
+			return err
+		}
+		return assets.Update(ctx, owner)
+	})
+	require.NoError(t, err)
+	account, err := repo.FindAccountByAsset(ctx, checking.ID)
+	require.NoError(t, err)
+	entries, err = repo.FindEntriesByAccount(ctx, account.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2) // 기초 잔액 + 지출
+}
@@ -0,0 +1,135 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// MemoryRepository 원장의 인메모리 저장소 구현체입니다.
+// 계정과 분개는 자산의 인메모리 저장소에 보관하므로, 자산 저장소의 WithTransaction 안에서의 쓰기는
+// 자산·거래의 쓰기와 같은 작업 단위에 스테이징되었다가 함께 커밋되거나 버려집니다.
+type MemoryRepository struct {
+	accounts *asset.MemoryRepository[*Account]
+	entries  *asset.MemoryRepository[*JournalEntry]
+}
+
+// NewMemoryRepository 새로운 인메모리 원장 저장소를 생성합니다.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		accounts: asset.NewMemoryRepository[*Account](),
+		entries:  asset.NewMemoryRepository[*JournalEntry](),
+	}
+}
+
+// SaveAccount 계정을 저장합니다.
+func (r *MemoryRepository) SaveAccount(ctx context.Context, account *Account) error {
+	if _, err := r.accounts.FindByID(ctx, account.ID); err == nil {
+		return domain.NewError("ledger", domain.ErrCodeAlreadyExists, fmt.Sprintf("account with ID %s already exists", account.ID))
+	}
+	if account.AssetID != "" {
+		if _, err := r.FindAccountByAsset(ctx, account.AssetID); err == nil {
+			return domain.NewError("ledger", domain.ErrCodeAlreadyExists, fmt.Sprintf("account for asset %s already exists", account.AssetID))
+		}
+	}
+	return storageError(r.accounts.Save(ctx, account))
+}
+
+// FindAccount ID로 계정을 조회합니다.
+func (r *MemoryRepository) FindAccount(ctx context.Context, id string) (*Account, error) {
+	account, err := r.accounts.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(fmt.Sprintf("account with ID %s not found", id))
+	}
+	return account, nil
+}
+
+// FindAccountByAsset 자산에 대응하는 계정을 조회합니다.
+func (r *MemoryRepository) FindAccountByAsset(ctx context.Context, assetID string) (*Account, error) {
+	accounts, err := r.findAccounts(ctx, func(a *Account) bool { return a.AssetID == assetID })
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, notFound(fmt.Sprintf("account for asset %s not found", assetID))
+	}
+	return accounts[0], nil
+}
+
+// FindAccountsByUser 사용자의 계정을 조회합니다.
+func (r *MemoryRepository) FindAccountsByUser(ctx context.Context, userID string) ([]*Account, error) {
+	return r.findAccounts(ctx, func(a *Account) bool { return a.UserID == userID })
+}
+
+func (r *MemoryRepository) findAccounts(ctx context.Context, match func(a *Account) bool) ([]*Account, error) {
+	all, err := r.accounts.FindAll(ctx, nil)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	accounts := make([]*Account, 0)
+	for _, account := range all {
+		if match(account) {
+			accounts = append(accounts, account)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].CreatedAt.Before(accounts[j].CreatedAt)
+	})
+	return accounts, nil
+}
+
+// AppendEntry 분개를 추가합니다.
+func (r *MemoryRepository) AppendEntry(ctx context.Context, entry *JournalEntry) error {
+	if _, err := r.entries.FindByID(ctx, entry.ID); err == nil {
+		return domain.NewError("ledger", domain.ErrCodeAlreadyExists, fmt.Sprintf("journal entry with ID %s already exists", entry.ID))
+	}
+	return storageError(r.entries.Save(ctx, entry))
+}
+
+// FindEntriesByUser 사용자의 분개를 발생 시각 순으로 조회합니다.
+func (r *MemoryRepository) FindEntriesByUser(ctx context.Context, userID string) ([]*JournalEntry, error) {
+	return r.findEntries(ctx, func(e *JournalEntry) bool { return e.UserID == userID })
+}
+
+// FindEntriesByAccount 계정에 전기된 분개를 발생 시각 순으로 조회합니다.
+func (r *MemoryRepository) FindEntriesByAccount(ctx context.Context, accountID string) ([]*JournalEntry, error) {
+	return r.findEntries(ctx, func(e *JournalEntry) bool { return touches(e, accountID) })
+}
+
+func (r *MemoryRepository) findEntries(ctx context.Context, match func(e *JournalEntry) bool) ([]*JournalEntry, error) {
+	all, err := r.entries.FindAll(ctx, nil)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	entries := make([]*JournalEntry, 0)
+	for _, entry := range all {
+		if match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sortEntries(entries)
+	return entries, nil
+}
+
+// touches 분개에 계정의 전기가 있는지 확인합니다.
+func touches(entry *JournalEntry, accountID string) bool {
+	for _, p := range entry.Postings {
+		if p.AccountID == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// sortEntries 분개를 발생 시각 순으로 정렬합니다. 같은 시각이면 기록 시각 순입니다.
+func sortEntries(entries []*JournalEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].OccurredAt.Equal(entries[j].OccurredAt) {
+			return entries[i].OccurredAt.Before(entries[j].OccurredAt)
+		}
+		return entries[i].RecordedAt.Before(entries[j].RecordedAt)
+	})
+}
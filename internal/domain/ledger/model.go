@@ -0,0 +1,349 @@
+// Package ledger 자산 간 현금 이동을 복식부기 분개로 기록하고, 자산 잔액을 전기 내역에서 도출합니다.
+package ledger
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/google/uuid"
+)
+
+// balanceTolerance 차변과 대변 합계를 비교할 때 허용하는 부동소수점 오차입니다.
+const balanceTolerance = 1e-6
+
+// AccountType 계정의 종류입니다.
+type AccountType string
+
+const (
+	AccountAsset     AccountType = "ASSET"
+	AccountLiability AccountType = "LIABILITY"
+	AccountEquity    AccountType = "EQUITY"
+	AccountIncome    AccountType = "INCOME"
+	AccountExpense   AccountType = "EXPENSE"
+)
+
+// IsValid 지원하는 계정 종류인지 확인합니다.
+func (t AccountType) IsValid() bool {
+	switch t {
+	case AccountAsset, AccountLiability, AccountEquity, AccountIncome, AccountExpense:
+		return true
+	}
+	return false
+}
+
+// NormalSide 계정 잔액이 증가하는 쪽을 반환합니다.
+// 자산과 비용 계정은 차변, 부채·자본·수익 계정은 대변입니다.
+func (t AccountType) NormalSide() Side {
+	if t == AccountAsset || t == AccountExpense {
+		return Debit
+	}
+	return Credit
+}
+
+// Side 전기의 차변/대변입니다.
+type Side string
+
+const (
+	Debit  Side = "DEBIT"
+	Credit Side = "CREDIT"
+)
+
+// Opposite 반대쪽을 반환합니다.
+func (s Side) Opposite() Side {
+	if s == Debit {
+		return Credit
+	}
+	return Debit
+}
+
+// Account 원장의 계정입니다.
+// AssetID가 있으면 해당 자산의 잔액이 이 계정의 전기 내역에서 도출됩니다.
+type Account struct {
+	ID        string
+	UserID    string
+	Name      string
+	Type      AccountType
+	AssetID   string
+	Currency  string
+	CreatedAt time.Time
+}
+
+// NewAccount 새로운 계정을 생성합니다.
+func NewAccount(userID string, name string, accountType AccountType, currency string) (*Account, error) {
+	if userID == "" {
+		return nil, invalid("사용자 ID는 비어있을 수 없습니다")
+	}
+	if name == "" {
+		return nil, invalid("계정 이름은 비어있을 수 없습니다")
+	}
+	if !accountType.IsValid() {
+		return nil, invalid(fmt.Sprintf("지원하지 않는 계정 종류입니다: %s", accountType))
+	}
+	if currency == "" {
+		return nil, invalid("통화는 비어있을 수 없습니다")
+	}
+	return &Account{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Type:      accountType,
+		Currency:  currency,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// NewAssetAccount 자산에 대응하는 계정을 생성합니다. 부채 자산은 부채 계정이 됩니다.
+func NewAssetAccount(a *asset.Asset) (*Account, error) {
+	accountType := AccountAsset
+	if a.Type.IsLiability() {
+		accountType = AccountLiability
+	}
+	account, err := NewAccount(a.UserID, a.Name, accountType, a.Amount.Currency)
+	if err != nil {
+		return nil, err
+	}
+	account.AssetID = a.ID
+	return account, nil
+}
+
+// GetID 계정의 ID를 반환합니다.
+func (a *Account) GetID() string {
+	return a.ID
+}
+
+// GetCreatedAt 계정의 생성 시각을 반환합니다.
+func (a *Account) GetCreatedAt() time.Time {
+	return a.CreatedAt
+}
+
+// GetUpdatedAt 계정은 수정하지 않으므로 생성 시각을 반환합니다.
+func (a *Account) GetUpdatedAt() time.Time {
+	return a.CreatedAt
+}
+
+// Clone 계정의 복사본을 생성합니다.
+func (a *Account) Clone() *Account {
+	clone := *a
+	return &clone
+}
+
+// Posting 분개의 한 줄입니다. Amount는 항상 양수이며 방향은 Side로 나타냅니다.
+type Posting struct {
+	AccountID string
+	Side      Side
+	Amount    asset.Money
+}
+
+// DebitOf 계정의 차변 전기를 생성합니다.
+func DebitOf(account *Account, amount asset.Money) Posting {
+	return Posting{AccountID: account.ID, Side: Debit, Amount: amount}
+}
+
+// CreditOf 계정의 대변 전기를 생성합니다.
+func CreditOf(account *Account, amount asset.Money) Posting {
+	return Posting{AccountID: account.ID, Side: Credit, Amount: amount}
+}
+
+// signed 계정의 정상 잔액 방향 기준으로 부호를 붙인 금액을 반환합니다.
+func (p Posting) signed(normal Side) float64 {
+	if p.Side == normal {
+		return p.Amount.Amount
+	}
+	return -p.Amount.Amount
+}
+
+// JournalEntry 차변과 대변의 합계가 같은 분개입니다.
+// 기록된 분개는 수정하지 않으며, 정정은 역분개로 합니다.
+type JournalEntry struct {
+	ID          string
+	UserID      string
+	Description string
+	Reference   string // 분개의 원천 거래 ID
+	ReversalOf  string // 역분개이면 원 분개의 ID
+	Postings    []Posting
+	OccurredAt  time.Time
+	RecordedAt  time.Time
+}
+
+// NewJournalEntry 새로운 분개를 생성합니다. 통화별 차변과 대변 합계가 같아야 합니다.
+func NewJournalEntry(userID string, description string, occurredAt time.Time, postings ...Posting) (*JournalEntry, error) {
+	entry := &JournalEntry{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Description: description,
+		Postings:    append([]Posting(nil), postings...),
+		OccurredAt:  occurredAt,
+		RecordedAt:  time.Now(),
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Validate 분개가 유효하고 균형을 이루는지 검증합니다.
+func (e *JournalEntry) Validate() error {
+	if e.UserID == "" {
+		return invalid("사용자 ID는 비어있을 수 없습니다")
+	}
+	if len(e.Postings) < 2 {
+		return invalid("분개에는 두 개 이상의 전기가 필요합니다")
+	}
+	totals := make(map[string]float64)
+	for _, p := range e.Postings {
+		if p.AccountID == "" {
+			return invalid("전기의 계정 ID는 비어있을 수 없습니다")
+		}
+		if p.Side != Debit && p.Side != Credit {
+			return invalid(fmt.Sprintf("지원하지 않는 전기 방향입니다: %s", p.Side))
+		}
+		if !p.Amount.IsPositive() {
+			return invalid("전기 금액은 양수여야 합니다")
+		}
+		if p.Amount.Currency == "" {
+			return invalid("통화는 비어있을 수 없습니다")
+		}
+		totals[p.Amount.Currency] += p.signed(Debit)
+	}
+	for currency, diff := range totals {
+		if math.Abs(diff) > balanceTolerance {
+			return invalid(fmt.Sprintf("차변과 대변의 합계가 일치하지 않습니다: %s %f", currency, diff))
+		}
+	}
+	return nil
+}
+
+// Reverse 원 분개의 차변과 대변을 바꾼 역분개를 생성합니다.
+func (e *JournalEntry) Reverse(description string, occurredAt time.Time) *JournalEntry {
+	postings := make([]Posting, len(e.Postings))
+	for i, p := range e.Postings {
+		postings[i] = Posting{AccountID: p.AccountID, Side: p.Side.Opposite(), Amount: p.Amount}
+	}
+	return &JournalEntry{
+		ID:          uuid.New().String(),
+		UserID:      e.UserID,
+		Description: description,
+		Reference:   e.Reference,
+		ReversalOf:  e.ID,
+		Postings:    postings,
+		OccurredAt:  occurredAt,
+		RecordedAt:  time.Now(),
+	}
+}
+
+// GetID 분개의 ID를 반환합니다.
+func (e *JournalEntry) GetID() string {
+	return e.ID
+}
+
+// GetCreatedAt 분개의 기록 시각을 반환합니다.
+func (e *JournalEntry) GetCreatedAt() time.Time {
+	return e.RecordedAt
+}
+
+// GetUpdatedAt 분개는 수정하지 않으므로 기록 시각을 반환합니다.
+func (e *JournalEntry) GetUpdatedAt() time.Time {
+	return e.RecordedAt
+}
+
+// Clone 분개의 복사본을 생성합니다.
+func (e *JournalEntry) Clone() *JournalEntry {
+	clone := *e
+	clone.Postings = append([]Posting(nil), e.Postings...)
+	return &clone
+}
+
+// TrialBalanceLine 시산표의 계정별 합계입니다.
+// Balance는 계정의 정상 잔액 방향 기준 잔액입니다.
+type TrialBalanceLine struct {
+	AccountID string
+	Name      string
+	Type      AccountType
+	Currency  string
+	Debits    float64
+	Credits   float64
+	Balance   float64
+}
+
+// TrialBalance 계정별 차변/대변 합계와 통화별 총계입니다.
+type TrialBalance struct {
+	Lines        []TrialBalanceLine
+	TotalDebits  map[string]float64
+	TotalCredits map[string]float64
+}
+
+// NewTrialBalance 계정과 분개로 시산표를 작성합니다. 계정은 이름 순으로 정렬됩니다.
+func NewTrialBalance(accounts []*Account, entries []*JournalEntry) TrialBalance {
+	lines := make(map[string]*TrialBalanceLine, len(accounts))
+	for _, account := range accounts {
+		lines[account.ID] = &TrialBalanceLine{
+			AccountID: account.ID,
+			Name:      account.Name,
+			Type:      account.Type,
+			Currency:  account.Currency,
+		}
+	}
+
+	tb := TrialBalance{
+		TotalDebits:  make(map[string]float64),
+		TotalCredits: make(map[string]float64),
+	}
+	for _, entry := range entries {
+		for _, p := range entry.Postings {
+			line, ok := lines[p.AccountID]
+			if !ok {
+				line = &TrialBalanceLine{AccountID: p.AccountID, Currency: p.Amount.Currency}
+				lines[p.AccountID] = line
+			}
+			if p.Side == Debit {
+				line.Debits += p.Amount.Amount
+				tb.TotalDebits[p.Amount.Currency] += p.Amount.Amount
+			} else {
+				line.Credits += p.Amount.Amount
+				tb.TotalCredits[p.Amount.Currency] += p.Amount.Amount
+			}
+		}
+	}
+
+	for _, line := range lines {
+		line.Balance = line.Debits - line.Credits
+		if line.Type.NormalSide() == Credit {
+			line.Balance = -line.Balance
+		}
+		tb.Lines = append(tb.Lines, *line)
+	}
+	sort.Slice(tb.Lines, func(i, j int) bool {
+		if tb.Lines[i].Name != tb.Lines[j].Name {
+			return tb.Lines[i].Name < tb.Lines[j].Name
+		}
+		return tb.Lines[i].AccountID < tb.Lines[j].AccountID
+	})
+	return tb
+}
+
+// IsBalanced 모든 통화에서 차변 총계와 대변 총계가 같은지 확인합니다.
+func (tb TrialBalance) IsBalanced() bool {
+	for currency, debits := range tb.TotalDebits {
+		if math.Abs(debits-tb.TotalCredits[currency]) > balanceTolerance {
+			return false
+		}
+	}
+	for currency, credits := range tb.TotalCredits {
+		if math.Abs(credits-tb.TotalDebits[currency]) > balanceTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func invalid(msg string) error {
+	return domain.NewError("ledger", domain.ErrCodeInvalidArgument, msg)
+}
+
+func notFound(msg string) error {
+	return domain.NewError("ledger", domain.ErrCodeNotFound, msg)
+}
@@ -0,0 +1,148 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func krw(amount float64) asset.Money {
+	return asset.Money{Amount: amount, Currency: "KRW"}
+}
+
+func mustAccount(t *testing.T, name string, accountType AccountType) *Account {
+	account, err := NewAccount("user-1", name, accountType, "KRW")
+	require.NoError(t, err)
+	return account
+}
+
+func requireInvalidArgument(t *testing.T, err error) {
+	require.Error(t, err)
+	domainErr, ok := err.(domain.Error)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCodeInvalidArgument, domainErr.Code())
+}
+
+func Test_NewJournalEntry_should_reject_unbalanced_postings(t *testing.T) {
+	// Given
+	cash := mustAccount(t, "현금", AccountAsset)
+	salary := mustAccount(t, "급여", AccountIncome)
+
+	// When
+	_, err := NewJournalEntry("user-1", "급여", time.Now(),
+		DebitOf(cash, krw(1000)),
+		CreditOf(salary, krw(900)),
+	)
+
+	// Then
+	requireInvalidArgument(t, err)
+}
+
+func Test_NewJournalEntry_should_reject_single_posting_and_non_positive_amount(t *testing.T) {
+	// Given
+	cash := mustAccount(t, "현금", AccountAsset)
+	salary := mustAccount(t, "급여", AccountIncome)
+
+	// When
+	_, single := NewJournalEntry("user-1", "급여", time.Now(), DebitOf(cash, krw(1000)))
+	_, zero := NewJournalEntry("user-1", "급여", time.Now(), DebitOf(cash, krw(0)), CreditOf(salary, krw(0)))
+
+	// Then
+	requireInvalidArgument(t, single)
+	requireInvalidArgument(t, zero)
+}
+
+func Test_TransferEntry_should_credit_source_and_debit_destination(t *testing.T) {
+	// Given
+	checking := mustAccount(t, "입출금", AccountAsset)
+	savings := mustAccount(t, "적금", AccountAsset)
+
+	// When
+	entry, err := TransferEntry(checking, savings, krw(500), "적금 이체", time.Now())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []Posting{
+		{AccountID: savings.ID, Side: Debit, Amount: krw(500)},
+		{AccountID: checking.ID, Side: Credit, Amount: krw(500)},
+	}, entry.Postings)
+}
+
+func Test_SellEntry_should_record_gain_and_loss_against_gains_account(t *testing.T) {
+	// Given
+	cash := mustAccount(t, "현금", AccountAsset)
+	stock := mustAccount(t, "주식", AccountAsset)
+	gains := mustAccount(t, "매매 손익", AccountIncome)
+
+	// When
+	profit, profitErr := SellEntry(cash, stock, krw(1200), krw(1000), gains, "매도", time.Now())
+	loss, lossErr := SellEntry(cash, stock, krw(800), krw(1000), gains, "매도", time.Now())
+
+	// Then
+	require.NoError(t, profitErr)
+	require.NoError(t, lossErr)
+	assert.Contains(t, profit.Postings, Posting{AccountID: gains.ID, Side: Credit, Amount: krw(200)})
+	assert.Contains(t, loss.Postings, Posting{AccountID: gains.ID, Side: Debit, Amount: krw(200)})
+}
+
+func Test_BuyEntry_should_charge_fee_to_expense_account(t *testing.T) {
+	// Given
+	cash := mustAccount(t, "현금", AccountAsset)
+	stock := mustAccount(t, "주식", AccountAsset)
+	fees := mustAccount(t, "수수료", AccountExpense)
+
+	// When
+	entry, err := BuyEntry(cash, stock, krw(1000), fees, krw(15), "매수", time.Now())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []Posting{
+		{AccountID: stock.ID, Side: Debit, Amount: krw(1000)},
+		{AccountID: fees.ID, Side: Debit, Amount: krw(15)},
+		{AccountID: cash.ID, Side: Credit, Amount: krw(1015)},
+	}, entry.Postings)
+}
+
+func Test_Reverse_should_swap_sides_and_link_original(t *testing.T) {
+	// Given
+	cash := mustAccount(t, "현금", AccountAsset)
+	food := mustAccount(t, "식비", AccountExpense)
+	entry, err := ExpenseEntry(cash, food, krw(300), "점심", time.Now())
+	require.NoError(t, err)
+
+	// When
+	reversal := entry.Reverse("점심 취소", time.Now())
+
+	// Then
+	require.NoError(t, reversal.Validate())
+	assert.Equal(t, entry.ID, reversal.ReversalOf)
+	assert.Equal(t, Credit, reversal.Postings[0].Side)
+	assert.Equal(t, Debit, reversal.Postings[1].Side)
+}
+
+func Test_NewTrialBalance_should_sum_postings_per_account(t *testing.T) {
+	// Given
+	cash := mustAccount(t, "현금", AccountAsset)
+	salary := mustAccount(t, "급여", AccountIncome)
+	food := mustAccount(t, "식비", AccountExpense)
+	income, err := IncomeEntry(cash, salary, krw(1000), "급여", time.Now())
+	require.NoError(t, err)
+	expense, err := ExpenseEntry(cash, food, krw(300), "점심", time.Now())
+	require.NoError(t, err)
+
+	// When
+	tb := NewTrialBalance([]*Account{cash, salary, food}, []*JournalEntry{income, expense})
+
+	// Then
+	assert.True(t, tb.IsBalanced())
+	assert.Equal(t, 1300.0, tb.TotalDebits["KRW"])
+	balances := make(map[string]float64)
+	for _, line := range tb.Lines {
+		balances[line.Name] = line.Balance
+	}
+	assert.Equal(t, map[string]float64{"현금": 700, "급여": 1000, "식비": 300}, balances)
+}
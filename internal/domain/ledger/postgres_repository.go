@@ -0,0 +1,127 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// PostgresRepository ledger_accounts, ledger_entries 테이블을 사용하는 원장 저장소 구현체입니다.
+// 계정과 분개는 data 컬럼에 JSON으로 저장하며, 계정별 분개 조회는 전기 목록의 GIN 인덱스를 사용합니다.
+// ctx에 같은 *sql.DB의 트랜잭션이 있으면 그 안에서 기록합니다.
+type PostgresRepository struct {
+	db       *sql.DB
+	accounts *sqldb.Documents[*Account]
+	entries  *sqldb.Documents[*JournalEntry]
+}
+
+// NewPostgresRepository 새로운 PostgreSQL 원장 저장소를 생성합니다.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{
+		db: db,
+		accounts: sqldb.NewDocuments(db, "ledger_accounts", []string{"id"}, []string{"user_id", "asset_id"},
+			func(a *Account) []interface{} {
+				var assetID interface{}
+				if a.AssetID != "" {
+					assetID = a.AssetID
+				}
+				return []interface{}{a.ID, a.UserID, assetID}
+			}),
+		entries: sqldb.NewDocuments(db, "ledger_entries", []string{"id"}, []string{"user_id"},
+			func(e *JournalEntry) []interface{} { return []interface{}{e.ID, e.UserID} }),
+	}
+}
+
+// SaveAccount 계정을 저장합니다.
+func (r *PostgresRepository) SaveAccount(ctx context.Context, account *Account) error {
+	return storageError(sqldb.WithTx(ctx, r.db, func(ctx context.Context) error {
+		if account.AssetID != "" {
+			mapped, err := r.accounts.Find(ctx, "asset_id = $1", account.AssetID)
+			if err != nil {
+				return err
+			}
+			if len(mapped) > 0 {
+				return domain.NewError("ledger", domain.ErrCodeAlreadyExists, fmt.Sprintf("account for asset %s already exists", account.AssetID))
+			}
+		}
+		inserted, err := r.accounts.Insert(ctx, account)
+		if err != nil {
+			return err
+		}
+		if !inserted {
+			return domain.NewError("ledger", domain.ErrCodeAlreadyExists, fmt.Sprintf("account with ID %s already exists", account.ID))
+		}
+		return nil
+	}))
+}
+
+// FindAccount ID로 계정을 조회합니다.
+func (r *PostgresRepository) FindAccount(ctx context.Context, id string) (*Account, error) {
+	account, exists, err := r.accounts.Get(ctx, id)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if !exists {
+		return nil, notFound(fmt.Sprintf("account with ID %s not found", id))
+	}
+	return account, nil
+}
+
+// FindAccountByAsset 자산에 대응하는 계정을 조회합니다.
+func (r *PostgresRepository) FindAccountByAsset(ctx context.Context, assetID string) (*Account, error) {
+	accounts, err := r.accounts.Find(ctx, "asset_id = $1", assetID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if len(accounts) == 0 {
+		return nil, notFound(fmt.Sprintf("account for asset %s not found", assetID))
+	}
+	return accounts[0], nil
+}
+
+// FindAccountsByUser 사용자의 계정을 조회합니다.
+func (r *PostgresRepository) FindAccountsByUser(ctx context.Context, userID string) ([]*Account, error) {
+	accounts, err := r.accounts.Find(ctx, "user_id = $1", userID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].CreatedAt.Before(accounts[j].CreatedAt)
+	})
+	return accounts, nil
+}
+
+// AppendEntry 분개를 추가합니다.
+func (r *PostgresRepository) AppendEntry(ctx context.Context, entry *JournalEntry) error {
+	inserted, err := r.entries.Insert(ctx, entry)
+	if err != nil {
+		return storageError(err)
+	}
+	if !inserted {
+		return domain.NewError("ledger", domain.ErrCodeAlreadyExists, fmt.Sprintf("journal entry with ID %s already exists", entry.ID))
+	}
+	return nil
+}
+
+// FindEntriesByUser 사용자의 분개를 발생 시각 순으로 조회합니다.
+func (r *PostgresRepository) FindEntriesByUser(ctx context.Context, userID string) ([]*JournalEntry, error) {
+	return r.findEntries(ctx, "user_id = $1", userID)
+}
+
+// FindEntriesByAccount 계정에 전기된 분개를 발생 시각 순으로 조회합니다.
+func (r *PostgresRepository) FindEntriesByAccount(ctx context.Context, accountID string) ([]*JournalEntry, error) {
+	return r.findEntries(ctx, "data->'Postings' @> jsonb_build_array(jsonb_build_object('AccountID', $1::text))", accountID)
+}
+
+func (r *PostgresRepository) findEntries(ctx context.Context, where string, args ...interface{}) ([]*JournalEntry, error) {
+	entries, err := r.entries.Find(ctx, where, args...)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortEntries(entries)
+	return entries, nil
+}
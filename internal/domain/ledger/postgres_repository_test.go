@@ -0,0 +1,90 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/storage/postgres"
+	"github.com/aske/go_fi_chart/pkg/storage/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestDatabase POSTGRES_TEST_DSN이 가리키는 데이터베이스에 스키마를 새로 적용해 엽니다.
+func openTestDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN이 설정되지 않아 PostgreSQL 통합 테스트를 건너뜁니다")
+	}
+
+	db, err := sql.Open(postgres.DriverName, dsn)
+	require.NoError(t, err)
+
+	migrations, err := postgres.Migrations()
+	require.NoError(t, err)
+	migrator := migrate.New(db, migrations)
+
+	ctx := context.Background()
+	require.NoError(t, migrator.Down(ctx, 0))
+	require.NoError(t, migrator.Up(ctx))
+	t.Cleanup(func() {
+		_ = migrator.Down(context.Background(), 0)
+		_ = db.Close()
+	})
+	return db
+}
+
+func Test_PostgresRepository_should_find_entries_by_posted_account(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewPostgresRepository(openTestDatabase(t))
+	ledger := NewLedger(repo)
+	cash, err := ledger.OpenAssetAccount(ctx, newAsset(t, "현금", 1000))
+	require.NoError(t, err)
+	food := mustAccount(t, "식비", AccountExpense)
+	require.NoError(t, repo.SaveAccount(ctx, food))
+	entry, err := ExpenseEntry(cash, food, krw(300), "점심", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, ledger.Post(ctx, entry))
+
+	// When
+	balance, err := ledger.Balance(ctx, cash.ID)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, krw(700), balance)
+	entries, err := repo.FindEntriesByAccount(ctx, food.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entry.ID, entries[0].ID)
+	accounts, err := repo.FindAccountsByUser(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, accounts, 3)
+}
+
+func Test_PostgresRepository_should_reject_duplicate_entry_and_asset_account(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewPostgresRepository(openTestDatabase(t))
+	first, err := NewAssetAccount(newAsset(t, "현금", 0))
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveAccount(ctx, first))
+	second := *first
+	second.ID = "other"
+	food := mustAccount(t, "식비", AccountExpense)
+	entry, err := ExpenseEntry(first, food, krw(100), "점심", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.AppendEntry(ctx, entry))
+
+	// When
+	accountErr := repo.SaveAccount(ctx, &second)
+	entryErr := repo.AppendEntry(ctx, entry)
+
+	// Then
+	assert.Error(t, accountErr)
+	assert.Error(t, entryErr)
+}
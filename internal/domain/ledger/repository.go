@@ -0,0 +1,28 @@
+package ledger
+
+import "context"
+
+// Repository 원장 저장소 인터페이스입니다.
+// 분개는 추가만 가능하며 수정하거나 삭제하지 않습니다.
+type Repository interface {
+	// SaveAccount 계정을 저장합니다.
+	SaveAccount(ctx context.Context, account *Account) error
+
+	// FindAccount ID로 계정을 조회합니다.
+	FindAccount(ctx context.Context, id string) (*Account, error)
+
+	// FindAccountByAsset 자산에 대응하는 계정을 조회합니다.
+	FindAccountByAsset(ctx context.Context, assetID string) (*Account, error)
+
+	// FindAccountsByUser 사용자의 계정을 조회합니다.
+	FindAccountsByUser(ctx context.Context, userID string) ([]*Account, error)
+
+	// AppendEntry 분개를 추가합니다.
+	AppendEntry(ctx context.Context, entry *JournalEntry) error
+
+	// FindEntriesByUser 사용자의 분개를 발생 시각 순으로 조회합니다.
+	FindEntriesByUser(ctx context.Context, userID string) ([]*JournalEntry, error)
+
+	// FindEntriesByAccount 계정에 전기된 분개를 발생 시각 순으로 조회합니다.
+	FindEntriesByAccount(ctx context.Context, accountID string) ([]*JournalEntry, error)
+}
@@ -0,0 +1,61 @@
+package networth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	snapshotBucket = "networth_snapshots"
+
+	indexUserID = "user_id"
+)
+
+// EmbeddedRepository 순자산 스냅샷의 임베디드 키-값 저장소 구현체입니다.
+// 스냅샷은 "사용자 ID/날짜" 키로 저장합니다.
+type EmbeddedRepository struct {
+	snapshots *kv.Collection[*Snapshot]
+}
+
+// NewEmbeddedRepository 사용자 인덱스를 가진 임베디드 순자산 스냅샷 저장소를 생성합니다.
+func NewEmbeddedRepository(db *kv.DB) (*EmbeddedRepository, error) {
+	snapshots := kv.NewCollection[*Snapshot](db, snapshotBucket)
+	if err := snapshots.Index(indexUserID, func(s *Snapshot) []string { return []string{s.UserID} }); err != nil {
+		return nil, err
+	}
+	return &EmbeddedRepository{snapshots: snapshots}, nil
+}
+
+// storageError 도메인 에러는 그대로 두고, 저장소 에러는 내부 에러로 감쌉니다.
+func storageError(err error) error {
+	var domainErr domain.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewError("networth", domain.ErrCodeInternal, err.Error())
+}
+
+// Save 스냅샷을 저장합니다.
+func (r *EmbeddedRepository) Save(ctx context.Context, snapshot *Snapshot) error {
+	return storageError(r.snapshots.Update(ctx, func(tx *kv.Tx) error {
+		return r.snapshots.Put(tx, snapshot.Key(), snapshot)
+	}))
+}
+
+// FindByUserID 사용자의 스냅샷을 날짜 순으로 조회합니다.
+func (r *EmbeddedRepository) FindByUserID(ctx context.Context, userID string) ([]*Snapshot, error) {
+	var snapshots []*Snapshot
+	err := r.snapshots.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.snapshots.Lookup(tx, indexUserID, userID)
+		snapshots = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortSnapshots(snapshots)
+	return snapshots, nil
+}
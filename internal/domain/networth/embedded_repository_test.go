@@ -0,0 +1,33 @@
+package networth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+func Test_EmbeddedRepository_should_overwrite_snapshot_of_same_day(t *testing.T) {
+	// Given
+	repo, err := NewEmbeddedRepository(kv.OpenMemory())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// When
+	require.NoError(t, repo.Save(ctx, NewSnapshot("user-1", date(2024, 3, 2), []Position{{Type: "CASH", Amount: krw(10)}}, true, date(2024, 3, 2))))
+	require.NoError(t, repo.Save(ctx, NewSnapshot("user-1", date(2024, 3, 1), []Position{{Type: "CASH", Amount: krw(5)}}, true, date(2024, 3, 2))))
+	require.NoError(t, repo.Save(ctx, NewSnapshot("user-1", date(2024, 3, 2), []Position{{Type: "CASH", Amount: krw(20)}}, false, date(2024, 3, 2))))
+	require.NoError(t, repo.Save(ctx, NewSnapshot("user-2", date(2024, 3, 2), nil, false, date(2024, 3, 2))))
+
+	// Then
+	found, err := repo.FindByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "2024-03-01", DateKey(found[0].Date))
+	assert.Equal(t, 20.0, found[1].NetWorth["KRW"])
+	assert.False(t, found[1].Backfilled)
+	require.Len(t, found[1].Positions, 1)
+}
@@ -0,0 +1,67 @@
+package networth
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryRepository 순자산 스냅샷의 인메모리 저장소 구현체입니다.
+type MemoryRepository struct {
+	snapshots map[string]*Snapshot
+	mutex     sync.RWMutex
+}
+
+// NewMemoryRepository 새로운 인메모리 순자산 스냅샷 저장소를 생성합니다.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{snapshots: make(map[string]*Snapshot)}
+}
+
+// Save 스냅샷을 저장합니다.
+func (r *MemoryRepository) Save(_ context.Context, snapshot *Snapshot) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.snapshots[snapshot.Key()] = clone(snapshot)
+	return nil
+}
+
+// FindByUserID 사용자의 스냅샷을 날짜 순으로 조회합니다.
+func (r *MemoryRepository) FindByUserID(_ context.Context, userID string) ([]*Snapshot, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]*Snapshot, 0)
+	for _, s := range r.snapshots {
+		if s.UserID == userID {
+			result = append(result, clone(s))
+		}
+	}
+	sortSnapshots(result)
+	return result, nil
+}
+
+// clone 포지션과 합계를 공유하지 않도록 스냅샷을 복사합니다.
+func clone(snapshot *Snapshot) *Snapshot {
+	c := *snapshot
+	c.Positions = append([]Position(nil), snapshot.Positions...)
+	c.ByType = append([]Line(nil), snapshot.ByType...)
+	c.Assets = cloneTotals(snapshot.Assets)
+	c.Liabilities = cloneTotals(snapshot.Liabilities)
+	c.NetWorth = cloneTotals(snapshot.NetWorth)
+	return &c
+}
+
+func cloneTotals(totals map[string]float64) map[string]float64 {
+	c := make(map[string]float64, len(totals))
+	for k, v := range totals {
+		c[k] = v
+	}
+	return c
+}
+
+func sortSnapshots(snapshots []*Snapshot) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Date.Before(snapshots[j].Date)
+	})
+}
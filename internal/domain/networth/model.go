@@ -0,0 +1,109 @@
+// Package networth 사용자의 순자산(자산 - 부채)을 하루 단위 스냅샷으로 기록하여 순자산 추이를 제공합니다.
+// 스냅샷은 자산 금액이 바뀌는 이벤트마다 그날의 값으로 갱신하며, 이전 기간은 거래를 되돌려 채웁니다.
+package networth
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// Position 스냅샷 시점의 자산 또는 부채 하나의 금액입니다. 부채 금액은 양수로 기록합니다.
+type Position struct {
+	ID        string
+	Name      string
+	Type      string
+	Amount    asset.Money
+	Liability bool
+}
+
+// Line 유형과 통화별 합계입니다.
+type Line struct {
+	Type      string
+	Currency  string
+	Amount    float64
+	Liability bool
+}
+
+// Snapshot 사용자의 하루 끝 시점 순자산입니다. 날짜는 UTC 기준입니다.
+type Snapshot struct {
+	UserID    string
+	Date      time.Time
+	Positions []Position
+	// Assets, Liabilities, NetWorth 통화별 합계입니다. 통화가 다른 금액은 합치지 않습니다
+	Assets      map[string]float64
+	Liabilities map[string]float64
+	NetWorth    map[string]float64
+	ByType      []Line
+	// Backfilled 이벤트가 아니라 거래를 되돌려 계산한 스냅샷입니다
+	Backfilled bool
+	UpdatedAt  time.Time
+}
+
+// NewSnapshot 포지션으로 합계를 계산한 스냅샷을 생성합니다.
+func NewSnapshot(userID string, date time.Time, positions []Position, backfilled bool, now time.Time) *Snapshot {
+	s := &Snapshot{
+		UserID:      userID,
+		Date:        Day(date),
+		Positions:   positions,
+		Assets:      make(map[string]float64),
+		Liabilities: make(map[string]float64),
+		NetWorth:    make(map[string]float64),
+		Backfilled:  backfilled,
+		UpdatedAt:   now,
+	}
+
+	lines := make(map[Line]float64)
+	for _, p := range positions {
+		currency := p.Amount.Currency
+		if p.Liability {
+			s.Liabilities[currency] += p.Amount.Amount
+			s.NetWorth[currency] -= p.Amount.Amount
+		} else {
+			s.Assets[currency] += p.Amount.Amount
+			s.NetWorth[currency] += p.Amount.Amount
+		}
+		lines[Line{Type: p.Type, Currency: currency, Liability: p.Liability}] += p.Amount.Amount
+	}
+	for line, amount := range lines {
+		line.Amount = amount
+		s.ByType = append(s.ByType, line)
+	}
+	sort.Slice(s.ByType, func(i, j int) bool {
+		a, b := s.ByType[i], s.ByType[j]
+		if a.Liability != b.Liability {
+			return !a.Liability
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Currency < b.Currency
+	})
+	return s
+}
+
+// Key 사용자와 날짜로 스냅샷을 구분하는 키입니다.
+func (s *Snapshot) Key() string {
+	return snapshotKey(s.UserID, s.Date)
+}
+
+func snapshotKey(userID string, date time.Time) string {
+	return userID + "/" + DateKey(date)
+}
+
+// Day t가 속한 UTC 날짜의 시작입니다.
+func Day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DateKey 날짜를 YYYY-MM-DD 형식으로 나타냅니다.
+func DateKey(t time.Time) string {
+	return Day(t).Format("2006-01-02")
+}
+
+func invalid(msg string) error {
+	return domain.NewError("networth", domain.ErrCodeInvalidArgument, msg)
+}
@@ -0,0 +1,39 @@
+package networth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func krw(amount float64) asset.Money {
+	return asset.Money{Amount: amount, Currency: "KRW"}
+}
+
+func Test_NewSnapshot_should_total_by_currency_and_type(t *testing.T) {
+	// When
+	snapshot := NewSnapshot("user-1", time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC), []Position{
+		{ID: "a1", Type: string(asset.Cash), Amount: krw(1000000)},
+		{ID: "a2", Type: string(asset.Cash), Amount: krw(500000)},
+		{ID: "a3", Type: string(asset.Stock), Amount: asset.Money{Amount: 100, Currency: "USD"}},
+		{ID: "l1", Type: "MORTGAGE", Amount: krw(300000), Liability: true},
+	}, false, date(2024, 3, 1))
+
+	// Then
+	assert.True(t, snapshot.Date.Equal(date(2024, 3, 1)))
+	assert.Equal(t, "user-1/2024-03-01", snapshot.Key())
+	assert.Equal(t, map[string]float64{"KRW": 1500000, "USD": 100}, snapshot.Assets)
+	assert.Equal(t, map[string]float64{"KRW": 300000}, snapshot.Liabilities)
+	assert.Equal(t, map[string]float64{"KRW": 1200000, "USD": 100}, snapshot.NetWorth)
+	require.Len(t, snapshot.ByType, 3)
+	assert.Equal(t, Line{Type: string(asset.Cash), Currency: "KRW", Amount: 1500000}, snapshot.ByType[0])
+	assert.Equal(t, Line{Type: "MORTGAGE", Currency: "KRW", Amount: 300000, Liability: true}, snapshot.ByType[2])
+}
@@ -0,0 +1,37 @@
+package networth
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// PostgresRepository networth_snapshots 테이블을 사용하는 순자산 스냅샷 저장소 구현체입니다.
+// 스냅샷은 (사용자 ID, 날짜)를 키로 data 컬럼에 JSON으로 저장합니다.
+type PostgresRepository struct {
+	snapshots *sqldb.Documents[*Snapshot]
+}
+
+// NewPostgresRepository 새로운 PostgreSQL 순자산 스냅샷 저장소를 생성합니다.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{
+		snapshots: sqldb.NewDocuments(db, "networth_snapshots", []string{"user_id", "snapshot_date"}, nil,
+			func(s *Snapshot) []interface{} { return []interface{}{s.UserID, DateKey(s.Date)} }),
+	}
+}
+
+// Save 스냅샷을 저장합니다.
+func (r *PostgresRepository) Save(ctx context.Context, snapshot *Snapshot) error {
+	return storageError(r.snapshots.Put(ctx, snapshot))
+}
+
+// FindByUserID 사용자의 스냅샷을 날짜 순으로 조회합니다.
+func (r *PostgresRepository) FindByUserID(ctx context.Context, userID string) ([]*Snapshot, error) {
+	snapshots, err := r.snapshots.Find(ctx, "user_id = $1", userID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortSnapshots(snapshots)
+	return snapshots, nil
+}
@@ -0,0 +1,11 @@
+package networth
+
+import "context"
+
+// Repository 순자산 스냅샷 저장소입니다.
+type Repository interface {
+	// Save 스냅샷을 저장합니다. 같은 사용자와 날짜의 스냅샷이 있으면 덮어씁니다.
+	Save(ctx context.Context, snapshot *Snapshot) error
+	// FindByUserID 사용자의 스냅샷을 날짜 순으로 조회합니다.
+	FindByUserID(ctx context.Context, userID string) ([]*Snapshot, error)
+}
@@ -0,0 +1,256 @@
+package networth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+)
+
+// MaxDays 한 번에 되돌려 채우거나 조회할 수 있는 최대 일수입니다.
+const MaxDays = 3660
+
+// LiabilitySource 자산 저장소 밖에서 관리하는 사용자의 부채 잔액을 제공합니다.
+// 대출, 주택담보대출, 신용카드 같은 부채 유형의 자산은 자산 저장소에서 읽어 부채로 계산합니다.
+type LiabilitySource interface {
+	// Liabilities at 시점 사용자의 부채 잔액을 Liability가 true인 포지션으로 반환합니다.
+	Liabilities(ctx context.Context, userID string, at time.Time) ([]Position, error)
+}
+
+// Tracker 자산과 부채로 순자산 스냅샷을 기록하고 추이를 조회합니다.
+type Tracker struct {
+	repo         Repository
+	assets       asset.Repository
+	transactions asset.TransactionRepository
+	liabilities  LiabilitySource
+	now          func() time.Time
+	mutex        sync.Mutex
+}
+
+// TrackerOption 순자산 기록기 설정 함수입니다.
+type TrackerOption func(*Tracker)
+
+// WithLiabilities 순자산 계산에서 뺄 부채 잔액을 제공하는 소스를 지정합니다.
+func WithLiabilities(source LiabilitySource) TrackerOption {
+	return func(t *Tracker) {
+		t.liabilities = source
+	}
+}
+
+// WithClock 현재 시각 함수를 지정합니다.
+func WithClock(now func() time.Time) TrackerOption {
+	return func(t *Tracker) {
+		t.now = now
+	}
+}
+
+// NewTracker 새로운 순자산 기록기를 생성합니다.
+func NewTracker(repo Repository, assets asset.Repository, transactions asset.TransactionRepository, opts ...TrackerOption) *Tracker {
+	t := &Tracker{repo: repo, assets: assets, transactions: transactions, now: time.Now}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// HandlerName 이벤트 핸들러 이름입니다.
+func (t *Tracker) HandlerName() string {
+	return "networth-tracker"
+}
+
+// HandleEvent 자산 금액이 바뀌는 이벤트를 받으면 그 사용자의 오늘 스냅샷을 갱신합니다.
+func (t *Tracker) HandleEvent(ctx context.Context, evt event.Event) error {
+	switch evt.EventType() {
+	case event.TypeAssetCreated, event.TypeAssetDeleted, event.TypeAssetAmountChanged,
+		event.TypeTransactionRecorded, event.TypeRecurringPosted:
+	default:
+		return nil
+	}
+	userID := evt.Metadata()["userID"]
+	if userID == "" {
+		return nil
+	}
+	_, err := t.Capture(ctx, userID)
+	return err
+}
+
+// Capture 사용자의 현재 자산과 부채로 오늘 스냅샷을 기록합니다.
+func (t *Tracker) Capture(ctx context.Context, userID string) (*Snapshot, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.now()
+	owned, err := t.assets.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	positions := make([]Position, 0, len(owned))
+	for _, a := range owned {
+		positions = append(positions, assetPosition(a, a.Amount))
+	}
+	liabilities, err := t.liabilitiesAt(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := NewSnapshot(userID, now, append(positions, liabilities...), false, now)
+	if err := t.repo.Save(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Backfill from부터 어제까지 스냅샷이 없는 날을 채우고 기록한 스냅샷 수를 반환합니다.
+// 현재 자산 금액에서 이후 거래를 되돌려 날마다의 금액을 계산합니다. 거래 없이 바뀐 금액(직접 수정, 시세 재평가)은 되돌리지 못하므로
+// 이벤트로 기록한 스냅샷은 덮어쓰지 않고, 이전에 되돌려 계산한 스냅샷만 다시 계산합니다.
+func (t *Tracker) Backfill(ctx context.Context, userID string, from time.Time) (int, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.now()
+	today := Day(now)
+	from = Day(from)
+	if !from.Before(today) {
+		return 0, nil
+	}
+	if today.Sub(from) > MaxDays*24*time.Hour {
+		return 0, invalid(fmt.Sprintf("backfill is limited to %d days", MaxDays))
+	}
+
+	owned, err := t.assets.FindByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	existing, err := t.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	recorded := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		if !s.Backfilled {
+			recorded[DateKey(s.Date)] = true
+		}
+	}
+
+	amounts := make(map[string]asset.Money, len(owned))
+	isLiability := make(map[string]bool)
+	for _, a := range owned {
+		amounts[a.ID] = a.Amount
+		isLiability[a.ID] = a.Type.IsLiability()
+	}
+	txs, err := t.transactions.FindByDateRange(ctx, from, now)
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Date.After(txs[j].Date) })
+
+	// 오늘 거래부터 거꾸로 되돌리며 전날 끝의 금액을 구합니다
+	written := 0
+	next := 0
+	for day := today.AddDate(0, 0, -1); !day.Before(from); day = day.AddDate(0, 0, -1) {
+		end := day.AddDate(0, 0, 1)
+		for ; next < len(txs) && !txs[next].Date.Before(end); next++ {
+			undo(amounts, isLiability, txs[next])
+		}
+		if recorded[DateKey(day)] {
+			continue
+		}
+
+		positions := make([]Position, 0, len(owned))
+		for _, a := range owned {
+			if !a.CreatedAt.Before(end) {
+				continue
+			}
+			positions = append(positions, assetPosition(a, amounts[a.ID]))
+		}
+		external, err := t.liabilitiesAt(ctx, userID, end.Add(-time.Nanosecond))
+		if err != nil {
+			return written, err
+		}
+		// 자산도 부채도 없던 날은 기록하지 않습니다
+		positions = append(positions, external...)
+		if len(positions) == 0 {
+			continue
+		}
+		if err := t.repo.Save(ctx, NewSnapshot(userID, day, positions, true, now)); err != nil {
+			return written, err
+		}
+		written++
+	}
+	return written, nil
+}
+
+// Timeline from부터 to까지 날마다의 스냅샷을 반환합니다.
+// 스냅샷이 없는 날은 직전 스냅샷을 그 날짜로 이어 쓰며, 그 이전 스냅샷도 없는 날은 빠집니다.
+func (t *Tracker) Timeline(ctx context.Context, userID string, from, to time.Time) ([]*Snapshot, error) {
+	from, to = Day(from), Day(to)
+	if to.Before(from) {
+		return nil, invalid("to must not be before from")
+	}
+	if to.Sub(from) > MaxDays*24*time.Hour {
+		return nil, invalid(fmt.Sprintf("timeline is limited to %d days", MaxDays))
+	}
+	snapshots, err := t.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var series []*Snapshot
+	var last *Snapshot
+	next := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for ; next < len(snapshots) && !snapshots[next].Date.After(day); next++ {
+			last = snapshots[next]
+		}
+		if last == nil {
+			continue
+		}
+		point := last
+		if !last.Date.Equal(day) {
+			point = clone(last)
+			point.Date = day
+		}
+		series = append(series, point)
+	}
+	return series, nil
+}
+
+func (t *Tracker) liabilitiesAt(ctx context.Context, userID string, at time.Time) ([]Position, error) {
+	if t.liabilities == nil {
+		return nil, nil
+	}
+	return t.liabilities.Liabilities(ctx, userID, at)
+}
+
+func assetPosition(a *asset.Asset, amount asset.Money) Position {
+	return Position{ID: a.ID, Name: a.Name, Type: string(a.Type), Amount: amount, Liability: a.Type.IsLiability()}
+}
+
+// undo 거래가 자산 금액에 준 영향을 되돌립니다. Asset.ProcessTransaction과 Asset.ReceiveTransfer의 반대 연산입니다.
+func undo(amounts map[string]asset.Money, isLiability map[string]bool, tx *asset.Transaction) {
+	if amount, ok := amounts[tx.AssetID]; ok && amount.Currency == tx.Amount.Currency {
+		// 부채 자산은 입금(상환)으로 잔액이 줄어들었으므로 반대로 되돌립니다
+		if (tx.Type == asset.Income) != isLiability[tx.AssetID] {
+			amount.Amount -= tx.Amount.Amount
+		} else {
+			amount.Amount += tx.Amount.Amount
+		}
+		amounts[tx.AssetID] = amount
+	}
+	if tx.Type != asset.Transfer || tx.CounterAssetID == "" {
+		return
+	}
+	// 이체의 입금 자산은 금액이 늘었고, 입금 자산이 부채이면 상환으로 잔액이 줄었습니다
+	if amount, ok := amounts[tx.CounterAssetID]; ok && amount.Currency == tx.Amount.Currency {
+		if isLiability[tx.CounterAssetID] {
+			amount.Amount += tx.Amount.Amount
+		} else {
+			amount.Amount -= tx.Amount.Amount
+		}
+		amounts[tx.CounterAssetID] = amount
+	}
+}
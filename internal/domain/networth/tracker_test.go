@@ -0,0 +1,237 @@
+package networth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+type fixedLiabilities struct {
+	balance float64
+}
+
+func (l fixedLiabilities) Liabilities(_ context.Context, _ string, at time.Time) ([]Position, error) {
+	// 3월 1일부터 매일 1,000원씩 상환한다고 가정합니다
+	paid := float64(int(at.Sub(date(2024, 3, 1)).Hours()/24)) * 1000
+	return []Position{{ID: "loan", Name: "대출", Type: "LOAN", Amount: krw(l.balance - paid), Liability: true}}, nil
+}
+
+type trackerFixture struct {
+	ctx          context.Context
+	now          time.Time
+	assets       *asset.MemoryAssetRepository
+	transactions *asset.MemoryTransactionRepository
+	repo         *MemoryRepository
+	tracker      *Tracker
+	account      *asset.Asset
+}
+
+func newTrackerFixture(t *testing.T, opts ...TrackerOption) *trackerFixture {
+	t.Helper()
+	f := &trackerFixture{
+		ctx:          context.Background(),
+		now:          time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+		assets:       asset.NewMemoryAssetRepository(),
+		transactions: asset.NewMemoryTransactionRepository(),
+		repo:         NewMemoryRepository(),
+	}
+	opts = append(opts, WithClock(func() time.Time { return f.now }))
+	f.tracker = NewTracker(f.repo, f.assets, f.transactions, opts...)
+
+	var err error
+	f.account, err = asset.NewAsset("user-1", asset.Cash, "입출금", 100000, "KRW")
+	require.NoError(t, err)
+	f.account.CreatedAt = date(2024, 3, 1)
+	require.NoError(t, f.assets.Save(f.ctx, f.account))
+	return f
+}
+
+// record 거래를 자산에 반영하고 저장합니다.
+func (f *trackerFixture) record(t *testing.T, txType asset.TransactionType, amount float64, at time.Time) {
+	t.Helper()
+	account, err := f.assets.FindByID(f.ctx, f.account.ID)
+	require.NoError(t, err)
+	tx, err := asset.NewTransaction(account.ID, txType, krw(amount), "", "")
+	require.NoError(t, err)
+	tx.Date = at
+	require.NoError(t, account.ProcessTransaction(tx))
+	require.NoError(t, f.transactions.Save(f.ctx, tx))
+	require.NoError(t, f.assets.Update(f.ctx, account))
+}
+
+func Test_Tracker_should_capture_snapshot_on_amount_change_events(t *testing.T) {
+	// Given
+	f := newTrackerFixture(t, WithLiabilities(fixedLiabilities{balance: 50000}))
+	bus := memory.NewEventBus()
+	require.NoError(t, bus.Subscribe(f.tracker))
+	f.record(t, asset.Income, 20000, f.now)
+
+	// When
+	require.NoError(t, bus.Publish(f.ctx, event.NewEvent(event.TypeTransactionRecorded, f.account.ID, "asset", nil, map[string]string{"userID": "user-1"}, 1)))
+	require.NoError(t, bus.Publish(f.ctx, event.NewEvent(event.TypePriceUpdated, "AAPL", "price", nil, nil, 1)))
+
+	// Then
+	snapshots, err := f.repo.FindByUserID(f.ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.True(t, snapshots[0].Date.Equal(date(2024, 3, 10)))
+	assert.False(t, snapshots[0].Backfilled)
+	assert.Equal(t, 120000.0, snapshots[0].Assets["KRW"])
+	assert.Equal(t, 41000.0, snapshots[0].Liabilities["KRW"])
+	assert.Equal(t, 79000.0, snapshots[0].NetWorth["KRW"])
+}
+
+func Test_Tracker_should_backfill_by_replaying_transactions(t *testing.T) {
+	// Given
+	f := newTrackerFixture(t)
+	f.record(t, asset.Income, 50000, date(2024, 3, 3).Add(9*time.Hour))
+	f.record(t, asset.Expense, 30000, date(2024, 3, 5).Add(18*time.Hour))
+	f.record(t, asset.Expense, 10000, f.now)
+
+	// When
+	written, err := f.tracker.Backfill(f.ctx, "user-1", date(2024, 2, 28))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 9, written)
+	series, err := f.tracker.Timeline(f.ctx, "user-1", date(2024, 2, 28), date(2024, 3, 9))
+	require.NoError(t, err)
+	require.Len(t, series, 9)
+	assert.Equal(t, "2024-03-01", DateKey(series[0].Date))
+	assert.Equal(t, 100000.0, series[0].NetWorth["KRW"])
+	assert.Equal(t, 150000.0, series[2].NetWorth["KRW"])
+	assert.Equal(t, 120000.0, series[4].NetWorth["KRW"])
+	assert.Equal(t, 120000.0, series[8].NetWorth["KRW"])
+	assert.True(t, series[8].Backfilled)
+}
+
+func Test_Tracker_should_keep_event_snapshots_when_backfilling(t *testing.T) {
+	// Given
+	f := newTrackerFixture(t)
+	f.now = date(2024, 3, 5).Add(20 * time.Hour)
+	_, err := f.tracker.Capture(f.ctx, "user-1")
+	require.NoError(t, err)
+	f.now = date(2024, 3, 8).Add(8 * time.Hour)
+	f.record(t, asset.Expense, 40000, f.now)
+
+	// When
+	written, err := f.tracker.Backfill(f.ctx, "user-1", date(2024, 3, 1))
+	require.NoError(t, err)
+	again, err := f.tracker.Backfill(f.ctx, "user-1", date(2024, 3, 1))
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, 6, written)
+	assert.Equal(t, 6, again)
+	snapshots, err := f.repo.FindByUserID(f.ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 7)
+	assert.False(t, snapshots[4].Backfilled)
+	assert.Equal(t, 100000.0, snapshots[4].NetWorth["KRW"])
+}
+
+func Test_Tracker_should_carry_forward_and_validate_timeline(t *testing.T) {
+	// Given
+	f := newTrackerFixture(t)
+	require.NoError(t, f.repo.Save(f.ctx, NewSnapshot("user-1", date(2024, 3, 2), []Position{{Type: "CASH", Amount: krw(10)}}, false, f.now)))
+	require.NoError(t, f.repo.Save(f.ctx, NewSnapshot("user-1", date(2024, 3, 4), []Position{{Type: "CASH", Amount: krw(20)}}, false, f.now)))
+
+	// When
+	series, err := f.tracker.Timeline(f.ctx, "user-1", date(2024, 3, 1), date(2024, 3, 5))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, series, 4)
+	assert.Equal(t, "2024-03-03", DateKey(series[1].Date))
+	assert.Equal(t, 10.0, series[1].NetWorth["KRW"])
+	assert.Equal(t, 20.0, series[3].NetWorth["KRW"])
+	_, err = f.tracker.Timeline(f.ctx, "user-1", date(2024, 3, 5), date(2024, 3, 1))
+	assert.Error(t, err)
+	_, err = f.tracker.Backfill(f.ctx, "user-1", date(2000, 1, 1))
+	assert.Error(t, err)
+}
+
+func Test_Tracker_should_subtract_liability_assets_and_replay_their_transactions(t *testing.T) {
+	// Given
+	f := newTrackerFixture(t)
+	card, err := asset.NewAsset("user-1", asset.CreditCard, "신용카드", 0, "KRW")
+	require.NoError(t, err)
+	card.CreatedAt = date(2024, 3, 1)
+	require.NoError(t, f.assets.Save(f.ctx, card))
+	purchase, err := asset.NewTransaction(card.ID, asset.Expense, krw(30000), "식비", "마트")
+	require.NoError(t, err)
+	purchase.Date = date(2024, 3, 8).Add(12 * time.Hour)
+	require.NoError(t, card.ProcessTransaction(purchase))
+	require.NoError(t, f.transactions.Save(f.ctx, purchase))
+	require.NoError(t, f.assets.Update(f.ctx, card))
+
+	// When
+	snapshot, err := f.tracker.Capture(f.ctx, "user-1")
+	require.NoError(t, err)
+	_, err = f.tracker.Backfill(f.ctx, "user-1", date(2024, 3, 7))
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, 30000.0, snapshot.Liabilities["KRW"])
+	assert.Equal(t, 70000.0, snapshot.NetWorth["KRW"])
+	series, err := f.tracker.Timeline(f.ctx, "user-1", date(2024, 3, 7), date(2024, 3, 8))
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+	assert.Equal(t, 100000.0, series[0].NetWorth["KRW"])
+	assert.Equal(t, 70000.0, series[1].NetWorth["KRW"])
+}
+
+func Test_Tracker_should_undo_both_sides_of_transfers_when_backfilling(t *testing.T) {
+	// Given
+	f := newTrackerFixture(t)
+	savings, err := asset.NewAsset("user-1", asset.Cash, "적금", 0, "KRW")
+	require.NoError(t, err)
+	card, err := asset.NewAsset("user-1", asset.CreditCard, "신용카드", 30000, "KRW")
+	require.NoError(t, err)
+	for _, a := range []*asset.Asset{savings, card} {
+		a.CreatedAt = date(2024, 3, 1)
+		require.NoError(t, f.assets.Save(f.ctx, a))
+	}
+	transfer := func(to *asset.Asset, amount float64, at time.Time) {
+		account, err := f.assets.FindByID(f.ctx, f.account.ID)
+		require.NoError(t, err)
+		counter, err := f.assets.FindByID(f.ctx, to.ID)
+		require.NoError(t, err)
+		tx, err := asset.NewTransaction(account.ID, asset.Transfer, krw(amount), "", "")
+		require.NoError(t, err)
+		tx.CounterAssetID = counter.ID
+		tx.Date = at
+		require.NoError(t, account.ProcessTransaction(tx))
+		require.NoError(t, counter.ReceiveTransfer(tx))
+		require.NoError(t, f.transactions.Save(f.ctx, tx))
+		require.NoError(t, f.assets.Update(f.ctx, account))
+		require.NoError(t, f.assets.Update(f.ctx, counter))
+	}
+	transfer(savings, 40000, date(2024, 3, 5).Add(9*time.Hour))
+	transfer(card, 20000, date(2024, 3, 8).Add(9*time.Hour))
+
+	// When
+	_, err = f.tracker.Backfill(f.ctx, "user-1", date(2024, 3, 4))
+
+	// Then
+	require.NoError(t, err)
+	series, err := f.tracker.Timeline(f.ctx, "user-1", date(2024, 3, 4), date(2024, 3, 8))
+	require.NoError(t, err)
+	require.Len(t, series, 5)
+	// 적금 이체는 자산 합계를, 카드 대금 상환은 순자산을 바꾸지 않습니다
+	assert.Equal(t, 100000.0, series[0].Assets["KRW"])
+	assert.Equal(t, 30000.0, series[0].Liabilities["KRW"])
+	assert.Equal(t, 100000.0, series[1].Assets["KRW"])
+	assert.Equal(t, 80000.0, series[4].Assets["KRW"])
+	assert.Equal(t, 10000.0, series[4].Liabilities["KRW"])
+	for _, snapshot := range series {
+		assert.Equal(t, 70000.0, snapshot.NetWorth["KRW"], DateKey(snapshot.Date))
+	}
+}
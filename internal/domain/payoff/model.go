@@ -0,0 +1,99 @@
+// Package payoff 사용자의 부채를 월 상환 예산으로 갚아 나가는 과정을 모의 계산하여 상환 완료 시점과 총 이자를 제공합니다.
+// 스노볼(잔액이 작은 부채부터), 애벌랜치(이율이 높은 부채부터), 사용자 지정 순서를 지원하며
+// 빚 청산(DEBT_FREE) 목표의 진행률을 부채 상환 현황에 맞춰 갱신합니다.
+package payoff
+
+import (
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// Strategy 추가 상환금을 배분할 부채의 순서를 정하는 전략입니다.
+type Strategy string
+
+const (
+	// Snowball 잔액이 작은 부채부터 갚아 상환 건수를 빨리 줄입니다
+	Snowball Strategy = "SNOWBALL"
+	// Avalanche 이율이 높은 부채부터 갚아 총 이자를 줄입니다
+	Avalanche Strategy = "AVALANCHE"
+	// Custom 요청한 순서대로 갚습니다. 순서에 없는 부채는 스노볼 순서로 뒤에 붙습니다
+	Custom Strategy = "CUSTOM"
+)
+
+// IsValid 지원하는 전략인지 확인합니다.
+func (s Strategy) IsValid() bool {
+	switch s {
+	case Snowball, Avalanche, Custom:
+		return true
+	}
+	return false
+}
+
+// Request 상환 계획 요청입니다.
+type Request struct {
+	UserID   string
+	Budget   asset.Money // 매월 부채 상환에 쓸 수 있는 총액
+	Strategy Strategy
+	Order    []string // Custom 전략의 부채 자산 ID 순서
+	Start    time.Time
+}
+
+// Payment 한 달 동안 한 부채에 낸 상환금입니다.
+type Payment struct {
+	AssetID   string
+	Payment   float64
+	Interest  float64
+	Principal float64
+	Balance   float64 // 상환 후 남은 원금
+}
+
+// Month 모의 계산의 한 달입니다.
+type Month struct {
+	Number   int
+	Date     time.Time
+	Payments []Payment
+	Payment  float64
+	Interest float64
+	Balance  float64 // 모든 부채의 남은 원금 합계
+}
+
+// Debt 계획에 포함된 부채와 상환 결과입니다.
+type Debt struct {
+	AssetID    string
+	Name       string
+	Type       asset.Type
+	Balance    float64 // 계획 시작 시점의 남은 원금
+	AnnualRate float64
+	Minimum    float64 // 첫 달 최소 상환금
+	Interest   float64 // 계획 기간 동안 낸 이자
+	PaidOffAt  time.Time
+}
+
+// GoalStatus 빚 청산 목표의 진행 상황입니다.
+type GoalStatus struct {
+	AssetID  string
+	GoalID   string
+	Progress float64
+	Deadline time.Time
+	OnTrack  bool // 계획의 상환 완료일이 목표 기한 이전인지 여부
+}
+
+// Plan 상환 계획입니다.
+type Plan struct {
+	UserID        string
+	Strategy      Strategy
+	Budget        asset.Money
+	Debts         []Debt // 상환 순서
+	Months        []Month
+	TotalPayment  float64
+	TotalInterest float64
+	PayoffDate    time.Time
+	Goals         []GoalStatus
+	Excluded      []string // 예산과 통화가 달라 계획에서 제외한 부채 자산 ID
+}
+
+func invalid(msg string) error {
+	return domain.NewError("payoff", domain.ErrCodeInvalidArgument, msg)
+}
@@ -0,0 +1,369 @@
+package payoff
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+)
+
+const (
+	// MaxMonths 모의 계산할 수 있는 최대 개월 수입니다.
+	MaxMonths = 600
+	// RevolvingMinimumRate 만기가 없는 부채(신용카드)의 최소 상환 비율입니다. 이번 달 이자에 잔액의 이 비율을 더해 냅니다.
+	RevolvingMinimumRate = 0.02
+)
+
+// Planner 사용자의 부채로 상환 계획을 세우고 빚 청산 목표의 진행률을 갱신합니다.
+type Planner struct {
+	assets asset.Repository
+	now    func() time.Time
+}
+
+// PlannerOption 상환 계획기 설정 함수입니다.
+type PlannerOption func(*Planner)
+
+// WithClock 현재 시각 함수를 지정합니다.
+func WithClock(now func() time.Time) PlannerOption {
+	return func(p *Planner) {
+		p.now = now
+	}
+}
+
+// NewPlanner 새로운 상환 계획기를 생성합니다.
+func NewPlanner(assets asset.Repository, opts ...PlannerOption) *Planner {
+	p := &Planner{assets: assets, now: time.Now}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// HandlerName 이벤트 핸들러 이름입니다.
+func (p *Planner) HandlerName() string {
+	return "payoff-goals"
+}
+
+// HandleEvent 자산 금액이 바뀌는 이벤트를 받으면 그 사용자의 빚 청산 목표 진행률을 갱신합니다.
+func (p *Planner) HandleEvent(ctx context.Context, evt event.Event) error {
+	switch evt.EventType() {
+	case event.TypeAssetCreated, event.TypeAssetDeleted, event.TypeAssetAmountChanged,
+		event.TypeTransactionRecorded, event.TypeRecurringPosted:
+	default:
+		return nil
+	}
+	userID := evt.Metadata()["userID"]
+	if userID == "" {
+		return nil
+	}
+	_, err := p.SyncGoals(ctx, userID)
+	return err
+}
+
+// SyncGoals 사용자의 빚 청산(DEBT_FREE) 목표 진행률을 부채 상환 현황으로 갱신하고 저장한 자산 수를 반환합니다.
+// 부채 자산의 목표는 그 부채의 최초 원금 대비 상환 비율을, 그 밖의 자산에 둔 목표는 같은 통화 부채 전체의 상환 비율을 사용합니다.
+func (p *Planner) SyncGoals(ctx context.Context, userID string) (int, error) {
+	owned, err := p.assets.FindByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	principal := make(map[string]float64)
+	balance := make(map[string]float64)
+	for _, a := range owned {
+		if _, ok := a.PaidOffProgress(); ok {
+			principal[a.Amount.Currency] += a.OriginalPrincipal().Amount
+			balance[a.Amount.Currency] += a.Amount.Amount
+		}
+	}
+
+	updated := 0
+	for _, a := range owned {
+		progress, ok := a.PaidOffProgress()
+		if !a.Type.IsLiability() {
+			total := principal[a.Amount.Currency]
+			progress, ok = 0, total > 0
+			if ok {
+				progress = math.Min(100, math.Max(0, (total-balance[a.Amount.Currency])/total*100))
+			}
+		}
+		if !ok || !a.SetDebtFreeProgress(progress) {
+			continue
+		}
+		if err := p.assets.Update(ctx, a); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// debt 모의 계산 중인 부채입니다.
+type debt struct {
+	asset   *asset.Asset
+	balance float64
+	rate    float64 // 연 이율(%)
+}
+
+// Plan 요청한 전략으로 부채를 모두 갚을 때까지 매월 상환 내역을 모의 계산합니다.
+// 매월 모든 부채에 최소 상환금을 낸 뒤 남은 예산을 전략 순서의 첫 부채에 더 냅니다.
+// 이자는 상환 주기와 관계없이 연 이율의 1/12을 매월 남은 원금에 붙입니다.
+func (p *Planner) Plan(ctx context.Context, req Request) (*Plan, error) {
+	if req.UserID == "" {
+		return nil, invalid("user ID is required")
+	}
+	if !req.Budget.IsPositive() || req.Budget.Currency == "" {
+		return nil, invalid("budget must be a positive amount with currency")
+	}
+	if !req.Strategy.IsValid() {
+		return nil, invalid(fmt.Sprintf("unsupported strategy: %s", req.Strategy))
+	}
+	start := req.Start
+	if start.IsZero() {
+		start = p.now()
+	}
+
+	owned, err := p.assets.FindByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{UserID: req.UserID, Strategy: req.Strategy, Budget: req.Budget, PayoffDate: start}
+	var debts []*debt
+	for _, a := range owned {
+		if !a.Type.IsLiability() || !a.Amount.IsPositive() {
+			continue
+		}
+		if a.Amount.Currency != req.Budget.Currency {
+			plan.Excluded = append(plan.Excluded, a.ID)
+			continue
+		}
+		d := &debt{asset: a, balance: a.Amount.Amount}
+		if a.Liability != nil {
+			d.rate = a.Liability.AnnualRate
+		}
+		debts = append(debts, d)
+	}
+	if err := order(debts, req.Strategy, req.Order); err != nil {
+		return nil, err
+	}
+
+	plan.Debts = make([]Debt, len(debts))
+	for i, d := range debts {
+		plan.Debts[i] = Debt{AssetID: d.asset.ID, Name: d.asset.Name, Type: d.asset.Type, Balance: d.balance, AnnualRate: d.rate}
+	}
+	if err := simulate(plan, debts, start); err != nil {
+		return nil, err
+	}
+	plan.Goals = goalStatuses(plan, owned)
+	return plan, nil
+}
+
+// Compare 스노볼과 애벌랜치 전략으로, 순서를 지정했다면 사용자 지정 순서로도 계획을 세워 함께 반환합니다.
+func (p *Planner) Compare(ctx context.Context, req Request) ([]*Plan, error) {
+	strategies := []Strategy{Snowball, Avalanche}
+	if len(req.Order) > 0 {
+		strategies = append(strategies, Custom)
+	}
+	plans := make([]*Plan, 0, len(strategies))
+	for _, strategy := range strategies {
+		req.Strategy = strategy
+		plan, err := p.Plan(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// order 전략에 따라 추가 상환금을 배분할 순서로 부채를 정렬합니다.
+func order(debts []*debt, strategy Strategy, custom []string) error {
+	snowball := func(a, b *debt) bool {
+		if a.balance != b.balance {
+			return a.balance < b.balance
+		}
+		if a.rate != b.rate {
+			return a.rate > b.rate
+		}
+		return a.asset.ID < b.asset.ID
+	}
+
+	switch strategy {
+	case Avalanche:
+		sort.SliceStable(debts, func(i, j int) bool {
+			if debts[i].rate != debts[j].rate {
+				return debts[i].rate > debts[j].rate
+			}
+			return snowball(debts[i], debts[j])
+		})
+	case Custom:
+		rank := make(map[string]int, len(custom))
+		for i, id := range custom {
+			rank[id] = i + 1
+		}
+		found := 0
+		for _, d := range debts {
+			if rank[d.asset.ID] > 0 {
+				found++
+			}
+		}
+		if found != len(custom) {
+			return invalid("order contains an unknown or duplicated liability")
+		}
+		sort.SliceStable(debts, func(i, j int) bool {
+			ri, rj := rank[debts[i].asset.ID], rank[debts[j].asset.ID]
+			switch {
+			case ri > 0 && rj > 0:
+				return ri < rj
+			case ri > 0 || rj > 0:
+				return ri > 0
+			default:
+				return snowball(debts[i], debts[j])
+			}
+		})
+	default:
+		sort.SliceStable(debts, func(i, j int) bool { return snowball(debts[i], debts[j]) })
+	}
+	return nil
+}
+
+// simulate 부채를 모두 갚을 때까지 매월 상환 내역을 계획에 기록합니다.
+func simulate(plan *Plan, debts []*debt, start time.Time) error {
+	budget := plan.Budget.Amount
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for n := 1; remaining(debts) > 0; n++ {
+		if n > MaxMonths {
+			return invalid(fmt.Sprintf("debts cannot be paid off within %d months on this budget", MaxMonths))
+		}
+		date := first.AddDate(0, n, 0)
+
+		interest := make([]float64, len(debts))
+		minimum := make([]float64, len(debts))
+		var required float64
+		for i, d := range debts {
+			if d.balance <= 0 {
+				continue
+			}
+			interest[i] = roundMoney(d.balance * d.rate / 100 / 12)
+			minimum[i] = minimumPayment(d, interest[i], date.AddDate(0, -1, 0))
+			required += minimum[i]
+		}
+		if n == 1 && required > budget {
+			return invalid(fmt.Sprintf("budget %.2f does not cover minimum payments %.2f", budget, required))
+		}
+
+		// 최소 상환금을 먼저 내고 남은 예산은 순서대로 다음 부채에 넘깁니다
+		left := budget
+		paid := make([]float64, len(debts))
+		for i := range debts {
+			paid[i] = math.Min(minimum[i], left)
+			left -= paid[i]
+		}
+		for i, d := range debts {
+			extra := math.Min(left, d.balance+interest[i]-paid[i])
+			if extra > 0 {
+				paid[i] += extra
+				left -= extra
+			}
+		}
+
+		month := Month{Number: n, Date: date}
+		for i, d := range debts {
+			if d.balance <= 0 {
+				continue
+			}
+			payment := roundMoney(paid[i])
+			d.balance = roundMoney(d.balance + interest[i] - payment)
+			if d.balance < 0.01 {
+				d.balance = 0
+				plan.Debts[i].PaidOffAt = date
+			}
+			plan.Debts[i].Interest = roundMoney(plan.Debts[i].Interest + interest[i])
+			if n == 1 {
+				plan.Debts[i].Minimum = minimum[i]
+			}
+			month.Payments = append(month.Payments, Payment{
+				AssetID:   d.asset.ID,
+				Payment:   payment,
+				Interest:  interest[i],
+				Principal: roundMoney(payment - interest[i]),
+				Balance:   d.balance,
+			})
+			month.Payment += payment
+			month.Interest += interest[i]
+		}
+		month.Payment = roundMoney(month.Payment)
+		month.Interest = roundMoney(month.Interest)
+		month.Balance = roundMoney(remaining(debts))
+
+		plan.Months = append(plan.Months, month)
+		plan.TotalPayment = roundMoney(plan.TotalPayment + month.Payment)
+		plan.TotalInterest = roundMoney(plan.TotalInterest + month.Interest)
+		plan.PayoffDate = date
+	}
+	return nil
+}
+
+// minimumPayment 이번 달에 내야 할 최소 상환금입니다.
+// 만기가 있는 부채는 남은 원금으로 다시 계산한 상환 일정의 한 달치 금액을, 만기가 없는 부채는 이자에 잔액의 일정 비율을 더한 금액을 사용합니다.
+func minimumPayment(d *debt, interest float64, after time.Time) float64 {
+	due := interest + d.balance*RevolvingMinimumRate
+	if terms := d.asset.Liability; terms != nil && terms.Term > 0 {
+		if schedule := terms.Remaining(d.balance, after); len(schedule) > 0 {
+			due = schedule[0].Payment * float64(terms.Frequency.PeriodsPerYear()) / 12
+		}
+	}
+	return roundMoney(math.Min(due, d.balance+interest))
+}
+
+func remaining(debts []*debt) float64 {
+	var total float64
+	for _, d := range debts {
+		total += d.balance
+	}
+	return total
+}
+
+// goalStatuses 사용자 자산의 빚 청산 목표가 계획대로면 기한 안에 달성되는지 계산합니다.
+// 부채 자산의 목표는 그 부채의 상환 완료일을, 그 밖의 자산에 둔 목표는 전체 상환 완료일을 기한과 비교합니다.
+func goalStatuses(plan *Plan, owned []*asset.Asset) []GoalStatus {
+	paidOff := make(map[string]time.Time, len(plan.Debts))
+	for _, d := range plan.Debts {
+		paidOff[d.AssetID] = d.PaidOffAt
+	}
+
+	var statuses []GoalStatus
+	for _, a := range owned {
+		done := plan.PayoffDate
+		if a.Type.IsLiability() {
+			at, ok := paidOff[a.ID]
+			if !ok && a.Amount.IsPositive() {
+				// 통화가 달라 계획에서 빠진 부채는 판단할 수 없습니다
+				continue
+			}
+			done = at
+		}
+		for _, goal := range a.Goals {
+			if goal.Type != asset.GoalTypeDebtFree {
+				continue
+			}
+			statuses = append(statuses, GoalStatus{
+				AssetID:  a.ID,
+				GoalID:   goal.ID,
+				Progress: goal.Progress,
+				Deadline: goal.Deadline,
+				OnTrack:  goal.Deadline.IsZero() || !done.After(goal.Deadline),
+			})
+		}
+	}
+	return statuses
+}
+
+// roundMoney 금액을 소수점 둘째 자리로 반올림합니다.
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
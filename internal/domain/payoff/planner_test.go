@@ -0,0 +1,177 @@
+package payoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+var start = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func krw(amount float64) asset.Money {
+	return asset.Money{Amount: amount, Currency: "KRW"}
+}
+
+type plannerFixture struct {
+	ctx     context.Context
+	assets  *asset.MemoryAssetRepository
+	planner *Planner
+	card    *asset.Asset
+	loan    *asset.Asset
+	student *asset.Asset
+}
+
+func newPlannerFixture(t *testing.T) *plannerFixture {
+	t.Helper()
+	f := &plannerFixture{ctx: context.Background(), assets: asset.NewMemoryAssetRepository()}
+	f.planner = NewPlanner(f.assets, WithClock(func() time.Time { return start }))
+	f.card = f.liability(t, asset.CreditCard, "신용카드", 1500000, 18, 0)
+	f.loan = f.liability(t, asset.Loan, "자동차 대출", 2000000, 6, 24)
+	f.student = f.liability(t, asset.Loan, "학자금 대출", 1000000, 3, 0)
+	return f
+}
+
+func (f *plannerFixture) liability(t *testing.T, liabilityType asset.Type, name string, principal, rate float64, term int) *asset.Asset {
+	t.Helper()
+	terms, err := asset.NewLiability(krw(principal), rate, term, asset.Monthly, asset.EqualInstallment, start)
+	require.NoError(t, err)
+	a, err := asset.NewLiabilityAsset("user-1", liabilityType, name, terms)
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, a))
+	return a
+}
+
+func Test_Planner_should_order_debts_by_strategy(t *testing.T) {
+	// Given
+	f := newPlannerFixture(t)
+
+	// When
+	plans, err := f.planner.Compare(f.ctx, Request{UserID: "user-1", Budget: krw(300000), Order: []string{f.loan.ID}})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, plans, 3)
+	snowball, avalanche, custom := plans[0], plans[1], plans[2]
+	assert.Equal(t, Snowball, snowball.Strategy)
+	assert.Equal(t, f.student.ID, snowball.Debts[0].AssetID)
+	assert.Equal(t, f.card.ID, avalanche.Debts[0].AssetID)
+	assert.Equal(t, f.loan.ID, custom.Debts[0].AssetID)
+	assert.Equal(t, f.student.ID, custom.Debts[1].AssetID)
+	assert.Less(t, avalanche.TotalInterest, snowball.TotalInterest)
+	assert.True(t, snowball.Debts[0].PaidOffAt.Before(avalanche.Debts[2].PaidOffAt))
+
+	for _, plan := range plans {
+		last := plan.Months[len(plan.Months)-1]
+		assert.Zero(t, last.Balance)
+		assert.Equal(t, last.Date, plan.PayoffDate)
+		assert.InDelta(t, 4500000+plan.TotalInterest, plan.TotalPayment, 0.05)
+		for _, month := range plan.Months[:len(plan.Months)-1] {
+			assert.InDelta(t, 300000, month.Payment, 0.01)
+		}
+	}
+}
+
+func Test_Planner_should_pay_minimums_before_extra(t *testing.T) {
+	// Given
+	f := newPlannerFixture(t)
+
+	// When
+	plan, err := f.planner.Plan(f.ctx, Request{UserID: "user-1", Budget: krw(300000), Strategy: Avalanche})
+
+	// Then
+	require.NoError(t, err)
+	first := plan.Months[0]
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), first.Date)
+	require.Len(t, first.Payments, 3)
+	// 학자금 대출: 이자 2,500원 + 잔액의 2%
+	student := first.Payments[2]
+	assert.Equal(t, f.student.ID, student.AssetID)
+	assert.Equal(t, 2500.0, student.Interest)
+	assert.Equal(t, 22500.0, student.Payment)
+	// 자동차 대출: 24개월 원리금 균등 상환금
+	loan := first.Payments[1]
+	assert.InDelta(t, 88641.21, loan.Payment, 0.01)
+	assert.Equal(t, 10000.0, loan.Interest)
+	// 남은 예산은 모두 신용카드에
+	assert.InDelta(t, 300000-22500-88641.21, first.Payments[0].Payment, 0.02)
+	assert.Equal(t, 22500.0, plan.Debts[2].Minimum)
+}
+
+func Test_Planner_should_reject_invalid_requests(t *testing.T) {
+	// Given
+	f := newPlannerFixture(t)
+	dollars, err := asset.NewAsset("user-1", asset.CreditCard, "해외 카드", 100, "USD")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, dollars))
+
+	// When
+	_, tooSmall := f.planner.Plan(f.ctx, Request{UserID: "user-1", Budget: krw(50000), Strategy: Snowball})
+	_, unknown := f.planner.Plan(f.ctx, Request{UserID: "user-1", Budget: krw(300000), Strategy: Custom, Order: []string{"missing"}})
+	_, strategy := f.planner.Plan(f.ctx, Request{UserID: "user-1", Budget: krw(300000), Strategy: "RANDOM"})
+	empty, err := f.planner.Plan(f.ctx, Request{UserID: "user-2", Budget: krw(300000), Strategy: Snowball})
+	require.NoError(t, err)
+	plan, err := f.planner.Plan(f.ctx, Request{UserID: "user-1", Budget: krw(300000), Strategy: Snowball})
+	require.NoError(t, err)
+
+	// Then
+	assert.Error(t, tooSmall)
+	assert.Error(t, unknown)
+	assert.Error(t, strategy)
+	assert.Empty(t, empty.Months)
+	assert.Equal(t, start, empty.PayoffDate)
+	assert.Equal(t, []string{dollars.ID}, plan.Excluded)
+	assert.Len(t, plan.Debts, 3)
+}
+
+func Test_Planner_should_sync_debt_free_goals_on_events(t *testing.T) {
+	// Given
+	f := newPlannerFixture(t)
+	cash, err := asset.NewAsset("user-1", asset.Cash, "입출금", 1000000, "KRW")
+	require.NoError(t, err)
+	overall := cash.AddGoal(asset.GoalTypeDebtFree, krw(0), time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, f.assets.Save(f.ctx, cash))
+	loan, err := f.assets.FindByID(f.ctx, f.student.ID)
+	require.NoError(t, err)
+	goal := loan.AddGoal(asset.GoalTypeDebtFree, krw(0), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	repayment, err := loan.PlanRepayment(cash.ID, krw(502500))
+	require.NoError(t, err)
+	require.NoError(t, loan.ReceiveTransfer(repayment.Principal))
+	// 목표 진행률이 아직 반영되지 않은 상태로 저장합니다
+	goal.Progress = 0
+	require.NoError(t, f.assets.Update(f.ctx, loan))
+
+	bus := memory.NewEventBus()
+	require.NoError(t, bus.Subscribe(f.planner))
+
+	// When
+	require.NoError(t, bus.Publish(f.ctx, event.NewEvent(event.TypeAssetAmountChanged, loan.ID, "asset", nil, map[string]string{"userID": "user-1"}, 1)))
+
+	// Then
+	saved, err := f.assets.FindByID(f.ctx, loan.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 50, saved.Goals[0].Progress, 1e-9)
+	savedCash, err := f.assets.FindByID(f.ctx, cash.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 500000.0/4500000*100, savedCash.Goals[0].Progress, 1e-9)
+	updated, err := f.planner.SyncGoals(f.ctx, "user-1")
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+
+	plan, err := f.planner.Plan(f.ctx, Request{UserID: "user-1", Budget: krw(300000), Strategy: Avalanche})
+	require.NoError(t, err)
+	require.Len(t, plan.Goals, 2)
+	byGoal := map[string]GoalStatus{}
+	for _, status := range plan.Goals {
+		byGoal[status.GoalID] = status
+	}
+	assert.True(t, byGoal[overall.ID].OnTrack)
+	assert.False(t, byGoal[goal.ID].OnTrack)
+	assert.InDelta(t, 50, byGoal[goal.ID].Progress, 1e-9)
+}
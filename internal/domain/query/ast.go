@@ -0,0 +1,86 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operator 비교 연산자를 나타냅니다.
+type Operator string
+
+const (
+	OpEq       Operator = "="
+	OpNe       Operator = "!="
+	OpGt       Operator = ">"
+	OpGte      Operator = ">="
+	OpLt       Operator = "<"
+	OpLte      Operator = "<="
+	OpContains Operator = "~"
+	OpIn       Operator = "in"
+	OpNotIn    Operator = "not in"
+)
+
+// Node 쿼리 AST의 노드 인터페이스입니다.
+type Node interface {
+	String() string
+}
+
+// And 두 조건을 모두 만족해야 하는 논리곱 노드입니다.
+type And struct {
+	Left  Node
+	Right Node
+}
+
+func (n *And) String() string {
+	return fmt.Sprintf("(%s and %s)", n.Left, n.Right)
+}
+
+// Or 두 조건 중 하나를 만족하면 되는 논리합 노드입니다.
+type Or struct {
+	Left  Node
+	Right Node
+}
+
+func (n *Or) String() string {
+	return fmt.Sprintf("(%s or %s)", n.Left, n.Right)
+}
+
+// Not 조건을 부정하는 노드입니다.
+type Not struct {
+	Expr Node
+}
+
+func (n *Not) String() string {
+	return fmt.Sprintf("not %s", n.Expr)
+}
+
+// Comparison 필드와 값을 비교하는 노드입니다.
+// Value는 string, float64, bool 중 하나이며 in 연산자의 경우 []interface{}입니다.
+type Comparison struct {
+	Field string
+	Op    Operator
+	Value interface{}
+}
+
+func (n *Comparison) String() string {
+	if values, ok := n.Value.([]interface{}); ok {
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = formatLiteral(v)
+		}
+		return fmt.Sprintf("%s %s (%s)", n.Field, n.Op, strings.Join(parts, ","))
+	}
+	return fmt.Sprintf("%s %s %s", n.Field, n.Op, formatLiteral(n.Value))
+}
+
+func formatLiteral(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return strconv.Quote(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,89 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Criteria 필터 언어로 작성된 검색 조건입니다.
+// domain.SearchCriteria와 domain.Matcher를 구현하므로 SQL 저장소와 인메모리 저장소 모두에서 사용할 수 있습니다.
+//
+// 예: type in (STOCK,BOND) and amount.amount > 1000 and name ~ "삼성"
+type Criteria struct {
+	source string
+	root   Node
+}
+
+// Parse 필터 문자열을 파싱하여 Criteria를 생성합니다.
+func Parse(input string) (*Criteria, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, newParseError(0, "빈 쿼리입니다")
+	}
+
+	root, err := parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return &Criteria{source: input, root: root}, nil
+}
+
+// MustParse Parse와 같지만 실패하면 panic을 발생시킵니다.
+func MustParse(input string) *Criteria {
+	c, err := Parse(input)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Root 파싱된 AST의 루트 노드를 반환합니다.
+func (c *Criteria) Root() Node {
+	return c.root
+}
+
+// String 원본 쿼리 문자열을 반환합니다.
+func (c *Criteria) String() string {
+	return c.source
+}
+
+// ToQuery 기본 컬럼 매핑으로 SQL WHERE 절과 바인딩 인자를 생성합니다.
+func (c *Criteria) ToQuery() (string, []interface{}) {
+	return c.ToSQL(SnakeCaseColumns)
+}
+
+// ColumnMapper 필드 경로를 저장소의 컬럼(또는 문서 필드) 이름으로 변환합니다.
+type ColumnMapper func(field string) string
+
+// SnakeCaseColumns 필드 경로를 snake_case 컬럼으로 변환합니다. (amount.amount -> amount_amount)
+func SnakeCaseColumns(field string) string {
+	segments := strings.Split(field, ".")
+	for i, segment := range segments {
+		segments[i] = toSnakeCase(segment)
+	}
+	return strings.Join(segments, "_")
+}
+
+// SnakeCaseFields 필드 경로의 각 구간을 snake_case로 변환하고 점 표기를 유지합니다. (userId -> user_id)
+func SnakeCaseFields(field string) string {
+	segments := strings.Split(field, ".")
+	for i, segment := range segments {
+		segments[i] = toSnakeCase(segment)
+	}
+	return strings.Join(segments, ".")
+}
+
+func toSnakeCase(s string) string {
+	var sb strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteRune('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,235 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Match 엔티티가 검색 조건을 만족하는지 인메모리로 평가합니다.
+// 필드 경로는 구조체 필드 이름(대소문자 무시) 또는 json 태그로 해석됩니다.
+func (c *Criteria) Match(entity interface{}) (bool, error) {
+	return evaluate(c.root, reflect.ValueOf(entity))
+}
+
+func evaluate(node Node, entity reflect.Value) (bool, error) {
+	switch n := node.(type) {
+	case *And:
+		left, err := evaluate(n.Left, entity)
+		if err != nil || !left {
+			return false, err
+		}
+		return evaluate(n.Right, entity)
+	case *Or:
+		left, err := evaluate(n.Left, entity)
+		if err != nil {
+			return false, err
+		}
+		if left {
+			return true, nil
+		}
+		return evaluate(n.Right, entity)
+	case *Not:
+		result, err := evaluate(n.Expr, entity)
+		return !result, err
+	case *Comparison:
+		actual, err := resolveField(entity, n.Field)
+		if err != nil {
+			return false, err
+		}
+		return compare(actual, n.Op, n.Value)
+	}
+	return false, fmt.Errorf("지원하지 않는 노드입니다: %T", node)
+}
+
+// resolveField 점으로 구분된 필드 경로를 따라 값을 찾습니다.
+func resolveField(v reflect.Value, path string) (interface{}, error) {
+	for _, segment := range strings.Split(path, ".") {
+		v = indirect(v)
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := findField(v, segment)
+			if !ok {
+				return nil, fmt.Errorf("알 수 없는 필드입니다: %s", path)
+			}
+			v = field
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("알 수 없는 필드입니다: %s", path)
+			}
+			v = v.MapIndex(reflect.ValueOf(segment).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return nil, nil
+			}
+		default:
+			return nil, fmt.Errorf("알 수 없는 필드입니다: %s", path)
+		}
+	}
+
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+	return normalize(v), nil
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func findField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := strings.Split(sf.Tag.Get("json"), ",")[0]
+		if strings.EqualFold(sf.Name, name) || (tag != "" && tag == name) ||
+			strings.EqualFold(sf.Name, strings.ReplaceAll(name, "_", "")) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// normalize 비교를 위해 값을 float64, string, bool, time.Time 중 하나로 변환합니다.
+func normalize(v reflect.Value) interface{} {
+	if t, ok := v.Interface().(time.Time); ok {
+		return t
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok && v.Kind() == reflect.Struct {
+		return s.String()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func compare(actual interface{}, op Operator, literal interface{}) (bool, error) {
+	switch op {
+	case OpIn, OpNotIn:
+		values, _ := literal.([]interface{})
+		found := false
+		for _, value := range values {
+			cmp, ok := compareValues(actual, value)
+			if ok && cmp == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (op == OpIn), nil
+	case OpContains:
+		if actual == nil {
+			return false, nil
+		}
+		return strings.Contains(strings.ToLower(fmt.Sprint(actual)), strings.ToLower(fmt.Sprint(literal))), nil
+	}
+
+	cmp, ok := compareValues(actual, literal)
+	switch op {
+	case OpEq:
+		return ok && cmp == 0, nil
+	case OpNe:
+		return !ok || cmp != 0, nil
+	case OpGt:
+		return ok && cmp > 0, nil
+	case OpGte:
+		return ok && cmp >= 0, nil
+	case OpLt:
+		return ok && cmp < 0, nil
+	case OpLte:
+		return ok && cmp <= 0, nil
+	}
+	return false, fmt.Errorf("지원하지 않는 연산자입니다: %s", op)
+}
+
+// compareValues 실제 값과 리터럴을 비교합니다. 비교할 수 없으면 ok가 false입니다.
+func compareValues(actual, literal interface{}) (int, bool) {
+	if actual == nil {
+		return 0, false
+	}
+
+	switch a := actual.(type) {
+	case float64:
+		b, ok := toFloat(literal)
+		if !ok {
+			return 0, false
+		}
+		return compareOrdered(a, b), true
+	case bool:
+		b, ok := literal.(bool)
+		if !ok {
+			parsed, err := strconv.ParseBool(fmt.Sprint(literal))
+			if err != nil {
+				return 0, false
+			}
+			b = parsed
+		}
+		if a == b {
+			return 0, true
+		}
+		return 1, true
+	case time.Time:
+		b, ok := toTime(literal)
+		if !ok {
+			return 0, false
+		}
+		return a.Compare(b), true
+	case string:
+		return strings.Compare(a, fmt.Sprint(literal)), true
+	}
+	return 0, false
+}
+
+func compareOrdered(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case string:
+		f, err := strconv.ParseFloat(val, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toTime(v interface{}) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
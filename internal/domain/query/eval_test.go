@@ -0,0 +1,86 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMoney struct {
+	Amount   float64
+	Currency string
+}
+
+type testAssetType string
+
+type testAsset struct {
+	ID        string
+	UserID    string `json:"userId"`
+	Type      testAssetType
+	Name      string
+	Amount    testMoney
+	Tags      map[string]string
+	Active    bool
+	CreatedAt time.Time
+	secret    string
+}
+
+func newTestAsset() *testAsset {
+	return &testAsset{
+		ID:        "asset-1",
+		UserID:    "user-1",
+		Type:      "STOCK",
+		Name:      "삼성전자",
+		Amount:    testMoney{Amount: 1500, Currency: "KRW"},
+		Tags:      map[string]string{"sector": "tech"},
+		Active:    true,
+		CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		secret:    "hidden",
+	}
+}
+
+func TestCriteria_Match(t *testing.T) {
+	asset := newTestAsset()
+
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{`type in (STOCK,BOND) and amount.amount > 1000 and name ~ "삼성"`, true},
+		{`type in (CASH,BOND)`, false},
+		{`type not in (CASH)`, true},
+		{`amount.amount >= 1500 and amount.amount <= 1500`, true},
+		{`amount.amount < 1000 or amount.currency = KRW`, true},
+		{`not amount.currency = KRW`, false},
+		{`name ~ "전자"`, true},
+		{`name ~ "lg"`, false},
+		{`userId = "user-1"`, true},
+		{`user_id = "user-1"`, true},
+		{`tags.sector = tech`, true},
+		{`tags.missing = tech`, false},
+		{`tags.missing != tech`, true},
+		{`active = true`, true},
+		{`createdAt > "2025-01-01"`, true},
+		{`createdAt < "2025-02-01T00:00:00Z"`, false},
+		{`amount.amount > "abc"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			ok, err := MustParse(tt.query).Match(asset)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
+
+func TestCriteria_Match_UnknownField(t *testing.T) {
+	asset := newTestAsset()
+
+	for _, q := range []string{`price > 10`, `secret = hidden`, `name.first = a`} {
+		_, err := MustParse(q).Match(asset)
+		assert.Error(t, err, q)
+	}
+}
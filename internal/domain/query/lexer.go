@@ -0,0 +1,176 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind 토큰의 종류를 나타냅니다.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+// token 렉서가 만들어내는 토큰입니다.
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// lexer 쿼리 문자열을 토큰으로 분리합니다.
+type lexer struct {
+	input string
+	pos   int
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: input}
+}
+
+// tokenize 입력 전체를 토큰 목록으로 변환합니다.
+func (l *lexer) tokenize() ([]token, error) {
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpaces()
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+
+	switch {
+	case r == '(':
+		l.pos += size
+		return token{kind: tokenLParen, value: "(", pos: start}, nil
+	case r == ')':
+		l.pos += size
+		return token{kind: tokenRParen, value: ")", pos: start}, nil
+	case r == ',':
+		l.pos += size
+		return token{kind: tokenComma, value: ",", pos: start}, nil
+	case r == '"' || r == '\'':
+		return l.readString(r)
+	case strings.ContainsRune("=!<>~", r):
+		return l.readOperator()
+	case unicode.IsDigit(r) || ((r == '-' || r == '+') && l.peekDigit(size)):
+		return l.readNumber(), nil
+	case isIdentRune(r):
+		return l.readIdent(), nil
+	}
+
+	return token{}, newParseError(start, "알 수 없는 문자 '%c'", r)
+}
+
+func (l *lexer) skipSpaces() {
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		l.pos += size
+	}
+}
+
+func (l *lexer) peekDigit(offset int) bool {
+	if l.pos+offset >= len(l.input) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.pos+offset:])
+	return unicode.IsDigit(r)
+}
+
+func (l *lexer) readString(quote rune) (token, error) {
+	start := l.pos
+	l.pos++ // 여는 따옴표
+
+	var sb strings.Builder
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		l.pos += size
+		switch r {
+		case '\\':
+			if l.pos >= len(l.input) {
+				return token{}, newParseError(start, "문자열이 닫히지 않았습니다")
+			}
+			escaped, escSize := utf8.DecodeRuneInString(l.input[l.pos:])
+			l.pos += escSize
+			sb.WriteRune(escaped)
+		case quote:
+			return token{kind: tokenString, value: sb.String(), pos: start}, nil
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return token{}, newParseError(start, "문자열이 닫히지 않았습니다")
+}
+
+func (l *lexer) readOperator() (token, error) {
+	start := l.pos
+	for _, op := range []string{">=", "<=", "!=", "<>", "==", "=", ">", "<", "~"} {
+		if strings.HasPrefix(l.input[l.pos:], op) {
+			l.pos += len(op)
+			switch op {
+			case "==":
+				op = string(OpEq)
+			case "<>":
+				op = string(OpNe)
+			}
+			return token{kind: tokenOperator, value: op, pos: start}, nil
+		}
+	}
+	return token{}, newParseError(start, "알 수 없는 연산자")
+}
+
+func (l *lexer) readNumber() token {
+	start := l.pos
+	if l.input[l.pos] == '-' || l.input[l.pos] == '+' {
+		l.pos++
+	}
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		if (c < '0' || c > '9') && c != '.' && c != '_' {
+			break
+		}
+		l.pos++
+	}
+	return token{kind: tokenNumber, value: strings.ReplaceAll(l.input[start:l.pos], "_", ""), pos: start}
+}
+
+func (l *lexer) readIdent() token {
+	start := l.pos
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !isIdentRune(r) && !unicode.IsDigit(r) && r != '.' {
+			break
+		}
+		l.pos += size
+	}
+	return token{kind: tokenIdent, value: l.input[start:l.pos], pos: start}
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
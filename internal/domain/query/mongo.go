@@ -0,0 +1,78 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ToMongoFilter 검색 조건을 MongoDB 필터 문서로 변환합니다.
+// 반환값은 bson.M과 같은 형태이므로 repository.WithMongoFilter(bson.M(filter))로 전달할 수 있습니다.
+// fields가 nil이면 SnakeCaseFields를 사용합니다.
+func (c *Criteria) ToMongoFilter(fields ColumnMapper) map[string]interface{} {
+	if fields == nil {
+		fields = SnakeCaseFields
+	}
+	return mongoFilter(c.root, fields)
+}
+
+func mongoFilter(node Node, fields ColumnMapper) map[string]interface{} {
+	switch n := node.(type) {
+	case *And:
+		return map[string]interface{}{"$and": flatten(n, fields)}
+	case *Or:
+		return map[string]interface{}{"$or": flatten(n, fields)}
+	case *Not:
+		return map[string]interface{}{"$nor": []interface{}{mongoFilter(n.Expr, fields)}}
+	case *Comparison:
+		return map[string]interface{}{fields(n.Field): mongoCondition(n)}
+	}
+	return map[string]interface{}{}
+}
+
+// flatten 같은 종류의 연속된 논리 노드를 하나의 배열로 펼칩니다.
+func flatten(node Node, fields ColumnMapper) []interface{} {
+	var result []interface{}
+	switch n := node.(type) {
+	case *And:
+		for _, child := range []Node{n.Left, n.Right} {
+			if _, same := child.(*And); same {
+				result = append(result, flatten(child, fields)...)
+			} else {
+				result = append(result, mongoFilter(child, fields))
+			}
+		}
+	case *Or:
+		for _, child := range []Node{n.Left, n.Right} {
+			if _, same := child.(*Or); same {
+				result = append(result, flatten(child, fields)...)
+			} else {
+				result = append(result, mongoFilter(child, fields))
+			}
+		}
+	}
+	return result
+}
+
+func mongoCondition(n *Comparison) interface{} {
+	switch n.Op {
+	case OpEq:
+		return n.Value
+	case OpNe:
+		return map[string]interface{}{"$ne": n.Value}
+	case OpGt:
+		return map[string]interface{}{"$gt": n.Value}
+	case OpGte:
+		return map[string]interface{}{"$gte": n.Value}
+	case OpLt:
+		return map[string]interface{}{"$lt": n.Value}
+	case OpLte:
+		return map[string]interface{}{"$lte": n.Value}
+	case OpIn:
+		return map[string]interface{}{"$in": n.Value}
+	case OpNotIn:
+		return map[string]interface{}{"$nin": n.Value}
+	case OpContains:
+		return map[string]interface{}{"$regex": regexp.QuoteMeta(fmt.Sprint(n.Value)), "$options": "i"}
+	}
+	return n.Value
+}
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCriteria_ToMongoFilter(t *testing.T) {
+	criteria := MustParse(`type in (STOCK,BOND) and amount.amount > 1000 and name ~ "삼성(주)"`)
+
+	filter := criteria.ToMongoFilter(nil)
+
+	assert.Equal(t, map[string]interface{}{
+		"$and": []interface{}{
+			map[string]interface{}{"type": map[string]interface{}{"$in": []interface{}{"STOCK", "BOND"}}},
+			map[string]interface{}{"amount.amount": map[string]interface{}{"$gt": 1000.0}},
+			map[string]interface{}{"name": map[string]interface{}{"$regex": `삼성\(주\)`, "$options": "i"}},
+		},
+	}, filter)
+}
+
+func TestCriteria_ToMongoFilter_Operators(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected map[string]interface{}
+	}{
+		{`userId = "u1"`, map[string]interface{}{"user_id": "u1"}},
+		{`a != 1`, map[string]interface{}{"a": map[string]interface{}{"$ne": 1.0}}},
+		{`a >= 1`, map[string]interface{}{"a": map[string]interface{}{"$gte": 1.0}}},
+		{`a < 1`, map[string]interface{}{"a": map[string]interface{}{"$lt": 1.0}}},
+		{`a <= 1`, map[string]interface{}{"a": map[string]interface{}{"$lte": 1.0}}},
+		{`a not in (x)`, map[string]interface{}{"a": map[string]interface{}{"$nin": []interface{}{"x"}}}},
+		{`not a = 1`, map[string]interface{}{"$nor": []interface{}{map[string]interface{}{"a": 1.0}}}},
+		{`a = 1 or b = 2 or c = 3`, map[string]interface{}{"$or": []interface{}{
+			map[string]interface{}{"a": 1.0},
+			map[string]interface{}{"b": 2.0},
+			map[string]interface{}{"c": 3.0},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MustParse(tt.query).ToMongoFilter(nil))
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError 쿼리 구문 오류를 나타냅니다.
+type ParseError struct {
+	Pos     int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("쿼리 구문 오류 (위치 %d): %s", e.Pos, e.Message)
+}
+
+func newParseError(pos int, format string, args ...interface{}) error {
+	return &ParseError{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// parser 토큰 목록을 AST로 변환하는 재귀 하향 파서입니다.
+//
+//	expr       := or
+//	or         := and ("or" and)*
+//	and        := unary ("and" unary)*
+//	unary      := "not" unary | primary
+//	primary    := "(" expr ")" | comparison
+//	comparison := field op value | field ["not"] "in" "(" value ("," value)* ")"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(input string) (Node, error) {
+	tokens, err := newLexer(input).tokenize()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, newParseError(tok.pos, "예상하지 못한 토큰 '%s'", tok.value)
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && strings.EqualFold(tok.value, keyword)
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.advance()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.isKeyword("not") {
+		p.advance()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.peek()
+	if tok.kind == tokenLParen {
+		p.advance()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokenRParen {
+			return nil, newParseError(closing.pos, "')'가 필요합니다")
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	field := p.advance()
+	if field.kind != tokenIdent {
+		return nil, newParseError(field.pos, "필드 이름이 필요합니다")
+	}
+
+	switch {
+	case p.isKeyword("in"):
+		p.advance()
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &Comparison{Field: field.value, Op: OpIn, Value: values}, nil
+	case p.isKeyword("not"):
+		p.advance()
+		if !p.isKeyword("in") {
+			return nil, newParseError(p.peek().pos, "'not' 뒤에는 'in'이 필요합니다")
+		}
+		p.advance()
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &Comparison{Field: field.value, Op: OpNotIn, Value: values}, nil
+	}
+
+	op := p.advance()
+	if op.kind != tokenOperator {
+		return nil, newParseError(op.pos, "'%s' 뒤에 연산자가 필요합니다", field.value)
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return &Comparison{Field: field.value, Op: Operator(op.value), Value: value}, nil
+}
+
+func (p *parser) parseList() ([]interface{}, error) {
+	if open := p.advance(); open.kind != tokenLParen {
+		return nil, newParseError(open.pos, "'('가 필요합니다")
+	}
+
+	var values []interface{}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		next := p.advance()
+		switch next.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return values, nil
+		default:
+			return nil, newParseError(next.pos, "',' 또는 ')'가 필요합니다")
+		}
+	}
+}
+
+func (p *parser) parseValue() (interface{}, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokenString:
+		return tok.value, nil
+	case tokenNumber:
+		n, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, newParseError(tok.pos, "잘못된 숫자 '%s'", tok.value)
+		}
+		return n, nil
+	case tokenIdent:
+		switch strings.ToLower(tok.value) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		// 따옴표 없는 단어는 문자열로 취급합니다 (예: type = STOCK)
+		return tok.value, nil
+	}
+	return nil, newParseError(tok.pos, "값이 필요합니다")
+}
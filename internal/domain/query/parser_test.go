@@ -0,0 +1,85 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "단순 비교",
+			input:    `amount.amount > 1000`,
+			expected: `amount.amount > 1000`,
+		},
+		{
+			name:     "in 목록과 문자열 포함",
+			input:    `type in (STOCK,BOND) and amount.amount > 1000 and name ~ "삼성"`,
+			expected: `((type in ("STOCK","BOND") and amount.amount > 1000) and name ~ "삼성")`,
+		},
+		{
+			name:     "and가 or보다 우선",
+			input:    `a = 1 or b = 2 and c = 3`,
+			expected: `(a = 1 or (b = 2 and c = 3))`,
+		},
+		{
+			name:     "괄호와 not",
+			input:    `not (a = 1 or b != 'x')`,
+			expected: `not (a = 1 or b != "x")`,
+		},
+		{
+			name:     "not in과 대소문자 무시 키워드",
+			input:    `type NOT IN (CASH) AND active = true`,
+			expected: `(type not in ("CASH") and active = true)`,
+		},
+		{
+			name:     "음수와 이스케이프된 문자열",
+			input:    `balance >= -10.5 and name = "a\"b"`,
+			expected: `(balance >= -10.5 and name = "a\"b")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, criteria.Root().String())
+			assert.Equal(t, tt.input, criteria.String())
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "빈 쿼리", input: "  "},
+		{name: "연산자 누락", input: "amount 1000"},
+		{name: "값 누락", input: "amount >"},
+		{name: "닫히지 않은 괄호", input: "(a = 1"},
+		{name: "닫히지 않은 문자열", input: `name = "abc`},
+		{name: "잘못된 in 목록", input: "type in STOCK"},
+		{name: "남은 토큰", input: "a = 1 b = 2"},
+		{name: "알 수 없는 문자", input: "a = 1 & b = 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			require.Error(t, err)
+			var parseErr *ParseError
+			assert.ErrorAs(t, err, &parseErr)
+		})
+	}
+}
+
+func TestMustParse_Panics(t *testing.T) {
+	assert.Panics(t, func() { MustParse("a =") })
+}
@@ -0,0 +1,76 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToSQL 검색 조건을 PostgreSQL 스타일($1, $2 ...) 플레이스홀더를 사용하는 WHERE 절로 변환합니다.
+func (c *Criteria) ToSQL(columns ColumnMapper) (string, []interface{}) {
+	return c.ToSQLFrom(columns, 1)
+}
+
+// ToSQLFrom ToSQL과 같지만 플레이스홀더 번호를 start부터 시작합니다.
+// 다른 조건 뒤에 WHERE 절을 이어 붙일 때 사용합니다.
+func (c *Criteria) ToSQLFrom(columns ColumnMapper, start int) (string, []interface{}) {
+	if columns == nil {
+		columns = SnakeCaseColumns
+	}
+	b := &sqlBuilder{columns: columns, next: start}
+	return b.build(c.root), b.args
+}
+
+type sqlBuilder struct {
+	columns ColumnMapper
+	args    []interface{}
+	next    int
+}
+
+func (b *sqlBuilder) bind(value interface{}) string {
+	b.args = append(b.args, value)
+	placeholder := fmt.Sprintf("$%d", b.next)
+	b.next++
+	return placeholder
+}
+
+func (b *sqlBuilder) build(node Node) string {
+	switch n := node.(type) {
+	case *And:
+		return fmt.Sprintf("(%s AND %s)", b.build(n.Left), b.build(n.Right))
+	case *Or:
+		return fmt.Sprintf("(%s OR %s)", b.build(n.Left), b.build(n.Right))
+	case *Not:
+		return fmt.Sprintf("NOT (%s)", b.build(n.Expr))
+	case *Comparison:
+		return b.comparison(n)
+	}
+	return "FALSE"
+}
+
+func (b *sqlBuilder) comparison(n *Comparison) string {
+	column := b.columns(n.Field)
+
+	switch n.Op {
+	case OpIn, OpNotIn:
+		values, _ := n.Value.([]interface{})
+		placeholders := make([]string, len(values))
+		for i, v := range values {
+			placeholders[i] = b.bind(v)
+		}
+		keyword := "IN"
+		if n.Op == OpNotIn {
+			keyword = "NOT IN"
+		}
+		return fmt.Sprintf("%s %s (%s)", column, keyword, strings.Join(placeholders, ", "))
+	case OpContains:
+		return fmt.Sprintf("%s ILIKE %s", column, b.bind("%"+escapeLike(fmt.Sprint(n.Value))+"%"))
+	case OpNe:
+		return fmt.Sprintf("%s <> %s", column, b.bind(n.Value))
+	}
+	return fmt.Sprintf("%s %s %s", column, n.Op, b.bind(n.Value))
+}
+
+// escapeLike LIKE 패턴의 와일드카드 문자를 이스케이프합니다.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
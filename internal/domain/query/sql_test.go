@@ -0,0 +1,57 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCriteria_ToQuery(t *testing.T) {
+	criteria := MustParse(`type in (STOCK,BOND) and amount.amount > 1000 and name ~ "50%_삼성"`)
+
+	where, args := criteria.ToQuery()
+
+	assert.Equal(t, "((type IN ($1, $2) AND amount_amount > $3) AND name ILIKE $4)", where)
+	assert.Equal(t, []interface{}{"STOCK", "BOND", 1000.0, `%50\%\_삼성%`}, args)
+}
+
+func TestCriteria_ToSQL(t *testing.T) {
+	columns := func(field string) string {
+		if field == "amount.amount" {
+			return "amount"
+		}
+		return SnakeCaseColumns(field)
+	}
+
+	tests := []struct {
+		query string
+		where string
+		args  []interface{}
+	}{
+		{`userId = "u1" or not amount.amount != 3`, "(user_id = $1 OR NOT (amount <> $2))", []interface{}{"u1", 3.0}},
+		{`type not in (CASH)`, "type NOT IN ($1)", []interface{}{"CASH"}},
+		{`createdAt <= "2025-01-01"`, "created_at <= $1", []interface{}{"2025-01-01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			where, args := MustParse(tt.query).ToSQL(columns)
+			assert.Equal(t, tt.where, where)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestCriteria_ToSQLFrom(t *testing.T) {
+	where, args := MustParse(`a = 1 and b = 2`).ToSQLFrom(nil, 3)
+
+	assert.Equal(t, "(a = $3 AND b = $4)", where)
+	assert.Len(t, args, 2)
+}
+
+func TestSnakeCase(t *testing.T) {
+	assert.Equal(t, "user_id", SnakeCaseColumns("userID"))
+	assert.Equal(t, "amount_amount", SnakeCaseColumns("amount.amount"))
+	assert.Equal(t, "amount.currency", SnakeCaseFields("Amount.Currency"))
+	assert.Equal(t, "created_at", SnakeCaseFields("createdAt"))
+}
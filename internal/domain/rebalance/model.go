@@ -0,0 +1,110 @@
+// Package rebalance 포트폴리오의 목표 비중(PortfolioAsset.Weight)과 실제 자산 금액을 비교하여
+// 목표 비중으로 되돌리는 매매 목록을 계산합니다.
+// 허용 오차 밴드, 매도 없이 투입 현금만으로 맞추는 방식, 최소 거래 금액, 매매 단위 반올림을 지원하며
+// 계획을 실행하면 매매를 자금 자산과의 이체 거래로 기록해 자산 금액을 갱신하고 portfolio.rebalanced 이벤트를 발행합니다.
+package rebalance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// Side 매매 방향입니다.
+type Side string
+
+const (
+	Buy  Side = "BUY"
+	Sell Side = "SELL"
+)
+
+// Options 리밸런싱 설정입니다.
+type Options struct {
+	// Tolerance 목표 비중에서 벗어나도 되는 폭(%p)입니다. 모든 자산이 밴드 안에 있으면 매도하지 않고 투입 현금만 배분합니다
+	Tolerance float64
+	// Inflow 새로 투입하는 현금(포트폴리오 통화)입니다. 실행할 때는 자금 자산에서 나갑니다
+	Inflow float64
+	// FundingAssetID 실행할 때 투입 현금을 내고 매도 대금과 남는 현금을 받는 자금 자산입니다. 포트폴리오 밖의 자산이어야 합니다
+	FundingAssetID string
+	// CashOnly 매도 없이 투입 현금으로 비중이 모자란 자산만 삽니다
+	CashOnly bool
+	// MinTrade 이보다 작은 금액의 거래는 하지 않습니다
+	MinTrade float64
+	// DefaultLot 보유 포지션이 있는 자산의 기본 매매 단위입니다. 0이면 소수 단위 거래를 허용합니다
+	DefaultLot float64
+	// Lots 심볼별 매매 단위입니다. DefaultLot보다 우선합니다
+	Lots map[string]float64
+}
+
+// Position 자산 하나의 리밸런싱 전후 비중입니다. 비중은 모두 % 단위입니다.
+type Position struct {
+	AssetID string
+	Name    string
+	Symbol  string
+	Value   float64 // 리밸런싱 전 금액
+	Weight  float64 // 리밸런싱 전 비중
+	Target  float64 // 목표 비중. 목표 비중의 합이 100이 아니면 합에 대한 비율로 환산합니다
+	Drift   float64 // Weight - Target
+	Result  float64 // 거래 후 비중
+}
+
+// Trade 매매 한 건입니다. 보유 포지션이 없는 자산은 금액으로만 거래하며 Quantity와 Price가 0입니다.
+type Trade struct {
+	AssetID  string
+	Symbol   string
+	Side     Side
+	Amount   float64
+	Quantity float64
+	Price    float64
+}
+
+// signed 매수는 양수, 매도는 음수인 금액과 수량을 반환합니다.
+func (t Trade) signed() (float64, float64) {
+	if t.Side == Sell {
+		return -t.Amount, -t.Quantity
+	}
+	return t.Amount, t.Quantity
+}
+
+// transaction 매매를 자금 자산과의 이체 거래로 만듭니다. 매수는 자금 자산에서 자산으로, 매도는 자산에서 자금 자산으로 이체합니다.
+func (t Trade) transaction(fundingAssetID, currency string) (*asset.Transaction, error) {
+	from, to := fundingAssetID, t.AssetID
+	if t.Side == Sell {
+		from, to = t.AssetID, fundingAssetID
+	}
+	description := fmt.Sprintf("리밸런싱 %s", t.Side)
+	if t.Symbol != "" {
+		description = fmt.Sprintf("리밸런싱 %s %s %g", t.Side, t.Symbol, t.Quantity)
+	}
+	tx, err := asset.NewTransaction(from, asset.Transfer, asset.Money{Amount: t.Amount, Currency: currency}, "", description)
+	if err != nil {
+		return nil, err
+	}
+	tx.CounterAssetID = to
+	return tx, nil
+}
+
+// Plan 리밸런싱 계획입니다.
+type Plan struct {
+	PortfolioID string
+	UserID      string
+	Currency    string
+	Invested    float64 // 리밸런싱 전 자산 금액 합계
+	Inflow      float64
+	Positions   []Position
+	Trades      []Trade // 매도 후 매수 순서
+	Cash        float64 // 거래 후 남는 현금 (투입 현금 + 매도 - 매수). 실행하면 자금 자산에 남습니다
+	MaxDrift    float64 // 리밸런싱 전 가장 큰 비중 차이의 절댓값
+	WithinBand  bool    // 모든 자산이 허용 오차 밴드 안에 있었는지 여부
+	// FundingAssetID 실행에 쓴 자금 자산입니다. 계획만 세웠으면 비어 있습니다
+	FundingAssetID string
+	// TransactionIDs 실행하며 기록한 이체 거래의 ID입니다. Trades와 같은 순서입니다
+	TransactionIDs []string
+	CreatedAt      time.Time
+}
+
+func invalid(msg string) error {
+	return domain.NewError("rebalance", domain.ErrCodeInvalidArgument, msg)
+}
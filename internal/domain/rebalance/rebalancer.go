@@ -0,0 +1,471 @@
+package rebalance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+)
+
+// epsilon 금액과 수량 비교에서 부동소수점 오차로 보는 크기입니다.
+const epsilon = 1e-9
+
+// Rebalancer 사용자 포트폴리오의 리밸런싱 계획을 세우고 실행합니다.
+type Rebalancer struct {
+	portfolios   asset.PortfolioRepository
+	assets       asset.Repository
+	transactions asset.TransactionRepository
+	ledger       *ledger.Ledger
+	bus          event.Bus
+	now          func() time.Time
+	mutex        sync.Mutex
+}
+
+// Option 리밸런서 설정 함수입니다.
+type Option func(*Rebalancer)
+
+// WithClock 현재 시각 함수를 지정합니다.
+func WithClock(now func() time.Time) Option {
+	return func(r *Rebalancer) {
+		r.now = now
+	}
+}
+
+// WithLedger 리밸런싱 매매의 이체 거래를 원장에 복식부기 분개로 함께 전기합니다.
+func WithLedger(l *ledger.Ledger) Option {
+	return func(r *Rebalancer) {
+		r.ledger = l
+	}
+}
+
+// NewRebalancer 새로운 리밸런서를 생성합니다. bus가 nil이면 이벤트를 발행하지 않습니다.
+func NewRebalancer(portfolios asset.PortfolioRepository, assets asset.Repository, transactions asset.TransactionRepository, bus event.Bus, opts ...Option) *Rebalancer {
+	r := &Rebalancer{portfolios: portfolios, assets: assets, transactions: transactions, bus: bus, now: time.Now}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Targets 사용자 포트폴리오를 조회합니다.
+func (r *Rebalancer) Targets(ctx context.Context, userID string) (*asset.Portfolio, error) {
+	portfolio, err := r.portfolios.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.NewError("rebalance", domain.ErrCodeNotFound, fmt.Sprintf("portfolio for user %s not found", userID))
+	}
+	return portfolio, nil
+}
+
+// SetTargets 사용자 포트폴리오의 목표 비중을 설정합니다. 포트폴리오가 없으면 새로 만듭니다.
+// 자산은 사용자 소유여야 하며 비중의 합은 0보다 크고 100 이하여야 합니다.
+func (r *Rebalancer) SetTargets(ctx context.Context, userID string, targets []asset.PortfolioAsset) (*asset.Portfolio, error) {
+	var sum float64
+	seen := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		if target.Weight < 0 {
+			return nil, invalid(fmt.Sprintf("weight of asset %s must not be negative", target.AssetID))
+		}
+		if seen[target.AssetID] {
+			return nil, invalid(fmt.Sprintf("asset %s appears more than once", target.AssetID))
+		}
+		seen[target.AssetID] = true
+		a, err := r.assets.FindByID(ctx, target.AssetID)
+		if err != nil || a.UserID != userID {
+			return nil, invalid(fmt.Sprintf("asset %s not found", target.AssetID))
+		}
+		if a.Type.IsLiability() {
+			return nil, invalid(fmt.Sprintf("liability %s cannot be a portfolio asset", target.AssetID))
+		}
+		sum += target.Weight
+	}
+	if sum <= 0 || sum > 100+epsilon {
+		return nil, invalid(fmt.Sprintf("target weights must sum to more than 0 and at most 100, got %g", sum))
+	}
+
+	targets = append([]asset.PortfolioAsset(nil), targets...)
+	portfolio, err := r.portfolios.FindByUserID(ctx, userID)
+	if err != nil {
+		portfolio = asset.NewPortfolio(userID, targets)
+		if err := r.portfolios.Save(ctx, portfolio); err != nil {
+			return nil, err
+		}
+		return portfolio, nil
+	}
+	if err := r.portfolios.UpdateAssets(ctx, portfolio.ID, targets); err != nil {
+		return nil, err
+	}
+	return r.portfolios.FindByID(ctx, portfolio.ID)
+}
+
+// Plan 사용자 포트폴리오의 리밸런싱 계획을 계산합니다. 자산은 바꾸지 않습니다.
+func (r *Rebalancer) Plan(ctx context.Context, userID string, opts Options) (*Plan, error) {
+	portfolio, assets, err := r.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return Compute(portfolio, assets, opts, r.now())
+}
+
+// Rebalance 리밸런싱 계획을 세워 매매를 자금 자산(Options.FundingAssetID)과의 이체 거래로 기록하고,
+// 자산 금액 변경 이벤트와 portfolio.rebalanced 이벤트를 발행합니다.
+// 투입 현금과 매수 대금은 자금 자산에서 나가고 매도 대금은 자금 자산으로 들어오므로 남는 현금은 자금 자산에 남습니다.
+// 거래 기록과 자산 갱신은 하나의 작업 단위로 처리하며, 거래가 없으면 자산을 바꾸지 않고 이벤트도 발행하지 않습니다.
+func (r *Rebalancer) Rebalance(ctx context.Context, userID string, opts Options) (*Plan, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if opts.FundingAssetID == "" {
+		return nil, invalid("funding asset is required to rebalance")
+	}
+	var plan *Plan
+	var changed []*asset.Asset
+	err := r.assets.WithTransaction(ctx, func(ctx context.Context) error {
+		portfolio, assets, err := r.load(ctx, userID)
+		if err != nil {
+			return err
+		}
+		funding, err := r.funding(ctx, userID, portfolio, opts)
+		if err != nil {
+			return err
+		}
+		plan, err = Compute(portfolio, assets, opts, r.now())
+		if err != nil || len(plan.Trades) == 0 {
+			return err
+		}
+		if funding.Amount.Currency != plan.Currency {
+			return invalid(fmt.Sprintf("funding asset %s must be in %s", funding.ID, plan.Currency))
+		}
+		plan.FundingAssetID = funding.ID
+
+		byID := make(map[string]*asset.Asset, len(assets))
+		for _, a := range assets {
+			a.ClearEvents()
+			byID[a.ID] = a
+		}
+		funding.ClearEvents()
+		// 매도를 먼저 기록하므로 매수 대금은 투입 현금과 매도 대금 안에서 자금 자산에서 나갑니다
+		for _, trade := range plan.Trades {
+			a := byID[trade.AssetID]
+			tx, err := trade.transaction(funding.ID, plan.Currency)
+			if err != nil {
+				return err
+			}
+			owner, counter := funding, a
+			if trade.Side == Sell {
+				owner, counter = a, funding
+			}
+			if err := r.record(ctx, tx, owner, counter); err != nil {
+				return err
+			}
+			amount, quantity := trade.signed()
+			if err := a.SettleTrade(amount, quantity); err != nil {
+				return invalid(err.Error())
+			}
+			if err := r.transactions.Save(ctx, tx); err != nil {
+				return err
+			}
+			plan.TransactionIDs = append(plan.TransactionIDs, tx.ID)
+			changed = append(changed, a)
+		}
+		changed = append(changed, funding)
+		for _, a := range changed {
+			if err := r.assets.Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) > 0 {
+		r.publish(ctx, plan, changed)
+	}
+	return plan, nil
+}
+
+// funding 자금 자산을 조회합니다. 사용자 소유이고 부채나 포트폴리오 자산이 아니어야 하며 투입 현금만큼의 잔액이 있어야 합니다.
+func (r *Rebalancer) funding(ctx context.Context, userID string, portfolio *asset.Portfolio, opts Options) (*asset.Asset, error) {
+	funding, err := r.assets.FindByID(ctx, opts.FundingAssetID)
+	if err != nil || funding.UserID != userID {
+		return nil, invalid(fmt.Sprintf("funding asset %s not found", opts.FundingAssetID))
+	}
+	if funding.Type.IsLiability() || funding.Holding != nil {
+		return nil, invalid(fmt.Sprintf("funding asset %s must be a cash asset", funding.ID))
+	}
+	for _, target := range portfolio.Assets {
+		if target.AssetID == funding.ID {
+			return nil, invalid(fmt.Sprintf("funding asset %s must not be a portfolio asset", funding.ID))
+		}
+	}
+	if funding.Amount.Amount+epsilon < opts.Inflow {
+		return nil, invalid(fmt.Sprintf("funding asset %s has less than the inflow %g", funding.ID, opts.Inflow))
+	}
+	return funding, nil
+}
+
+// record 이체 거래를 자산에 반영합니다. 원장이 있으면 원장이 자산 반영과 분개 전기를 함께 처리합니다.
+func (r *Rebalancer) record(ctx context.Context, tx *asset.Transaction, owner, counter *asset.Asset) error {
+	if r.ledger != nil {
+		_, err := r.ledger.Record(ctx, tx, owner, counter)
+		return err
+	}
+	if err := owner.ProcessTransaction(tx); err != nil {
+		return invalid(err.Error())
+	}
+	if err := counter.ReceiveTransfer(tx); err != nil {
+		return invalid(err.Error())
+	}
+	return nil
+}
+
+func (r *Rebalancer) load(ctx context.Context, userID string) (*asset.Portfolio, []*asset.Asset, error) {
+	portfolio, err := r.Targets(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	assets := make([]*asset.Asset, len(portfolio.Assets))
+	for i, target := range portfolio.Assets {
+		a, err := r.assets.FindByID(ctx, target.AssetID)
+		if err != nil || a.UserID != userID {
+			return nil, nil, invalid(fmt.Sprintf("portfolio asset %s not found", target.AssetID))
+		}
+		assets[i] = a
+	}
+	return portfolio, assets, nil
+}
+
+func (r *Rebalancer) publish(ctx context.Context, plan *Plan, changed []*asset.Asset) {
+	if r.bus == nil {
+		return
+	}
+	for _, a := range changed {
+		for _, evt := range a.GetUncommittedEvents() {
+			_ = r.bus.Publish(ctx, evt)
+		}
+		a.ClearEvents()
+	}
+	evt := event.NewEvent(event.TypePortfolioRebalanced, plan.PortfolioID, "portfolio", *plan, map[string]string{
+		"userID":      plan.UserID,
+		"portfolioID": plan.PortfolioID,
+	}, 1)
+	_ = r.bus.Publish(ctx, evt)
+}
+
+// Compute 포트폴리오 목표 비중과 자산(portfolio.Assets와 같은 순서)으로 리밸런싱 계획을 계산합니다.
+//
+// 한 자산이라도 허용 오차 밴드를 벗어나면 모든 자산을 목표 금액으로 사고팝니다.
+// 모두 밴드 안에 있거나 CashOnly이면 매도하지 않고, 투입 현금을 목표 금액에 모자란 만큼 나누어 삽니다.
+// 매매 단위가 있으면 수량을 0 쪽으로 내림하므로 매수 총액이 쓸 수 있는 현금(투입 현금 + 매도 금액)을 넘지 않습니다.
+func Compute(portfolio *asset.Portfolio, assets []*asset.Asset, opts Options, now time.Time) (*Plan, error) {
+	if err := validate(portfolio, assets, opts); err != nil {
+		return nil, err
+	}
+
+	currency := assets[0].Amount.Currency
+	var weightSum, invested float64
+	for i, a := range assets {
+		weightSum += portfolio.Assets[i].Weight
+		invested += a.Amount.Amount
+	}
+	total := invested + opts.Inflow
+	if total <= 0 {
+		return nil, invalid("portfolio has no value to rebalance")
+	}
+
+	plan := &Plan{
+		PortfolioID: portfolio.ID,
+		UserID:      portfolio.UserID,
+		Currency:    currency,
+		Invested:    roundMoney(invested),
+		Inflow:      opts.Inflow,
+		Positions:   make([]Position, len(assets)),
+		CreatedAt:   now,
+	}
+	desired := make([]float64, len(assets))
+	for i, a := range assets {
+		position := Position{AssetID: a.ID, Name: a.Name, Value: a.Amount.Amount, Target: portfolio.Assets[i].Weight / weightSum * 100}
+		if a.Holding != nil {
+			position.Symbol = a.Holding.Symbol
+		}
+		if invested > 0 {
+			position.Weight = a.Amount.Amount / invested * 100
+		}
+		position.Drift = position.Weight - position.Target
+		plan.MaxDrift = math.Max(plan.MaxDrift, math.Abs(position.Drift))
+		desired[i] = total*position.Target/100 - a.Amount.Amount
+		plan.Positions[i] = position
+	}
+	plan.WithinBand = plan.MaxDrift <= opts.Tolerance+epsilon
+	if opts.CashOnly || plan.WithinBand {
+		desired = allocateInflow(desired, plan.Positions, opts.Inflow)
+	}
+
+	// 매도를 먼저 정해 쓸 수 있는 현금을 구한 뒤 매수를 그 안에서 맞춥니다
+	trades := make([]*Trade, len(assets))
+	available := opts.Inflow
+	for i, a := range assets {
+		if desired[i] < 0 {
+			trades[i] = roundTrade(a, desired[i], opts)
+			if trades[i] != nil {
+				available += trades[i].Amount
+			}
+		}
+	}
+	var buying float64
+	for i := range assets {
+		if desired[i] > 0 {
+			buying += desired[i]
+		}
+	}
+	scale := 1.0
+	if buying > available+epsilon {
+		scale = available / buying
+	}
+	cash := available
+	for i, a := range assets {
+		if desired[i] > 0 {
+			trades[i] = roundTrade(a, desired[i]*scale, opts)
+			if trades[i] != nil {
+				cash -= trades[i].Amount
+			}
+		}
+	}
+	plan.Cash = roundMoney(math.Max(cash, 0))
+
+	after := total - plan.Cash
+	for i, trade := range trades {
+		value := plan.Positions[i].Value
+		if trade != nil {
+			amount, _ := trade.signed()
+			value += amount
+			plan.Trades = append(plan.Trades, *trade)
+		}
+		if after > 0 {
+			plan.Positions[i].Result = value / after * 100
+		}
+	}
+	sort.SliceStable(plan.Trades, func(i, j int) bool {
+		return plan.Trades[i].Side == Sell && plan.Trades[j].Side == Buy
+	})
+	return plan, nil
+}
+
+func validate(portfolio *asset.Portfolio, assets []*asset.Asset, opts Options) error {
+	switch {
+	case len(portfolio.Assets) == 0:
+		return invalid("portfolio has no target weights")
+	case len(assets) != len(portfolio.Assets):
+		return invalid("assets do not match portfolio targets")
+	case opts.Tolerance < 0:
+		return invalid("tolerance must not be negative")
+	case opts.Inflow < 0:
+		return invalid("inflow must not be negative")
+	case opts.MinTrade < 0:
+		return invalid("minimum trade must not be negative")
+	case opts.DefaultLot < 0:
+		return invalid("lot size must not be negative")
+	}
+	for symbol, lot := range opts.Lots {
+		if lot < 0 {
+			return invalid(fmt.Sprintf("lot size of %s must not be negative", symbol))
+		}
+	}
+	var weightSum float64
+	for i, a := range assets {
+		if a.ID != portfolio.Assets[i].AssetID {
+			return invalid("assets do not match portfolio targets")
+		}
+		if a.Amount.Currency != assets[0].Amount.Currency {
+			return invalid(fmt.Sprintf("portfolio assets must share one currency: %s, %s", assets[0].Amount.Currency, a.Amount.Currency))
+		}
+		weightSum += portfolio.Assets[i].Weight
+	}
+	if weightSum <= 0 {
+		return invalid("target weights must sum to more than 0")
+	}
+	return nil
+}
+
+// allocateInflow 매도 없이 투입 현금을 목표 금액에 모자란 자산에 모자란 비율대로 나눕니다.
+// 모자란 금액을 모두 채우고 남는 현금은 목표 비중대로 나눕니다.
+func allocateInflow(desired []float64, positions []Position, inflow float64) []float64 {
+	allocated := make([]float64, len(desired))
+	var deficit float64
+	for _, d := range desired {
+		deficit += math.Max(d, 0)
+	}
+	if inflow <= 0 {
+		return allocated
+	}
+	if deficit >= inflow {
+		for i, d := range desired {
+			allocated[i] = inflow * math.Max(d, 0) / deficit
+		}
+		return allocated
+	}
+	rest := inflow - deficit
+	for i, d := range desired {
+		allocated[i] = math.Max(d, 0) + rest*positions[i].Target/100
+	}
+	return allocated
+}
+
+// roundTrade 원하는 금액(매수 양수, 매도 음수)을 매매 단위와 보유 수량, 최소 거래 금액에 맞춘 거래로 바꿉니다.
+// 거래할 것이 없으면 nil을 반환합니다.
+func roundTrade(a *asset.Asset, amount float64, opts Options) *Trade {
+	trade := &Trade{AssetID: a.ID, Side: Buy}
+	if amount < 0 {
+		trade.Side = Sell
+	}
+	if price := unitPrice(a.Holding); price > 0 {
+		quantity := math.Abs(amount) / price
+		if trade.Side == Sell {
+			quantity = math.Min(quantity, a.Holding.Quantity)
+		}
+		lot := opts.DefaultLot
+		if l, ok := opts.Lots[a.Holding.Symbol]; ok {
+			lot = l
+		}
+		if lot > 0 {
+			quantity = math.Floor(quantity/lot+epsilon) * lot
+		}
+		trade.Symbol = a.Holding.Symbol
+		trade.Price = price
+		trade.Quantity = math.Round(quantity*1e8) / 1e8
+		trade.Amount = roundMoney(trade.Quantity * price)
+	} else {
+		trade.Amount = roundMoney(math.Abs(amount))
+		if trade.Side == Sell {
+			trade.Amount = math.Min(trade.Amount, a.Amount.Amount)
+		}
+	}
+	if trade.Amount < epsilon || trade.Amount < opts.MinTrade {
+		return nil
+	}
+	return trade
+}
+
+// unitPrice 보유 포지션의 1단위 가격입니다. 시장 가격이 없으면 평균 취득 단가를 씁니다.
+func unitPrice(h *asset.Holding) float64 {
+	switch {
+	case h == nil:
+		return 0
+	case h.IsPriced():
+		return h.Price.Amount
+	case h.Quantity > 0:
+		return h.CostBasis.Amount / h.Quantity
+	}
+	return 0
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,306 @@
+package rebalance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+var now = time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+
+type rebalanceFixture struct {
+	ctx          context.Context
+	assets       *asset.MemoryAssetRepository
+	portfolios   *asset.MemoryPortfolioRepository
+	transactions *asset.MemoryTransactionRepository
+	ledger       *ledger.Ledger
+	bus          event.Bus
+	rebalancer   *Rebalancer
+	stocks       *asset.Asset
+	bonds        *asset.Asset
+	cash         *asset.Asset
+	funding      *asset.Asset
+}
+
+// newRebalanceFixture 주식 60,000원, 채권 20,000원, 현금 20,000원을 목표 비중 40:40:20으로 둔 포트폴리오와
+// 포트폴리오 밖의 자금 자산(입출금 50,000원)을 만듭니다.
+func newRebalanceFixture(t *testing.T) *rebalanceFixture {
+	t.Helper()
+	f := &rebalanceFixture{
+		ctx:          context.Background(),
+		assets:       asset.NewMemoryAssetRepository(),
+		portfolios:   asset.NewMemoryPortfolioRepository(),
+		transactions: asset.NewMemoryTransactionRepository(),
+		ledger:       ledger.NewLedger(ledger.NewMemoryRepository()),
+		bus:          memory.NewEventBus(),
+	}
+	f.rebalancer = NewRebalancer(f.portfolios, f.assets, f.transactions, f.bus, WithClock(func() time.Time { return now }), WithLedger(f.ledger))
+	f.stocks = f.holding(t, "주식 ETF", "STOCK", 60)
+	f.bonds = f.holding(t, "채권 ETF", "BOND", 20)
+	cash, err := asset.NewAsset("user-1", asset.Cash, "예수금", 20000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, cash))
+	f.cash = cash
+	funding, err := asset.NewAsset("user-1", asset.Cash, "입출금", 50000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, funding))
+	f.funding = funding
+
+	_, err = f.rebalancer.SetTargets(f.ctx, "user-1", []asset.PortfolioAsset{
+		{AssetID: f.stocks.ID, Weight: 40},
+		{AssetID: f.bonds.ID, Weight: 40},
+		{AssetID: f.cash.ID, Weight: 20},
+	})
+	require.NoError(t, err)
+	return f
+}
+
+func (f *rebalanceFixture) holding(t *testing.T, name, symbol string, quantity float64) *asset.Asset {
+	t.Helper()
+	a, err := asset.NewAsset("user-1", asset.Stock, name, quantity*1000, "KRW")
+	require.NoError(t, err)
+	holding, err := asset.NewHolding(symbol, quantity, asset.Money{Amount: quantity * 1000, Currency: "KRW"})
+	require.NoError(t, err)
+	require.NoError(t, a.SetHolding(holding))
+	_, err = a.Revalue(asset.Money{Amount: 1000, Currency: "KRW"}, now)
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, a))
+	return a
+}
+
+func Test_Rebalancer_Plan_should_trade_back_to_targets_outside_band(t *testing.T) {
+	// Given
+	f := newRebalanceFixture(t)
+
+	// When
+	plan, err := f.rebalancer.Plan(f.ctx, "user-1", Options{Tolerance: 5, DefaultLot: 1})
+
+	// Then
+	require.NoError(t, err)
+	assert.False(t, plan.WithinBand)
+	assert.InDelta(t, 20, plan.MaxDrift, 1e-9)
+	assert.Equal(t, []Trade{
+		{AssetID: f.stocks.ID, Symbol: "STOCK", Side: Sell, Amount: 20000, Quantity: 20, Price: 1000},
+		{AssetID: f.bonds.ID, Symbol: "BOND", Side: Buy, Amount: 20000, Quantity: 20, Price: 1000},
+	}, plan.Trades)
+	assert.Zero(t, plan.Cash)
+	for _, position := range plan.Positions {
+		assert.InDelta(t, position.Target, position.Result, 1e-9)
+	}
+}
+
+func Test_Rebalancer_Plan_should_not_trade_within_band(t *testing.T) {
+	// Given
+	f := newRebalanceFixture(t)
+
+	// When
+	plan, err := f.rebalancer.Plan(f.ctx, "user-1", Options{Tolerance: 25})
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, plan.WithinBand)
+	assert.Empty(t, plan.Trades)
+	assert.InDelta(t, 60, plan.Positions[0].Result, 1e-9)
+}
+
+func Test_Rebalancer_Plan_should_buy_underweight_assets_with_inflow_only(t *testing.T) {
+	// Given
+	f := newRebalanceFixture(t)
+
+	// When
+	plan, err := f.rebalancer.Plan(f.ctx, "user-1", Options{CashOnly: true, Inflow: 10000, MinTrade: 1000, DefaultLot: 1})
+
+	// Then
+	require.NoError(t, err)
+	// 채권 24,000원과 현금 2,000원이 모자라 투입 현금을 12:1로 나누고, 채권은 1주 단위로 내림, 현금 769원은 최소 거래 금액 미만
+	assert.Equal(t, []Trade{
+		{AssetID: f.bonds.ID, Symbol: "BOND", Side: Buy, Amount: 9000, Quantity: 9, Price: 1000},
+	}, plan.Trades)
+	assert.Equal(t, 1000.0, plan.Cash)
+}
+
+func Test_Rebalancer_Plan_should_round_to_symbol_lots(t *testing.T) {
+	// Given
+	f := newRebalanceFixture(t)
+
+	// When
+	plan, err := f.rebalancer.Plan(f.ctx, "user-1", Options{Lots: map[string]float64{"BOND": 7}})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, plan.Trades, 2)
+	assert.Equal(t, 20.0, plan.Trades[0].Quantity)
+	assert.Equal(t, 14.0, plan.Trades[1].Quantity)
+	assert.Equal(t, 6000.0, plan.Cash)
+}
+
+func Test_Rebalancer_Rebalance_should_book_trades_as_transfers_and_publish_event(t *testing.T) {
+	// Given
+	f := newRebalanceFixture(t)
+	var published []event.Event
+	require.NoError(t, f.bus.Subscribe(&eventRecorder{events: &published}))
+	opts := Options{Tolerance: 5, DefaultLot: 1, FundingAssetID: f.funding.ID}
+
+	// When
+	plan, err := f.rebalancer.Rebalance(f.ctx, "user-1", opts)
+
+	// Then
+	require.NoError(t, err)
+	stocks, err := f.assets.FindByID(f.ctx, f.stocks.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, stocks.Holding.Quantity)
+	assert.Equal(t, 40000.0, stocks.Amount.Amount)
+	bonds, err := f.assets.FindByID(f.ctx, f.bonds.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, bonds.Holding.Quantity)
+	assert.Equal(t, 40000.0, bonds.Amount.Amount)
+	funding, err := f.assets.FindByID(f.ctx, f.funding.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 50000.0, funding.Amount.Amount)
+
+	// 매도는 자산에서 자금 자산으로, 매수는 자금 자산에서 자산으로의 이체입니다
+	assert.Equal(t, f.funding.ID, plan.FundingAssetID)
+	require.Len(t, plan.TransactionIDs, 2)
+	sale, err := f.transactions.FindByID(f.ctx, plan.TransactionIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, asset.Transfer, sale.Type)
+	assert.Equal(t, f.stocks.ID, sale.AssetID)
+	assert.Equal(t, f.funding.ID, sale.CounterAssetID)
+	purchase, err := f.transactions.FindByID(f.ctx, plan.TransactionIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, f.funding.ID, purchase.AssetID)
+	assert.Equal(t, f.bonds.ID, purchase.CounterAssetID)
+	assert.Equal(t, 20000.0, purchase.Amount.Amount)
+	discrepancies, err := f.ledger.Check(f.ctx, "user-1", []*asset.Asset{stocks, bonds, funding})
+	require.NoError(t, err)
+	assert.Empty(t, discrepancies)
+
+	rebalanced := published[len(published)-1]
+	assert.Equal(t, event.TypePortfolioRebalanced, rebalanced.EventType())
+	assert.Equal(t, plan.PortfolioID, rebalanced.AggregateID())
+	assert.Equal(t, "user-1", rebalanced.Metadata()["userID"])
+	assert.Len(t, rebalanced.Payload().(Plan).Trades, 2)
+	for _, evt := range published[:len(published)-1] {
+		assert.Equal(t, event.TypeTransactionRecorded, evt.EventType())
+	}
+
+	count := len(published)
+	again, err := f.rebalancer.Rebalance(f.ctx, "user-1", opts)
+	require.NoError(t, err)
+	assert.Empty(t, again.Trades)
+	assert.Len(t, published, count)
+}
+
+func Test_Rebalancer_Rebalance_should_draw_inflow_from_funding_asset(t *testing.T) {
+	// Given
+	f := newRebalanceFixture(t)
+
+	// When
+	plan, err := f.rebalancer.Rebalance(f.ctx, "user-1", Options{Tolerance: 5, DefaultLot: 1, Inflow: 10500, FundingAssetID: f.funding.ID})
+
+	// Then
+	require.NoError(t, err)
+	var bought, sold float64
+	for _, trade := range plan.Trades {
+		if trade.Side == Buy {
+			bought += trade.Amount
+		} else {
+			sold += trade.Amount
+		}
+	}
+	assert.InDelta(t, 10500+sold-bought, plan.Cash, 1e-9)
+	assert.Positive(t, plan.Cash)
+	funding, err := f.assets.FindByID(f.ctx, f.funding.ID)
+	require.NoError(t, err)
+	// 남는 현금은 자금 자산에 남으므로 자금 자산에서는 투입 현금 중 쓴 만큼만 빠집니다
+	assert.InDelta(t, 50000-10500+plan.Cash, funding.Amount.Amount, 1e-9)
+	var total float64
+	for _, id := range []string{f.stocks.ID, f.bonds.ID, f.cash.ID, f.funding.ID} {
+		a, err := f.assets.FindByID(f.ctx, id)
+		require.NoError(t, err)
+		total += a.Amount.Amount
+	}
+	assert.InDelta(t, 150000, total, 1e-9)
+}
+
+func Test_Rebalancer_Rebalance_should_validate_funding_asset(t *testing.T) {
+	// Given
+	f := newRebalanceFixture(t)
+	other, err := asset.NewAsset("user-2", asset.Cash, "남의 현금", 100000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, other))
+
+	// When
+	for name, opts := range map[string]Options{
+		"자금 자산 없음":      {Tolerance: 5},
+		"다른 사용자 자산":     {Tolerance: 5, FundingAssetID: other.ID},
+		"포트폴리오 자산":      {Tolerance: 5, FundingAssetID: f.cash.ID},
+		"투입 현금보다 적은 잔액": {Tolerance: 5, Inflow: 60000, FundingAssetID: f.funding.ID},
+	} {
+		_, err := f.rebalancer.Rebalance(f.ctx, "user-1", opts)
+		assert.Error(t, err, name)
+	}
+
+	// Then
+	stocks, err := f.assets.FindByID(f.ctx, f.stocks.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 60000.0, stocks.Amount.Amount)
+	all, err := f.transactions.FindAll(f.ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func Test_Rebalancer_SetTargets_should_validate_weights_and_assets(t *testing.T) {
+	// Given
+	f := newRebalanceFixture(t)
+	loan, err := asset.NewAsset("user-1", asset.Loan, "대출", 1000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, loan))
+	other, err := asset.NewAsset("user-2", asset.Cash, "남의 현금", 1000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, other))
+
+	// When
+	for name, targets := range map[string][]asset.PortfolioAsset{
+		"합이 100 초과": {{AssetID: f.stocks.ID, Weight: 70}, {AssetID: f.bonds.ID, Weight: 40}},
+		"음수 비중":     {{AssetID: f.stocks.ID, Weight: -10}},
+		"중복 자산":     {{AssetID: f.stocks.ID, Weight: 10}, {AssetID: f.stocks.ID, Weight: 10}},
+		"부채":        {{AssetID: loan.ID, Weight: 10}},
+		"다른 사용자 자산": {{AssetID: other.ID, Weight: 10}},
+		"비어 있음":     {},
+	} {
+		_, err := f.rebalancer.SetTargets(f.ctx, "user-1", targets)
+		assert.Error(t, err, name)
+	}
+	updated, err := f.rebalancer.SetTargets(f.ctx, "user-1", []asset.PortfolioAsset{{AssetID: f.stocks.ID, Weight: 50}, {AssetID: f.bonds.ID, Weight: 25}})
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, updated.Assets, 2)
+	plan, err := f.rebalancer.Plan(f.ctx, "user-1", Options{})
+	require.NoError(t, err)
+	assert.InDelta(t, 66.67, plan.Positions[0].Target, 0.01)
+	_, err = f.rebalancer.Plan(f.ctx, "user-2", Options{})
+	assert.Error(t, err)
+}
+
+type eventRecorder struct {
+	events *[]event.Event
+}
+
+func (r *eventRecorder) HandlerName() string {
+	return "event-recorder"
+}
+
+func (r *eventRecorder) HandleEvent(_ context.Context, evt event.Event) error {
+	*r.events = append(*r.events, evt)
+	return nil
+}
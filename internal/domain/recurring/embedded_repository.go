@@ -0,0 +1,110 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	recurrenceBucket = "recurrences"
+
+	indexAssetID = "asset_id"
+)
+
+// EmbeddedRepository 정기 거래의 임베디드 키-값 저장소 구현체입니다.
+type EmbeddedRepository struct {
+	recurrences *kv.Collection[*Recurrence]
+}
+
+// NewEmbeddedRepository 자산 인덱스를 가진 임베디드 정기 거래 저장소를 생성합니다.
+func NewEmbeddedRepository(db *kv.DB) (*EmbeddedRepository, error) {
+	recurrences := kv.NewCollection[*Recurrence](db, recurrenceBucket)
+	if err := recurrences.Index(indexAssetID, func(r *Recurrence) []string { return []string{r.AssetID} }); err != nil {
+		return nil, err
+	}
+	return &EmbeddedRepository{recurrences: recurrences}, nil
+}
+
+// storageError 도메인 에러는 그대로 두고, 저장소 에러는 내부 에러로 감쌉니다.
+func storageError(err error) error {
+	var domainErr domain.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewError("recurring", domain.ErrCodeInternal, err.Error())
+}
+
+// Save 정기 거래를 저장합니다.
+func (r *EmbeddedRepository) Save(ctx context.Context, recurrence *Recurrence) error {
+	return storageError(r.recurrences.Update(ctx, func(tx *kv.Tx) error {
+		return r.recurrences.Put(tx, recurrence.ID, recurrence)
+	}))
+}
+
+// FindByID ID로 정기 거래를 조회합니다.
+func (r *EmbeddedRepository) FindByID(ctx context.Context, id string) (*Recurrence, error) {
+	var recurrence *Recurrence
+	err := r.recurrences.View(ctx, func(tx *kv.Tx) error {
+		found, exists, err := r.recurrences.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(fmt.Sprintf("recurrence with ID %s not found", id))
+		}
+		recurrence = found
+		return nil
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return recurrence, nil
+}
+
+// FindByAssetID 자산의 정기 거래를 생성 순으로 조회합니다.
+func (r *EmbeddedRepository) FindByAssetID(ctx context.Context, assetID string) ([]*Recurrence, error) {
+	var recurrences []*Recurrence
+	err := r.recurrences.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.recurrences.Lookup(tx, indexAssetID, assetID)
+		recurrences = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortRecurrences(recurrences)
+	return recurrences, nil
+}
+
+// FindAll 모든 정기 거래를 생성 순으로 조회합니다.
+func (r *EmbeddedRepository) FindAll(ctx context.Context) ([]*Recurrence, error) {
+	var recurrences []*Recurrence
+	err := r.recurrences.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.recurrences.All(tx)
+		recurrences = found
+		return err
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortRecurrences(recurrences)
+	return recurrences, nil
+}
+
+// Delete 정기 거래를 삭제합니다.
+func (r *EmbeddedRepository) Delete(ctx context.Context, id string) error {
+	return storageError(r.recurrences.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.recurrences.Exists(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return notFound(fmt.Sprintf("recurrence with ID %s not found", id))
+		}
+		return r.recurrences.Delete(tx, id)
+	}))
+}
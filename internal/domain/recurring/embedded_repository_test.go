@@ -0,0 +1,51 @@
+package recurring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+func Test_EmbeddedRepository_should_keep_overrides_and_cursor(t *testing.T) {
+	// Given
+	repo, err := NewEmbeddedRepository(kv.OpenMemory())
+	require.NoError(t, err)
+	ctx := context.Background()
+	amount := 5000.0
+	end := date(2024, 12, 31)
+	rec := &Recurrence{
+		ID:            "r1",
+		AssetID:       "a1",
+		Type:          asset.Expense,
+		Amount:        asset.Money{Amount: 10000, Currency: "KRW"},
+		Schedule:      Schedule{Frequency: Monthly, LastBusinessDay: true},
+		Start:         date(2024, 1, 1),
+		End:           &end,
+		Overrides:     map[string]Override{"2024-03-29": {Amount: &amount}},
+		PostedThrough: date(2024, 2, 29),
+	}
+
+	// When
+	require.NoError(t, repo.Save(ctx, rec))
+	require.NoError(t, repo.Save(ctx, &Recurrence{ID: "r2", AssetID: "a2"}))
+
+	// Then
+	found, err := repo.FindByAssetID(ctx, "a1")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, 5000.0, *found[0].Overrides["2024-03-29"].Amount)
+	assert.True(t, found[0].PostedThrough.Equal(rec.PostedThrough))
+	assert.Equal(t, []string{"2024-03-29"}, dateKeys(found[0].Due(date(2024, 3, 31))))
+	all, err := repo.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	require.NoError(t, repo.Delete(ctx, "r1"))
+	_, err = repo.FindByID(ctx, "r1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package recurring
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryRepository 정기 거래의 인메모리 저장소 구현체입니다.
+type MemoryRepository struct {
+	recurrences map[string]*Recurrence
+	mutex       sync.RWMutex
+}
+
+// NewMemoryRepository 새로운 인메모리 정기 거래 저장소를 생성합니다.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{recurrences: make(map[string]*Recurrence)}
+}
+
+// Save 정기 거래를 저장합니다.
+func (r *MemoryRepository) Save(_ context.Context, recurrence *Recurrence) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.recurrences[recurrence.ID] = clone(recurrence)
+	return nil
+}
+
+// FindByID ID로 정기 거래를 조회합니다.
+func (r *MemoryRepository) FindByID(_ context.Context, id string) (*Recurrence, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	recurrence, ok := r.recurrences[id]
+	if !ok {
+		return nil, notFound(fmt.Sprintf("recurrence with ID %s not found", id))
+	}
+	return clone(recurrence), nil
+}
+
+// FindByAssetID 자산의 정기 거래를 생성 순으로 조회합니다.
+func (r *MemoryRepository) FindByAssetID(_ context.Context, assetID string) ([]*Recurrence, error) {
+	return r.filter(func(rec *Recurrence) bool { return rec.AssetID == assetID }), nil
+}
+
+// FindAll 모든 정기 거래를 생성 순으로 조회합니다.
+func (r *MemoryRepository) FindAll(_ context.Context) ([]*Recurrence, error) {
+	return r.filter(func(*Recurrence) bool { return true }), nil
+}
+
+// Delete 정기 거래를 삭제합니다.
+func (r *MemoryRepository) Delete(_ context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.recurrences[id]; !ok {
+		return notFound(fmt.Sprintf("recurrence with ID %s not found", id))
+	}
+	delete(r.recurrences, id)
+	return nil
+}
+
+func (r *MemoryRepository) filter(match func(*Recurrence) bool) []*Recurrence {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]*Recurrence, 0)
+	for _, rec := range r.recurrences {
+		if match(rec) {
+			result = append(result, clone(rec))
+		}
+	}
+	sortRecurrences(result)
+	return result
+}
+
+// clone 회차 변경 사항과 종료일을 공유하지 않도록 정기 거래를 복사합니다.
+func clone(recurrence *Recurrence) *Recurrence {
+	c := *recurrence
+	if recurrence.End != nil {
+		end := *recurrence.End
+		c.End = &end
+	}
+	c.Overrides = make(map[string]Override, len(recurrence.Overrides))
+	for k, v := range recurrence.Overrides {
+		c.Overrides[k] = v
+	}
+	return &c
+}
+
+func sortRecurrences(recurrences []*Recurrence) {
+	sort.SliceStable(recurrences, func(i, j int) bool {
+		return recurrences[i].CreatedAt.Before(recurrences[j].CreatedAt)
+	})
+}
@@ -0,0 +1,272 @@
+// Package recurring 자산에 연결된 정기 수입/지출을 정의하고 예정일마다 거래로 기록합니다.
+// 반복 규칙은 RRULE과 비슷하게 주기와 간격, 월의 날짜 또는 마지막 영업일로 지정합니다.
+package recurring
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/google/uuid"
+)
+
+// Frequency 반복 주기입니다.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// dateLayout 회차를 구분하는 날짜 형식입니다.
+const dateLayout = "2006-01-02"
+
+// maxOccurrences 한 번에 계산하는 회차 수의 상한입니다. 잘못된 범위로 무한히 계산하는 것을 막습니다.
+const maxOccurrences = 10000
+
+// Schedule 반복 규칙입니다.
+// 주간 반복은 시작일의 요일을 따르고, 월간 반복은 DayOfMonth일(기본값은 시작일의 날짜) 또는
+// LastBusinessDay이면 그 달의 마지막 평일에 발생합니다. DayOfMonth가 그 달의 마지막 날보다 크면 말일로 맞춥니다.
+type Schedule struct {
+	Frequency       Frequency `json:"frequency"`
+	Interval        int       `json:"interval,omitempty"`
+	DayOfMonth      int       `json:"dayOfMonth,omitempty"`
+	LastBusinessDay bool      `json:"lastBusinessDay,omitempty"`
+}
+
+// Validate 반복 규칙을 확인합니다.
+func (s Schedule) Validate() error {
+	switch s.Frequency {
+	case Daily, Weekly:
+		if s.DayOfMonth != 0 || s.LastBusinessDay {
+			return invalid("day of month is only allowed for monthly schedules")
+		}
+	case Monthly:
+		if s.DayOfMonth < 0 || s.DayOfMonth > 31 {
+			return invalid(fmt.Sprintf("invalid day of month %d", s.DayOfMonth))
+		}
+		if s.DayOfMonth != 0 && s.LastBusinessDay {
+			return invalid("day of month and last business day cannot be combined")
+		}
+	default:
+		return invalid(fmt.Sprintf("unsupported frequency %q", s.Frequency))
+	}
+	if s.Interval < 0 {
+		return invalid("interval must be positive")
+	}
+	return nil
+}
+
+func (s Schedule) interval() int {
+	if s.Interval < 1 {
+		return 1
+	}
+	return s.Interval
+}
+
+// occurrence 시작일 기준 n번째 주기의 발생 시각입니다. 시각은 시작 시각을 따릅니다.
+func (s Schedule) occurrence(start time.Time, n int) time.Time {
+	switch s.Frequency {
+	case Daily:
+		return start.AddDate(0, 0, n*s.interval())
+	case Weekly:
+		return start.AddDate(0, 0, 7*n*s.interval())
+	}
+
+	first := time.Date(start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	month := first.AddDate(0, n*s.interval(), 0)
+	last := month.AddDate(0, 1, -1).Day()
+	if s.LastBusinessDay {
+		day := month.AddDate(0, 0, last-1)
+		for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			day = day.AddDate(0, 0, -1)
+		}
+		return day
+	}
+	day := s.DayOfMonth
+	if day == 0 {
+		day = start.Day()
+	}
+	if day > last {
+		day = last
+	}
+	return month.AddDate(0, 0, day-1)
+}
+
+// Override 특정 회차만 건너뛰거나 금액, 분류, 설명을 바꿉니다.
+type Override struct {
+	Skip        bool     `json:"skip,omitempty"`
+	Amount      *float64 `json:"amount,omitempty"`
+	Category    *string  `json:"category,omitempty"`
+	Description *string  `json:"description,omitempty"`
+}
+
+// Recurrence 자산에 연결된 정기 거래 정의입니다.
+// PostedThrough는 처리를 마친 마지막 회차의 시각이며 스케줄러는 그 이후 회차부터 기록합니다.
+type Recurrence struct {
+	ID            string
+	UserID        string
+	AssetID       string
+	Type          asset.TransactionType
+	Amount        asset.Money
+	Category      string
+	Description   string
+	Schedule      Schedule
+	Start         time.Time
+	End           *time.Time
+	Overrides     map[string]Override
+	PostedThrough time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewRecurrence 새로운 정기 거래를 생성합니다.
+func NewRecurrence(owner *asset.Asset, txType asset.TransactionType, amount asset.Money, category, description string, schedule Schedule, start time.Time, end *time.Time) (*Recurrence, error) {
+	switch txType {
+	case asset.Income, asset.Expense:
+	default:
+		return nil, invalid(fmt.Sprintf("unsupported transaction type %q", txType))
+	}
+	if amount.IsZero() || amount.IsNegative() {
+		return nil, invalid("amount must be positive")
+	}
+	if amount.Currency != owner.Amount.Currency {
+		return nil, invalid(fmt.Sprintf("currency %s does not match asset currency %s", amount.Currency, owner.Amount.Currency))
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	if start.IsZero() {
+		return nil, invalid("start is required")
+	}
+	if end != nil && end.Before(start) {
+		return nil, invalid("end must not be before start")
+	}
+
+	now := time.Now()
+	return &Recurrence{
+		ID:          uuid.New().String(),
+		UserID:      owner.UserID,
+		AssetID:     owner.ID,
+		Type:        txType,
+		Amount:      amount,
+		Category:    category,
+		Description: description,
+		Schedule:    schedule,
+		Start:       start,
+		End:         end,
+		Overrides:   make(map[string]Override),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Occurrences from 이후부터 to까지(모두 포함) 발생하는 회차의 시각을 순서대로 반환합니다.
+func (r *Recurrence) Occurrences(from, to time.Time) []time.Time {
+	var result []time.Time
+	for n := 0; len(result) < maxOccurrences; n++ {
+		at := r.Schedule.occurrence(r.Start, n)
+		if at.After(to) || (r.End != nil && at.After(*r.End)) {
+			break
+		}
+		if at.Before(r.Start) || at.Before(from) {
+			continue
+		}
+		result = append(result, at)
+	}
+	return result
+}
+
+// Due 아직 처리하지 않은 회차 중 now까지 도래한 회차를 반환합니다. 중단 이후 밀린 회차도 모두 포함합니다.
+func (r *Recurrence) Due(now time.Time) []time.Time {
+	from := r.Start
+	if !r.PostedThrough.IsZero() {
+		from = r.PostedThrough.Add(time.Nanosecond)
+	}
+	return r.Occurrences(from, now)
+}
+
+// Occurrence 날짜(YYYY-MM-DD)에 회차가 있으면 그 회차의 시각을 반환합니다.
+func (r *Recurrence) Occurrence(date string) (time.Time, bool) {
+	day, err := time.ParseInLocation(dateLayout, date, r.Start.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+	for _, at := range r.Occurrences(day, day.AddDate(0, 0, 1).Add(-time.Nanosecond)) {
+		if DateKey(at) == date {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Posted 회차가 이미 처리되었는지 확인합니다.
+func (r *Recurrence) Posted(at time.Time) bool {
+	return !r.PostedThrough.IsZero() && !at.After(r.PostedThrough)
+}
+
+// SetOverride 회차의 변경 사항을 지정합니다. 이미 처리한 회차는 바꿀 수 없습니다.
+func (r *Recurrence) SetOverride(at time.Time, override Override) error {
+	if r.Posted(at) {
+		return domain.NewError("recurring", domain.ErrCodeInvalidOperation, fmt.Sprintf("occurrence %s is already posted", DateKey(at)))
+	}
+	if override.Amount != nil && *override.Amount <= 0 {
+		return invalid("amount must be positive")
+	}
+	if r.Overrides == nil {
+		r.Overrides = make(map[string]Override)
+	}
+	if override == (Override{}) {
+		delete(r.Overrides, DateKey(at))
+	} else {
+		r.Overrides[DateKey(at)] = override
+	}
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Transaction 회차에 기록할 거래를 만듭니다. 같은 회차는 항상 같은 거래 ID를 가지므로
+// 기록 도중 중단되어 다시 처리하더라도 이미 저장된 거래를 알아볼 수 있습니다.
+func (r *Recurrence) Transaction(at time.Time) (*asset.Transaction, error) {
+	override := r.Overrides[DateKey(at)]
+	amount := r.Amount
+	if override.Amount != nil {
+		amount.Amount = *override.Amount
+	}
+	category := r.Category
+	if override.Category != nil {
+		category = *override.Category
+	}
+	description := r.Description
+	if override.Description != nil {
+		description = *override.Description
+	}
+
+	tx, err := asset.NewTransaction(r.AssetID, r.Type, amount, category, description)
+	if err != nil {
+		return nil, err
+	}
+	tx.ID = OccurrenceTransactionID(r.ID, at)
+	tx.Date = at
+	return tx, nil
+}
+
+// OccurrenceTransactionID 회차에 기록하는 거래의 ID입니다.
+func OccurrenceTransactionID(recurrenceID string, at time.Time) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(recurrenceID+"/"+DateKey(at))).String()
+}
+
+// DateKey 회차를 구분하는 날짜 문자열입니다.
+func DateKey(at time.Time) string {
+	return at.Format(dateLayout)
+}
+
+func invalid(msg string) error {
+	return domain.NewError("recurring", domain.ErrCodeInvalidArgument, msg)
+}
+
+func notFound(msg string) error {
+	return domain.NewError("recurring", domain.ErrCodeNotFound, msg)
+}
@@ -0,0 +1,115 @@
+package recurring
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+func dateKeys(times []time.Time) []string {
+	keys := make([]string, len(times))
+	for i, at := range times {
+		keys[i] = DateKey(at)
+	}
+	return keys
+}
+
+func Test_Recurrence_should_compute_occurrences_per_schedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		start    time.Time
+		want     []string
+	}{
+		{
+			name:     "매월 31일은 짧은 달의 말일로 맞춤",
+			schedule: Schedule{Frequency: Monthly, DayOfMonth: 31},
+			start:    date(2024, 1, 1),
+			want:     []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30"},
+		},
+		{
+			name:     "매월 마지막 영업일",
+			schedule: Schedule{Frequency: Monthly, LastBusinessDay: true},
+			start:    date(2024, 1, 1),
+			want:     []string{"2024-01-31", "2024-02-29", "2024-03-29", "2024-04-30"},
+		},
+		{
+			name:     "시작일 이전 날짜는 다음 달부터",
+			schedule: Schedule{Frequency: Monthly, DayOfMonth: 5},
+			start:    date(2024, 1, 10),
+			want:     []string{"2024-02-05", "2024-03-05", "2024-04-05"},
+		},
+		{
+			name:     "격주",
+			schedule: Schedule{Frequency: Weekly, Interval: 2},
+			start:    date(2024, 1, 5),
+			want:     []string{"2024-01-05", "2024-01-19", "2024-02-02", "2024-02-16", "2024-03-01", "2024-03-15", "2024-03-29", "2024-04-12", "2024-04-26"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &Recurrence{Schedule: tt.schedule, Start: tt.start}
+			assert.Equal(t, tt.want, dateKeys(rec.Occurrences(tt.start, date(2024, 4, 30))))
+		})
+	}
+}
+
+func Test_Recurrence_should_stop_at_end_and_resume_after_cursor(t *testing.T) {
+	// Given
+	end := date(2024, 1, 4)
+	rec := &Recurrence{Schedule: Schedule{Frequency: Daily}, Start: date(2024, 1, 1), End: &end}
+
+	// When
+	rec.PostedThrough = date(2024, 1, 2)
+
+	// Then
+	assert.Equal(t, []string{"2024-01-03", "2024-01-04"}, dateKeys(rec.Due(date(2024, 2, 1))))
+	at, ok := rec.Occurrence("2024-01-03")
+	require.True(t, ok)
+	assert.Equal(t, date(2024, 1, 3), at)
+	_, ok = rec.Occurrence("2024-01-05")
+	assert.False(t, ok)
+
+	var domainErr domain.Error
+	require.True(t, errors.As(rec.SetOverride(date(2024, 1, 2), Override{Skip: true}), &domainErr))
+	assert.Equal(t, domain.ErrCodeInvalidOperation, domainErr.Code())
+}
+
+func Test_NewRecurrence_should_validate_definition(t *testing.T) {
+	owner, err := asset.NewAsset("user-1", asset.Cash, "월급 통장", 0, "KRW")
+	require.NoError(t, err)
+	krw := asset.Money{Amount: 1000, Currency: "KRW"}
+	monthly := Schedule{Frequency: Monthly}
+	end := date(2023, 1, 1)
+
+	tests := []struct {
+		name     string
+		txType   asset.TransactionType
+		amount   asset.Money
+		schedule Schedule
+		end      *time.Time
+	}{
+		{name: "이체 거래", txType: asset.Transfer, amount: krw, schedule: monthly},
+		{name: "통화 불일치", txType: asset.Income, amount: asset.Money{Amount: 1000, Currency: "USD"}, schedule: monthly},
+		{name: "알 수 없는 주기", txType: asset.Income, amount: krw, schedule: Schedule{Frequency: "YEARLY"}},
+		{name: "주간 반복의 날짜 지정", txType: asset.Income, amount: krw, schedule: Schedule{Frequency: Weekly, DayOfMonth: 3}},
+		{name: "날짜와 마지막 영업일 동시 지정", txType: asset.Income, amount: krw, schedule: Schedule{Frequency: Monthly, DayOfMonth: 3, LastBusinessDay: true}},
+		{name: "시작 이전 종료", txType: asset.Income, amount: krw, schedule: monthly, end: &end},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRecurrence(owner, tt.txType, tt.amount, "급여", "", tt.schedule, date(2024, 1, 1), tt.end)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// PostgresRepository recurrences 테이블을 사용하는 정기 거래 저장소 구현체입니다.
+// 정기 거래는 data 컬럼에 JSON으로 저장하고, 자산 ID를 조회용 컬럼으로 함께 저장합니다.
+type PostgresRepository struct {
+	recurrences *sqldb.Documents[*Recurrence]
+}
+
+// NewPostgresRepository 새로운 PostgreSQL 정기 거래 저장소를 생성합니다.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{
+		recurrences: sqldb.NewDocuments(db, "recurrences", []string{"id"}, []string{"asset_id"},
+			func(r *Recurrence) []interface{} { return []interface{}{r.ID, r.AssetID} }),
+	}
+}
+
+// Save 정기 거래를 저장합니다.
+func (r *PostgresRepository) Save(ctx context.Context, recurrence *Recurrence) error {
+	return storageError(r.recurrences.Put(ctx, recurrence))
+}
+
+// FindByID ID로 정기 거래를 조회합니다.
+func (r *PostgresRepository) FindByID(ctx context.Context, id string) (*Recurrence, error) {
+	recurrence, exists, err := r.recurrences.Get(ctx, id)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if !exists {
+		return nil, notFound(fmt.Sprintf("recurrence with ID %s not found", id))
+	}
+	return recurrence, nil
+}
+
+// FindByAssetID 자산의 정기 거래를 생성 순으로 조회합니다.
+func (r *PostgresRepository) FindByAssetID(ctx context.Context, assetID string) ([]*Recurrence, error) {
+	recurrences, err := r.recurrences.Find(ctx, "asset_id = $1", assetID)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortRecurrences(recurrences)
+	return recurrences, nil
+}
+
+// FindAll 모든 정기 거래를 생성 순으로 조회합니다.
+func (r *PostgresRepository) FindAll(ctx context.Context) ([]*Recurrence, error) {
+	recurrences, err := r.recurrences.Find(ctx, "")
+	if err != nil {
+		return nil, storageError(err)
+	}
+	sortRecurrences(recurrences)
+	return recurrences, nil
+}
+
+// Delete 정기 거래를 삭제합니다.
+func (r *PostgresRepository) Delete(ctx context.Context, id string) error {
+	deleted, err := r.recurrences.Delete(ctx, id)
+	if err != nil {
+		return storageError(err)
+	}
+	if !deleted {
+		return notFound(fmt.Sprintf("recurrence with ID %s not found", id))
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package recurring
+
+import "context"
+
+// Repository 정기 거래 저장소입니다.
+type Repository interface {
+	// Save 정기 거래를 저장합니다. 같은 ID가 있으면 덮어씁니다.
+	Save(ctx context.Context, recurrence *Recurrence) error
+	FindByID(ctx context.Context, id string) (*Recurrence, error)
+	FindByAssetID(ctx context.Context, assetID string) ([]*Recurrence, error)
+	// FindAll 스케줄러가 처리할 모든 정기 거래를 조회합니다.
+	FindAll(ctx context.Context) ([]*Recurrence, error)
+	Delete(ctx context.Context, id string) error
+}
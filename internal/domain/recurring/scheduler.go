@@ -0,0 +1,264 @@
+package recurring
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+)
+
+// PostingStatus 회차 처리 결과입니다.
+type PostingStatus string
+
+const (
+	PostingPosted  PostingStatus = "POSTED"
+	PostingSkipped PostingStatus = "SKIPPED"
+	PostingFailed  PostingStatus = "FAILED" // 다음 실행에서 다시 시도합니다
+)
+
+// Posting 회차 하나의 처리 결과입니다.
+type Posting struct {
+	RecurrenceID  string
+	Date          time.Time
+	Status        PostingStatus
+	TransactionID string
+	Error         string
+}
+
+// Report 스케줄러 실행 결과입니다.
+type Report struct {
+	Postings []Posting
+	Posted   int
+	Skipped  int
+	Failed   int
+}
+
+func (r *Report) add(p Posting) {
+	r.Postings = append(r.Postings, p)
+	switch p.Status {
+	case PostingPosted:
+		r.Posted++
+	case PostingSkipped:
+		r.Skipped++
+	case PostingFailed:
+		r.Failed++
+	}
+}
+
+// Scheduler 도래한 정기 거래 회차를 자산 거래로 기록합니다.
+// 회차마다 정해진 거래 ID를 사용하고 처리한 회차까지 커서를 저장하므로 여러 번 실행해도 같은 회차를 두 번 기록하지 않으며,
+// 서버가 멈춰 있던 동안 밀린 회차는 다음 실행에서 순서대로 기록합니다.
+type Scheduler struct {
+	repo         Repository
+	assets       asset.Repository
+	transactions asset.TransactionRepository
+	ledger       *ledger.Ledger
+	bus          event.Bus
+	now          func() time.Time
+	mutex        sync.Mutex
+}
+
+// SchedulerOption 스케줄러 설정 함수입니다.
+type SchedulerOption func(*Scheduler)
+
+// WithEventBus 회차를 기록할 때마다 recurring.posted 이벤트를 발행합니다.
+func WithEventBus(bus event.Bus) SchedulerOption {
+	return func(s *Scheduler) {
+		s.bus = bus
+	}
+}
+
+// WithLedger 회차의 거래를 원장에 복식부기 분개로 함께 전기합니다.
+func WithLedger(l *ledger.Ledger) SchedulerOption {
+	return func(s *Scheduler) {
+		s.ledger = l
+	}
+}
+
+// WithClock 현재 시각 함수를 지정합니다.
+func WithClock(now func() time.Time) SchedulerOption {
+	return func(s *Scheduler) {
+		s.now = now
+	}
+}
+
+// NewScheduler 새로운 정기 거래 스케줄러를 생성합니다.
+func NewScheduler(repo Repository, assets asset.Repository, transactions asset.TransactionRepository, opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{repo: repo, assets: assets, transactions: transactions, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start interval마다 도래한 회차를 기록합니다. 시작하자마자 한 번 실행하여 밀린 회차를 처리하며 ctx가 끝나면 멈춥니다.
+func (s *Scheduler) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.RunDue(ctx)
+		if err != nil {
+			log.Printf("정기 거래 처리 실패: %v", err)
+		} else if report.Failed > 0 {
+			log.Printf("정기 거래 %d건을 기록하지 못했습니다", report.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue 모든 정기 거래의 도래한 회차를 기록합니다.
+// 회차 기록에 실패하면 그 정기 거래의 이후 회차는 다음 실행으로 미룹니다.
+func (s *Scheduler) RunDue(ctx context.Context) (*Report, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	recurrences, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	now := s.now()
+	for _, rec := range recurrences {
+		if err := s.process(ctx, rec, now, report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// process 정기 거래 하나의 도래한 회차를 순서대로 처리합니다.
+func (s *Scheduler) process(ctx context.Context, rec *Recurrence, now time.Time, report *Report) error {
+	for _, at := range rec.Due(now) {
+		posting := Posting{RecurrenceID: rec.ID, Date: at, Status: PostingSkipped}
+		if !rec.Overrides[DateKey(at)].Skip {
+			txID, err := s.post(ctx, rec, at)
+			if err != nil {
+				posting.Status = PostingFailed
+				posting.Error = err.Error()
+				report.add(posting)
+				return nil
+			}
+			posting.Status = PostingPosted
+			posting.TransactionID = txID
+		}
+
+		rec.PostedThrough = at
+		if err := s.repo.Save(ctx, rec); err != nil {
+			return err
+		}
+		report.add(posting)
+		if posting.Status == PostingPosted {
+			s.publish(ctx, rec, posting)
+		}
+	}
+	return nil
+}
+
+// post 회차의 거래를 자산에 반영하고 저장합니다. 이미 저장된 회차의 거래는 다시 반영하지 않습니다.
+func (s *Scheduler) post(ctx context.Context, rec *Recurrence, at time.Time) (string, error) {
+	tx, err := rec.Transaction(at)
+	if err != nil {
+		return "", err
+	}
+
+	// 자산 갱신과 거래 저장을 하나의 작업 단위로 처리하여 부분 반영을 막습니다
+	err = s.assets.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.transactions.FindByID(ctx, tx.ID); err == nil {
+			return nil
+		}
+		owner, err := s.assets.FindByID(ctx, rec.AssetID)
+		if err != nil {
+			return err
+		}
+		if s.ledger != nil {
+			if _, err := s.ledger.Record(ctx, tx, owner, nil); err != nil {
+				return err
+			}
+		} else if err := owner.ProcessTransaction(tx); err != nil {
+			return err
+		}
+		if err := s.transactions.Save(ctx, tx); err != nil {
+			return err
+		}
+		return s.assets.Update(ctx, owner)
+	})
+	if err != nil {
+		return "", err
+	}
+	return tx.ID, nil
+}
+
+func (s *Scheduler) publish(ctx context.Context, rec *Recurrence, posting Posting) {
+	if s.bus == nil {
+		return
+	}
+	evt := event.NewEvent(
+		event.TypeRecurringPosted,
+		rec.ID,
+		"recurrence",
+		map[string]interface{}{
+			"transactionID": posting.TransactionID,
+			"assetID":       rec.AssetID,
+			"date":          DateKey(posting.Date),
+			"type":          rec.Type,
+		},
+		map[string]string{
+			"userID": rec.UserID,
+		},
+		1,
+	)
+	// 발행에 실패해도 이미 기록한 거래는 되돌리지 않습니다
+	if err := s.bus.Publish(ctx, evt); err != nil {
+		log.Printf("정기 거래 이벤트 발행 실패(%s): %v", rec.ID, err)
+	}
+}
+
+// Create 정기 거래를 등록합니다. 시작일이 지난 회차는 다음 실행에서 기록합니다.
+func (s *Scheduler) Create(ctx context.Context, rec *Recurrence) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.repo.Save(ctx, rec)
+}
+
+// SetOverride 날짜(YYYY-MM-DD)의 회차를 건너뛰거나 바꿉니다.
+// 실행 중인 처리와 겹치지 않도록 스케줄러를 거쳐 변경합니다.
+func (s *Scheduler) SetOverride(ctx context.Context, id, date string, override Override) (*Recurrence, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rec, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	at, ok := rec.Occurrence(date)
+	if !ok {
+		return nil, notFound(fmt.Sprintf("recurrence %s has no occurrence on %s", id, date))
+	}
+	if err := rec.SetOverride(at, override); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// Delete 정기 거래를 삭제합니다. 이미 기록한 거래는 남겨 둡니다.
+func (s *Scheduler) Delete(ctx context.Context, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.repo.Delete(ctx, id)
+}
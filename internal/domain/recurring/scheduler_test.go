@@ -0,0 +1,166 @@
+package recurring
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+)
+
+type recordingHandler struct {
+	events []event.Event
+}
+
+func (h *recordingHandler) HandleEvent(_ context.Context, evt event.Event) error {
+	h.events = append(h.events, evt)
+	return nil
+}
+
+func (h *recordingHandler) HandlerName() string {
+	return "recording"
+}
+
+type schedulerFixture struct {
+	ctx          context.Context
+	now          time.Time
+	assets       *asset.MemoryAssetRepository
+	transactions *asset.MemoryTransactionRepository
+	repo         *MemoryRepository
+	events       *recordingHandler
+	scheduler    *Scheduler
+	account      *asset.Asset
+}
+
+func newSchedulerFixture(t *testing.T, balance float64) *schedulerFixture {
+	t.Helper()
+	f := &schedulerFixture{
+		ctx:          context.Background(),
+		now:          date(2024, 1, 1),
+		assets:       asset.NewMemoryAssetRepository(),
+		transactions: asset.NewMemoryTransactionRepository(),
+		repo:         NewMemoryRepository(),
+		events:       &recordingHandler{},
+	}
+	bus := memory.NewEventBus()
+	require.NoError(t, bus.Subscribe(f.events))
+	f.scheduler = NewScheduler(f.repo, f.assets, f.transactions, WithEventBus(bus), WithClock(func() time.Time { return f.now }))
+
+	var err error
+	f.account, err = asset.NewAsset("user-1", asset.Cash, "입출금", balance, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, f.account))
+	return f
+}
+
+func (f *schedulerFixture) create(t *testing.T, txType asset.TransactionType, amount float64, schedule Schedule) *Recurrence {
+	t.Helper()
+	rec, err := NewRecurrence(f.account, txType, asset.Money{Amount: amount, Currency: "KRW"}, "정기", "정기 거래", schedule, date(2024, 1, 1), nil)
+	require.NoError(t, err)
+	require.NoError(t, f.scheduler.Create(f.ctx, rec))
+	return rec
+}
+
+func (f *schedulerFixture) balance(t *testing.T) float64 {
+	t.Helper()
+	found, err := f.assets.FindByID(f.ctx, f.account.ID)
+	require.NoError(t, err)
+	return found.Amount.Amount
+}
+
+func Test_Scheduler_should_catch_up_missed_occurrences_once(t *testing.T) {
+	// Given
+	f := newSchedulerFixture(t, 0)
+	rec := f.create(t, asset.Income, 3000000, Schedule{Frequency: Monthly, DayOfMonth: 25})
+	f.now = date(2024, 3, 30)
+
+	// When
+	first, err := f.scheduler.RunDue(f.ctx)
+	require.NoError(t, err)
+	second, err := f.scheduler.RunDue(f.ctx)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, 3, first.Posted)
+	assert.Equal(t, 0, second.Posted)
+	assert.Equal(t, 9000000.0, f.balance(t))
+	txs, err := f.transactions.FindByAssetID(f.ctx, f.account.ID)
+	require.NoError(t, err)
+	assert.Len(t, txs, 3)
+	require.Len(t, f.events.events, 3)
+	assert.Equal(t, event.TypeRecurringPosted, f.events.events[0].EventType())
+	assert.Equal(t, rec.ID, f.events.events[0].AggregateID())
+	assert.Equal(t, "2024-01-25", f.events.events[0].Payload().(map[string]interface{})["date"])
+}
+
+func Test_Scheduler_should_not_post_twice_when_cursor_was_not_saved(t *testing.T) {
+	// Given
+	f := newSchedulerFixture(t, 0)
+	rec := f.create(t, asset.Income, 1000, Schedule{Frequency: Daily})
+	f.now = date(2024, 1, 2)
+	_, err := f.scheduler.RunDue(f.ctx)
+	require.NoError(t, err)
+
+	// When
+	rec.PostedThrough = time.Time{}
+	require.NoError(t, f.repo.Save(f.ctx, rec))
+	report, err := f.scheduler.RunDue(f.ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Posted)
+	assert.Equal(t, 2000.0, f.balance(t))
+}
+
+func Test_Scheduler_should_skip_or_edit_individual_occurrences(t *testing.T) {
+	// Given
+	f := newSchedulerFixture(t, 100000)
+	rec := f.create(t, asset.Expense, 10000, Schedule{Frequency: Weekly})
+	amount := 25000.0
+	memo := "연말 정산"
+	_, err := f.scheduler.SetOverride(f.ctx, rec.ID, "2024-01-08", Override{Skip: true})
+	require.NoError(t, err)
+	_, err = f.scheduler.SetOverride(f.ctx, rec.ID, "2024-01-15", Override{Amount: &amount, Description: &memo})
+	require.NoError(t, err)
+	_, err = f.scheduler.SetOverride(f.ctx, rec.ID, "2024-01-09", Override{Skip: true})
+	assert.Error(t, err)
+
+	// When
+	f.now = date(2024, 1, 15)
+	report, err := f.scheduler.RunDue(f.ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Posted)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 100000.0-10000-25000, f.balance(t))
+	edited, err := f.transactions.FindByID(f.ctx, OccurrenceTransactionID(rec.ID, date(2024, 1, 15)))
+	require.NoError(t, err)
+	assert.Equal(t, "연말 정산", edited.Description)
+	assert.Len(t, f.events.events, 2)
+}
+
+func Test_Scheduler_should_retry_failed_occurrence_on_next_run(t *testing.T) {
+	// Given
+	f := newSchedulerFixture(t, 5000)
+	f.create(t, asset.Expense, 10000, Schedule{Frequency: Monthly})
+	f.now = date(2024, 2, 1)
+	failed, err := f.scheduler.RunDue(f.ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, failed.Failed)
+
+	// When
+	f.account.Amount.Amount = 50000
+	require.NoError(t, f.assets.Update(f.ctx, f.account))
+	report, err := f.scheduler.RunDue(f.ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Posted)
+	assert.Equal(t, 30000.0, f.balance(t))
+}
@@ -0,0 +1,112 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Table 보고서의 한 표입니다. CSV에서는 이름 행 뒤에 이어지는 블록, XLSX에서는 시트 하나가 됩니다.
+type Table struct {
+	Name   string
+	Header []string
+	// Rows 칸 값은 string 또는 float64입니다
+	Rows [][]interface{}
+}
+
+// Tables 보고서를 요약, 기간, 분류, 자산, 유형, 거래처 표로 나눕니다.
+func Tables(r *Report) []Table {
+	summary := Table{
+		Name:   "요약",
+		Header: []string{"항목", "값"},
+		Rows: [][]interface{}{
+			{"시작", r.Range.Start.Format(time.RFC3339)},
+			{"종료", r.Range.End.Format(time.RFC3339)},
+			{"통화", r.Currency},
+			{"수입", r.Totals.Income},
+			{"지출", r.Totals.Expense},
+			{"이체", r.Totals.Transfer},
+			{"순수입", r.Totals.Net},
+			{"저축률", r.Totals.SavingsRate},
+			{"거래 수", float64(r.Totals.Count)},
+			{"환산 제외 거래 수", float64(r.Excluded)},
+		},
+	}
+
+	periods := Table{
+		Name:   "기간",
+		Header: []string{"기간", "수입", "지출", "이체", "순수입", "저축률", "거래 수", "수입 증감", "지출 증감", "순수입 증감", "수입 증감률", "지출 증감률"},
+	}
+	for _, p := range r.Periods {
+		periods.Rows = append(periods.Rows, []interface{}{
+			p.Key, p.Income, p.Expense, p.Transfer, p.Net, p.SavingsRate, float64(p.Count),
+			p.Change.Income, p.Change.Expense, p.Change.Net, optional(p.Change.IncomeRate), optional(p.Change.ExpenseRate),
+		})
+	}
+
+	tables := []Table{summary, periods}
+	for _, b := range []struct {
+		name  string
+		items []Breakdown
+	}{{"분류", r.Categories}, {"자산", r.Assets}, {"유형", r.Types}} {
+		table := Table{Name: b.name, Header: []string{b.name, "수입", "지출", "이체", "순수입", "거래 수", "지출 비중"}}
+		for _, item := range b.items {
+			table.Rows = append(table.Rows, []interface{}{item.Name, item.Income, item.Expense, item.Transfer, item.Net, float64(item.Count), item.Share})
+		}
+		tables = append(tables, table)
+	}
+
+	merchants := Table{Name: "거래처", Header: []string{"거래처", "지출", "거래 수"}}
+	for _, m := range r.Merchants {
+		merchants.Rows = append(merchants.Rows, []interface{}{m.Name, m.Expense, float64(m.Count)})
+	}
+	return append(tables, merchants)
+}
+
+// WriteCSV 표를 CSV로 씁니다. 표 사이에는 빈 행을 둡니다.
+func WriteCSV(w io.Writer, tables []Table) error {
+	writer := csv.NewWriter(w)
+	for i, table := range tables {
+		if i > 0 {
+			if err := writer.Write([]string{}); err != nil {
+				return err
+			}
+		}
+		if err := writer.Write([]string{table.Name}); err != nil {
+			return err
+		}
+		if err := writer.Write(table.Header); err != nil {
+			return err
+		}
+		for _, row := range table.Rows {
+			record := make([]string, len(row))
+			for j, cell := range row {
+				record[j] = formatCell(cell)
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func optional(v *float64) interface{} {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func formatCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
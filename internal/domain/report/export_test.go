@@ -0,0 +1,102 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+)
+
+func sampleReport() *Report {
+	rate := 0.5
+	return &Report{
+		Range:    valueobjects.TimeRange{Start: date(2024, 1, 1), End: date(2024, 1, 31)},
+		Currency: "KRW",
+		Totals:   Totals{Income: 3000000, Expense: 1000000, Net: 2000000, SavingsRate: 2.0 / 3, Count: 3},
+		Periods: []Period{{
+			Key:    "2024-01",
+			Totals: Totals{Income: 3000000, Expense: 1000000, Net: 2000000, Count: 3},
+			Change: Change{Expense: 500000, ExpenseRate: &rate},
+		}},
+		Categories: []Breakdown{{Key: "식비", Name: "식비 & 외식", Totals: Totals{Expense: 1000000, Count: 2}, Share: 1}},
+		Merchants:  []Merchant{{Name: "마트", Expense: 1000000, Count: 2}},
+	}
+}
+
+func Test_WriteCSV_should_write_table_blocks(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+
+	// When
+	require.NoError(t, WriteCSV(&buf, Tables(sampleReport())))
+
+	// Then
+	reader := csv.NewReader(&buf)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"요약"}, records[0])
+	assert.Contains(t, records, []string{"지출", "1000000"})
+	assert.Contains(t, records, []string{"2024-01", "3000000", "1000000", "0", "2000000", "0", "3", "0", "500000", "0", "", "0.5"})
+	assert.Contains(t, records, []string{"마트", "1000000", "2"})
+}
+
+func Test_WriteXLSX_should_write_sheet_per_table(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+
+	// When
+	require.NoError(t, WriteXLSX(&buf, Tables(sampleReport())))
+
+	// Then
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(content)
+	}
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="거래처" sheetId="6" r:id="rId6"/>`)
+	assert.Contains(t, files["xl/worksheets/sheet3.xml"], `<t>식비 &amp; 외식</t>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="B6"><v>1000000</v></c>`)
+	assert.True(t, strings.HasPrefix(files["xl/worksheets/sheet2.xml"], "<?xml"))
+	assert.Equal(t, "AA", columnName(26))
+}
+
+func Test_sheetNames_should_sanitize_truncate_and_deduplicate(t *testing.T) {
+	// Given
+	long := strings.Repeat("가", 40)
+	tables := []Table{
+		{Name: "2024/01: 식비[외식]?"},
+		{Name: long},
+		{Name: long},
+		{Name: "요약"},
+		{Name: "요약"},
+		{Name: "'*'"},
+	}
+
+	// When
+	names := sheetNames(tables)
+
+	// Then
+	assert.Equal(t, []string{
+		"2024_01_ 식비_외식__",
+		strings.Repeat("가", 31),
+		strings.Repeat("가", 27) + " (2)",
+		"요약",
+		"요약 (2)",
+		"_",
+	}, names)
+}
@@ -0,0 +1,273 @@
+package report
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// uncategorized 분류가 없는 거래를 묶는 이름입니다.
+const uncategorized = "미분류"
+
+// Generator 사용자 자산의 거래로 보고서를 만듭니다.
+type Generator struct {
+	assets       asset.Repository
+	transactions asset.TransactionRepository
+	rates        Rates
+	now          func() time.Time
+}
+
+// GeneratorOption 보고서 생성기 설정 함수입니다.
+type GeneratorOption func(*Generator)
+
+// WithRates 기준 통화와 다른 통화의 거래를 환산할 환율을 지정합니다.
+// 지정하지 않으면 기준 통화와 같은 통화의 거래만 집계합니다.
+func WithRates(rates Rates) GeneratorOption {
+	return func(g *Generator) {
+		g.rates = rates
+	}
+}
+
+// NewGenerator 새로운 보고서 생성기를 생성합니다.
+func NewGenerator(assets asset.Repository, transactions asset.TransactionRepository, opts ...GeneratorOption) *Generator {
+	g := &Generator{assets: assets, transactions: transactions, rates: RateTable{}, now: time.Now}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Generate 보고서를 만듭니다. 기간별 증감을 계산하기 위해 범위 바로 앞 기간의 거래도 읽습니다.
+func (g *Generator) Generate(ctx context.Context, req Request) (*Report, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	owned, err := g.assets.FindByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Currency == "" {
+		req.Currency = baseCurrency(owned)
+	}
+	if req.TopMerchants == 0 {
+		req.TopMerchants = DefaultTopMerchants
+	}
+	assetNames := make(map[string]string, len(owned))
+	for _, a := range owned {
+		assetNames[a.ID] = a.Name
+	}
+
+	loc := req.Range.Start.Location()
+	first := truncate(req.Granularity, req.Range.Start.In(loc))
+	previousStart := step(req.Granularity, first, -1)
+	txs, err := g.transactions.FindByDateRange(ctx, previousStart, req.Range.End)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		UserID:      req.UserID,
+		Range:       req.Range,
+		Currency:    req.Currency,
+		Granularity: req.Granularity,
+		GeneratedAt: g.now(),
+	}
+	periods := make(map[string]*Period)
+	for start := first; !start.After(req.Range.End); start = step(req.Granularity, start, 1) {
+		report.Periods = append(report.Periods, Period{Key: periodKey(req.Granularity, start), Start: start, End: step(req.Granularity, start, 1)})
+	}
+	for i := range report.Periods {
+		periods[report.Periods[i].Key] = &report.Periods[i]
+	}
+	var previous Totals
+	categories := newBreakdowns()
+	assets := newBreakdowns()
+	types := newBreakdowns()
+	merchants := make(map[string]*Merchant)
+
+	for _, tx := range txs {
+		if _, ok := assetNames[tx.AssetID]; !ok {
+			continue
+		}
+		at := tx.Date.In(loc)
+		inRange := req.Range.Contains(at)
+		amount, err := g.rates.Convert(tx.Amount, req.Currency)
+		if err != nil {
+			if inRange {
+				report.Excluded++
+			}
+			continue
+		}
+		if at.Before(first) {
+			previous.add(tx.Type, amount)
+			continue
+		}
+		if !inRange {
+			continue
+		}
+
+		report.Totals.add(tx.Type, amount)
+		periods[periodKey(req.Granularity, truncate(req.Granularity, at))].add(tx.Type, amount)
+		categoryName := strings.TrimSpace(tx.Category)
+		if categoryName == "" {
+			categoryName = uncategorized
+		}
+		categories.add(strings.ToLower(categoryName), categoryName, tx.Type, amount)
+		assets.add(tx.AssetID, assetNames[tx.AssetID], tx.Type, amount)
+		types.add(string(tx.Type), string(tx.Type), tx.Type, amount)
+		if name := merchantName(tx.Description); name != "" && tx.Type == asset.Expense {
+			key := strings.ToLower(name)
+			m, ok := merchants[key]
+			if !ok {
+				m = &Merchant{Name: name}
+				merchants[key] = m
+			}
+			m.Expense += amount
+			m.Count++
+		}
+	}
+
+	for i := range report.Periods {
+		report.Periods[i].Change = change(previous, report.Periods[i].Totals)
+		previous = report.Periods[i].Totals
+	}
+	report.Categories = categories.sorted(report.Totals.Expense)
+	report.Assets = assets.sorted(report.Totals.Expense)
+	report.Types = types.sorted(report.Totals.Expense)
+	report.Merchants = topMerchants(merchants, req.TopMerchants)
+	return report, nil
+}
+
+// baseCurrency 사용자 자산에 가장 많이 쓰인 통화입니다.
+// 같은 수의 자산에 쓰인 통화가 여럿이면 기본 통화를, 기본 통화가 없으면 통화 코드 순서로 앞선 통화를 사용합니다.
+func baseCurrency(owned []*asset.Asset) string {
+	counts := make(map[string]int)
+	for _, a := range owned {
+		counts[a.Amount.Currency]++
+	}
+	best := DefaultCurrency
+	for currency, count := range counts {
+		switch {
+		case count > counts[best]:
+			best = currency
+		case count == counts[best] && best != DefaultCurrency && currency < best:
+			best = currency
+		}
+	}
+	return best
+}
+
+// truncate at이 속한 기간의 시작입니다.
+func truncate(granularity Granularity, at time.Time) time.Time {
+	switch granularity {
+	case Daily:
+		return time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	case Yearly:
+		return time.Date(at.Year(), time.January, 1, 0, 0, 0, 0, at.Location())
+	default:
+		return time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, at.Location())
+	}
+}
+
+// step 기간 시작을 n개 기간만큼 옮깁니다.
+func step(granularity Granularity, start time.Time, n int) time.Time {
+	switch granularity {
+	case Daily:
+		return start.AddDate(0, 0, n)
+	case Yearly:
+		return start.AddDate(n, 0, 0)
+	default:
+		return start.AddDate(0, n, 0)
+	}
+}
+
+func periodKey(granularity Granularity, start time.Time) string {
+	switch granularity {
+	case Daily:
+		return start.Format("2006-01-02")
+	case Yearly:
+		return start.Format("2006")
+	default:
+		return start.Format("2006-01")
+	}
+}
+
+func change(previous, current Totals) Change {
+	return Change{
+		Income:      current.Income - previous.Income,
+		Expense:     current.Expense - previous.Expense,
+		Net:         current.Net - previous.Net,
+		IncomeRate:  rate(previous.Income, current.Income),
+		ExpenseRate: rate(previous.Expense, current.Expense),
+	}
+}
+
+func rate(previous, current float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	r := (current - previous) / previous
+	return &r
+}
+
+// merchantName 거래 설명의 거래처 부분입니다. 가져온 거래는 "거래처 - 메모" 형식의 설명을 가집니다.
+func merchantName(description string) string {
+	name, _, _ := strings.Cut(description, " - ")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+type breakdowns map[string]*Breakdown
+
+func newBreakdowns() breakdowns {
+	return make(breakdowns)
+}
+
+func (b breakdowns) add(key, name string, txType asset.TransactionType, amount float64) {
+	item, ok := b[key]
+	if !ok {
+		item = &Breakdown{Key: key, Name: name}
+		b[key] = item
+	}
+	item.Totals.add(txType, amount)
+}
+
+// sorted 지출이 큰 순서로 정렬합니다. 지출이 같으면 수입이 큰 순서, 이름 순서입니다.
+func (b breakdowns) sorted(totalExpense float64) []Breakdown {
+	items := make([]Breakdown, 0, len(b))
+	for _, item := range b {
+		if totalExpense > 0 {
+			item.Share = item.Expense / totalExpense
+		}
+		items = append(items, *item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Expense != items[j].Expense {
+			return items[i].Expense > items[j].Expense
+		}
+		if items[i].Income != items[j].Income {
+			return items[i].Income > items[j].Income
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+func topMerchants(merchants map[string]*Merchant, limit int) []Merchant {
+	items := make([]Merchant, 0, len(merchants))
+	for _, m := range merchants {
+		items = append(items, *m)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Expense != items[j].Expense {
+			return items[i].Expense > items[j].Expense
+		}
+		return items[i].Name < items[j].Name
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
@@ -0,0 +1,163 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+type generatorFixture struct {
+	ctx          context.Context
+	assets       *asset.MemoryAssetRepository
+	transactions *asset.MemoryTransactionRepository
+	account      *asset.Asset
+	dollars      *asset.Asset
+}
+
+func newGeneratorFixture(t *testing.T) *generatorFixture {
+	t.Helper()
+	f := &generatorFixture{
+		ctx:          context.Background(),
+		assets:       asset.NewMemoryAssetRepository(),
+		transactions: asset.NewMemoryTransactionRepository(),
+	}
+	var err error
+	f.account, err = asset.NewAsset("user-1", asset.Cash, "입출금", 0, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, f.account))
+	f.dollars, err = asset.NewAsset("user-1", asset.Cash, "외화", 0, "USD")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, f.dollars))
+	return f
+}
+
+func (f *generatorFixture) record(t *testing.T, a *asset.Asset, txType asset.TransactionType, amount float64, category, description string, at time.Time) {
+	t.Helper()
+	tx, err := asset.NewTransaction(a.ID, txType, asset.Money{Amount: amount, Currency: a.Amount.Currency}, category, description)
+	require.NoError(t, err)
+	tx.Date = at
+	require.NoError(t, f.transactions.Save(f.ctx, tx))
+}
+
+func yearOf(t *testing.T, year int) valueobjects.TimeRange {
+	t.Helper()
+	r, err := valueobjects.NewTimeRange(date(year, 1, 1), date(year+1, 1, 1).Add(-time.Nanosecond))
+	require.NoError(t, err)
+	return r
+}
+
+func Test_Generator_should_aggregate_by_period_category_asset_and_type(t *testing.T) {
+	// Given
+	f := newGeneratorFixture(t)
+	f.record(t, f.account, asset.Income, 3000000, "급여", "회사", date(2023, 12, 25))
+	f.record(t, f.account, asset.Expense, 1000000, "식비", "마트 - 장보기", date(2023, 12, 26))
+	f.record(t, f.account, asset.Income, 3000000, "급여", "회사", date(2024, 1, 25))
+	f.record(t, f.account, asset.Expense, 600000, "식비", "마트 - 장보기", date(2024, 1, 5))
+	f.record(t, f.account, asset.Expense, 200000, "", "카페", date(2024, 1, 6))
+	f.record(t, f.account, asset.Transfer, 500000, "이체", "", date(2024, 1, 7))
+	f.record(t, f.account, asset.Income, 3300000, "급여", "회사", date(2024, 2, 25))
+	f.record(t, f.account, asset.Expense, 900000, "식비", "마트 - 외식", date(2024, 2, 10))
+	f.record(t, f.dollars, asset.Expense, 100, "여행", "항공사", date(2024, 2, 11))
+	generator := NewGenerator(f.assets, f.transactions, WithRates(RateTable{"KRW": 1, "USD": 1300}))
+
+	// When
+	report, err := generator.Generate(f.ctx, Request{UserID: "user-1", Range: yearOf(t, 2024), Granularity: Monthly})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "KRW", report.Currency)
+	assert.Equal(t, 6300000.0, report.Totals.Income)
+	assert.Equal(t, 1830000.0, report.Totals.Expense)
+	assert.Equal(t, 500000.0, report.Totals.Transfer)
+	assert.InDelta(t, 4470000.0/6300000.0, report.Totals.SavingsRate, 1e-9)
+	assert.Zero(t, report.Excluded)
+
+	require.Len(t, report.Periods, 12)
+	january, february := report.Periods[0], report.Periods[1]
+	assert.Equal(t, "2024-01", january.Key)
+	assert.Equal(t, 800000.0, january.Expense)
+	assert.Equal(t, -200000.0, january.Change.Expense)
+	require.NotNil(t, january.Change.ExpenseRate)
+	assert.InDelta(t, -0.2, *january.Change.ExpenseRate, 1e-9)
+	assert.Equal(t, 1030000.0, february.Expense)
+	assert.Equal(t, 300000.0, february.Change.Income)
+	assert.Nil(t, report.Periods[3].Change.ExpenseRate)
+
+	require.Len(t, report.Categories, 5)
+	assert.Equal(t, "식비", report.Categories[0].Name)
+	assert.InDelta(t, 1500000.0/1830000.0, report.Categories[0].Share, 1e-9)
+	assert.Equal(t, "미분류", report.Categories[1].Name)
+	require.Len(t, report.Assets, 2)
+	assert.Equal(t, "입출금", report.Assets[0].Name)
+	assert.Equal(t, 130000.0, report.Assets[1].Expense)
+	require.Len(t, report.Types, 3)
+	assert.Equal(t, string(asset.Expense), report.Types[0].Key)
+
+	require.Len(t, report.Merchants, 3)
+	assert.Equal(t, Merchant{Name: "마트", Expense: 1500000, Count: 2}, report.Merchants[0])
+}
+
+func Test_Generator_should_exclude_unconvertible_transactions_and_other_users(t *testing.T) {
+	// Given
+	f := newGeneratorFixture(t)
+	other, err := asset.NewAsset("user-2", asset.Cash, "다른 사용자", 0, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, f.assets.Save(f.ctx, other))
+	f.record(t, f.account, asset.Expense, 10000, "식비", "", date(2024, 3, 1))
+	f.record(t, f.dollars, asset.Expense, 10, "식비", "", date(2024, 3, 2))
+	f.record(t, other, asset.Expense, 50000, "식비", "", date(2024, 3, 3))
+
+	// When
+	report, err := NewGenerator(f.assets, f.transactions).Generate(f.ctx, Request{
+		UserID: "user-1", Range: yearOf(t, 2024), Currency: "KRW", Granularity: Yearly, TopMerchants: 1,
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 10000.0, report.Totals.Expense)
+	assert.Equal(t, 1, report.Excluded)
+	require.Len(t, report.Periods, 1)
+	assert.Equal(t, "2024", report.Periods[0].Key)
+}
+
+func Test_Generator_should_reject_invalid_request(t *testing.T) {
+	f := newGeneratorFixture(t)
+	generator := NewGenerator(f.assets, f.transactions)
+
+	_, err := generator.Generate(f.ctx, Request{Range: yearOf(t, 2024), Granularity: Monthly})
+	assert.Error(t, err)
+	_, err = generator.Generate(f.ctx, Request{UserID: "user-1", Granularity: Monthly})
+	assert.Error(t, err)
+	_, err = generator.Generate(f.ctx, Request{UserID: "user-1", Range: yearOf(t, 2024), Granularity: "WEEKLY"})
+	assert.Error(t, err)
+}
+
+func Test_RateTable_should_convert_through_common_unit(t *testing.T) {
+	// Given
+	table, err := ParseRateTable("krw=1, USD=1350,EUR=1450")
+	require.NoError(t, err)
+
+	// When
+	won, err := table.Convert(asset.Money{Amount: 2, Currency: "USD"}, "KRW")
+	require.NoError(t, err)
+	dollars, err := table.Convert(asset.Money{Amount: 2700, Currency: "KRW"}, "USD")
+	require.NoError(t, err)
+	_, missing := table.Convert(asset.Money{Amount: 1, Currency: "JPY"}, "KRW")
+
+	// Then
+	assert.Equal(t, 2700.0, won)
+	assert.Equal(t, 2.0, dollars)
+	assert.Error(t, missing)
+	_, err = ParseRateTable("USD")
+	assert.Error(t, err)
+}
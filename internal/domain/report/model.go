@@ -0,0 +1,143 @@
+// Package report 자산 거래를 기간, 분류, 자산, 유형별로 집계하여 수입/지출 보고서를 만듭니다.
+// 보고서는 사용자의 기준 통화로 계산하며 JSON, CSV, XLSX로 내보낼 수 있습니다.
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+)
+
+// Granularity 기간별 집계 단위입니다.
+type Granularity string
+
+const (
+	Daily   Granularity = "DAILY"
+	Monthly Granularity = "MONTHLY"
+	Yearly  Granularity = "YEARLY"
+)
+
+// DefaultCurrency 자산이 없는 사용자의 기준 통화입니다.
+const DefaultCurrency = "KRW"
+
+// DefaultTopMerchants 보고서에 포함하는 기본 상위 거래처 수입니다.
+const DefaultTopMerchants = 10
+
+// Request 보고서 생성 조건입니다.
+type Request struct {
+	UserID string
+	// Range 집계 기간입니다. 시작과 끝을 모두 포함합니다
+	Range valueobjects.TimeRange
+	// Currency 기준 통화입니다. 다른 통화의 거래는 환율로 환산하며, 비어 있으면 사용자 자산에 가장 많이 쓰인 통화를 사용합니다
+	Currency    string
+	Granularity Granularity
+	// TopMerchants 포함할 상위 거래처 수입니다. 0이면 DefaultTopMerchants를 사용합니다
+	TopMerchants int
+}
+
+func (r Request) validate() error {
+	if r.UserID == "" {
+		return invalid("user ID is required")
+	}
+	if r.Range.IsZero() || r.Range.End.Before(r.Range.Start) {
+		return invalid("report range is required")
+	}
+	switch r.Granularity {
+	case Daily, Monthly, Yearly:
+	default:
+		return invalid(fmt.Sprintf("unsupported granularity %q", r.Granularity))
+	}
+	if r.TopMerchants < 0 {
+		return invalid("top merchants must not be negative")
+	}
+	return nil
+}
+
+// Totals 수입, 지출, 이체 합계입니다.
+type Totals struct {
+	Income   float64
+	Expense  float64
+	Transfer float64
+	// Net 수입에서 지출을 뺀 금액입니다. 이체는 자산 간 이동이므로 포함하지 않습니다
+	Net float64
+	// SavingsRate 수입 대비 순수입 비율입니다. 수입이 없으면 0입니다
+	SavingsRate float64
+	Count       int
+}
+
+func (t *Totals) add(txType asset.TransactionType, amount float64) {
+	switch txType {
+	case asset.Income:
+		t.Income += amount
+	case asset.Expense:
+		t.Expense += amount
+	default:
+		t.Transfer += amount
+	}
+	t.Count++
+	t.Net = t.Income - t.Expense
+	t.SavingsRate = 0
+	if t.Income > 0 {
+		t.SavingsRate = t.Net / t.Income
+	}
+}
+
+// Change 직전 기간 대비 변화입니다.
+type Change struct {
+	Income  float64
+	Expense float64
+	Net     float64
+	// IncomeRate, ExpenseRate 직전 기간 대비 증감률입니다. 직전 기간 금액이 0이면 nil입니다
+	IncomeRate  *float64
+	ExpenseRate *float64
+}
+
+// Period 한 집계 기간의 합계입니다.
+type Period struct {
+	Key   string
+	Start time.Time
+	End   time.Time
+	Totals
+	// Change 직전 기간 대비 변화입니다. 보고서의 첫 기간도 보고서 범위 바로 앞 기간과 비교합니다
+	Change Change
+}
+
+// Breakdown 분류, 자산, 유형별 합계입니다.
+type Breakdown struct {
+	Key  string
+	Name string
+	Totals
+	// Share 전체 지출 중 이 항목의 지출 비율입니다
+	Share float64
+}
+
+// Merchant 지출 거래처별 합계입니다.
+type Merchant struct {
+	Name    string
+	Expense float64
+	Count   int
+}
+
+// Report 수입/지출 보고서입니다.
+type Report struct {
+	UserID      string
+	Range       valueobjects.TimeRange
+	Currency    string
+	Granularity Granularity
+	Totals      Totals
+	Periods     []Period
+	Categories  []Breakdown
+	Assets      []Breakdown
+	Types       []Breakdown
+	Merchants   []Merchant
+	// Excluded 기준 통화로 환산할 수 없어 집계에서 뺀 거래 수입니다
+	Excluded    int
+	GeneratedAt time.Time
+}
+
+func invalid(msg string) error {
+	return domain.NewError("report", domain.ErrCodeInvalidArgument, msg)
+}
@@ -0,0 +1,51 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// Rates 금액을 다른 통화로 환산합니다.
+type Rates interface {
+	Convert(amount asset.Money, currency string) (float64, error)
+}
+
+// RateTable 통화 1단위의 공통 기준 가치입니다.
+// 예를 들어 {"KRW": 1, "USD": 1350}이면 1달러를 1350원으로, 1350원을 1달러로 환산합니다.
+type RateTable map[string]float64
+
+// Convert 금액을 currency로 환산합니다. 같은 통화는 그대로 반환합니다.
+func (t RateTable) Convert(amount asset.Money, currency string) (float64, error) {
+	if amount.Currency == currency {
+		return amount.Amount, nil
+	}
+	from, ok := t[amount.Currency]
+	to, ok2 := t[currency]
+	if !ok || !ok2 || from <= 0 || to <= 0 {
+		return 0, invalid(fmt.Sprintf("no rate from %s to %s", amount.Currency, currency))
+	}
+	return amount.Amount * from / to, nil
+}
+
+// ParseRateTable "KRW=1,USD=1350" 형식의 환율 목록을 읽습니다.
+func ParseRateTable(s string) (RateTable, error) {
+	table := make(RateTable)
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		currency, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, invalid(fmt.Sprintf("invalid rate %q", pair))
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			return nil, invalid(fmt.Sprintf("invalid rate %q", pair))
+		}
+		table[strings.ToUpper(strings.TrimSpace(currency))] = rate
+	}
+	return table, nil
+}
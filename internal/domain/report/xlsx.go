@@ -0,0 +1,169 @@
+package report
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// XLSX 파트의 고정 내용입니다. 외부 라이브러리 없이 표마다 시트 하나를 가진 최소 통합 문서를 만듭니다.
+const (
+	xlsxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+	xlsxRels   = xlsxHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	// maxSheetName 엑셀이 허용하는 시트 이름의 최대 글자 수입니다.
+	maxSheetName = 31
+)
+
+// WriteXLSX 표마다 시트 하나를 가진 XLSX 통합 문서를 씁니다.
+func WriteXLSX(w io.Writer, tables []Table) error {
+	archive := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes(len(tables))},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", workbook(tables)},
+		{"xl/_rels/workbook.xml.rels", workbookRels(len(tables))},
+	}
+	for i, table := range tables {
+		parts = append(parts, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheet(table)})
+	}
+
+	for _, part := range parts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func contentTypes(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xlsxHeader)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func workbook(tables []Table) string {
+	var b strings.Builder
+	b.WriteString(xlsxHeader)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range sheetNames(tables) {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func workbookRels(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xlsxHeader)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func worksheet(table Table) string {
+	var b strings.Builder
+	b.WriteString(xlsxHeader)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	header := make([]interface{}, len(table.Header))
+	for i, h := range table.Header {
+		header[i] = h
+	}
+	for i, row := range append([][]interface{}{header}, table.Rows...) {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, cell := range row {
+			ref := fmt.Sprintf("%s%d", columnName(j), i+1)
+			switch v := cell.(type) {
+			case float64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, formatCell(v))
+			case string:
+				if v != "" {
+					fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, escape(v))
+				}
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// sheetNames 표 이름을 엑셀이 받아들이는 시트 이름으로 바꿉니다.
+// 쓸 수 없는 문자 []:*?/\는 _로 바꾸고 31자로 자르며, 대소문자를 무시하고 겹치는 이름에는 (2), (3)을 붙입니다.
+func sheetNames(tables []Table) []string {
+	names := make([]string, len(tables))
+	used := make(map[string]bool, len(tables))
+	for i, table := range tables {
+		base := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`[]:*?/\`, r) {
+				return '_'
+			}
+			return r
+		}, table.Name)
+		// 작은따옴표로 시작하거나 끝나는 이름도 허용되지 않습니다
+		base = strings.Trim(base, "'")
+		if strings.TrimSpace(base) == "" {
+			base = fmt.Sprintf("Sheet%d", i+1)
+		}
+
+		name := truncateRunes(base, maxSheetName)
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = truncateRunes(base, maxSheetName-len(suffix)) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+// truncateRunes s를 최대 n글자로 자릅니다.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// columnName 0부터 시작하는 열 번호를 A, B, ..., AA 형식의 열 이름으로 바꿉니다.
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -17,6 +17,11 @@ type SearchCriteria interface {
 	ToQuery() (string, []interface{})
 }
 
+// Matcher 인메모리 저장소에서 직접 평가할 수 있는 검색 조건 인터페이스
+type Matcher interface {
+	Match(entity interface{}) (bool, error)
+}
+
 // Repository 기본 레포지토리 인터페이스
 type Repository[T Entity, ID comparable] interface {
 	// 기본 CRUD 작업
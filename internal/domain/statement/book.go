@@ -0,0 +1,279 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+)
+
+// ErrMergeUnsupported 병합할 수 없는 거래에 대한 에러입니다.
+var ErrMergeUnsupported = errors.New("이 거래는 병합할 수 없습니다")
+
+// Book 거래 내역과 대사할 기존 거래를 제공하는 장부입니다.
+type Book interface {
+	// Candidates 대상의 from~to 기간 거래를 조회합니다.
+	Candidates(ctx context.Context, target Target, from, to time.Time) ([]Candidate, error)
+	// MarkReconciled 거래를 대사 완료로 표시합니다.
+	MarkReconciled(ctx context.Context, target Target, transactionID string) error
+	// Merge 거래 내역의 날짜와 설명을 기존 거래에 반영하고 대사 완료로 표시합니다.
+	Merge(ctx context.Context, target Target, transactionID string, record Record) error
+	// BalanceAt at 시점의 장부 잔액을 계산합니다. 잔액을 계산할 수 없는 장부는 ok가 false입니다.
+	BalanceAt(ctx context.Context, target Target, at time.Time) (balance float64, ok bool, err error)
+}
+
+// AssetBook 모놀리스 자산의 입출금 거래 장부입니다.
+type AssetBook struct {
+	assets       asset.Repository
+	transactions asset.TransactionRepository
+}
+
+// NewAssetBook 새로운 자산 거래 장부를 생성합니다.
+func NewAssetBook(assets asset.Repository, transactions asset.TransactionRepository) *AssetBook {
+	return &AssetBook{assets: assets, transactions: transactions}
+}
+
+// signedAmount 자산 잔액에 반영되는 방향의 거래 금액입니다.
+func signedAmount(tx *asset.Transaction) float64 {
+	if tx.Type == asset.Income {
+		return tx.Amount.Amount
+	}
+	return -tx.Amount.Amount
+}
+
+// Candidates 자산의 from~to 기간 거래를 조회합니다.
+func (b *AssetBook) Candidates(ctx context.Context, target Target, from, to time.Time) ([]Candidate, error) {
+	transactions, err := b.transactions.FindByAssetID(ctx, target.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]Candidate, 0, len(transactions))
+	for _, tx := range transactions {
+		if tx.Date.Before(from) || tx.Date.After(to) {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			ID:          tx.ID,
+			Source:      "asset",
+			Date:        tx.Date,
+			Amount:      signedAmount(tx),
+			Currency:    tx.Amount.Currency,
+			Description: tx.Description,
+			Reconciled:  tx.Reconciled,
+		})
+	}
+	return candidates, nil
+}
+
+// MarkReconciled 거래를 대사 완료로 표시합니다.
+func (b *AssetBook) MarkReconciled(ctx context.Context, _ Target, transactionID string) error {
+	tx, err := b.transactions.FindByID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+	tx.Reconciled = true
+	return b.transactions.Update(ctx, tx)
+}
+
+// Merge 거래 내역의 날짜, 설명, 분류를 기존 거래에 반영합니다.
+// 금액은 대응 기준의 허용 오차 안에서만 다르므로 자산 잔액을 바꾸지 않도록 그대로 둡니다.
+func (b *AssetBook) Merge(ctx context.Context, _ Target, transactionID string, record Record) error {
+	tx, err := b.transactions.FindByID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+	tx.Date = record.Date
+	if d := description(record); d != "" {
+		tx.Description = d
+	}
+	if record.Category != "" {
+		tx.Category = record.Category
+	}
+	tx.Reconciled = true
+	return b.transactions.Update(ctx, tx)
+}
+
+// BalanceAt 현재 자산 금액에서 at 이후 거래를 되돌려 at 시점의 잔액을 계산합니다.
+// 시장 가격으로 평가되는 자산은 입출금만으로 잔액이 정해지지 않으므로 계산하지 않습니다.
+func (b *AssetBook) BalanceAt(ctx context.Context, target Target, at time.Time) (float64, bool, error) {
+	account, err := b.assets.FindByID(ctx, target.AssetID)
+	if err != nil {
+		return 0, false, err
+	}
+	if account.Holding != nil {
+		return 0, false, nil
+	}
+	transactions, err := b.transactions.FindByAssetID(ctx, target.AssetID)
+	if err != nil {
+		return 0, false, err
+	}
+	balance := account.Amount.Amount
+	for _, tx := range transactions {
+		if tx.Date.After(at) {
+			balance -= signedAmount(tx)
+		}
+	}
+	return balance, true, nil
+}
+
+// TransactionServiceBook 거래 서비스의 포트폴리오 거래 장부입니다.
+type TransactionServiceBook struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewTransactionServiceBook 새로운 거래 서비스 장부를 생성합니다. client가 nil이면 기본 클라이언트를 사용합니다.
+func NewTransactionServiceBook(baseURL string, client *http.Client) *TransactionServiceBook {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &TransactionServiceBook{baseURL: strings.TrimRight(baseURL, "/"), client: client}
+}
+
+// serviceTransactionResponse 거래 서비스의 거래 응답 중 대사에 필요한 필드입니다.
+type serviceTransactionResponse struct {
+	ID          string    `json:"id"`
+	AssetID     string    `json:"asset_id"`
+	Type        string    `json:"type"`
+	TotalAmount float64   `json:"total_amount"`
+	ExecutedAt  time.Time `json:"executed_at"`
+	Reconciled  bool      `json:"reconciled"`
+}
+
+// Candidates 포트폴리오의 from~to 기간 거래를 조회합니다. 대상에 자산이 있으면 그 자산의 거래만 조회합니다.
+// 매수는 현금이 나가므로 음수, 매도와 배당 등은 양수 금액입니다.
+func (b *TransactionServiceBook) Candidates(ctx context.Context, target Target, from, to time.Time) ([]Candidate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		b.baseURL+"/api/v1/transactions/portfolio/"+url.PathEscape(target.PortfolioID), nil)
+	if err != nil {
+		return nil, err
+	}
+	var transactions []serviceTransactionResponse
+	if err := b.do(req, &transactions); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]Candidate, 0, len(transactions))
+	for _, tx := range transactions {
+		if target.AssetID != "" && tx.AssetID != target.AssetID {
+			continue
+		}
+		if tx.ExecutedAt.Before(from) || tx.ExecutedAt.After(to) {
+			continue
+		}
+		amount := tx.TotalAmount
+		if tx.Type == "BUY" {
+			amount = -amount
+		}
+		candidates = append(candidates, Candidate{
+			ID:         tx.ID,
+			Source:     "transaction",
+			Date:       tx.ExecutedAt,
+			Amount:     amount,
+			Reconciled: tx.Reconciled,
+		})
+	}
+	return candidates, nil
+}
+
+// MarkReconciled 거래 서비스의 거래를 대사 완료로 표시합니다.
+func (b *TransactionServiceBook) MarkReconciled(ctx context.Context, _ Target, transactionID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut,
+		b.baseURL+"/api/v1/transactions/"+url.PathEscape(transactionID)+"/reconciled",
+		bytes.NewReader([]byte(`{"reconciled":true}`)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return b.do(req, nil)
+}
+
+// Merge 증권 거래는 수량과 체결가가 로트 계산에 쓰이므로 거래 내역으로 덮어쓰지 않습니다.
+func (b *TransactionServiceBook) Merge(context.Context, Target, string, Record) error {
+	return ErrMergeUnsupported
+}
+
+// BalanceAt 포트폴리오는 현금 잔액을 관리하지 않으므로 계산하지 않습니다.
+func (b *TransactionServiceBook) BalanceAt(context.Context, Target, time.Time) (float64, bool, error) {
+	return 0, false, nil
+}
+
+func (b *TransactionServiceBook) do(req *http.Request, out interface{}) error {
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("거래 서비스 요청 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var msg bytes.Buffer
+		_, _ = msg.ReadFrom(resp.Body)
+		return fmt.Errorf("거래 서비스 요청 실패(%d): %s", resp.StatusCode, strings.TrimSpace(msg.String()))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("거래 서비스 응답을 읽을 수 없습니다: %w", err)
+	}
+	return nil
+}
+
+// TargetBook 대상에 포트폴리오가 지정되면 Portfolio를, 아니면 Assets를 사용합니다.
+type TargetBook struct {
+	Assets    Book
+	Portfolio Book // 거래 서비스가 설정되지 않았으면 nil입니다
+}
+
+func (b TargetBook) book(target Target) (Book, error) {
+	if target.PortfolioID == "" {
+		return b.Assets, nil
+	}
+	if b.Portfolio == nil {
+		return nil, errors.New("거래 서비스가 설정되지 않아 포트폴리오 거래를 대사할 수 없습니다")
+	}
+	return b.Portfolio, nil
+}
+
+// Candidates 대상에 맞는 장부에서 거래를 조회합니다.
+func (b TargetBook) Candidates(ctx context.Context, target Target, from, to time.Time) ([]Candidate, error) {
+	book, err := b.book(target)
+	if err != nil {
+		return nil, err
+	}
+	return book.Candidates(ctx, target, from, to)
+}
+
+// BalanceAt 대상에 맞는 장부에서 잔액을 계산합니다.
+func (b TargetBook) BalanceAt(ctx context.Context, target Target, at time.Time) (float64, bool, error) {
+	book, err := b.book(target)
+	if err != nil {
+		return 0, false, err
+	}
+	return book.BalanceAt(ctx, target, at)
+}
+
+// MarkReconciled 대상에 맞는 장부의 거래를 대사 완료로 표시합니다.
+func (b TargetBook) MarkReconciled(ctx context.Context, target Target, transactionID string) error {
+	book, err := b.book(target)
+	if err != nil {
+		return err
+	}
+	return book.MarkReconciled(ctx, target, transactionID)
+}
+
+// Merge 대상에 맞는 장부의 거래에 거래 내역을 병합합니다.
+func (b TargetBook) Merge(ctx context.Context, target Target, transactionID string, record Record) error {
+	book, err := b.book(target)
+	if err != nil {
+		return err
+	}
+	return book.Merge(ctx, target, transactionID, record)
+}
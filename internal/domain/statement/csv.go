@@ -0,0 +1,238 @@
+package statement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CSVMapping CSV 열과 거래 필드의 대응입니다. 열은 헤더 이름으로 지정하며,
+// NoHeader이면 1부터 시작하는 열 번호로 지정합니다.
+// 금액은 부호 있는 Amount 열 하나나, 출금(Debit)/입금(Credit) 열 두 개로 지정합니다.
+type CSVMapping struct {
+	Delimiter       string `json:"delimiter,omitempty"`
+	SkipRows        int    `json:"skipRows,omitempty"` // 헤더 앞의 안내 문구 행 수
+	NoHeader        bool   `json:"noHeader,omitempty"`
+	Date            string `json:"date"`
+	DateLayout      string `json:"dateLayout,omitempty"` // 비어 있으면 흔한 형식을 차례로 시도합니다
+	Amount          string `json:"amount,omitempty"`
+	Debit           string `json:"debit,omitempty"`
+	Credit          string `json:"credit,omitempty"`
+	Payee           string `json:"payee,omitempty"`
+	Memo            string `json:"memo,omitempty"`
+	Category        string `json:"category,omitempty"`
+	Currency        string `json:"currency,omitempty"`
+	ID              string `json:"id,omitempty"`
+	Type            string `json:"type,omitempty"`
+	Symbol          string `json:"symbol,omitempty"`
+	Quantity        string `json:"quantity,omitempty"`
+	Price           string `json:"price,omitempty"`
+	Balance         string `json:"balance,omitempty"` // 거래 후 잔액 열, 가장 늦은 행의 잔액을 기말 잔액으로 사용합니다
+	DefaultCurrency string `json:"defaultCurrency,omitempty"`
+}
+
+// Validate 매핑에 필수 열이 지정되었는지 확인합니다.
+func (m CSVMapping) Validate() error {
+	if m.Date == "" {
+		return errors.New("날짜 열이 필요합니다")
+	}
+	if m.Amount == "" && m.Debit == "" && m.Credit == "" {
+		return errors.New("금액 열이나 출금/입금 열이 필요합니다")
+	}
+	if m.Delimiter != "" && utf8.RuneCountInString(m.Delimiter) != 1 {
+		return fmt.Errorf("구분자는 한 글자여야 합니다: %q", m.Delimiter)
+	}
+	return nil
+}
+
+// dateLayouts 날짜 형식이 지정되지 않았을 때 시도하는 형식입니다.
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006.01.02",
+	"2006.01.02 15:04:05",
+	"2006.01.02 15:04",
+	"2006/01/02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"20060102",
+	"01/02/2006",
+	time.RFC3339,
+}
+
+func parseDate(s, layout string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if layout != "" {
+		return time.Parse(layout, s)
+	}
+	for _, l := range dateLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("날짜를 읽을 수 없습니다: %q", s)
+}
+
+// ParseCSV 매핑에 따라 CSV 거래 내역을 읽습니다.
+func ParseCSV(text string, m CSVMapping) (*ParseResult, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	if m.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(m.Delimiter)
+	}
+
+	result := &ParseResult{}
+	seen := make(map[string]int)
+	var columns map[string]int
+	line := 0
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			result.addError(line, "CSV 행을 읽을 수 없습니다: %v", err)
+			continue
+		}
+		if line <= m.SkipRows || isBlank(row) {
+			continue
+		}
+		if columns == nil {
+			if columns, err = m.columns(row); err != nil {
+				return nil, err
+			}
+			if !m.NoHeader {
+				continue
+			}
+		}
+
+		record, err := m.record(row, columns)
+		if err != nil {
+			result.addError(line, "%v", err)
+			continue
+		}
+		record.Line = line
+		result.addRecord(record, seen)
+		if i := columns[m.Balance]; m.Balance != "" && i < len(row) {
+			if raw := row[i]; strings.TrimSpace(raw) != "" {
+				balance, err := parseAmount(raw)
+				if err != nil {
+					result.addError(line, "잔액을 읽을 수 없습니다: %v", err)
+					continue
+				}
+				result.observeBalance(balance, record.Date)
+			}
+		}
+	}
+	return result, nil
+}
+
+// columns 헤더 행이나 열 번호로 열 이름과 위치의 대응을 만들고, 매핑의 열이 모두 있는지 확인합니다.
+func (m CSVMapping) columns(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if m.NoHeader {
+			columns[strconv.Itoa(i+1)] = i
+			continue
+		}
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{m.Date, m.Amount, m.Debit, m.Credit, m.Payee, m.Memo, m.Category, m.Currency, m.ID, m.Type, m.Symbol, m.Quantity, m.Price, m.Balance} {
+		if name == "" {
+			continue
+		}
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV에 %q 열이 없습니다", name)
+		}
+	}
+	return columns, nil
+}
+
+func (m CSVMapping) record(row []string, columns map[string]int) (Record, error) {
+	field := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		i := columns[name]
+		if i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	date, err := parseDate(field(m.Date), m.DateLayout)
+	if err != nil {
+		return Record{}, err
+	}
+
+	var amount float64
+	if m.Amount != "" {
+		if amount, err = parseAmount(field(m.Amount)); err != nil {
+			return Record{}, err
+		}
+	} else {
+		debit, err := parseAmount(field(m.Debit))
+		if err != nil {
+			return Record{}, err
+		}
+		credit, err := parseAmount(field(m.Credit))
+		if err != nil {
+			return Record{}, err
+		}
+		amount = credit - debit
+	}
+	if amount == 0 {
+		return Record{}, errors.New("금액이 0입니다")
+	}
+
+	record := Record{
+		ExternalID: field(m.ID),
+		Date:       date,
+		Amount:     amount,
+		Currency:   field(m.Currency),
+		Payee:      field(m.Payee),
+		Memo:       field(m.Memo),
+		Category:   field(m.Category),
+		Type:       field(m.Type),
+		Symbol:     field(m.Symbol),
+	}
+	if record.Currency == "" {
+		record.Currency = m.DefaultCurrency
+	}
+	if m.Quantity != "" {
+		if record.Quantity, err = parseAmount(field(m.Quantity)); err != nil {
+			return Record{}, err
+		}
+		if record.Quantity < 0 {
+			record.Quantity = -record.Quantity
+		}
+	}
+	if m.Price != "" {
+		if record.Price, err = parseAmount(field(m.Price)); err != nil {
+			return Record{}, err
+		}
+	}
+	return record, nil
+}
+
+func isBlank(row []string) bool {
+	for _, field := range row {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
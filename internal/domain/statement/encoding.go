@@ -0,0 +1,29 @@
+package statement
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/korean"
+)
+
+const (
+	EncodingUTF8  = "UTF-8"
+	EncodingEUCKR = "EUC-KR"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// DecodeText 파일 내용의 문자 인코딩을 판별해 UTF-8 문자열로 변환합니다.
+// 유효한 UTF-8이면 그대로 사용하고, 아니면 국내 은행 내보내기 파일에 흔한 EUC-KR(CP949)로 읽습니다.
+func DecodeText(data []byte) (string, string, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	if utf8.Valid(data) {
+		return string(data), EncodingUTF8, nil
+	}
+	decoded, err := korean.EUCKR.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", "", err
+	}
+	return string(decoded), EncodingEUCKR, nil
+}
@@ -0,0 +1,220 @@
+package statement
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// RowStatus 가져오기 결과의 행 상태입니다.
+type RowStatus string
+
+const (
+	RowNew       RowStatus = "NEW"       // 미리보기에서 새로 생성될 거래
+	RowCreated   RowStatus = "CREATED"   // 거래가 생성됨
+	RowDuplicate RowStatus = "DUPLICATE" // 이미 가져온 거래
+	RowIgnored   RowStatus = "IGNORED"   // 대사에서 제외한 거래
+
+	// RowPossibleDuplicate 다른 경로로 입력된 기존 거래와 비슷해 생성하지 않은 거래입니다.
+	// 대사에서 처리하거나 SkipDuplicateCheck로 다시 가져오면 생성됩니다.
+	RowPossibleDuplicate RowStatus = "POSSIBLE_DUPLICATE"
+	RowInvalid           RowStatus = "INVALID" // 파일에서 읽지 못한 행
+	RowFailed            RowStatus = "FAILED"  // 거래 생성에 실패한 행
+)
+
+// Target 가져온 거래가 생성될 대상입니다. 모놀리스는 AssetID를, 거래 서비스는 세 값을 모두 사용합니다.
+type Target struct {
+	UserID      string `json:"userId,omitempty"`
+	AssetID     string `json:"assetId"`
+	PortfolioID string `json:"portfolioId,omitempty"`
+}
+
+// key 대상과 거래 지문으로 가져오기 기록의 키를 만듭니다.
+func (t Target) key(r Record) string {
+	return t.UserID + "/" + t.PortfolioID + "/" + t.AssetID + "/" + r.Fingerprint()
+}
+
+// Sink 읽은 거래를 실제 거래로 생성합니다.
+type Sink interface {
+	Create(ctx context.Context, target Target, record Record) (transactionID string, err error)
+}
+
+// Request 가져오기 요청입니다.
+type Request struct {
+	Format  Format
+	Data    []byte
+	Options Options
+	Target  Target
+	// SkipDuplicateCheck 기존 거래와 비슷한 거래도 생성합니다
+	SkipDuplicateCheck bool
+}
+
+// RowResult 행별 가져오기 결과입니다.
+// TransactionID는 생성된 거래나, 중복이면 기존 거래의 ID입니다.
+type RowResult struct {
+	Line          int
+	Status        RowStatus
+	TransactionID string
+	Error         string
+	Record        *Record
+	Score         float64 // POSSIBLE_DUPLICATE일 때 기존 거래와의 유사도입니다
+}
+
+// Report 가져오기 결과입니다.
+type Report struct {
+	Encoding   string
+	Total      int
+	New        int
+	Created    int
+	Duplicates int
+	Ignored    int
+	Possible   int
+	Invalid    int
+	Failed     int
+	Rows       []RowResult
+}
+
+func (r *Report) add(row RowResult) {
+	r.Rows = append(r.Rows, row)
+	switch row.Status {
+	case RowNew:
+		r.New++
+	case RowCreated:
+		r.Created++
+	case RowDuplicate:
+		r.Duplicates++
+	case RowIgnored:
+		r.Ignored++
+	case RowPossibleDuplicate:
+		r.Possible++
+	case RowInvalid:
+		r.Invalid++
+	case RowFailed:
+		r.Failed++
+	}
+}
+
+// Importer 거래 내역 파일을 읽어 거래를 생성합니다.
+type Importer struct {
+	registry Registry
+	sink     Sink
+	book     Book
+	options  MatchOptions
+	// mutex 같은 파일을 동시에 가져올 때 거래가 중복 생성되지 않도록 가져오기를 직렬화합니다
+	mutex sync.Mutex
+}
+
+// ImporterOption 가져오기 도구의 설정입니다.
+type ImporterOption func(*Importer)
+
+// WithDuplicateDetection 장부의 기존 거래와 비슷한 거래를 생성하지 않도록 합니다.
+// 기간이 겹치는 다른 형식의 거래 내역이나 직접 입력한 거래가 중복 생성되는 것을 막습니다.
+func WithDuplicateDetection(book Book) ImporterOption {
+	return func(i *Importer) {
+		i.book = book
+	}
+}
+
+// NewImporter 새로운 가져오기 도구를 생성합니다.
+func NewImporter(registry Registry, sink Sink, opts ...ImporterOption) *Importer {
+	importer := &Importer{registry: registry, sink: sink, options: DefaultMatchOptions()}
+	for _, opt := range opts {
+		opt(importer)
+	}
+	return importer
+}
+
+// Preview 거래를 생성하지 않고 가져오기 결과를 미리 보여줍니다.
+func (i *Importer) Preview(ctx context.Context, req Request) (*Report, error) {
+	return i.run(ctx, req, true)
+}
+
+// Import 파일의 거래를 생성합니다. 이미 가져온 거래는 건너뛰므로 같은 파일을 다시 가져와도 안전합니다.
+// 일부 행이 실패해도 나머지 행은 가져오며, 실패한 행은 다음 가져오기에서 다시 시도됩니다.
+func (i *Importer) Import(ctx context.Context, req Request) (*Report, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return i.run(ctx, req, false)
+}
+
+func (i *Importer) run(ctx context.Context, req Request, dryRun bool) (*Report, error) {
+	parsed, err := Parse(req.Format, req.Data, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Encoding: parsed.Encoding, Total: len(parsed.Records) + len(parsed.Errors)}
+	for _, rowErr := range parsed.Errors {
+		report.add(RowResult{Line: rowErr.Line, Status: RowInvalid, Error: rowErr.Message})
+	}
+
+	fresh := make([]Record, 0, len(parsed.Records))
+	for _, record := range parsed.Records {
+		existing, seen, err := i.registry.Lookup(ctx, req.Target.key(record))
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case seen && existing == "":
+			report.add(RowResult{Line: record.Line, Status: RowIgnored, Record: &record})
+		case seen:
+			report.add(RowResult{Line: record.Line, Status: RowDuplicate, TransactionID: existing, Record: &record})
+		default:
+			fresh = append(fresh, record)
+		}
+	}
+
+	possible, err := i.possibleDuplicates(ctx, req, fresh)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range fresh {
+		record := fresh[idx]
+		row := RowResult{Line: record.Line, Record: &record}
+		if match, ok := possible[idx]; ok {
+			row.Status = RowPossibleDuplicate
+			row.TransactionID = match.Candidate.ID
+			row.Score = match.Score
+			report.add(row)
+			continue
+		}
+		if dryRun {
+			row.Status = RowNew
+			report.add(row)
+			continue
+		}
+
+		id, err := i.sink.Create(ctx, req.Target, record)
+		if err != nil {
+			row.Status = RowFailed
+			row.Error = err.Error()
+			report.add(row)
+			continue
+		}
+		if err := i.registry.Remember(ctx, req.Target.key(record), id); err != nil {
+			return nil, err
+		}
+		row.Status = RowCreated
+		row.TransactionID = id
+		report.add(row)
+	}
+	sortRows(report.Rows)
+	return report, nil
+}
+
+// sortRows 행 결과를 파일의 행 순서로 정렬합니다.
+func sortRows(rows []RowResult) {
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Line < rows[j].Line })
+}
+
+// possibleDuplicates 장부의 기존 거래와 일대일로 대응되는 거래를 찾습니다.
+func (i *Importer) possibleDuplicates(ctx context.Context, req Request, records []Record) (map[int]Match, error) {
+	if i.book == nil || req.SkipDuplicateCheck {
+		return nil, nil
+	}
+	candidates, err := findCandidates(ctx, i.book, req.Target, records, i.options)
+	if err != nil {
+		return nil, err
+	}
+	return i.options.Assign(records, candidates), nil
+}
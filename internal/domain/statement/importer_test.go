@@ -0,0 +1,224 @@
+package statement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bankCSV = "date,amount,payee\n" +
+	"2024-01-05,-5600,스타벅스\n" +
+	"2024-01-25,3000000,급여\n" +
+	"bad,1,오류\n"
+
+func csvRequest(assetID string, data string) Request {
+	return Request{
+		Format:  FormatCSV,
+		Data:    []byte(data),
+		Options: Options{Currency: "KRW", CSV: CSVMapping{Date: "date", Amount: "amount", Payee: "payee"}},
+		Target:  Target{AssetID: assetID},
+	}
+}
+
+func newCashAsset(t *testing.T, repo asset.Repository) *asset.Asset {
+	a, err := asset.NewAsset("user-1", asset.Cash, "입출금", 1000000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), a))
+	return a
+}
+
+func Test_Importer_should_not_create_transactions_on_preview(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	account := newCashAsset(t, assets)
+	importer := NewImporter(NewMemoryRegistry(), NewAssetSink(assets, transactions))
+
+	// When
+	report, err := importer.Preview(ctx, csvRequest(account.ID, bankCSV))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 2, report.New)
+	assert.Equal(t, 1, report.Invalid)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, []int{2, 3, 4}, []int{report.Rows[0].Line, report.Rows[1].Line, report.Rows[2].Line})
+	found, err := assets.FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1000000.0, found.Amount.Amount)
+}
+
+func Test_Importer_should_skip_already_imported_records_on_reimport(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	account := newCashAsset(t, assets)
+	importer := NewImporter(NewMemoryRegistry(), NewAssetSink(assets, transactions))
+	first, err := importer.Import(ctx, csvRequest(account.ID, bankCSV))
+	require.NoError(t, err)
+	require.Equal(t, 2, first.Created)
+
+	// When
+	second, err := importer.Import(ctx, csvRequest(account.ID, bankCSV+"2024-01-31,-10000,관리비\n"))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, second.Created)
+	assert.Equal(t, 2, second.Duplicates)
+	assert.Equal(t, first.Rows[0].TransactionID, second.Rows[0].TransactionID)
+	found, err := assets.FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1000000.0-5600+3000000-10000, found.Amount.Amount)
+
+	created, err := transactions.FindByID(ctx, first.Rows[0].TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, asset.Expense, created.Type)
+	assert.Equal(t, "스타벅스", created.Description)
+	assert.Equal(t, 2024, created.Date.Year())
+}
+
+type flakySink struct {
+	fail  bool
+	calls int
+}
+
+func (s *flakySink) Create(_ context.Context, _ Target, record Record) (string, error) {
+	s.calls++
+	if s.fail {
+		return "", errors.New("일시적인 오류")
+	}
+	return "tx-" + record.Payee, nil
+}
+
+func Test_Importer_should_retry_failed_records_on_next_import(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	sink := &flakySink{fail: true}
+	importer := NewImporter(NewMemoryRegistry(), sink)
+	failed, err := importer.Import(ctx, csvRequest("asset-1", bankCSV))
+	require.NoError(t, err)
+	require.Equal(t, 2, failed.Failed)
+	assert.Equal(t, "일시적인 오류", failed.Rows[0].Error)
+
+	// When
+	sink.fail = false
+	report, err := importer.Import(ctx, csvRequest("asset-1", bankCSV))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 4, sink.calls)
+}
+
+func Test_Importer_should_import_same_file_separately_per_target(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	importer := NewImporter(NewMemoryRegistry(), &flakySink{})
+	_, err := importer.Import(ctx, csvRequest("asset-1", bankCSV))
+	require.NoError(t, err)
+
+	// When
+	report, err := importer.Import(ctx, csvRequest("asset-2", bankCSV))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 0, report.Duplicates)
+}
+
+func Test_EmbeddedRegistry_should_remember_imports_across_restart(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data.kv")
+	db, err := kv.Open(path, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewEmbeddedRegistry(db).Remember(ctx, "asset-1/id:1", "tx-1"))
+	require.NoError(t, db.Close())
+
+	// When
+	db, err = kv.Open(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	registry := NewEmbeddedRegistry(db)
+	id, ok, err := registry.Lookup(ctx, "asset-1/id:1")
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "tx-1", id)
+	_, ok, err = registry.Lookup(ctx, "asset-1/id:2")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func Test_TransactionServiceSink_should_post_trades_to_transaction_service(t *testing.T) {
+	// Given
+	var received serviceTransactionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/transactions/", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"svc-1"}`))
+	}))
+	defer server.Close()
+	sink := NewTransactionServiceSink(server.URL, nil)
+	record := Record{Type: "매수", Symbol: "AAPL", Quantity: 10, Price: 185.5, Amount: -1855}
+	target := Target{UserID: "user-1", PortfolioID: "pf-1"}
+
+	// When
+	id, err := sink.Create(context.Background(), target, record)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "svc-1", id)
+	assert.Equal(t, "BUY", received.Type)
+	assert.Equal(t, "AAPL", received.AssetID)
+	assert.Equal(t, 1855.0, received.Amount)
+	assert.Equal(t, "pf-1", received.PortfolioID)
+
+	_, err = sink.Create(context.Background(), target, Record{Amount: -5600, Payee: "스타벅스"})
+	assert.Error(t, err)
+}
+
+type payeeCategorizer map[string]string
+
+func (c payeeCategorizer) Apply(_ context.Context, userID string, tx *asset.Transaction) error {
+	if userID == "user-1" && tx.Category == "" {
+		tx.Category = c[tx.Description]
+	}
+	return nil
+}
+
+func Test_AssetSink_should_categorize_records_without_category(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	account := newCashAsset(t, assets)
+	sink := NewAssetSink(assets, transactions, WithCategorizer(payeeCategorizer{"스타벅스": "카페"}))
+
+	// When
+	report, err := NewImporter(NewMemoryRegistry(), sink).Import(ctx, csvRequest(account.ID, bankCSV))
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Created)
+	cafe, err := transactions.FindByID(ctx, report.Rows[0].TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, "카페", cafe.Category)
+	salary, err := transactions.FindByID(ctx, report.Rows[1].TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, importCategory, salary.Category)
+}
@@ -0,0 +1,183 @@
+package statement
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Candidate 거래 내역과 비교할 기존 거래입니다. Amount는 Record와 같이 입금이 양수인 부호 있는 금액입니다.
+type Candidate struct {
+	ID           string
+	Source       string // 거래가 저장된 곳(asset, transaction)
+	Date         time.Time
+	Amount       float64
+	Currency     string
+	Counterparty string
+	Description  string
+	Reconciled   bool
+}
+
+// MatchOptions 유사 거래 판별 기준입니다.
+type MatchOptions struct {
+	// DateWindow 같은 거래로 볼 수 있는 날짜 차이입니다. 카드 거래는 승인일과 매입일이 며칠 차이 납니다
+	DateWindow time.Duration
+	// AmountTolerance 같은 거래로 볼 수 있는 금액 차이입니다
+	AmountTolerance float64
+	// MinScore 후보로 인정하는 최소 점수(0~1)입니다
+	MinScore float64
+	// MinTextScore 양쪽에 거래처나 설명이 있을 때 요구하는 최소 문장 유사도(0~1)입니다.
+	// 날짜와 금액만 같고 거래처가 전혀 다른 거래를 같은 거래로 보지 않도록 합니다
+	MinTextScore float64
+}
+
+// DefaultMatchOptions 기본 유사 거래 판별 기준입니다.
+func DefaultMatchOptions() MatchOptions {
+	return MatchOptions{
+		DateWindow:      3 * 24 * time.Hour,
+		AmountTolerance: 0.005,
+		MinScore:        0.5,
+		MinTextScore:    0.3,
+	}
+}
+
+// Match 거래 내역과 기존 거래의 대응입니다.
+type Match struct {
+	Candidate Candidate
+	Score     float64
+}
+
+// Score 거래 내역과 기존 거래의 유사도를 0~1로 계산합니다.
+// 금액이 허용 오차를 넘거나, 통화가 다르거나, 날짜가 기간을 벗어나면 0입니다.
+// 양쪽에 거래처나 설명이 있는데 유사도가 MinTextScore에 못 미쳐도 0입니다.
+// 그 외에는 날짜가 가까울수록, 거래처와 설명이 비슷할수록 점수가 높습니다.
+func (o MatchOptions) Score(r Record, c Candidate) float64 {
+	if math.Abs(r.Amount-c.Amount) > o.AmountTolerance {
+		return 0
+	}
+	if r.Currency != "" && c.Currency != "" && !strings.EqualFold(r.Currency, c.Currency) {
+		return 0
+	}
+	gap := r.Date.Sub(c.Date)
+	if gap < 0 {
+		gap = -gap
+	}
+	if gap > o.DateWindow {
+		return 0
+	}
+
+	// 같은 날짜는 1, 기간 끝은 0에 가까워지도록 하루를 여유로 둡니다
+	dateScore := 1 - float64(gap)/float64(o.DateWindow+24*time.Hour)
+	textScore := 0.5 // 한쪽 설명이 비어 있으면 판단하지 않습니다
+	left := r.Payee + " " + r.Memo
+	right := c.Counterparty + " " + c.Description
+	if normalizeText(left) != "" && normalizeText(right) != "" {
+		textScore = Similarity(left, right)
+		if textScore < o.MinTextScore {
+			return 0
+		}
+	}
+	return (dateScore + textScore) / 2
+}
+
+// Suggest 거래 내역과 비슷한 기존 거래를 점수가 높은 순으로 최대 limit개 반환합니다.
+func (o MatchOptions) Suggest(r Record, candidates []Candidate, limit int) []Match {
+	matches := make([]Match, 0)
+	for _, c := range candidates {
+		if score := o.Score(r, c); score >= o.MinScore {
+			matches = append(matches, Match{Candidate: c, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Assign 거래 내역과 기존 거래를 일대일로 대응시킵니다. 점수가 높은 쌍부터 대응시키므로
+// 같은 금액의 거래가 여러 건 있어도 한 기존 거래가 두 번 대응되지 않습니다.
+// 반환값은 records의 인덱스별 대응이며, 대응이 없는 거래는 빠집니다.
+func (o MatchOptions) Assign(records []Record, candidates []Candidate) map[int]Match {
+	type pair struct {
+		record    int
+		candidate int
+		score     float64
+	}
+	pairs := make([]pair, 0)
+	for i, r := range records {
+		for j, c := range candidates {
+			if score := o.Score(r, c); score >= o.MinScore {
+				pairs = append(pairs, pair{record: i, candidate: j, score: score})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].score > pairs[j].score })
+
+	assigned := make(map[int]Match)
+	used := make(map[int]bool)
+	for _, p := range pairs {
+		if _, done := assigned[p.record]; done || used[p.candidate] {
+			continue
+		}
+		assigned[p.record] = Match{Candidate: candidates[p.candidate], Score: p.score}
+		used[p.candidate] = true
+	}
+	return assigned
+}
+
+// Similarity 두 문장의 유사도를 0~1로 계산합니다. 공백 없이 붙여 쓰는 한글 적요도 비교할 수 있도록
+// 대소문자와 기호를 무시한 글자 바이그램의 다이스 계수를 사용합니다.
+func Similarity(a, b string) float64 {
+	a, b = normalizeText(a), normalizeText(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b || strings.Contains(a, b) || strings.Contains(b, a) {
+		return 1
+	}
+	left, right := bigrams(a), bigrams(b)
+	if len(left) == 0 || len(right) == 0 {
+		return 0
+	}
+	common := 0
+	for gram, n := range left {
+		if m := right[gram]; m > 0 {
+			common += min(n, m)
+		}
+	}
+	total := 0
+	for _, n := range left {
+		total += n
+	}
+	for _, n := range right {
+		total += n
+	}
+	return 2 * float64(common) / float64(total)
+}
+
+// normalizeText 글자와 숫자만 남기고 소문자로 바꿉니다.
+func normalizeText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func bigrams(s string) map[string]int {
+	runes := []rune(s)
+	grams := make(map[string]int, len(runes))
+	if len(runes) == 1 {
+		grams[s]++
+		return grams
+	}
+	for i := 0; i+1 < len(runes); i++ {
+		grams[string(runes[i:i+2])]++
+	}
+	return grams
+}
@@ -0,0 +1,79 @@
+package statement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func Test_Similarity_should_compare_korean_descriptions_without_spaces(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("스타벅스 강남점", "스타벅스강남점"))
+	assert.Equal(t, 1.0, Similarity("STARBUCKS", "starbucks #1234 seoul"))
+	assert.Greater(t, Similarity("쿠팡(주) 결제", "쿠팡 주식회사"), 0.3)
+	assert.Equal(t, 0.0, Similarity("급여", "관리비"))
+	assert.Equal(t, 0.0, Similarity("", "관리비"))
+}
+
+func Test_MatchOptions_should_score_only_same_amount_within_date_window(t *testing.T) {
+	// Given
+	opts := DefaultMatchOptions()
+	record := Record{Date: day(10), Amount: -5600, Currency: "KRW", Payee: "스타벅스"}
+
+	// When & Then
+	assert.InDelta(t, 1.0, opts.Score(record, Candidate{Date: day(10), Amount: -5600, Description: "스타벅스"}), 1e-9)
+	assert.Greater(t, opts.Score(record, Candidate{Date: day(12), Amount: -5600, Description: "스타벅스"}), opts.MinScore)
+	assert.Zero(t, opts.Score(record, Candidate{Date: day(14), Amount: -5600, Description: "스타벅스"}))
+	assert.Zero(t, opts.Score(record, Candidate{Date: day(10), Amount: -5700, Description: "스타벅스"}))
+	assert.Zero(t, opts.Score(record, Candidate{Date: day(10), Amount: 5600, Description: "스타벅스"}))
+	assert.Zero(t, opts.Score(record, Candidate{Date: day(10), Amount: -5600, Currency: "USD"}))
+	assert.Less(t, opts.Score(record, Candidate{Date: day(10), Amount: -5600, Description: "편의점"}), opts.Score(record, Candidate{Date: day(10), Amount: -5600}))
+}
+
+func Test_MatchOptions_should_not_suggest_same_day_same_amount_with_unrelated_payee(t *testing.T) {
+	// Given
+	opts := DefaultMatchOptions()
+	record := Record{Date: day(10), Amount: -5600, Currency: "KRW", Payee: "스타벅스"}
+	candidates := []Candidate{
+		{ID: "unrelated", Date: day(10), Amount: -5600, Currency: "KRW", Counterparty: "관리비"},
+		{ID: "blank", Date: day(10), Amount: -5600, Currency: "KRW"},
+	}
+
+	// When
+	matches := opts.Suggest(record, candidates, 0)
+	assigned := opts.Assign([]Record{record}, candidates[:1])
+
+	// Then
+	require.Len(t, matches, 1)
+	assert.Equal(t, "blank", matches[0].Candidate.ID)
+	assert.Empty(t, assigned)
+}
+
+func Test_MatchOptions_should_assign_each_candidate_once(t *testing.T) {
+	// Given
+	opts := DefaultMatchOptions()
+	records := []Record{
+		{Date: day(10), Amount: -1500, Payee: "버스"},
+		{Date: day(11), Amount: -1500, Payee: "버스"},
+		{Date: day(12), Amount: -1500, Payee: "버스"},
+	}
+	candidates := []Candidate{
+		{ID: "a", Date: day(11), Amount: -1500, Description: "버스"},
+		{ID: "b", Date: day(10), Amount: -1500, Description: "버스"},
+	}
+
+	// When
+	assigned := opts.Assign(records, candidates)
+
+	// Then
+	require.Len(t, assigned, 2)
+	assert.Equal(t, "b", assigned[0].Candidate.ID)
+	assert.Equal(t, "a", assigned[1].Candidate.ID)
+	_, ok := assigned[2]
+	assert.False(t, ok)
+}
@@ -0,0 +1,139 @@
+// Package statement 은행/증권사 거래 내역 파일(CSV, OFX/QFX, QIF)을 읽어 거래로 가져옵니다.
+// 가져오기 전에 미리보기로 결과를 확인할 수 있고, 같은 파일을 다시 가져와도 거래가 중복 생성되지 않습니다.
+package statement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format 거래 내역 파일 형식입니다.
+type Format string
+
+const (
+	FormatCSV Format = "CSV"
+	FormatOFX Format = "OFX" // QFX도 같은 형식으로 읽습니다
+	FormatQIF Format = "QIF"
+)
+
+// ParseFormat 문자열을 파일 형식으로 변환합니다. QFX는 OFX로 취급합니다.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "CSV":
+		return FormatCSV, nil
+	case "OFX", "QFX":
+		return FormatOFX, nil
+	case "QIF":
+		return FormatQIF, nil
+	}
+	return "", fmt.Errorf("지원하지 않는 파일 형식입니다: %s", s)
+}
+
+// Record 거래 내역 파일의 한 거래입니다.
+// Amount는 부호가 있는 금액으로, 입금은 양수이고 출금은 음수입니다.
+// Quantity가 0보다 크면 증권 매매 내역이며 Symbol과 Price가 함께 채워집니다.
+type Record struct {
+	Line       int
+	ExternalID string // 은행이 부여한 거래 ID(OFX FITID 등), 없으면 비어 있습니다
+	Date       time.Time
+	Amount     float64
+	Currency   string
+	Payee      string
+	Memo       string
+	Category   string
+	Type       string // 파일에 적힌 거래 유형(OFX TRNTYPE, CSV 유형 열)
+	Symbol     string
+	Quantity   float64
+	Price      float64
+}
+
+// Fingerprint 다시 가져올 때 같은 거래임을 판별하는 키입니다.
+// 은행 거래 ID가 있으면 그것을, 없으면 날짜·금액·거래처·메모의 해시를 사용합니다.
+func (r Record) Fingerprint() string {
+	if r.ExternalID != "" {
+		return "id:" + r.ExternalID
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		r.Date.Format("2006-01-02"),
+		strconv.FormatFloat(r.Amount, 'f', 4, 64),
+		r.Currency,
+		strings.TrimSpace(r.Payee),
+		strings.TrimSpace(r.Memo),
+		r.Symbol,
+		strconv.FormatFloat(r.Quantity, 'f', 8, 64),
+	}, "|")))
+	return "hash:" + hex.EncodeToString(sum[:16])
+}
+
+// RowError 읽지 못한 행과 그 이유입니다.
+type RowError struct {
+	Line    int
+	Message string
+}
+
+// Balance 거래 내역 파일에 적힌 잔액입니다.
+type Balance struct {
+	Amount float64
+	AsOf   time.Time
+}
+
+// ParseResult 파일을 읽은 결과입니다. 일부 행이 잘못되어도 나머지 행은 Records에 담깁니다.
+// ClosingBalance는 파일에 기말 잔액이 있을 때만 채워집니다.
+type ParseResult struct {
+	Encoding       string
+	Records        []Record
+	Errors         []RowError
+	ClosingBalance *Balance
+}
+
+// observeBalance 잔액을 기록합니다. 가장 늦은 날짜의 잔액이 기말 잔액이 되며,
+// 같은 날짜면 파일에서 나중에 나온 잔액을 사용합니다.
+func (p *ParseResult) observeBalance(amount float64, asOf time.Time) {
+	if p.ClosingBalance != nil && asOf.Before(p.ClosingBalance.AsOf) {
+		return
+	}
+	p.ClosingBalance = &Balance{Amount: amount, AsOf: asOf}
+}
+
+// addRecord 거래를 추가합니다. 같은 파일에 같은 지문의 거래가 여러 번 있으면
+// 두 번째부터 순번을 붙여 서로 다른 거래로 구분합니다.
+func (p *ParseResult) addRecord(r Record, seen map[string]int) {
+	if r.ExternalID == "" {
+		key := r.Fingerprint()
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			r.ExternalID = fmt.Sprintf("%s#%d", key, n)
+		}
+	}
+	p.Records = append(p.Records, r)
+}
+
+func (p *ParseResult) addError(line int, format string, args ...interface{}) {
+	p.Errors = append(p.Errors, RowError{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// parseAmount 천 단위 구분자, 통화 기호, 괄호 음수 표기를 처리해 금액을 읽습니다.
+func parseAmount(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	s = strings.NewReplacer(",", "", "₩", "", "$", "", "원", "", " ", "").Replace(s)
+	if s == "" || s == "-" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("금액을 읽을 수 없습니다: %q", s)
+	}
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
@@ -0,0 +1,177 @@
+package statement
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ofxTag SGML(OFX 1.x)과 XML(OFX 2.x) 태그를 모두 읽는 패턴입니다.
+// SGML은 값 뒤에 닫는 태그가 없으므로 다음 태그 전까지를 값으로 봅니다.
+var ofxTag = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)>([^<]*)`)
+
+// ofxTradeBlocks 증권 매매 거래 블록과 거래 유형입니다.
+var ofxTradeBlocks = map[string]string{
+	"BUYSTOCK":  "BUY",
+	"BUYMF":     "BUY",
+	"BUYOTHER":  "BUY",
+	"SELLSTOCK": "SELL",
+	"SELLMF":    "SELL",
+	"SELLOTHER": "SELL",
+}
+
+// ParseOFX OFX/QFX 거래 내역의 은행 거래(STMTTRN)와 증권 매매(BUYSTOCK, SELLSTOCK 등)를 읽습니다.
+func ParseOFX(text string) (*ParseResult, error) {
+	if !strings.Contains(strings.ToUpper(text), "<OFX>") {
+		return nil, fmt.Errorf("OFX 문서가 아닙니다")
+	}
+
+	result := &ParseResult{}
+	seen := make(map[string]int)
+	currency := ""
+	var block string
+	var fields map[string]string
+	var start int
+	var ledger map[string]string
+
+	flush := func() {
+		if fields == nil {
+			return
+		}
+		record, err := ofxRecord(block, fields, currency)
+		if err != nil {
+			result.addError(start, "%v", err)
+		} else {
+			record.Line = start
+			result.addRecord(record, seen)
+		}
+		fields = nil
+	}
+
+	for _, m := range ofxTag.FindAllStringSubmatchIndex(text, -1) {
+		closing := text[m[2]:m[3]] == "/"
+		tag := strings.ToUpper(text[m[4]:m[5]])
+		value := strings.TrimSpace(text[m[6]:m[7]])
+
+		_, trade := ofxTradeBlocks[tag]
+		switch {
+		case tag == "STMTTRN" || trade:
+			if closing {
+				flush()
+				continue
+			}
+			flush()
+			block = tag
+			fields = make(map[string]string)
+			start = 1 + strings.Count(text[:m[0]], "\n")
+		case tag == "CURDEF" && !closing:
+			currency = value
+		case tag == "LEDGERBAL":
+			if !closing {
+				ledger = make(map[string]string)
+			} else if ledger != nil {
+				if err := result.ledgerBalance(ledger); err != nil {
+					result.addError(1+strings.Count(text[:m[0]], "\n"), "잔액을 읽을 수 없습니다: %v", err)
+				}
+				ledger = nil
+			}
+		case ledger != nil && !closing && (tag == "BALAMT" || tag == "DTASOF"):
+			ledger[tag] = value
+		case fields != nil && !closing && value != "":
+			// 같은 이름의 중첩 태그(예: PAYEE/NAME)는 처음 값을 사용합니다
+			if _, exists := fields[tag]; !exists {
+				fields[tag] = decodeOFXEntities(value)
+			}
+		case tag == "BANKTRANLIST" || tag == "INVTRANLIST":
+			if closing {
+				flush()
+			}
+		}
+	}
+	flush()
+	return result, nil
+}
+
+// ledgerBalance OFX 기말 잔액(LEDGERBAL)을 기록합니다.
+func (p *ParseResult) ledgerBalance(fields map[string]string) error {
+	amount, err := parseAmount(fields["BALAMT"])
+	if err != nil {
+		return err
+	}
+	asOf, err := parseOFXDate(fields["DTASOF"])
+	if err != nil {
+		return err
+	}
+	p.observeBalance(amount, asOf)
+	return nil
+}
+
+func ofxRecord(block string, fields map[string]string, currency string) (Record, error) {
+	if cur := fields["CURSYM"]; cur != "" {
+		currency = cur
+	}
+	record := Record{
+		ExternalID: fields["FITID"],
+		Currency:   currency,
+		Payee:      fields["NAME"],
+		Memo:       fields["MEMO"],
+		Type:       fields["TRNTYPE"],
+	}
+
+	dateField := "DTPOSTED"
+	amountField := "TRNAMT"
+	if tradeType, ok := ofxTradeBlocks[block]; ok {
+		dateField, amountField = "DTTRADE", "TOTAL"
+		record.Type = tradeType
+		record.Symbol = fields["TICKER"]
+		if record.Symbol == "" {
+			record.Symbol = fields["UNIQUEID"]
+		}
+		units, err := parseAmount(fields["UNITS"])
+		if err != nil {
+			return Record{}, err
+		}
+		record.Quantity = math.Abs(units)
+		if record.Price, err = parseAmount(fields["UNITPRICE"]); err != nil {
+			return Record{}, err
+		}
+	}
+
+	date, err := parseOFXDate(fields[dateField])
+	if err != nil {
+		return Record{}, err
+	}
+	record.Date = date
+	if record.Amount, err = parseAmount(fields[amountField]); err != nil {
+		return Record{}, err
+	}
+	if record.Amount == 0 {
+		return Record{}, fmt.Errorf("금액이 0입니다")
+	}
+	return record, nil
+}
+
+// parseOFXDate YYYYMMDD[HHMMSS[.XXX]][[오프셋:시간대]] 형식의 OFX 날짜를 읽습니다.
+func parseOFXDate(s string) (time.Time, error) {
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i]
+	}
+	switch len(s) {
+	case 8:
+		return time.Parse("20060102", s)
+	case 12:
+		return time.Parse("200601021504", s)
+	case 14:
+		return time.Parse("20060102150405", s)
+	}
+	return time.Time{}, fmt.Errorf("OFX 날짜를 읽을 수 없습니다: %q", s)
+}
+
+func decodeOFXEntities(s string) string {
+	return strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&apos;", "'").Replace(s)
+}
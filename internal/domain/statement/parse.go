@@ -0,0 +1,48 @@
+package statement
+
+import "fmt"
+
+// Options 파일을 읽을 때의 설정입니다.
+type Options struct {
+	// CSV CSV 파일의 열 매핑입니다
+	CSV CSVMapping `json:"csv"`
+	// Currency 파일에 통화 정보가 없을 때 사용할 통화입니다
+	Currency string `json:"currency,omitempty"`
+}
+
+// Parse 인코딩을 판별해 텍스트로 변환한 뒤 형식에 맞는 파서로 읽습니다.
+func Parse(format Format, data []byte, opts Options) (*ParseResult, error) {
+	text, encoding, err := DecodeText(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *ParseResult
+	switch format {
+	case FormatCSV:
+		mapping := opts.CSV
+		if mapping.DefaultCurrency == "" {
+			mapping.DefaultCurrency = opts.Currency
+		}
+		result, err = ParseCSV(text, mapping)
+	case FormatOFX:
+		result, err = ParseOFX(text)
+	case FormatQIF:
+		result, err = ParseQIF(text, opts.Currency)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 파일 형식입니다: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Encoding = encoding
+	if opts.Currency != "" {
+		for i := range result.Records {
+			if result.Records[i].Currency == "" {
+				result.Records[i].Currency = opts.Currency
+			}
+		}
+	}
+	return result, nil
+}
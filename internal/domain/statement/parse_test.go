@@ -0,0 +1,215 @@
+package statement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/korean"
+)
+
+func Test_Parse_should_decode_EUCKR_bank_export_with_split_amount_columns(t *testing.T) {
+	// Given
+	text := "하나은행 거래내역조회\n" +
+		"거래일시,적요,출금액,입금액,잔액\n" +
+		"2024.01.05 09:12:00,스타벅스,\"5,600\",0,\"994,400\"\n" +
+		"2024.01.25 10:00:00,급여,0,\"3,000,000\",\"3,994,400\"\n"
+	data, err := korean.EUCKR.NewEncoder().Bytes([]byte(text))
+	require.NoError(t, err)
+	opts := Options{
+		Currency: "KRW",
+		CSV:      CSVMapping{SkipRows: 1, Date: "거래일시", Payee: "적요", Debit: "출금액", Credit: "입금액"},
+	}
+
+	// When
+	result, err := Parse(FormatCSV, data, opts)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, EncodingEUCKR, result.Encoding)
+	assert.Empty(t, result.Errors)
+	require.Len(t, result.Records, 2)
+	assert.Equal(t, "스타벅스", result.Records[0].Payee)
+	assert.Equal(t, -5600.0, result.Records[0].Amount)
+	assert.Equal(t, "KRW", result.Records[0].Currency)
+	assert.Equal(t, 3, result.Records[0].Line)
+	assert.Equal(t, 3000000.0, result.Records[1].Amount)
+	assert.Equal(t, time.Date(2024, 1, 25, 10, 0, 0, 0, time.UTC), result.Records[1].Date)
+}
+
+func Test_ParseCSV_should_report_bad_rows_and_keep_the_rest(t *testing.T) {
+	// Given
+	text := "date,amount,payee\n" +
+		"2024-01-05,-12.50,Coffee\n" +
+		"yesterday,-3.00,Bus\n" +
+		"2024-01-06,abc,Lunch\n" +
+		"2024-01-07,100,Refund\n"
+
+	// When
+	result, err := ParseCSV(text, CSVMapping{Date: "date", Amount: "amount", Payee: "payee", DefaultCurrency: "USD"})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Records, 2)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, 3, result.Errors[0].Line)
+	assert.Equal(t, 4, result.Errors[1].Line)
+	assert.Equal(t, "Refund", result.Records[1].Payee)
+}
+
+func Test_ParseCSV_should_reject_mapping_with_missing_column(t *testing.T) {
+	// When
+	_, err := ParseCSV("date,amount\n2024-01-05,1\n", CSVMapping{Date: "date", Amount: "금액"})
+
+	// Then
+	assert.Error(t, err)
+}
+
+func Test_ParseCSV_should_distinguish_identical_rows_in_one_file(t *testing.T) {
+	// Given
+	text := "date,amount,payee\n" +
+		"2024-01-05,-2.00,Bus\n" +
+		"2024-01-05,-2.00,Bus\n"
+
+	// When
+	result, err := ParseCSV(text, CSVMapping{Date: "date", Amount: "amount", Payee: "payee"})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Records, 2)
+	assert.NotEqual(t, result.Records[0].Fingerprint(), result.Records[1].Fingerprint())
+}
+
+func Test_ParseOFX_should_read_SGML_bank_statement(t *testing.T) {
+	// Given
+	text := `OFXHEADER:100
+DATA:OFXSGML
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKTRANLIST>
+<DTSTART>20240101
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240105120000.000[-5:EST]
+<TRNAMT>-42.10
+<FITID>2024010501
+<NAME>GROCERY &amp; CO
+<MEMO>POS PURCHASE
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240131
+<TRNAMT>2500.00
+<FITID>2024013101
+<NAME>PAYROLL
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>2024-02-01
+<TRNAMT>-1.00
+<FITID>2024020101
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL>
+<BALAMT>3457.90
+<DTASOF>20240131
+</LEDGERBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+	// When
+	result, err := ParseOFX(text)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Records, 2)
+	require.Len(t, result.Errors, 1)
+	first := result.Records[0]
+	assert.Equal(t, "2024010501", first.ExternalID)
+	assert.Equal(t, "GROCERY & CO", first.Payee)
+	assert.Equal(t, -42.10, first.Amount)
+	assert.Equal(t, "USD", first.Currency)
+	assert.Equal(t, time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC), first.Date)
+	assert.Equal(t, "id:2024013101", result.Records[1].Fingerprint())
+	assert.Equal(t, 24, result.Errors[0].Line)
+	require.NotNil(t, result.ClosingBalance)
+	assert.Equal(t, 3457.90, result.ClosingBalance.Amount)
+	assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), result.ClosingBalance.AsOf)
+}
+
+func Test_ParseOFX_should_read_XML_investment_trades(t *testing.T) {
+	// Given
+	text := `<?xml version="1.0"?>
+<OFX><INVSTMTMSGSRSV1><INVSTMTTRNRS><INVSTMTRS>
+<CURDEF>USD</CURDEF>
+<INVTRANLIST>
+<BUYSTOCK><INVBUY><INVTRAN><FITID>T1</FITID><DTTRADE>20240110</DTTRADE></INVTRAN>
+<SECID><UNIQUEID>AAPL</UNIQUEID><UNIQUEIDTYPE>TICKER</UNIQUEIDTYPE></SECID>
+<UNITS>10</UNITS><UNITPRICE>185.5</UNITPRICE><TOTAL>-1855.00</TOTAL></INVBUY><BUYTYPE>BUY</BUYTYPE></BUYSTOCK>
+<SELLSTOCK><INVSELL><INVTRAN><FITID>T2</FITID><DTTRADE>20240210</DTTRADE></INVTRAN>
+<SECID><UNIQUEID>AAPL</UNIQUEID></SECID>
+<UNITS>-4</UNITS><UNITPRICE>190</UNITPRICE><TOTAL>760.00</TOTAL></INVSELL><SELLTYPE>SELL</SELLTYPE></SELLSTOCK>
+</INVTRANLIST>
+</INVSTMTRS></INVSTMTTRNRS></INVSTMTMSGSRSV1></OFX>`
+
+	// When
+	result, err := ParseOFX(text)
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	require.Len(t, result.Records, 2)
+	assert.Equal(t, "BUY", result.Records[0].Type)
+	assert.Equal(t, "AAPL", result.Records[0].Symbol)
+	assert.Equal(t, 10.0, result.Records[0].Quantity)
+	assert.Equal(t, 185.5, result.Records[0].Price)
+	assert.Equal(t, "SELL", result.Records[1].Type)
+	assert.Equal(t, 4.0, result.Records[1].Quantity)
+	assert.Equal(t, 760.0, result.Records[1].Amount)
+}
+
+func Test_ParseQIF_should_read_bank_records(t *testing.T) {
+	// Given
+	text := "!Type:Bank\n" +
+		"D01/05'24\n" +
+		"T-1,234.56\n" +
+		"PRent\n" +
+		"LHousing\n" +
+		"^\n" +
+		"D2024-01-20\n" +
+		"T500.00\n" +
+		"PSalary\n" +
+		"MJanuary\n" +
+		"^\n" +
+		"Dsomeday\n" +
+		"T1.00\n" +
+		"^\n"
+
+	// When
+	result, err := ParseQIF(text, "USD")
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Records, 2)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 12, result.Errors[0].Line)
+	assert.Equal(t, -1234.56, result.Records[0].Amount)
+	assert.Equal(t, "Housing", result.Records[0].Category)
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), result.Records[0].Date)
+	assert.Equal(t, "January", result.Records[1].Memo)
+	assert.Equal(t, "USD", result.Records[1].Currency)
+}
+
+func Test_ParseFormat_should_treat_QFX_as_OFX(t *testing.T) {
+	// When
+	format, err := ParseFormat("qfx")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, FormatOFX, format)
+	_, err = ParseFormat("xlsx")
+	assert.Error(t, err)
+}
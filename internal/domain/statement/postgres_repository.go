@@ -0,0 +1,70 @@
+package statement
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+)
+
+// PostgresRegistry statement_imports 테이블을 사용하는 가져오기 기록 저장소 구현체입니다.
+type PostgresRegistry struct {
+	records *sqldb.Documents[*importedRecord]
+}
+
+// NewPostgresRegistry 새로운 PostgreSQL 가져오기 기록 저장소를 생성합니다.
+func NewPostgresRegistry(db *sql.DB) *PostgresRegistry {
+	return &PostgresRegistry{
+		records: sqldb.NewDocuments(db, "statement_imports", []string{"import_key"}, []string{"transaction_id"},
+			func(r *importedRecord) []interface{} { return []interface{}{r.Key, r.TransactionID} }),
+	}
+}
+
+// Lookup 키로 가져온 거래의 ID를 조회합니다.
+func (r *PostgresRegistry) Lookup(ctx context.Context, key string) (string, bool, error) {
+	record, exists, err := r.records.Get(ctx, key)
+	if err != nil {
+		return "", false, storageError(err)
+	}
+	if !exists {
+		return "", false, nil
+	}
+	return record.TransactionID, true, nil
+}
+
+// Remember 키와 생성된 거래 ID를 기록합니다.
+func (r *PostgresRegistry) Remember(ctx context.Context, key string, transactionID string) error {
+	record := &importedRecord{Key: key, TransactionID: transactionID, ImportedAt: time.Now()}
+	return storageError(r.records.Put(ctx, record))
+}
+
+// PostgresReconciliationRepository statement_reconciliations 테이블을 사용하는 대사 작업 저장소 구현체입니다.
+type PostgresReconciliationRepository struct {
+	reconciliations *sqldb.Documents[*Reconciliation]
+}
+
+// NewPostgresReconciliationRepository 새로운 PostgreSQL 대사 작업 저장소를 생성합니다.
+func NewPostgresReconciliationRepository(db *sql.DB) *PostgresReconciliationRepository {
+	return &PostgresReconciliationRepository{
+		reconciliations: sqldb.NewDocuments(db, "statement_reconciliations", []string{"id"}, nil,
+			func(rec *Reconciliation) []interface{} { return []interface{}{rec.ID} }),
+	}
+}
+
+// Save 대사 작업을 저장합니다.
+func (r *PostgresReconciliationRepository) Save(ctx context.Context, rec *Reconciliation) error {
+	return storageError(r.reconciliations.Put(ctx, rec))
+}
+
+// FindByID ID로 대사 작업을 조회합니다.
+func (r *PostgresReconciliationRepository) FindByID(ctx context.Context, id string) (*Reconciliation, error) {
+	rec, exists, err := r.reconciliations.Get(ctx, id)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if !exists {
+		return nil, reconciliationNotFound(id)
+	}
+	return rec, nil
+}
@@ -0,0 +1,108 @@
+package statement
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// qifDateLayouts QIF 날짜 형식입니다. 2000년 이후 날짜는 Quicken이 작은따옴표로 연도를 구분합니다.
+var qifDateLayouts = []string{
+	"01/02/2006",
+	"1/2/2006",
+	"01/02/06",
+	"1/2/06",
+	"01/02'06",
+	"1/2'06",
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+}
+
+// ParseQIF QIF 거래 내역을 읽습니다. 거래는 ^ 행으로 구분되며 D(날짜), T/U(금액), P(거래처),
+// M(메모), L(분류), N(번호) 필드를 사용합니다. QIF에는 통화 정보가 없으므로 currency를 적용합니다.
+func ParseQIF(text string, currency string) (*ParseResult, error) {
+	result := &ParseResult{}
+	seen := make(map[string]int)
+
+	var fields map[byte]string
+	start := 0
+	flush := func() {
+		if len(fields) == 0 {
+			fields = nil
+			return
+		}
+		record, err := qifRecord(fields, currency)
+		if err != nil {
+			result.addError(start, "%v", err)
+		} else {
+			record.Line = start
+			result.addRecord(record, seen)
+		}
+		fields = nil
+	}
+
+	for i, raw := range strings.Split(text, "\n") {
+		line := strings.TrimRight(raw, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		switch line[0] {
+		case '!':
+			flush()
+			if !strings.HasPrefix(strings.ToLower(line), "!type:") && !strings.HasPrefix(strings.ToLower(line), "!option") {
+				return nil, fmt.Errorf("지원하지 않는 QIF 헤더입니다: %s", line)
+			}
+		case '^':
+			flush()
+		default:
+			if fields == nil {
+				fields = make(map[byte]string)
+				start = i + 1
+			}
+			// 분할 거래(S, E, $)는 거래 전체 금액만 사용하므로 무시합니다
+			if _, exists := fields[line[0]]; !exists {
+				fields[line[0]] = strings.TrimSpace(line[1:])
+			}
+		}
+	}
+	flush()
+	return result, nil
+}
+
+func qifRecord(fields map[byte]string, currency string) (Record, error) {
+	date, err := parseQIFDate(fields['D'])
+	if err != nil {
+		return Record{}, err
+	}
+	rawAmount := fields['T']
+	if rawAmount == "" {
+		rawAmount = fields['U']
+	}
+	amount, err := parseAmount(rawAmount)
+	if err != nil {
+		return Record{}, err
+	}
+	if amount == 0 {
+		return Record{}, fmt.Errorf("금액이 0입니다")
+	}
+	return Record{
+		ExternalID: fields['N'],
+		Date:       date,
+		Amount:     amount,
+		Currency:   currency,
+		Payee:      fields['P'],
+		Memo:       fields['M'],
+		Category:   fields['L'],
+	}, nil
+}
+
+func parseQIFDate(s string) (time.Time, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	for _, layout := range qifDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("QIF 날짜를 읽을 수 없습니다: %q", s)
+}
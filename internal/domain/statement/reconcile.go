@@ -0,0 +1,372 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/google/uuid"
+)
+
+// suggestionLimit 거래 내역 한 건에 제시하는 후보 거래 수입니다.
+const suggestionLimit = 3
+
+// balanceTolerance 기말 잔액이 일치한다고 보는 오차입니다.
+const balanceTolerance = 0.005
+
+// ItemStatus 대사 항목의 상태입니다.
+type ItemStatus string
+
+const (
+	ItemUnmatched ItemStatus = "UNMATCHED" // 비슷한 기존 거래가 없음
+	ItemSuggested ItemStatus = "SUGGESTED" // 비슷한 기존 거래가 있어 확인이 필요함
+	ItemMatched   ItemStatus = "MATCHED"   // 기존 거래와 같은 거래로 확인됨
+	ItemMerged    ItemStatus = "MERGED"    // 기존 거래에 거래 내역을 반영함
+	ItemIgnored   ItemStatus = "IGNORED"   // 가져오기와 대사에서 제외함
+)
+
+// Action 대사 항목에 대한 처리입니다.
+type Action string
+
+const (
+	ActionMatch  Action = "MATCH"
+	ActionMerge  Action = "MERGE"
+	ActionIgnore Action = "IGNORE"
+)
+
+// Item 거래 내역 한 건의 대사 상태입니다.
+type Item struct {
+	Line          int
+	Record        Record
+	Status        ItemStatus
+	TransactionID string  // MATCHED, MERGED 상태에서 대응된 기존 거래입니다
+	Suggestions   []Match // 점수가 높은 순의 후보 거래입니다
+}
+
+// Resolved 항목의 처리가 끝났는지 확인합니다.
+func (i Item) Resolved() bool {
+	return i.Status == ItemMatched || i.Status == ItemMerged || i.Status == ItemIgnored
+}
+
+// Reconciliation 거래 내역 파일 하나와 장부의 대사 작업입니다.
+type Reconciliation struct {
+	ID              string
+	Target          Target
+	Items           []Item
+	Errors          []RowError
+	ClosingBalance  *Balance // 거래 내역의 기말 잔액입니다
+	ComputedBalance *float64 // 기말 잔액 시점의 장부 잔액입니다. 계산할 수 없으면 nil입니다
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Difference 거래 내역의 기말 잔액에서 장부 잔액을 뺀 차이입니다. 어느 한쪽이 없으면 ok가 false입니다.
+func (r *Reconciliation) Difference() (float64, bool) {
+	if r.ClosingBalance == nil || r.ComputedBalance == nil {
+		return 0, false
+	}
+	return r.ClosingBalance.Amount - *r.ComputedBalance, true
+}
+
+// Reconciled 모든 항목이 처리되었고 잔액 차이가 없으면 대사가 끝난 것입니다.
+func (r *Reconciliation) Reconciled() bool {
+	for _, item := range r.Items {
+		if !item.Resolved() {
+			return false
+		}
+	}
+	if diff, ok := r.Difference(); ok && math.Abs(diff) > balanceTolerance {
+		return false
+	}
+	return true
+}
+
+// Count 상태별 항목 수를 반환합니다.
+func (r *Reconciliation) Count(status ItemStatus) int {
+	n := 0
+	for _, item := range r.Items {
+		if item.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *Reconciliation) item(line int) (*Item, error) {
+	for i := range r.Items {
+		if r.Items[i].Line == line {
+			return &r.Items[i], nil
+		}
+	}
+	return nil, domain.NewError("statement", domain.ErrCodeNotFound, fmt.Sprintf("line %d not found in reconciliation %s", line, r.ID))
+}
+
+// Clone 대사 작업의 복사본을 반환합니다.
+func (r *Reconciliation) Clone() *Reconciliation {
+	clone := *r
+	clone.Items = make([]Item, len(r.Items))
+	for i, item := range r.Items {
+		item.Suggestions = append([]Match(nil), item.Suggestions...)
+		clone.Items[i] = item
+	}
+	clone.Errors = append([]RowError(nil), r.Errors...)
+	if r.ClosingBalance != nil {
+		balance := *r.ClosingBalance
+		clone.ClosingBalance = &balance
+	}
+	if r.ComputedBalance != nil {
+		computed := *r.ComputedBalance
+		clone.ComputedBalance = &computed
+	}
+	return &clone
+}
+
+// ReconcileRequest 대사 작업 생성 요청입니다.
+// ClosingBalance가 있으면 파일의 기말 잔액 대신 사용합니다. QIF처럼 잔액이 없는 형식에 필요합니다.
+type ReconcileRequest struct {
+	Request
+	ClosingBalance *float64
+}
+
+// Reconciler 거래 내역과 장부를 대사합니다.
+type Reconciler struct {
+	book     Book
+	registry Registry
+	repo     ReconciliationRepository
+	options  MatchOptions
+}
+
+// NewReconciler 새로운 대사 도구를 생성합니다. 가져오기와 같은 기록 저장소를 사용해야
+// 대사에서 처리한 거래가 다시 가져와지지 않습니다.
+func NewReconciler(book Book, registry Registry, repo ReconciliationRepository) *Reconciler {
+	return &Reconciler{book: book, registry: registry, repo: repo, options: DefaultMatchOptions()}
+}
+
+// Start 거래 내역 파일을 읽어 대사 작업을 만듭니다. 이 파일에서 이미 가져온 거래는
+// 대사 완료로 표시하고, 나머지 거래에는 비슷한 기존 거래를 후보로 제시합니다.
+func (r *Reconciler) Start(ctx context.Context, req ReconcileRequest) (*Reconciliation, error) {
+	parsed, err := Parse(req.Format, req.Data, req.Options)
+	if err != nil {
+		return nil, domain.NewError("statement", domain.ErrCodeInvalidArgument, err.Error())
+	}
+
+	now := time.Now()
+	rec := &Reconciliation{
+		ID:             uuid.New().String(),
+		Target:         req.Target,
+		Errors:         parsed.Errors,
+		ClosingBalance: parsed.ClosingBalance,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if req.ClosingBalance != nil {
+		asOf := latestDate(parsed.Records)
+		if parsed.ClosingBalance != nil {
+			asOf = parsed.ClosingBalance.AsOf
+		}
+		rec.ClosingBalance = &Balance{Amount: *req.ClosingBalance, AsOf: asOf}
+	}
+
+	candidates, err := findCandidates(ctx, r.book, req.Target, parsed.Records, r.options)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]int, 0, len(parsed.Records))
+	for _, record := range parsed.Records {
+		item := Item{Line: record.Line, Record: record, Status: ItemUnmatched}
+		id, seen, err := r.registry.Lookup(ctx, req.Target.key(record))
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case seen && id == "":
+			item.Status = ItemIgnored
+		case seen:
+			// 이 거래 내역에서 생성된 거래이므로 확인 없이 대사 완료로 표시합니다
+			item.Status = ItemMatched
+			item.TransactionID = id
+			if c, ok := takeCandidate(&candidates, id); ok && !c.Reconciled {
+				if err := r.book.MarkReconciled(ctx, req.Target, id); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			pending = append(pending, len(rec.Items))
+		}
+		rec.Items = append(rec.Items, item)
+	}
+
+	records := make([]Record, len(pending))
+	for i, idx := range pending {
+		records[i] = rec.Items[idx].Record
+	}
+	assigned := r.options.Assign(records, candidates)
+	for i, idx := range pending {
+		item := &rec.Items[idx]
+		item.Suggestions = r.options.Suggest(item.Record, candidates, suggestionLimit)
+		if best, ok := assigned[i]; ok {
+			item.Suggestions = withFirst(item.Suggestions, best)
+		}
+		if len(item.Suggestions) > 0 {
+			item.Status = ItemSuggested
+		}
+	}
+
+	if err := r.refreshBalance(ctx, rec); err != nil {
+		return nil, err
+	}
+	if err := r.repo.Save(ctx, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// Get 대사 작업을 조회합니다.
+func (r *Reconciler) Get(ctx context.Context, id string) (*Reconciliation, error) {
+	return r.repo.FindByID(ctx, id)
+}
+
+// Resolve 대사 항목을 처리합니다.
+// MATCH는 기존 거래를 대사 완료로 표시하고, MERGE는 거래 내역의 날짜와 설명을 기존 거래에 반영한 뒤
+// 대사 완료로 표시합니다. transactionID가 비어 있으면 첫 번째 후보를 사용합니다.
+// IGNORE는 거래 내역을 제외해 이후 가져오기에서도 생성되지 않도록 합니다.
+func (r *Reconciler) Resolve(ctx context.Context, id string, line int, action Action, transactionID string) (*Reconciliation, error) {
+	if action != ActionMatch && action != ActionMerge && action != ActionIgnore {
+		return nil, domain.NewError("statement", domain.ErrCodeInvalidArgument, fmt.Sprintf("unknown action %s", action))
+	}
+	rec, err := r.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	item, err := rec.item(line)
+	if err != nil {
+		return nil, err
+	}
+	if item.Resolved() {
+		return nil, domain.NewError("statement", domain.ErrCodeInvalidOperation, fmt.Sprintf("line %d is already %s", line, item.Status))
+	}
+
+	key := rec.Target.key(item.Record)
+	switch action {
+	case ActionMatch, ActionMerge:
+		if transactionID == "" {
+			if len(item.Suggestions) == 0 {
+				return nil, domain.NewError("statement", domain.ErrCodeInvalidArgument, "대응할 거래를 지정해야 합니다")
+			}
+			transactionID = item.Suggestions[0].Candidate.ID
+		}
+		if action == ActionMatch {
+			err = r.book.MarkReconciled(ctx, rec.Target, transactionID)
+		} else {
+			err = r.book.Merge(ctx, rec.Target, transactionID, item.Record)
+		}
+		if errors.Is(err, ErrMergeUnsupported) {
+			return nil, domain.NewError("statement", domain.ErrCodeInvalidOperation, err.Error())
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := r.registry.Remember(ctx, key, transactionID); err != nil {
+			return nil, err
+		}
+		item.Status = ItemMatched
+		if action == ActionMerge {
+			item.Status = ItemMerged
+		}
+		item.TransactionID = transactionID
+	case ActionIgnore:
+		if err := r.registry.Remember(ctx, key, ""); err != nil {
+			return nil, err
+		}
+		item.Status = ItemIgnored
+	}
+
+	// 병합은 거래일을 바꾸므로 기말 잔액 시점의 장부 잔액이 달라질 수 있습니다
+	if err := r.refreshBalance(ctx, rec); err != nil {
+		return nil, err
+	}
+	rec.UpdatedAt = time.Now()
+	if err := r.repo.Save(ctx, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (r *Reconciler) refreshBalance(ctx context.Context, rec *Reconciliation) error {
+	if rec.ClosingBalance == nil {
+		return nil
+	}
+	balance, ok, err := r.book.BalanceAt(ctx, rec.Target, endOfDay(rec.ClosingBalance.AsOf))
+	if err != nil {
+		return err
+	}
+	rec.ComputedBalance = nil
+	if ok {
+		rec.ComputedBalance = &balance
+	}
+	return nil
+}
+
+// findCandidates 거래 내역 기간 앞뒤로 대응 기간만큼 넓혀 기존 거래를 조회합니다.
+func findCandidates(ctx context.Context, book Book, target Target, records []Record, options MatchOptions) ([]Candidate, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+	from, to := records[0].Date, records[0].Date
+	for _, record := range records[1:] {
+		if record.Date.Before(from) {
+			from = record.Date
+		}
+		if record.Date.After(to) {
+			to = record.Date
+		}
+	}
+	return book.Candidates(ctx, target, from.Add(-options.DateWindow), endOfDay(to).Add(options.DateWindow))
+}
+
+// takeCandidate ID가 같은 후보를 목록에서 꺼냅니다.
+func takeCandidate(candidates *[]Candidate, id string) (Candidate, bool) {
+	for i, c := range *candidates {
+		if c.ID == id {
+			*candidates = append((*candidates)[:i], (*candidates)[i+1:]...)
+			return c, true
+		}
+	}
+	return Candidate{}, false
+}
+
+// withFirst 일대일 대응된 후보를 맨 앞에 둡니다.
+func withFirst(matches []Match, first Match) []Match {
+	result := []Match{first}
+	for _, m := range matches {
+		if m.Candidate.ID != first.Candidate.ID {
+			result = append(result, m)
+		}
+	}
+	if len(result) > suggestionLimit {
+		result = result[:suggestionLimit]
+	}
+	return result
+}
+
+func latestDate(records []Record) time.Time {
+	var latest time.Time
+	for _, r := range records {
+		if r.Date.After(latest) {
+			latest = r.Date
+		}
+	}
+	return latest
+}
+
+// endOfDay 날짜만 있는 잔액 기준일이 그날의 거래를 모두 포함하도록 하루의 끝으로 옮깁니다.
+func endOfDay(t time.Time) time.Time {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t
+}
@@ -0,0 +1,303 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reconcileFixture struct {
+	ctx          context.Context
+	assets       *asset.MemoryAssetRepository
+	transactions *asset.MemoryTransactionRepository
+	account      *asset.Asset
+	registry     *MemoryRegistry
+	book         *AssetBook
+	importer     *Importer
+	reconciler   *Reconciler
+}
+
+func newReconcileFixture(t *testing.T) *reconcileFixture {
+	f := &reconcileFixture{
+		ctx:          context.Background(),
+		assets:       asset.NewMemoryAssetRepository(),
+		transactions: asset.NewMemoryTransactionRepository(),
+		registry:     NewMemoryRegistry(),
+	}
+	f.account = newCashAsset(t, f.assets)
+	f.book = NewAssetBook(f.assets, f.transactions)
+	f.importer = NewImporter(f.registry, NewAssetSink(f.assets, f.transactions), WithDuplicateDetection(f.book))
+	f.reconciler = NewReconciler(f.book, f.registry, NewMemoryReconciliationRepository())
+	return f
+}
+
+// record 직접 입력한 거래를 자산에 반영합니다.
+func (f *reconcileFixture) record(t *testing.T, txType asset.TransactionType, amount float64, description string, date time.Time) *asset.Transaction {
+	if txType != asset.Income {
+		amount = -amount
+	}
+	id, err := NewAssetSink(f.assets, f.transactions).Create(f.ctx, Target{AssetID: f.account.ID}, Record{
+		Date:     date,
+		Amount:   amount,
+		Currency: "KRW",
+		Payee:    description,
+	})
+	require.NoError(t, err)
+	tx, err := f.transactions.FindByID(f.ctx, id)
+	require.NoError(t, err)
+	return tx
+}
+
+func (f *reconcileFixture) request(data string) Request {
+	return csvRequest(f.account.ID, data)
+}
+
+const overlapCSV = "date,amount,payee\n" +
+	"2024-01-05,-5600,스타벅스 강남\n" +
+	"2024-01-08,-32000,주유소\n"
+
+func Test_Importer_should_hold_back_records_similar_to_existing_transactions(t *testing.T) {
+	// Given
+	f := newReconcileFixture(t)
+	manual := f.record(t, asset.Expense, 5600, "스타벅스", day(4))
+
+	// When
+	report, err := f.importer.Import(f.ctx, f.request(overlapCSV))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Possible)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, RowPossibleDuplicate, report.Rows[0].Status)
+	assert.Equal(t, manual.ID, report.Rows[0].TransactionID)
+
+	// When
+	req := f.request(overlapCSV)
+	req.SkipDuplicateCheck = true
+	forced, err := f.importer.Import(f.ctx, req)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, forced.Created)
+	assert.Equal(t, 1, forced.Duplicates)
+}
+
+func Test_Reconciler_should_mark_transactions_imported_from_statement_as_reconciled(t *testing.T) {
+	// Given
+	f := newReconcileFixture(t)
+	imported, err := f.importer.Import(f.ctx, f.request(overlapCSV))
+	require.NoError(t, err)
+	require.Equal(t, 2, imported.Created)
+
+	// When
+	rec, err := f.reconciler.Start(f.ctx, ReconcileRequest{Request: f.request(overlapCSV)})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, rec.Count(ItemMatched))
+	assert.True(t, rec.Reconciled())
+	tx, err := f.transactions.FindByID(f.ctx, imported.Rows[0].TransactionID)
+	require.NoError(t, err)
+	assert.True(t, tx.Reconciled)
+}
+
+func Test_Reconciler_should_match_statement_line_to_manual_transaction(t *testing.T) {
+	// Given
+	f := newReconcileFixture(t)
+	manual := f.record(t, asset.Expense, 5600, "스타벅스", day(4))
+	rec, err := f.reconciler.Start(f.ctx, ReconcileRequest{Request: f.request(overlapCSV)})
+	require.NoError(t, err)
+	require.Equal(t, ItemSuggested, rec.Items[0].Status)
+	require.Equal(t, manual.ID, rec.Items[0].Suggestions[0].Candidate.ID)
+	assert.Equal(t, ItemUnmatched, rec.Items[1].Status)
+
+	// When
+	rec, err = f.reconciler.Resolve(f.ctx, rec.ID, rec.Items[0].Line, ActionMatch, "")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, ItemMatched, rec.Items[0].Status)
+	assert.False(t, rec.Reconciled())
+	tx, err := f.transactions.FindByID(f.ctx, manual.ID)
+	require.NoError(t, err)
+	assert.True(t, tx.Reconciled)
+	assert.Equal(t, day(4), tx.Date)
+
+	report, err := f.importer.Import(f.ctx, f.request(overlapCSV))
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Duplicates)
+	assert.Equal(t, 1, report.Created)
+}
+
+func Test_Reconciler_should_merge_statement_details_into_transaction(t *testing.T) {
+	// Given
+	f := newReconcileFixture(t)
+	manual := f.record(t, asset.Expense, 5600, "커피", day(5))
+	rec, err := f.reconciler.Start(f.ctx, ReconcileRequest{Request: f.request(overlapCSV)})
+	require.NoError(t, err)
+
+	// When
+	rec, err = f.reconciler.Resolve(f.ctx, rec.ID, rec.Items[0].Line, ActionMerge, manual.ID)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, ItemMerged, rec.Items[0].Status)
+	tx, err := f.transactions.FindByID(f.ctx, manual.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "스타벅스 강남", tx.Description)
+	assert.True(t, tx.Reconciled)
+
+	_, err = f.reconciler.Resolve(f.ctx, rec.ID, rec.Items[0].Line, ActionIgnore, "")
+	var domainErr domain.Error
+	require.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, domain.ErrCodeInvalidOperation, domainErr.Code())
+}
+
+func Test_Reconciler_should_keep_ignored_lines_out_of_later_imports(t *testing.T) {
+	// Given
+	f := newReconcileFixture(t)
+	rec, err := f.reconciler.Start(f.ctx, ReconcileRequest{Request: f.request(overlapCSV)})
+	require.NoError(t, err)
+
+	// When
+	rec, err = f.reconciler.Resolve(f.ctx, rec.ID, rec.Items[1].Line, ActionIgnore, "")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, ItemIgnored, rec.Items[1].Status)
+	report, err := f.importer.Import(f.ctx, f.request(overlapCSV))
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Ignored)
+	assert.Equal(t, 1, report.Created)
+}
+
+func Test_Reconciler_should_report_difference_between_closing_and_computed_balance(t *testing.T) {
+	// Given
+	f := newReconcileFixture(t)
+	_, err := f.importer.Import(f.ctx, f.request(overlapCSV))
+	require.NoError(t, err)
+	f.record(t, asset.Income, 100000, "이후 입금", day(20))
+	statement := "date,amount,payee,balance\n" +
+		"2024-01-05,-5600,스타벅스 강남,994400\n" +
+		"2024-01-08,-32000,주유소,952400\n"
+	req := f.request(statement)
+	req.Options.CSV.Balance = "balance"
+
+	// When
+	rec, err := f.reconciler.Start(f.ctx, ReconcileRequest{Request: req})
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, rec.ComputedBalance)
+	assert.Equal(t, 1000000.0-5600-32000, *rec.ComputedBalance)
+	diff, ok := rec.Difference()
+	require.True(t, ok)
+	assert.InDelta(t, -10000, diff, 1e-9)
+	assert.False(t, rec.Reconciled())
+
+	// When
+	override := 962400.0
+	rec, err = f.reconciler.Start(f.ctx, ReconcileRequest{Request: req, ClosingBalance: &override})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, day(8), rec.ClosingBalance.AsOf)
+	assert.True(t, rec.Reconciled())
+}
+
+func Test_Reconciler_should_reject_unknown_reconciliation_and_line(t *testing.T) {
+	// Given
+	f := newReconcileFixture(t)
+	rec, err := f.reconciler.Start(f.ctx, ReconcileRequest{Request: f.request(overlapCSV)})
+	require.NoError(t, err)
+
+	// When
+	_, missingRec := f.reconciler.Resolve(f.ctx, "missing", 2, ActionIgnore, "")
+	_, missingLine := f.reconciler.Resolve(f.ctx, rec.ID, 99, ActionIgnore, "")
+	_, noCandidate := f.reconciler.Resolve(f.ctx, rec.ID, rec.Items[1].Line, ActionMatch, "")
+
+	// Then
+	for err, code := range map[error]string{missingRec: domain.ErrCodeNotFound, missingLine: domain.ErrCodeNotFound, noCandidate: domain.ErrCodeInvalidArgument} {
+		var domainErr domain.Error
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, code, domainErr.Code())
+	}
+}
+
+func Test_EmbeddedReconciliationRepository_should_round_trip_reconciliation(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewEmbeddedReconciliationRepository(kv.OpenMemory())
+	balance := 100.0
+	rec := &Reconciliation{
+		ID:              "rec-1",
+		Target:          Target{AssetID: "asset-1"},
+		Items:           []Item{{Line: 2, Status: ItemSuggested, Record: Record{Amount: -5}, Suggestions: []Match{{Candidate: Candidate{ID: "tx-1"}, Score: 0.9}}}},
+		ClosingBalance:  &Balance{Amount: 120, AsOf: day(31)},
+		ComputedBalance: &balance,
+	}
+
+	// When
+	require.NoError(t, repo.Save(ctx, rec))
+	found, err := repo.FindByID(ctx, "rec-1")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "tx-1", found.Items[0].Suggestions[0].Candidate.ID)
+	diff, ok := found.Difference()
+	assert.True(t, ok)
+	assert.Equal(t, 20.0, diff)
+	_, err = repo.FindByID(ctx, "missing")
+	assert.Error(t, err)
+}
+
+func Test_TransactionServiceBook_should_read_candidates_and_mark_reconciled(t *testing.T) {
+	// Given
+	var reconciled string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/transactions/portfolio/pf-1":
+			_, _ = w.Write([]byte(`[
+				{"id":"t1","asset_id":"a1","type":"BUY","total_amount":1855,"executed_at":"2024-01-10T00:00:00Z"},
+				{"id":"t2","asset_id":"a1","type":"DIVIDEND","total_amount":12.5,"executed_at":"2024-01-20T00:00:00Z","reconciled":true},
+				{"id":"t3","asset_id":"a2","type":"BUY","total_amount":10,"executed_at":"2024-01-10T00:00:00Z"},
+				{"id":"t4","asset_id":"a1","type":"SELL","total_amount":10,"executed_at":"2024-03-10T00:00:00Z"}
+			]`))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/reconciled"):
+			reconciled = strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/transactions/"), "/reconciled")
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	book := NewTransactionServiceBook(server.URL, nil)
+	target := Target{PortfolioID: "pf-1", AssetID: "a1"}
+
+	// When
+	candidates, err := book.Candidates(context.Background(), target, day(1), day(31))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, -1855.0, candidates[0].Amount)
+	assert.Equal(t, 12.5, candidates[1].Amount)
+	assert.True(t, candidates[1].Reconciled)
+
+	require.NoError(t, book.MarkReconciled(context.Background(), target, "t1"))
+	assert.Equal(t, "t1", reconciled)
+	assert.ErrorIs(t, book.Merge(context.Background(), target, "t1", Record{}), ErrMergeUnsupported)
+	_, ok, err := book.BalanceAt(context.Background(), target, day(31))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,92 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const reconciliationBucket = "statement_reconciliations"
+
+// ReconciliationRepository 대사 작업 저장소입니다.
+type ReconciliationRepository interface {
+	// Save 대사 작업을 저장합니다. 같은 ID가 있으면 덮어씁니다.
+	Save(ctx context.Context, rec *Reconciliation) error
+	FindByID(ctx context.Context, id string) (*Reconciliation, error)
+}
+
+func reconciliationNotFound(id string) error {
+	return domain.NewError("statement", domain.ErrCodeNotFound, fmt.Sprintf("reconciliation with ID %s not found", id))
+}
+
+// MemoryReconciliationRepository 대사 작업의 인메모리 저장소 구현체입니다.
+type MemoryReconciliationRepository struct {
+	reconciliations map[string]*Reconciliation
+	mutex           sync.RWMutex
+}
+
+// NewMemoryReconciliationRepository 새로운 인메모리 대사 작업 저장소를 생성합니다.
+func NewMemoryReconciliationRepository() *MemoryReconciliationRepository {
+	return &MemoryReconciliationRepository{reconciliations: make(map[string]*Reconciliation)}
+}
+
+// Save 대사 작업을 저장합니다.
+func (r *MemoryReconciliationRepository) Save(_ context.Context, rec *Reconciliation) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.reconciliations[rec.ID] = rec.Clone()
+	return nil
+}
+
+// FindByID ID로 대사 작업을 조회합니다.
+func (r *MemoryReconciliationRepository) FindByID(_ context.Context, id string) (*Reconciliation, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rec, ok := r.reconciliations[id]
+	if !ok {
+		return nil, reconciliationNotFound(id)
+	}
+	return rec.Clone(), nil
+}
+
+// EmbeddedReconciliationRepository 대사 작업의 임베디드 키-값 저장소 구현체입니다.
+type EmbeddedReconciliationRepository struct {
+	reconciliations *kv.Collection[*Reconciliation]
+}
+
+// NewEmbeddedReconciliationRepository 새로운 임베디드 대사 작업 저장소를 생성합니다.
+func NewEmbeddedReconciliationRepository(db *kv.DB) *EmbeddedReconciliationRepository {
+	return &EmbeddedReconciliationRepository{reconciliations: kv.NewCollection[*Reconciliation](db, reconciliationBucket)}
+}
+
+// Save 대사 작업을 저장합니다.
+func (r *EmbeddedReconciliationRepository) Save(ctx context.Context, rec *Reconciliation) error {
+	return storageError(r.reconciliations.Update(ctx, func(tx *kv.Tx) error {
+		return r.reconciliations.Put(tx, rec.ID, rec)
+	}))
+}
+
+// FindByID ID로 대사 작업을 조회합니다.
+func (r *EmbeddedReconciliationRepository) FindByID(ctx context.Context, id string) (*Reconciliation, error) {
+	var rec *Reconciliation
+	err := r.reconciliations.View(ctx, func(tx *kv.Tx) error {
+		found, exists, err := r.reconciliations.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return reconciliationNotFound(id)
+		}
+		rec = found
+		return nil
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return rec, nil
+}
@@ -0,0 +1,102 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const importBucket = "statement_imports"
+
+// Registry 이미 가져온 거래를 기록해 같은 거래가 다시 생성되지 않도록 합니다.
+type Registry interface {
+	// Lookup 키로 가져온 거래의 ID를 조회합니다. 기록이 없으면 ok가 false입니다.
+	Lookup(ctx context.Context, key string) (transactionID string, ok bool, err error)
+	// Remember 키와 생성된 거래 ID를 기록합니다.
+	Remember(ctx context.Context, key string, transactionID string) error
+}
+
+// importedRecord 가져온 거래 기록입니다.
+type importedRecord struct {
+	Key           string    `json:"key"`
+	TransactionID string    `json:"transactionId"`
+	ImportedAt    time.Time `json:"importedAt"`
+}
+
+// MemoryRegistry 가져오기 기록의 인메모리 저장소 구현체입니다.
+type MemoryRegistry struct {
+	records map[string]string
+	mutex   sync.RWMutex
+}
+
+// NewMemoryRegistry 새로운 인메모리 가져오기 기록 저장소를 생성합니다.
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{records: make(map[string]string)}
+}
+
+// Lookup 키로 가져온 거래의 ID를 조회합니다.
+func (r *MemoryRegistry) Lookup(_ context.Context, key string) (string, bool, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	id, ok := r.records[key]
+	return id, ok, nil
+}
+
+// Remember 키와 생성된 거래 ID를 기록합니다.
+func (r *MemoryRegistry) Remember(_ context.Context, key string, transactionID string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.records[key] = transactionID
+	return nil
+}
+
+// EmbeddedRegistry 가져오기 기록의 임베디드 키-값 저장소 구현체입니다.
+type EmbeddedRegistry struct {
+	records *kv.Collection[*importedRecord]
+}
+
+// NewEmbeddedRegistry 새로운 임베디드 가져오기 기록 저장소를 생성합니다.
+func NewEmbeddedRegistry(db *kv.DB) *EmbeddedRegistry {
+	return &EmbeddedRegistry{records: kv.NewCollection[*importedRecord](db, importBucket)}
+}
+
+// Lookup 키로 가져온 거래의 ID를 조회합니다.
+func (r *EmbeddedRegistry) Lookup(ctx context.Context, key string) (string, bool, error) {
+	var record *importedRecord
+	var exists bool
+	err := r.records.View(ctx, func(tx *kv.Tx) error {
+		var err error
+		record, exists, err = r.records.Get(tx, key)
+		return err
+	})
+	if err != nil {
+		return "", false, storageError(err)
+	}
+	if !exists {
+		return "", false, nil
+	}
+	return record.TransactionID, true, nil
+}
+
+// Remember 키와 생성된 거래 ID를 기록합니다.
+func (r *EmbeddedRegistry) Remember(ctx context.Context, key string, transactionID string) error {
+	record := &importedRecord{Key: key, TransactionID: transactionID, ImportedAt: time.Now()}
+	return storageError(r.records.Update(ctx, func(tx *kv.Tx) error {
+		return r.records.Put(tx, key, record)
+	}))
+}
+
+// storageError 도메인 에러는 그대로 두고, 저장소 에러는 내부 에러로 감쌉니다.
+func storageError(err error) error {
+	var domainErr domain.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewError("statement", domain.ErrCodeInternal, err.Error())
+}
@@ -0,0 +1,232 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/ledger"
+)
+
+// importCategory 분류가 없는 거래에 붙이는 분류입니다.
+const importCategory = "가져오기"
+
+// AssetSink 읽은 거래를 모놀리스 자산의 입출금 거래로 생성합니다.
+// 입금은 수입, 출금은 지출 거래가 되며 거래일은 파일의 날짜를 따릅니다.
+type AssetSink struct {
+	assets       asset.Repository
+	transactions asset.TransactionRepository
+	categorizer  Categorizer
+	ledger       *ledger.Ledger
+}
+
+// Categorizer 분류가 없는 거래에 사용자의 규칙으로 분류를 지정합니다.
+type Categorizer interface {
+	Apply(ctx context.Context, userID string, tx *asset.Transaction) error
+}
+
+// AssetSinkOption 자산 거래 생성기 설정 함수입니다.
+type AssetSinkOption func(*AssetSink)
+
+// WithCategorizer 파일에 분류가 없는 거래를 저장하기 전에 분류 규칙을 적용합니다.
+func WithCategorizer(categorizer Categorizer) AssetSinkOption {
+	return func(s *AssetSink) {
+		s.categorizer = categorizer
+	}
+}
+
+// WithLedger 가져온 거래를 원장에 복식부기 분개로 함께 전기합니다.
+func WithLedger(l *ledger.Ledger) AssetSinkOption {
+	return func(s *AssetSink) {
+		s.ledger = l
+	}
+}
+
+// NewAssetSink 새로운 자산 거래 생성기를 생성합니다.
+func NewAssetSink(assets asset.Repository, transactions asset.TransactionRepository, opts ...AssetSinkOption) *AssetSink {
+	s := &AssetSink{assets: assets, transactions: transactions}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Create 자산에 거래를 반영하고 저장합니다.
+func (s *AssetSink) Create(ctx context.Context, target Target, record Record) (string, error) {
+	txType := asset.Income
+	if record.Amount < 0 {
+		txType = asset.Expense
+	}
+	money, err := asset.NewMoney(math.Abs(record.Amount), record.Currency)
+	if err != nil {
+		return "", err
+	}
+	tx, err := asset.NewTransaction(target.AssetID, txType, money, record.Category, description(record))
+	if err != nil {
+		return "", err
+	}
+	tx.Date = record.Date
+
+	// 자산 갱신과 거래 저장을 하나의 작업 단위로 처리하여 부분 반영을 막습니다
+	err = s.assets.WithTransaction(ctx, func(ctx context.Context) error {
+		target, err := s.assets.FindByID(ctx, target.AssetID)
+		if err != nil {
+			return err
+		}
+		if s.categorizer != nil {
+			if err := s.categorizer.Apply(ctx, target.UserID, tx); err != nil {
+				return err
+			}
+		}
+		if tx.Category == "" {
+			tx.Category = importCategory
+		}
+		if s.ledger != nil {
+			if _, err := s.ledger.Record(ctx, tx, target, nil); err != nil {
+				return err
+			}
+		} else if err := target.ProcessTransaction(tx); err != nil {
+			return err
+		}
+		if err := s.transactions.Save(ctx, tx); err != nil {
+			return err
+		}
+		return s.assets.Update(ctx, target)
+	})
+	if err != nil {
+		return "", err
+	}
+	return tx.ID, nil
+}
+
+// description 거래처와 메모를 거래 설명으로 합칩니다.
+func description(r Record) string {
+	parts := make([]string, 0, 2)
+	for _, s := range []string{r.Payee, r.Memo} {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " - ")
+}
+
+// TransactionServiceSink 읽은 증권 거래를 거래 서비스 API로 생성합니다.
+// 매수, 매도, 배당, 이자 거래만 생성할 수 있습니다.
+type TransactionServiceSink struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewTransactionServiceSink 새로운 거래 서비스 거래 생성기를 생성합니다. client가 nil이면 기본 클라이언트를 사용합니다.
+func NewTransactionServiceSink(baseURL string, client *http.Client) *TransactionServiceSink {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &TransactionServiceSink{baseURL: strings.TrimRight(baseURL, "/"), client: client}
+}
+
+// serviceTransactionRequest 거래 서비스의 거래 생성 요청입니다.
+type serviceTransactionRequest struct {
+	UserID        string  `json:"userID"`
+	PortfolioID   string  `json:"portfolioID"`
+	AssetID       string  `json:"assetID"`
+	Type          string  `json:"type"`
+	Amount        float64 `json:"amount"`
+	Quantity      float64 `json:"quantity"`
+	ExecutedPrice float64 `json:"executedPrice"`
+	ExecutedAt    string  `json:"executedAt"`
+}
+
+// Create 거래 서비스에 거래를 생성하고 생성된 거래 ID를 반환합니다.
+func (s *TransactionServiceSink) Create(ctx context.Context, target Target, record Record) (string, error) {
+	txType, err := serviceTransactionType(record)
+	if err != nil {
+		return "", err
+	}
+	assetID := target.AssetID
+	if assetID == "" {
+		assetID = record.Symbol
+	}
+	body, err := json.Marshal(serviceTransactionRequest{
+		UserID:        target.UserID,
+		PortfolioID:   target.PortfolioID,
+		AssetID:       assetID,
+		Type:          txType,
+		Amount:        math.Abs(record.Amount),
+		Quantity:      record.Quantity,
+		ExecutedPrice: record.Price,
+		ExecutedAt:    record.Date.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/api/v1/transactions/", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("거래 서비스 요청 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		var msg bytes.Buffer
+		_, _ = msg.ReadFrom(resp.Body)
+		return "", fmt.Errorf("거래 서비스가 거래를 생성하지 못했습니다(%d): %s", resp.StatusCode, strings.TrimSpace(msg.String()))
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("거래 서비스 응답을 읽을 수 없습니다: %w", err)
+	}
+	return created.ID, nil
+}
+
+// serviceTransactionType 파일의 거래 유형을 거래 서비스의 거래 유형으로 변환합니다.
+func serviceTransactionType(r Record) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(r.Type)) {
+	case "BUY", "매수":
+		return "BUY", nil
+	case "SELL", "매도":
+		return "SELL", nil
+	case "DIV", "DIVIDEND", "배당", "배당금":
+		return "DIVIDEND", nil
+	case "INT", "INTEREST", "이자":
+		return "INTEREST", nil
+	}
+	if r.Quantity > 0 {
+		if r.Amount < 0 {
+			return "BUY", nil
+		}
+		return "SELL", nil
+	}
+	return "", errors.New("증권 거래가 아닌 거래는 거래 서비스로 가져올 수 없습니다")
+}
+
+// TargetSink 대상에 포트폴리오가 지정되면 Portfolio로, 아니면 Assets로 거래를 생성합니다.
+type TargetSink struct {
+	Assets    Sink
+	Portfolio Sink // 거래 서비스가 설정되지 않았으면 nil입니다
+}
+
+// Create 대상에 맞는 생성기로 거래를 생성합니다.
+func (s TargetSink) Create(ctx context.Context, target Target, record Record) (string, error) {
+	if target.PortfolioID == "" {
+		return s.Assets.Create(ctx, target, record)
+	}
+	if s.Portfolio == nil {
+		return "", errors.New("거래 서비스가 설정되지 않아 포트폴리오로 가져올 수 없습니다")
+	}
+	return s.Portfolio.Create(ctx, target, record)
+}
@@ -0,0 +1,49 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/internal/domain/budget"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/services/monitoring/pkg/alerts"
+)
+
+// Forwarder 이벤트 버스의 alert.triggered 이벤트를 모니터링 알림 처리자로 전달하는 이벤트 핸들러입니다.
+type Forwarder struct {
+	notifier alerts.Notifier
+}
+
+// NewForwarder 새로운 알림 전달자를 생성합니다.
+func NewForwarder(notifier alerts.Notifier) *Forwarder {
+	return &Forwarder{notifier: notifier}
+}
+
+// HandlerName 이벤트 핸들러 이름입니다.
+func (f *Forwarder) HandlerName() string {
+	return "alert-forwarder"
+}
+
+// HandleEvent alert.triggered 이벤트의 알림을 알림 처리자로 전달하고 나머지 이벤트는 무시합니다.
+func (f *Forwarder) HandleEvent(ctx context.Context, evt event.Event) error {
+	if evt.EventType() != event.TypeAlertTriggered {
+		return nil
+	}
+	var alert alerts.Alert
+	switch payload := evt.Payload().(type) {
+	case alerts.Alert:
+		alert = payload
+	case budget.Alert:
+		alert = alerts.Alert{
+			ID:        payload.ID,
+			Level:     alerts.AlertLevel(payload.Level),
+			Source:    payload.Source,
+			Message:   payload.Message,
+			Timestamp: payload.Timestamp,
+			Metadata:  payload.Metadata,
+		}
+	default:
+		return fmt.Errorf("알 수 없는 알림 이벤트 페이로드입니다: %T", evt.Payload())
+	}
+	return f.notifier.Notify(ctx, alert)
+}
@@ -0,0 +1,81 @@
+package alerting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/internal/domain/asset"
+	"github.com/aske/go_fi_chart/internal/domain/budget"
+	"github.com/aske/go_fi_chart/internal/domain/event"
+	"github.com/aske/go_fi_chart/internal/infrastructure/events/memory"
+	"github.com/aske/go_fi_chart/services/monitoring/pkg/alerts"
+)
+
+type recordingNotifier struct {
+	alerts []alerts.Alert
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, alert alerts.Alert) error {
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func Test_Forwarder_should_deliver_budget_alert_to_notifier(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	assets := asset.NewMemoryAssetRepository()
+	transactions := asset.NewMemoryTransactionRepository()
+	account, err := asset.NewAsset("user-1", asset.Cash, "입출금", 1000000, "KRW")
+	require.NoError(t, err)
+	require.NoError(t, assets.Save(ctx, account))
+
+	service := budget.NewService(budget.NewMemoryRepository(), assets, transactions)
+	now := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	b, err := budget.NewBudget("user-1", "식비", budget.Monthly, asset.Money{Amount: 100000, Currency: "KRW"}, false, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NoError(t, service.Create(ctx, b))
+
+	received := &recordingNotifier{}
+	notifier := alerts.NewSimpleNotifier(NewPublisher())
+	notifier.AddHandler(received)
+
+	bus := memory.NewEventBus()
+	require.NoError(t, bus.Subscribe(budget.NewMonitor(service, bus, budget.WithClock(func() time.Time { return now }))))
+	require.NoError(t, bus.Subscribe(NewForwarder(notifier)))
+
+	tx, err := asset.NewTransaction(account.ID, asset.Expense, asset.Money{Amount: 90000, Currency: "KRW"}, "식비", "")
+	require.NoError(t, err)
+	tx.Date = time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, account.ProcessTransaction(tx))
+	require.NoError(t, transactions.Save(ctx, tx))
+
+	// When
+	for _, evt := range account.GetUncommittedEvents() {
+		require.NoError(t, bus.Publish(ctx, evt))
+	}
+
+	// Then
+	require.Len(t, received.alerts, 1)
+	alert := received.alerts[0]
+	assert.Equal(t, alerts.LevelWarning, alert.Level)
+	assert.Equal(t, "budget", alert.Source)
+	assert.Equal(t, b.ID, alert.Metadata["budgetID"])
+}
+
+func Test_Forwarder_should_reject_unknown_alert_payload(t *testing.T) {
+	// Given
+	received := &recordingNotifier{}
+	forwarder := NewForwarder(received)
+	evt := event.NewEvent(event.TypeAlertTriggered, "aggregate-1", "unknown", "payload", nil, 1)
+
+	// When
+	err := forwarder.HandleEvent(context.Background(), evt)
+
+	// Then
+	require.Error(t, err)
+	assert.Empty(t, received.alerts)
+}
@@ -0,0 +1,75 @@
+// Package alerting 서버 안에서 발생한 알림을 모니터링 서비스의 알림 처리자(alerts.Notifier)로 전달합니다.
+package alerting
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/aske/go_fi_chart/services/monitoring/pkg/alerts"
+	monitoring "github.com/aske/go_fi_chart/services/monitoring/pkg/domain"
+)
+
+// NewNotifier 알림을 서버 로그에 남기는 모니터링 알림 처리자를 생성합니다.
+// 다른 전달 경로는 AddHandler로 추가합니다.
+func NewNotifier() *alerts.SimpleNotifier {
+	notifier := alerts.NewSimpleNotifier(NewPublisher())
+	notifier.AddHandler(LogNotifier{})
+	return notifier
+}
+
+// LogNotifier 알림을 서버 로그에 남기는 알림 처리자입니다.
+type LogNotifier struct{}
+
+// Notify 알림을 로그로 기록합니다.
+func (LogNotifier) Notify(_ context.Context, alert alerts.Alert) error {
+	log.Printf("[%s] %s: %s %v", alert.Level, alert.Source, alert.Message, alert.Metadata)
+	return nil
+}
+
+// Publisher 모니터링 이벤트를 등록된 핸들러에 동기적으로 전달하는 인메모리 발행자입니다.
+type Publisher struct {
+	handlers []monitoring.Handler
+	mu       sync.RWMutex
+}
+
+// NewPublisher 새로운 인메모리 모니터링 이벤트 발행자를 생성합니다.
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+// Publish 모든 핸들러에 이벤트를 전달합니다. 한 핸들러의 에러는 다른 핸들러의 처리를 막지 않습니다.
+func (p *Publisher) Publish(ctx context.Context, evt monitoring.Event) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, handler := range p.handlers {
+		if err := handler.Handle(ctx, evt); err != nil {
+			log.Printf("모니터링 이벤트 처리 실패(%s): %v", evt.Type, err)
+		}
+	}
+	return nil
+}
+
+// Subscribe 핸들러를 등록합니다.
+func (p *Publisher) Subscribe(handler monitoring.Handler) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers = append(p.handlers, handler)
+	return nil
+}
+
+// Unsubscribe 핸들러를 제거합니다.
+func (p *Publisher) Unsubscribe(handler monitoring.Handler) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, h := range p.handlers {
+		if h == handler {
+			p.handlers = append(p.handlers[:i], p.handlers[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package alerting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/services/monitoring/pkg/alerts"
+	monitoring "github.com/aske/go_fi_chart/services/monitoring/pkg/domain"
+)
+
+type handlerFunc func(ctx context.Context, evt monitoring.Event) error
+
+func (h handlerFunc) Handle(ctx context.Context, evt monitoring.Event) error { return h(ctx, evt) }
+
+func Test_Notifier_should_publish_alert_event_to_subscribers(t *testing.T) {
+	// Given
+	publisher := NewPublisher()
+	var received []monitoring.Event
+	require.NoError(t, publisher.Subscribe(handlerFunc(func(_ context.Context, evt monitoring.Event) error {
+		received = append(received, evt)
+		return nil
+	})))
+	notifier := alerts.NewSimpleNotifier(publisher)
+	notifier.AddHandler(LogNotifier{})
+	alert := alerts.Alert{ID: "alert-1", Level: alerts.LevelWarning, Source: "test", Message: "한도 초과"}
+
+	// When
+	err := notifier.Notify(context.Background(), alert)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, monitoring.TypeAlertTriggered, received[0].Type)
+	assert.Equal(t, alert, received[0].Data)
+}
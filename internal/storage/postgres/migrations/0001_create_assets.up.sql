@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS assets (
+    id              TEXT PRIMARY KEY,
+    user_id         TEXT NOT NULL,
+    type            TEXT NOT NULL,
+    name            TEXT NOT NULL,
+    amount_amount   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    amount_currency TEXT NOT NULL,
+    performance     JSONB,
+    goals           JSONB NOT NULL DEFAULT '[]',
+    achievements    JSONB NOT NULL DEFAULT '[]',
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_assets_user_id ON assets (user_id);
+CREATE INDEX IF NOT EXISTS idx_assets_type ON assets (type);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS transactions (
+    id              TEXT PRIMARY KEY,
+    asset_id        TEXT NOT NULL,
+    type            TEXT NOT NULL,
+    amount_amount   DOUBLE PRECISION NOT NULL,
+    amount_currency TEXT NOT NULL,
+    category        TEXT NOT NULL DEFAULT '',
+    description     TEXT NOT NULL DEFAULT '',
+    date            TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_asset_id ON transactions (asset_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_date ON transactions (date);
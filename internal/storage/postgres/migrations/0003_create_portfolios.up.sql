@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS portfolios (
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT NOT NULL,
+    assets     JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_portfolios_user_id ON portfolios (user_id);
+CREATE INDEX IF NOT EXISTS idx_portfolios_assets ON portfolios USING GIN (assets jsonb_path_ops);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id          TEXT PRIMARY KEY,
+    entity_type TEXT NOT NULL,
+    entity_id   TEXT NOT NULL,
+    action      TEXT NOT NULL,
+    actor       TEXT NOT NULL,
+    changes     JSONB NOT NULL DEFAULT '[]',
+    state       JSONB,
+    recorded_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_entries_entity ON audit_entries (entity_type, entity_id, recorded_at);
@@ -0,0 +1,4 @@
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS holding_symbol TEXT;
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS holding JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_assets_holding_symbol ON assets (holding_symbol) WHERE holding_symbol IS NOT NULL;
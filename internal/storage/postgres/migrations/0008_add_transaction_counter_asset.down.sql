@@ -0,0 +1 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS counter_asset_id;
@@ -0,0 +1 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS counter_asset_id TEXT NOT NULL DEFAULT '';
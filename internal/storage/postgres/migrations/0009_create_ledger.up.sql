@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id       TEXT PRIMARY KEY,
+    user_id  TEXT NOT NULL,
+    asset_id TEXT,
+    data     JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_accounts_user_id ON ledger_accounts (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_asset_id ON ledger_accounts (asset_id) WHERE asset_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id      TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    data    JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_user_id ON ledger_entries (user_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_postings ON ledger_entries USING GIN ((data->'Postings') jsonb_path_ops);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS budgets (
+    id      TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    data    JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_budgets_user_id ON budgets (user_id);
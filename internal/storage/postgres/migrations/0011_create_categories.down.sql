@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS category_corrections;
+DROP TABLE IF EXISTS category_rules;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id      TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    data    JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_user_id ON categories (user_id);
+
+CREATE TABLE IF NOT EXISTS category_rules (
+    id      TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    data    JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_rules_user_id ON category_rules (user_id);
+
+CREATE TABLE IF NOT EXISTS category_corrections (
+    id      TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    data    JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_corrections_user_id ON category_corrections (user_id);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS recurrences (
+    id       TEXT PRIMARY KEY,
+    asset_id TEXT NOT NULL,
+    data     JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurrences_asset_id ON recurrences (asset_id);
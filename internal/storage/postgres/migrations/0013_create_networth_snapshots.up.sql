@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS networth_snapshots (
+    user_id       TEXT NOT NULL,
+    snapshot_date DATE NOT NULL,
+    data          JSONB NOT NULL,
+    PRIMARY KEY (user_id, snapshot_date)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS drift_watches;
+DROP TABLE IF EXISTS drift_samples;
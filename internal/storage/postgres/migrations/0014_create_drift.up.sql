@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS drift_samples (
+    portfolio_id TEXT NOT NULL,
+    sample_date  DATE NOT NULL,
+    data         JSONB NOT NULL,
+    PRIMARY KEY (portfolio_id, sample_date)
+);
+
+CREATE TABLE IF NOT EXISTS drift_watches (
+    portfolio_id TEXT PRIMARY KEY,
+    data         JSONB NOT NULL
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS gamification_profiles;
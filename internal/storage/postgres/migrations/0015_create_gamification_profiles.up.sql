@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS gamification_profiles (
+    id      TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    data    JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_gamification_profiles_user_id ON gamification_profiles (user_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS statement_imports (
+    import_key     TEXT PRIMARY KEY,
+    transaction_id TEXT NOT NULL,
+    data           JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS statement_reconciliations (
+    id   TEXT PRIMARY KEY,
+    data JSONB NOT NULL
+);
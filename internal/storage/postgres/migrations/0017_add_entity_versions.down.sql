@@ -0,0 +1,3 @@
+ALTER TABLE portfolios DROP COLUMN IF EXISTS version;
+ALTER TABLE transactions DROP COLUMN IF EXISTS version;
+ALTER TABLE assets DROP COLUMN IF EXISTS version;
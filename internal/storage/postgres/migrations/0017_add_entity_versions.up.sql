@@ -0,0 +1,3 @@
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE portfolios ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,78 @@
+// Package postgres PostgreSQL 연결과 스키마 마이그레이션을 관리합니다.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/aske/go_fi_chart/internal/config"
+	"github.com/aske/go_fi_chart/pkg/storage/migrate"
+)
+
+// DriverName database/sql에 등록된 PostgreSQL 드라이버 이름입니다.
+// 드라이버 패키지는 실행 파일(cmd)에서 import 합니다.
+const DriverName = "postgres"
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations 애플리케이션 스키마 마이그레이션 목록을 반환합니다.
+func Migrations() ([]migrate.Migration, error) {
+	return migrate.Load(migrationFiles, "migrations")
+}
+
+// DSN 데이터베이스 설정으로 연결 문자열을 생성합니다.
+func DSN(cfg config.DatabaseConfig) string {
+	dsn := url.URL{
+		Scheme: "postgres",
+		Host:   cfg.Host + ":" + strconv.Itoa(cfg.Port),
+		Path:   "/" + cfg.Name,
+	}
+	if cfg.User != "" {
+		dsn.User = url.UserPassword(cfg.User, cfg.Password)
+	}
+
+	query := url.Values{}
+	query.Set("sslmode", "disable")
+	if seconds := int(cfg.ConnectTimeout.Seconds()); seconds > 0 {
+		query.Set("connect_timeout", strconv.Itoa(seconds))
+	}
+	dsn.RawQuery = query.Encode()
+	return dsn.String()
+}
+
+// Open 설정의 커넥션 풀 값을 적용해 데이터베이스에 연결하고 연결을 확인합니다.
+func Open(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open(DriverName, DSN(cfg))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	pingCtx := ctx
+	if cfg.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		pingCtx, cancel = context.WithTimeout(ctx, cfg.ConnectTimeout)
+		defer cancel()
+	}
+	if err := db.PingContext(pingCtx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("데이터베이스 연결 실패: %w", err)
+	}
+	return db, nil
+}
+
+// Migrate 적용되지 않은 스키마 마이그레이션을 모두 적용합니다.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	return migrate.New(db, migrations).Up(ctx)
+}
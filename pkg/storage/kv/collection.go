@@ -0,0 +1,132 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Collection JSON으로 직렬화된 엔티티를 담는 버킷입니다.
+// 저장소 구현체가 트랜잭션 처리와 직렬화를 반복하지 않도록 도와줍니다.
+type Collection[T any] struct {
+	db     *DB
+	bucket string
+}
+
+// NewCollection 버킷 이름으로 Collection을 생성합니다.
+func NewCollection[T any](db *DB, bucket string) *Collection[T] {
+	return &Collection[T]{db: db, bucket: bucket}
+}
+
+// DB 컬렉션이 속한 데이터베이스를 반환합니다.
+func (c *Collection[T]) DB() *DB {
+	return c.db
+}
+
+// Index 엔티티에서 인덱스 키를 추출하는 보조 인덱스를 등록합니다.
+func (c *Collection[T]) Index(name string, fn func(entity T) []string) error {
+	return c.db.EnsureIndex(c.bucket, name, func(_ string, value []byte) []string {
+		entity, err := c.decode(value)
+		if err != nil {
+			return nil
+		}
+		return fn(entity)
+	})
+}
+
+// View 컨텍스트의 트랜잭션이 있으면 그 안에서, 없으면 읽기 전용 트랜잭션에서 fn을 실행합니다.
+func (c *Collection[T]) View(ctx context.Context, fn func(tx *Tx) error) error {
+	if tx := TxFromContext(ctx); tx != nil {
+		return fn(tx)
+	}
+	return c.db.View(fn)
+}
+
+// Update 컨텍스트의 트랜잭션이 있으면 그 안에서, 없으면 새 쓰기 트랜잭션에서 fn을 실행합니다.
+func (c *Collection[T]) Update(ctx context.Context, fn func(tx *Tx) error) error {
+	if tx := TxFromContext(ctx); tx != nil {
+		if !tx.Writable() {
+			return ErrReadOnly
+		}
+		return fn(tx)
+	}
+	return c.db.Update(fn)
+}
+
+// Get 키로 엔티티를 조회합니다.
+func (c *Collection[T]) Get(tx *Tx, key string) (T, bool, error) {
+	var zero T
+	value, ok, err := tx.Get(c.bucket, key)
+	if err != nil || !ok {
+		return zero, false, err
+	}
+	entity, err := c.decode(value)
+	if err != nil {
+		return zero, false, err
+	}
+	return entity, true, nil
+}
+
+// Put 엔티티를 기록합니다.
+func (c *Collection[T]) Put(tx *Tx, key string, entity T) error {
+	value, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	return tx.Put(c.bucket, key, value)
+}
+
+// Delete 키로 엔티티를 삭제합니다.
+func (c *Collection[T]) Delete(tx *Tx, key string) error {
+	return tx.Delete(c.bucket, key)
+}
+
+// Exists 키가 존재하는지 확인합니다.
+func (c *Collection[T]) Exists(tx *Tx, key string) (bool, error) {
+	_, ok, err := tx.Get(c.bucket, key)
+	return ok, err
+}
+
+// All 버킷의 모든 엔티티를 키 순서대로 반환합니다.
+func (c *Collection[T]) All(tx *Tx) ([]T, error) {
+	var result []T
+	err := tx.ForEach(c.bucket, func(_ string, value []byte) error {
+		entity, err := c.decode(value)
+		if err != nil {
+			return err
+		}
+		result = append(result, entity)
+		return nil
+	})
+	return result, err
+}
+
+// Count 버킷의 엔티티 수를 반환합니다.
+func (c *Collection[T]) Count(tx *Tx) (int, error) {
+	return tx.Count(c.bucket)
+}
+
+// Lookup 보조 인덱스로 엔티티를 조회합니다.
+func (c *Collection[T]) Lookup(tx *Tx, index, indexValue string) ([]T, error) {
+	keys, err := tx.Lookup(c.bucket, index, indexValue)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, 0, len(keys))
+	for _, key := range keys {
+		entity, ok, err := c.Get(tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, entity)
+		}
+	}
+	return result, nil
+}
+
+func (c *Collection[T]) decode(value []byte) (T, error) {
+	var entity T
+	err := json.Unmarshal(value, &entity)
+	return entity, err
+}
@@ -0,0 +1,301 @@
+// Package kv 외부 의존성 없이 동작하는 임베디드 키-값 저장소입니다.
+//
+// 데이터는 버킷 단위로 메모리에 유지되고, 모든 쓰기는 트랜잭션 단위의 배치로
+// CRC가 포함된 로그 파일 끝에 추가됩니다. 데이터베이스를 열면 로그를 재생해 상태를 복원하며,
+// 마지막 배치가 중간에 잘린 경우(비정상 종료) 해당 배치는 버려지고,
+// 손상된 배치 뒤에 다른 배치가 남아 있으면 데이터를 잃지 않도록 열기를 실패합니다.
+// 하나의 파일은 한 프로세스에서만 열어야 합니다.
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var (
+	// ErrClosed 닫힌 데이터베이스를 사용하려 할 때 반환됩니다.
+	ErrClosed = errors.New("kv: database is closed")
+	// ErrTxClosed 이미 종료된 트랜잭션을 사용하려 할 때 반환됩니다.
+	ErrTxClosed = errors.New("kv: transaction is closed")
+	// ErrReadOnly 읽기 전용 트랜잭션에서 쓰기를 시도할 때 반환됩니다.
+	ErrReadOnly = errors.New("kv: transaction is read-only")
+	// ErrIndexNotFound 등록되지 않은 인덱스를 조회할 때 반환됩니다.
+	ErrIndexNotFound = errors.New("kv: index not found")
+	// ErrFrameTooLarge 한 트랜잭션의 변경이 frame 최대 크기를 넘을 때 반환됩니다.
+	ErrFrameTooLarge = errors.New("kv: transaction exceeds maximum frame size")
+)
+
+// Options 데이터베이스 옵션입니다.
+type Options struct {
+	// SyncWrites 커밋마다 fsync를 호출해 내구성을 보장합니다.
+	SyncWrites bool
+	// CompactThreshold 로그의 레코드 수가 살아있는 키 수보다 이 값 이상 많으면 열 때 압축합니다.
+	// 0이면 기본값(1000)을 사용하고, 음수이면 자동 압축을 하지 않습니다.
+	CompactThreshold int
+}
+
+const defaultCompactThreshold = 1000
+
+// DB 임베디드 키-값 데이터베이스입니다.
+type DB struct {
+	mu      sync.RWMutex
+	path    string
+	opts    Options
+	log     *logFile
+	buckets map[string]map[string][]byte
+	indexes map[string]map[string]*index
+	records int
+	closed  bool
+}
+
+// Open path의 로그 파일을 열거나 생성하고 저장된 상태를 복원합니다.
+func Open(path string, opts *Options) (*DB, error) {
+	db := newDB(path, opts)
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("kv: open %s: %w", path, err)
+	}
+
+	log, err := replay(f, db.applyRecord)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("kv: replay %s: %w", path, err)
+	}
+	db.log = log
+
+	threshold := db.opts.CompactThreshold
+	if threshold == 0 {
+		threshold = defaultCompactThreshold
+	}
+	if threshold > 0 && db.records-db.liveKeys() >= threshold {
+		// 압축은 새 파일을 다 쓴 뒤에 교체하므로 실패해도 기존 로그는 그대로입니다.
+		// 자동 압축 실패로 데이터베이스를 열지 못하게 되지 않도록 무시하고 다음에 다시 시도합니다.
+		_ = db.compactLocked()
+	}
+	return db, nil
+}
+
+// OpenMemory 파일 없이 메모리에서만 동작하는 데이터베이스를 생성합니다.
+// 테스트나 임시 데이터에 사용합니다.
+func OpenMemory() *DB {
+	return newDB("", nil)
+}
+
+func newDB(path string, opts *Options) *DB {
+	db := &DB{
+		path:    path,
+		buckets: make(map[string]map[string][]byte),
+		indexes: make(map[string]map[string]*index),
+	}
+	if opts != nil {
+		db.opts = *opts
+	}
+	return db
+}
+
+// Path 로그 파일 경로를 반환합니다. 메모리 데이터베이스는 빈 문자열을 반환합니다.
+func (db *DB) Path() string {
+	return db.path
+}
+
+// Close 데이터베이스를 닫습니다.
+func (db *DB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return nil
+	}
+	db.closed = true
+	if db.log == nil {
+		return nil
+	}
+	return db.log.close()
+}
+
+// View 읽기 전용 트랜잭션 안에서 fn을 실행합니다.
+func (db *DB) View(fn func(tx *Tx) error) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if db.closed {
+		return ErrClosed
+	}
+
+	tx := newTx(db, nil, false)
+	defer tx.close()
+	return fn(tx)
+}
+
+// Update 쓰기 트랜잭션 안에서 fn을 실행합니다.
+// fn이 nil을 반환하면 모든 쓰기가 하나의 배치로 기록되고, 에러를 반환하면 버려집니다.
+// 쓰기 트랜잭션은 한 번에 하나만 실행됩니다.
+func (db *DB) Update(fn func(tx *Tx) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return ErrClosed
+	}
+
+	tx := newTx(db, nil, true)
+	defer tx.close()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return db.commit(tx)
+}
+
+// txKey 컨텍스트에 진행 중인 트랜잭션을 저장하기 위한 키입니다.
+type txKey struct{}
+
+// TxFromContext 컨텍스트에 담긴 트랜잭션을 반환합니다. 없으면 nil입니다.
+func TxFromContext(ctx context.Context) *Tx {
+	if ctx == nil {
+		return nil
+	}
+	tx, _ := ctx.Value(txKey{}).(*Tx)
+	return tx
+}
+
+// ContextWithTx 트랜잭션을 담은 컨텍스트를 반환합니다.
+func ContextWithTx(ctx context.Context, tx *Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// UpdateContext 트랜잭션을 컨텍스트에 담아 fn을 실행합니다.
+// 컨텍스트에 이미 쓰기 트랜잭션이 있으면 세이브포인트로 동작하여,
+// fn이 실패해도 바깥 트랜잭션의 쓰기는 유지되고 성공하면 바깥 트랜잭션에 합쳐집니다.
+func (db *DB) UpdateContext(ctx context.Context, fn func(ctx context.Context) error) error {
+	if parent := TxFromContext(ctx); parent != nil {
+		if !parent.writable {
+			return ErrReadOnly
+		}
+		child := newTx(db, parent, true)
+		defer child.close()
+		if err := fn(ContextWithTx(ctx, child)); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		parent.absorb(child)
+		return nil
+	}
+
+	return db.Update(func(tx *Tx) error {
+		if err := fn(ContextWithTx(ctx, tx)); err != nil {
+			return err
+		}
+		return ctx.Err()
+	})
+}
+
+// EnsureIndex 버킷에 보조 인덱스를 등록하고 기존 데이터로 인덱스를 구성합니다.
+// 같은 이름의 인덱스가 이미 있으면 새 함수로 다시 구성합니다.
+func (db *DB) EnsureIndex(bucket, name string, fn IndexFunc) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return ErrClosed
+	}
+
+	idx := newIndex(fn)
+	for key, value := range db.buckets[bucket] {
+		idx.add(key, value)
+	}
+
+	if db.indexes[bucket] == nil {
+		db.indexes[bucket] = make(map[string]*index)
+	}
+	db.indexes[bucket][name] = idx
+	return nil
+}
+
+// Compact 현재 상태만 남도록 로그 파일을 다시 씁니다.
+func (db *DB) Compact() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return ErrClosed
+	}
+	return db.compactLocked()
+}
+
+func (db *DB) compactLocked() error {
+	if db.log == nil {
+		return nil
+	}
+
+	var records []record
+	for bucket, entries := range db.buckets {
+		for key, value := range entries {
+			records = append(records, record{op: opPut, bucket: bucket, key: key, value: value})
+		}
+	}
+	sortRecords(records)
+
+	if err := db.log.rewrite(records, db.opts.SyncWrites); err != nil {
+		return fmt.Errorf("kv: compact %s: %w", db.path, err)
+	}
+	db.records = len(records)
+	return nil
+}
+
+// commit 트랜잭션의 쓰기를 로그에 기록한 뒤 메모리 상태와 인덱스에 반영합니다.
+func (db *DB) commit(tx *Tx) error {
+	records := tx.records()
+	if len(records) == 0 {
+		return nil
+	}
+
+	if db.log != nil {
+		if err := db.log.append(records, db.opts.SyncWrites); err != nil {
+			return fmt.Errorf("kv: commit: %w", err)
+		}
+	}
+	for _, rec := range records {
+		db.applyRecord(rec)
+	}
+	return nil
+}
+
+// applyRecord 레코드 하나를 메모리 상태와 인덱스에 반영합니다.
+func (db *DB) applyRecord(rec record) {
+	db.records++
+	entries := db.buckets[rec.bucket]
+
+	if old, exists := entries[rec.key]; exists {
+		for _, idx := range db.indexes[rec.bucket] {
+			idx.remove(rec.key, old)
+		}
+	}
+
+	switch rec.op {
+	case opPut:
+		if entries == nil {
+			entries = make(map[string][]byte)
+			db.buckets[rec.bucket] = entries
+		}
+		entries[rec.key] = rec.value
+		for _, idx := range db.indexes[rec.bucket] {
+			idx.add(rec.key, rec.value)
+		}
+	case opDelete:
+		delete(entries, rec.key)
+	}
+}
+
+func (db *DB) liveKeys() int {
+	n := 0
+	for _, entries := range db.buckets {
+		n += len(entries)
+	}
+	return n
+}
@@ -0,0 +1,369 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTemp(t *testing.T) (*DB, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.kv")
+	db, err := Open(path, nil)
+	require.NoError(t, err)
+	return db, path
+}
+
+func TestDB_PersistsAcrossReopen(t *testing.T) {
+	db, path := openTemp(t)
+
+	err := db.Update(func(tx *Tx) error {
+		if err := tx.Put("assets", "a1", []byte("one")); err != nil {
+			return err
+		}
+		if err := tx.Put("assets", "a2", []byte("two")); err != nil {
+			return err
+		}
+		return tx.Delete("assets", "a2")
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	reopened, err := Open(path, nil)
+	require.NoError(t, err)
+	defer reopened.Close()
+
+	err = reopened.View(func(tx *Tx) error {
+		value, ok, err := tx.Get("assets", "a1")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("one"), value)
+
+		_, ok, err = tx.Get("assets", "a2")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestDB_UpdateRollsBackOnError(t *testing.T) {
+	db := OpenMemory()
+	boom := errors.New("boom")
+
+	err := db.Update(func(tx *Tx) error {
+		require.NoError(t, tx.Put("assets", "a1", []byte("one")))
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+
+	_ = db.View(func(tx *Tx) error {
+		_, ok, _ := tx.Get("assets", "a1")
+		assert.False(t, ok)
+		return nil
+	})
+}
+
+func TestDB_ViewIsReadOnly(t *testing.T) {
+	db := OpenMemory()
+
+	err := db.View(func(tx *Tx) error {
+		return tx.Put("assets", "a1", []byte("one"))
+	})
+	assert.ErrorIs(t, err, ErrReadOnly)
+}
+
+func TestDB_TruncatesTornWrite(t *testing.T) {
+	db, path := openTemp(t)
+	require.NoError(t, db.Update(func(tx *Tx) error {
+		return tx.Put("assets", "a1", []byte("one"))
+	}))
+	require.NoError(t, db.Update(func(tx *Tx) error {
+		return tx.Put("assets", "a2", []byte("two"))
+	}))
+	require.NoError(t, db.Close())
+
+	// 마지막 배치가 기록 도중 끊긴 상황을 흉내냅니다
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-3))
+
+	reopened, err := Open(path, nil)
+	require.NoError(t, err)
+
+	_ = reopened.View(func(tx *Tx) error {
+		_, ok, _ := tx.Get("assets", "a1")
+		assert.True(t, ok)
+		_, ok, _ = tx.Get("assets", "a2")
+		assert.False(t, ok)
+		return nil
+	})
+
+	// 잘린 꼬리 뒤에 새 배치를 이어 쓸 수 있어야 합니다
+	require.NoError(t, reopened.Update(func(tx *Tx) error {
+		return tx.Put("assets", "a3", []byte("three"))
+	}))
+	require.NoError(t, reopened.Close())
+
+	again, err := Open(path, nil)
+	require.NoError(t, err)
+	defer again.Close()
+	_ = again.View(func(tx *Tx) error {
+		n, err := tx.Count("assets")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+		return nil
+	})
+}
+
+func TestDB_TruncatesFrameWithInvalidLength(t *testing.T) {
+	db, path := openTemp(t)
+	require.NoError(t, db.Update(func(tx *Tx) error {
+		return tx.Put("assets", "a1", []byte("one"))
+	}))
+	require.NoError(t, db.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// 길이 필드가 손상되어 남은 파일보다 큰 frame을 흉내냅니다
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	var header [frameHeaderSize]byte
+	binary.BigEndian.PutUint32(header[0:4], 0xFFFFFFF0)
+	_, err = f.Write(append(header[:], "garbage"...))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	reopened, err := Open(path, nil)
+	require.NoError(t, err)
+	defer reopened.Close()
+
+	truncated, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, info.Size(), truncated.Size())
+	_ = reopened.View(func(tx *Tx) error {
+		value, ok, err := tx.Get("assets", "a1")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("one"), value)
+		return nil
+	})
+}
+
+func TestDB_RejectsCorruptFrameBeforeCommittedData(t *testing.T) {
+	db, path := openTemp(t)
+	require.NoError(t, db.Update(func(tx *Tx) error {
+		return tx.Put("assets", "a1", []byte("one"))
+	}))
+	require.NoError(t, db.Update(func(tx *Tx) error {
+		return tx.Put("assets", "a2", []byte("two"))
+	}))
+	require.NoError(t, db.Close())
+
+	// 첫 배치의 payload를 손상시킵니다. 뒤에 커밋된 배치가 남아 있으므로 잘라내면 안 됩니다
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	data[frameHeaderSize+1] ^= 0xFF
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	_, err = Open(path, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errCorruptFrame))
+
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, data, after)
+}
+
+func TestDB_CompactSplitsLargeStateIntoFrames(t *testing.T) {
+	previous := maxFrameSize
+	maxFrameSize = 64
+	defer func() { maxFrameSize = previous }()
+
+	db, path := openTemp(t)
+	for i := 0; i < 20; i++ {
+		require.NoError(t, db.Update(func(tx *Tx) error {
+			return tx.Put("assets", fmt.Sprintf("a%02d", i), []byte("0123456789"))
+		}))
+	}
+	require.NoError(t, db.Compact())
+	require.NoError(t, db.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	frames := 0
+	for offset := 0; offset < len(data); frames++ {
+		length := int(binary.BigEndian.Uint32(data[offset : offset+4]))
+		assert.LessOrEqual(t, length, maxFrameSize)
+		offset += frameHeaderSize + length
+	}
+	assert.Greater(t, frames, 1)
+
+	reopened, err := Open(path, nil)
+	require.NoError(t, err)
+	defer reopened.Close()
+	_ = reopened.View(func(tx *Tx) error {
+		n, err := tx.Count("assets")
+		assert.NoError(t, err)
+		assert.Equal(t, 20, n)
+		return nil
+	})
+}
+
+func TestDB_Compact(t *testing.T) {
+	db, path := openTemp(t)
+	for i := 0; i < 50; i++ {
+		require.NoError(t, db.Update(func(tx *Tx) error {
+			return tx.Put("assets", "a1", []byte{byte(i)})
+		}))
+	}
+	before, err := os.Stat(path)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Compact())
+	after, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Less(t, after.Size(), before.Size())
+
+	require.NoError(t, db.Update(func(tx *Tx) error {
+		return tx.Put("assets", "a2", []byte("two"))
+	}))
+	require.NoError(t, db.Close())
+
+	reopened, err := Open(path, nil)
+	require.NoError(t, err)
+	defer reopened.Close()
+	_ = reopened.View(func(tx *Tx) error {
+		value, _, _ := tx.Get("assets", "a1")
+		assert.Equal(t, []byte{49}, value)
+		_, ok, _ := tx.Get("assets", "a2")
+		assert.True(t, ok)
+		return nil
+	})
+}
+
+func TestDB_IndexLookupReflectsStagedWrites(t *testing.T) {
+	db := OpenMemory()
+	require.NoError(t, db.EnsureIndex("assets", "owner", func(_ string, value []byte) []string {
+		return []string{string(value)}
+	}))
+	require.NoError(t, db.Update(func(tx *Tx) error {
+		_ = tx.Put("assets", "a1", []byte("alice"))
+		_ = tx.Put("assets", "a2", []byte("alice"))
+		return tx.Put("assets", "a3", []byte("bob"))
+	}))
+
+	err := db.Update(func(tx *Tx) error {
+		require.NoError(t, tx.Put("assets", "a2", []byte("bob")))
+		require.NoError(t, tx.Delete("assets", "a1"))
+		require.NoError(t, tx.Put("assets", "a4", []byte("alice")))
+
+		keys, err := tx.Lookup("assets", "owner", "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a4"}, keys)
+
+		keys, err = tx.Lookup("assets", "owner", "bob")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a2", "a3"}, keys)
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+
+	_ = db.View(func(tx *Tx) error {
+		keys, err := tx.Lookup("assets", "owner", "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a1", "a2"}, keys)
+
+		_, err = tx.Lookup("assets", "missing", "alice")
+		assert.ErrorIs(t, err, ErrIndexNotFound)
+		return nil
+	})
+}
+
+func TestDB_IndexRebuiltOnReopen(t *testing.T) {
+	db, path := openTemp(t)
+	require.NoError(t, db.Update(func(tx *Tx) error {
+		return tx.Put("assets", "a1", []byte("alice"))
+	}))
+	require.NoError(t, db.Close())
+
+	reopened, err := Open(path, nil)
+	require.NoError(t, err)
+	defer reopened.Close()
+	require.NoError(t, reopened.EnsureIndex("assets", "owner", func(_ string, value []byte) []string {
+		return []string{string(value)}
+	}))
+
+	_ = reopened.View(func(tx *Tx) error {
+		keys, _ := tx.Lookup("assets", "owner", "alice")
+		assert.Equal(t, []string{"a1"}, keys)
+		return nil
+	})
+}
+
+func TestDB_UpdateContextSavepoint(t *testing.T) {
+	db := OpenMemory()
+	ctx := context.Background()
+
+	err := db.UpdateContext(ctx, func(ctx context.Context) error {
+		tx := TxFromContext(ctx)
+		require.NoError(t, tx.Put("assets", "outer", []byte("1")))
+
+		inner := db.UpdateContext(ctx, func(ctx context.Context) error {
+			require.NoError(t, TxFromContext(ctx).Put("assets", "inner", []byte("2")))
+			return errors.New("inner failed")
+		})
+		assert.Error(t, inner)
+
+		return db.UpdateContext(ctx, func(ctx context.Context) error {
+			return TxFromContext(ctx).Put("assets", "kept", []byte("3"))
+		})
+	})
+	require.NoError(t, err)
+
+	_ = db.View(func(tx *Tx) error {
+		_, ok, _ := tx.Get("assets", "outer")
+		assert.True(t, ok)
+		_, ok, _ = tx.Get("assets", "inner")
+		assert.False(t, ok)
+		_, ok, _ = tx.Get("assets", "kept")
+		assert.True(t, ok)
+		return nil
+	})
+}
+
+func TestCollection_RoundTrip(t *testing.T) {
+	type item struct {
+		ID    string
+		Owner string
+	}
+	db := OpenMemory()
+	items := NewCollection[*item](db, "items")
+	require.NoError(t, items.Index("owner", func(i *item) []string { return []string{i.Owner} }))
+
+	ctx := context.Background()
+	require.NoError(t, items.Update(ctx, func(tx *Tx) error {
+		_ = items.Put(tx, "1", &item{ID: "1", Owner: "alice"})
+		return items.Put(tx, "2", &item{ID: "2", Owner: "bob"})
+	}))
+
+	require.NoError(t, items.View(ctx, func(tx *Tx) error {
+		found, err := items.Lookup(tx, "owner", "bob")
+		assert.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "2", found[0].ID)
+
+		all, err := items.All(tx)
+		assert.NoError(t, err)
+		assert.Len(t, all, 2)
+		return nil
+	}))
+}
@@ -0,0 +1,77 @@
+package kv
+
+import "sort"
+
+// IndexFunc 값에서 인덱스 키를 추출합니다.
+// 여러 개를 반환하면 값은 각 인덱스 키로 모두 조회됩니다. 빈 문자열은 무시됩니다.
+type IndexFunc func(key string, value []byte) []string
+
+// index 인덱스 키에서 기본 키 집합으로의 보조 인덱스입니다.
+type index struct {
+	fn      IndexFunc
+	entries map[string]map[string]struct{}
+	keys    map[string][]string // 기본 키 -> 인덱스 키 (삭제 시 사용)
+}
+
+func newIndex(fn IndexFunc) *index {
+	return &index{
+		fn:      fn,
+		entries: make(map[string]map[string]struct{}),
+		keys:    make(map[string][]string),
+	}
+}
+
+func (idx *index) add(key string, value []byte) {
+	values := idx.extract(key, value)
+	for _, v := range values {
+		if idx.entries[v] == nil {
+			idx.entries[v] = make(map[string]struct{})
+		}
+		idx.entries[v][key] = struct{}{}
+	}
+	idx.keys[key] = values
+}
+
+func (idx *index) remove(key string, _ []byte) {
+	for _, v := range idx.keys[key] {
+		delete(idx.entries[v], key)
+		if len(idx.entries[v]) == 0 {
+			delete(idx.entries, v)
+		}
+	}
+	delete(idx.keys, key)
+}
+
+func (idx *index) extract(key string, value []byte) []string {
+	var values []string
+	seen := make(map[string]struct{})
+	for _, v := range idx.fn(key, value) {
+		if v == "" {
+			continue
+		}
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+	return values
+}
+
+func (idx *index) matches(key string, value []byte, indexValue string) bool {
+	for _, v := range idx.extract(key, value) {
+		if v == indexValue {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,314 @@
+package kv
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+)
+
+// 로그 파일은 배치(frame)의 연속입니다.
+//
+//	frame  = length(uint32) crc(uint32) payload
+//	record = op(byte) uvarint(len) bucket uvarint(len) key [uvarint(len) value]
+//
+// payload는 하나의 트랜잭션에서 발생한 레코드들이며, crc가 맞지 않거나 길이가 모자란
+// 마지막 frame은 커밋되지 않은 것으로 보고 잘라냅니다. 길이가 남은 파일 크기를 넘는 frame도
+// 끊긴 꼬리로 봅니다. 손상된 frame 뒤에 데이터가 남아 있으면 잘라내지 않고 열기를 실패합니다.
+
+const frameHeaderSize = 8
+
+// maxFrameSize frame payload의 최대 크기입니다. 손상된 길이로 큰 메모리를 할당하지 않도록 합니다.
+// 테스트에서 줄일 수 있도록 변수로 둡니다.
+var maxFrameSize = 1 << 30
+
+type opCode byte
+
+const (
+	opPut    opCode = 1
+	opDelete opCode = 2
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+type record struct {
+	op     opCode
+	bucket string
+	key    string
+	value  []byte
+}
+
+func sortRecords(records []record) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].bucket != records[j].bucket {
+			return records[i].bucket < records[j].bucket
+		}
+		return records[i].key < records[j].key
+	})
+}
+
+// logFile 추가 전용 로그 파일입니다.
+type logFile struct {
+	file *os.File
+	size int64
+}
+
+// replay 파일의 모든 frame을 읽어 apply에 전달하고, 기록 도중 끊긴 마지막 frame을 잘라냅니다.
+// 손상된 frame 뒤에 다른 frame이 남아 있으면 커밋된 데이터를 버리지 않도록 잘라내지 않고 에러를 반환합니다.
+func replay(f *os.File, apply func(record)) (*logFile, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	r := bufio.NewReader(f)
+	var offset int64
+
+	for {
+		payload, err := readFrame(r, size-offset)
+		if errors.Is(err, io.EOF) || errors.Is(err, errTornFrame) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("frame at offset %d: %w", offset, err)
+		}
+		end := offset + int64(frameHeaderSize+len(payload))
+		records, err := decodeRecords(payload)
+		if err != nil {
+			// crc가 맞는데 해석할 수 없는 frame도 파일 끝에 있을 때만 끊긴 기록으로 봅니다
+			if end == size {
+				break
+			}
+			return nil, fmt.Errorf("frame at offset %d: %w: %v", offset, errCorruptFrame, err)
+		}
+		for _, rec := range records {
+			apply(rec)
+		}
+		offset = end
+	}
+
+	if err := f.Truncate(offset); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return &logFile{file: f, size: offset}, nil
+}
+
+var (
+	// errTornFrame 파일 끝까지 이어지는 frame이 온전하지 않습니다. 기록 도중 끊긴 것으로 보고 잘라냅니다.
+	errTornFrame = errors.New("torn frame")
+	// errCorruptFrame 뒤에 다른 데이터가 있는 frame이 손상되었습니다.
+	errCorruptFrame = errors.New("corrupt frame")
+)
+
+// readFrame frame 하나를 읽습니다. remaining은 frame 시작 위치부터 남은 파일 크기입니다.
+// 손상된 frame이 파일 끝까지 이어지면 errTornFrame을, 뒤에 데이터가 남아 있으면 errCorruptFrame을 반환합니다.
+func readFrame(r io.Reader, remaining int64) ([]byte, error) {
+	var header [frameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errTornFrame
+		}
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[0:4])
+	checksum := binary.BigEndian.Uint32(header[4:8])
+	available := remaining - frameHeaderSize
+	if int64(length) > available {
+		return nil, errTornFrame
+	}
+	if int64(length) > int64(maxFrameSize) {
+		if int64(length) == available {
+			return nil, errTornFrame
+		}
+		return nil, errCorruptFrame
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errTornFrame
+		}
+		return nil, err
+	}
+	if crc32.Checksum(payload, crcTable) != checksum {
+		if int64(length) == available {
+			return nil, errTornFrame
+		}
+		return nil, errCorruptFrame
+	}
+	return payload, nil
+}
+
+func encodeFrame(records []record) ([]byte, error) {
+	var payload []byte
+	for _, rec := range records {
+		payload = appendRecord(payload, rec)
+	}
+	if len(payload) > maxFrameSize {
+		return nil, ErrFrameTooLarge
+	}
+
+	frame := make([]byte, frameHeaderSize, frameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(frame[4:8], crc32.Checksum(payload, crcTable))
+	return append(frame, payload...), nil
+}
+
+// splitFrames 레코드를 payload가 maxFrameSize를 넘지 않는 묶음으로 나눕니다.
+// 압축처럼 원자성이 필요 없는 쓰기에서 여러 frame으로 나눠 기록할 때 사용합니다.
+func splitFrames(records []record) [][]record {
+	var batches [][]record
+	start, size := 0, 0
+	for i, rec := range records {
+		n := len(appendRecord(nil, rec))
+		if i > start && size+n > maxFrameSize {
+			batches = append(batches, records[start:i])
+			start, size = i, 0
+		}
+		size += n
+	}
+	if start < len(records) {
+		batches = append(batches, records[start:])
+	}
+	return batches
+}
+
+func appendRecord(dst []byte, rec record) []byte {
+	dst = append(dst, byte(rec.op))
+	dst = appendBytes(dst, []byte(rec.bucket))
+	dst = appendBytes(dst, []byte(rec.key))
+	if rec.op == opPut {
+		dst = appendBytes(dst, rec.value)
+	}
+	return dst
+}
+
+func appendBytes(dst, b []byte) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(b)))
+	return append(dst, b...)
+}
+
+func decodeRecords(payload []byte) ([]record, error) {
+	var records []record
+	for len(payload) > 0 {
+		rec := record{op: opCode(payload[0])}
+		payload = payload[1:]
+
+		var bucket, key []byte
+		var err error
+		if bucket, payload, err = readBytes(payload); err != nil {
+			return nil, err
+		}
+		if key, payload, err = readBytes(payload); err != nil {
+			return nil, err
+		}
+		rec.bucket, rec.key = string(bucket), string(key)
+
+		switch rec.op {
+		case opPut:
+			if rec.value, payload, err = readBytes(payload); err != nil {
+				return nil, err
+			}
+		case opDelete:
+		default:
+			return nil, fmt.Errorf("unknown op %d", rec.op)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func readBytes(b []byte) ([]byte, []byte, error) {
+	n, size := binary.Uvarint(b)
+	if size <= 0 || uint64(len(b)-size) < n {
+		return nil, nil, errCorruptFrame
+	}
+	b = b[size:]
+	value := make([]byte, n)
+	copy(value, b[:n])
+	return value, b[n:], nil
+}
+
+// append 레코드를 하나의 frame으로 기록합니다.
+// 기록에 실패하면 파일을 이전 크기로 되돌려 다음 frame이 손상된 꼬리 뒤에 쓰이지 않도록 합니다.
+func (l *logFile) append(records []record, sync bool) error {
+	frame, err := encodeFrame(records)
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(frame); err != nil {
+		l.rollback()
+		return err
+	}
+	if sync {
+		if err := l.file.Sync(); err != nil {
+			l.rollback()
+			return err
+		}
+	}
+	l.size += int64(len(frame))
+	return nil
+}
+
+func (l *logFile) rollback() {
+	if err := l.file.Truncate(l.size); err == nil {
+		_, _ = l.file.Seek(l.size, io.SeekStart)
+	}
+}
+
+// rewrite 레코드만 담긴 새 파일을 만든 뒤 기존 파일과 교체합니다.
+func (l *logFile) rewrite(records []record, sync bool) error {
+	path := l.file.Name()
+	tmpPath := path + ".compact"
+
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	// 살아있는 레코드가 많아도 frame 최대 크기를 넘지 않도록 여러 frame으로 나눠 씁니다
+	var size int64
+	for _, batch := range splitFrames(records) {
+		frame, err := encodeFrame(batch)
+		if err == nil {
+			_, err = tmp.Write(frame)
+		}
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmpPath)
+			return err
+		}
+		size += int64(len(frame))
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	_ = l.file.Close()
+	l.file = tmp
+	l.size = size
+	_, err = l.file.Seek(size, io.SeekStart)
+	if err == nil && sync {
+		err = l.file.Sync()
+	}
+	return err
+}
+
+func (l *logFile) close() error {
+	return l.file.Close()
+}
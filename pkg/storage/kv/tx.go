@@ -0,0 +1,204 @@
+package kv
+
+import "sort"
+
+// write 트랜잭션 안에서 스테이징된 쓰기입니다.
+type write struct {
+	value   []byte
+	deleted bool
+}
+
+// Tx 데이터베이스 트랜잭션입니다. 하나의 고루틴에서만 사용해야 합니다.
+// 트랜잭션 안의 읽기는 자신과 바깥 트랜잭션(세이브포인트의 경우)의 쓰기를 먼저 반영합니다.
+type Tx struct {
+	db       *DB
+	parent   *Tx
+	writable bool
+	writes   map[string]map[string]write
+	closed   bool
+}
+
+func newTx(db *DB, parent *Tx, writable bool) *Tx {
+	return &Tx{
+		db:       db,
+		parent:   parent,
+		writable: writable,
+		writes:   make(map[string]map[string]write),
+	}
+}
+
+func (tx *Tx) close() {
+	tx.closed = true
+}
+
+// Writable 쓰기 가능한 트랜잭션인지 반환합니다.
+func (tx *Tx) Writable() bool {
+	return tx.writable
+}
+
+// Get 버킷에서 키의 값을 조회합니다. 반환된 슬라이스는 수정하면 안 됩니다.
+func (tx *Tx) Get(bucket, key string) ([]byte, bool, error) {
+	if tx.closed {
+		return nil, false, ErrTxClosed
+	}
+
+	for t := tx; t != nil; t = t.parent {
+		if w, ok := t.writes[bucket][key]; ok {
+			if w.deleted {
+				return nil, false, nil
+			}
+			return w.value, true, nil
+		}
+	}
+
+	value, ok := tx.db.buckets[bucket][key]
+	return value, ok, nil
+}
+
+// Put 버킷에 값을 기록합니다.
+func (tx *Tx) Put(bucket, key string, value []byte) error {
+	if err := tx.checkWritable(); err != nil {
+		return err
+	}
+
+	stored := make([]byte, len(value))
+	copy(stored, value)
+	tx.stage(bucket, key, write{value: stored})
+	return nil
+}
+
+// Delete 버킷에서 키를 삭제합니다. 키가 없어도 에러가 아닙니다.
+func (tx *Tx) Delete(bucket, key string) error {
+	if err := tx.checkWritable(); err != nil {
+		return err
+	}
+
+	tx.stage(bucket, key, write{deleted: true})
+	return nil
+}
+
+// ForEach 버킷의 모든 항목을 키 순서대로 순회합니다.
+func (tx *Tx) ForEach(bucket string, fn func(key string, value []byte) error) error {
+	if tx.closed {
+		return ErrTxClosed
+	}
+
+	view := make(map[string][]byte, len(tx.db.buckets[bucket]))
+	for key, value := range tx.db.buckets[bucket] {
+		view[key] = value
+	}
+	for _, t := range tx.chain() {
+		for key, w := range t.writes[bucket] {
+			if w.deleted {
+				delete(view, key)
+			} else {
+				view[key] = w.value
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(view))
+	for key := range view {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := fn(key, view[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Count 버킷의 항목 수를 반환합니다.
+func (tx *Tx) Count(bucket string) (int, error) {
+	n := 0
+	err := tx.ForEach(bucket, func(string, []byte) error {
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// Lookup 보조 인덱스에서 indexValue에 해당하는 키 목록을 키 순서대로 반환합니다.
+// 커밋된 인덱스에 트랜잭션의 스테이징된 쓰기를 반영한 결과입니다.
+func (tx *Tx) Lookup(bucket, name, indexValue string) ([]string, error) {
+	if tx.closed {
+		return nil, ErrTxClosed
+	}
+
+	idx, ok := tx.db.indexes[bucket][name]
+	if !ok {
+		return nil, ErrIndexNotFound
+	}
+
+	result := make(map[string]struct{}, len(idx.entries[indexValue]))
+	for key := range idx.entries[indexValue] {
+		result[key] = struct{}{}
+	}
+	for _, t := range tx.chain() {
+		for key, w := range t.writes[bucket] {
+			delete(result, key)
+			if !w.deleted && idx.matches(key, w.value, indexValue) {
+				result[key] = struct{}{}
+			}
+		}
+	}
+	return sortedKeys(result), nil
+}
+
+func (tx *Tx) checkWritable() error {
+	if tx.closed {
+		return ErrTxClosed
+	}
+	if !tx.writable {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+func (tx *Tx) stage(bucket, key string, w write) {
+	if tx.writes[bucket] == nil {
+		tx.writes[bucket] = make(map[string]write)
+	}
+	tx.writes[bucket][key] = w
+}
+
+// chain 바깥 트랜잭션부터 자신까지의 순서로 트랜잭션 목록을 반환합니다.
+// 이 순서로 덮어써야 안쪽 세이브포인트의 쓰기가 우선합니다.
+func (tx *Tx) chain() []*Tx {
+	var chain []*Tx
+	for t := tx; t != nil; t = t.parent {
+		chain = append([]*Tx{t}, chain...)
+	}
+	return chain
+}
+
+// absorb 세이브포인트의 쓰기를 현재 트랜잭션으로 가져옵니다.
+func (tx *Tx) absorb(child *Tx) {
+	for bucket, writes := range child.writes {
+		for key, w := range writes {
+			tx.stage(bucket, key, w)
+		}
+	}
+}
+
+// records 스테이징된 쓰기를 로그 레코드로 변환합니다.
+func (tx *Tx) records() []record {
+	var records []record
+	for bucket, writes := range tx.writes {
+		for key, w := range writes {
+			if w.deleted {
+				if _, exists := tx.db.buckets[bucket][key]; !exists {
+					continue
+				}
+				records = append(records, record{op: opDelete, bucket: bucket, key: key})
+				continue
+			}
+			records = append(records, record{op: opPut, bucket: bucket, key: key, value: w.value})
+		}
+	}
+	sortRecords(records)
+	return records
+}
@@ -0,0 +1,262 @@
+// Package migrate 버전이 매겨진 SQL 스키마 마이그레이션을 적용합니다.
+//
+// 마이그레이션 파일은 "<버전>_<이름>.up.sql"과 "<버전>_<이름>.down.sql" 쌍으로 작성합니다.
+// 적용 이력은 schema_migrations 테이블에 기록되며, 여러 인스턴스가 동시에 시작해도
+// PostgreSQL advisory lock으로 한 번에 하나의 프로세스만 마이그레이션을 수행합니다.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// DefaultTable 적용 이력을 기록하는 기본 테이블 이름입니다.
+const DefaultTable = "schema_migrations"
+
+// defaultLockID advisory lock 키입니다. 같은 데이터베이스의 마이그레이터끼리만 공유됩니다.
+const defaultLockID int64 = 0x66696e5f72706721
+
+// ErrInvalidMigration 마이그레이션 파일 구성이 올바르지 않을 때 반환됩니다.
+var ErrInvalidMigration = errors.New("migrate: invalid migration")
+
+var filePattern = regexp.MustCompile(`^(\d+)_([a-zA-Z0-9_\-]+)\.(up|down)\.sql$`)
+
+// Migration 하나의 스키마 변경입니다.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load dir 아래의 마이그레이션 파일을 읽어 버전 순으로 반환합니다.
+// 모든 버전에는 up 파일이 있어야 하며, 버전이 중복되면 에러를 반환합니다.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := filePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidMigration, entry.Name(), err)
+		}
+		body, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d has conflicting names %q and %q", ErrInvalidMigration, version, m.Name, match[2])
+		}
+
+		switch match[3] {
+		case "up":
+			m.Up = string(body)
+		case "down":
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("%w: version %d has no up file", ErrInvalidMigration, m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrator 마이그레이션을 데이터베이스에 적용합니다.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	table      string
+	lockID     int64
+}
+
+// Option Migrator 설정 옵션입니다.
+type Option func(*Migrator)
+
+// WithTable 적용 이력 테이블 이름을 지정합니다. 서비스마다 다른 테이블을 쓸 때 사용합니다.
+func WithTable(table string) Option {
+	return func(m *Migrator) {
+		m.table = table
+	}
+}
+
+// WithLockID advisory lock 키를 지정합니다.
+func WithLockID(id int64) Option {
+	return func(m *Migrator) {
+		m.lockID = id
+	}
+}
+
+// New 새로운 Migrator를 생성합니다.
+func New(db *sql.DB, migrations []Migration, opts ...Option) *Migrator {
+	m := &Migrator{
+		db:         db,
+		migrations: migrations,
+		table:      DefaultTable,
+		lockID:     defaultLockID,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Up 적용되지 않은 모든 마이그레이션을 버전 순으로 적용합니다.
+// 각 마이그레이션은 이력 기록과 함께 하나의 트랜잭션에서 실행됩니다.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if migration.Version <= current {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, migration.Up, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down 가장 최근에 적용된 마이그레이션부터 steps개를 되돌립니다. steps가 0 이하이면 모두 되돌립니다.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		reverted := 0
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if steps > 0 && reverted >= steps {
+				break
+			}
+			migration := m.migrations[i]
+			if migration.Version > current {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("%w: version %d has no down file", ErrInvalidMigration, migration.Version)
+			}
+			if err := m.apply(ctx, conn, migration, migration.Down, false); err != nil {
+				return err
+			}
+			reverted++
+		}
+		return nil
+	})
+}
+
+// Version 마지막으로 적용된 마이그레이션 버전을 반환합니다. 적용된 것이 없으면 0입니다.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version int64
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		version, err = m.version(ctx, conn)
+		return err
+	})
+	return version, err
+}
+
+// withLock 전용 커넥션에서 advisory lock을 잡고 fn을 실행합니다.
+// 세션 단위 잠금이므로 잠금과 해제는 반드시 같은 커넥션에서 이루어져야 합니다.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", m.lockID); err != nil {
+		return fmt.Errorf("migrate: acquire lock: %w", err)
+	}
+	defer func() {
+		// 요청 컨텍스트가 취소되어도 잠금은 해제되어야 합니다
+		if _, unlockErr := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", m.lockID); unlockErr != nil && err == nil {
+			err = fmt.Errorf("migrate: release lock: %w", unlockErr)
+		}
+	}()
+
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version    BIGINT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`, m.table))
+	return err
+}
+
+func (m *Migrator) version(ctx context.Context, conn *sql.Conn) (int64, error) {
+	var version sql.NullInt64
+	if err := conn.QueryRowContext(ctx, fmt.Sprintf("SELECT MAX(version) FROM %s", m.table)).Scan(&version); err != nil {
+		return 0, err
+	}
+	return version.Int64, nil
+}
+
+// apply 마이그레이션 하나를 트랜잭션 안에서 실행하고 이력을 갱신합니다.
+// 실패하면 트랜잭션이 롤백되므로 스키마와 이력은 실행 전 상태로 남습니다.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, script string, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		direction := "down"
+		if up {
+			direction = "up"
+		}
+		return fmt.Errorf("migrate: %d_%s %s: %w", migration.Version, migration.Name, direction, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (version, name) VALUES ($1, $2)", m.table), migration.Version, migration.Name)
+	} else {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE version = $1", m.table), migration.Version)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,42 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_add_index.up.sql":       {Data: []byte("CREATE INDEX idx ON assets (user_id);")},
+		"migrations/0002_add_index.down.sql":     {Data: []byte("DROP INDEX idx;")},
+		"migrations/0001_create_assets.up.sql":   {Data: []byte("CREATE TABLE assets (id TEXT);")},
+		"migrations/0001_create_assets.down.sql": {Data: []byte("DROP TABLE assets;")},
+		"migrations/README.md":                   {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys, "migrations")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "create_assets", migrations[0].Name)
+	assert.Equal(t, "CREATE TABLE assets (id TEXT);", migrations[0].Up)
+	assert.Equal(t, "DROP TABLE assets;", migrations[0].Down)
+	assert.Equal(t, int64(2), migrations[1].Version)
+}
+
+func TestLoad_RejectsInvalidMigrations(t *testing.T) {
+	_, err := Load(fstest.MapFS{
+		"m/0001_create.down.sql": {Data: []byte("DROP TABLE t;")},
+	}, "m")
+	assert.ErrorIs(t, err, ErrInvalidMigration)
+
+	_, err = Load(fstest.MapFS{
+		"m/0001_create.up.sql":  {Data: []byte("CREATE TABLE t ();")},
+		"m/0001_other.down.sql": {Data: []byte("DROP TABLE t;")},
+	}, "m")
+	assert.ErrorIs(t, err, ErrInvalidMigration)
+}
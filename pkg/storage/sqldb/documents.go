@@ -0,0 +1,153 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Documents 엔티티를 JSON으로 직렬화해 data 컬럼에 저장하는 테이블입니다.
+// 조회 조건으로 쓰는 값은 키 컬럼과 조회 컬럼에 함께 저장합니다.
+// 임베디드 저장소의 kv.Collection처럼 저장소 구현체가 직렬화와 질의 작성을 반복하지 않도록 도와주며,
+// ctx에 같은 *sql.DB의 트랜잭션이 있으면 그 안에서 실행합니다.
+// 질의는 PostgreSQL 문법($n, ON CONFLICT)을 사용합니다.
+type Documents[T any] struct {
+	db      *sql.DB
+	table   string
+	keys    []string
+	columns []string
+	values  func(entity T) []interface{}
+}
+
+// NewDocuments table에 대한 Documents를 생성합니다.
+// keys는 기본 키 컬럼, columns는 조회용 컬럼이며, values는 엔티티에서 keys와 columns 순서대로 값을 꺼냅니다.
+func NewDocuments[T any](db *sql.DB, table string, keys, columns []string, values func(entity T) []interface{}) *Documents[T] {
+	return &Documents[T]{db: db, table: table, keys: keys, columns: columns, values: values}
+}
+
+func (d *Documents[T]) allColumns() []string {
+	columns := make([]string, 0, len(d.keys)+len(d.columns)+1)
+	columns = append(columns, d.keys...)
+	columns = append(columns, d.columns...)
+	return append(columns, "data")
+}
+
+func (d *Documents[T]) insertSQL() string {
+	columns := d.allColumns()
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s)",
+		d.table, strings.Join(columns, ", "), strings.Join(placeholders, ", "), strings.Join(d.keys, ", "))
+}
+
+// keyCondition 키 컬럼 비교 조건을 $1부터 만듭니다.
+func (d *Documents[T]) keyCondition() string {
+	conditions := make([]string, len(d.keys))
+	for i, key := range d.keys {
+		conditions[i] = fmt.Sprintf("%s = $%d", key, i+1)
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+func (d *Documents[T]) args(entity T) ([]interface{}, error) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	values := d.values(entity)
+	if len(values) != len(d.keys)+len(d.columns) {
+		return nil, fmt.Errorf("%s: expected %d column values, got %d", d.table, len(d.keys)+len(d.columns), len(values))
+	}
+	return append(values, data), nil
+}
+
+// Put 엔티티를 기록합니다. 같은 키의 행이 있으면 덮어씁니다.
+func (d *Documents[T]) Put(ctx context.Context, entity T) error {
+	args, err := d.args(entity)
+	if err != nil {
+		return err
+	}
+	updates := make([]string, 0, len(d.columns)+1)
+	for _, column := range append(append([]string(nil), d.columns...), "data") {
+		updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+	}
+	_, err = Conn(ctx, d.db).ExecContext(ctx, d.insertSQL()+" DO UPDATE SET "+strings.Join(updates, ", "), args...)
+	return err
+}
+
+// Insert 같은 키의 행이 없을 때만 엔티티를 기록하고, 기록했는지 반환합니다.
+func (d *Documents[T]) Insert(ctx context.Context, entity T) (bool, error) {
+	args, err := d.args(entity)
+	if err != nil {
+		return false, err
+	}
+	res, err := Conn(ctx, d.db).ExecContext(ctx, d.insertSQL()+" DO NOTHING", args...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+// Get 키로 엔티티를 조회합니다. 키 값은 키 컬럼 순서대로 전달합니다.
+func (d *Documents[T]) Get(ctx context.Context, key ...interface{}) (T, bool, error) {
+	var zero T
+	var data []byte
+	err := Conn(ctx, d.db).QueryRowContext(ctx,
+		fmt.Sprintf("SELECT data FROM %s WHERE %s", d.table, d.keyCondition()), key...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return zero, false, nil
+	}
+	if err != nil {
+		return zero, false, err
+	}
+	var entity T
+	if err := json.Unmarshal(data, &entity); err != nil {
+		return zero, false, err
+	}
+	return entity, true, nil
+}
+
+// Find where 조건에 맞는 엔티티를 조회합니다. where가 비어 있으면 모든 엔티티를 조회하며,
+// 순서는 보장하지 않으므로 호출자가 정렬합니다.
+func (d *Documents[T]) Find(ctx context.Context, where string, args ...interface{}) ([]T, error) {
+	query := fmt.Sprintf("SELECT data FROM %s", d.table)
+	if where != "" {
+		query += " WHERE " + where
+	}
+	rows, err := Conn(ctx, d.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entities := []T{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var entity T
+		if err := json.Unmarshal(data, &entity); err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+	return entities, rows.Err()
+}
+
+// Delete 키로 엔티티를 삭제하고, 삭제했는지 반환합니다.
+func (d *Documents[T]) Delete(ctx context.Context, key ...interface{}) (bool, error) {
+	res, err := Conn(ctx, d.db).ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE %s", d.table, d.keyCondition()), key...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
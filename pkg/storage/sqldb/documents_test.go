@@ -0,0 +1,70 @@
+package sqldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type document struct {
+	UserID string `json:"userId"`
+	Date   string `json:"date"`
+	Amount int    `json:"amount"`
+}
+
+func newTestDocuments(t *testing.T) (*Documents[*document], *recorder) {
+	db, r := openRecorder(t)
+	docs := NewDocuments(db, "snapshots", []string{"user_id", "date"}, []string{"amount"},
+		func(d *document) []interface{} { return []interface{}{d.UserID, d.Date, d.Amount} })
+	return docs, r
+}
+
+func TestDocuments_WritesWithKeyAndLookupColumns(t *testing.T) {
+	docs, r := newTestDocuments(t)
+	ctx := context.Background()
+	doc := &document{UserID: "user-1", Date: "2024-03-01", Amount: 100}
+
+	require.NoError(t, docs.Put(ctx, doc))
+	inserted, err := docs.Insert(ctx, doc)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+	deleted, err := docs.Delete(ctx, "user-1", "2024-03-01")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	assert.Equal(t, []string{
+		"INSERT INTO snapshots (user_id, date, amount, data) VALUES ($1, $2, $3, $4) ON CONFLICT (user_id, date) DO UPDATE SET amount = EXCLUDED.amount, data = EXCLUDED.data",
+		"INSERT INTO snapshots (user_id, date, amount, data) VALUES ($1, $2, $3, $4) ON CONFLICT (user_id, date) DO NOTHING",
+		"DELETE FROM snapshots WHERE user_id = $1 AND date = $2",
+	}, r.stmts)
+}
+
+func TestDocuments_DecodesRows(t *testing.T) {
+	docs, r := newTestDocuments(t)
+	ctx := context.Background()
+	r.rows = []string{`{"userId":"user-1","date":"2024-03-01","amount":100}`, `{"userId":"user-1","date":"2024-03-02","amount":200}`}
+
+	found, err := docs.Find(ctx, "user_id = $1", "user-1")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, 200, found[1].Amount)
+
+	r.rows = r.rows[:1]
+	doc, ok, err := docs.Get(ctx, "user-1", "2024-03-01")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "2024-03-01", doc.Date)
+
+	r.rows = nil
+	_, ok, err = docs.Get(ctx, "user-1", "2024-03-09")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{
+		"SELECT data FROM snapshots WHERE user_id = $1",
+		"SELECT data FROM snapshots WHERE user_id = $1 AND date = $2",
+		"SELECT data FROM snapshots WHERE user_id = $1 AND date = $2",
+	}, r.stmts)
+}
@@ -0,0 +1,85 @@
+// Package sqldb database/sql 기반 저장소가 공유하는 트랜잭션 헬퍼를 제공합니다.
+//
+// WithTx로 시작한 트랜잭션은 컨텍스트에 실려 전달되므로, 같은 *sql.DB를 쓰는 저장소들은
+// Conn(ctx, db)로 얻은 Querier를 통해 자동으로 같은 트랜잭션에 참여합니다.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Querier *sql.DB와 *sql.Tx가 공통으로 제공하는 질의 메서드입니다.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type txKey struct{}
+
+// txState 컨텍스트에 저장되는 트랜잭션 상태입니다.
+type txState struct {
+	db    *sql.DB
+	tx    *sql.Tx
+	depth int
+}
+
+// Conn ctx에 db의 트랜잭션이 있으면 그 트랜잭션을, 없으면 db를 반환합니다.
+func Conn(ctx context.Context, db *sql.DB) Querier {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok && state.db == db {
+		return state.tx
+	}
+	return db
+}
+
+// InTx ctx가 db의 트랜잭션을 가지고 있는지 반환합니다.
+func InTx(ctx context.Context, db *sql.DB) bool {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	return ok && state.db == db
+}
+
+// WithTx 트랜잭션 안에서 fn을 실행합니다. fn이 에러를 반환하면 롤백하고, 아니면 커밋합니다.
+// 이미 같은 db의 트랜잭션 안에서 호출되면 SAVEPOINT를 만들어 fn의 변경만 되돌릴 수 있게 합니다.
+func WithTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) (err error) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok && state.db == db {
+		return withSavepoint(ctx, state, fn)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, &txState{db: db, tx: tx})); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback: %v)", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func withSavepoint(ctx context.Context, parent *txState, fn func(ctx context.Context) error) error {
+	state := &txState{db: parent.db, tx: parent.tx, depth: parent.depth + 1}
+	name := fmt.Sprintf("sp_%d", state.depth)
+
+	if _, err := parent.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		if _, rbErr := parent.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint: %v)", err, rbErr)
+		}
+		return err
+	}
+	_, err := parent.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
@@ -0,0 +1,138 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder 실행된 문장을 기록하는 최소한의 database/sql 드라이버입니다.
+// 조회 문장은 rows에 준비된 한 컬럼짜리 행을 돌려줍니다.
+type recorder struct {
+	mu    sync.Mutex
+	stmts []string
+	rows  []string
+}
+
+func (r *recorder) record(stmt string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stmts = append(r.stmts, stmt)
+}
+
+func (r *recorder) Open(string) (driver.Conn, error) { return &recorderConn{r: r}, nil }
+
+type recorderConn struct{ r *recorder }
+
+func (c *recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return &recorderStmt{r: c.r, query: query}, nil
+}
+func (c *recorderConn) Close() error { return nil }
+func (c *recorderConn) Begin() (driver.Tx, error) {
+	c.r.record("BEGIN")
+	return &recorderTx{r: c.r}, nil
+}
+
+type recorderTx struct{ r *recorder }
+
+func (t *recorderTx) Commit() error   { t.r.record("COMMIT"); return nil }
+func (t *recorderTx) Rollback() error { t.r.record("ROLLBACK"); return nil }
+
+type recorderStmt struct {
+	r     *recorder
+	query string
+}
+
+func (s *recorderStmt) Close() error  { return nil }
+func (s *recorderStmt) NumInput() int { return -1 }
+func (s *recorderStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.r.record(s.query)
+	return driver.RowsAffected(1), nil
+}
+func (s *recorderStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.r.record(s.query)
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	return &recorderRows{values: append([]string(nil), s.r.rows...)}, nil
+}
+
+type recorderRows struct{ values []string }
+
+func (r *recorderRows) Columns() []string { return []string{"data"} }
+func (r *recorderRows) Close() error      { return nil }
+func (r *recorderRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0] = []byte(r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+var registerOnce sync.Once
+var rec = &recorder{}
+
+func openRecorder(t *testing.T) (*sql.DB, *recorder) {
+	registerOnce.Do(func() { sql.Register("sqldb-recorder", rec) })
+	rec.mu.Lock()
+	rec.stmts = nil
+	rec.rows = nil
+	rec.mu.Unlock()
+
+	db, err := sql.Open("sqldb-recorder", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, rec
+}
+
+func TestWithTx_CommitsAndRollsBack(t *testing.T) {
+	db, r := openRecorder(t)
+	ctx := context.Background()
+
+	err := WithTx(ctx, db, func(ctx context.Context) error {
+		assert.True(t, InTx(ctx, db))
+		_, err := Conn(ctx, db).ExecContext(ctx, "INSERT 1")
+		return err
+	})
+	require.NoError(t, err)
+
+	failure := errors.New("boom")
+	err = WithTx(ctx, db, func(ctx context.Context) error {
+		_, _ = Conn(ctx, db).ExecContext(ctx, "INSERT 2")
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	assert.Equal(t, []string{"BEGIN", "INSERT 1", "COMMIT", "BEGIN", "INSERT 2", "ROLLBACK"}, r.stmts)
+	assert.False(t, InTx(ctx, db))
+}
+
+func TestWithTx_NestedCallsUseSavepoints(t *testing.T) {
+	db, r := openRecorder(t)
+	ctx := context.Background()
+
+	err := WithTx(ctx, db, func(ctx context.Context) error {
+		_ = WithTx(ctx, db, func(ctx context.Context) error {
+			return errors.New("inner failure")
+		})
+		return WithTx(ctx, db, func(ctx context.Context) error {
+			_, err := Conn(ctx, db).ExecContext(ctx, "INSERT 1")
+			return err
+		})
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"BEGIN",
+		"SAVEPOINT sp_1", "ROLLBACK TO SAVEPOINT sp_1",
+		"SAVEPOINT sp_1", "INSERT 1", "RELEASE SAVEPOINT sp_1",
+		"COMMIT",
+	}, r.stmts)
+}
@@ -2,19 +2,27 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
+	"github.com/aske/go_fi_chart/pkg/domain/events"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
 	"github.com/aske/go_fi_chart/services/asset/internal/api"
 	"github.com/aske/go_fi_chart/services/asset/internal/domain"
+	"github.com/aske/go_fi_chart/services/asset/internal/infrastructure/references"
+	"github.com/aske/go_fi_chart/services/asset/internal/infrastructure/store/embedded"
+	"github.com/aske/go_fi_chart/services/asset/internal/infrastructure/store/postgres"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	_ "github.com/lib/pq"
 )
 
 func main() {
@@ -33,10 +41,54 @@ func main() {
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(60 * time.Second))
 
+	// 저장소 설정
+	repo, closeRepo, err := newAssetRepository(os.Getenv("DB_DRIVER"), os.Getenv("DB_PATH"))
+	if err != nil {
+		log.Fatalf("저장소 생성 실패: %v", err)
+	}
+	defer func() {
+		if err := closeRepo(); err != nil {
+			log.Printf("저장소 종료 중 오류 발생: %v", err)
+		}
+	}()
+
+	// 삭제 정책 설정
+	checker, policy, err := newReferenceChecker(os.Getenv("ASSET_DELETION_POLICY"),
+		os.Getenv("TRANSACTION_SERVICE_URL"), os.Getenv("PORTFOLIO_SERVICE_URL"))
+	if err != nil {
+		log.Fatalf("삭제 정책 설정 실패: %v", err)
+	}
+
 	// 핸들러 설정
-	handler := api.NewHandler(domain.NewMemoryAssetRepository())
+	handler := api.NewHandler(repo, api.WithDeletionPolicy(checker, policy))
 	handler.RegisterRoutes(r)
 
+	// 시장 가격 이벤트로 보유 포지션을 다시 평가합니다
+	eventBus := events.NewSimplePublisher()
+	if err := eventBus.Subscribe(domain.EventTypePriceUpdated, domain.NewRevaluer(repo, eventBus)); err != nil {
+		log.Fatalf("가격 이벤트 구독 실패: %v", err)
+	}
+	defer func() {
+		if err := eventBus.Close(); err != nil {
+			log.Printf("이벤트 버스 종료 중 오류 발생: %v", err)
+		}
+	}()
+	api.NewPriceHandler(eventBus).RegisterRoutes(r)
+
+	// 삭제된 자산 영구 삭제 작업 시작
+	retention, err := durationEnv("ASSET_RETENTION", 30*24*time.Hour)
+	if err != nil {
+		log.Fatalf("보존 기간 설정 실패: %v", err)
+	}
+	interval, err := durationEnv("ASSET_PURGE_INTERVAL", time.Hour)
+	if err != nil {
+		log.Fatalf("영구 삭제 주기 설정 실패: %v", err)
+	}
+	purger := domain.NewPurger(repo, retention,
+		domain.WithPurgeInterval(interval),
+		domain.WithReferenceChecker(checker, policy))
+	go purger.Run(serverCtx)
+
 	// 서버 종료 시그널 처리
 	go func() {
 		sigChan := make(chan os.Signal, 1)
@@ -68,3 +120,93 @@ func main() {
 		log.Printf("서버 종료 중 오류 발생: %v", err)
 	}
 }
+
+// newReferenceChecker 삭제 정책과 참조 확인에 사용할 서비스 주소로 ReferenceChecker를 생성합니다.
+// 정책이 비어 있으면 차단 정책을 사용하며, 서비스 주소가 모두 비어 있으면 참조를 확인하지 않습니다.
+func newReferenceChecker(policy, transactionURL, portfolioURL string) (domain.ReferenceChecker, domain.DeletionPolicy, error) {
+	deletionPolicy := domain.DeletionPolicyBlock
+	if policy != "" {
+		deletionPolicy = domain.DeletionPolicy(policy)
+	}
+	if !domain.IsValidDeletionPolicy(deletionPolicy) {
+		return nil, "", fmt.Errorf("지원하지 않는 삭제 정책입니다: %s", policy)
+	}
+	if transactionURL == "" && portfolioURL == "" {
+		return nil, deletionPolicy, nil
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	return references.NewHTTPChecker(transactionURL, portfolioURL, client), deletionPolicy, nil
+}
+
+// durationEnv 환경 변수 값을 time.Duration으로 읽습니다. 값이 없으면 fallback을 반환합니다.
+func durationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s는 0보다 커야 합니다", key)
+	}
+	return d, nil
+}
+
+// newAssetRepository DB_DRIVER 값에 따라 자산 저장소를 생성합니다.
+// "embedded"이면 path의 임베디드 키-값 저장소를, "postgres"이면 DATABASE_URL의 PostgreSQL을,
+// 그 외에는 인메모리 저장소를 사용합니다.
+func newAssetRepository(driver, path string) (domain.AssetRepository, func() error, error) {
+	switch driver {
+	case "embedded":
+		return newEmbeddedAssetRepository(path)
+	case "postgres":
+		return newPostgresAssetRepository(os.Getenv("DATABASE_URL"))
+	default:
+		return domain.NewMemoryAssetRepository(), func() error { return nil }, nil
+	}
+}
+
+func newEmbeddedAssetRepository(path string) (domain.AssetRepository, func() error, error) {
+	if path == "" {
+		path = "data/asset.kv"
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, nil, err
+	}
+
+	db, err := kv.Open(path, &kv.Options{SyncWrites: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, err := embedded.NewAssetRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	return repo, db.Close, nil
+}
+
+// newPostgresAssetRepository PostgreSQL에 연결해 스키마 마이그레이션을 적용한 뒤 저장소를 생성합니다.
+func newPostgresAssetRepository(dsn string) (domain.AssetRepository, func() error, error) {
+	if dsn == "" {
+		return nil, nil, errors.New("DATABASE_URL이 설정되지 않았습니다")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	if err := postgres.Migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	return postgres.NewAssetRepository(db), db.Close, nil
+}
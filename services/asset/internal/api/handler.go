@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	commonerrors "github.com/aske/go_fi_chart/internal/common/errors"
+	"github.com/aske/go_fi_chart/internal/common/repository"
 	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
 	"github.com/aske/go_fi_chart/services/asset/internal/domain"
 	"github.com/go-chi/chi/v5"
@@ -13,14 +15,32 @@ import (
 
 // Handler API 핸들러입니다.
 type Handler struct {
-	assetRepo domain.AssetRepository
+	assetRepo      domain.AssetRepository
+	checker        domain.ReferenceChecker
+	deletionPolicy domain.DeletionPolicy
+}
+
+// HandlerOption 핸들러 설정 옵션입니다.
+type HandlerOption func(*Handler)
+
+// WithDeletionPolicy 자산을 삭제하기 전에 참조를 확인할 checker와 삭제 정책을 지정합니다.
+func WithDeletionPolicy(checker domain.ReferenceChecker, policy domain.DeletionPolicy) HandlerOption {
+	return func(h *Handler) {
+		h.checker = checker
+		h.deletionPolicy = policy
+	}
 }
 
 // NewHandler 새로운 API 핸들러를 생성합니다.
-func NewHandler(assetRepo domain.AssetRepository) *Handler {
-	return &Handler{
-		assetRepo: assetRepo,
+func NewHandler(assetRepo domain.AssetRepository, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		assetRepo:      assetRepo,
+		deletionPolicy: domain.DeletionPolicyBlock,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // RegisterRoutes 라우터에 API 핸들러를 등록합니다.
@@ -31,20 +51,42 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Get("/{id}", h.GetAsset)
 		r.Put("/{id}", h.UpdateAsset)
 		r.Delete("/{id}", h.DeleteAsset)
+		r.Post("/{id}/restore", h.RestoreAsset)
+		r.Put("/{id}/holding", h.SetHolding)
 		r.Get("/types/{type}", h.ListAssetsByType)
 	})
 }
 
 // AssetResponse 자산 응답 구조체
 type AssetResponse struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"userId"`
-	Type      string    `json:"type"`
-	Name      string    `json:"name"`
-	Amount    float64   `json:"amount"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID        string           `json:"id"`
+	UserID    string           `json:"userId"`
+	Type      string           `json:"type"`
+	Name      string           `json:"name"`
+	Amount    float64          `json:"amount"`
+	Currency  string           `json:"currency"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+	DeletedAt *time.Time       `json:"deletedAt,omitempty"`
+	Holding   *HoldingResponse `json:"holding,omitempty"`
+}
+
+// HoldingResponse 보유 포지션 응답 구조체
+type HoldingResponse struct {
+	Symbol         string     `json:"symbol"`
+	Quantity       float64    `json:"quantity"`
+	CostBasis      float64    `json:"costBasis"`
+	Currency       string     `json:"currency"`
+	Price          *float64   `json:"price,omitempty"`
+	PricedAt       *time.Time `json:"pricedAt,omitempty"`
+	UnrealizedGain float64    `json:"unrealizedGain"`
+}
+
+// HoldingRequest 보유 포지션 요청 구조체. 취득 원가의 통화는 자산 통화를 따릅니다.
+type HoldingRequest struct {
+	Symbol    string  `json:"symbol"`
+	Quantity  float64 `json:"quantity"`
+	CostBasis float64 `json:"costBasis"`
 }
 
 // CreateAssetRequest 자산 생성 요청 구조체
@@ -54,6 +96,8 @@ type CreateAssetRequest struct {
 	Name     string  `json:"name"`
 	Amount   float64 `json:"amount"`
 	Currency string  `json:"currency"`
+	// Holding이 있으면 자산은 시장 가격으로 평가되며, 가격이 반영되기 전까지 금액은 취득 원가입니다.
+	Holding *HoldingRequest `json:"holding,omitempty"`
 }
 
 // UpdateAssetRequest 자산 업데이트 요청 구조체
@@ -72,11 +116,15 @@ type ErrorResponse struct {
 
 // API 에러 코드 상수
 const (
-	ErrInvalidRequest = "INVALID_REQUEST"
-	ErrNotFound       = "NOT_FOUND"
-	ErrInternalServer = "INTERNAL_SERVER_ERROR"
+	ErrInvalidRequest     = "INVALID_REQUEST"
+	ErrNotFound           = "NOT_FOUND"
+	ErrInternalServer     = "INTERNAL_SERVER_ERROR"
+	ErrPreconditionFailed = "PRECONDITION_FAILED"
+	ErrConflict           = "CONFLICT"
+	ErrAssetReferenced    = "ASSET_REFERENCED"
 )
 
+// ListAssets 사용자의 자산 목록을 반환합니다. deleted=true이면 삭제된 자산 목록을 반환합니다.
 func (h *Handler) ListAssets(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("userId")
 	if userID == "" {
@@ -84,7 +132,17 @@ func (h *Handler) ListAssets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	assets, err := h.assetRepo.FindByUserID(r.Context(), userID)
+	var assets []*domain.Asset
+	var err error
+	switch r.URL.Query().Get("deleted") {
+	case "", "false":
+		assets, err = h.assetRepo.FindByUserID(r.Context(), userID)
+	case "true":
+		assets, err = h.assetRepo.FindDeletedByUserID(r.Context(), userID)
+	default:
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "deleted는 true 또는 false여야 합니다")
+		return
+	}
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 목록 조회 실패")
 		return
@@ -101,6 +159,8 @@ func (h *Handler) ListAssets(w http.ResponseWriter, r *http.Request) {
 			Currency:  asset.Amount.Currency,
 			CreatedAt: asset.CreatedAt,
 			UpdatedAt: asset.UpdatedAt,
+			Holding:   toHoldingResponse(asset.Holding),
+			DeletedAt: asset.DeletedAt,
 		}
 	}
 
@@ -121,6 +181,17 @@ func (h *Handler) CreateAsset(w http.ResponseWriter, r *http.Request) {
 	}
 
 	asset := domain.NewAsset(req.UserID, domain.AssetType(req.Type), req.Name, money)
+	if req.Holding != nil {
+		holding, err := newHolding(*req.Holding, money.Currency)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
+			return
+		}
+		if err := asset.SetHolding(holding); err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
+			return
+		}
+	}
 	if err := h.assetRepo.Save(r.Context(), asset); err != nil {
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 생성 실패")
 		return
@@ -135,8 +206,10 @@ func (h *Handler) CreateAsset(w http.ResponseWriter, r *http.Request) {
 		Currency:  asset.Amount.Currency,
 		CreatedAt: asset.CreatedAt,
 		UpdatedAt: asset.UpdatedAt,
+		Holding:   toHoldingResponse(asset.Holding),
 	}
 
+	w.Header().Set("ETag", commonerrors.ETag(asset.Version))
 	respondJSON(w, http.StatusCreated, response)
 }
 
@@ -169,8 +242,10 @@ func (h *Handler) GetAsset(w http.ResponseWriter, r *http.Request) {
 		Currency:  asset.Amount.Currency,
 		CreatedAt: asset.CreatedAt,
 		UpdatedAt: asset.UpdatedAt,
+		Holding:   toHoldingResponse(asset.Holding),
 	}
 
+	w.Header().Set("ETag", commonerrors.ETag(asset.Version))
 	respondJSON(w, http.StatusOK, response)
 }
 
@@ -200,6 +275,10 @@ func (h *Handler) UpdateAsset(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if !checkIfMatch(w, r, asset.Version) {
+		return
+	}
+
 	money, err := valueobjects.NewMoney(req.Amount, req.Currency)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
@@ -211,6 +290,10 @@ func (h *Handler) UpdateAsset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.assetRepo.Update(r.Context(), asset); err != nil {
+		if commonerrors.IsVersionConflict(err) {
+			respondError(w, http.StatusPreconditionFailed, ErrPreconditionFailed, "다른 요청이 먼저 자산을 변경했습니다")
+			return
+		}
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 업데이트 실패")
 		return
 	}
@@ -224,8 +307,10 @@ func (h *Handler) UpdateAsset(w http.ResponseWriter, r *http.Request) {
 		Currency:  asset.Amount.Currency,
 		CreatedAt: asset.CreatedAt,
 		UpdatedAt: asset.UpdatedAt,
+		Holding:   toHoldingResponse(asset.Holding),
 	}
 
+	w.Header().Set("ETag", commonerrors.ETag(asset.Version))
 	respondJSON(w, http.StatusOK, response)
 }
 
@@ -250,6 +335,19 @@ func (h *Handler) DeleteAsset(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if !checkIfMatch(w, r, asset.Version) {
+		return
+	}
+
+	if err := domain.CheckDeletable(r.Context(), h.checker, h.deletionPolicy, asset.ID); err != nil {
+		if domain.IsAssetReferenced(err) {
+			respondError(w, http.StatusConflict, ErrAssetReferenced, "거래 또는 포트폴리오가 참조하는 자산은 삭제할 수 없습니다")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 참조 확인 실패")
+		return
+	}
+
 	// 자산 삭제
 	if err := h.assetRepo.Delete(r.Context(), asset.ID); err != nil {
 		var assetNotFoundError domain.AssetNotFoundError
@@ -266,6 +364,45 @@ func (h *Handler) DeleteAsset(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusNoContent, nil)
 }
 
+// RestoreAsset 삭제된 자산을 복원합니다.
+func (h *Handler) RestoreAsset(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "자산 ID가 필요합니다")
+		return
+	}
+
+	asset, err := h.assetRepo.Restore(r.Context(), id)
+	if err != nil {
+		switch {
+		case domain.IsAssetNotFound(err), errors.Is(err, repository.ErrEntityNotFound):
+			respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
+		case domain.IsAssetNotDeleted(err):
+			respondError(w, http.StatusConflict, ErrConflict, "삭제되지 않은 자산입니다")
+		case commonerrors.IsVersionConflict(err):
+			respondError(w, http.StatusConflict, ErrConflict, "다른 요청이 먼저 자산을 변경했습니다")
+		default:
+			respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 복원 실패")
+		}
+		return
+	}
+
+	response := AssetResponse{
+		ID:        asset.ID,
+		UserID:    asset.UserID,
+		Type:      string(asset.Type),
+		Name:      asset.Name,
+		Amount:    asset.Amount.Amount,
+		Currency:  asset.Amount.Currency,
+		CreatedAt: asset.CreatedAt,
+		UpdatedAt: asset.UpdatedAt,
+		Holding:   toHoldingResponse(asset.Holding),
+	}
+
+	w.Header().Set("ETag", commonerrors.ETag(asset.Version))
+	respondJSON(w, http.StatusOK, response)
+}
+
 func (h *Handler) ListAssetsByType(w http.ResponseWriter, r *http.Request) {
 	assetType := chi.URLParam(r, "type")
 	if !domain.IsValidAssetType(domain.AssetType(assetType)) {
@@ -290,12 +427,126 @@ func (h *Handler) ListAssetsByType(w http.ResponseWriter, r *http.Request) {
 			Currency:  asset.Amount.Currency,
 			CreatedAt: asset.CreatedAt,
 			UpdatedAt: asset.UpdatedAt,
+			Holding:   toHoldingResponse(asset.Holding),
 		})
 	}
 
 	respondJSON(w, http.StatusOK, response)
 }
 
+// SetHolding 자산의 보유 포지션을 지정합니다. 본문이 null이면 보유 포지션을 제거합니다.
+func (h *Handler) SetHolding(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "자산 ID가 필요합니다")
+		return
+	}
+
+	var req *HoldingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	asset, err := h.assetRepo.FindByID(r.Context(), id)
+	if err != nil {
+		var assetNotFoundError domain.AssetNotFoundError
+		switch {
+		case errors.As(err, &assetNotFoundError):
+			respondError(w, http.StatusNotFound, ErrNotFound, "자산을 찾을 수 없습니다")
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, ErrInternalServer, "자산 조회 실패")
+			return
+		}
+	}
+
+	if !checkIfMatch(w, r, asset.Version) {
+		return
+	}
+
+	var holding *domain.Holding
+	if req != nil {
+		if holding, err = newHolding(*req, asset.Amount.Currency); err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
+			return
+		}
+	}
+	if err := asset.SetHolding(holding); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
+		return
+	}
+	if err := h.assetRepo.Update(r.Context(), asset); err != nil {
+		if commonerrors.IsVersionConflict(err) {
+			respondError(w, http.StatusPreconditionFailed, ErrPreconditionFailed, "다른 요청이 먼저 자산을 변경했습니다")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "보유 포지션 변경 실패")
+		return
+	}
+
+	response := AssetResponse{
+		ID:        asset.ID,
+		UserID:    asset.UserID,
+		Type:      string(asset.Type),
+		Name:      asset.Name,
+		Amount:    asset.Amount.Amount,
+		Currency:  asset.Amount.Currency,
+		CreatedAt: asset.CreatedAt,
+		UpdatedAt: asset.UpdatedAt,
+		Holding:   toHoldingResponse(asset.Holding),
+	}
+
+	w.Header().Set("ETag", commonerrors.ETag(asset.Version))
+	respondJSON(w, http.StatusOK, response)
+}
+
+// newHolding 요청으로 보유 포지션을 생성합니다.
+func newHolding(req HoldingRequest, currency string) (*domain.Holding, error) {
+	costBasis, err := valueobjects.NewMoney(req.CostBasis, currency)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewHolding(req.Symbol, req.Quantity, costBasis)
+}
+
+// toHoldingResponse 보유 포지션을 응답으로 변환합니다. 보유 포지션이 없으면 nil입니다.
+func toHoldingResponse(holding *domain.Holding) *HoldingResponse {
+	if holding == nil {
+		return nil
+	}
+	response := &HoldingResponse{
+		Symbol:    holding.Symbol,
+		Quantity:  holding.Quantity,
+		CostBasis: holding.CostBasis.Amount,
+		Currency:  holding.CostBasis.Currency,
+	}
+	if holding.IsPriced() {
+		price, pricedAt := holding.Price.Amount, holding.PricedAt
+		response.Price = &price
+		response.PricedAt = &pricedAt
+	}
+	if gain, err := holding.UnrealizedGain(); err == nil {
+		response.UnrealizedGain = gain
+	}
+	return response
+}
+
+// checkIfMatch는 If-Match 헤더가 있으면 현재 버전과 비교합니다.
+// 일치하지 않으면 412 응답을 쓰고 false를 반환합니다.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, version int64) bool {
+	expected, ok, err := commonerrors.IfMatchVersion(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
+		return false
+	}
+	if ok && expected != version {
+		respondError(w, http.StatusPreconditionFailed, ErrPreconditionFailed, "자산 버전이 일치하지 않습니다")
+		return false
+	}
+	return true
+}
+
 // 응답 헬퍼 함수
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/domain/events"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/aske/go_fi_chart/services/asset/internal/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// PriceHandler 데이터 수집 서비스가 전달한 시장 가격을 가격 이벤트로 발행하는 API 핸들러입니다.
+type PriceHandler struct {
+	eventBus events.EventBus
+}
+
+// NewPriceHandler 새로운 가격 API 핸들러를 생성합니다.
+func NewPriceHandler(eventBus events.EventBus) *PriceHandler {
+	return &PriceHandler{eventBus: eventBus}
+}
+
+// RegisterRoutes 라우터에 가격 API를 등록합니다.
+func (h *PriceHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/api/v1/prices", h.PublishPrices)
+}
+
+// PriceRequest 시장 가격 요청 구조체
+type PriceRequest struct {
+	Symbol    string    `json:"symbol"`
+	Price     float64   `json:"price"`
+	Currency  string    `json:"currency"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PublishPrices 시장 가격 목록을 검증한 뒤 가격 이벤트로 발행합니다.
+// 이벤트를 모두 발행하면 202를 반환하며, 자산 평가는 이벤트 핸들러가 수행합니다.
+func (h *PriceHandler) PublishPrices(w http.ResponseWriter, r *http.Request) {
+	var reqs []PriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidRequest, "잘못된 요청 형식")
+		return
+	}
+
+	priceEvents := make([]events.Event, 0, len(reqs))
+	for _, req := range reqs {
+		symbol := strings.TrimSpace(req.Symbol)
+		if symbol == "" {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, "심볼이 필요합니다")
+			return
+		}
+		price, err := valueobjects.NewMoney(req.Price, req.Currency)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, ErrInvalidRequest, err.Error())
+			return
+		}
+		pricedAt := req.Timestamp
+		if pricedAt.IsZero() {
+			pricedAt = time.Now()
+		}
+		priceEvents = append(priceEvents, domain.NewPriceUpdatedEvent(symbol, price, pricedAt))
+	}
+
+	for _, event := range priceEvents {
+		if err := h.eventBus.Publish(r.Context(), event); err != nil {
+			respondError(w, http.StatusInternalServerError, ErrInternalServer, "가격 반영 실패")
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusAccepted, nil)
+}
@@ -27,10 +27,12 @@ type Asset struct {
 	Type      AssetType
 	Name      string
 	Amount    valueobjects.Money
+	Holding   *Holding // 시장 가격으로 평가되는 자산의 보유 포지션, 없으면 Amount가 고정 금액입니다
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	IsDeleted bool
 	DeletedAt *time.Time
+	Version   int64 // 낙관적 잠금 버전, 저장소가 저장/수정할 때 관리합니다
 
 	events []events.Event
 	mu     sync.RWMutex
@@ -83,15 +85,7 @@ func (a *Asset) UpdateAmount(amount valueobjects.Money) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	if a.Amount.Equals(amount) {
-		return
-	}
-
-	prevAmount := a.Amount
-	a.Amount = amount
-	a.UpdatedAt = time.Now()
-
-	a.events = append(a.events, NewAssetAmountChangedEvent(a, prevAmount))
+	a.changeAmount(amount)
 }
 
 // MarkAsDeleted 자산을 삭제 상태로 표시합니다.
@@ -107,6 +101,53 @@ func (a *Asset) MarkAsDeleted() {
 	a.events = append(a.events, NewAssetDeletedEvent(a))
 }
 
+// Restore 삭제된 자산을 복원합니다.
+func (a *Asset) Restore() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.IsDeleted {
+		return NewAssetNotDeletedError(a.ID)
+	}
+
+	a.IsDeleted = false
+	a.DeletedAt = nil
+	a.UpdatedAt = time.Now()
+
+	a.events = append(a.events, NewAssetRestoredEvent(a))
+	return nil
+}
+
+// Clone 이벤트 목록을 포함한 자산의 복사본을 반환합니다.
+// 메모리 저장소는 복사본을 보관해 호출자의 변경이 Update 전에 반영되지 않도록 합니다.
+func (a *Asset) Clone() *Asset {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	clone := &Asset{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		Type:      a.Type,
+		Name:      a.Name,
+		Amount:    a.Amount,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+		IsDeleted: a.IsDeleted,
+		Version:   a.Version,
+		events:    make([]events.Event, len(a.events)),
+	}
+	if a.DeletedAt != nil {
+		deletedAt := *a.DeletedAt
+		clone.DeletedAt = &deletedAt
+	}
+	if a.Holding != nil {
+		holding := *a.Holding
+		clone.Holding = &holding
+	}
+	copy(clone.events, a.events)
+	return clone
+}
+
 // Events 발생한 이벤트 목록을 반환합니다.
 func (a *Asset) Events() []events.Event {
 	a.mu.RLock()
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/aske/go_fi_chart/internal/common/repository"
 )
@@ -23,4 +24,13 @@ type AssetRepository interface {
 	FindByType(ctx context.Context, assetType AssetType, opts ...repository.FindOption) ([]*Asset, error)
 	CountByUserID(ctx context.Context, userID string) (int64, error)
 	CountByType(ctx context.Context, assetType AssetType) (int64, error)
+	// FindBySymbol은 심볼의 보유 포지션을 가진 자산을 모두 조회합니다. 페이지네이션을 적용하지 않습니다.
+	FindBySymbol(ctx context.Context, symbol string) ([]*Asset, error)
+
+	// 삭제된 자산 관리
+	// Delete는 자산을 삭제 상태로 표시하며, 삭제된 자산은 위의 조회 기능에서 제외됩니다.
+	FindDeletedByUserID(ctx context.Context, userID string, opts ...repository.FindOption) ([]*Asset, error)
+	FindDeletedBefore(ctx context.Context, before time.Time) ([]*Asset, error)
+	Restore(ctx context.Context, id string) (*Asset, error)
+	Purge(ctx context.Context, id string) error
 }
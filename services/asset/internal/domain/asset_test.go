@@ -176,3 +176,25 @@ func TestAsset_Associate(t *testing.T) {
 		t.Skip("Money.Multiply 메서드가 에러를 반환하는 상황을 시뮬레이션하기 어려우므로 스킵합니다.")
 	})
 }
+
+func TestAsset_Restore(t *testing.T) {
+	// Given
+	asset := createTestAsset()
+	assert.True(t, IsAssetNotDeleted(asset.Restore()))
+	asset.MarkAsDeleted()
+
+	// When
+	err := asset.Restore()
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, asset.IsDeleted)
+	assert.Nil(t, asset.DeletedAt)
+
+	events := asset.Events()
+	assert.Len(t, events, 3) // Created + Deleted + Restored
+	assert.Equal(t, EventTypeAssetRestored, events[2].EventType())
+	payload, ok := events[2].Payload().(AssetRestoredEvent)
+	assert.True(t, ok)
+	assert.Equal(t, asset.ID, payload.AssetID)
+}
@@ -99,6 +99,62 @@ func NewAssetDeletedError(assetID string) error {
 	return AssetDeletedError{AssetID: assetID}
 }
 
+// AssetNotDeletedError 삭제되지 않은 자산을 복원하거나 영구 삭제하려 할 때 발생하는 에러입니다.
+type AssetNotDeletedError struct {
+	AssetID string
+}
+
+// Error 에러 메시지를 반환합니다.
+func (e AssetNotDeletedError) Error() string {
+	return fmt.Sprintf("삭제되지 않은 자산입니다: %s", e.AssetID)
+}
+
+// Code 에러 코드를 반환합니다.
+func (e AssetNotDeletedError) Code() string {
+	return "ERROR_ASSET_NOT_DELETED"
+}
+
+// StatusCode 에러의 HTTP 상태 코드를 반환합니다.
+func (e AssetNotDeletedError) StatusCode() int {
+	return http.StatusConflict
+}
+
+// NewAssetNotDeletedError는 새로운 AssetNotDeletedError를 생성합니다.
+func NewAssetNotDeletedError(assetID string) error {
+	return AssetNotDeletedError{AssetID: assetID}
+}
+
+// AssetReferencedError 다른 엔티티가 참조하는 자산을 삭제하려 할 때 발생하는 에러입니다.
+type AssetReferencedError struct {
+	AssetID    string
+	References int
+}
+
+// Error 에러 메시지를 반환합니다.
+func (e AssetReferencedError) Error() string {
+	return fmt.Sprintf("자산을 참조하는 거래나 포트폴리오가 %d개 있습니다: %s", e.References, e.AssetID)
+}
+
+// Code 에러 코드를 반환합니다.
+func (e AssetReferencedError) Code() string {
+	return "ERROR_ASSET_REFERENCED"
+}
+
+// StatusCode 에러의 HTTP 상태 코드를 반환합니다.
+func (e AssetReferencedError) StatusCode() int {
+	return http.StatusConflict
+}
+
+// NewAssetReferencedError는 새로운 AssetReferencedError를 생성합니다.
+func NewAssetReferencedError(assetID string, references int) error {
+	return AssetReferencedError{AssetID: assetID, References: references}
+}
+
+// NewAssetVersionConflictError 자산의 요청 버전이 저장된 버전과 다른 에러를 생성합니다.
+func NewAssetVersionConflictError(assetID string, expected, actual int64) error {
+	return commonerrors.NewVersionConflictError("asset", assetID, expected, actual)
+}
+
 // 에러 타입 확인 유틸리티 함수들
 
 // IsAssetNotFound는 주어진 에러가 AssetNotFoundError 타입인지 확인합니다.
@@ -119,6 +175,18 @@ func IsAssetInvalidData(err error) bool {
 	return commonerrors.As(err, &e)
 }
 
+// IsAssetNotDeleted는 주어진 에러가 AssetNotDeletedError 타입인지 확인합니다.
+func IsAssetNotDeleted(err error) bool {
+	var e AssetNotDeletedError
+	return commonerrors.As(err, &e)
+}
+
+// IsAssetReferenced는 주어진 에러가 AssetReferencedError 타입인지 확인합니다.
+func IsAssetReferenced(err error) bool {
+	var e AssetReferencedError
+	return commonerrors.As(err, &e)
+}
+
 // IsAssetDeleted는 주어진 에러가 AssetDeletedError 타입인지 확인합니다.
 func IsAssetDeleted(err error) bool {
 	var e AssetDeletedError
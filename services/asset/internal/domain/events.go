@@ -13,6 +13,8 @@ const (
 	EventTypeAssetUpdated       = "asset.updated"
 	EventTypeAssetDeleted       = "asset.deleted"
 	EventTypeAssetAmountChanged = "asset.amount_changed"
+	EventTypeAssetRestored      = "asset.restored"
+	EventTypePriceUpdated       = "price.updated"
 )
 
 // AssetCreatedEvent는 자산이 생성되었을 때 발생하는 이벤트입니다.
@@ -122,3 +124,54 @@ func NewAssetDeletedEvent(asset *Asset) events.Event {
 		nil,
 	)
 }
+
+// AssetRestoredEvent는 삭제된 자산이 복원되었을 때 발생하는 이벤트입니다.
+type AssetRestoredEvent struct {
+	events.BaseEvent
+	AssetID    string    `json:"assetId"`
+	UserID     string    `json:"userId"`
+	RestoredAt time.Time `json:"restoredAt"`
+}
+
+// NewAssetRestoredEvent는 새로운 AssetRestoredEvent를 생성합니다.
+func NewAssetRestoredEvent(asset *Asset) events.Event {
+	return events.NewEvent(
+		EventTypeAssetRestored,
+		uuid.MustParse(asset.ID),
+		"asset",
+		1,
+		AssetRestoredEvent{
+			AssetID:    asset.ID,
+			UserID:     asset.UserID,
+			RestoredAt: asset.UpdatedAt,
+		},
+		nil,
+	)
+}
+
+// PriceUpdatedEvent는 심볼의 시장 가격이 갱신되었을 때 발생하는 이벤트입니다.
+// 애그리게잇 ID는 심볼에서 결정적으로 만들어지므로 같은 심볼의 가격 이벤트는 같은 애그리게잇에 속합니다.
+type PriceUpdatedEvent struct {
+	events.BaseEvent
+	Symbol   string    `json:"symbol"`
+	Price    float64   `json:"price"`
+	Currency string    `json:"currency"`
+	PricedAt time.Time `json:"pricedAt"`
+}
+
+// NewPriceUpdatedEvent는 새로운 PriceUpdatedEvent를 생성합니다.
+func NewPriceUpdatedEvent(symbol string, price valueobjects.Money, pricedAt time.Time) events.Event {
+	return events.NewEvent(
+		EventTypePriceUpdated,
+		uuid.NewSHA1(uuid.NameSpaceOID, []byte(symbol)),
+		"price",
+		1,
+		PriceUpdatedEvent{
+			Symbol:   symbol,
+			Price:    price.Amount,
+			Currency: price.Currency,
+			PricedAt: pricedAt,
+		},
+		nil,
+	)
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+)
+
+// Holding 수량과 시장 가격으로 평가되는 보유 포지션입니다.
+// 가격이 반영되기 전까지 자산은 취득 원가로 평가됩니다.
+type Holding struct {
+	Symbol    string
+	Quantity  float64
+	CostBasis valueobjects.Money // 보유 수량 전체의 취득 원가
+	Price     valueobjects.Money // 마지막으로 반영된 단위 시장 가격
+	PricedAt  time.Time          // Price가 반영된 시각, 가격이 없으면 zero value
+}
+
+// NewHolding 새로운 보유 포지션을 생성합니다.
+func NewHolding(symbol string, quantity float64, costBasis valueobjects.Money) (*Holding, error) {
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return nil, fmt.Errorf("심볼은 비어있을 수 없습니다")
+	}
+	if quantity < 0 {
+		return nil, fmt.Errorf("수량은 음수가 될 수 없습니다: %f", quantity)
+	}
+	if costBasis.Currency == "" {
+		return nil, fmt.Errorf("취득 원가의 통화는 비어있을 수 없습니다")
+	}
+	return &Holding{Symbol: symbol, Quantity: quantity, CostBasis: costBasis}, nil
+}
+
+// IsPriced 시장 가격이 반영되었는지 확인합니다.
+func (h *Holding) IsPriced() bool {
+	return !h.PricedAt.IsZero()
+}
+
+// MarketValue 현재 평가 금액을 반환합니다. 가격이 없으면 취득 원가입니다.
+func (h *Holding) MarketValue() (valueobjects.Money, error) {
+	if !h.IsPriced() {
+		return h.CostBasis, nil
+	}
+	return h.Price.Multiply(h.Quantity)
+}
+
+// UnrealizedGain 평가 금액에서 취득 원가를 뺀 평가 손익을 반환합니다. 손실이면 음수입니다.
+func (h *Holding) UnrealizedGain() (float64, error) {
+	value, err := h.MarketValue()
+	if err != nil {
+		return 0, err
+	}
+	if value.Currency != h.CostBasis.Currency {
+		return 0, fmt.Errorf("통화가 일치하지 않습니다: %s != %s", value.Currency, h.CostBasis.Currency)
+	}
+	return value.Amount - h.CostBasis.Amount, nil
+}
+
+// SetHolding 자산에 보유 포지션을 지정하고 평가 금액을 다시 계산합니다. nil이면 보유 포지션을 제거하고 금액은 그대로 둡니다.
+func (a *Asset) SetHolding(holding *Holding) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.IsDeleted {
+		return NewAssetDeletedError(a.ID)
+	}
+
+	a.Holding = holding
+	a.UpdatedAt = time.Now()
+	a.events = append(a.events, NewAssetUpdatedEvent(a))
+	if holding == nil {
+		return nil
+	}
+
+	value, err := holding.MarketValue()
+	if err != nil {
+		return NewAssetInvalidDataError(a.ID, err.Error())
+	}
+	a.changeAmount(value)
+	return nil
+}
+
+// Revalue 보유 포지션의 시장 가격을 반영해 평가 금액을 다시 계산합니다.
+// 이미 반영된 가격보다 오래된 가격은 무시하며, 평가 금액이 바뀌었으면 true를 반환합니다.
+func (a *Asset) Revalue(price valueobjects.Money, pricedAt time.Time) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.IsDeleted {
+		return false, NewAssetDeletedError(a.ID)
+	}
+	if a.Holding == nil {
+		return false, NewAssetInvalidDataError(a.ID, "보유 포지션이 없는 자산은 시장 가격으로 평가할 수 없습니다")
+	}
+	if price.Currency != a.Holding.CostBasis.Currency {
+		return false, NewAssetInvalidDataError(a.ID,
+			fmt.Sprintf("가격 통화가 취득 원가 통화와 일치하지 않습니다: %s != %s", price.Currency, a.Holding.CostBasis.Currency))
+	}
+	if a.Holding.IsPriced() && pricedAt.Before(a.Holding.PricedAt) {
+		return false, nil
+	}
+
+	holding := *a.Holding
+	holding.Price = price
+	holding.PricedAt = pricedAt
+	value, err := holding.MarketValue()
+	if err != nil {
+		return false, NewAssetInvalidDataError(a.ID, err.Error())
+	}
+	a.Holding = &holding
+	return a.changeAmount(value), nil
+}
+
+// changeAmount 금액이 바뀌었으면 반영하고 금액 변경 이벤트를 추가합니다. 호출자가 잠금을 가지고 있어야 합니다.
+func (a *Asset) changeAmount(amount valueobjects.Money) bool {
+	if a.Amount.Equals(amount) {
+		return false
+	}
+	prevAmount := a.Amount
+	a.Amount = amount
+	a.UpdatedAt = time.Now()
+	a.events = append(a.events, NewAssetAmountChangedEvent(a, prevAmount))
+	return true
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustMoney(t *testing.T, amount float64, currency string) valueobjects.Money {
+	t.Helper()
+	money, err := valueobjects.NewMoney(amount, currency)
+	require.NoError(t, err)
+	return money
+}
+
+func TestNewHolding(t *testing.T) {
+	holding, err := NewHolding(" TSLA ", 10, mustMoney(t, 1000, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, "TSLA", holding.Symbol)
+	assert.False(t, holding.IsPriced())
+
+	_, err = NewHolding("", 10, mustMoney(t, 1000, "USD"))
+	assert.Error(t, err)
+	_, err = NewHolding("TSLA", -1, mustMoney(t, 1000, "USD"))
+	assert.Error(t, err)
+}
+
+func TestAsset_SetHolding(t *testing.T) {
+	// Given
+	asset := createTestAsset()
+	asset.ClearEvents()
+	holding, err := NewHolding("TSLA", 10, mustMoney(t, 1500, "USD"))
+	require.NoError(t, err)
+
+	// When
+	err = asset.SetHolding(holding)
+
+	// Then: 가격이 반영되기 전에는 취득 원가로 평가됩니다
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, asset.Amount.Amount)
+	events := asset.Events()
+	require.Len(t, events, 2) // Updated + AmountChanged
+	assert.Equal(t, EventTypeAssetUpdated, events[0].EventType())
+	assert.Equal(t, EventTypeAssetAmountChanged, events[1].EventType())
+
+	// 보유 포지션을 제거해도 금액은 유지됩니다
+	require.NoError(t, asset.SetHolding(nil))
+	assert.Nil(t, asset.Holding)
+	assert.Equal(t, 1500.0, asset.Amount.Amount)
+
+	asset.MarkAsDeleted()
+	assert.Error(t, asset.SetHolding(holding))
+}
+
+func TestAsset_Revalue(t *testing.T) {
+	now := time.Now()
+
+	t.Run("시장 가격으로 평가 금액을 다시 계산한다", func(t *testing.T) {
+		// Given
+		asset := createTestAsset()
+		holding, err := NewHolding("TSLA", 10, mustMoney(t, 1000, "USD"))
+		require.NoError(t, err)
+		require.NoError(t, asset.SetHolding(holding))
+		asset.ClearEvents()
+
+		// When
+		changed, err := asset.Revalue(mustMoney(t, 120, "USD"), now)
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, 1200.0, asset.Amount.Amount)
+		assert.True(t, asset.Holding.IsPriced())
+		gain, err := asset.Holding.UnrealizedGain()
+		require.NoError(t, err)
+		assert.Equal(t, 200.0, gain)
+
+		events := asset.Events()
+		require.Len(t, events, 1)
+		payload, ok := events[0].Payload().(AssetAmountChangedEvent)
+		require.True(t, ok)
+		assert.Equal(t, 1000.0, payload.PrevAmount)
+		assert.Equal(t, 1200.0, payload.Amount)
+	})
+
+	t.Run("이전 가격이나 같은 평가 금액은 반영하지 않는다", func(t *testing.T) {
+		// Given
+		asset := createTestAsset()
+		holding, err := NewHolding("TSLA", 10, mustMoney(t, 1000, "USD"))
+		require.NoError(t, err)
+		require.NoError(t, asset.SetHolding(holding))
+		_, err = asset.Revalue(mustMoney(t, 120, "USD"), now)
+		require.NoError(t, err)
+		asset.ClearEvents()
+
+		// When
+		stale, staleErr := asset.Revalue(mustMoney(t, 90, "USD"), now.Add(-time.Minute))
+		same, sameErr := asset.Revalue(mustMoney(t, 120, "USD"), now.Add(time.Minute))
+
+		// Then
+		require.NoError(t, staleErr)
+		require.NoError(t, sameErr)
+		assert.False(t, stale)
+		assert.False(t, same)
+		assert.Equal(t, 1200.0, asset.Amount.Amount)
+		assert.Empty(t, asset.Events())
+	})
+
+	t.Run("보유 포지션이 없거나 통화가 다르면 에러를 반환한다", func(t *testing.T) {
+		asset := createTestAsset()
+		_, err := asset.Revalue(mustMoney(t, 120, "USD"), now)
+		assert.Error(t, err)
+
+		holding, err := NewHolding("TSLA", 10, mustMoney(t, 1000, "USD"))
+		require.NoError(t, err)
+		require.NoError(t, asset.SetHolding(holding))
+		_, err = asset.Revalue(mustMoney(t, 120, "KRW"), now)
+		assert.Error(t, err)
+	})
+}
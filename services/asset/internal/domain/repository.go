@@ -2,16 +2,20 @@ package domain
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/aske/go_fi_chart/internal/common/repository"
 )
 
 // MemoryAssetRepository 인메모리 자산 저장소 구현체입니다.
+// 삭제된 자산은 Purge 전까지 assets에 남지만 인덱스에서는 제거됩니다.
 type MemoryAssetRepository struct {
 	assets      map[string]*Asset
 	userIDIndex map[string]map[string]*Asset    // userID -> assetID -> Asset
 	typeIndex   map[AssetType]map[string]*Asset // AssetType -> assetID -> Asset
+	symbolIndex map[string]map[string]*Asset    // Holding.Symbol -> assetID -> Asset
 	mutex       sync.RWMutex
 }
 
@@ -21,6 +25,7 @@ func NewMemoryAssetRepository() *MemoryAssetRepository {
 		assets:      make(map[string]*Asset),
 		userIDIndex: make(map[string]map[string]*Asset),
 		typeIndex:   make(map[AssetType]map[string]*Asset),
+		symbolIndex: make(map[string]map[string]*Asset),
 	}
 }
 
@@ -37,6 +42,14 @@ func (r *MemoryAssetRepository) updateIndices(asset *Asset) {
 		r.typeIndex[asset.Type] = make(map[string]*Asset)
 	}
 	r.typeIndex[asset.Type][asset.ID] = asset
+
+	// 보유 심볼 인덱스 업데이트
+	if asset.Holding != nil {
+		if _, exists := r.symbolIndex[asset.Holding.Symbol]; !exists {
+			r.symbolIndex[asset.Holding.Symbol] = make(map[string]*Asset)
+		}
+		r.symbolIndex[asset.Holding.Symbol][asset.ID] = asset
+	}
 }
 
 // 인덱스에서 자산을 제거하는 내부 메소드
@@ -60,6 +73,16 @@ func (r *MemoryAssetRepository) removeFromIndices(asset *Asset) {
 			delete(r.typeIndex, asset.Type)
 		}
 	}
+
+	// 보유 심볼 인덱스에서 제거
+	if asset.Holding != nil {
+		if symbolAssets, exists := r.symbolIndex[asset.Holding.Symbol]; exists {
+			delete(symbolAssets, asset.ID)
+			if len(symbolAssets) == 0 {
+				delete(r.symbolIndex, asset.Holding.Symbol)
+			}
+		}
+	}
 }
 
 // Save 자산을 저장합니다.
@@ -71,8 +94,14 @@ func (r *MemoryAssetRepository) Save(_ context.Context, asset *Asset) error {
 		return NewAssetAlreadyExistsError(asset.ID)
 	}
 
-	r.assets[asset.ID] = asset
-	r.updateIndices(asset)
+	if asset.Version == 0 {
+		asset.Version = 1
+	}
+
+	// 호출자가 Update 없이 변경한 내용이 반영되지 않도록 복사본을 보관합니다
+	stored := asset.Clone()
+	r.assets[asset.ID] = stored
+	r.updateIndices(stored)
 	return nil
 }
 
@@ -82,11 +111,11 @@ func (r *MemoryAssetRepository) FindByID(_ context.Context, id string) (*Asset,
 	defer r.mutex.RUnlock()
 
 	asset, exists := r.assets[id]
-	if !exists {
+	if !exists || asset.IsDeleted {
 		return nil, NewAssetNotFoundError(id)
 	}
 
-	return asset, nil
+	return asset.Clone(), nil
 }
 
 // Update 자산을 업데이트합니다.
@@ -95,37 +124,128 @@ func (r *MemoryAssetRepository) Update(_ context.Context, asset *Asset) error {
 	defer r.mutex.Unlock()
 
 	oldAsset, exists := r.assets[asset.ID]
-	if !exists {
+	if !exists || oldAsset.IsDeleted {
 		return NewAssetNotFoundError(asset.ID)
 	}
+	if oldAsset.Version != asset.Version {
+		return NewAssetVersionConflictError(asset.ID, asset.Version, oldAsset.Version)
+	}
 
 	// 인덱스에서 이전 자산 정보 제거
 	r.removeFromIndices(oldAsset)
 
 	// 새 자산 정보로 업데이트
-	r.assets[asset.ID] = asset
-	r.updateIndices(asset)
+	asset.Version++
+	stored := asset.Clone()
+	r.assets[asset.ID] = stored
+	r.updateIndices(stored)
 	return nil
 }
 
-// Delete ID로 자산을 삭제합니다.
+// Delete ID로 자산을 삭제 상태로 표시합니다.
 func (r *MemoryAssetRepository) Delete(_ context.Context, id string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	asset, exists := r.assets[id]
-	if !exists {
+	stored, exists := r.assets[id]
+	if !exists || stored.IsDeleted {
 		return NewAssetNotFoundError(id)
 	}
 
 	// 인덱스에서 제거
-	r.removeFromIndices(asset)
+	r.removeFromIndices(stored)
+
+	asset := stored.Clone()
+	asset.MarkAsDeleted()
+	asset.Version++
+	r.assets[id] = asset
+	return nil
+}
+
+// FindDeletedByUserID 사용자의 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *MemoryAssetRepository) FindDeletedByUserID(_ context.Context, userID string, opts ...repository.FindOption) ([]*Asset, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	options := repository.NewFindOptions()
+	for _, opt := range opts {
+		opt.Apply(options)
+	}
+
+	var assets []*Asset
+	for _, asset := range r.assets {
+		if asset.IsDeleted && asset.UserID == userID {
+			assets = append(assets, asset.Clone())
+		}
+	}
+	sortByDeletedAt(assets)
+	return applyPagination(assets, options), nil
+}
+
+// FindDeletedBefore before 이전에 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *MemoryAssetRepository) FindDeletedBefore(_ context.Context, before time.Time) ([]*Asset, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var assets []*Asset
+	for _, asset := range r.assets {
+		if asset.IsDeleted && asset.DeletedAt != nil && asset.DeletedAt.Before(before) {
+			assets = append(assets, asset.Clone())
+		}
+	}
+	sortByDeletedAt(assets)
+	return assets, nil
+}
+
+// Restore 삭제된 자산을 복원하고 복원된 자산을 반환합니다.
+func (r *MemoryAssetRepository) Restore(_ context.Context, id string) (*Asset, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, exists := r.assets[id]
+	if !exists {
+		return nil, NewAssetNotFoundError(id)
+	}
+
+	asset := stored.Clone()
+	if err := asset.Restore(); err != nil {
+		return nil, err
+	}
+	asset.Version++
+	stored = asset.Clone()
+	r.assets[id] = stored
+	r.updateIndices(stored)
+	return asset, nil
+}
+
+// Purge 삭제된 자산을 영구 삭제합니다.
+func (r *MemoryAssetRepository) Purge(_ context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	asset, exists := r.assets[id]
+	if !exists {
+		return NewAssetNotFoundError(id)
+	}
+	if !asset.IsDeleted {
+		return NewAssetNotDeletedError(id)
+	}
 
-	// 자산 맵에서 제거
 	delete(r.assets, id)
 	return nil
 }
 
+// sortByDeletedAt 자산 목록을 삭제 시각, ID 순으로 정렬합니다.
+func sortByDeletedAt(assets []*Asset) {
+	sort.Slice(assets, func(i, j int) bool {
+		a, b := assets[i], assets[j]
+		if a.DeletedAt != nil && b.DeletedAt != nil && !a.DeletedAt.Equal(*b.DeletedAt) {
+			return a.DeletedAt.Before(*b.DeletedAt)
+		}
+		return a.ID < b.ID
+	})
+}
+
 // applyPagination 자산 목록에 페이지네이션을 적용하는 내부 도우미 함수입니다.
 func applyPagination(assets []*Asset, options *repository.FindOptions) []*Asset {
 	if options.Limit <= 0 {
@@ -162,7 +282,10 @@ func (r *MemoryAssetRepository) FindAll(_ context.Context, opts ...repository.Fi
 	var result []*Asset
 	for _, asset := range r.assets {
 		// 필터링 로직은 향후 구현
-		result = append(result, asset)
+		if asset.IsDeleted {
+			continue
+		}
+		result = append(result, asset.Clone())
 	}
 
 	// 페이지네이션 적용
@@ -175,8 +298,14 @@ func (r *MemoryAssetRepository) Count(_ context.Context, opts ...repository.Find
 	defer r.mutex.RUnlock()
 
 	// 필터링 로직은 향후 구현
-	// 지금은 모든 자산의 개수를 반환
-	return int64(len(r.assets)), nil
+	// 지금은 삭제되지 않은 모든 자산의 개수를 반환
+	var count int64
+	for _, asset := range r.assets {
+		if !asset.IsDeleted {
+			count++
+		}
+	}
+	return count, nil
 }
 
 // FindByUserID 사용자 ID로 자산 목록을 조회합니다.
@@ -194,7 +323,7 @@ func (r *MemoryAssetRepository) FindByUserID(_ context.Context, userID string, o
 	var assets []*Asset
 	if userAssets, exists := r.userIDIndex[userID]; exists {
 		for _, asset := range userAssets {
-			assets = append(assets, asset)
+			assets = append(assets, asset.Clone())
 		}
 	}
 
@@ -202,6 +331,18 @@ func (r *MemoryAssetRepository) FindByUserID(_ context.Context, userID string, o
 	return applyPagination(assets, options), nil
 }
 
+// FindBySymbol 보유 심볼로 자산 목록을 조회합니다.
+func (r *MemoryAssetRepository) FindBySymbol(_ context.Context, symbol string) ([]*Asset, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	assets := make([]*Asset, 0, len(r.symbolIndex[symbol]))
+	for _, asset := range r.symbolIndex[symbol] {
+		assets = append(assets, asset.Clone())
+	}
+	return assets, nil
+}
+
 // FindByType 자산 유형으로 자산 목록을 조회합니다.
 func (r *MemoryAssetRepository) FindByType(_ context.Context, assetType AssetType, opts ...repository.FindOption) ([]*Asset, error) {
 	r.mutex.RLock()
@@ -217,7 +358,7 @@ func (r *MemoryAssetRepository) FindByType(_ context.Context, assetType AssetTyp
 	var assets []*Asset
 	if typeAssets, exists := r.typeIndex[assetType]; exists {
 		for _, asset := range typeAssets {
-			assets = append(assets, asset)
+			assets = append(assets, asset.Clone())
 		}
 	}
 
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// DeletionPolicy 다른 엔티티가 참조하는 자산을 삭제할 때의 처리 방식입니다.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyBlock 참조가 남아 있으면 삭제와 영구 삭제를 거부합니다.
+	DeletionPolicyBlock DeletionPolicy = "block"
+	// DeletionPolicyCascade 삭제를 허용하고, 영구 삭제할 때 참조를 함께 정리합니다.
+	// 보존 기간 안에 복원하면 참조는 그대로 유지됩니다.
+	DeletionPolicyCascade DeletionPolicy = "cascade"
+)
+
+// IsValidDeletionPolicy 지원하는 삭제 정책인지 확인합니다.
+func IsValidDeletionPolicy(policy DeletionPolicy) bool {
+	return policy == DeletionPolicyBlock || policy == DeletionPolicyCascade
+}
+
+// ReferenceChecker 거래, 포트폴리오처럼 다른 서비스가 소유한 엔티티의 자산 참조를 확인하고 정리합니다.
+type ReferenceChecker interface {
+	// References 자산을 참조하는 엔티티 개수를 반환합니다.
+	References(ctx context.Context, assetID string) (int, error)
+	// Release 자산에 대한 참조를 모두 제거합니다.
+	Release(ctx context.Context, assetID string) error
+}
+
+// CheckDeletable 삭제 정책에 따라 자산을 삭제할 수 있는지 확인합니다.
+// checker가 nil이면 참조를 확인하지 않습니다.
+func CheckDeletable(ctx context.Context, checker ReferenceChecker, policy DeletionPolicy, assetID string) error {
+	if checker == nil || policy == DeletionPolicyCascade {
+		return nil
+	}
+	count, err := checker.References(ctx, assetID)
+	if err != nil {
+		return fmt.Errorf("failed to check asset references: %w", err)
+	}
+	if count > 0 {
+		return NewAssetReferencedError(assetID, count)
+	}
+	return nil
+}
+
+// Purger 보존 기간이 지난 삭제된 자산을 영구 삭제하는 백그라운드 작업입니다.
+type Purger struct {
+	repo      AssetRepository
+	retention time.Duration
+	interval  time.Duration
+	checker   ReferenceChecker
+	policy    DeletionPolicy
+	now       func() time.Time
+}
+
+// PurgerOption Purger 설정 옵션입니다.
+type PurgerOption func(*Purger)
+
+// WithPurgeInterval 영구 삭제를 확인하는 주기를 지정합니다. 기본값은 1시간입니다.
+func WithPurgeInterval(interval time.Duration) PurgerOption {
+	return func(p *Purger) {
+		p.interval = interval
+	}
+}
+
+// WithReferenceChecker 영구 삭제 전에 참조를 확인할 checker와 삭제 정책을 지정합니다.
+func WithReferenceChecker(checker ReferenceChecker, policy DeletionPolicy) PurgerOption {
+	return func(p *Purger) {
+		p.checker = checker
+		p.policy = policy
+	}
+}
+
+// WithPurgeClock 현재 시각을 구하는 함수를 지정합니다.
+func WithPurgeClock(now func() time.Time) PurgerOption {
+	return func(p *Purger) {
+		p.now = now
+	}
+}
+
+// NewPurger 삭제 후 retention이 지난 자산을 영구 삭제하는 Purger를 생성합니다.
+func NewPurger(repo AssetRepository, retention time.Duration, opts ...PurgerOption) *Purger {
+	p := &Purger{
+		repo:      repo,
+		retention: retention,
+		interval:  time.Hour,
+		policy:    DeletionPolicyBlock,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Run ctx가 취소될 때까지 주기적으로 PurgeExpired를 실행합니다.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := p.PurgeExpired(ctx); err != nil {
+			log.Printf("삭제된 자산 영구 삭제 실패: %v", err)
+		} else if purged > 0 {
+			log.Printf("삭제된 자산 %d개를 영구 삭제했습니다", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeExpired 보존 기간이 지난 삭제된 자산을 영구 삭제하고 삭제한 개수를 반환합니다.
+// 차단 정책에서 참조가 남아 있는 자산은 건너뛰고, 연쇄 정책에서는 참조를 먼저 정리합니다.
+func (p *Purger) PurgeExpired(ctx context.Context) (int, error) {
+	expired, err := p.repo.FindDeletedBefore(ctx, p.now().Add(-p.retention))
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, asset := range expired {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+		if err := CheckDeletable(ctx, p.checker, p.policy, asset.ID); err != nil {
+			log.Printf("자산 %s 영구 삭제를 건너뜁니다: %v", asset.ID, err)
+			continue
+		}
+		if p.checker != nil && p.policy == DeletionPolicyCascade {
+			if err := p.checker.Release(ctx, asset.ID); err != nil {
+				return purged, fmt.Errorf("failed to release references of asset %s: %w", asset.ID, err)
+			}
+		}
+		if err := p.repo.Purge(ctx, asset.ID); err != nil {
+			if IsAssetNotFound(err) || IsAssetNotDeleted(err) {
+				// 그 사이 다른 요청이 복원했거나 영구 삭제했습니다
+				continue
+			}
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReferenceChecker는 자산별 참조 개수를 메모리에 보관합니다.
+type fakeReferenceChecker struct {
+	refs     map[string]int
+	released []string
+	err      error
+}
+
+func (f *fakeReferenceChecker) References(_ context.Context, assetID string) (int, error) {
+	return f.refs[assetID], f.err
+}
+
+func (f *fakeReferenceChecker) Release(_ context.Context, assetID string) error {
+	f.released = append(f.released, assetID)
+	delete(f.refs, assetID)
+	return nil
+}
+
+// saveDeletedAsset은 자산을 저장한 뒤 삭제 상태로 표시합니다.
+func saveDeletedAsset(t *testing.T, repo AssetRepository) *Asset {
+	t.Helper()
+	asset := createTestAsset()
+	require.NoError(t, repo.Save(context.Background(), asset))
+	require.NoError(t, repo.Delete(context.Background(), asset.ID))
+	return asset
+}
+
+func TestCheckDeletable(t *testing.T) {
+	ctx := context.Background()
+	checker := &fakeReferenceChecker{refs: map[string]int{"referenced": 2}}
+
+	assert.NoError(t, CheckDeletable(ctx, nil, DeletionPolicyBlock, "referenced"))
+	assert.NoError(t, CheckDeletable(ctx, checker, DeletionPolicyBlock, "free"))
+	assert.NoError(t, CheckDeletable(ctx, checker, DeletionPolicyCascade, "referenced"))
+
+	err := CheckDeletable(ctx, checker, DeletionPolicyBlock, "referenced")
+	assert.True(t, IsAssetReferenced(err))
+
+	checker.err = errors.New("unavailable")
+	err = CheckDeletable(ctx, checker, DeletionPolicyBlock, "free")
+	assert.Error(t, err)
+	assert.False(t, IsAssetReferenced(err))
+}
+
+func TestPurger_PurgeExpired(t *testing.T) {
+	later := func() time.Time { return time.Now().Add(48 * time.Hour) }
+
+	t.Run("보존 기간이 지나지 않은 자산은 남겨둔다", func(t *testing.T) {
+		// Given
+		repo := NewMemoryAssetRepository()
+		asset := saveDeletedAsset(t, repo)
+		purger := NewPurger(repo, 24*time.Hour)
+
+		// When
+		purged, err := purger.PurgeExpired(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 0, purged)
+		restored, err := repo.Restore(context.Background(), asset.ID)
+		require.NoError(t, err)
+		assert.False(t, restored.IsDeleted)
+	})
+
+	t.Run("차단 정책에서는 참조된 자산을 건너뛴다", func(t *testing.T) {
+		// Given
+		repo := NewMemoryAssetRepository()
+		referenced := saveDeletedAsset(t, repo)
+		free := saveDeletedAsset(t, repo)
+		checker := &fakeReferenceChecker{refs: map[string]int{referenced.ID: 1}}
+		purger := NewPurger(repo, 24*time.Hour,
+			WithReferenceChecker(checker, DeletionPolicyBlock), WithPurgeClock(later))
+
+		// When
+		purged, err := purger.PurgeExpired(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		assert.True(t, IsAssetNotFound(repo.Purge(context.Background(), free.ID)))
+		assert.NoError(t, repo.Purge(context.Background(), referenced.ID))
+		assert.Empty(t, checker.released)
+	})
+
+	t.Run("연쇄 정책에서는 참조를 정리한 뒤 영구 삭제한다", func(t *testing.T) {
+		// Given
+		repo := NewMemoryAssetRepository()
+		referenced := saveDeletedAsset(t, repo)
+		checker := &fakeReferenceChecker{refs: map[string]int{referenced.ID: 3}}
+		purger := NewPurger(repo, 24*time.Hour,
+			WithReferenceChecker(checker, DeletionPolicyCascade), WithPurgeClock(later))
+
+		// When
+		purged, err := purger.PurgeExpired(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		assert.Equal(t, []string{referenced.ID}, checker.released)
+		deleted, err := repo.FindDeletedByUserID(context.Background(), referenced.UserID)
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+}
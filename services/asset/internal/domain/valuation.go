@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	commonerrors "github.com/aske/go_fi_chart/internal/common/errors"
+	"github.com/aske/go_fi_chart/pkg/domain/events"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+)
+
+// maxRevalueAttempts 버전 충돌이 났을 때 자산을 다시 읽어 평가를 재시도하는 최대 횟수입니다.
+const maxRevalueAttempts = 3
+
+// Revaluer 가격 이벤트를 받아 같은 심볼을 보유한 자산의 평가 금액을 다시 계산하는 이벤트 핸들러입니다.
+// 저장소가 발행하지 않은 금액 변경 이벤트는 eventBus로 발행합니다.
+type Revaluer struct {
+	repo     AssetRepository
+	eventBus events.EventBus
+}
+
+// NewRevaluer 새로운 Revaluer를 생성합니다. eventBus가 nil이면 이벤트를 발행하지 않습니다.
+func NewRevaluer(repo AssetRepository, eventBus events.EventBus) *Revaluer {
+	return &Revaluer{repo: repo, eventBus: eventBus}
+}
+
+// HandlerType 처리하는 이벤트 타입을 반환합니다.
+func (r *Revaluer) HandlerType() string {
+	return EventTypePriceUpdated
+}
+
+// HandleEvent 가격 이벤트로 자산을 다시 평가합니다.
+// 평가에 실패한 자산은 기록만 하고, 다음 가격 이벤트에서 다시 평가됩니다.
+func (r *Revaluer) HandleEvent(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload().(PriceUpdatedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected payload for %s: %T", event.EventType(), event.Payload())
+	}
+	price, err := valueobjects.NewMoney(payload.Price, payload.Currency)
+	if err != nil {
+		return err
+	}
+	if _, err := r.Revalue(ctx, payload.Symbol, price, payload.PricedAt); err != nil {
+		log.Printf("%s 시장 가격으로 자산을 평가하지 못했습니다: %v", payload.Symbol, err)
+	}
+	return nil
+}
+
+// Revalue symbol을 보유한 모든 자산을 price로 다시 평가하고 평가 금액이 바뀐 자산 개수를 반환합니다.
+// 일부 자산의 평가가 실패해도 나머지 자산은 계속 평가하며, 실패한 에러를 모아 반환합니다.
+func (r *Revaluer) Revalue(ctx context.Context, symbol string, price valueobjects.Money, pricedAt time.Time) (int, error) {
+	assets, err := r.repo.FindBySymbol(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	revalued := 0
+	var errs []error
+	for _, asset := range assets {
+		changed, err := r.revalueAsset(ctx, asset, price, pricedAt)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to revalue asset %s: %w", asset.ID, err))
+			continue
+		}
+		if changed {
+			revalued++
+		}
+	}
+	return revalued, errors.Join(errs...)
+}
+
+// revalueAsset 자산 하나를 평가해 저장합니다. 다른 요청이 먼저 자산을 변경했으면 다시 읽어 재시도합니다.
+func (r *Revaluer) revalueAsset(ctx context.Context, asset *Asset, price valueobjects.Money, pricedAt time.Time) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		// 저장소가 보관한 이전 이벤트가 다시 발행되지 않도록 평가 전에 비웁니다
+		asset.ClearEvents()
+		changed, err := asset.Revalue(price, pricedAt)
+		if err != nil || !changed {
+			return false, err
+		}
+
+		err = r.repo.Update(ctx, asset)
+		if err == nil {
+			return true, r.publish(ctx, asset)
+		}
+		if !commonerrors.IsVersionConflict(err) || attempt == maxRevalueAttempts {
+			return false, err
+		}
+		if asset, err = r.repo.FindByID(ctx, asset.ID); err != nil {
+			return false, err
+		}
+	}
+}
+
+// publish 저장소가 발행하지 않고 남겨 둔 이벤트를 발행합니다.
+func (r *Revaluer) publish(ctx context.Context, asset *Asset) error {
+	if r.eventBus == nil {
+		return nil
+	}
+	for _, event := range asset.Events() {
+		if err := r.eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	asset.ClearEvents()
+	return nil
+}
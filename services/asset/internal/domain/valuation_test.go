@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHandler는 받은 이벤트를 기록합니다.
+type recordingHandler struct {
+	eventType string
+	mu        sync.Mutex
+	events    []events.Event
+}
+
+func (h *recordingHandler) HandleEvent(_ context.Context, event events.Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+	return nil
+}
+
+func (h *recordingHandler) HandlerType() string {
+	return h.eventType
+}
+
+// saveHolding은 심볼의 보유 포지션을 가진 자산을 저장합니다.
+func saveHolding(t *testing.T, repo AssetRepository, symbol string, quantity float64) *Asset {
+	t.Helper()
+	asset := createTestAsset()
+	holding, err := NewHolding(symbol, quantity, mustMoney(t, 1000, "USD"))
+	require.NoError(t, err)
+	require.NoError(t, asset.SetHolding(holding))
+	require.NoError(t, repo.Save(context.Background(), asset))
+	return asset
+}
+
+func TestRevaluer_HandleEvent(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewMemoryAssetRepository()
+	tesla := saveHolding(t, repo, "TSLA", 10)
+	other := saveHolding(t, repo, "AAPL", 5)
+	plain := createTestAsset()
+	require.NoError(t, repo.Save(ctx, plain))
+
+	bus := events.NewSimplePublisher()
+	amountChanged := &recordingHandler{eventType: EventTypeAssetAmountChanged}
+	require.NoError(t, bus.Subscribe(EventTypeAssetAmountChanged, amountChanged))
+	require.NoError(t, bus.Subscribe(EventTypePriceUpdated, NewRevaluer(repo, bus)))
+
+	// When
+	err := bus.Publish(ctx, NewPriceUpdatedEvent("TSLA", mustMoney(t, 150, "USD"), time.Now()))
+
+	// Then
+	require.NoError(t, err)
+	found, err := repo.FindByID(ctx, tesla.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, found.Amount.Amount)
+	assert.Equal(t, 150.0, found.Holding.Price.Amount)
+
+	untouched, err := repo.FindByID(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, untouched.Amount.Amount)
+
+	require.Len(t, amountChanged.events, 1)
+	assert.Equal(t, tesla.ID, amountChanged.events[0].Payload().(AssetAmountChangedEvent).AssetID)
+}
+
+func TestRevaluer_Revalue(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryAssetRepository()
+	saveHolding(t, repo, "TSLA", 10)
+	saveHolding(t, repo, "TSLA", 2)
+	revaluer := NewRevaluer(repo, nil)
+
+	revalued, err := revaluer.Revalue(ctx, "TSLA", mustMoney(t, 120, "USD"), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, revalued)
+
+	// 통화가 다른 가격은 자산별 에러로 모아 반환합니다
+	revalued, err = revaluer.Revalue(ctx, "TSLA", mustMoney(t, 120, "KRW"), time.Now())
+	assert.Error(t, err)
+	assert.Equal(t, 0, revalued)
+
+	revalued, err = revaluer.Revalue(ctx, "MSFT", mustMoney(t, 100, "USD"), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, revalued)
+}
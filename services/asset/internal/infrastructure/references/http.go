@@ -0,0 +1,126 @@
+package references
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HTTPChecker는 거래 서비스와 포트폴리오 서비스의 API로 자산 참조를 확인하고 정리하는 domain.ReferenceChecker 구현체입니다.
+// 주소가 비어 있는 서비스는 확인하지 않습니다.
+type HTTPChecker struct {
+	transactionURL string
+	portfolioURL   string
+	client         *http.Client
+}
+
+// NewHTTPChecker는 새로운 HTTPChecker를 생성합니다. client가 nil이면 http.DefaultClient를 사용합니다.
+func NewHTTPChecker(transactionURL, portfolioURL string, client *http.Client) *HTTPChecker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPChecker{
+		transactionURL: strings.TrimSuffix(transactionURL, "/"),
+		portfolioURL:   strings.TrimSuffix(portfolioURL, "/"),
+		client:         client,
+	}
+}
+
+type reference struct {
+	ID string `json:"id"`
+}
+
+// References는 자산을 참조하는 거래와 포트폴리오 개수의 합을 반환합니다.
+func (c *HTTPChecker) References(ctx context.Context, assetID string) (int, error) {
+	transactions, err := c.transactions(ctx, assetID)
+	if err != nil {
+		return 0, err
+	}
+	portfolios, err := c.portfolios(ctx, assetID)
+	if err != nil {
+		return 0, err
+	}
+	return len(transactions) + len(portfolios), nil
+}
+
+// Release는 자산의 거래를 삭제하고 포트폴리오 구성에서 자산을 제거합니다.
+// 이미 삭제된 참조는 무시합니다.
+func (c *HTTPChecker) Release(ctx context.Context, assetID string) error {
+	transactions, err := c.transactions(ctx, assetID)
+	if err != nil {
+		return err
+	}
+	for _, tx := range transactions {
+		if err := c.delete(ctx, c.transactionURL+"/api/v1/transactions/"+url.PathEscape(tx.ID)); err != nil {
+			return err
+		}
+	}
+
+	portfolios, err := c.portfolios(ctx, assetID)
+	if err != nil {
+		return err
+	}
+	for _, p := range portfolios {
+		if err := c.delete(ctx, c.portfolioURL+"/portfolios/"+url.PathEscape(p.ID)+"/assets/"+url.PathEscape(assetID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *HTTPChecker) transactions(ctx context.Context, assetID string) ([]reference, error) {
+	if c.transactionURL == "" {
+		return nil, nil
+	}
+	return c.list(ctx, c.transactionURL+"/api/v1/transactions/asset/"+url.PathEscape(assetID))
+}
+
+func (c *HTTPChecker) portfolios(ctx context.Context, assetID string) ([]reference, error) {
+	if c.portfolioURL == "" {
+		return nil, nil
+	}
+	return c.list(ctx, c.portfolioURL+"/assets/"+url.PathEscape(assetID)+"/portfolios")
+}
+
+func (c *HTTPChecker) list(ctx context.Context, endpoint string) ([]reference, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, endpoint)
+	}
+	var refs []reference
+	if err := json.NewDecoder(resp.Body).Decode(&refs); err != nil {
+		return nil, fmt.Errorf("failed to decode response from %s: %w", endpoint, err)
+	}
+	return refs, nil
+}
+
+func (c *HTTPChecker) delete(ctx context.Context, endpoint string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, endpoint)
+	}
+}
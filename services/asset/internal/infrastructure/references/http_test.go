@@ -0,0 +1,115 @@
+package references
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/services/asset/internal/domain"
+)
+
+// fakeServices는 거래 서비스와 포트폴리오 서비스의 참조 API를 흉내 냅니다.
+type fakeServices struct {
+	mu           sync.Mutex
+	transactions map[string][]string
+	portfolios   map[string][]string
+}
+
+func (f *fakeServices) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/transactions/asset/{assetID}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeRefs(w, f.transactions[r.PathValue("assetID")])
+	})
+	mux.HandleFunc("DELETE /api/v1/transactions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for assetID, ids := range f.transactions {
+			f.transactions[assetID] = without(ids, r.PathValue("id"))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /assets/{assetID}/portfolios", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeRefs(w, f.portfolios[r.PathValue("assetID")])
+	})
+	mux.HandleFunc("DELETE /portfolios/{id}/assets/{assetID}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		assetID := r.PathValue("assetID")
+		f.portfolios[assetID] = without(f.portfolios[assetID], r.PathValue("id"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func writeRefs(w http.ResponseWriter, ids []string) {
+	refs := make([]reference, 0, len(ids))
+	for _, id := range ids {
+		refs = append(refs, reference{ID: id})
+	}
+	_ = json.NewEncoder(w).Encode(refs)
+}
+
+func without(ids []string, id string) []string {
+	result := ids[:0]
+	for _, v := range ids {
+		if v != id {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func TestHTTPChecker_ReferencesAndRelease(t *testing.T) {
+	ctx := context.Background()
+	services := &fakeServices{
+		transactions: map[string][]string{"asset1": {"tx1", "tx2"}},
+		portfolios:   map[string][]string{"asset1": {"p1"}},
+	}
+	server := httptest.NewServer(services.handler())
+	defer server.Close()
+
+	var checker domain.ReferenceChecker = NewHTTPChecker(server.URL, server.URL+"/", server.Client())
+
+	count, err := checker.References(ctx, "asset1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = checker.References(ctx, "asset2")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	require.NoError(t, checker.Release(ctx, "asset1"))
+	count, err = checker.References(ctx, "asset1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestHTTPChecker_SkipsUnconfiguredServices(t *testing.T) {
+	checker := NewHTTPChecker("", "", nil)
+
+	count, err := checker.References(context.Background(), "asset1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.NoError(t, checker.Release(context.Background(), "asset1"))
+}
+
+func TestHTTPChecker_ReturnsErrorOnUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	checker := NewHTTPChecker(server.URL, "", server.Client())
+	_, err := checker.References(context.Background(), "asset1")
+	assert.Error(t, err)
+}
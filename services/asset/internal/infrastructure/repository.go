@@ -35,7 +35,10 @@ func (r *MemoryAssetRepository) Save(ctx context.Context, asset *domain.Asset) e
 		return fmt.Errorf("asset already exists: %s", asset.ID)
 	}
 
-	r.assets[asset.ID] = asset
+	if asset.Version == 0 {
+		asset.Version = 1
+	}
+	r.assets[asset.ID] = storedCopy(asset)
 	r.mu.Unlock()
 
 	// 락 해제 후 이벤트 발행
@@ -59,7 +62,7 @@ func (r *MemoryAssetRepository) FindByID(_ context.Context, id string) (*domain.
 		return nil, fmt.Errorf("asset not found: %s", id)
 	}
 
-	return asset, nil
+	return asset.Clone(), nil
 }
 
 // Update는 자산을 업데이트합니다.
@@ -67,12 +70,18 @@ func (r *MemoryAssetRepository) Update(ctx context.Context, asset *domain.Asset)
 	events := asset.Events()
 
 	r.mu.Lock()
-	if _, exists := r.assets[asset.ID]; !exists {
+	stored, exists := r.assets[asset.ID]
+	if !exists {
 		r.mu.Unlock()
 		return fmt.Errorf("asset not found: %s", asset.ID)
 	}
+	if stored.Version != asset.Version {
+		r.mu.Unlock()
+		return domain.NewAssetVersionConflictError(asset.ID, asset.Version, stored.Version)
+	}
 
-	r.assets[asset.ID] = asset
+	asset.Version++
+	r.assets[asset.ID] = storedCopy(asset)
 	r.mu.Unlock()
 
 	// 락 해제 후 이벤트 발행
@@ -124,7 +133,7 @@ func (r *MemoryAssetRepository) FindAll(_ context.Context, opts ...repository.Fi
 	var result []*domain.Asset
 	for _, asset := range r.assets {
 		// 필터링 로직은 향후 구현
-		result = append(result, asset)
+		result = append(result, asset.Clone())
 	}
 
 	// 페이지네이션 적용
@@ -165,7 +174,7 @@ func (r *MemoryAssetRepository) FindByUserID(_ context.Context, userID string) (
 	var assets []*domain.Asset
 	for _, asset := range r.assets {
 		if asset.UserID == userID {
-			assets = append(assets, asset)
+			assets = append(assets, asset.Clone())
 		}
 	}
 
@@ -180,9 +189,17 @@ func (r *MemoryAssetRepository) FindByType(_ context.Context, assetType domain.A
 	var assets []*domain.Asset
 	for _, asset := range r.assets {
 		if asset.Type == assetType {
-			assets = append(assets, asset)
+			assets = append(assets, asset.Clone())
 		}
 	}
 
 	return assets, nil
 }
+
+// storedCopy는 저장소에 보관할 자산 복사본을 만듭니다.
+// 발행 대상 이벤트는 호출자 쪽 자산에 남기고 복사본에서는 비웁니다.
+func storedCopy(asset *domain.Asset) *domain.Asset {
+	stored := asset.Clone()
+	stored.ClearEvents()
+	return stored
+}
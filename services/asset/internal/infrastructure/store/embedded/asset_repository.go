@@ -0,0 +1,327 @@
+package embedded
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/common/repository"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/aske/go_fi_chart/services/asset/internal/domain"
+)
+
+const (
+	assetBucket = "assets"
+
+	indexUserID = "user_id"
+	indexType   = "type"
+	indexSymbol = "symbol"
+)
+
+var errAssetExists = errors.New("asset already exists")
+
+// AssetRepository는 임베디드 키-값 저장소 기반의 자산 저장소 구현체입니다.
+// 인메모리 저장소와 같은 사용자 ID, 자산 유형 보조 인덱스를 유지합니다.
+// 삭제된 자산은 Purge 전까지 남아 있으며 일반 조회에서는 제외됩니다.
+type AssetRepository struct {
+	assets *kv.Collection[*domain.Asset]
+}
+
+// NewAssetRepository는 새로운 임베디드 자산 저장소를 생성합니다.
+func NewAssetRepository(db *kv.DB) (*AssetRepository, error) {
+	assets := kv.NewCollection[*domain.Asset](db, assetBucket)
+	if err := assets.Index(indexUserID, func(a *domain.Asset) []string { return []string{a.UserID} }); err != nil {
+		return nil, err
+	}
+	if err := assets.Index(indexType, func(a *domain.Asset) []string { return []string{string(a.Type)} }); err != nil {
+		return nil, err
+	}
+	err := assets.Index(indexSymbol, func(a *domain.Asset) []string {
+		if a.Holding == nil {
+			return nil
+		}
+		return []string{a.Holding.Symbol}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AssetRepository{assets: assets}, nil
+}
+
+// Save는 자산을 저장합니다.
+func (r *AssetRepository) Save(ctx context.Context, asset *domain.Asset) error {
+	err := r.assets.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.assets.Exists(tx, asset.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errAssetExists
+		}
+		if asset.Version == 0 {
+			asset.Version = 1
+		}
+		return r.assets.Put(tx, asset.ID, asset)
+	})
+	if errors.Is(err, errAssetExists) {
+		return domain.NewAssetAlreadyExistsError(asset.ID)
+	}
+	return err
+}
+
+// FindByID는 ID로 자산을 조회합니다.
+func (r *AssetRepository) FindByID(ctx context.Context, id string) (*domain.Asset, error) {
+	var asset *domain.Asset
+	var exists bool
+	err := r.assets.View(ctx, func(tx *kv.Tx) error {
+		var err error
+		asset, exists, err = r.assets.Get(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !exists || asset.IsDeleted {
+		return nil, domain.NewAssetNotFoundError(id)
+	}
+	return asset, nil
+}
+
+// Update는 자산을 업데이트합니다.
+// 저장된 버전이 asset.Version과 다르면 버전 충돌 에러를 반환하고, 성공하면 버전을 1 증가시킵니다.
+func (r *AssetRepository) Update(ctx context.Context, asset *domain.Asset) error {
+	expected := asset.Version
+	var exists bool
+	err := r.assets.Update(ctx, func(tx *kv.Tx) error {
+		stored, found, err := r.assets.Get(tx, asset.ID)
+		if exists = found && !stored.IsDeleted; err != nil || !exists {
+			return err
+		}
+		if stored.Version != expected {
+			return domain.NewAssetVersionConflictError(asset.ID, expected, stored.Version)
+		}
+		asset.Version = expected + 1
+		return r.assets.Put(tx, asset.ID, asset)
+	})
+	if err != nil {
+		asset.Version = expected
+		return err
+	}
+	if !exists {
+		return domain.NewAssetNotFoundError(asset.ID)
+	}
+	return nil
+}
+
+// Delete는 ID로 자산을 삭제 상태로 표시합니다.
+func (r *AssetRepository) Delete(ctx context.Context, id string) error {
+	var exists bool
+	err := r.assets.Update(ctx, func(tx *kv.Tx) error {
+		asset, found, err := r.assets.Get(tx, id)
+		if exists = found && !asset.IsDeleted; err != nil || !exists {
+			return err
+		}
+		asset.MarkAsDeleted()
+		asset.Version++
+		return r.assets.Put(tx, id, asset)
+	})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.NewAssetNotFoundError(id)
+	}
+	return nil
+}
+
+// FindDeletedByUserID는 사용자의 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *AssetRepository) FindDeletedByUserID(ctx context.Context, userID string, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	var assets []*domain.Asset
+	err := r.assets.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.assets.Lookup(tx, indexUserID, userID)
+		assets = filterDeleted(found, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortByDeletedAt(assets)
+	return applyPagination(assets, findOptions(opts)), nil
+}
+
+// FindDeletedBefore는 before 이전에 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *AssetRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Asset, error) {
+	var assets []*domain.Asset
+	err := r.assets.View(ctx, func(tx *kv.Tx) error {
+		all, err := r.assets.All(tx)
+		for _, asset := range filterDeleted(all, true) {
+			if asset.DeletedAt != nil && asset.DeletedAt.Before(before) {
+				assets = append(assets, asset)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortByDeletedAt(assets)
+	return assets, nil
+}
+
+// Restore는 삭제된 자산을 복원하고 복원된 자산을 반환합니다.
+func (r *AssetRepository) Restore(ctx context.Context, id string) (*domain.Asset, error) {
+	var asset *domain.Asset
+	var exists bool
+	err := r.assets.Update(ctx, func(tx *kv.Tx) error {
+		var err error
+		if asset, exists, err = r.assets.Get(tx, id); err != nil || !exists {
+			return err
+		}
+		if err := asset.Restore(); err != nil {
+			return err
+		}
+		asset.Version++
+		return r.assets.Put(tx, id, asset)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.NewAssetNotFoundError(id)
+	}
+	return asset, nil
+}
+
+// Purge는 삭제된 자산을 영구 삭제합니다.
+func (r *AssetRepository) Purge(ctx context.Context, id string) error {
+	var exists bool
+	err := r.assets.Update(ctx, func(tx *kv.Tx) error {
+		asset, found, err := r.assets.Get(tx, id)
+		if exists = found; err != nil || !exists {
+			return err
+		}
+		if !asset.IsDeleted {
+			return domain.NewAssetNotDeletedError(id)
+		}
+		return r.assets.Delete(tx, id)
+	})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.NewAssetNotFoundError(id)
+	}
+	return nil
+}
+
+// FindAll은 모든 자산을 ID 순서로 조회합니다. 옵션으로 페이지네이션을 적용할 수 있습니다.
+func (r *AssetRepository) FindAll(ctx context.Context, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	var assets []*domain.Asset
+	err := r.assets.View(ctx, func(tx *kv.Tx) error {
+		all, err := r.assets.All(tx)
+		assets = filterDeleted(all, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return applyPagination(assets, findOptions(opts)), nil
+}
+
+// Count는 삭제되지 않은 자산의 총 개수를 반환합니다.
+func (r *AssetRepository) Count(ctx context.Context, _ ...repository.FindOption) (int64, error) {
+	assets, err := r.FindAll(ctx)
+	return int64(len(assets)), err
+}
+
+// FindByUserID는 사용자 ID 인덱스로 자산 목록을 조회합니다.
+func (r *AssetRepository) FindByUserID(ctx context.Context, userID string, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	assets, err := r.lookup(ctx, indexUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return applyPagination(assets, findOptions(opts)), nil
+}
+
+// FindByType은 자산 유형 인덱스로 자산 목록을 조회합니다.
+func (r *AssetRepository) FindByType(ctx context.Context, assetType domain.AssetType, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	assets, err := r.lookup(ctx, indexType, string(assetType))
+	if err != nil {
+		return nil, err
+	}
+	return applyPagination(assets, findOptions(opts)), nil
+}
+
+// FindBySymbol은 보유 심볼 인덱스로 자산 목록을 조회합니다.
+func (r *AssetRepository) FindBySymbol(ctx context.Context, symbol string) ([]*domain.Asset, error) {
+	return r.lookup(ctx, indexSymbol, symbol)
+}
+
+// CountByUserID는 사용자 ID에 해당하는 자산 개수를 반환합니다.
+func (r *AssetRepository) CountByUserID(ctx context.Context, userID string) (int64, error) {
+	assets, err := r.lookup(ctx, indexUserID, userID)
+	return int64(len(assets)), err
+}
+
+// CountByType은 자산 유형에 해당하는 자산 개수를 반환합니다.
+func (r *AssetRepository) CountByType(ctx context.Context, assetType domain.AssetType) (int64, error) {
+	assets, err := r.lookup(ctx, indexType, string(assetType))
+	return int64(len(assets)), err
+}
+
+func (r *AssetRepository) lookup(ctx context.Context, index, value string) ([]*domain.Asset, error) {
+	var assets []*domain.Asset
+	err := r.assets.View(ctx, func(tx *kv.Tx) error {
+		found, err := r.assets.Lookup(tx, index, value)
+		assets = filterDeleted(found, false)
+		return err
+	})
+	return assets, err
+}
+
+// filterDeleted는 삭제 여부가 deleted와 같은 자산만 남깁니다.
+func filterDeleted(assets []*domain.Asset, deleted bool) []*domain.Asset {
+	result := make([]*domain.Asset, 0, len(assets))
+	for _, asset := range assets {
+		if asset.IsDeleted == deleted {
+			result = append(result, asset)
+		}
+	}
+	return result
+}
+
+// sortByDeletedAt는 자산 목록을 삭제 시각, ID 순으로 정렬합니다.
+func sortByDeletedAt(assets []*domain.Asset) {
+	sort.SliceStable(assets, func(i, j int) bool {
+		a, b := assets[i], assets[j]
+		if a.DeletedAt != nil && b.DeletedAt != nil && !a.DeletedAt.Equal(*b.DeletedAt) {
+			return a.DeletedAt.Before(*b.DeletedAt)
+		}
+		return a.ID < b.ID
+	})
+}
+
+func findOptions(opts []repository.FindOption) *repository.FindOptions {
+	options := repository.NewFindOptions()
+	for _, opt := range opts {
+		opt.Apply(options)
+	}
+	return options
+}
+
+// applyPagination 자산 목록에 Offset과 Limit을 적용합니다.
+func applyPagination(assets []*domain.Asset, options *repository.FindOptions) []*domain.Asset {
+	if options.Limit <= 0 {
+		return assets
+	}
+	if options.Offset >= len(assets) {
+		return []*domain.Asset{}
+	}
+
+	end := options.Offset + options.Limit
+	if end > len(assets) {
+		end = len(assets)
+	}
+	return assets[options.Offset:end]
+}
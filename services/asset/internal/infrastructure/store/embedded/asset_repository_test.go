@@ -0,0 +1,202 @@
+package embedded
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/aske/go_fi_chart/internal/common/errors"
+	"github.com/aske/go_fi_chart/internal/common/repository"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/aske/go_fi_chart/services/asset/internal/domain"
+)
+
+func newTestAsset(t *testing.T, userID string, assetType domain.AssetType) *domain.Asset {
+	t.Helper()
+	amount, err := valueobjects.NewMoney(1000.0, "KRW")
+	require.NoError(t, err)
+	return domain.NewAsset(userID, assetType, "테스트 자산", amount)
+}
+
+func TestAssetRepository_PersistsAcrossRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "asset.kv")
+
+	db, err := kv.Open(path, nil)
+	require.NoError(t, err)
+	repo, err := NewAssetRepository(db)
+	require.NoError(t, err)
+
+	asset := newTestAsset(t, "user1", domain.Stock)
+	require.NoError(t, repo.Save(ctx, asset))
+	require.NoError(t, db.Close())
+
+	db, err = kv.Open(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	repo, err = NewAssetRepository(db)
+	require.NoError(t, err)
+
+	found, err := repo.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, asset.Name, found.Name)
+	assert.Equal(t, asset.Amount, found.Amount)
+
+	count, err := repo.CountByUserID(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestAssetRepository_IndexesAndPagination(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewAssetRepository(kv.OpenMemory())
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, repo.Save(ctx, newTestAsset(t, "user1", domain.Stock)))
+	}
+	cash := newTestAsset(t, "user2", domain.Cash)
+	require.NoError(t, repo.Save(ctx, cash))
+
+	byUser, err := repo.FindByUserID(ctx, "user1", repository.WithLimit(2))
+	require.NoError(t, err)
+	assert.Len(t, byUser, 2)
+
+	cash.Type = domain.Bond
+	require.NoError(t, repo.Update(ctx, cash))
+	count, err := repo.CountByType(ctx, domain.Cash)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	count, err = repo.CountByType(ctx, domain.Bond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, repo.Delete(ctx, cash.ID))
+	total, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+}
+
+func TestAssetRepository_Errors(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewAssetRepository(kv.OpenMemory())
+	require.NoError(t, err)
+	asset := newTestAsset(t, "user1", domain.Stock)
+	require.NoError(t, repo.Save(ctx, asset))
+
+	assert.Error(t, repo.Save(ctx, asset))
+	_, err = repo.FindByID(ctx, "missing")
+	assert.Error(t, err)
+	assert.Error(t, repo.Update(ctx, newTestAsset(t, "user1", domain.Stock)))
+	assert.Error(t, repo.Delete(ctx, "missing"))
+}
+
+func TestAssetRepository_UpdateRejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewAssetRepository(kv.OpenMemory())
+	require.NoError(t, err)
+
+	asset := newTestAsset(t, "user1", domain.Stock)
+	require.NoError(t, repo.Save(ctx, asset))
+	assert.Equal(t, int64(1), asset.Version)
+
+	first, err := repo.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+
+	first.Name = "첫 번째 변경"
+	require.NoError(t, repo.Update(ctx, first))
+	assert.Equal(t, int64(2), first.Version)
+
+	second.Name = "두 번째 변경"
+	err = repo.Update(ctx, second)
+	assert.True(t, commonerrors.IsVersionConflict(err))
+	assert.Equal(t, int64(1), second.Version)
+
+	found, err := repo.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "첫 번째 변경", found.Name)
+}
+
+func TestAssetRepository_SoftDeleteRestorePurge(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewAssetRepository(kv.OpenMemory())
+	require.NoError(t, err)
+
+	asset := newTestAsset(t, "user1", domain.Stock)
+	require.NoError(t, repo.Save(ctx, asset))
+	require.NoError(t, repo.Delete(ctx, asset.ID))
+	assert.True(t, domain.IsAssetNotFound(repo.Delete(ctx, asset.ID)))
+
+	_, err = repo.FindByID(ctx, asset.ID)
+	assert.True(t, domain.IsAssetNotFound(err))
+	count, err := repo.CountByUserID(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	deleted, err := repo.FindDeletedByUserID(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.NotNil(t, deleted[0].DeletedAt)
+
+	expired, err := repo.FindDeletedBefore(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, expired, 1)
+	expired, err = repo.FindDeletedBefore(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+
+	restored, err := repo.Restore(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.False(t, restored.IsDeleted)
+	assert.Equal(t, int64(3), restored.Version)
+	assert.True(t, domain.IsAssetNotDeleted(repo.Purge(ctx, asset.ID)))
+	_, err = repo.Restore(ctx, asset.ID)
+	assert.True(t, domain.IsAssetNotDeleted(err))
+
+	require.NoError(t, repo.Delete(ctx, asset.ID))
+	require.NoError(t, repo.Purge(ctx, asset.ID))
+	assert.True(t, domain.IsAssetNotFound(repo.Purge(ctx, asset.ID)))
+	_, err = repo.Restore(ctx, "missing")
+	assert.True(t, domain.IsAssetNotFound(err))
+}
+
+func TestAssetRepository_FindBySymbol(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewAssetRepository(kv.OpenMemory())
+	require.NoError(t, err)
+
+	asset := newTestAsset(t, "user1", domain.Stock)
+	costBasis, err := valueobjects.NewMoney(500, "KRW")
+	require.NoError(t, err)
+	holding, err := domain.NewHolding("005930", 10, costBasis)
+	require.NoError(t, err)
+	require.NoError(t, asset.SetHolding(holding))
+	require.NoError(t, repo.Save(ctx, asset))
+	require.NoError(t, repo.Save(ctx, newTestAsset(t, "user1", domain.Stock)))
+
+	price, err := valueobjects.NewMoney(70, "KRW")
+	require.NoError(t, err)
+	_, err = asset.Revalue(price, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(ctx, asset))
+
+	found, err := repo.FindBySymbol(ctx, "005930")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, 700.0, found[0].Amount.Amount)
+	assert.Equal(t, 70.0, found[0].Holding.Price.Amount)
+	assert.True(t, found[0].Holding.IsPriced())
+
+	require.NoError(t, found[0].SetHolding(nil))
+	require.NoError(t, repo.Update(ctx, found[0]))
+	found, err = repo.FindBySymbol(ctx, "005930")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
@@ -3,7 +3,9 @@ package memory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/aske/go_fi_chart/internal/common/repository"
 	"github.com/aske/go_fi_chart/pkg/domain/events"
@@ -39,7 +41,7 @@ func (r *AssetRepository) FindByID(_ context.Context, id string) (*domain.Asset,
 		return nil, fmt.Errorf("%w: asset is deleted", repository.ErrEntityNotFound)
 	}
 
-	return asset, nil
+	return asset.Clone(), nil
 }
 
 // FindAll은 모든 자산을 조회합니다. 옵션을 통해 필터링, 정렬, 페이지네이션을 적용할 수 있습니다.
@@ -62,7 +64,7 @@ func (r *AssetRepository) FindAll(_ context.Context, opts ...repository.FindOpti
 
 		// 필터링 적용
 		if r.matchesFilters(asset, options.Filters) {
-			result = append(result, asset)
+			result = append(result, asset.Clone())
 		}
 	}
 
@@ -100,6 +102,10 @@ func (r *AssetRepository) matchesFilters(asset *domain.Asset, filters map[string
 			if asset.Type != value.(domain.AssetType) {
 				return false
 			}
+		case "symbol":
+			if asset.Holding == nil || asset.Holding.Symbol != value.(string) {
+				return false
+			}
 		}
 	}
 	return true
@@ -115,7 +121,10 @@ func (r *AssetRepository) Save(ctx context.Context, asset *domain.Asset) error {
 		return fmt.Errorf("%w: asset already exists", repository.ErrDuplicateEntity)
 	}
 
-	r.assets[asset.ID] = asset
+	if asset.Version == 0 {
+		asset.Version = 1
+	}
+	r.assets[asset.ID] = storedCopy(asset)
 	r.mu.Unlock()
 
 	// 락 해제 후 이벤트 발행
@@ -139,12 +148,18 @@ func (r *AssetRepository) Update(ctx context.Context, asset *domain.Asset) error
 		return fmt.Errorf("%w: asset not found", repository.ErrEntityNotFound)
 	}
 
-	if r.assets[asset.ID].IsDeleted {
+	stored := r.assets[asset.ID]
+	if stored.IsDeleted {
 		r.mu.Unlock()
 		return fmt.Errorf("%w: asset is deleted", repository.ErrEntityNotFound)
 	}
+	if stored.Version != asset.Version {
+		r.mu.Unlock()
+		return domain.NewAssetVersionConflictError(asset.ID, asset.Version, stored.Version)
+	}
 
-	r.assets[asset.ID] = asset
+	asset.Version++
+	r.assets[asset.ID] = storedCopy(asset)
 	r.mu.Unlock()
 
 	// 락 해제 후 이벤트 발행
@@ -174,10 +189,13 @@ func (r *AssetRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	asset.MarkAsDeleted()
+	asset.Version++
+	events := asset.Events()
+	asset.ClearEvents()
 	r.mu.Unlock()
 
 	// 이벤트 발행
-	for _, event := range asset.Events() {
+	for _, event := range events {
 		if err := r.eventBus.Publish(ctx, event); err != nil {
 			return fmt.Errorf("failed to publish event: %w", err)
 		}
@@ -186,6 +204,98 @@ func (r *AssetRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// FindDeletedByUserID는 사용자의 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *AssetRepository) FindDeletedByUserID(_ context.Context, userID string, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	options := repository.NewFindOptions()
+	for _, opt := range opts {
+		opt.Apply(options)
+	}
+
+	var result []*domain.Asset
+	for _, asset := range r.assets {
+		if asset.IsDeleted && asset.UserID == userID {
+			result = append(result, asset.Clone())
+		}
+	}
+	sortByDeletedAt(result)
+
+	if options.Limit > 0 {
+		if options.Offset >= len(result) {
+			return []*domain.Asset{}, nil
+		}
+		end := options.Offset + options.Limit
+		if end > len(result) {
+			end = len(result)
+		}
+		return result[options.Offset:end], nil
+	}
+	return result, nil
+}
+
+// FindDeletedBefore는 before 이전에 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *AssetRepository) FindDeletedBefore(_ context.Context, before time.Time) ([]*domain.Asset, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Asset
+	for _, asset := range r.assets {
+		if asset.IsDeleted && asset.DeletedAt != nil && asset.DeletedAt.Before(before) {
+			result = append(result, asset.Clone())
+		}
+	}
+	sortByDeletedAt(result)
+	return result, nil
+}
+
+// Restore는 삭제된 자산을 복원하고 복원 이벤트를 발행합니다.
+func (r *AssetRepository) Restore(ctx context.Context, id string) (*domain.Asset, error) {
+	r.mu.Lock()
+	stored, exists := r.assets[id]
+	if !exists {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w: asset not found", repository.ErrEntityNotFound)
+	}
+
+	asset := stored.Clone()
+	if err := asset.Restore(); err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	asset.Version++
+	r.assets[id] = storedCopy(asset)
+	r.mu.Unlock()
+
+	// 락 해제 후 이벤트 발행
+	for _, event := range asset.Events() {
+		if err := r.eventBus.Publish(ctx, event); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	asset.ClearEvents()
+
+	return asset, nil
+}
+
+// Purge는 삭제된 자산을 영구 삭제합니다.
+func (r *AssetRepository) Purge(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	asset, exists := r.assets[id]
+	if !exists {
+		return fmt.Errorf("%w: asset not found", repository.ErrEntityNotFound)
+	}
+	if !asset.IsDeleted {
+		return domain.NewAssetNotDeletedError(id)
+	}
+
+	delete(r.assets, id)
+	return nil
+}
+
 // Count는 조건에 맞는 자산의 총 개수를 반환합니다.
 func (r *AssetRepository) Count(_ context.Context, opts ...repository.FindOption) (int64, error) {
 	r.mu.RLock()
@@ -224,6 +334,11 @@ func (r *AssetRepository) FindByUserID(ctx context.Context, userID string, opts
 	return r.FindAll(ctx, opts...)
 }
 
+// FindBySymbol은 보유 심볼로 자산 목록을 조회합니다.
+func (r *AssetRepository) FindBySymbol(ctx context.Context, symbol string) ([]*domain.Asset, error) {
+	return r.FindAll(ctx, repository.WithFilter("symbol", symbol), repository.WithLimit(0))
+}
+
 // FindByType은 자산 유형으로 자산 목록을 조회합니다.
 func (r *AssetRepository) FindByType(ctx context.Context, assetType domain.AssetType, opts ...repository.FindOption) ([]*domain.Asset, error) {
 	// 필터에 자산 유형 추가
@@ -253,3 +368,22 @@ func (r *AssetRepository) CountByType(ctx context.Context, assetType domain.Asse
 	// Count 메서드 호출
 	return r.Count(ctx, filterOpt)
 }
+
+// sortByDeletedAt는 자산 목록을 삭제 시각, ID 순으로 정렬합니다.
+func sortByDeletedAt(assets []*domain.Asset) {
+	sort.Slice(assets, func(i, j int) bool {
+		a, b := assets[i], assets[j]
+		if a.DeletedAt != nil && b.DeletedAt != nil && !a.DeletedAt.Equal(*b.DeletedAt) {
+			return a.DeletedAt.Before(*b.DeletedAt)
+		}
+		return a.ID < b.ID
+	})
+}
+
+// storedCopy는 저장소에 보관할 자산 복사본을 만듭니다.
+// 발행 대상 이벤트는 호출자 쪽 자산에 남기고 복사본에서는 비웁니다.
+func storedCopy(asset *domain.Asset) *domain.Asset {
+	stored := asset.Clone()
+	stored.ClearEvents()
+	return stored
+}
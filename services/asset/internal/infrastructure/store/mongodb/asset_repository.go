@@ -30,10 +30,12 @@ type assetDocument struct {
 	Type      domain.AssetType    `bson:"type"`
 	Name      string              `bson:"name"`
 	Amount    amountDocument      `bson:"amount"`
+	Holding   *holdingDocument    `bson:"holding"`
 	CreatedAt primitive.DateTime  `bson:"created_at"`
 	UpdatedAt primitive.DateTime  `bson:"updated_at"`
 	IsDeleted bool                `bson:"is_deleted"`
 	DeletedAt *primitive.DateTime `bson:"deleted_at,omitempty"`
+	Version   int64               `bson:"version"`
 }
 
 type amountDocument struct {
@@ -41,6 +43,15 @@ type amountDocument struct {
 	Currency string  `bson:"currency"`
 }
 
+// holdingDocument는 보유 포지션 문서입니다. 보유 포지션을 제거하면 null로 저장됩니다.
+type holdingDocument struct {
+	Symbol    string              `bson:"symbol"`
+	Quantity  float64             `bson:"quantity"`
+	CostBasis amountDocument      `bson:"cost_basis"`
+	Price     amountDocument      `bson:"price"`
+	PricedAt  *primitive.DateTime `bson:"priced_at,omitempty"`
+}
+
 // NewAssetRepository는 새로운 MongoDB 자산 저장소를 생성합니다.
 func NewAssetRepository(db *mongo.Database, eventBus events.EventBus) *AssetRepository {
 	return &AssetRepository{
@@ -69,10 +80,17 @@ func (r *AssetRepository) Init(ctx context.Context) error {
 		Options: options.Index().SetBackground(true),
 	}
 
+	// 보유 심볼에 대한 인덱스 생성
+	symbolIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "holding.symbol", Value: 1}},
+		Options: options.Index().SetBackground(true).SetSparse(true),
+	}
+
 	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
 		userIDIndex,
 		assetTypeIndex,
 		isDeletedIndex,
+		symbolIndex,
 	})
 
 	return err
@@ -92,6 +110,7 @@ func toDocument(asset *domain.Asset) assetDocument {
 		CreatedAt: primitive.NewDateTimeFromTime(asset.CreatedAt),
 		UpdatedAt: primitive.NewDateTimeFromTime(asset.UpdatedAt),
 		IsDeleted: asset.IsDeleted,
+		Version:   asset.Version,
 	}
 
 	if asset.DeletedAt != nil {
@@ -99,6 +118,19 @@ func toDocument(asset *domain.Asset) assetDocument {
 		doc.DeletedAt = &deletedAt
 	}
 
+	if h := asset.Holding; h != nil {
+		doc.Holding = &holdingDocument{
+			Symbol:    h.Symbol,
+			Quantity:  h.Quantity,
+			CostBasis: amountDocument{Amount: h.CostBasis.Amount, Currency: h.CostBasis.Currency},
+			Price:     amountDocument{Amount: h.Price.Amount, Currency: h.Price.Currency},
+		}
+		if h.IsPriced() {
+			pricedAt := primitive.NewDateTimeFromTime(h.PricedAt)
+			doc.Holding.PricedAt = &pricedAt
+		}
+	}
+
 	return doc
 }
 
@@ -118,6 +150,7 @@ func fromDocument(doc assetDocument) (*domain.Asset, error) {
 		CreatedAt: doc.CreatedAt.Time(),
 		UpdatedAt: doc.UpdatedAt.Time(),
 		IsDeleted: doc.IsDeleted,
+		Version:   doc.Version,
 	}
 
 	if doc.DeletedAt != nil {
@@ -125,6 +158,18 @@ func fromDocument(doc assetDocument) (*domain.Asset, error) {
 		asset.DeletedAt = &deletedAt
 	}
 
+	if h := doc.Holding; h != nil {
+		asset.Holding = &domain.Holding{
+			Symbol:    h.Symbol,
+			Quantity:  h.Quantity,
+			CostBasis: valueobjects.Money{Amount: h.CostBasis.Amount, Currency: h.CostBasis.Currency},
+			Price:     valueobjects.Money{Amount: h.Price.Amount, Currency: h.Price.Currency},
+		}
+		if h.PricedAt != nil {
+			asset.Holding.PricedAt = h.PricedAt.Time()
+		}
+	}
+
 	return asset, nil
 }
 
@@ -150,18 +195,22 @@ func (r *AssetRepository) FindByID(ctx context.Context, id string) (*domain.Asse
 
 // FindAll은 모든 자산을 조회합니다. 옵션을 통해 필터링, 정렬, 페이지네이션을 적용할 수 있습니다.
 func (r *AssetRepository) FindAll(ctx context.Context, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	return r.find(ctx, false, opts...)
+}
+
+// find는 삭제 여부가 deleted와 같은 자산 중 옵션에 맞는 자산을 조회합니다.
+func (r *AssetRepository) find(ctx context.Context, deleted bool, opts ...repository.FindOption) ([]*domain.Asset, error) {
 	options := repository.NewFindOptions()
 	for _, opt := range opts {
 		opt.Apply(options)
 	}
 
-	// 기본 필터: 삭제되지 않은 자산만 조회
-	filter := bson.M{"is_deleted": false}
-
-	// 사용자 지정 필터 적용
-	for field, value := range options.Filters {
-		filter[field] = value
+	// 사용자 지정 필터 적용 후 기본 필터: 삭제 여부
+	filter, err := options.ToMongoFilter()
+	if err != nil {
+		return nil, err
 	}
+	filter["is_deleted"] = deleted
 
 	// MongoDB 옵션 설정
 	findOptions := options.ToMongoOptions()
@@ -206,6 +255,10 @@ func (r *AssetRepository) Save(ctx context.Context, asset *domain.Asset) error {
 		return err
 	}
 
+	if asset.Version == 0 {
+		asset.Version = 1
+	}
+
 	// 문서로 변환
 	doc := toDocument(asset)
 
@@ -228,24 +281,31 @@ func (r *AssetRepository) Save(ctx context.Context, asset *domain.Asset) error {
 }
 
 // Update는 자산을 업데이트합니다.
+// 저장된 버전이 asset.Version과 같을 때만 갱신하며, 성공하면 버전을 1 증가시킵니다.
 func (r *AssetRepository) Update(ctx context.Context, asset *domain.Asset) error {
-	// 존재하는지 확인
-	_, err := r.FindByID(ctx, asset.ID)
-	if err != nil {
-		return err
-	}
+	expected := asset.Version
 
 	// 문서로 변환
 	doc := toDocument(asset)
+	doc.Version = expected + 1
 
-	// 업데이트
-	filter := bson.M{"_id": asset.ID}
+	// 버전이 일치하는 문서만 업데이트
+	filter := bson.M{"_id": asset.ID, "is_deleted": false, "version": versionFilter(expected)}
 	update := bson.M{"$set": doc}
 
-	_, err = r.collection.UpdateOne(ctx, filter, update)
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return fmt.Errorf("failed to update asset: %w", err)
 	}
+	if result.MatchedCount == 0 {
+		// 문서가 없으면 FindByID가 not found 에러를 반환합니다
+		current, err := r.FindByID(ctx, asset.ID)
+		if err != nil {
+			return err
+		}
+		return domain.NewAssetVersionConflictError(asset.ID, expected, current.Version)
+	}
+	asset.Version = doc.Version
 
 	// 이벤트 발행
 	events := asset.Events()
@@ -259,7 +319,7 @@ func (r *AssetRepository) Update(ctx context.Context, asset *domain.Asset) error
 	return nil
 }
 
-// Delete는 자산을 삭제합니다.
+// Delete는 자산을 삭제 상태로 표시합니다.
 func (r *AssetRepository) Delete(ctx context.Context, id string) error {
 	// 존재하는지 확인
 	asset, err := r.FindByID(ctx, id)
@@ -274,28 +334,117 @@ func (r *AssetRepository) Delete(ctx context.Context, id string) error {
 	doc := toDocument(asset)
 
 	// 업데이트
-	filter := bson.M{"_id": id}
+	filter := bson.M{"_id": id, "is_deleted": false}
 	update := bson.M{
 		"$set": bson.M{
 			"is_deleted": true,
 			"deleted_at": doc.DeletedAt,
-			"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+			"updated_at": primitive.NewDateTimeFromTime(asset.UpdatedAt),
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
-	_, err = r.collection.UpdateOne(ctx, filter, update)
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return fmt.Errorf("failed to delete asset: %w", err)
 	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%w: asset not found with id: %s", repository.ErrEntityNotFound, id)
+	}
 
 	// 이벤트 발행
-	events := asset.Events()
-	for _, event := range events {
+	return r.publish(ctx, asset)
+}
+
+// FindDeletedByUserID는 사용자의 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *AssetRepository) FindDeletedByUserID(ctx context.Context, userID string, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	opts = append(opts,
+		repository.WithFilter("user_id", userID),
+		repository.WithSort("deleted_at", repository.SortAscending))
+	return r.find(ctx, true, opts...)
+}
+
+// FindDeletedBefore는 before 이전에 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *AssetRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Asset, error) {
+	return r.find(ctx, true,
+		repository.WithComplexFilter("deleted_at", "lt", before),
+		repository.WithSort("deleted_at", repository.SortAscending),
+		repository.WithLimit(0))
+}
+
+// Restore는 삭제된 자산을 복원하고 복원 이벤트를 발행합니다.
+func (r *AssetRepository) Restore(ctx context.Context, id string) (*domain.Asset, error) {
+	var doc assetDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: asset not found with id: %s", repository.ErrEntityNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find asset: %w", err)
+	}
+
+	asset, err := fromDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	expected := asset.Version
+	if err := asset.Restore(); err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": id, "is_deleted": true, "version": versionFilter(expected)}
+	update := bson.M{
+		"$set": bson.M{
+			"is_deleted": false,
+			"updated_at": primitive.NewDateTimeFromTime(asset.UpdatedAt),
+			"version":    expected + 1,
+		},
+		"$unset": bson.M{"deleted_at": ""},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore asset: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		// 조회 이후 다른 요청이 먼저 복원하거나 변경했습니다
+		return nil, domain.NewAssetVersionConflictError(id, expected, expected+1)
+	}
+	asset.Version = expected + 1
+
+	if err := r.publish(ctx, asset); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// Purge는 삭제된 자산 문서를 영구 삭제합니다.
+func (r *AssetRepository) Purge(ctx context.Context, id string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "is_deleted": true})
+	if err != nil {
+		return fmt.Errorf("failed to purge asset: %w", err)
+	}
+	if result.DeletedCount > 0 {
+		return nil
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to purge asset: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: asset not found with id: %s", repository.ErrEntityNotFound, id)
+	}
+	return domain.NewAssetNotDeletedError(id)
+}
+
+// publish는 자산에 쌓인 도메인 이벤트를 발행하고 비웁니다.
+func (r *AssetRepository) publish(ctx context.Context, asset *domain.Asset) error {
+	for _, event := range asset.Events() {
 		if err := r.eventBus.Publish(ctx, event); err != nil {
 			return fmt.Errorf("failed to publish event: %w", err)
 		}
 	}
-
+	asset.ClearEvents()
 	return nil
 }
 
@@ -306,13 +455,12 @@ func (r *AssetRepository) Count(ctx context.Context, opts ...repository.FindOpti
 		opt.Apply(options)
 	}
 
-	// 기본 필터: 삭제되지 않은 자산만 계산
-	filter := bson.M{"is_deleted": false}
-
-	// 사용자 지정 필터 적용
-	for field, value := range options.Filters {
-		filter[field] = value
+	// 사용자 지정 필터 적용 후 기본 필터: 삭제되지 않은 자산만 계산
+	filter, err := options.ToMongoFilter()
+	if err != nil {
+		return 0, err
 	}
+	filter["is_deleted"] = false
 
 	// 개수 조회
 	count, err := r.collection.CountDocuments(ctx, filter)
@@ -335,6 +483,11 @@ func (r *AssetRepository) FindByUserID(ctx context.Context, userID string, opts
 	return r.FindAll(ctx, opts...)
 }
 
+// FindBySymbol은 보유 심볼로 자산 목록을 조회합니다.
+func (r *AssetRepository) FindBySymbol(ctx context.Context, symbol string) ([]*domain.Asset, error) {
+	return r.find(ctx, false, repository.WithFilter("holding.symbol", symbol), repository.WithLimit(0))
+}
+
 // FindByType은 자산 유형으로 자산 목록을 조회합니다.
 func (r *AssetRepository) FindByType(ctx context.Context, assetType domain.AssetType, opts ...repository.FindOption) ([]*domain.Asset, error) {
 	// 필터에 자산 유형 추가
@@ -364,3 +517,12 @@ func (r *AssetRepository) CountByType(ctx context.Context, assetType domain.Asse
 	// Count 메서드 호출
 	return r.Count(ctx, filterOpt)
 }
+
+// versionFilter는 낙관적 잠금용 버전 조건을 만듭니다.
+// 버전 필드가 도입되기 전에 저장된 문서는 version이 없으므로 0과 같이 취급합니다.
+func versionFilter(expected int64) interface{} {
+	if expected == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return expected
+}
@@ -0,0 +1,417 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aske/go_fi_chart/internal/common/repository"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/aske/go_fi_chart/pkg/storage/migrate"
+	"github.com/aske/go_fi_chart/pkg/storage/sqldb"
+	"github.com/aske/go_fi_chart/services/asset/internal/domain"
+)
+
+// migrationTable 자산 서비스의 마이그레이션 이력 테이블입니다.
+// 다른 서비스와 데이터베이스를 공유해도 이력이 섞이지 않도록 별도 이름을 사용합니다.
+const migrationTable = "asset_schema_migrations"
+
+const selectAssets = `SELECT id, user_id, type, name, amount_amount, amount_currency, holding,
+	created_at, updated_at, is_deleted, deleted_at, version FROM assets`
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// columns FindOptions의 필드 이름과 assets 테이블 컬럼의 매핑입니다.
+var columns = map[string]string{
+	"id":         "id",
+	"user_id":    "user_id",
+	"type":       "type",
+	"name":       "name",
+	"amount":     "amount_amount",
+	"currency":   "amount_currency",
+	"symbol":     "holding_symbol",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"is_deleted": "is_deleted",
+	"deleted_at": "deleted_at",
+}
+
+// Migrate 자산 서비스 스키마 마이그레이션을 적용합니다.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	migrations, err := migrate.Load(migrationFiles, "migrations")
+	if err != nil {
+		return err
+	}
+	return migrate.New(db, migrations, migrate.WithTable(migrationTable)).Up(ctx)
+}
+
+// AssetRepository는 PostgreSQL 기반의 자산 저장소 구현체입니다.
+// FindOptions는 FindOptions.ToSQL로 WHERE/ORDER BY/LIMIT 절로 변환됩니다.
+// 삭제된 자산은 Purge 전까지 행이 남아 있으며 일반 조회에서는 제외됩니다.
+type AssetRepository struct {
+	db *sql.DB
+}
+
+// NewAssetRepository는 새로운 PostgreSQL 자산 저장소를 생성합니다.
+func NewAssetRepository(db *sql.DB) *AssetRepository {
+	return &AssetRepository{db: db}
+}
+
+// WithTransaction은 트랜잭션 안에서 fn을 실행합니다. 중첩 호출은 세이브포인트로 동작합니다.
+func (r *AssetRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return sqldb.WithTx(ctx, r.db, fn)
+}
+
+// Save는 자산을 저장합니다.
+func (r *AssetRepository) Save(ctx context.Context, asset *domain.Asset) error {
+	holding, err := marshalHolding(asset.Holding)
+	if err != nil {
+		return fmt.Errorf("failed to save asset: %w", err)
+	}
+	res, err := sqldb.Conn(ctx, r.db).ExecContext(ctx, `INSERT INTO assets
+	(id, user_id, type, name, amount_amount, amount_currency, holding_symbol, holding,
+	created_at, updated_at, is_deleted, deleted_at, version)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	ON CONFLICT (id) DO NOTHING`,
+		asset.ID, asset.UserID, string(asset.Type), asset.Name, asset.Amount.Amount, asset.Amount.Currency,
+		holdingSymbol(asset.Holding), holding,
+		asset.CreatedAt, asset.UpdatedAt, asset.IsDeleted, asset.DeletedAt, initialVersion(asset.Version))
+	if err != nil {
+		return fmt.Errorf("failed to save asset: %w", err)
+	}
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return domain.NewAssetAlreadyExistsError(asset.ID)
+	}
+	asset.Version = initialVersion(asset.Version)
+	return nil
+}
+
+// FindByID는 ID로 자산을 조회합니다.
+func (r *AssetRepository) FindByID(ctx context.Context, id string) (*domain.Asset, error) {
+	asset, err := scanAsset(sqldb.Conn(ctx, r.db).QueryRowContext(ctx, selectAssets+" WHERE id = $1 AND NOT is_deleted", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.NewAssetNotFoundError(id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find asset: %w", err)
+	}
+	return asset, nil
+}
+
+// Update는 자산을 업데이트합니다.
+// 저장된 버전이 asset.Version과 같을 때만 갱신하며, 성공하면 버전을 1 증가시킵니다.
+func (r *AssetRepository) Update(ctx context.Context, asset *domain.Asset) error {
+	holding, err := marshalHolding(asset.Holding)
+	if err != nil {
+		return fmt.Errorf("failed to update asset: %w", err)
+	}
+
+	conn := sqldb.Conn(ctx, r.db)
+	res, err := conn.ExecContext(ctx, `UPDATE assets SET
+	user_id = $2, type = $3, name = $4, amount_amount = $5, amount_currency = $6,
+	holding_symbol = $7, holding = $8,
+	updated_at = $9, is_deleted = $10, deleted_at = $11, version = version + 1
+	WHERE id = $1 AND version = $12 AND NOT is_deleted`,
+		asset.ID, asset.UserID, string(asset.Type), asset.Name, asset.Amount.Amount, asset.Amount.Currency,
+		holdingSymbol(asset.Holding), holding,
+		asset.UpdatedAt, asset.IsDeleted, asset.DeletedAt, asset.Version)
+	if err != nil {
+		return fmt.Errorf("failed to update asset: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// 행이 없으면 not found, 있으면 다른 요청이 먼저 갱신한 것입니다
+		var current int64
+		err := conn.QueryRowContext(ctx, "SELECT version FROM assets WHERE id = $1 AND NOT is_deleted", asset.ID).Scan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.NewAssetNotFoundError(asset.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update asset: %w", err)
+		}
+		return domain.NewAssetVersionConflictError(asset.ID, asset.Version, current)
+	}
+	asset.Version++
+	return nil
+}
+
+// Delete는 ID로 자산을 삭제 상태로 표시합니다.
+func (r *AssetRepository) Delete(ctx context.Context, id string) error {
+	now := time.Now()
+	res, err := sqldb.Conn(ctx, r.db).ExecContext(ctx, `UPDATE assets SET
+	is_deleted = TRUE, deleted_at = $2, updated_at = $2, version = version + 1
+	WHERE id = $1 AND NOT is_deleted`, id, now)
+	if err != nil {
+		return fmt.Errorf("failed to delete asset: %w", err)
+	}
+	return notFoundIfUnaffected(res, id)
+}
+
+// FindDeletedByUserID는 사용자의 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *AssetRepository) FindDeletedByUserID(ctx context.Context, userID string, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	return r.find(ctx, true, append(opts,
+		repository.WithFilter("user_id", userID),
+		repository.WithSort("deleted_at", repository.SortAscending))...)
+}
+
+// FindDeletedBefore는 before 이전에 삭제된 자산 목록을 삭제 시각 순으로 조회합니다.
+func (r *AssetRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Asset, error) {
+	return r.find(ctx, true,
+		repository.WithComplexFilter("deleted_at", "lt", before),
+		repository.WithSort("deleted_at", repository.SortAscending),
+		repository.WithLimit(0))
+}
+
+// Restore는 삭제된 자산을 복원하고 복원된 자산을 반환합니다.
+func (r *AssetRepository) Restore(ctx context.Context, id string) (*domain.Asset, error) {
+	var asset *domain.Asset
+	err := r.WithTransaction(ctx, func(ctx context.Context) error {
+		conn := sqldb.Conn(ctx, r.db)
+		var err error
+		asset, err = scanAsset(conn.QueryRowContext(ctx, selectAssets+" WHERE id = $1 FOR UPDATE", id))
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.NewAssetNotFoundError(id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find asset: %w", err)
+		}
+		if err := asset.Restore(); err != nil {
+			return err
+		}
+
+		_, err = conn.ExecContext(ctx, `UPDATE assets SET
+		is_deleted = FALSE, deleted_at = NULL, updated_at = $2, version = version + 1
+		WHERE id = $1`, id, asset.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore asset: %w", err)
+		}
+		asset.Version++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// Purge는 삭제된 자산의 행을 영구 삭제합니다.
+func (r *AssetRepository) Purge(ctx context.Context, id string) error {
+	conn := sqldb.Conn(ctx, r.db)
+	res, err := conn.ExecContext(ctx, "DELETE FROM assets WHERE id = $1 AND is_deleted", id)
+	if err != nil {
+		return fmt.Errorf("failed to purge asset: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := conn.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM assets WHERE id = $1)", id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to purge asset: %w", err)
+	}
+	if !exists {
+		return domain.NewAssetNotFoundError(id)
+	}
+	return domain.NewAssetNotDeletedError(id)
+}
+
+// FindAll은 옵션의 필터, 정렬, 페이지네이션을 적용해 자산 목록을 조회합니다.
+// 정렬 옵션이 없으면 ID 순서로 반환합니다.
+func (r *AssetRepository) FindAll(ctx context.Context, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	return r.find(ctx, false, opts...)
+}
+
+// find는 삭제 여부가 deleted와 같은 자산 중 옵션에 맞는 자산을 조회합니다.
+func (r *AssetRepository) find(ctx context.Context, deleted bool, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	query, err := findOptions(append(opts, repository.WithFilter("is_deleted", deleted))).ToSQL(columns)
+	if err != nil {
+		return nil, err
+	}
+	if query.OrderBy == "" {
+		query.OrderBy = "ORDER BY id"
+	}
+
+	rows, err := sqldb.Conn(ctx, r.db).QueryContext(ctx, query.Build(selectAssets), query.Args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := []*domain.Asset{}
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+	return assets, rows.Err()
+}
+
+// Count는 옵션의 필터에 맞는 삭제되지 않은 자산 개수를 반환합니다. 정렬과 페이지네이션은 무시됩니다.
+func (r *AssetRepository) Count(ctx context.Context, opts ...repository.FindOption) (int64, error) {
+	options := findOptions(append(opts, repository.WithFilter("is_deleted", false)))
+	options.Sort, options.SortBy = nil, ""
+	options.Limit, options.Offset, options.Pagination = 0, 0, nil
+
+	query, err := options.ToSQL(columns)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = sqldb.Conn(ctx, r.db).QueryRowContext(ctx, query.Build("SELECT COUNT(*) FROM assets"), query.Args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count assets: %w", err)
+	}
+	return count, nil
+}
+
+// FindByUserID는 사용자 ID로 자산 목록을 조회합니다.
+func (r *AssetRepository) FindByUserID(ctx context.Context, userID string, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	return r.FindAll(ctx, append(opts, repository.WithFilter("user_id", userID))...)
+}
+
+// FindByType은 자산 유형으로 자산 목록을 조회합니다.
+func (r *AssetRepository) FindByType(ctx context.Context, assetType domain.AssetType, opts ...repository.FindOption) ([]*domain.Asset, error) {
+	return r.FindAll(ctx, append(opts, repository.WithFilter("type", string(assetType)))...)
+}
+
+// FindBySymbol은 보유 심볼로 자산 목록을 조회합니다.
+func (r *AssetRepository) FindBySymbol(ctx context.Context, symbol string) ([]*domain.Asset, error) {
+	return r.find(ctx, false, repository.WithFilter("symbol", symbol), repository.WithLimit(0))
+}
+
+// CountByUserID는 사용자 ID에 해당하는 자산 개수를 반환합니다.
+func (r *AssetRepository) CountByUserID(ctx context.Context, userID string) (int64, error) {
+	return r.Count(ctx, repository.WithFilter("user_id", userID))
+}
+
+// CountByType은 자산 유형에 해당하는 자산 개수를 반환합니다.
+func (r *AssetRepository) CountByType(ctx context.Context, assetType domain.AssetType) (int64, error) {
+	return r.Count(ctx, repository.WithFilter("type", string(assetType)))
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAsset(row rowScanner) (*domain.Asset, error) {
+	var asset domain.Asset
+	var assetType string
+	var deletedAt sql.NullTime
+	var holding []byte
+	err := row.Scan(&asset.ID, &asset.UserID, &assetType, &asset.Name, &asset.Amount.Amount, &asset.Amount.Currency, &holding,
+		&asset.CreatedAt, &asset.UpdatedAt, &asset.IsDeleted, &deletedAt, &asset.Version)
+	if err != nil {
+		return nil, err
+	}
+	asset.Type = domain.AssetType(assetType)
+	if asset.Holding, err = unmarshalHolding(holding); err != nil {
+		return nil, err
+	}
+	if deletedAt.Valid {
+		asset.DeletedAt = &deletedAt.Time
+	}
+	return &asset, nil
+}
+
+// holdingRecord는 holding 컬럼에 JSON으로 저장되는 보유 포지션입니다.
+type holdingRecord struct {
+	Symbol        string     `json:"symbol"`
+	Quantity      float64    `json:"quantity"`
+	CostAmount    float64    `json:"costAmount"`
+	CostCurrency  string     `json:"costCurrency"`
+	PriceAmount   float64    `json:"priceAmount"`
+	PriceCurrency string     `json:"priceCurrency"`
+	PricedAt      *time.Time `json:"pricedAt,omitempty"`
+}
+
+// holdingSymbol은 holding_symbol 컬럼 값을 반환합니다. 보유 포지션이 없으면 NULL입니다.
+func holdingSymbol(h *domain.Holding) sql.NullString {
+	if h == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: h.Symbol, Valid: true}
+}
+
+func marshalHolding(h *domain.Holding) ([]byte, error) {
+	if h == nil {
+		return nil, nil
+	}
+	record := holdingRecord{
+		Symbol:        h.Symbol,
+		Quantity:      h.Quantity,
+		CostAmount:    h.CostBasis.Amount,
+		CostCurrency:  h.CostBasis.Currency,
+		PriceAmount:   h.Price.Amount,
+		PriceCurrency: h.Price.Currency,
+	}
+	if h.IsPriced() {
+		pricedAt := h.PricedAt
+		record.PricedAt = &pricedAt
+	}
+	return json.Marshal(record)
+}
+
+func unmarshalHolding(data []byte) (*domain.Holding, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var record holdingRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	h := &domain.Holding{
+		Symbol:    record.Symbol,
+		Quantity:  record.Quantity,
+		CostBasis: valueobjects.Money{Amount: record.CostAmount, Currency: record.CostCurrency},
+		Price:     valueobjects.Money{Amount: record.PriceAmount, Currency: record.PriceCurrency},
+	}
+	if record.PricedAt != nil {
+		h.PricedAt = *record.PricedAt
+	}
+	return h, nil
+}
+
+// initialVersion은 새로 저장하는 엔티티의 버전을 반환합니다. 지정되지 않았으면 1부터 시작합니다.
+func initialVersion(version int64) int64 {
+	if version == 0 {
+		return 1
+	}
+	return version
+}
+
+func notFoundIfUnaffected(res sql.Result, id string) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.NewAssetNotFoundError(id)
+	}
+	return nil
+}
+
+func findOptions(opts []repository.FindOption) *repository.FindOptions {
+	options := repository.NewFindOptions()
+	for _, opt := range opts {
+		opt.Apply(options)
+	}
+	return options
+}
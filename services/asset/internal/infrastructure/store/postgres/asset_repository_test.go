@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/aske/go_fi_chart/internal/common/errors"
+	"github.com/aske/go_fi_chart/internal/common/repository"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/aske/go_fi_chart/services/asset/internal/domain"
+)
+
+// openTestRepository POSTGRES_TEST_DSN이 가리키는 데이터베이스로 저장소를 생성합니다.
+// 로컬 PostgreSQL(또는 호환 서버)이 없으면 테스트를 건너뜁니다.
+func openTestRepository(t *testing.T) *AssetRepository {
+	t.Helper()
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN이 설정되지 않아 PostgreSQL 통합 테스트를 건너뜁니다")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, Migrate(ctx, db))
+	_, err = db.ExecContext(ctx, "TRUNCATE assets")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return NewAssetRepository(db)
+}
+
+func newTestAsset(t *testing.T, userID string, assetType domain.AssetType, amount float64) *domain.Asset {
+	t.Helper()
+	money, err := valueobjects.NewMoney(amount, "KRW")
+	require.NoError(t, err)
+	return domain.NewAsset(userID, assetType, "테스트 자산", money)
+}
+
+func TestAssetRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	asset := newTestAsset(t, "user1", domain.Stock, 1000)
+	require.NoError(t, repo.Save(ctx, asset))
+
+	err := repo.Save(ctx, asset)
+	assert.Error(t, err)
+
+	found, err := repo.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, asset.Amount, found.Amount)
+
+	asset.Name = "변경된 자산"
+	require.NoError(t, repo.Update(ctx, asset))
+	found, err = repo.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "변경된 자산", found.Name)
+
+	require.NoError(t, repo.Delete(ctx, asset.ID))
+	_, err = repo.FindByID(ctx, asset.ID)
+	assert.Error(t, err)
+}
+
+func TestAssetRepository_FindAllWithOptions(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	require.NoError(t, repo.Save(ctx, newTestAsset(t, "user1", domain.Stock, 1000)))
+	require.NoError(t, repo.Save(ctx, newTestAsset(t, "user1", domain.Stock, 3000)))
+	require.NoError(t, repo.Save(ctx, newTestAsset(t, "user1", domain.Bond, 2000)))
+	require.NoError(t, repo.Save(ctx, newTestAsset(t, "user2", domain.Stock, 5000)))
+
+	assets, err := repo.FindByUserID(ctx, "user1",
+		repository.WithComplexFilter("amount", "gte", 2000),
+		repository.WithSort("amount", repository.SortDescending),
+	)
+	require.NoError(t, err)
+	require.Len(t, assets, 2)
+	assert.Equal(t, 3000.0, assets[0].Amount.Amount)
+	assert.Equal(t, 2000.0, assets[1].Amount.Amount)
+
+	page, err := repo.FindAll(ctx, repository.WithPagination(2, 3))
+	require.NoError(t, err)
+	assert.Len(t, page, 1)
+
+	count, err := repo.CountByType(ctx, domain.Stock)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestAssetRepository_UpdateRejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	asset := newTestAsset(t, "user1", domain.Stock, 1000)
+	require.NoError(t, repo.Save(ctx, asset))
+
+	stale, err := repo.FindByID(ctx, asset.ID)
+	require.NoError(t, err)
+
+	asset.Name = "먼저 변경"
+	require.NoError(t, repo.Update(ctx, asset))
+	assert.Equal(t, int64(2), asset.Version)
+
+	stale.Name = "나중 변경"
+	err = repo.Update(ctx, stale)
+	assert.True(t, commonerrors.IsVersionConflict(err))
+
+	missing := newTestAsset(t, "user1", domain.Stock, 1000)
+	assert.True(t, domain.IsAssetNotFound(repo.Update(ctx, missing)))
+}
+
+func TestAssetRepository_SoftDeleteRestorePurge(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	asset := newTestAsset(t, "user1", domain.Stock, 1000)
+	require.NoError(t, repo.Save(ctx, asset))
+	require.NoError(t, repo.Delete(ctx, asset.ID))
+	assert.True(t, domain.IsAssetNotFound(repo.Delete(ctx, asset.ID)))
+
+	count, err := repo.CountByUserID(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	deleted, err := repo.FindDeletedByUserID(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.NotNil(t, deleted[0].DeletedAt)
+
+	expired, err := repo.FindDeletedBefore(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, expired, 1)
+
+	restored, err := repo.Restore(ctx, asset.ID)
+	require.NoError(t, err)
+	assert.False(t, restored.IsDeleted)
+	assert.Equal(t, int64(3), restored.Version)
+	assert.True(t, domain.IsAssetNotDeleted(repo.Purge(ctx, asset.ID)))
+
+	_, err = repo.Restore(ctx, asset.ID)
+	assert.True(t, domain.IsAssetNotDeleted(err))
+
+	require.NoError(t, repo.Delete(ctx, asset.ID))
+	require.NoError(t, repo.Purge(ctx, asset.ID))
+	assert.True(t, domain.IsAssetNotFound(repo.Purge(ctx, asset.ID)))
+}
+
+func TestAssetRepository_FindBySymbol(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	asset := newTestAsset(t, "user1", domain.Stock, 0)
+	costBasis, err := valueobjects.NewMoney(500, "KRW")
+	require.NoError(t, err)
+	holding, err := domain.NewHolding("005930", 10, costBasis)
+	require.NoError(t, err)
+	require.NoError(t, asset.SetHolding(holding))
+	require.NoError(t, repo.Save(ctx, asset))
+	require.NoError(t, repo.Save(ctx, newTestAsset(t, "user1", domain.Stock, 1000)))
+
+	price, err := valueobjects.NewMoney(70, "KRW")
+	require.NoError(t, err)
+	_, err = asset.Revalue(price, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(ctx, asset))
+
+	found, err := repo.FindBySymbol(ctx, "005930")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, 700.0, found[0].Amount.Amount)
+	assert.Equal(t, 70.0, found[0].Holding.Price.Amount)
+	assert.True(t, found[0].Holding.IsPriced())
+}
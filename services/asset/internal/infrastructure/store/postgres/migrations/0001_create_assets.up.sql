@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS assets (
+    id              TEXT PRIMARY KEY,
+    user_id         TEXT NOT NULL,
+    type            TEXT NOT NULL,
+    name            TEXT NOT NULL,
+    amount_amount   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    amount_currency TEXT NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    is_deleted      BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at      TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_assets_user_id ON assets (user_id);
+CREATE INDEX IF NOT EXISTS idx_assets_type ON assets (type);
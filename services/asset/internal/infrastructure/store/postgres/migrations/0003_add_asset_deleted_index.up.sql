@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_assets_deleted_at ON assets (deleted_at) WHERE is_deleted;
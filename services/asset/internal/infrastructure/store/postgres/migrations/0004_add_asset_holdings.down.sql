@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_assets_holding_symbol;
+ALTER TABLE assets DROP COLUMN IF EXISTS holding;
+ALTER TABLE assets DROP COLUMN IF EXISTS holding_symbol;
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/normalization"
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/infrastructure/sink/asset"
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/infrastructure/source/yahoo"
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/infrastructure/splits/transaction"
+)
+
+func main() {
+	serverCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 환경 변수 로드
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8084"
+	}
+	symbols, assetIDs, err := parseSymbols(os.Getenv("FEED_SYMBOLS"))
+	if err != nil {
+		log.Fatalf("수집 심볼 설정 실패: %v", err)
+	}
+	interval, err := durationEnv("FEED_INTERVAL", time.Minute)
+	if err != nil {
+		log.Fatalf("수집 주기 설정 실패: %v", err)
+	}
+
+	// 데이터 소스의 응답은 정규화하고, 과거 데이터의 수정 종가는 거래 서비스에 기록된 분할과 대조합니다
+	client := &http.Client{Timeout: 10 * time.Second}
+	var splits normalization.SplitProvider
+	if url := os.Getenv("TRANSACTION_SERVICE_URL"); url != "" {
+		splits = transaction.NewClient(url, client, assetIDs)
+	}
+	dataSource := normalization.NewSource(
+		yahoo.NewClient(yahoo.NewDefaultConfig()),
+		normalization.NewStandardNormalizer(normalization.NormalizationConfig{}),
+		splits,
+	)
+
+	// 구독 심볼의 시세를 주기적으로 자산 서비스에 전달합니다
+	if url := os.Getenv("ASSET_SERVICE_URL"); url != "" && len(symbols) > 0 {
+		feed := source.NewPriceFeed(dataSource, asset.NewClient(url, client), interval, symbols...)
+		go feed.Run(serverCtx)
+	} else {
+		log.Println("ASSET_SERVICE_URL 또는 FEED_SYMBOLS가 없어 시세 전달을 시작하지 않습니다")
+	}
+
+	// 라우터 설정
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /api/v1/history/{symbol}", historyHandler(dataSource))
+
+	// 서버 종료 시그널 처리
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		<-sigChan
+		cancel()
+	}()
+
+	// 서버 설정
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%s", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// 서버 시작
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("서버 시작 실패: %v", err)
+		}
+	}()
+
+	// 서버 종료 대기
+	<-serverCtx.Done()
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer shutdownCancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("서버 종료 중 오류 발생: %v", err)
+	}
+}
+
+// priceResponse 정규화된 과거 가격 응답 항목
+type priceResponse struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Open          float64   `json:"open"`
+	High          float64   `json:"high"`
+	Low           float64   `json:"low"`
+	Close         float64   `json:"close"`
+	Volume        int64     `json:"volume"`
+	AdjustedClose float64   `json:"adjustedClose"`
+}
+
+// historyHandler 심볼의 정규화된 과거 가격을 조회하는 핸들러를 반환합니다.
+// interval(기본 1d), from, to(YYYY-MM-DD, 기본 최근 1년) 쿼리를 사용합니다.
+func historyHandler(dataSource source.DataSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		end := time.Now()
+		if to := query.Get("to"); to != "" {
+			parsed, err := time.Parse(time.DateOnly, to)
+			if err != nil {
+				http.Error(w, "invalid to date", http.StatusBadRequest)
+				return
+			}
+			end = parsed
+		}
+		start := end.AddDate(-1, 0, 0)
+		if from := query.Get("from"); from != "" {
+			parsed, err := time.Parse(time.DateOnly, from)
+			if err != nil {
+				http.Error(w, "invalid from date", http.StatusBadRequest)
+				return
+			}
+			start = parsed
+		}
+		interval := source.Interval(query.Get("interval"))
+		if interval == "" {
+			interval = source.IntervalDaily
+		}
+
+		history, err := dataSource.FetchHistoricalData(r.Context(), source.HistoricalDataRequest{
+			Symbol:    r.PathValue("symbol"),
+			AssetType: source.AssetType(query.Get("type")),
+			Interval:  interval,
+			StartTime: start,
+			EndTime:   end,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		response := make([]priceResponse, len(history.Data))
+		for i, price := range history.Data {
+			response[i] = priceResponse(price)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf("응답 인코딩 실패: %v", err)
+		}
+	}
+}
+
+// parseSymbols FEED_SYMBOLS("NVDA:{자산 ID},TSLA")를 수집 요청과 심볼별 자산 ID로 읽습니다.
+// 자산 ID는 거래 서비스에서 분할 기록을 조회할 때 사용하며 생략할 수 있습니다.
+func parseSymbols(raw string) ([]source.RealTimeDataRequest, map[string]string, error) {
+	var requests []source.RealTimeDataRequest
+	assetIDs := make(map[string]string)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		symbol, assetID, _ := strings.Cut(entry, ":")
+		symbol = strings.TrimSpace(symbol)
+		if symbol == "" {
+			return nil, nil, fmt.Errorf("심볼이 비어 있습니다: %q", entry)
+		}
+		requests = append(requests, source.RealTimeDataRequest{Symbol: symbol, AssetType: source.AssetTypeStock})
+		if assetID = strings.TrimSpace(assetID); assetID != "" {
+			assetIDs[symbol] = assetID
+		}
+	}
+	return requests, assetIDs, nil
+}
+
+// durationEnv 환경 변수 값을 time.Duration으로 읽습니다. 값이 없으면 fallback을 반환합니다.
+func durationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s는 0보다 커야 합니다", key)
+	}
+	return d, nil
+}
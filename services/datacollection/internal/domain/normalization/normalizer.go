@@ -41,6 +41,14 @@ type NormalizationConfig struct {
 	// MaxGapRatio는 허용되는 최대 갭 비율입니다.
 	// 이 값을 초과하는 갭은 보간되지 않고 에러로 처리됩니다.
 	MaxGapRatio float64
+
+	// Splits는 심볼별로 기록된 주식 분할/병합입니다.
+	// 기록이 있는 심볼의 과거 데이터는 수정 종가를 분할과 대조합니다.
+	// Source에 SplitProvider를 지정하면 SplitProvider에 기록이 없는 심볼에만 사용합니다.
+	Splits map[string][]Split
+
+	// SplitTolerance는 수정 종가와 분할로 계산한 값 사이에 허용되는 상대 오차입니다.
+	SplitTolerance float64
 }
 
 // StandardNormalizer는 Normalizer 인터페이스의 기본 구현체입니다.
@@ -75,16 +83,30 @@ func NewStandardNormalizer(config NormalizationConfig) *StandardNormalizer {
 		config.MaxGapRatio = 0.1 // 10% 이상의 갭은 보간하지 않음
 	}
 
+	if config.SplitTolerance == 0 {
+		config.SplitTolerance = 0.01 // 반올림 오차는 불일치로 보지 않음
+	}
+
 	return &StandardNormalizer{
 		config: config,
 	}
 }
 
 // NormalizeHistoricalData는 과거 가격 데이터를 정규화합니다.
+// 수정 종가는 설정에 기록된 심볼의 분할과 대조합니다.
 func (n *StandardNormalizer) NormalizeHistoricalData(response *source.HistoricalDataResponse) (*source.HistoricalDataResponse, error) {
 	if response == nil {
 		return nil, fmt.Errorf("input response is nil")
 	}
+	return n.NormalizeHistoricalDataWithSplits(response, n.config.Splits[response.Symbol])
+}
+
+// NormalizeHistoricalDataWithSplits는 과거 가격 데이터를 정규화하고 수정 종가를 주어진 분할과 대조합니다.
+// 분할이 없으면 대조하지 않습니다.
+func (n *StandardNormalizer) NormalizeHistoricalDataWithSplits(response *source.HistoricalDataResponse, splits []Split) (*source.HistoricalDataResponse, error) {
+	if response == nil {
+		return nil, fmt.Errorf("input response is nil")
+	}
 
 	// 원본 데이터 복사
 	normalizedResponse := &source.HistoricalDataResponse{
@@ -120,6 +142,17 @@ func (n *StandardNormalizer) NormalizeHistoricalData(response *source.Historical
 		return nil, fmt.Errorf("interpolation error: %w", err)
 	}
 
+	// 기록된 분할과 수정 종가 대조
+	if len(splits) > 0 {
+		reconciled, discrepancies := ReconcileSplits(normalizedResponse.Data, splits, n.config.SplitTolerance)
+		if len(discrepancies) > 0 {
+			d := discrepancies[0]
+			return nil, fmt.Errorf("adjusted close of %s does not match recorded splits at %s: reported %g, expected %g (%d mismatches)",
+				response.Symbol, d.Timestamp.Format(time.DateOnly), d.Reported, d.Expected, len(discrepancies))
+		}
+		normalizedResponse.Data = reconciled
+	}
+
 	return normalizedResponse, nil
 }
 
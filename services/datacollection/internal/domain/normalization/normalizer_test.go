@@ -6,6 +6,7 @@ import (
 
 	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewStandardNormalizer(t *testing.T) {
@@ -151,6 +152,57 @@ func TestNormalizeHistoricalData(t *testing.T) {
 	assert.Equal(t, testDataWithGaps[0].Volume, normalized.Data[1].Volume)
 }
 
+func TestNormalizeHistoricalDataWithSplits(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+	normalizer := NewStandardNormalizer(NormalizationConfig{
+		InterpolationMethod: "none",
+		Splits: map[string][]Split{
+			"NVDA": {{Date: day(10), Factor: 10}},
+		},
+	})
+
+	t.Run("비어 있는 수정 종가를 분할로 계산한 값으로 채운다", func(t *testing.T) {
+		response := &source.HistoricalDataResponse{
+			Symbol: "NVDA",
+			Data: []source.PriceData{
+				{Timestamp: day(7), Close: 1200},
+				{Timestamp: day(10), Close: 121, AdjustedClose: 121},
+			},
+		}
+
+		normalized, err := normalizer.NormalizeHistoricalData(response)
+
+		require.NoError(t, err)
+		assert.Equal(t, 120.0, normalized.Data[0].AdjustedClose)
+		assert.Equal(t, 121.0, normalized.Data[1].AdjustedClose)
+	})
+
+	t.Run("분할과 맞지 않는 수정 종가는 에러를 반환한다", func(t *testing.T) {
+		response := &source.HistoricalDataResponse{
+			Symbol: "NVDA",
+			Data:   []source.PriceData{{Timestamp: day(7), Close: 1200, AdjustedClose: 1200}},
+		}
+
+		_, err := normalizer.NormalizeHistoricalData(response)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("분할 기록이 없는 심볼은 대조하지 않는다", func(t *testing.T) {
+		response := &source.HistoricalDataResponse{
+			Symbol: "AAPL",
+			Data:   []source.PriceData{{Timestamp: day(7), Close: 1200, AdjustedClose: 1200}},
+		}
+
+		normalized, err := normalizer.NormalizeHistoricalData(response)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1200.0, normalized.Data[0].AdjustedClose)
+	})
+}
+
 func TestNormalizeRealTimeData(t *testing.T) {
 	// 테스트를 위한 타임존 설정
 	loc, _ := time.LoadLocation("Asia/Tokyo")
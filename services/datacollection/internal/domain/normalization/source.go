@@ -0,0 +1,76 @@
+package normalization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
+)
+
+// Source는 데이터 소스의 응답을 정규화해 반환하는 DataSource 구현입니다.
+// 수집 파이프라인(예: PriceFeed)은 원본 데이터 소스 대신 Source를 사용해 정규화된 데이터만 전달합니다.
+type Source struct {
+	source     source.DataSource
+	normalizer *StandardNormalizer
+	splits     SplitProvider
+}
+
+// NewSource는 새로운 Source를 생성합니다.
+// splits가 nil이면 정규화 설정에 기록된 분할만 대조합니다.
+func NewSource(dataSource source.DataSource, normalizer *StandardNormalizer, splits SplitProvider) *Source {
+	return &Source{
+		source:     dataSource,
+		normalizer: normalizer,
+		splits:     splits,
+	}
+}
+
+// FetchHistoricalData는 과거 가격 데이터를 가져와 정규화하고, 수정 종가를 기록된 분할과 대조합니다.
+func (s *Source) FetchHistoricalData(ctx context.Context, request source.HistoricalDataRequest) (*source.HistoricalDataResponse, error) {
+	response, err := s.source.FetchHistoricalData(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	splits, err := s.recordedSplits(ctx, request.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	return s.normalizer.NormalizeHistoricalDataWithSplits(response, splits)
+}
+
+// FetchRealTimeData는 실시간 가격 데이터를 가져와 정규화합니다.
+func (s *Source) FetchRealTimeData(ctx context.Context, request source.RealTimeDataRequest) (*source.RealTimeDataResponse, error) {
+	response, err := s.source.FetchRealTimeData(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return s.normalizer.NormalizeRealTimeData(response)
+}
+
+// GetMetadata는 자산 메타데이터를 가져와 정규화합니다.
+func (s *Source) GetMetadata(ctx context.Context, request source.MetadataRequest) (*source.MetadataResponse, error) {
+	response, err := s.source.GetMetadata(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return s.normalizer.NormalizeMetadata(response)
+}
+
+// SourceName은 원본 데이터 소스의 이름을 반환합니다.
+func (s *Source) SourceName() string {
+	return s.source.SourceName()
+}
+
+// recordedSplits는 SplitProvider에 기록된 분할을 조회하고, 기록이 없으면 정규화 설정의 분할을 반환합니다.
+func (s *Source) recordedSplits(ctx context.Context, symbol string) ([]Split, error) {
+	if s.splits != nil {
+		splits, err := s.splits.Splits(ctx, symbol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load splits of %s: %w", symbol, err)
+		}
+		if len(splits) > 0 {
+			return splits, nil
+		}
+	}
+	return s.normalizer.config.Splits[symbol], nil
+}
@@ -0,0 +1,88 @@
+package normalization
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
+)
+
+// historySource는 심볼별로 고정된 과거 데이터를 반환하는 DataSource입니다.
+type historySource struct {
+	data map[string][]source.PriceData
+}
+
+func (s *historySource) FetchHistoricalData(_ context.Context, request source.HistoricalDataRequest) (*source.HistoricalDataResponse, error) {
+	return &source.HistoricalDataResponse{Symbol: request.Symbol, Data: s.data[request.Symbol]}, nil
+}
+
+func (s *historySource) FetchRealTimeData(_ context.Context, request source.RealTimeDataRequest) (*source.RealTimeDataResponse, error) {
+	return &source.RealTimeDataResponse{Symbol: request.Symbol, CurrentPrice: 100}, nil
+}
+
+func (s *historySource) GetMetadata(_ context.Context, request source.MetadataRequest) (*source.MetadataResponse, error) {
+	return &source.MetadataResponse{Symbol: request.Symbol}, nil
+}
+
+func (s *historySource) SourceName() string {
+	return "history"
+}
+
+// splitTable은 심볼별 분할을 반환하는 SplitProvider입니다.
+type splitTable struct {
+	splits map[string][]Split
+	err    error
+}
+
+func (t splitTable) Splits(_ context.Context, symbol string) ([]Split, error) {
+	return t.splits[symbol], t.err
+}
+
+func TestSourceFetchHistoricalData(t *testing.T) {
+	ctx := context.Background()
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+	dataSource := &historySource{data: map[string][]source.PriceData{
+		"NVDA": {{Timestamp: day(7), Close: 1200}, {Timestamp: day(10), Close: 121}},
+		"AAPL": {{Timestamp: day(7), Close: 400, AdjustedClose: 400}},
+	}}
+	normalizer := NewStandardNormalizer(NormalizationConfig{
+		InterpolationMethod: "none",
+		Splits:              map[string][]Split{"AAPL": {{Date: day(10), Factor: 4}}},
+	})
+	request := func(symbol string) source.HistoricalDataRequest {
+		return source.HistoricalDataRequest{Symbol: symbol, Interval: source.IntervalDaily, StartTime: day(1), EndTime: day(30)}
+	}
+
+	t.Run("SplitProvider에 기록된 분할로 수정 종가를 채운다", func(t *testing.T) {
+		src := NewSource(dataSource, normalizer, splitTable{splits: map[string][]Split{"NVDA": {{Date: day(10), Factor: 10}}}})
+
+		response, err := src.FetchHistoricalData(ctx, request("NVDA"))
+
+		require.NoError(t, err)
+		assert.Equal(t, 120.0, response.Data[0].AdjustedClose)
+		assert.Equal(t, 121.0, response.Data[1].AdjustedClose)
+	})
+
+	t.Run("SplitProvider에 기록이 없으면 설정의 분할과 대조한다", func(t *testing.T) {
+		src := NewSource(dataSource, normalizer, splitTable{})
+
+		_, err := src.FetchHistoricalData(ctx, request("AAPL"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("분할 조회가 실패하면 에러를 반환한다", func(t *testing.T) {
+		src := NewSource(dataSource, normalizer, splitTable{err: errors.New("조회 실패")})
+
+		_, err := src.FetchHistoricalData(ctx, request("NVDA"))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+package normalization
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
+)
+
+// Split은 기록된 주식 분할/병합입니다.
+// Factor는 보유 수량 배수이며 2:1 분할은 2, 1:4 병합은 0.25입니다.
+type Split struct {
+	Date   time.Time
+	Factor float64
+}
+
+// SplitProvider는 심볼에 기록된 분할/병합을 조회하는 인터페이스입니다(예: 거래 서비스).
+type SplitProvider interface {
+	// Splits는 심볼의 분할/병합 목록을 반환합니다. 기록이 없으면 빈 목록을 반환합니다.
+	Splits(ctx context.Context, symbol string) ([]Split, error)
+}
+
+// SplitDiscrepancy는 수정 종가가 기록된 분할로 계산한 값과 다른 데이터입니다.
+type SplitDiscrepancy struct {
+	Timestamp time.Time
+	Close     float64
+	Reported  float64 // 데이터 소스가 제공한 수정 종가
+	Expected  float64 // 기록된 분할로 계산한 수정 종가
+}
+
+// RelativeError는 기대값 대비 차이의 비율을 반환합니다.
+func (d SplitDiscrepancy) RelativeError() float64 {
+	if d.Expected == 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(d.Reported-d.Expected) / d.Expected
+}
+
+// ReconcileSplits는 수정 종가를 기록된 분할과 대조합니다.
+// 각 데이터의 기대 수정 종가는 종가를 그 이후 분할 배수의 곱으로 나눈 값이며,
+// 상대 오차가 tolerance를 넘는 데이터를 반환합니다.
+// 수정 종가가 비어 있는 데이터는 기대값으로 채우고 불일치로 보지 않습니다.
+// 배당 조정은 고려하지 않으므로 배당이 있는 자산은 tolerance를 여유 있게 지정해야 합니다.
+func ReconcileSplits(data []source.PriceData, splits []Split, tolerance float64) ([]source.PriceData, []SplitDiscrepancy) {
+	sorted := append([]Split(nil), splits...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	reconciled := make([]source.PriceData, len(data))
+	var discrepancies []SplitDiscrepancy
+	for i, price := range data {
+		expected := price.Close / laterSplitFactor(sorted, price.Timestamp)
+		if price.AdjustedClose == 0 {
+			price.AdjustedClose = expected
+		} else {
+			d := SplitDiscrepancy{
+				Timestamp: price.Timestamp,
+				Close:     price.Close,
+				Reported:  price.AdjustedClose,
+				Expected:  expected,
+			}
+			if d.RelativeError() > tolerance {
+				discrepancies = append(discrepancies, d)
+			}
+		}
+		reconciled[i] = price
+	}
+	return reconciled, discrepancies
+}
+
+// laterSplitFactor는 at 이후에 적용된 분할 배수의 곱을 반환합니다.
+// 분할 당일의 가격은 이미 분할이 반영된 것으로 봅니다.
+func laterSplitFactor(splits []Split, at time.Time) float64 {
+	factor := 1.0
+	for _, split := range splits {
+		if split.Factor > 0 && split.Date.After(at) {
+			factor *= split.Factor
+		}
+	}
+	return factor
+}
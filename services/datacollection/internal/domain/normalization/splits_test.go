@@ -0,0 +1,56 @@
+package normalization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileSplits(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+	splits := []Split{
+		{Date: day(10), Factor: 4},
+		{Date: day(5), Factor: 2},
+	}
+
+	t.Run("분할이 반영된 수정 종가는 일치한다", func(t *testing.T) {
+		data := []source.PriceData{
+			{Timestamp: day(1), Close: 800, AdjustedClose: 100},
+			{Timestamp: day(5), Close: 400, AdjustedClose: 100},
+			{Timestamp: day(10), Close: 100, AdjustedClose: 100},
+		}
+
+		_, discrepancies := ReconcileSplits(data, splits, 0.001)
+
+		assert.Empty(t, discrepancies)
+	})
+
+	t.Run("분할이 누락된 수정 종가는 불일치로 반환한다", func(t *testing.T) {
+		data := []source.PriceData{
+			{Timestamp: day(1), Close: 800, AdjustedClose: 200},
+			{Timestamp: day(11), Close: 100, AdjustedClose: 100},
+		}
+
+		_, discrepancies := ReconcileSplits(data, splits, 0.001)
+
+		require.Len(t, discrepancies, 1)
+		assert.Equal(t, day(1), discrepancies[0].Timestamp)
+		assert.Equal(t, 100.0, discrepancies[0].Expected)
+		assert.InDelta(t, 1.0, discrepancies[0].RelativeError(), 1e-9)
+	})
+
+	t.Run("비어 있는 수정 종가는 기대값으로 채운다", func(t *testing.T) {
+		data := []source.PriceData{{Timestamp: day(6), Close: 400}}
+
+		reconciled, discrepancies := ReconcileSplits(data, splits, 0.001)
+
+		assert.Empty(t, discrepancies)
+		assert.Equal(t, 100.0, reconciled[0].AdjustedClose)
+		assert.Zero(t, data[0].AdjustedClose)
+	})
+}
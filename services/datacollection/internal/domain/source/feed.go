@@ -0,0 +1,130 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Quote는 자산 평가에 사용할 심볼의 최신 시세입니다.
+type Quote struct {
+	Symbol    string    `json:"symbol"`    // 자산 심볼
+	Price     float64   `json:"price"`     // 현재 가격
+	Currency  string    `json:"currency"`  // 통화
+	Timestamp time.Time `json:"timestamp"` // 가격 업데이트 시간
+}
+
+// PriceSink는 수집한 시세를 전달받는 대상(예: 자산 서비스)에 대한 인터페이스를 정의합니다.
+type PriceSink interface {
+	// PushQuotes는 시세 목록을 전달합니다.
+	PushQuotes(ctx context.Context, quotes []Quote) error
+}
+
+// PriceFeed는 데이터 소스에서 구독 심볼의 실시간 가격을 주기적으로 가져와 PriceSink로 전달합니다.
+// 통화는 메타데이터에서 가져오며, 심볼마다 한 번만 조회해 캐시합니다.
+type PriceFeed struct {
+	source   DataSource
+	sink     PriceSink
+	interval time.Duration
+	requests []RealTimeDataRequest
+
+	currencyMutex sync.Mutex
+	currencies    map[string]string
+}
+
+// NewPriceFeed는 새로운 PriceFeed를 생성합니다.
+func NewPriceFeed(source DataSource, sink PriceSink, interval time.Duration, requests ...RealTimeDataRequest) *PriceFeed {
+	return &PriceFeed{
+		source:     source,
+		sink:       sink,
+		interval:   interval,
+		requests:   requests,
+		currencies: make(map[string]string),
+	}
+}
+
+// Run은 컨텍스트가 취소될 때까지 interval마다 Poll을 실행합니다.
+func (f *PriceFeed) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.Poll(ctx); err != nil {
+			log.Printf("[%s] 시세 전달 실패: %v", f.source.SourceName(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll은 모든 구독 심볼의 시세를 한 번 가져와 전달합니다.
+// 일부 심볼의 조회가 실패해도 나머지 시세는 전달하며, 실패는 모아서 반환합니다.
+func (f *PriceFeed) Poll(ctx context.Context) error {
+	quotes := make([]Quote, 0, len(f.requests))
+	var errs []error
+	for _, request := range f.requests {
+		quote, err := f.fetchQuote(ctx, request)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", request.Symbol, err))
+			continue
+		}
+		quotes = append(quotes, quote)
+	}
+
+	if len(quotes) > 0 {
+		if err := f.sink.PushQuotes(ctx, quotes); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fetchQuote는 심볼의 실시간 가격과 통화로 시세를 만듭니다.
+func (f *PriceFeed) fetchQuote(ctx context.Context, request RealTimeDataRequest) (Quote, error) {
+	data, err := f.source.FetchRealTimeData(ctx, request)
+	if err != nil {
+		return Quote{}, err
+	}
+	currency, err := f.currency(ctx, request)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	timestamp := data.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	return Quote{
+		Symbol:    request.Symbol,
+		Price:     data.CurrentPrice,
+		Currency:  currency,
+		Timestamp: timestamp,
+	}, nil
+}
+
+// currency는 캐시된 통화를 반환하고, 없으면 메타데이터를 조회해 캐시합니다.
+func (f *PriceFeed) currency(ctx context.Context, request RealTimeDataRequest) (string, error) {
+	f.currencyMutex.Lock()
+	defer f.currencyMutex.Unlock()
+
+	if currency, ok := f.currencies[request.Symbol]; ok {
+		return currency, nil
+	}
+
+	metadata, err := f.source.GetMetadata(ctx, MetadataRequest(request))
+	if err != nil {
+		return "", err
+	}
+	if metadata.Currency == "" {
+		return "", NewParseError(f.source.SourceName(), "통화 정보가 없습니다", request.Symbol)
+	}
+	f.currencies[request.Symbol] = metadata.Currency
+	return metadata.Currency, nil
+}
@@ -0,0 +1,50 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSink는 전달받은 시세를 기록하는 PriceSink입니다.
+type recordingSink struct {
+	pushes [][]Quote
+}
+
+func (s *recordingSink) PushQuotes(_ context.Context, quotes []Quote) error {
+	s.pushes = append(s.pushes, quotes)
+	return nil
+}
+
+func TestPriceFeedPoll(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	tsla := RealTimeDataRequest{Symbol: "TSLA", AssetType: AssetTypeStock}
+	aapl := RealTimeDataRequest{Symbol: "AAPL", AssetType: AssetTypeStock}
+
+	src := new(MockDataSource)
+	src.On("SourceName").Return("mock")
+	src.On("FetchRealTimeData", ctx, tsla).Return(&RealTimeDataResponse{Symbol: "TSLA", CurrentPrice: 250.5, Timestamp: now}, nil)
+	src.On("FetchRealTimeData", ctx, aapl).Return(nil, errors.New("조회 실패"))
+	src.On("GetMetadata", ctx, MetadataRequest(tsla)).Return(&MetadataResponse{Symbol: "TSLA", Currency: "USD"}, nil).Once()
+
+	sink := &recordingSink{}
+	feed := NewPriceFeed(src, sink, time.Minute, tsla, aapl)
+
+	// 실패한 심볼이 있어도 나머지 시세는 전달됩니다
+	err := feed.Poll(ctx)
+	assert.Error(t, err)
+	require.Len(t, sink.pushes, 1)
+	assert.Equal(t, []Quote{{Symbol: "TSLA", Price: 250.5, Currency: "USD", Timestamp: now}}, sink.pushes[0])
+
+	// 통화는 캐시되어 메타데이터를 다시 조회하지 않습니다
+	_ = feed.Poll(ctx)
+	require.Len(t, sink.pushes, 2)
+	src.AssertNumberOfCalls(t, "GetMetadata", 1)
+	src.AssertCalled(t, "FetchRealTimeData", mock.Anything, tsla)
+}
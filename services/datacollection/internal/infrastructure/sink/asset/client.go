@@ -0,0 +1,58 @@
+package asset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
+)
+
+// pricesPath는 자산 서비스의 시세 수신 API 경로입니다.
+const pricesPath = "/api/v1/prices"
+
+// Client는 수집한 시세를 자산 서비스로 전달하는 PriceSink 구현입니다.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient는 자산 서비스 기본 URL로 새로운 Client를 생성합니다.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// PushQuotes는 시세 목록을 JSON 배열로 자산 서비스에 전송합니다.
+// 자산 서비스는 시세를 가격 이벤트로 발행한 뒤 202를 반환합니다.
+func (c *Client) PushQuotes(ctx context.Context, quotes []source.Quote) error {
+	body, err := json.Marshal(quotes)
+	if err != nil {
+		return fmt.Errorf("시세 직렬화 실패: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+pricesPath, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("요청 생성 실패: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return source.NewNetworkError("asset", err.Error(), true)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return source.NewNetworkError("asset", fmt.Sprintf("예상하지 못한 응답 상태: %d", resp.StatusCode), resp.StatusCode >= 500)
+	}
+	return nil
+}
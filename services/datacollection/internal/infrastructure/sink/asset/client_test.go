@@ -0,0 +1,51 @@
+package asset
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPushQuotes(t *testing.T) {
+	pricedAt := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	var received []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/prices", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", nil)
+	err := client.PushQuotes(context.Background(), []source.Quote{
+		{Symbol: "TSLA", Price: 250.5, Currency: "USD", Timestamp: pricedAt},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, "TSLA", received[0]["symbol"])
+	assert.Equal(t, 250.5, received[0]["price"])
+	assert.Equal(t, "USD", received[0]["currency"])
+	assert.Equal(t, "2024-03-15T09:00:00Z", received[0]["timestamp"])
+}
+
+func TestClientPushQuotesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewClient(server.URL, nil).PushQuotes(context.Background(), []source.Quote{{Symbol: "TSLA", Price: 1, Currency: "USD"}})
+
+	var networkErr *source.NetworkError
+	require.ErrorAs(t, err, &networkErr)
+	assert.True(t, networkErr.IsRetryable())
+}
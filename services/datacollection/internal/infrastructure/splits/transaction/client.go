@@ -0,0 +1,78 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/normalization"
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
+)
+
+// splitsPath는 거래 서비스의 자산별 분할/병합 조회 API 경로입니다.
+const splitsPath = "/api/v1/transactions/asset/%s/splits"
+
+// splitResponse는 거래 서비스가 반환하는 분할/병합 항목입니다.
+type splitResponse struct {
+	ExecutedAt time.Time `json:"executed_at"`
+	Factor     float64   `json:"factor"`
+}
+
+// Client는 거래 서비스에 기록된 분할/병합을 조회하는 SplitProvider 구현입니다.
+// 거래 서비스는 자산 ID로 분할을 기록하므로 심볼별 자산 ID를 함께 지정합니다.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	assetIDs   map[string]string
+}
+
+// NewClient는 거래 서비스 기본 URL과 심볼별 자산 ID로 새로운 Client를 생성합니다.
+func NewClient(baseURL string, httpClient *http.Client, assetIDs map[string]string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		assetIDs:   assetIDs,
+	}
+}
+
+// Splits는 심볼에 해당하는 자산의 분할/병합을 조회합니다.
+// 자산 ID가 지정되지 않은 심볼은 기록이 없는 것으로 보고 빈 목록을 반환합니다.
+func (c *Client) Splits(ctx context.Context, symbol string) ([]normalization.Split, error) {
+	assetID, ok := c.assetIDs[symbol]
+	if !ok {
+		return nil, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+fmt.Sprintf(splitsPath, url.PathEscape(assetID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("요청 생성 실패: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, source.NewNetworkError("transaction", err.Error(), true)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, source.NewNetworkError("transaction", fmt.Sprintf("예상하지 못한 응답 상태: %d", resp.StatusCode), resp.StatusCode >= 500)
+	}
+
+	var body []splitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, source.NewParseError("transaction", err.Error(), symbol)
+	}
+	// 분할 당일의 가격은 이미 분할이 반영된 것으로 보므로 체결 시각은 날짜로 자릅니다
+	splits := make([]normalization.Split, len(body))
+	for i, split := range body {
+		splits[i] = normalization.Split{Date: split.ExecutedAt.UTC().Truncate(24 * time.Hour), Factor: split.Factor}
+	}
+	return splits, nil
+}
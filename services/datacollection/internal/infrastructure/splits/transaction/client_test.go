@@ -0,0 +1,49 @@
+package transaction
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kimjooyoon/go_fi_chart/services/datacollection/internal/domain/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientSplits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v1/transactions/asset/asset-1/splits", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"executed_at":"2024-06-10T13:30:00Z","factor":10}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", nil, map[string]string{"NVDA": "asset-1"})
+
+	splits, err := client.Splits(context.Background(), "NVDA")
+	require.NoError(t, err)
+	require.Len(t, splits, 1)
+	assert.Equal(t, time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), splits[0].Date)
+	assert.Equal(t, 10.0, splits[0].Factor)
+
+	// 자산 ID가 지정되지 않은 심볼은 조회하지 않습니다
+	splits, err = client.Splits(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.Empty(t, splits)
+}
+
+func TestClientSplitsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, nil, map[string]string{"NVDA": "asset-1"}).Splits(context.Background(), "NVDA")
+
+	var networkErr *source.NetworkError
+	require.ErrorAs(t, err, &networkErr)
+	assert.True(t, networkErr.IsRetryable())
+}
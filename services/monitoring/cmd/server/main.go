@@ -7,9 +7,13 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/aske/go_fi_chart/services/monitoring/internal/metrics"
+	"github.com/aske/go_fi_chart/services/monitoring/internal/storage"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -22,6 +26,18 @@ func main() {
 		port = "8083" // 다른 서비스와 다른 포트 사용
 	}
 
+	// 메트릭 저장소 설정
+	db, err := openDatabase(os.Getenv("DB_DRIVER"), os.Getenv("DB_PATH"))
+	if err != nil {
+		log.Fatalf("저장소 생성 실패: %v", err)
+	}
+	defer db.Close()
+
+	metricRepo, err := storage.NewMetricRepository(db)
+	if err != nil {
+		log.Fatalf("메트릭 저장소 생성 실패: %v", err)
+	}
+
 	// 라우터 설정
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
@@ -41,6 +57,13 @@ func main() {
 	// 서버 시작
 	serverCtx, serverStopCtx := context.WithCancel(context.Background())
 
+	// 메트릭 수집 시작
+	collector := metrics.NewCollector(metricRepo, nil)
+	if err := collector.Start(serverCtx); err != nil {
+		log.Fatalf("메트릭 수집 시작 실패: %v", err)
+	}
+	defer collector.Stop()
+
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	go func() {
@@ -67,7 +90,7 @@ func main() {
 
 	// 서버 시작
 	log.Printf("Monitoring 서비스 시작 (포트: %s)\n", port)
-	err := server.ListenAndServe()
+	err = server.ListenAndServe()
 	if err != nil && err != http.ErrServerClosed {
 		log.Fatal(err)
 	}
@@ -75,3 +98,18 @@ func main() {
 	// 종료 대기
 	<-serverCtx.Done()
 }
+
+// openDatabase DB_DRIVER 값에 따라 메트릭 저장소가 사용할 데이터베이스를 엽니다.
+// "embedded"이면 path의 파일에 저장하고, 그 외에는 메모리에만 유지합니다.
+func openDatabase(driver, path string) (*kv.DB, error) {
+	if driver != "embedded" {
+		return kv.OpenMemory(), nil
+	}
+	if path == "" {
+		path = "data/monitoring.kv"
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+	return kv.Open(path, nil)
+}
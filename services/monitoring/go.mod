@@ -3,6 +3,7 @@ module github.com/aske/go_fi_chart/services/monitoring
 go 1.24.0
 
 require (
+	github.com/aske/go_fi_chart/pkg v0.0.0
 	github.com/go-chi/chi/v5 v5.2.1
 	github.com/prometheus/client_golang v1.20.5
 	github.com/stretchr/testify v1.10.0
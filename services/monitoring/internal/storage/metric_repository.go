@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/aske/go_fi_chart/services/monitoring/internal/domain"
+)
+
+const (
+	metricBucket = "metrics"
+	indexType    = "type"
+)
+
+// metricRecord는 메트릭을 직렬화하기 위한 저장 형식입니다.
+type metricRecord struct {
+	ID        string            `json:"id"`
+	Type      domain.MetricType `json:"type"`
+	Value     float64           `json:"value"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+func toRecord(metric *domain.Metric) *metricRecord {
+	record := &metricRecord{
+		ID:        metric.ID(),
+		Type:      metric.Type(),
+		Timestamp: metric.Timestamp(),
+	}
+	if value := metric.Value(); value != nil {
+		record.Value = value.Value()
+		record.Labels = value.Labels()
+	}
+	return record
+}
+
+func (r *metricRecord) toMetric() *domain.Metric {
+	return domain.NewMetric(r.ID, r.Type, domain.NewMetricValue(r.Value, r.Labels), r.Timestamp)
+}
+
+// MetricRepository는 임베디드 키-값 저장소 기반의 메트릭 저장소입니다.
+// 메트릭 타입 보조 인덱스를 유지하며, 같은 ID로 저장하면 최신 값으로 덮어씁니다.
+type MetricRepository struct {
+	metrics *kv.Collection[*metricRecord]
+}
+
+// NewMetricRepository는 새로운 메트릭 저장소를 생성합니다.
+func NewMetricRepository(db *kv.DB) (*MetricRepository, error) {
+	metrics := kv.NewCollection[*metricRecord](db, metricBucket)
+	if err := metrics.Index(indexType, func(r *metricRecord) []string { return []string{string(r.Type)} }); err != nil {
+		return nil, err
+	}
+	return &MetricRepository{metrics: metrics}, nil
+}
+
+// Save는 메트릭을 저장합니다.
+func (r *MetricRepository) Save(ctx context.Context, metric *domain.Metric) error {
+	if err := metric.Validate(); err != nil {
+		return err
+	}
+
+	err := r.metrics.Update(ctx, func(tx *kv.Tx) error {
+		return r.metrics.Put(tx, metric.ID(), toRecord(metric))
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrMetricSaveFailed, err)
+	}
+	return nil
+}
+
+// FindByID는 ID로 메트릭을 조회합니다.
+func (r *MetricRepository) FindByID(ctx context.Context, id string) (*domain.Metric, error) {
+	var record *metricRecord
+	var exists bool
+	err := r.metrics.View(ctx, func(tx *kv.Tx) error {
+		var err error
+		record, exists, err = r.metrics.Get(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("metric not found: %s", id)
+	}
+	return record.toMetric(), nil
+}
+
+// FindByType은 타입 인덱스로 메트릭을 조회합니다.
+func (r *MetricRepository) FindByType(ctx context.Context, metricType domain.MetricType) ([]*domain.Metric, error) {
+	var records []*metricRecord
+	err := r.metrics.View(ctx, func(tx *kv.Tx) error {
+		var err error
+		records, err = r.metrics.Lookup(tx, indexType, string(metricType))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toMetrics(records, func(*metricRecord) bool { return true }), nil
+}
+
+// FindByTimeRange는 [start, end] 범위의 타임스탬프를 가진 메트릭을 조회합니다.
+func (r *MetricRepository) FindByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.Metric, error) {
+	var records []*metricRecord
+	err := r.metrics.View(ctx, func(tx *kv.Tx) error {
+		var err error
+		records, err = r.metrics.All(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toMetrics(records, func(record *metricRecord) bool {
+		return !record.Timestamp.Before(start) && !record.Timestamp.After(end)
+	}), nil
+}
+
+func toMetrics(records []*metricRecord, keep func(*metricRecord) bool) []*domain.Metric {
+	metrics := make([]*domain.Metric, 0, len(records))
+	for _, record := range records {
+		if keep(record) {
+			metrics = append(metrics, record.toMetric())
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/aske/go_fi_chart/services/monitoring/internal/domain"
+)
+
+func TestMetricRepository_PersistsAcrossRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "monitoring.kv")
+	now := time.Now().UTC()
+
+	db, err := kv.Open(path, nil)
+	require.NoError(t, err)
+	repo, err := NewMetricRepository(db)
+	require.NoError(t, err)
+
+	metric := domain.NewMetric("asset-value", domain.MetricTypeAssetValue,
+		domain.NewMetricValue(1500, map[string]string{"currency": "KRW"}), now)
+	require.NoError(t, repo.Save(ctx, metric))
+	require.NoError(t, db.Close())
+
+	db, err = kv.Open(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	repo, err = NewMetricRepository(db)
+	require.NoError(t, err)
+
+	found, err := repo.FindByID(ctx, "asset-value")
+	require.NoError(t, err)
+	assert.Equal(t, domain.MetricTypeAssetValue, found.Type())
+	assert.True(t, metric.Value().Equals(found.Value()))
+	assert.True(t, now.Equal(found.Timestamp()))
+}
+
+func TestMetricRepository_FindByTypeAndTimeRange(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewMetricRepository(kv.OpenMemory())
+	require.NoError(t, err)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Save(ctx, domain.NewMetric("m1", domain.MetricTypeGauge, domain.NewMetricValue(1, nil), base)))
+	require.NoError(t, repo.Save(ctx, domain.NewMetric("m2", domain.MetricTypeGauge, domain.NewMetricValue(2, nil), base.Add(time.Hour))))
+	require.NoError(t, repo.Save(ctx, domain.NewMetric("m3", domain.MetricTypeUserCount, domain.NewMetricValue(3, nil), base.Add(2*time.Hour))))
+
+	gauges, err := repo.FindByType(ctx, domain.MetricTypeGauge)
+	require.NoError(t, err)
+	assert.Len(t, gauges, 2)
+
+	inRange, err := repo.FindByTimeRange(ctx, base.Add(30*time.Minute), base.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, inRange, 2)
+
+	err = repo.Save(ctx, domain.NewMetric("", domain.MetricTypeGauge, domain.NewMetricValue(1, nil), base))
+	assert.ErrorIs(t, err, domain.ErrInvalidMetricID)
+}
@@ -7,14 +7,19 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"log/slog"
 
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
 	"github.com/aske/go_fi_chart/services/portfolio/internal/api"
 	"github.com/aske/go_fi_chart/services/portfolio/internal/domain"
+	"github.com/aske/go_fi_chart/services/portfolio/internal/infrastructure/store/mongodb"
 	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func main() {
@@ -36,7 +41,16 @@ func main() {
 	r.Use(timeoutMiddleware)
 
 	// 핸들러 설정
-	portfolioRepo := domain.NewMemoryPortfolioRepository()
+	portfolioRepo, closeRepo, err := newPortfolioRepository(os.Getenv("DB_DRIVER"), os.Getenv("DB_PATH"))
+	if err != nil {
+		logger.Error("failed to create repository", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := closeRepo(); err != nil {
+			logger.Error("failed to close repository", "error", err)
+		}
+	}()
 	handler := api.NewHandler(portfolioRepo, logger)
 	handler.RegisterRoutes(r)
 
@@ -74,6 +88,66 @@ func main() {
 	}
 }
 
+// newPortfolioRepository DB_DRIVER 값에 따라 포트폴리오 저장소를 생성합니다.
+// "embedded"이면 path의 임베디드 키-값 저장소를, "mongodb"이면 MONGODB_URI의 MongoDB를,
+// 그 외에는 인메모리 저장소를 사용합니다.
+func newPortfolioRepository(driver, path string) (domain.PortfolioRepository, func() error, error) {
+	switch driver {
+	case "embedded":
+		return newEmbeddedPortfolioRepository(path)
+	case "mongodb":
+		return newMongoPortfolioRepository(os.Getenv("MONGODB_URI"), os.Getenv("MONGODB_DATABASE"))
+	default:
+		return domain.NewMemoryPortfolioRepository(), func() error { return nil }, nil
+	}
+}
+
+func newEmbeddedPortfolioRepository(path string) (domain.PortfolioRepository, func() error, error) {
+	if path == "" {
+		path = "data/portfolio.kv"
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, nil, err
+	}
+
+	db, err := kv.Open(path, &kv.Options{SyncWrites: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, err := domain.NewEmbeddedPortfolioRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	return repo, db.Close, nil
+}
+
+// newMongoPortfolioRepository MongoDB에 연결해 인덱스를 초기화한 뒤 저장소를 생성합니다.
+func newMongoPortfolioRepository(uri, database string) (domain.PortfolioRepository, func() error, error) {
+	if uri == "" {
+		uri = "mongodb://localhost:27017"
+	}
+	if database == "" {
+		database = "portfolio"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, nil, err
+	}
+	closeClient := func() error { return client.Disconnect(context.Background()) }
+
+	repo := mongodb.NewPortfolioRepository(client.Database(database))
+	if err := repo.Init(ctx); err != nil {
+		_ = closeClient()
+		return nil, nil, err
+	}
+	return repo, closeClient, nil
+}
+
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("%s %s %s", r.RemoteAddr, r.Method, r.URL)
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	commonerrors "github.com/aske/go_fi_chart/internal/common/errors"
 	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
 	"github.com/aske/go_fi_chart/services/portfolio/internal/domain"
 	"github.com/gorilla/mux"
@@ -33,6 +34,7 @@ func (h *Handler) RegisterRoutes(r *mux.Router) {
 	r.HandleFunc("/portfolios/{id}/assets/{assetId}", h.UpdateAssetWeight).Methods("PUT")
 	r.HandleFunc("/portfolios/{id}/assets/{assetId}", h.RemoveAsset).Methods("DELETE")
 	r.HandleFunc("/users/{userId}/portfolios", h.ListUserPortfolios).Methods("GET")
+	r.HandleFunc("/assets/{assetId}/portfolios", h.ListAssetPortfolios).Methods("GET")
 	r.HandleFunc("/portfolios", h.ListPortfolios).Methods("GET")
 }
 
@@ -98,6 +100,7 @@ func (h *Handler) CreatePortfolio(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", commonerrors.ETag(portfolio.Version))
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(toPortfolioResponse(portfolio)); err != nil {
 		h.logger.Error("failed to encode response", "error", err)
@@ -118,6 +121,7 @@ func (h *Handler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", commonerrors.ETag(portfolio.Version))
 	if err := json.NewEncoder(w).Encode(toPortfolioResponse(portfolio)); err != nil {
 		h.logger.Error("failed to encode response", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -143,16 +147,19 @@ func (h *Handler) UpdatePortfolio(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !checkIfMatch(w, r, portfolio) {
+		return
+	}
+
 	portfolio.Name = req.Name
 	portfolio.UpdatedAt = time.Now()
 
-	if err := h.portfolioRepo.Update(r.Context(), portfolio); err != nil {
-		h.logger.Error("failed to update portfolio", "error", err)
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+	if !h.updatePortfolio(w, r, portfolio) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", commonerrors.ETag(portfolio.Version))
 	if err := json.NewEncoder(w).Encode(toPortfolioResponse(portfolio)); err != nil {
 		h.logger.Error("failed to encode response", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -164,13 +171,17 @@ func (h *Handler) DeletePortfolio(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	_, err := h.portfolioRepo.FindByID(r.Context(), id)
+	portfolio, err := h.portfolioRepo.FindByID(r.Context(), id)
 	if err != nil {
 		h.logger.Error("failed to find portfolio", "error", err)
 		http.Error(w, "portfolio not found", http.StatusNotFound)
 		return
 	}
 
+	if !checkIfMatch(w, r, portfolio) {
+		return
+	}
+
 	if err := h.portfolioRepo.Delete(r.Context(), id); err != nil {
 		h.logger.Error("failed to delete portfolio", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -198,6 +209,10 @@ func (h *Handler) AddAsset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !checkIfMatch(w, r, portfolio) {
+		return
+	}
+
 	weight, err := valueobjects.NewPercentage(req.Weight)
 	if err != nil {
 		h.logger.Error("failed to create percentage", "error", err)
@@ -211,13 +226,12 @@ func (h *Handler) AddAsset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.portfolioRepo.Update(r.Context(), portfolio); err != nil {
-		h.logger.Error("failed to update portfolio", "error", err)
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+	if !h.updatePortfolio(w, r, portfolio) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", commonerrors.ETag(portfolio.Version))
 	if err := json.NewEncoder(w).Encode(toPortfolioResponse(portfolio)); err != nil {
 		h.logger.Error("failed to encode response", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -244,6 +258,10 @@ func (h *Handler) UpdateAssetWeight(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !checkIfMatch(w, r, portfolio) {
+		return
+	}
+
 	weight, err := valueobjects.NewPercentage(req.Weight)
 	if err != nil {
 		h.logger.Error("failed to create percentage", "error", err)
@@ -264,13 +282,12 @@ func (h *Handler) UpdateAssetWeight(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := h.portfolioRepo.Update(r.Context(), portfolio); err != nil {
-		h.logger.Error("failed to update portfolio", "error", err)
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+	if !h.updatePortfolio(w, r, portfolio) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", commonerrors.ETag(portfolio.Version))
 	if err := json.NewEncoder(w).Encode(toPortfolioResponse(portfolio)); err != nil {
 		h.logger.Error("failed to encode response", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -290,6 +307,10 @@ func (h *Handler) RemoveAsset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !checkIfMatch(w, r, portfolio) {
+		return
+	}
+
 	if err := portfolio.RemoveAsset(assetID); err != nil {
 		h.logger.Error("failed to remove asset", "error", err)
 		var assetNotFoundError domain.AssetNotFoundError
@@ -303,15 +324,44 @@ func (h *Handler) RemoveAsset(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := h.portfolioRepo.Update(r.Context(), portfolio); err != nil {
-		h.logger.Error("failed to update portfolio", "error", err)
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+	if !h.updatePortfolio(w, r, portfolio) {
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// checkIfMatch If-Match 헤더가 있으면 포트폴리오의 현재 버전과 비교합니다.
+// 일치하지 않으면 412 응답을 쓰고 false를 반환합니다.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, portfolio *domain.Portfolio) bool {
+	expected, ok, err := commonerrors.IfMatchVersion(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	if ok && expected != portfolio.Version {
+		http.Error(w, "portfolio version mismatch", http.StatusPreconditionFailed)
+		return false
+	}
+	return true
+}
+
+// updatePortfolio 포트폴리오를 저장하고, 실패하면 에러 응답을 쓰고 false를 반환합니다.
+// 그 사이 다른 요청이 포트폴리오를 변경했다면 412로 응답합니다.
+func (h *Handler) updatePortfolio(w http.ResponseWriter, r *http.Request, portfolio *domain.Portfolio) bool {
+	err := h.portfolioRepo.Update(r.Context(), portfolio)
+	if err == nil {
+		return true
+	}
+	h.logger.Error("failed to update portfolio", "error", err)
+	if commonerrors.IsVersionConflict(err) {
+		http.Error(w, "portfolio version mismatch", http.StatusPreconditionFailed)
+		return false
+	}
+	http.Error(w, "internal server error", http.StatusInternalServerError)
+	return false
+}
+
 func (h *Handler) ListUserPortfolios(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["userId"]
@@ -344,6 +394,34 @@ func (h *Handler) ListUserPortfolios(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ListAssetPortfolios 자산을 구성에 포함한 포트폴리오 목록을 반환합니다.
+func (h *Handler) ListAssetPortfolios(w http.ResponseWriter, r *http.Request) {
+	assetID := mux.Vars(r)["assetId"]
+	if assetID == "" {
+		http.Error(w, "asset ID is required", http.StatusBadRequest)
+		return
+	}
+
+	portfolios, err := h.portfolioRepo.FindByAssetID(r.Context(), assetID)
+	if err != nil {
+		h.logger.Error("failed to find portfolios", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]portfolioResponse, len(portfolios))
+	for i, p := range portfolios {
+		response[i] = toPortfolioResponse(p)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
 func (h *Handler) ListPortfolios(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("userId")
 	if userID == "" {
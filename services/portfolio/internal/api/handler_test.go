@@ -55,6 +55,14 @@ func (m *MockPortfolioRepository) FindByUserID(ctx context.Context, userID strin
 	return args.Get(0).([]*domain.Portfolio), args.Error(1)
 }
 
+func (m *MockPortfolioRepository) FindByAssetID(ctx context.Context, assetID string) ([]*domain.Portfolio, error) {
+	args := m.Called(ctx, assetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Portfolio), args.Error(1)
+}
+
 // UpdatePortfolioRequest는 포트폴리오 업데이트 요청 구조체입니다.
 type UpdatePortfolioRequest struct {
 	Name string `json:"name"`
@@ -169,7 +177,7 @@ func TestGetPortfolio(t *testing.T) {
 
 	t.Run("존재하지 않는 포트폴리오 조회", func(t *testing.T) {
 		nonExistentID := uuid.New().String()
-		repo.On("FindByID", mock.Anything, nonExistentID).Return(nil, domain.ErrAssetNotFound)
+		repo.On("FindByID", mock.Anything, nonExistentID).Return(nil, domain.NewPortfolioNotFoundError(nonExistentID))
 
 		w := httptest.NewRecorder()
 		httpReq := httptest.NewRequest("GET", "/portfolios/"+nonExistentID, nil)
@@ -205,7 +213,7 @@ func TestUpdatePortfolio(t *testing.T) {
 	})
 
 	t.Run("존재하지 않는 포트폴리오 업데이트", func(t *testing.T) {
-		repo.On("FindByID", mock.Anything, "not-found").Return(nil, domain.ErrPortfolioNotFound)
+		repo.On("FindByID", mock.Anything, "not-found").Return(nil, domain.NewPortfolioNotFoundError("not-found"))
 
 		updateReq := UpdatePortfolioRequest{
 			Name: "업데이트된 포트폴리오",
@@ -242,7 +250,7 @@ func TestDeletePortfolio(t *testing.T) {
 
 	t.Run("존재하지 않는 포트폴리오 삭제", func(t *testing.T) {
 		nonExistentID := uuid.New().String()
-		repo.On("FindByID", mock.Anything, nonExistentID).Return(nil, domain.ErrPortfolioNotFound)
+		repo.On("FindByID", mock.Anything, nonExistentID).Return(nil, domain.NewPortfolioNotFoundError(nonExistentID))
 
 		w := httptest.NewRecorder()
 		httpReq := httptest.NewRequest("DELETE", "/portfolios/"+nonExistentID, nil)
@@ -365,7 +373,7 @@ func TestAddAsset(t *testing.T) {
 			Weight:  0.5,
 		}
 
-		repo.On("FindByID", mock.Anything, "not-found").Return(nil, domain.ErrPortfolioNotFound)
+		repo.On("FindByID", mock.Anything, "not-found").Return(nil, domain.NewPortfolioNotFoundError("not-found"))
 
 		body, err := json.Marshal(req)
 		assert.NoError(t, err)
@@ -410,7 +418,7 @@ func TestUpdateAssetWeight(t *testing.T) {
 			Weight: 0.7,
 		}
 
-		repo.On("FindByID", mock.Anything, "not-found").Return(nil, domain.ErrPortfolioNotFound)
+		repo.On("FindByID", mock.Anything, "not-found").Return(nil, domain.NewPortfolioNotFoundError("not-found"))
 
 		body, err := json.Marshal(req)
 		assert.NoError(t, err)
@@ -443,7 +451,7 @@ func TestRemoveAsset(t *testing.T) {
 	})
 
 	t.Run("존재하지 않는 포트폴리오의 자산 제거", func(t *testing.T) {
-		repo.On("FindByID", mock.Anything, "not-found").Return(nil, domain.ErrPortfolioNotFound)
+		repo.On("FindByID", mock.Anything, "not-found").Return(nil, domain.NewPortfolioNotFoundError("not-found"))
 
 		w := httptest.NewRecorder()
 		httpReq := httptest.NewRequest("DELETE", "/portfolios/not-found/assets/asset-123", nil)
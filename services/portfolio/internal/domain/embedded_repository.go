@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+)
+
+const (
+	portfolioBucket = "portfolios"
+
+	indexUserID  = "user_id"
+	indexAssetID = "asset_id"
+)
+
+var (
+	errPortfolioExists   = errors.New("portfolio exists")
+	errPortfolioNotFound = errors.New("portfolio not found")
+)
+
+// EmbeddedPortfolioRepository 임베디드 키-값 저장소 기반의 포트폴리오 저장소 구현체입니다.
+type EmbeddedPortfolioRepository struct {
+	portfolios *kv.Collection[*Portfolio]
+}
+
+// NewEmbeddedPortfolioRepository 사용자 ID와 구성 자산 ID 인덱스를 가진 포트폴리오 저장소를 생성합니다.
+func NewEmbeddedPortfolioRepository(db *kv.DB) (*EmbeddedPortfolioRepository, error) {
+	portfolios := kv.NewCollection[*Portfolio](db, portfolioBucket)
+	if err := portfolios.Index(indexUserID, func(p *Portfolio) []string { return []string{p.UserID} }); err != nil {
+		return nil, err
+	}
+	err := portfolios.Index(indexAssetID, func(p *Portfolio) []string {
+		ids := make([]string, 0, len(p.Assets))
+		for _, asset := range p.Assets {
+			ids = append(ids, asset.AssetID)
+		}
+		return ids
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EmbeddedPortfolioRepository{portfolios: portfolios}, nil
+}
+
+// Save 포트폴리오를 저장합니다.
+func (r *EmbeddedPortfolioRepository) Save(ctx context.Context, portfolio *Portfolio) error {
+	err := r.portfolios.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.portfolios.Exists(tx, portfolio.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errPortfolioExists
+		}
+		if portfolio.Version == 0 {
+			portfolio.Version = 1
+		}
+		return r.portfolios.Put(tx, portfolio.ID, portfolio)
+	})
+	if errors.Is(err, errPortfolioExists) {
+		return fmt.Errorf("포트폴리오가 이미 존재합니다: %s", portfolio.ID)
+	}
+	return err
+}
+
+// FindByID ID로 포트폴리오를 조회합니다.
+func (r *EmbeddedPortfolioRepository) FindByID(ctx context.Context, id string) (*Portfolio, error) {
+	var portfolio *Portfolio
+	err := r.portfolios.View(ctx, func(tx *kv.Tx) error {
+		found, exists, err := r.portfolios.Get(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errPortfolioNotFound
+		}
+		portfolio = found
+		return nil
+	})
+	if errors.Is(err, errPortfolioNotFound) {
+		return nil, fmt.Errorf("포트폴리오를 찾을 수 없습니다: %s", id)
+	}
+	return portfolio, err
+}
+
+// Update 포트폴리오를 업데이트합니다.
+// 저장된 버전이 portfolio.Version과 다르면 버전 충돌 에러를 반환하고, 성공하면 버전을 1 증가시킵니다.
+func (r *EmbeddedPortfolioRepository) Update(ctx context.Context, portfolio *Portfolio) error {
+	expected := portfolio.Version
+	err := r.portfolios.Update(ctx, func(tx *kv.Tx) error {
+		stored, exists, err := r.portfolios.Get(tx, portfolio.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errPortfolioNotFound
+		}
+		if stored.Version != expected {
+			return NewPortfolioVersionConflictError(portfolio.ID, expected, stored.Version)
+		}
+		portfolio.Version = expected + 1
+		return r.portfolios.Put(tx, portfolio.ID, portfolio)
+	})
+	if err != nil {
+		portfolio.Version = expected
+	}
+	if errors.Is(err, errPortfolioNotFound) {
+		return fmt.Errorf("포트폴리오를 찾을 수 없습니다: %s", portfolio.ID)
+	}
+	return err
+}
+
+// Delete ID로 포트폴리오를 삭제합니다.
+func (r *EmbeddedPortfolioRepository) Delete(ctx context.Context, id string) error {
+	err := r.portfolios.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.portfolios.Exists(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errPortfolioNotFound
+		}
+		return r.portfolios.Delete(tx, id)
+	})
+	if errors.Is(err, errPortfolioNotFound) {
+		return fmt.Errorf("포트폴리오를 찾을 수 없습니다: %s", id)
+	}
+	return err
+}
+
+// FindByUserID 사용자 ID 인덱스로 포트폴리오 목록을 조회합니다.
+func (r *EmbeddedPortfolioRepository) FindByUserID(ctx context.Context, userID string) ([]*Portfolio, error) {
+	return r.lookup(ctx, indexUserID, userID)
+}
+
+// FindByAssetID 자산을 구성에 포함한 포트폴리오 목록을 조회합니다.
+func (r *EmbeddedPortfolioRepository) FindByAssetID(ctx context.Context, assetID string) ([]*Portfolio, error) {
+	return r.lookup(ctx, indexAssetID, assetID)
+}
+
+func (r *EmbeddedPortfolioRepository) lookup(ctx context.Context, index, value string) ([]*Portfolio, error) {
+	var portfolios []*Portfolio
+	err := r.portfolios.View(ctx, func(tx *kv.Tx) error {
+		var err error
+		portfolios, err = r.portfolios.Lookup(tx, index, value)
+		return err
+	})
+	return portfolios, err
+}
@@ -115,6 +115,11 @@ func NewAssetNotFoundError(assetID string) error {
 	return AssetNotFoundError{AssetID: assetID}
 }
 
+// NewPortfolioVersionConflictError 포트폴리오의 요청 버전이 저장된 버전과 다른 에러를 생성합니다.
+func NewPortfolioVersionConflictError(portfolioID string, expected, actual int64) error {
+	return commonerrors.NewVersionConflictError("portfolio", portfolioID, expected, actual)
+}
+
 // 에러 타입 확인 유틸리티 함수들
 
 // IsPortfolioNotFound는 주어진 에러가 PortfolioNotFoundError 타입인지 확인합니다.
@@ -22,6 +22,7 @@ type Portfolio struct {
 	Assets    []PortfolioAsset
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	Version   int64 // 낙관적 잠금 버전, 저장소가 저장/수정할 때 관리합니다
 }
 
 // NewPortfolio 새로운 포트폴리오를 생성합니다.
@@ -37,6 +38,14 @@ func NewPortfolio(userID string, name string) *Portfolio {
 	}
 }
 
+// Clone 자산 구성을 포함한 포트폴리오의 복사본을 반환합니다.
+func (p *Portfolio) Clone() *Portfolio {
+	clone := *p
+	clone.Assets = make([]PortfolioAsset, len(p.Assets))
+	copy(clone.Assets, p.Assets)
+	return &clone
+}
+
 // AddAsset 포트폴리오에 자산을 추가합니다.
 func (p *Portfolio) AddAsset(assetID string, weight valueobjects.Percentage) error {
 	// 기존 자산의 총 가중치 계산
@@ -148,4 +157,5 @@ type PortfolioRepository interface {
 	Update(ctx context.Context, portfolio *Portfolio) error
 	Delete(ctx context.Context, id string) error
 	FindByUserID(ctx context.Context, userID string) ([]*Portfolio, error)
+	FindByAssetID(ctx context.Context, assetID string) ([]*Portfolio, error)
 }
@@ -28,7 +28,11 @@ func (r *MemoryPortfolioRepository) Save(_ context.Context, portfolio *Portfolio
 		return fmt.Errorf("포트폴리오가 이미 존재합니다: %s", portfolio.ID)
 	}
 
-	r.portfolios[portfolio.ID] = portfolio
+	if portfolio.Version == 0 {
+		portfolio.Version = 1
+	}
+	// 호출자가 Update 없이 변경한 내용이 반영되지 않도록 복사본을 보관합니다
+	r.portfolios[portfolio.ID] = portfolio.Clone()
 	return nil
 }
 
@@ -42,19 +46,25 @@ func (r *MemoryPortfolioRepository) FindByID(_ context.Context, id string) (*Por
 		return nil, fmt.Errorf("포트폴리오를 찾을 수 없습니다: %s", id)
 	}
 
-	return portfolio, nil
+	return portfolio.Clone(), nil
 }
 
 // Update 포트폴리오를 업데이트합니다.
+// 저장된 버전이 portfolio.Version과 다르면 버전 충돌 에러를 반환하고, 성공하면 버전을 1 증가시킵니다.
 func (r *MemoryPortfolioRepository) Update(_ context.Context, portfolio *Portfolio) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.portfolios[portfolio.ID]; !exists {
+	stored, exists := r.portfolios[portfolio.ID]
+	if !exists {
 		return fmt.Errorf("포트폴리오를 찾을 수 없습니다: %s", portfolio.ID)
 	}
+	if stored.Version != portfolio.Version {
+		return NewPortfolioVersionConflictError(portfolio.ID, portfolio.Version, stored.Version)
+	}
 
-	r.portfolios[portfolio.ID] = portfolio
+	portfolio.Version++
+	r.portfolios[portfolio.ID] = portfolio.Clone()
 	return nil
 }
 
@@ -79,7 +89,25 @@ func (r *MemoryPortfolioRepository) FindByUserID(_ context.Context, userID strin
 	var portfolios []*Portfolio
 	for _, portfolio := range r.portfolios {
 		if portfolio.UserID == userID {
-			portfolios = append(portfolios, portfolio)
+			portfolios = append(portfolios, portfolio.Clone())
+		}
+	}
+
+	return portfolios, nil
+}
+
+// FindByAssetID 자산을 구성에 포함한 포트폴리오 목록을 조회합니다.
+func (r *MemoryPortfolioRepository) FindByAssetID(_ context.Context, assetID string) ([]*Portfolio, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var portfolios []*Portfolio
+	for _, portfolio := range r.portfolios {
+		for _, asset := range portfolio.Assets {
+			if asset.AssetID == assetID {
+				portfolios = append(portfolios, portfolio.Clone())
+				break
+			}
 		}
 	}
 
@@ -0,0 +1,218 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/aske/go_fi_chart/internal/common/repository"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/aske/go_fi_chart/services/portfolio/internal/domain"
+)
+
+// PortfolioRepository MongoDB 기반 포트폴리오 저장소 구현체입니다.
+type PortfolioRepository struct {
+	collection *mongo.Collection
+}
+
+// 포트폴리오 문서 구조체
+type portfolioDocument struct {
+	ID        string                   `bson:"_id"`
+	UserID    string                   `bson:"user_id"`
+	Name      string                   `bson:"name"`
+	Assets    []portfolioAssetDocument `bson:"assets"`
+	CreatedAt primitive.DateTime       `bson:"created_at"`
+	UpdatedAt primitive.DateTime       `bson:"updated_at"`
+	Version   int64                    `bson:"version"`
+}
+
+type portfolioAssetDocument struct {
+	AssetID string  `bson:"asset_id"`
+	Weight  float64 `bson:"weight"`
+}
+
+// NewPortfolioRepository는 새로운 MongoDB 포트폴리오 저장소를 생성합니다.
+func NewPortfolioRepository(db *mongo.Database) *PortfolioRepository {
+	return &PortfolioRepository{
+		collection: db.Collection("portfolios"),
+	}
+}
+
+// Init MongoDB 컬렉션에 필요한 인덱스를 초기화합니다.
+func (r *PortfolioRepository) Init(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// 사용자별 포트폴리오 조회
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		// 자산을 포함한 포트폴리오 조회 (배열 필드의 멀티키 인덱스)
+		{Keys: bson.D{{Key: "assets.asset_id", Value: 1}}},
+	})
+	return err
+}
+
+// toDocument는 Portfolio 엔티티를 MongoDB 문서로 변환합니다.
+func toDocument(p *domain.Portfolio) portfolioDocument {
+	assets := make([]portfolioAssetDocument, 0, len(p.Assets))
+	for _, asset := range p.Assets {
+		assets = append(assets, portfolioAssetDocument{AssetID: asset.AssetID, Weight: asset.Weight.Value})
+	}
+	return portfolioDocument{
+		ID:        p.ID,
+		UserID:    p.UserID,
+		Name:      p.Name,
+		Assets:    assets,
+		CreatedAt: primitive.NewDateTimeFromTime(p.CreatedAt),
+		UpdatedAt: primitive.NewDateTimeFromTime(p.UpdatedAt),
+		Version:   p.Version,
+	}
+}
+
+// fromDocument는 MongoDB 문서를 Portfolio 엔티티로 변환합니다.
+func fromDocument(doc portfolioDocument) *domain.Portfolio {
+	assets := make([]domain.PortfolioAsset, 0, len(doc.Assets))
+	for _, asset := range doc.Assets {
+		assets = append(assets, domain.PortfolioAsset{
+			AssetID: asset.AssetID,
+			Weight:  valueobjects.Percentage{Value: asset.Weight},
+		})
+	}
+	return &domain.Portfolio{
+		ID:        doc.ID,
+		UserID:    doc.UserID,
+		Name:      doc.Name,
+		Assets:    assets,
+		CreatedAt: doc.CreatedAt.Time(),
+		UpdatedAt: doc.UpdatedAt.Time(),
+		Version:   doc.Version,
+	}
+}
+
+// Save 포트폴리오를 저장합니다.
+func (r *PortfolioRepository) Save(ctx context.Context, portfolio *domain.Portfolio) error {
+	doc := toDocument(portfolio)
+	if doc.Version == 0 {
+		doc.Version = 1
+	}
+	_, err := r.collection.InsertOne(ctx, doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("포트폴리오가 이미 존재합니다: %s", portfolio.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save portfolio: %w", err)
+	}
+	portfolio.Version = doc.Version
+	return nil
+}
+
+// FindByID ID로 포트폴리오를 조회합니다.
+func (r *PortfolioRepository) FindByID(ctx context.Context, id string) (*domain.Portfolio, error) {
+	var doc portfolioDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, domain.NewPortfolioNotFoundError(id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find portfolio: %w", err)
+	}
+	return fromDocument(doc), nil
+}
+
+// Update 포트폴리오를 업데이트합니다.
+// 저장된 버전이 portfolio.Version과 같을 때만 교체하며, 성공하면 버전을 1 증가시킵니다.
+func (r *PortfolioRepository) Update(ctx context.Context, portfolio *domain.Portfolio) error {
+	doc := toDocument(portfolio)
+	doc.Version = portfolio.Version + 1
+
+	filter := bson.M{"_id": portfolio.ID, "version": portfolio.Version}
+	result, err := r.collection.ReplaceOne(ctx, filter, doc)
+	if err != nil {
+		return fmt.Errorf("failed to update portfolio: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		// 문서가 없으면 FindByID가 not found 에러를 반환합니다
+		current, err := r.FindByID(ctx, portfolio.ID)
+		if err != nil {
+			return err
+		}
+		return domain.NewPortfolioVersionConflictError(portfolio.ID, portfolio.Version, current.Version)
+	}
+	portfolio.Version = doc.Version
+	return nil
+}
+
+// Delete ID로 포트폴리오를 삭제합니다.
+func (r *PortfolioRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete portfolio: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return domain.NewPortfolioNotFoundError(id)
+	}
+	return nil
+}
+
+// FindByUserID 사용자 ID로 포트폴리오 목록을 생성 순으로 조회합니다.
+func (r *PortfolioRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Portfolio, error) {
+	return r.Find(ctx, repository.WithFilter("user_id", userID), repository.WithLimit(0),
+		repository.WithSort("created_at", repository.SortAscending))
+}
+
+// FindByAssetID 자산을 구성에 포함한 포트폴리오 목록을 조회합니다.
+func (r *PortfolioRepository) FindByAssetID(ctx context.Context, assetID string) ([]*domain.Portfolio, error) {
+	return r.Find(ctx, repository.WithFilter("assets.asset_id", assetID), repository.WithLimit(0),
+		repository.WithSort("created_at", repository.SortAscending))
+}
+
+// Find 조회 옵션의 필터, 정렬, 페이지네이션을 적용해 포트폴리오 목록을 조회합니다.
+// 필드 이름은 문서의 bson 필드 이름(예: "user_id", "assets.weight")을 사용합니다.
+func (r *PortfolioRepository) Find(ctx context.Context, opts ...repository.FindOption) ([]*domain.Portfolio, error) {
+	options := findOptions(opts)
+	filter, err := options.ToMongoFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.ToMongoOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find portfolios: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	portfolios := []*domain.Portfolio{}
+	for cursor.Next(ctx) {
+		var doc portfolioDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode portfolio: %w", err)
+		}
+		portfolios = append(portfolios, fromDocument(doc))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+	return portfolios, nil
+}
+
+// Count 조회 옵션의 필터에 맞는 포트폴리오 개수를 반환합니다.
+func (r *PortfolioRepository) Count(ctx context.Context, opts ...repository.FindOption) (int64, error) {
+	filter, err := findOptions(opts).ToMongoFilter()
+	if err != nil {
+		return 0, err
+	}
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count portfolios: %w", err)
+	}
+	return count, nil
+}
+
+func findOptions(opts []repository.FindOption) *repository.FindOptions {
+	options := repository.NewFindOptions()
+	for _, opt := range opts {
+		opt.Apply(options)
+	}
+	return options
+}
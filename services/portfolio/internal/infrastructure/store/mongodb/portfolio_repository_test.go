@@ -0,0 +1,134 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	commonerrors "github.com/aske/go_fi_chart/internal/common/errors"
+	"github.com/aske/go_fi_chart/internal/common/repository"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/aske/go_fi_chart/services/portfolio/internal/domain"
+)
+
+// setupMongoDBTest MONGODB_TEST_URI가 가리키는 로컬 mongod에 테스트 DB를 새로 만들어 저장소를 반환합니다.
+// 환경 변수가 없으면 테스트를 건너뜁니다.
+func setupMongoDBTest(t *testing.T) *PortfolioRepository {
+	t.Helper()
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI가 설정되지 않아 MongoDB 통합 테스트를 건너뜁니다")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	db := client.Database("portfolio_test_db")
+	require.NoError(t, db.Drop(ctx))
+	t.Cleanup(func() {
+		_ = db.Drop(context.Background())
+		_ = client.Disconnect(context.Background())
+	})
+
+	repo := NewPortfolioRepository(db)
+	require.NoError(t, repo.Init(ctx))
+	return repo
+}
+
+func newTestPortfolio(t *testing.T, userID, name string, assets map[string]float64) *domain.Portfolio {
+	t.Helper()
+	portfolio := domain.NewPortfolio(userID, name)
+	for assetID, weight := range assets {
+		percentage, err := valueobjects.NewPercentage(weight)
+		require.NoError(t, err)
+		require.NoError(t, portfolio.AddAsset(assetID, percentage))
+	}
+	return portfolio
+}
+
+func TestPortfolioRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := setupMongoDBTest(t)
+
+	portfolio := newTestPortfolio(t, "user1", "성장형", map[string]float64{"asset1": 60, "asset2": 40})
+	require.NoError(t, repo.Save(ctx, portfolio))
+	assert.Error(t, repo.Save(ctx, portfolio))
+
+	found, err := repo.FindByID(ctx, portfolio.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "성장형", found.Name)
+	assert.ElementsMatch(t, portfolio.Assets, found.Assets)
+
+	found.Name = "안정형"
+	require.NoError(t, repo.Update(ctx, found))
+	found, err = repo.FindByID(ctx, portfolio.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "안정형", found.Name)
+
+	require.NoError(t, repo.Delete(ctx, portfolio.ID))
+	_, err = repo.FindByID(ctx, portfolio.ID)
+	assert.Error(t, err)
+	assert.Error(t, repo.Delete(ctx, portfolio.ID))
+}
+
+func TestPortfolioRepository_UpdateRejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := setupMongoDBTest(t)
+
+	portfolio := newTestPortfolio(t, "user1", "성장형", map[string]float64{"asset1": 50})
+	require.NoError(t, repo.Save(ctx, portfolio))
+	assert.Equal(t, int64(1), portfolio.Version)
+
+	stale, err := repo.FindByID(ctx, portfolio.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, portfolio.UpdateAssetWeight("asset1", valueobjects.Percentage{Value: 70}))
+	require.NoError(t, repo.Update(ctx, portfolio))
+	assert.Equal(t, int64(2), portfolio.Version)
+
+	require.NoError(t, stale.UpdateAssetWeight("asset1", valueobjects.Percentage{Value: 30}))
+	err = repo.Update(ctx, stale)
+	assert.True(t, commonerrors.IsVersionConflict(err))
+
+	found, err := repo.FindByID(ctx, portfolio.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 70.0, found.Assets[0].Weight.Value)
+}
+
+func TestPortfolioRepository_FindWithOptions(t *testing.T) {
+	ctx := context.Background()
+	repo := setupMongoDBTest(t)
+
+	require.NoError(t, repo.Save(ctx, newTestPortfolio(t, "user1", "가", map[string]float64{"asset1": 100})))
+	require.NoError(t, repo.Save(ctx, newTestPortfolio(t, "user1", "나", map[string]float64{"asset2": 100})))
+	require.NoError(t, repo.Save(ctx, newTestPortfolio(t, "user2", "다", map[string]float64{"asset1": 50})))
+
+	byUser, err := repo.FindByUserID(ctx, "user1")
+	require.NoError(t, err)
+	assert.Len(t, byUser, 2)
+
+	byAsset, err := repo.FindByAssetID(ctx, "asset1")
+	require.NoError(t, err)
+	assert.Len(t, byAsset, 2)
+
+	page, err := repo.Find(ctx,
+		repository.WithComplexFilter("name", "in", []string{"가", "다"}),
+		repository.WithSort("name", repository.SortDescending),
+		repository.WithPagination(1, 1),
+	)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "다", page[0].Name)
+
+	count, err := repo.Count(ctx, repository.WithComplexFilter("assets.weight", "lt", 100))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
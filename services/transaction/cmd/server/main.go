@@ -7,14 +7,19 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/aske/go_fi_chart/pkg/domain/events"
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
 	"github.com/aske/go_fi_chart/services/transaction/internal/api"
 	"github.com/aske/go_fi_chart/services/transaction/internal/domain"
+	"github.com/aske/go_fi_chart/services/transaction/internal/infrastructure/store/mongodb"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func main() {
@@ -36,8 +41,23 @@ func main() {
 	eventBus := events.NewSimplePublisher()
 
 	// 핸들러 설정
-	repo := domain.NewMemoryTransactionRepository(eventBus)
+	repo, closeRepo, err := newTransactionRepository(os.Getenv("DB_DRIVER"), os.Getenv("DB_PATH"), eventBus)
+	if err != nil {
+		log.Fatalf("저장소 생성 실패: %v", err)
+	}
+	defer func() {
+		if err := closeRepo(); err != nil {
+			log.Printf("저장소 종료 중 오류 발생: %v", err)
+		}
+	}()
 	handler := api.NewHandler(repo)
+	if path := os.Getenv("FEE_SCHEDULES_PATH"); path != "" {
+		schedules, err := loadFeeSchedules(path)
+		if err != nil {
+			log.Fatalf("수수료 체계 로드 실패: %v", err)
+		}
+		handler.SetFeeSchedules(schedules)
+	}
 	handler.RegisterRoutes(r)
 
 	// 서버 종료 시그널 처리
@@ -75,3 +95,73 @@ func main() {
 		log.Printf("Error during event bus shutdown: %v", err)
 	}
 }
+
+// newTransactionRepository DB_DRIVER 값에 따라 거래 저장소를 생성합니다.
+// "embedded"이면 path의 임베디드 키-값 저장소를, "mongodb"이면 MONGODB_URI의 MongoDB를,
+// 그 외에는 인메모리 저장소를 사용합니다.
+func newTransactionRepository(driver, path string, eventBus events.EventBus) (domain.TransactionRepository, func() error, error) {
+	switch driver {
+	case "embedded":
+		return newEmbeddedTransactionRepository(path)
+	case "mongodb":
+		return newMongoTransactionRepository(os.Getenv("MONGODB_URI"), os.Getenv("MONGODB_DATABASE"), eventBus)
+	default:
+		return domain.NewMemoryTransactionRepository(eventBus), func() error { return nil }, nil
+	}
+}
+
+func newEmbeddedTransactionRepository(path string) (domain.TransactionRepository, func() error, error) {
+	if path == "" {
+		path = "data/transaction.kv"
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, nil, err
+	}
+
+	db, err := kv.Open(path, &kv.Options{SyncWrites: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, err := domain.NewEmbeddedTransactionRepository(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	return repo, db.Close, nil
+}
+
+// newMongoTransactionRepository MongoDB에 연결해 인덱스를 초기화한 뒤 저장소를 생성합니다.
+func newMongoTransactionRepository(uri, database string, eventBus events.EventBus) (domain.TransactionRepository, func() error, error) {
+	if uri == "" {
+		uri = "mongodb://localhost:27017"
+	}
+	if database == "" {
+		database = "transaction"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, nil, err
+	}
+	closeClient := func() error { return client.Disconnect(context.Background()) }
+
+	repo := mongodb.NewTransactionRepository(client.Database(database), eventBus)
+	if err := repo.Init(ctx); err != nil {
+		_ = closeClient()
+		return nil, nil, err
+	}
+	return repo, closeClient, nil
+}
+
+// loadFeeSchedules 증권사/시장별 수수료 체계 JSON 파일을 읽습니다.
+func loadFeeSchedules(path string) (*domain.FeeSchedules, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return domain.LoadFeeSchedules(f)
+}
@@ -3,18 +3,35 @@ module github.com/aske/go_fi_chart/services/transaction
 go 1.24.0
 
 require (
+	github.com/aske/go_fi_chart/internal/common/errors v0.0.0
+	github.com/aske/go_fi_chart/internal/common/repository v0.0.0-00010101000000-000000000000
 	github.com/aske/go_fi_chart/pkg v0.0.0
 	github.com/go-chi/chi/v5 v5.2.1
 	github.com/google/uuid v1.6.0
 	github.com/stretchr/testify v1.10.0
+	go.mongodb.org/mongo-driver v1.17.3
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/snappy v0.0.4 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
 	github.com/kr/text v0.2.0 // indirect
+	github.com/montanaflynn/stats v0.7.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
+	github.com/xdg-go/pbkdf2 v1.0.0 // indirect
+	github.com/xdg-go/scram v1.1.2 // indirect
+	github.com/xdg-go/stringprep v1.0.4 // indirect
+	github.com/youmark/pkcs8 v0.0.0-20240726163527-a2c0da244d78 // indirect
+	golang.org/x/crypto v0.26.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/text v0.17.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace github.com/aske/go_fi_chart/pkg => ../../pkg
+
+replace github.com/aske/go_fi_chart/internal/common/errors => ../../internal/common/errors
+
+replace github.com/aske/go_fi_chart/internal/common/repository => ../../internal/common/repository
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	commonerrors "github.com/aske/go_fi_chart/internal/common/errors"
 	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
 	"github.com/aske/go_fi_chart/services/transaction/internal/domain"
 	"github.com/go-chi/chi/v5"
@@ -14,7 +15,8 @@ import (
 
 // Handler는 Transaction 서비스의 HTTP 핸들러입니다
 type Handler struct {
-	repository domain.TransactionRepository
+	repository   domain.TransactionRepository
+	feeSchedules *domain.FeeSchedules
 }
 
 // NewHandler는 새로운 Handler를 생성합니다
@@ -24,17 +26,27 @@ func NewHandler(repository domain.TransactionRepository) *Handler {
 	}
 }
 
+// SetFeeSchedules는 요청의 feeSchedule로 선택할 수 있는 수수료 체계를 설정합니다
+func (h *Handler) SetFeeSchedules(schedules *domain.FeeSchedules) {
+	h.feeSchedules = schedules
+}
+
 // RegisterRoutes는 라우터에 API 엔드포인트를 등록합니다
 func (h *Handler) RegisterRoutes(r chi.Router) {
 	r.Route("/api/v1/transactions", func(r chi.Router) {
 		r.Get("/", h.ListTransactions)
 		r.Post("/", h.CreateTransaction)
+		r.Get("/fee-schedules", h.ListFeeSchedules)
 		r.Get("/{id}", h.GetTransaction)
 		r.Put("/{id}", h.UpdateTransaction)
+		r.Put("/{id}/reconciled", h.SetReconciled)
 		r.Delete("/{id}", h.DeleteTransaction)
 		r.Get("/user/{userID}", h.ListUserTransactions)
 		r.Get("/portfolio/{portfolioID}", h.ListPortfolioTransactions)
+		r.Get("/portfolio/{portfolioID}/lots", h.ListPortfolioLots)
+		r.Get("/portfolio/{portfolioID}/gains", h.GetPortfolioGains)
 		r.Get("/asset/{assetID}", h.ListAssetTransactions)
+		r.Get("/asset/{assetID}/splits", h.ListAssetSplits)
 	})
 }
 
@@ -47,6 +59,25 @@ type createTransactionRequest struct {
 	Quantity      float64 `json:"quantity"`
 	ExecutedPrice float64 `json:"executedPrice"`
 	ExecutedAt    string  `json:"executedAt"`
+	// Lots는 SPECIFIC 방식에서 매도가 소진할 매수 로트입니다
+	Lots []lotSelectionRequest `json:"lots,omitempty"`
+	// 기업 활동 거래에서만 사용합니다
+	Ratio           float64 `json:"ratio,omitempty"`
+	RelatedAssetID  string  `json:"relatedAssetID,omitempty"`
+	BasisAllocation float64 `json:"basisAllocation,omitempty"`
+	// Charges는 수수료/세금 항목입니다. 비어 있으면 FeeSchedule로 계산합니다
+	Charges     []chargeRequest `json:"charges,omitempty"`
+	FeeSchedule string          `json:"feeSchedule,omitempty"`
+}
+
+type chargeRequest struct {
+	Type   string  `json:"type"`
+	Amount float64 `json:"amount"`
+}
+
+type lotSelectionRequest struct {
+	LotID    string  `json:"lotID"`
+	Quantity float64 `json:"quantity"`
 }
 
 type TransactionResponse struct {
@@ -60,6 +91,27 @@ type TransactionResponse struct {
 	ExecutedPrice float64   `json:"executed_price"`
 	ExecutedAt    time.Time `json:"executed_at"`
 	CreatedAt     time.Time `json:"created_at"`
+	// Lots는 매도가 지정한 매수 로트입니다
+	Lots []LotSelectionResponse `json:"lots,omitempty"`
+	// 기업 활동 거래에서만 채워집니다
+	Ratio           float64          `json:"ratio,omitempty"`
+	RelatedAssetID  string           `json:"related_asset_id,omitempty"`
+	BasisAllocation float64          `json:"basis_allocation,omitempty"`
+	Reconciled      bool             `json:"reconciled"`
+	Charges         []ChargeResponse `json:"charges,omitempty"`
+	TotalCharges    float64          `json:"total_charges"`
+	// TotalAmount는 수수료와 세금을 반영한 결제 금액입니다
+	TotalAmount float64 `json:"total_amount"`
+}
+
+type ChargeResponse struct {
+	Type   string  `json:"type"`
+	Amount float64 `json:"amount"`
+}
+
+type LotSelectionResponse struct {
+	LotID    string  `json:"lot_id"`
+	Quantity float64 `json:"quantity"`
 }
 
 // CreateTransaction은 새로운 거래를 생성합니다
@@ -105,6 +157,12 @@ func (h *Handler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	transactionType := domain.TransactionType(req.Type)
+	if transactionType.IsCorporateAction() {
+		h.createCorporateAction(w, r, userID, portfolioID, assetID, amount, executedAt, req)
+		return
+	}
+
 	executedPrice, err := valueobjects.NewMoney(req.ExecutedPrice, "USD")
 	if err != nil {
 		http.Error(w, "invalid executed price", http.StatusBadRequest)
@@ -115,7 +173,7 @@ func (h *Handler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 		userID,
 		portfolioID,
 		assetID,
-		domain.TransactionType(req.Type),
+		transactionType,
 		amount,
 		req.Quantity,
 		executedPrice,
@@ -126,12 +184,63 @@ func (h *Handler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !selectLots(w, transaction, req.Lots) {
+		return
+	}
+	if !h.applyCharges(w, transaction, req) {
+		return
+	}
+
+	h.saveCreated(w, r, transaction)
+}
+
+// createCorporateAction은 배당, 분할, 합병 같은 기업 활동 거래를 생성합니다
+func (h *Handler) createCorporateAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	userID, portfolioID, assetID uuid.UUID,
+	amount valueobjects.Money,
+	executedAt time.Time,
+	req createTransactionRequest,
+) {
+	var relatedAssetID uuid.UUID
+	if req.RelatedAssetID != "" {
+		id, err := uuid.Parse(req.RelatedAssetID)
+		if err != nil {
+			http.Error(w, "invalid related asset ID", http.StatusBadRequest)
+			return
+		}
+		relatedAssetID = id
+	}
+
+	transaction, err := domain.NewCorporateAction(userID, portfolioID, assetID, domain.CorporateAction{
+		Type:            domain.TransactionType(req.Type),
+		Amount:          amount,
+		Quantity:        req.Quantity,
+		Ratio:           req.Ratio,
+		RelatedAssetID:  relatedAssetID,
+		BasisAllocation: req.BasisAllocation,
+	}, executedAt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !h.applyCharges(w, transaction, req) {
+		return
+	}
+
+	h.saveCreated(w, r, transaction)
+}
+
+// saveCreated는 새 거래를 저장하고 201 응답을 씁니다
+func (h *Handler) saveCreated(w http.ResponseWriter, r *http.Request, transaction *domain.Transaction) {
 	if err := h.repository.Save(r.Context(), transaction); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", commonerrors.ETag(transaction.Version))
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(toTransactionResponse(transaction)); err != nil {
 		log.Printf("Error encoding response: %v", err)
@@ -153,6 +262,7 @@ func (h *Handler) GetTransaction(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", commonerrors.ETag(transaction.Version))
 	if err := json.NewEncoder(w).Encode(toTransactionResponse(transaction)); err != nil {
 		log.Printf("Error encoding response: %v", err)
 	}
@@ -280,6 +390,16 @@ func (h *Handler) UpdateTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !checkIfMatch(w, r, transaction) {
+		return
+	}
+
+	// 기업 활동은 이후 로트 계산 전체에 영향을 주므로 수정 대신 삭제 후 다시 생성합니다
+	if transaction.Type.IsCorporateAction() || domain.TransactionType(req.Type).IsCorporateAction() {
+		http.Error(w, "corporate actions cannot be updated", http.StatusBadRequest)
+		return
+	}
+
 	executedAt, err := time.Parse(time.RFC3339, req.ExecutedAt)
 	if err != nil {
 		http.Error(w, "Invalid executed at time", http.StatusBadRequest)
@@ -306,11 +426,67 @@ func (h *Handler) UpdateTransaction(w http.ResponseWriter, r *http.Request) {
 		executedAt,
 	)
 
+	if !selectLots(w, transaction, req.Lots) {
+		return
+	}
+	if !h.applyCharges(w, transaction, req) {
+		return
+	}
+
 	if err := h.repository.Update(r.Context(), transaction); err != nil {
+		if commonerrors.IsVersionConflict(err) {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("ETag", commonerrors.ETag(transaction.Version))
+	if err := json.NewEncoder(w).Encode(toTransactionResponse(transaction)); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
+type reconciledRequest struct {
+	Reconciled bool `json:"reconciled"`
+}
+
+// SetReconciled는 거래의 대사 완료 여부를 변경합니다
+func (h *Handler) SetReconciled(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	var req reconciledRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	transaction, err := h.repository.FindByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if !checkIfMatch(w, r, transaction) {
+		return
+	}
+
+	transaction.SetReconciled(req.Reconciled)
+	if err := h.repository.Update(r.Context(), transaction); err != nil {
+		if commonerrors.IsVersionConflict(err) {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", commonerrors.ETag(transaction.Version))
 	if err := json.NewEncoder(w).Encode(toTransactionResponse(transaction)); err != nil {
 		log.Printf("Error encoding response: %v", err)
 	}
@@ -330,6 +506,10 @@ func (h *Handler) DeleteTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !checkIfMatch(w, r, transaction) {
+		return
+	}
+
 	transaction.MarkAsDeleted()
 	if err := h.repository.Delete(r.Context(), id); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -339,18 +519,122 @@ func (h *Handler) DeleteTransaction(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// checkIfMatch는 If-Match 헤더가 있으면 거래의 현재 버전과 비교합니다
+// 일치하지 않으면 412 응답을 쓰고 false를 반환합니다
+func checkIfMatch(w http.ResponseWriter, r *http.Request, transaction *domain.Transaction) bool {
+	expected, ok, err := commonerrors.IfMatchVersion(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	if ok && expected != transaction.Version {
+		http.Error(w, "transaction version mismatch", http.StatusPreconditionFailed)
+		return false
+	}
+	return true
+}
+
+// selectLots는 요청의 로트 지정을 거래에 반영합니다
+// 지정이 유효하지 않으면 400 응답을 쓰고 false를 반환합니다
+func selectLots(w http.ResponseWriter, transaction *domain.Transaction, reqs []lotSelectionRequest) bool {
+	selections := make([]domain.LotSelection, 0, len(reqs))
+	for _, req := range reqs {
+		lotID, err := uuid.Parse(req.LotID)
+		if err != nil {
+			http.Error(w, "invalid lot ID", http.StatusBadRequest)
+			return false
+		}
+		selections = append(selections, domain.LotSelection{LotID: lotID, Quantity: req.Quantity})
+	}
+	if err := transaction.SelectLots(selections); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// applyCharges는 요청의 수수료/세금 항목이나 수수료 체계를 거래에 반영합니다
+// 항목과 수수료 체계가 모두 없으면 수수료/세금을 제거합니다
+// 반영할 수 없으면 400 응답을 쓰고 false를 반환합니다
+func (h *Handler) applyCharges(w http.ResponseWriter, transaction *domain.Transaction, req createTransactionRequest) bool {
+	if len(req.Charges) == 0 && req.FeeSchedule != "" {
+		if h.feeSchedules == nil {
+			http.Error(w, domain.ErrFeeScheduleNotFound.Error(), http.StatusBadRequest)
+			return false
+		}
+		schedule, err := h.feeSchedules.Get(req.FeeSchedule)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return false
+		}
+		if err := transaction.ApplyFeeSchedule(schedule); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return false
+		}
+		return true
+	}
+
+	charges := make([]domain.Charge, 0, len(req.Charges))
+	for _, c := range req.Charges {
+		amount, err := valueobjects.NewMoney(c.Amount, transaction.Amount.Currency)
+		if err != nil {
+			http.Error(w, "invalid charge amount", http.StatusBadRequest)
+			return false
+		}
+		charges = append(charges, domain.Charge{Type: domain.ChargeType(c.Type), Amount: amount})
+	}
+	if err := transaction.SetCharges(charges); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// ListFeeSchedules는 등록된 수수료 체계를 조회합니다
+func (h *Handler) ListFeeSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules := make([]domain.FeeSchedule, 0)
+	if h.feeSchedules != nil {
+		schedules = h.feeSchedules.List()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(schedules); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
 // toTransactionResponse는 도메인 모델을 응답 모델로 변환합니다
 func toTransactionResponse(t *domain.Transaction) TransactionResponse {
+	var lots []LotSelectionResponse
+	for _, lot := range t.Lots {
+		lots = append(lots, LotSelectionResponse{LotID: lot.LotID.String(), Quantity: lot.Quantity})
+	}
+	var charges []ChargeResponse
+	for _, charge := range t.Charges {
+		charges = append(charges, ChargeResponse{Type: string(charge.Type), Amount: charge.Amount.Amount})
+	}
+	var relatedAssetID string
+	if t.RelatedAssetID != uuid.Nil {
+		relatedAssetID = t.RelatedAssetID.String()
+	}
 	return TransactionResponse{
-		ID:            t.ID.String(),
-		UserID:        t.UserID.String(),
-		PortfolioID:   t.PortfolioID.String(),
-		AssetID:       t.AssetID.String(),
-		Type:          string(t.Type),
-		Amount:        t.Amount.Amount,
-		Quantity:      t.Quantity,
-		ExecutedPrice: t.ExecutedPrice.Amount,
-		ExecutedAt:    t.ExecutedAt,
-		CreatedAt:     t.CreatedAt,
+		ID:              t.ID.String(),
+		UserID:          t.UserID.String(),
+		PortfolioID:     t.PortfolioID.String(),
+		AssetID:         t.AssetID.String(),
+		Type:            string(t.Type),
+		Amount:          t.Amount.Amount,
+		Quantity:        t.Quantity,
+		ExecutedPrice:   t.ExecutedPrice.Amount,
+		ExecutedAt:      t.ExecutedAt,
+		CreatedAt:       t.CreatedAt,
+		Lots:            lots,
+		Ratio:           t.Ratio,
+		RelatedAssetID:  relatedAssetID,
+		BasisAllocation: t.BasisAllocation,
+		Reconciled:      t.Reconciled,
+		Charges:         charges,
+		TotalCharges:    t.TotalCharges().Amount,
+		TotalAmount:     t.CalculateTotalAmount().Amount,
 	}
 }
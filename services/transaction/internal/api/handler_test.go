@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -331,3 +332,267 @@ func TestListPortfolioTransactions(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, response, 2)
 }
+
+func TestUpdateTransaction_IfMatch(t *testing.T) {
+	// Given
+	handler := setupTestHandler()
+	router := setupTestRouter(handler)
+	transaction := createValidTransaction(t)
+	assert.NoError(t, handler.repository.Save(context.Background(), transaction))
+
+	body, err := json.Marshal(createTransactionRequest{
+		Type:          string(domain.Sell),
+		Amount:        300.0,
+		Quantity:      3,
+		ExecutedPrice: 100.0,
+		ExecutedAt:    time.Now().Format(time.RFC3339),
+	})
+	assert.NoError(t, err)
+	update := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/transactions/"+transaction.ID.String(), bytes.NewReader(body))
+		req.Header.Set("If-Match", ifMatch)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("현재 버전과 일치하면 업데이트", func(t *testing.T) {
+		// When
+		rr := update(`"1"`)
+
+		// Then
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"2"`, rr.Header().Get("ETag"))
+	})
+
+	t.Run("이전 버전이면 412", func(t *testing.T) {
+		// When
+		rr := update(`"1"`)
+
+		// Then
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	})
+
+	t.Run("잘못된 If-Match 헤더", func(t *testing.T) {
+		// When
+		rr := update("abc")
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSetReconciled(t *testing.T) {
+	// Given
+	handler := setupTestHandler()
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	transaction := createValidTransaction(t)
+	assert.NoError(t, handler.repository.Save(context.Background(), transaction))
+	put := func(id string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/transactions/"+id+"/reconciled", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("대사 완료로 표시", func(t *testing.T) {
+		// When
+		rr := put(transaction.ID.String(), `{"reconciled":true}`)
+
+		// Then
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response TransactionResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		assert.True(t, response.Reconciled)
+		saved, err := handler.repository.FindByID(context.Background(), transaction.ID)
+		assert.NoError(t, err)
+		assert.True(t, saved.Reconciled)
+	})
+
+	t.Run("대사 완료 해제", func(t *testing.T) {
+		// When
+		rr := put(transaction.ID.String(), `{"reconciled":false}`)
+
+		// Then
+		assert.Equal(t, http.StatusOK, rr.Code)
+		saved, err := handler.repository.FindByID(context.Background(), transaction.ID)
+		assert.NoError(t, err)
+		assert.False(t, saved.Reconciled)
+	})
+
+	t.Run("존재하지 않는 거래", func(t *testing.T) {
+		// When
+		rr := put(uuid.New().String(), `{"reconciled":true}`)
+
+		// Then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestGetPortfolioGains(t *testing.T) {
+	// Given
+	handler := setupTestHandler()
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+
+	userID := uuid.New()
+	portfolioID := uuid.New()
+	assetID := uuid.New()
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	var firstLotID string
+	for i, trade := range []struct {
+		kind     domain.TransactionType
+		quantity float64
+		price    float64
+	}{
+		{domain.Buy, 10, 100},
+		{domain.Buy, 10, 200},
+		{domain.Sell, 15, 300},
+	} {
+		body, err := json.Marshal(createTransactionRequest{
+			UserID:        userID.String(),
+			PortfolioID:   portfolioID.String(),
+			AssetID:       assetID.String(),
+			Type:          string(trade.kind),
+			Amount:        trade.quantity * trade.price,
+			Quantity:      trade.quantity,
+			ExecutedPrice: trade.price,
+			ExecutedAt:    start.AddDate(0, 0, i).Format(time.RFC3339),
+		})
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/transactions/", bytes.NewReader(body)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		if i == 0 {
+			var created TransactionResponse
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&created))
+			firstLotID = created.ID
+		}
+	}
+
+	t.Run("LIFO 방식의 실현 손익과 평가 손익", func(t *testing.T) {
+		// When
+		rec := httptest.NewRecorder()
+		url := "/api/v1/transactions/portfolio/" + portfolioID.String() + "/gains?method=lifo&price=" + assetID.String() + ":250"
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+
+		// Then
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response GainsResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Equal(t, "LIFO", response.Method)
+		assert.Len(t, response.Realized, 1)
+		assert.InDelta(t, 4500.0-(10*200+5*100), response.TotalRealized, 1e-9)
+		if assert.Len(t, response.Unrealized, 1) {
+			assert.Equal(t, firstLotID, response.Unrealized[0].LotID)
+			assert.InDelta(t, 5*(250.0-100), *response.Unrealized[0].UnrealizedGain, 1e-9)
+		}
+		assert.InDelta(t, 5*(250.0-100), response.TotalUnrealized, 1e-9)
+	})
+
+	t.Run("보유 로트 조회", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/transactions/portfolio/"+portfolioID.String()+"/lots", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response LotsResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Equal(t, "FIFO", response.Method)
+		if assert.Len(t, response.Lots, 1) {
+			assert.InDelta(t, 5.0, response.Lots[0].Remaining, 1e-9)
+			assert.InDelta(t, 300.0, *response.Lots[0].MarketPrice, 1e-9)
+		}
+	})
+
+	t.Run("지원하지 않는 방식", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/transactions/portfolio/"+portfolioID.String()+"/lots?method=HIFO", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestCreateTransaction_Charges(t *testing.T) {
+	// Given
+	handler := setupTestHandler()
+	schedules, err := domain.NewFeeSchedules(domain.FeeSchedule{
+		Name: "broker-a",
+		Rules: []domain.FeeRule{
+			{Type: domain.Commission, Fixed: 5},
+			{Type: domain.TransactionTax, AppliesTo: []domain.TransactionType{domain.Sell}, Rate: 0.002},
+		},
+	})
+	assert.NoError(t, err)
+	handler.SetFeeSchedules(schedules)
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+
+	newRequest := func(kind domain.TransactionType) createTransactionRequest {
+		return createTransactionRequest{
+			UserID:        uuid.New().String(),
+			PortfolioID:   uuid.New().String(),
+			AssetID:       uuid.New().String(),
+			Type:          string(kind),
+			Amount:        1000,
+			Quantity:      10,
+			ExecutedPrice: 100,
+			ExecutedAt:    time.Now().Format(time.RFC3339),
+		}
+	}
+	post := func(reqBody createTransactionRequest) *httptest.ResponseRecorder {
+		body, err := json.Marshal(reqBody)
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/transactions/", bytes.NewReader(body)))
+		return rec
+	}
+
+	t.Run("수수료 체계로 매도 수수료와 거래세를 계산한다", func(t *testing.T) {
+		reqBody := newRequest(domain.Sell)
+		reqBody.FeeSchedule = "broker-a"
+
+		rec := post(reqBody)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var response TransactionResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Len(t, response.Charges, 2)
+		assert.InDelta(t, 7.0, response.TotalCharges, 1e-9)
+		assert.InDelta(t, 993.0, response.TotalAmount, 1e-9)
+	})
+
+	t.Run("직접 지정한 항목은 수수료 체계보다 우선한다", func(t *testing.T) {
+		reqBody := newRequest(domain.Buy)
+		reqBody.FeeSchedule = "broker-a"
+		reqBody.Charges = []chargeRequest{{Type: string(domain.FXSpread), Amount: 2}}
+
+		rec := post(reqBody)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var response TransactionResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Equal(t, []ChargeResponse{{Type: string(domain.FXSpread), Amount: 2}}, response.Charges)
+		assert.InDelta(t, 1002.0, response.TotalAmount, 1e-9)
+	})
+
+	t.Run("없는 수수료 체계", func(t *testing.T) {
+		reqBody := newRequest(domain.Buy)
+		reqBody.FeeSchedule = "missing"
+
+		rec := post(reqBody)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("수수료 체계 목록 조회", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/transactions/fee-schedules", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response []domain.FeeSchedule
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		if assert.Len(t, response, 1) {
+			assert.Equal(t, "broker-a", response[0].Name)
+		}
+	})
+}
@@ -0,0 +1,289 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/aske/go_fi_chart/services/transaction/internal/domain"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// LotResponse는 보유 로트와 평가 손익 응답입니다
+type LotResponse struct {
+	LotID          string    `json:"lot_id"`
+	AssetID        string    `json:"asset_id"`
+	AcquiredAt     time.Time `json:"acquired_at"`
+	Quantity       float64   `json:"quantity"`
+	Remaining      float64   `json:"remaining"`
+	UnitCost       float64   `json:"unit_cost"`
+	CostBasis      float64   `json:"cost_basis"`
+	MarketPrice    *float64  `json:"market_price,omitempty"`
+	MarketValue    *float64  `json:"market_value,omitempty"`
+	UnrealizedGain *float64  `json:"unrealized_gain,omitempty"`
+	Currency       string    `json:"currency"`
+}
+
+// LotsResponse는 포트폴리오의 보유 로트 목록 응답입니다
+type LotsResponse struct {
+	PortfolioID string        `json:"portfolio_id"`
+	Method      string        `json:"method"`
+	Lots        []LotResponse `json:"lots"`
+}
+
+// LotMatchResponse는 매도가 소진한 로트 응답입니다
+type LotMatchResponse struct {
+	LotID    string  `json:"lot_id"`
+	Quantity float64 `json:"quantity"`
+	UnitCost float64 `json:"unit_cost"`
+}
+
+// RealizedGainResponse는 매도별 실현 손익 응답입니다
+type RealizedGainResponse struct {
+	SaleID    string             `json:"sale_id"`
+	AssetID   string             `json:"asset_id"`
+	SoldAt    time.Time          `json:"sold_at"`
+	Quantity  float64            `json:"quantity"`
+	Proceeds  float64            `json:"proceeds"`
+	CostBasis float64            `json:"cost_basis"`
+	Gain      float64            `json:"gain"`
+	Currency  string             `json:"currency"`
+	Matches   []LotMatchResponse `json:"matches"`
+}
+
+// IncomeResponse는 배당/이자 수입 응답입니다
+type IncomeResponse struct {
+	TransactionID string    `json:"transaction_id"`
+	AssetID       string    `json:"asset_id"`
+	Type          string    `json:"type"`
+	Amount        float64   `json:"amount"`
+	Withheld      float64   `json:"withheld"`
+	NetAmount     float64   `json:"net_amount"`
+	Currency      string    `json:"currency"`
+	ReceivedAt    time.Time `json:"received_at"`
+}
+
+// GainsResponse는 포트폴리오의 실현/평가 손익 응답입니다
+type GainsResponse struct {
+	PortfolioID     string                 `json:"portfolio_id"`
+	Method          string                 `json:"method"`
+	Realized        []RealizedGainResponse `json:"realized"`
+	Unrealized      []LotResponse          `json:"unrealized"`
+	Income          []IncomeResponse       `json:"income"`
+	TotalRealized   float64                `json:"total_realized"`
+	TotalUnrealized float64                `json:"total_unrealized"`
+	TotalIncome     float64                `json:"total_income"` // 원천징수 후 수령액 합계
+}
+
+// SplitResponse는 자산의 분할/병합 응답입니다. Factor는 보유 수량 배수입니다
+type SplitResponse struct {
+	ExecutedAt time.Time `json:"executed_at"`
+	Factor     float64   `json:"factor"`
+}
+
+// ListPortfolioLots는 포트폴리오의 보유 로트와 로트별 평가 손익을 조회합니다
+// method 쿼리로 원가 산정 방식(FIFO, LIFO, AVERAGE, SPECIFIC)을 지정하며 기본값은 FIFO입니다
+func (h *Handler) ListPortfolioLots(w http.ResponseWriter, r *http.Request) {
+	portfolioID, book, lots, ok := h.portfolioLots(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(LotsResponse{
+		PortfolioID: portfolioID.String(),
+		Method:      string(book.Method()),
+		Lots:        lots,
+	}); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
+// GetPortfolioGains는 포트폴리오의 매도별 실현 손익과 로트별 평가 손익을 조회합니다
+func (h *Handler) GetPortfolioGains(w http.ResponseWriter, r *http.Request) {
+	portfolioID, book, lots, ok := h.portfolioLots(w, r)
+	if !ok {
+		return
+	}
+
+	response := GainsResponse{
+		PortfolioID: portfolioID.String(),
+		Method:      string(book.Method()),
+		Realized:    make([]RealizedGainResponse, 0, len(book.Realized())),
+		Unrealized:  lots,
+		Income:      make([]IncomeResponse, 0, len(book.Income())),
+	}
+	for _, gain := range book.Realized() {
+		response.Realized = append(response.Realized, toRealizedGainResponse(gain))
+		response.TotalRealized += gain.Gain
+	}
+	for _, lot := range lots {
+		if lot.UnrealizedGain != nil {
+			response.TotalUnrealized += *lot.UnrealizedGain
+		}
+	}
+	for _, income := range book.Income() {
+		response.Income = append(response.Income, IncomeResponse{
+			TransactionID: income.TransactionID.String(),
+			AssetID:       income.AssetID.String(),
+			Type:          string(income.Type),
+			Amount:        income.Amount,
+			Withheld:      income.Withheld,
+			NetAmount:     income.Net(),
+			Currency:      income.Currency,
+			ReceivedAt:    income.ReceivedAt,
+		})
+		response.TotalIncome += income.Net()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
+// ListAssetSplits는 자산에 기록된 분할/병합을 체결 시각 순으로 조회합니다
+// 시세 수집 서비스가 수정 종가를 대조할 때 사용합니다
+func (h *Handler) ListAssetSplits(w http.ResponseWriter, r *http.Request) {
+	assetID, err := uuid.Parse(chi.URLParam(r, "assetID"))
+	if err != nil {
+		http.Error(w, "Invalid asset ID", http.StatusBadRequest)
+		return
+	}
+
+	transactions, err := h.repository.FindByAssetID(r.Context(), assetID)
+	if err != nil && !errors.Is(err, domain.ErrTransactionNotFound) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]SplitResponse, 0)
+	for _, split := range domain.Splits(transactions) {
+		response = append(response, SplitResponse{ExecutedAt: split.ExecutedAt, Factor: split.Factor})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
+// portfolioLots는 포트폴리오 거래로 로트를 계산하고 보유 로트 응답을 만듭니다
+// 시장 가격은 자산별 최근 체결 가격이며, price 쿼리({assetID}:{가격})로 덮어쓸 수 있습니다
+// 실패하면 에러 응답을 쓰고 false를 반환합니다
+func (h *Handler) portfolioLots(w http.ResponseWriter, r *http.Request) (uuid.UUID, *domain.LotBook, []LotResponse, bool) {
+	portfolioID, err := uuid.Parse(chi.URLParam(r, "portfolioID"))
+	if err != nil {
+		http.Error(w, "invalid portfolio ID format", http.StatusBadRequest)
+		return uuid.Nil, nil, nil, false
+	}
+
+	method, err := domain.ParseCostBasisMethod(r.URL.Query().Get("method"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return uuid.Nil, nil, nil, false
+	}
+
+	transactions, err := h.repository.FindByPortfolioID(r.Context(), portfolioID)
+	if err != nil && !errors.Is(err, domain.ErrTransactionNotFound) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return uuid.Nil, nil, nil, false
+	}
+
+	prices := domain.LastTradePrices(transactions)
+	if err := parsePriceOverrides(r.URL.Query()["price"], prices); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return uuid.Nil, nil, nil, false
+	}
+
+	book, err := domain.BuildLotBook(transactions, method)
+	if err != nil {
+		// 기록된 거래 내역으로 로트를 맞출 수 없는 경우입니다
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return uuid.Nil, nil, nil, false
+	}
+	unrealized, err := book.Unrealized(prices)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return uuid.Nil, nil, nil, false
+	}
+
+	gains := make(map[uuid.UUID]domain.UnrealizedGain, len(unrealized))
+	for _, gain := range unrealized {
+		gains[gain.LotID] = gain
+	}
+	openLots := book.OpenLots()
+	lots := make([]LotResponse, 0, len(openLots))
+	for _, lot := range openLots {
+		response := LotResponse{
+			LotID:      lot.ID.String(),
+			AssetID:    lot.AssetID.String(),
+			AcquiredAt: lot.AcquiredAt,
+			Quantity:   lot.Quantity,
+			Remaining:  lot.Remaining,
+			UnitCost:   lot.UnitCost.Amount,
+			CostBasis:  lot.CostBasis(),
+			Currency:   lot.UnitCost.Currency,
+		}
+		if gain, ok := gains[lot.ID]; ok {
+			response.MarketPrice = &gain.MarketPrice
+			response.MarketValue = &gain.MarketValue
+			response.UnrealizedGain = &gain.Gain
+		}
+		lots = append(lots, response)
+	}
+	return portfolioID, book, lots, true
+}
+
+// parsePriceOverrides는 {assetID}:{가격} 형식의 시장 가격으로 prices를 덮어씁니다
+func parsePriceOverrides(values []string, prices map[uuid.UUID]valueobjects.Money) error {
+	for _, value := range values {
+		rawID, rawPrice, found := strings.Cut(value, ":")
+		if !found {
+			return errors.New("price must be in {assetID}:{price} format")
+		}
+		assetID, err := uuid.Parse(rawID)
+		if err != nil {
+			return errors.New("invalid asset ID in price")
+		}
+		amount, err := strconv.ParseFloat(rawPrice, 64)
+		if err != nil {
+			return errors.New("invalid price")
+		}
+		price, err := valueobjects.NewMoney(amount, "USD")
+		if err != nil {
+			return err
+		}
+		prices[assetID] = price
+	}
+	return nil
+}
+
+// toRealizedGainResponse는 실현 손익을 응답 모델로 변환합니다
+func toRealizedGainResponse(gain domain.RealizedGain) RealizedGainResponse {
+	matches := make([]LotMatchResponse, 0, len(gain.Matches))
+	for _, match := range gain.Matches {
+		matches = append(matches, LotMatchResponse{
+			LotID:    match.LotID.String(),
+			Quantity: match.Quantity,
+			UnitCost: match.UnitCost,
+		})
+	}
+	return RealizedGainResponse{
+		SaleID:    gain.SaleID.String(),
+		AssetID:   gain.AssetID.String(),
+		SoldAt:    gain.SoldAt,
+		Quantity:  gain.Quantity,
+		Proceeds:  gain.Proceeds,
+		CostBasis: gain.CostBasis,
+		Gain:      gain.Gain,
+		Currency:  gain.Currency,
+		Matches:   matches,
+	}
+}
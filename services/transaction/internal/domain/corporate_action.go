@@ -0,0 +1,295 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/domain/events"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/google/uuid"
+)
+
+// 기업 활동과 현금 수입 거래 유형입니다
+const (
+	CashDividend    TransactionType = "DIVIDEND"          // 현금 배당, Amount가 받은 현금입니다
+	StockDividend   TransactionType = "STOCK_DIVIDEND"    // 주식 배당, Quantity가 받은 주식 수입니다
+	Interest        TransactionType = "INTEREST"          // 이자, Amount가 받은 현금입니다
+	Split           TransactionType = "SPLIT"             // 주식 분할, 보유 수량에 Ratio를 곱합니다
+	ReverseSplit    TransactionType = "REVERSE_SPLIT"     // 주식 병합, 보유 수량을 Ratio로 나눕니다
+	Merger          TransactionType = "MERGER"            // 합병, 보유 로트를 RelatedAssetID 자산으로 Ratio만큼 전환합니다
+	SpinOff         TransactionType = "SPIN_OFF"          // 분사, 보유 주식당 Ratio만큼 RelatedAssetID 자산을 받습니다
+	ReturnOfCapital TransactionType = "RETURN_OF_CAPITAL" // 자본 반환, Amount만큼 취득 원가를 줄입니다
+)
+
+// IsTrade는 매수/매도 거래인지 확인합니다
+func (t TransactionType) IsTrade() bool {
+	return t == Buy || t == Sell
+}
+
+// IsCorporateAction은 기업 활동이나 현금 수입 거래인지 확인합니다
+func (t TransactionType) IsCorporateAction() bool {
+	switch t {
+	case CashDividend, StockDividend, Interest, Split, ReverseSplit, Merger, SpinOff, ReturnOfCapital:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsIncome은 로트에 영향을 주지 않는 현금 수입인지 확인합니다
+func (t TransactionType) IsIncome() bool {
+	return t == CashDividend || t == Interest
+}
+
+// CorporateAction은 기업 활동 거래를 만들기 위한 값입니다
+// 유형마다 필요한 값만 사용하며 나머지는 0으로 둡니다
+type CorporateAction struct {
+	Type            TransactionType
+	Amount          valueobjects.Money // 받은 현금(배당, 이자, 자본 반환, 합병 현금)
+	Quantity        float64            // 받은 주식 수(주식 배당)
+	Ratio           float64            // 분할/병합 비율, 합병·분사의 주당 교부 주식 수
+	RelatedAssetID  uuid.UUID          // 합병 후 자산 또는 분사된 자산
+	BasisAllocation float64            // 분사 자산에 배분할 취득 원가 비율(0~1)
+}
+
+// NewCorporateAction은 기업 활동 거래를 생성합니다
+func NewCorporateAction(
+	userID uuid.UUID,
+	portfolioID uuid.UUID,
+	assetID uuid.UUID,
+	action CorporateAction,
+	executedAt time.Time,
+) (*Transaction, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID is required")
+	}
+	if portfolioID == uuid.Nil {
+		return nil, errors.New("portfolio ID is required")
+	}
+	if assetID == uuid.Nil {
+		return nil, errors.New("asset ID is required")
+	}
+
+	now := time.Now()
+	transaction := &Transaction{
+		ID:              uuid.New(),
+		UserID:          userID,
+		PortfolioID:     portfolioID,
+		AssetID:         assetID,
+		Type:            action.Type,
+		Amount:          action.Amount,
+		Quantity:        action.Quantity,
+		ExecutedPrice:   valueobjects.Money{Currency: action.Amount.Currency},
+		Ratio:           action.Ratio,
+		RelatedAssetID:  action.RelatedAssetID,
+		BasisAllocation: action.BasisAllocation,
+		ExecutedAt:      executedAt,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		events:          make([]events.Event, 0),
+	}
+	if err := transaction.validateCorporateAction(); err != nil {
+		return nil, err
+	}
+
+	transaction.events = append(transaction.events, NewTransactionCreatedEvent(transaction))
+	return transaction, nil
+}
+
+// validateCorporateAction은 기업 활동 유형별 필수 값을 검증합니다
+func (t *Transaction) validateCorporateAction() error {
+	switch t.Type {
+	case CashDividend, Interest, ReturnOfCapital:
+		if !t.Amount.IsPositive() {
+			return errors.New("amount must be positive")
+		}
+	case StockDividend:
+		if t.Quantity <= 0 {
+			return errors.New("quantity must be positive")
+		}
+	case Split, ReverseSplit:
+		if t.Ratio <= 0 {
+			return errors.New("ratio must be positive")
+		}
+	case Merger, SpinOff:
+		if t.Ratio <= 0 {
+			return errors.New("ratio must be positive")
+		}
+		if t.RelatedAssetID == uuid.Nil || t.RelatedAssetID == t.AssetID {
+			return errors.New("related asset ID is required")
+		}
+		if t.Amount.IsNegative() {
+			return errors.New("amount must not be negative")
+		}
+		if t.Type == SpinOff && (t.BasisAllocation < 0 || t.BasisAllocation > 1) {
+			return errors.New("basis allocation must be between 0 and 1")
+		}
+	default:
+		return errors.New("invalid transaction type")
+	}
+	return nil
+}
+
+// IncomeRecord는 배당과 이자처럼 로트에 영향을 주지 않는 현금 수입입니다
+type IncomeRecord struct {
+	TransactionID uuid.UUID
+	AssetID       uuid.UUID
+	Type          TransactionType
+	Amount        float64 // 세전 금액
+	Withheld      float64 // 원천징수세 등 공제액
+	Currency      string
+	ReceivedAt    time.Time
+}
+
+// Net은 공제 후 수령액을 반환합니다
+func (r IncomeRecord) Net() float64 {
+	return r.Amount - r.Withheld
+}
+
+// SplitEvent는 자산에 기록된 분할/병합을 보유 수량 배수로 나타냅니다
+// 병합은 1보다 작은 배수가 됩니다
+type SplitEvent struct {
+	AssetID    uuid.UUID
+	ExecutedAt time.Time
+	Factor     float64
+}
+
+// Splits는 거래 내역에서 자산의 분할/병합을 체결 시각 순으로 추출합니다
+func Splits(transactions []*Transaction) []SplitEvent {
+	var splits []SplitEvent
+	for _, t := range transactions {
+		switch t.Type {
+		case Split:
+			splits = append(splits, SplitEvent{AssetID: t.AssetID, ExecutedAt: t.ExecutedAt, Factor: t.Ratio})
+		case ReverseSplit:
+			splits = append(splits, SplitEvent{AssetID: t.AssetID, ExecutedAt: t.ExecutedAt, Factor: 1 / t.Ratio})
+		}
+	}
+	sort.SliceStable(splits, func(i, j int) bool {
+		return splits[i].ExecutedAt.Before(splits[j].ExecutedAt)
+	})
+	return splits
+}
+
+// applyCorporateAction은 기업 활동을 보유 로트에 반영합니다
+// 분할·병합·주식 배당은 총 취득 원가를 유지한 채 수량과 단가를 조정하고,
+// 자본 반환은 원가를 줄이며 원가를 넘는 금액은 실현 이익으로 기록합니다
+func (b *LotBook) applyCorporateAction(t *Transaction) error {
+	lots := b.lots[t.AssetID]
+	switch t.Type {
+	case CashDividend, Interest:
+		b.income = append(b.income, IncomeRecord{
+			TransactionID: t.ID,
+			AssetID:       t.AssetID,
+			Type:          t.Type,
+			Amount:        t.Amount.Amount,
+			Withheld:      t.Amount.Amount - t.NetProceeds().Amount,
+			Currency:      t.Amount.Currency,
+			ReceivedAt:    t.ExecutedAt,
+		})
+		return nil
+	case Split:
+		scaleLots(lots, t.Ratio)
+		return nil
+	case ReverseSplit:
+		scaleLots(lots, 1/t.Ratio)
+		return nil
+	}
+
+	open := openQuantity(lots)
+	if open <= quantityEpsilon {
+		return fmt.Errorf("%w: %s %s has no open lots", ErrInsufficientLots, t.Type, t.ID)
+	}
+
+	switch t.Type {
+	case StockDividend:
+		scaleLots(lots, (open+t.Quantity)/open)
+	case ReturnOfCapital:
+		b.returnCapital(t, lots, t.Amount.Amount/open)
+	case Merger:
+		// 합병 현금은 자본 반환과 같이 원가를 줄인 뒤 남은 로트를 새 자산으로 전환합니다
+		if t.Amount.IsPositive() {
+			b.returnCapital(t, lots, t.Amount.Amount/open)
+		}
+		scaleLots(lots, t.Ratio)
+		for _, lot := range lots {
+			lot.AssetID = t.RelatedAssetID
+		}
+		b.lots[t.RelatedAssetID] = sortByAcquisition(append(b.lots[t.RelatedAssetID], lots...))
+		delete(b.lots, t.AssetID)
+	case SpinOff:
+		if t.Amount.IsPositive() {
+			b.returnCapital(t, lots, t.Amount.Amount/open)
+		}
+		children := make([]*Lot, 0, len(lots))
+		for _, lot := range lots {
+			child := &Lot{
+				ID:          uuid.NewSHA1(lot.ID, t.ID[:]),
+				PortfolioID: lot.PortfolioID,
+				AssetID:     t.RelatedAssetID,
+				AcquiredAt:  lot.AcquiredAt,
+				Quantity:    lot.Remaining * t.Ratio,
+				Remaining:   lot.Remaining * t.Ratio,
+				UnitCost: valueobjects.Money{
+					Amount:   lot.UnitCost.Amount * t.BasisAllocation / t.Ratio,
+					Currency: lot.UnitCost.Currency,
+				},
+			}
+			lot.UnitCost.Amount *= 1 - t.BasisAllocation
+			children = append(children, child)
+		}
+		b.lots[t.RelatedAssetID] = sortByAcquisition(append(b.lots[t.RelatedAssetID], children...))
+	}
+	return nil
+}
+
+// returnCapital은 주당 perShare만큼 로트 단가를 줄이고, 단가를 넘는 금액은 실현 이익으로 기록합니다
+func (b *LotBook) returnCapital(t *Transaction, lots []*Lot, perShare float64) {
+	var excess float64
+	for _, lot := range lots {
+		if perShare > lot.UnitCost.Amount {
+			excess += (perShare - lot.UnitCost.Amount) * lot.Remaining
+			lot.UnitCost.Amount = 0
+			continue
+		}
+		lot.UnitCost.Amount -= perShare
+	}
+	if excess > 0 {
+		b.realized = append(b.realized, RealizedGain{
+			SaleID:   t.ID,
+			AssetID:  t.AssetID,
+			SoldAt:   t.ExecutedAt,
+			Proceeds: excess,
+			Gain:     excess,
+			Currency: t.Amount.Currency,
+		})
+	}
+}
+
+// scaleLots는 총 취득 원가를 유지하면서 로트 수량에 factor를 곱합니다
+func scaleLots(lots []*Lot, factor float64) {
+	for _, lot := range lots {
+		lot.Quantity *= factor
+		lot.Remaining *= factor
+		lot.UnitCost.Amount /= factor
+	}
+}
+
+// openQuantity는 로트의 남은 수량 합계를 반환합니다
+func openQuantity(lots []*Lot) float64 {
+	var open float64
+	for _, lot := range lots {
+		open += lot.Remaining
+	}
+	return open
+}
+
+// sortByAcquisition은 로트를 매수 시각 순으로 정렬합니다
+func sortByAcquisition(lots []*Lot) []*Lot {
+	sort.SliceStable(lots, func(i, j int) bool {
+		return lots[i].AcquiredAt.Before(lots[j].AcquiredAt)
+	})
+	return lots
+}
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// action은 day일째에 기록된 기업 활동 거래를 만듭니다.
+func (f *lotFixture) action(day int, action CorporateAction) *Transaction {
+	if action.Amount.Currency == "" {
+		action.Amount = valueobjects.Money{Currency: "USD"}
+	}
+	transaction, err := NewCorporateAction(f.userID, f.portfolioID, f.assetID, action, f.start.AddDate(0, 0, day))
+	require.NoError(f.t, err)
+	return transaction
+}
+
+func usd(t *testing.T, amount float64) valueobjects.Money {
+	money, err := valueobjects.NewMoney(amount, "USD")
+	require.NoError(t, err)
+	return money
+}
+
+func TestNewCorporateAction(t *testing.T) {
+	f := newLotFixture(t)
+
+	tests := []struct {
+		name    string
+		action  CorporateAction
+		wantErr bool
+	}{
+		{name: "현금 배당은 금액이 필요하다", action: CorporateAction{Type: CashDividend, Amount: usd(t, 10)}},
+		{name: "금액 없는 배당은 거부한다", action: CorporateAction{Type: CashDividend, Amount: usd(t, 0)}, wantErr: true},
+		{name: "주식 분할은 비율이 필요하다", action: CorporateAction{Type: Split, Ratio: 2}},
+		{name: "비율 없는 분할은 거부한다", action: CorporateAction{Type: Split}, wantErr: true},
+		{name: "주식 배당은 수량이 필요하다", action: CorporateAction{Type: StockDividend}, wantErr: true},
+		{name: "합병은 대상 자산이 필요하다", action: CorporateAction{Type: Merger, Ratio: 1}, wantErr: true},
+		{name: "분사 원가 배분 비율은 1 이하여야 한다", action: CorporateAction{Type: SpinOff, Ratio: 1, RelatedAssetID: uuid.New(), BasisAllocation: 1.5}, wantErr: true},
+		{name: "매수는 기업 활동이 아니다", action: CorporateAction{Type: Buy, Amount: usd(t, 10)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction, err := NewCorporateAction(f.userID, f.portfolioID, f.assetID, tt.action, f.start)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.action.Type, transaction.Type)
+			assert.NoError(t, transaction.Validate())
+			assert.Len(t, transaction.Events(), 1)
+		})
+	}
+}
+
+func TestBuildLotBook_CorporateActions(t *testing.T) {
+	t.Run("분할은 총 원가를 유지하며 수량과 단가를 조정한다", func(t *testing.T) {
+		f := newLotFixture(t)
+		buy := f.trade(Buy, 0, 10, 100)
+		split := f.action(1, CorporateAction{Type: Split, Ratio: 2})
+		sale := f.trade(Sell, 2, 5, 60)
+
+		book, err := BuildLotBook([]*Transaction{sale, split, buy}, FIFO)
+		require.NoError(t, err)
+
+		lots := book.OpenLots()
+		require.Len(t, lots, 1)
+		assert.InDelta(t, 15, lots[0].Remaining, quantityEpsilon)
+		assert.InDelta(t, 50, lots[0].UnitCost.Amount, quantityEpsilon)
+		require.Len(t, book.Realized(), 1)
+		assert.InDelta(t, 5*60-5*50, book.Realized()[0].Gain, quantityEpsilon)
+	})
+
+	t.Run("병합은 분할의 역으로 조정한다", func(t *testing.T) {
+		f := newLotFixture(t)
+		buy := f.trade(Buy, 0, 10, 100)
+		reverse := f.action(1, CorporateAction{Type: ReverseSplit, Ratio: 5})
+
+		book, err := BuildLotBook([]*Transaction{buy, reverse}, FIFO)
+		require.NoError(t, err)
+
+		lots := book.OpenLots()
+		require.Len(t, lots, 1)
+		assert.InDelta(t, 2, lots[0].Remaining, quantityEpsilon)
+		assert.InDelta(t, 500, lots[0].UnitCost.Amount, quantityEpsilon)
+	})
+
+	t.Run("주식 배당은 보유 비율대로 로트에 배분한다", func(t *testing.T) {
+		f := newLotFixture(t)
+		first := f.trade(Buy, 0, 10, 100)
+		second := f.trade(Buy, 1, 30, 200)
+		dividend := f.action(2, CorporateAction{Type: StockDividend, Quantity: 4})
+
+		book, err := BuildLotBook([]*Transaction{first, second, dividend}, FIFO)
+		require.NoError(t, err)
+
+		lots := book.OpenLots()
+		require.Len(t, lots, 2)
+		assert.InDelta(t, 11, lots[0].Remaining, quantityEpsilon)
+		assert.InDelta(t, 33, lots[1].Remaining, quantityEpsilon)
+		assert.InDelta(t, 1000, lots[0].CostBasis(), 1e-6)
+		assert.InDelta(t, 6000, lots[1].CostBasis(), 1e-6)
+	})
+
+	t.Run("현금 배당과 이자는 수입으로만 기록한다", func(t *testing.T) {
+		f := newLotFixture(t)
+		buy := f.trade(Buy, 0, 10, 100)
+		dividend := f.action(1, CorporateAction{Type: CashDividend, Amount: usd(t, 25)})
+		interest := f.action(2, CorporateAction{Type: Interest, Amount: usd(t, 5)})
+
+		book, err := BuildLotBook([]*Transaction{buy, dividend, interest}, FIFO)
+		require.NoError(t, err)
+
+		require.Len(t, book.Income(), 2)
+		assert.Equal(t, CashDividend, book.Income()[0].Type)
+		assert.Equal(t, 25.0, book.Income()[0].Amount)
+		assert.InDelta(t, 100, book.OpenLots()[0].UnitCost.Amount, quantityEpsilon)
+	})
+
+	t.Run("자본 반환은 원가를 줄이고 원가를 넘는 금액은 실현 이익이 된다", func(t *testing.T) {
+		f := newLotFixture(t)
+		cheap := f.trade(Buy, 0, 10, 10)
+		expensive := f.trade(Buy, 1, 10, 100)
+		roc := f.action(2, CorporateAction{Type: ReturnOfCapital, Amount: usd(t, 300)})
+
+		book, err := BuildLotBook([]*Transaction{cheap, expensive, roc}, FIFO)
+		require.NoError(t, err)
+
+		lots := book.OpenLots()
+		require.Len(t, lots, 2)
+		assert.InDelta(t, 0, lots[0].UnitCost.Amount, quantityEpsilon)
+		assert.InDelta(t, 85, lots[1].UnitCost.Amount, quantityEpsilon)
+		require.Len(t, book.Realized(), 1)
+		assert.InDelta(t, 50, book.Realized()[0].Gain, quantityEpsilon)
+		assert.Zero(t, book.Realized()[0].Quantity)
+	})
+
+	t.Run("합병은 로트를 대상 자산으로 전환하고 매수일을 유지한다", func(t *testing.T) {
+		f := newLotFixture(t)
+		target := uuid.New()
+		buy := f.trade(Buy, 0, 10, 100)
+		merger := f.action(1, CorporateAction{Type: Merger, Ratio: 0.5, RelatedAssetID: target, Amount: usd(t, 100)})
+
+		book, err := BuildLotBook([]*Transaction{buy, merger}, FIFO)
+		require.NoError(t, err)
+
+		lots := book.OpenLots()
+		require.Len(t, lots, 1)
+		assert.Equal(t, target, lots[0].AssetID)
+		assert.Equal(t, buy.ExecutedAt, lots[0].AcquiredAt)
+		assert.InDelta(t, 5, lots[0].Remaining, quantityEpsilon)
+		assert.InDelta(t, 900, lots[0].CostBasis(), 1e-6)
+	})
+
+	t.Run("분사는 원가를 배분해 새 자산 로트를 만든다", func(t *testing.T) {
+		f := newLotFixture(t)
+		spun := uuid.New()
+		buy := f.trade(Buy, 0, 10, 100)
+		spinOff := f.action(1, CorporateAction{Type: SpinOff, Ratio: 0.5, RelatedAssetID: spun, BasisAllocation: 0.2})
+
+		book, err := BuildLotBook([]*Transaction{buy, spinOff}, FIFO)
+		require.NoError(t, err)
+
+		lots := book.OpenLots()
+		require.Len(t, lots, 2)
+		byAsset := map[uuid.UUID]*Lot{lots[0].AssetID: lots[0], lots[1].AssetID: lots[1]}
+		require.Contains(t, byAsset, f.assetID)
+		require.Contains(t, byAsset, spun)
+		assert.InDelta(t, 800, byAsset[f.assetID].CostBasis(), 1e-6)
+		assert.InDelta(t, 5, byAsset[spun].Remaining, quantityEpsilon)
+		assert.InDelta(t, 200, byAsset[spun].CostBasis(), 1e-6)
+		assert.Equal(t, buy.ExecutedAt, byAsset[spun].AcquiredAt)
+	})
+
+	t.Run("보유 로트가 없으면 주식 배당은 에러를 반환한다", func(t *testing.T) {
+		f := newLotFixture(t)
+		dividend := f.action(0, CorporateAction{Type: StockDividend, Quantity: 1})
+
+		_, err := BuildLotBook([]*Transaction{dividend}, FIFO)
+		assert.ErrorIs(t, err, ErrInsufficientLots)
+	})
+}
+
+func TestSplits(t *testing.T) {
+	f := newLotFixture(t)
+	buy := f.trade(Buy, 0, 10, 100)
+	reverse := f.action(3, CorporateAction{Type: ReverseSplit, Ratio: 4})
+	split := f.action(1, CorporateAction{Type: Split, Ratio: 2})
+
+	splits := Splits([]*Transaction{buy, reverse, split})
+
+	require.Len(t, splits, 2)
+	assert.Equal(t, 2.0, splits[0].Factor)
+	assert.Equal(t, 0.25, splits[1].Factor)
+	assert.Equal(t, f.assetID, splits[0].AssetID)
+}
+
+func TestLastTradePrices_AdjustsForLaterSplits(t *testing.T) {
+	f := newLotFixture(t)
+	buy := f.trade(Buy, 0, 10, 100)
+	split := f.action(1, CorporateAction{Type: Split, Ratio: 4})
+
+	prices := LastTradePrices([]*Transaction{buy, split})
+
+	assert.InDelta(t, 25, prices[f.assetID].Amount, quantityEpsilon)
+}
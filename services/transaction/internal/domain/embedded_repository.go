@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/google/uuid"
+)
+
+const (
+	transactionBucket = "transactions"
+
+	indexUserID      = "user_id"
+	indexPortfolioID = "portfolio_id"
+	indexAssetID     = "asset_id"
+)
+
+var errTransactionExists = errors.New("transaction already exists")
+
+// EmbeddedTransactionRepository는 임베디드 키-값 저장소 기반의 거래 저장소 구현입니다
+// 사용자, 포트폴리오, 자산 ID 보조 인덱스를 유지합니다
+type EmbeddedTransactionRepository struct {
+	transactions *kv.Collection[*Transaction]
+}
+
+// NewEmbeddedTransactionRepository는 새로운 임베디드 거래 저장소를 생성합니다
+func NewEmbeddedTransactionRepository(db *kv.DB) (*EmbeddedTransactionRepository, error) {
+	transactions := kv.NewCollection[*Transaction](db, transactionBucket)
+	indexes := map[string]func(t *Transaction) uuid.UUID{
+		indexUserID:      func(t *Transaction) uuid.UUID { return t.UserID },
+		indexPortfolioID: func(t *Transaction) uuid.UUID { return t.PortfolioID },
+		indexAssetID:     func(t *Transaction) uuid.UUID { return t.AssetID },
+	}
+	for name, field := range indexes {
+		err := transactions.Index(name, func(t *Transaction) []string {
+			return []string{field(t).String()}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &EmbeddedTransactionRepository{transactions: transactions}, nil
+}
+
+// Save는 새로운 거래를 저장합니다
+func (r *EmbeddedTransactionRepository) Save(ctx context.Context, transaction *Transaction) error {
+	id := transaction.ID.String()
+	err := r.transactions.Update(ctx, func(tx *kv.Tx) error {
+		exists, err := r.transactions.Exists(tx, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errTransactionExists
+		}
+		if transaction.Version == 0 {
+			transaction.Version = 1
+		}
+		return r.transactions.Put(tx, id, transaction)
+	})
+	if errors.Is(err, errTransactionExists) {
+		return fmt.Errorf("transaction already exists: %s", transaction.ID)
+	}
+	return err
+}
+
+// FindByID는 ID로 거래를 조회합니다
+func (r *EmbeddedTransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*Transaction, error) {
+	var transaction *Transaction
+	var exists bool
+	err := r.transactions.View(ctx, func(tx *kv.Tx) error {
+		var err error
+		transaction, exists, err = r.transactions.Get(tx, id.String())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionNotFound, id)
+	}
+	return transaction, nil
+}
+
+// FindByUserID는 사용자 ID로 거래 목록을 조회합니다
+func (r *EmbeddedTransactionRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*Transaction, error) {
+	return r.lookup(ctx, indexUserID, userID)
+}
+
+// FindByPortfolioID는 포트폴리오 ID로 거래 목록을 조회합니다
+func (r *EmbeddedTransactionRepository) FindByPortfolioID(ctx context.Context, portfolioID uuid.UUID) ([]*Transaction, error) {
+	return r.lookup(ctx, indexPortfolioID, portfolioID)
+}
+
+// FindByAssetID는 자산 ID로 거래 목록을 조회합니다
+func (r *EmbeddedTransactionRepository) FindByAssetID(ctx context.Context, assetID uuid.UUID) ([]*Transaction, error) {
+	return r.lookup(ctx, indexAssetID, assetID)
+}
+
+// Update는 기존 거래를 업데이트합니다
+// 저장된 버전이 다르면 버전 충돌 에러를 반환하고, 성공하면 버전을 1 증가시킵니다
+func (r *EmbeddedTransactionRepository) Update(ctx context.Context, transaction *Transaction) error {
+	id := transaction.ID.String()
+	expected := transaction.Version
+	var exists bool
+	err := r.transactions.Update(ctx, func(tx *kv.Tx) error {
+		stored, found, err := r.transactions.Get(tx, id)
+		if exists = found; err != nil || !exists {
+			return err
+		}
+		if stored.Version != expected {
+			return NewTransactionVersionConflictError(transaction.ID, expected, stored.Version)
+		}
+		transaction.Version = expected + 1
+		return r.transactions.Put(tx, id, transaction)
+	})
+	if err != nil {
+		transaction.Version = expected
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrTransactionNotFound, transaction.ID)
+	}
+	return nil
+}
+
+// Delete는 거래를 삭제합니다
+func (r *EmbeddedTransactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	var exists bool
+	err := r.transactions.Update(ctx, func(tx *kv.Tx) error {
+		var err error
+		if exists, err = r.transactions.Exists(tx, id.String()); err != nil || !exists {
+			return err
+		}
+		return r.transactions.Delete(tx, id.String())
+	})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrTransactionNotFound, id)
+	}
+	return nil
+}
+
+func (r *EmbeddedTransactionRepository) lookup(ctx context.Context, index string, id uuid.UUID) ([]*Transaction, error) {
+	var transactions []*Transaction
+	err := r.transactions.View(ctx, func(tx *kv.Tx) error {
+		var err error
+		transactions, err = r.transactions.Lookup(tx, index, id.String())
+		return err
+	})
+	return transactions, err
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/aske/go_fi_chart/pkg/storage/kv"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedTransactionRepository_PersistsAcrossRestart(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "transaction.kv")
+	db, err := kv.Open(path, nil)
+	require.NoError(t, err)
+	repo, err := NewEmbeddedTransactionRepository(db)
+	require.NoError(t, err)
+
+	transaction := createTestTransaction()
+	require.NoError(t, repo.Save(ctx, transaction))
+	require.NoError(t, db.Close())
+
+	// When
+	db, err = kv.Open(path, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	repo, err = NewEmbeddedTransactionRepository(db)
+	require.NoError(t, err)
+
+	// Then
+	found, err := repo.FindByID(ctx, transaction.ID)
+	require.NoError(t, err)
+	assert.Equal(t, transaction.Amount, found.Amount)
+	assert.Equal(t, transaction.Quantity, found.Quantity)
+	assert.True(t, transaction.ExecutedAt.Equal(found.ExecutedAt))
+
+	byPortfolio, err := repo.FindByPortfolioID(ctx, transaction.PortfolioID)
+	require.NoError(t, err)
+	assert.Len(t, byPortfolio, 1)
+}
+
+func TestEmbeddedTransactionRepository_Indexes(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo, err := NewEmbeddedTransactionRepository(kv.OpenMemory())
+	require.NoError(t, err)
+
+	first := createTestTransaction()
+	second := createTestTransaction()
+	second.UserID = first.UserID
+	require.NoError(t, repo.Save(ctx, first))
+	require.NoError(t, repo.Save(ctx, second))
+
+	// When
+	second.AssetID = first.AssetID
+	require.NoError(t, repo.Update(ctx, second))
+	require.NoError(t, repo.Delete(ctx, first.ID))
+
+	// Then
+	byUser, err := repo.FindByUserID(ctx, first.UserID)
+	require.NoError(t, err)
+	require.Len(t, byUser, 1)
+	assert.Equal(t, second.ID, byUser[0].ID)
+
+	byAsset, err := repo.FindByAssetID(ctx, first.AssetID)
+	require.NoError(t, err)
+	require.Len(t, byAsset, 1)
+	assert.Equal(t, second.ID, byAsset[0].ID)
+}
+
+func TestEmbeddedTransactionRepository_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewEmbeddedTransactionRepository(kv.OpenMemory())
+	require.NoError(t, err)
+
+	_, err = repo.FindByID(ctx, uuid.New())
+	assert.True(t, errors.Is(err, ErrTransactionNotFound))
+	assert.Error(t, repo.Update(ctx, createTestTransaction()))
+	assert.Error(t, repo.Delete(ctx, uuid.New()))
+}
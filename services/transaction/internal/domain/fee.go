@@ -0,0 +1,257 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+)
+
+// ChargeType은 거래에 부과되는 수수료/세금 항목입니다
+type ChargeType string
+
+const (
+	Commission     ChargeType = "COMMISSION"      // 중개 수수료
+	ExchangeFee    ChargeType = "EXCHANGE_FEE"    // 거래소/청산 수수료
+	FXSpread       ChargeType = "FX_SPREAD"       // 환전 스프레드
+	TransactionTax ChargeType = "TRANSACTION_TAX" // 증권거래세
+	WithholdingTax ChargeType = "WITHHOLDING_TAX" // 배당/이자 원천징수세
+)
+
+var (
+	ErrInvalidCharge       = errors.New("invalid charge")
+	ErrFeeScheduleNotFound = errors.New("fee schedule not found")
+	ErrInvalidFeeSchedule  = errors.New("invalid fee schedule")
+)
+
+// IsTax는 세금 항목인지 확인합니다
+func (c ChargeType) IsTax() bool {
+	return c == TransactionTax || c == WithholdingTax
+}
+
+func (c ChargeType) valid() bool {
+	switch c {
+	case Commission, ExchangeFee, FXSpread, TransactionTax, WithholdingTax:
+		return true
+	default:
+		return false
+	}
+}
+
+// Charge는 거래에 부과된 수수료/세금 한 항목입니다
+type Charge struct {
+	Type   ChargeType
+	Amount valueobjects.Money
+}
+
+// SetCharges는 거래의 수수료/세금 항목을 교체합니다. nil이면 모두 제거합니다
+// 항목의 통화는 거래 금액의 통화와 같아야 합니다
+func (t *Transaction) SetCharges(charges []Charge) error {
+	for _, charge := range charges {
+		if !charge.Type.valid() {
+			return fmt.Errorf("%w: unknown type %q", ErrInvalidCharge, charge.Type)
+		}
+		if charge.Amount.IsNegative() {
+			return fmt.Errorf("%w: %s amount must not be negative", ErrInvalidCharge, charge.Type)
+		}
+		if charge.Amount.Currency != t.Amount.Currency {
+			return fmt.Errorf("%w: %s currency %s != %s", ErrInvalidCharge, charge.Type, charge.Amount.Currency, t.Amount.Currency)
+		}
+	}
+	if len(charges) == 0 {
+		t.Charges = nil
+		return nil
+	}
+	t.Charges = append([]Charge(nil), charges...)
+	return nil
+}
+
+// ApplyFeeSchedule은 수수료 체계로 계산한 항목을 거래의 수수료/세금으로 설정합니다
+func (t *Transaction) ApplyFeeSchedule(schedule FeeSchedule) error {
+	return t.SetCharges(schedule.Charges(t))
+}
+
+// TotalCharges는 수수료와 세금의 합계를 반환합니다
+func (t *Transaction) TotalCharges() valueobjects.Money {
+	return t.sumCharges(func(ChargeType) bool { return true })
+}
+
+// TotalFees는 세금을 제외한 수수료 합계를 반환합니다
+func (t *Transaction) TotalFees() valueobjects.Money {
+	return t.sumCharges(func(c ChargeType) bool { return !c.IsTax() })
+}
+
+// TotalTaxes는 세금 합계를 반환합니다
+func (t *Transaction) TotalTaxes() valueobjects.Money {
+	return t.sumCharges(ChargeType.IsTax)
+}
+
+func (t *Transaction) sumCharges(include func(ChargeType) bool) valueobjects.Money {
+	total := valueobjects.Money{Currency: t.Amount.Currency}
+	for _, charge := range t.Charges {
+		if include(charge.Type) {
+			total.Amount += charge.Amount.Amount
+		}
+	}
+	return total
+}
+
+// CostBasis는 수수료와 세금을 포함한 매수 원가입니다
+func (t *Transaction) CostBasis() valueobjects.Money {
+	return valueobjects.Money{Amount: t.Amount.Amount + t.TotalCharges().Amount, Currency: t.Amount.Currency}
+}
+
+// NetProceeds는 매도 대금이나 수입에서 수수료와 세금을 뺀 순수령액입니다
+// 공제액이 금액보다 크면 0입니다
+func (t *Transaction) NetProceeds() valueobjects.Money {
+	net := math.Max(t.Amount.Amount-t.TotalCharges().Amount, 0)
+	return valueobjects.Money{Amount: net, Currency: t.Amount.Currency}
+}
+
+// FeeRule은 수수료 체계의 한 항목을 계산하는 규칙입니다
+// 금액은 Rate×거래 금액 + PerUnit×수량 + Fixed이며, Minimum/Maximum이 0보다 크면 그 범위로 제한합니다
+type FeeRule struct {
+	Type      ChargeType        `json:"type"`
+	AppliesTo []TransactionType `json:"appliesTo,omitempty"` // 비어 있으면 매수와 매도에 적용합니다
+	Rate      float64           `json:"rate,omitempty"`
+	PerUnit   float64           `json:"perUnit,omitempty"`
+	Fixed     float64           `json:"fixed,omitempty"`
+	Minimum   float64           `json:"minimum,omitempty"`
+	Maximum   float64           `json:"maximum,omitempty"`
+}
+
+func (r FeeRule) appliesTo(transactionType TransactionType) bool {
+	if len(r.AppliesTo) == 0 {
+		return transactionType.IsTrade()
+	}
+	for _, t := range r.AppliesTo {
+		if t == transactionType {
+			return true
+		}
+	}
+	return false
+}
+
+// Calculate는 거래 금액과 수량에 대한 부과액을 계산합니다
+func (r FeeRule) Calculate(amount, quantity float64) float64 {
+	fee := r.Rate*amount + r.PerUnit*quantity + r.Fixed
+	if r.Minimum > 0 && fee < r.Minimum {
+		fee = r.Minimum
+	}
+	if r.Maximum > 0 && fee > r.Maximum {
+		fee = r.Maximum
+	}
+	return fee
+}
+
+// FeeSchedule은 증권사나 시장별 수수료/세금 체계입니다
+type FeeSchedule struct {
+	Name  string    `json:"name"`
+	Rules []FeeRule `json:"rules"`
+}
+
+// Validate는 수수료 체계의 규칙을 검증합니다
+func (s FeeSchedule) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidFeeSchedule)
+	}
+	for _, rule := range s.Rules {
+		if !rule.Type.valid() {
+			return fmt.Errorf("%w: %s has unknown charge type %q", ErrInvalidFeeSchedule, s.Name, rule.Type)
+		}
+		if rule.Rate < 0 || rule.PerUnit < 0 || rule.Fixed < 0 || rule.Minimum < 0 || rule.Maximum < 0 {
+			return fmt.Errorf("%w: %s %s must not be negative", ErrInvalidFeeSchedule, s.Name, rule.Type)
+		}
+		if rule.Maximum > 0 && rule.Minimum > rule.Maximum {
+			return fmt.Errorf("%w: %s %s minimum exceeds maximum", ErrInvalidFeeSchedule, s.Name, rule.Type)
+		}
+	}
+	return nil
+}
+
+// Charges는 거래에 적용되는 규칙으로 수수료/세금 항목을 계산합니다
+// 금액이 0인 항목은 제외합니다
+func (s FeeSchedule) Charges(t *Transaction) []Charge {
+	var charges []Charge
+	for _, rule := range s.Rules {
+		if !rule.appliesTo(t.Type) {
+			continue
+		}
+		amount := rule.Calculate(t.Amount.Amount, t.Quantity)
+		if amount <= 0 {
+			continue
+		}
+		charges = append(charges, Charge{
+			Type:   rule.Type,
+			Amount: valueobjects.Money{Amount: amount, Currency: t.Amount.Currency},
+		})
+	}
+	return charges
+}
+
+// FeeSchedules는 이름으로 조회하는 수수료 체계 목록입니다
+type FeeSchedules struct {
+	mu        sync.RWMutex
+	schedules map[string]FeeSchedule
+}
+
+// NewFeeSchedules는 수수료 체계 목록을 생성합니다
+func NewFeeSchedules(schedules ...FeeSchedule) (*FeeSchedules, error) {
+	s := &FeeSchedules{schedules: make(map[string]FeeSchedule)}
+	for _, schedule := range schedules {
+		if err := s.Register(schedule); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// LoadFeeSchedules는 JSON 배열로 된 수수료 체계 설정을 읽습니다
+func LoadFeeSchedules(r io.Reader) (*FeeSchedules, error) {
+	var schedules []FeeSchedule
+	if err := json.NewDecoder(r).Decode(&schedules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFeeSchedule, err)
+	}
+	return NewFeeSchedules(schedules...)
+}
+
+// Register는 수수료 체계를 등록합니다. 같은 이름이 있으면 교체합니다
+func (s *FeeSchedules) Register(schedule FeeSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedules[schedule.Name] = schedule
+	return nil
+}
+
+// Get은 이름으로 수수료 체계를 조회합니다
+func (s *FeeSchedules) Get(name string) (FeeSchedule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	schedule, ok := s.schedules[name]
+	if !ok {
+		return FeeSchedule{}, fmt.Errorf("%w: %s", ErrFeeScheduleNotFound, name)
+	}
+	return schedule, nil
+}
+
+// List는 등록된 수수료 체계를 이름 순으로 반환합니다
+func (s *FeeSchedules) List() []FeeSchedule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	schedules := make([]FeeSchedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedules = append(schedules, schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].Name < schedules[j].Name
+	})
+	return schedules
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_SetCharges(t *testing.T) {
+	f := newLotFixture(t)
+
+	t.Run("수수료와 세금을 항목별로 합산한다", func(t *testing.T) {
+		transaction := f.trade(Sell, 0, 10, 100)
+		require.NoError(t, transaction.SetCharges([]Charge{
+			{Type: Commission, Amount: usd(t, 5)},
+			{Type: ExchangeFee, Amount: usd(t, 1)},
+			{Type: TransactionTax, Amount: usd(t, 2)},
+		}))
+
+		assert.Equal(t, 8.0, transaction.TotalCharges().Amount)
+		assert.Equal(t, 6.0, transaction.TotalFees().Amount)
+		assert.Equal(t, 2.0, transaction.TotalTaxes().Amount)
+		assert.Equal(t, 992.0, transaction.NetProceeds().Amount)
+		assert.Equal(t, 992.0, transaction.CalculateTotalAmount().Amount)
+	})
+
+	t.Run("매수 총액은 수수료를 포함한다", func(t *testing.T) {
+		transaction := f.trade(Buy, 0, 10, 100)
+		require.NoError(t, transaction.SetCharges([]Charge{{Type: Commission, Amount: usd(t, 5)}}))
+
+		assert.Equal(t, 1005.0, transaction.CostBasis().Amount)
+		assert.Equal(t, 1005.0, transaction.CalculateTotalAmount().Amount)
+	})
+
+	t.Run("통화가 다른 항목은 거부한다", func(t *testing.T) {
+		transaction := f.trade(Buy, 0, 10, 100)
+		charge := Charge{Type: Commission, Amount: usd(t, 5)}
+		charge.Amount.Currency = "KRW"
+
+		assert.ErrorIs(t, transaction.SetCharges([]Charge{charge}), ErrInvalidCharge)
+	})
+
+	t.Run("알 수 없는 항목은 거부한다", func(t *testing.T) {
+		transaction := f.trade(Buy, 0, 10, 100)
+
+		assert.ErrorIs(t, transaction.SetCharges([]Charge{{Type: "TIP", Amount: usd(t, 1)}}), ErrInvalidCharge)
+	})
+}
+
+func TestFeeSchedule_Charges(t *testing.T) {
+	f := newLotFixture(t)
+	schedule := FeeSchedule{
+		Name: "KRX",
+		Rules: []FeeRule{
+			{Type: Commission, Rate: 0.001, Minimum: 3, Maximum: 50},
+			{Type: TransactionTax, AppliesTo: []TransactionType{Sell}, Rate: 0.002},
+			{Type: WithholdingTax, AppliesTo: []TransactionType{CashDividend}, Rate: 0.15},
+		},
+	}
+	require.NoError(t, schedule.Validate())
+
+	tests := []struct {
+		name        string
+		transaction *Transaction
+		want        map[ChargeType]float64
+	}{
+		{
+			name:        "소액 매수는 최소 수수료를 적용한다",
+			transaction: f.trade(Buy, 0, 10, 100),
+			want:        map[ChargeType]float64{Commission: 3},
+		},
+		{
+			name:        "매도에는 거래세를 부과하고 수수료는 최대값으로 제한한다",
+			transaction: f.trade(Sell, 0, 1000, 100),
+			want:        map[ChargeType]float64{Commission: 50, TransactionTax: 200},
+		},
+		{
+			name:        "배당에는 원천징수세만 부과한다",
+			transaction: f.action(0, CorporateAction{Type: CashDividend, Amount: usd(t, 100)}),
+			want:        map[ChargeType]float64{WithholdingTax: 15},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charges := schedule.Charges(tt.transaction)
+
+			got := make(map[ChargeType]float64)
+			for _, charge := range charges {
+				assert.Equal(t, "USD", charge.Amount.Currency)
+				got[charge.Type] = charge.Amount.Amount
+			}
+			assert.InDeltaMapValues(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestBuildLotBook_Charges(t *testing.T) {
+	f := newLotFixture(t)
+	buy := f.trade(Buy, 0, 10, 100)
+	require.NoError(t, buy.SetCharges([]Charge{{Type: Commission, Amount: usd(t, 10)}}))
+	sale := f.trade(Sell, 1, 5, 200)
+	require.NoError(t, sale.SetCharges([]Charge{
+		{Type: Commission, Amount: usd(t, 5)},
+		{Type: TransactionTax, Amount: usd(t, 5)},
+	}))
+	dividend := f.action(2, CorporateAction{Type: CashDividend, Amount: usd(t, 20)})
+	require.NoError(t, dividend.SetCharges([]Charge{{Type: WithholdingTax, Amount: usd(t, 3)}}))
+
+	book, err := BuildLotBook([]*Transaction{buy, sale, dividend}, FIFO)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 101, book.OpenLots()[0].UnitCost.Amount, 1e-9)
+	require.Len(t, book.Realized(), 1)
+	assert.InDelta(t, 990, book.Realized()[0].Proceeds, 1e-9)
+	assert.InDelta(t, 990-505, book.Realized()[0].Gain, 1e-9)
+	require.Len(t, book.Income(), 1)
+	assert.InDelta(t, 17, book.Income()[0].Net(), 1e-9)
+}
+
+func TestLoadFeeSchedules(t *testing.T) {
+	t.Run("JSON 설정을 읽는다", func(t *testing.T) {
+		schedules, err := LoadFeeSchedules(strings.NewReader(`[
+			{"name": "broker-a", "rules": [{"type": "COMMISSION", "fixed": 1}]},
+			{"name": "KRX", "rules": [{"type": "TRANSACTION_TAX", "appliesTo": ["SELL"], "rate": 0.0018}]}
+		]`))
+		require.NoError(t, err)
+
+		schedule, err := schedules.Get("KRX")
+		require.NoError(t, err)
+		assert.Equal(t, 0.0018, schedule.Rules[0].Rate)
+		assert.Len(t, schedules.List(), 2)
+		assert.Equal(t, "KRX", schedules.List()[0].Name)
+	})
+
+	t.Run("음수 요율은 거부한다", func(t *testing.T) {
+		_, err := LoadFeeSchedules(strings.NewReader(`[{"name": "bad", "rules": [{"type": "COMMISSION", "rate": -1}]}]`))
+		assert.ErrorIs(t, err, ErrInvalidFeeSchedule)
+	})
+
+	t.Run("없는 수수료 체계는 에러를 반환한다", func(t *testing.T) {
+		schedules, err := NewFeeSchedules()
+		require.NoError(t, err)
+
+		_, err = schedules.Get("missing")
+		assert.ErrorIs(t, err, ErrFeeScheduleNotFound)
+	})
+}
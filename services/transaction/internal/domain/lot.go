@@ -0,0 +1,404 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/google/uuid"
+)
+
+// CostBasisMethod는 매도를 매수 로트에 대응시키는 원가 산정 방식입니다
+type CostBasisMethod string
+
+const (
+	FIFO        CostBasisMethod = "FIFO"     // 먼저 매수한 로트부터 소진합니다
+	LIFO        CostBasisMethod = "LIFO"     // 나중에 매수한 로트부터 소진합니다
+	AverageCost CostBasisMethod = "AVERAGE"  // 보유 로트의 평균 단가를 원가로 사용합니다
+	SpecificLot CostBasisMethod = "SPECIFIC" // 매도 거래가 지정한 로트를 소진합니다
+)
+
+// quantityEpsilon은 부분 체결 수량을 비교할 때 허용하는 부동소수점 오차입니다
+const quantityEpsilon = 1e-9
+
+var (
+	// ErrInsufficientLots는 매도 수량이 보유 로트 수량을 넘을 때 반환됩니다
+	ErrInsufficientLots = errors.New("sell quantity exceeds open lots")
+	// ErrLotNotFound는 매도가 지정한 로트가 없거나 이미 소진되었을 때 반환됩니다
+	ErrLotNotFound = errors.New("lot not found")
+	// ErrInvalidLotSelection은 로트 지정이 매도 수량과 맞지 않을 때 반환됩니다
+	ErrInvalidLotSelection = errors.New("invalid lot selection")
+)
+
+// ParseCostBasisMethod는 문자열을 원가 산정 방식으로 변환합니다. 빈 문자열은 FIFO입니다
+func ParseCostBasisMethod(s string) (CostBasisMethod, error) {
+	if s == "" {
+		return FIFO, nil
+	}
+	method := CostBasisMethod(strings.ToUpper(s))
+	switch method {
+	case FIFO, LIFO, AverageCost, SpecificLot:
+		return method, nil
+	default:
+		return "", fmt.Errorf("unknown cost basis method: %s", s)
+	}
+}
+
+// LotSelection은 SPECIFIC 방식에서 매도가 소진할 매수 로트와 수량입니다
+type LotSelection struct {
+	LotID    uuid.UUID
+	Quantity float64
+}
+
+// Lot은 매수 거래 하나로 생긴 보유 로트입니다. ID는 매수 거래의 ID입니다
+type Lot struct {
+	ID          uuid.UUID
+	PortfolioID uuid.UUID
+	AssetID     uuid.UUID
+	AcquiredAt  time.Time
+	Quantity    float64 // 매수 수량
+	Remaining   float64 // 아직 매도되지 않은 수량
+	UnitCost    valueobjects.Money
+}
+
+// CostBasis는 남은 수량의 취득 원가를 반환합니다
+func (l *Lot) CostBasis() float64 {
+	return l.Remaining * l.UnitCost.Amount
+}
+
+// LotMatch는 매도 하나가 소진한 로트와 수량입니다
+type LotMatch struct {
+	LotID    uuid.UUID
+	Quantity float64
+	UnitCost float64
+}
+
+// RealizedGain은 매도 하나의 실현 손익입니다
+type RealizedGain struct {
+	SaleID    uuid.UUID
+	AssetID   uuid.UUID
+	SoldAt    time.Time
+	Quantity  float64
+	Proceeds  float64 // 수수료와 세금을 뺀 순매도 대금
+	CostBasis float64
+	Gain      float64
+	Currency  string
+	Matches   []LotMatch
+}
+
+// UnrealizedGain은 보유 로트 하나의 평가 손익입니다
+type UnrealizedGain struct {
+	LotID       uuid.UUID
+	AssetID     uuid.UUID
+	Quantity    float64
+	CostBasis   float64
+	MarketPrice float64
+	MarketValue float64
+	Gain        float64
+	Currency    string
+}
+
+// LotBook은 포트폴리오 거래 내역을 자산별 로트로 정리한 결과입니다
+type LotBook struct {
+	method   CostBasisMethod
+	lots     map[uuid.UUID][]*Lot // 자산 ID -> 매수 순서의 보유 로트
+	realized []RealizedGain
+	income   []IncomeRecord
+}
+
+// BuildLotBook은 거래를 체결 시각 순으로 재생해 로트와 실현 손익을 계산합니다
+// 매수는 새 로트가 되고, 매도는 method에 따라 로트를 소진하며 부분 소진도 허용합니다
+// 기업 활동은 체결 시점의 보유 로트 수량과 원가를 조정합니다
+// SPECIFIC 방식에서 로트를 지정하지 않은 매도는 FIFO로 대응시킵니다
+func BuildLotBook(transactions []*Transaction, method CostBasisMethod) (*LotBook, error) {
+	ordered := make([]*Transaction, len(transactions))
+	copy(ordered, transactions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if !a.ExecutedAt.Equal(b.ExecutedAt) {
+			return a.ExecutedAt.Before(b.ExecutedAt)
+		}
+		// 같은 시각에는 매수, 기업 활동, 매도 순으로 반영합니다
+		return replayOrder(a.Type) < replayOrder(b.Type)
+	})
+
+	book := &LotBook{method: method, lots: make(map[uuid.UUID][]*Lot)}
+	for _, t := range ordered {
+		var err error
+		switch t.Type {
+		case Buy:
+			err = book.buy(t)
+		case Sell:
+			err = book.sell(t)
+		default:
+			if t.Type.IsCorporateAction() {
+				err = book.applyCorporateAction(t)
+				break
+			}
+			err = fmt.Errorf("invalid transaction type %q: %s", t.Type, t.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if method == AverageCost {
+		for _, lots := range book.lots {
+			averageLots(lots)
+		}
+	}
+	return book, nil
+}
+
+// Method는 로트 계산에 사용한 원가 산정 방식을 반환합니다
+func (b *LotBook) Method() CostBasisMethod {
+	return b.method
+}
+
+// OpenLots는 남은 수량이 있는 로트를 매수 시각 순으로 반환합니다
+func (b *LotBook) OpenLots() []*Lot {
+	var open []*Lot
+	for _, lots := range b.lots {
+		open = append(open, lots...)
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		return open[i].AcquiredAt.Before(open[j].AcquiredAt)
+	})
+	return open
+}
+
+// Realized는 매도별 실현 손익을 체결 시각 순으로 반환합니다
+func (b *LotBook) Realized() []RealizedGain {
+	return b.realized
+}
+
+// Income은 배당과 이자 수입을 체결 시각 순으로 반환합니다
+func (b *LotBook) Income() []IncomeRecord {
+	return b.income
+}
+
+// Unrealized는 자산별 시장 가격으로 보유 로트의 평가 손익을 계산합니다
+// 가격이 없는 자산의 로트는 결과에서 제외됩니다
+func (b *LotBook) Unrealized(prices map[uuid.UUID]valueobjects.Money) ([]UnrealizedGain, error) {
+	var gains []UnrealizedGain
+	for _, lot := range b.OpenLots() {
+		price, ok := prices[lot.AssetID]
+		if !ok {
+			continue
+		}
+		if price.Currency != lot.UnitCost.Currency {
+			return nil, fmt.Errorf("currency mismatch for asset %s: %s != %s", lot.AssetID, price.Currency, lot.UnitCost.Currency)
+		}
+		marketValue := lot.Remaining * price.Amount
+		gains = append(gains, UnrealizedGain{
+			LotID:       lot.ID,
+			AssetID:     lot.AssetID,
+			Quantity:    lot.Remaining,
+			CostBasis:   lot.CostBasis(),
+			MarketPrice: price.Amount,
+			MarketValue: marketValue,
+			Gain:        marketValue - lot.CostBasis(),
+			Currency:    price.Currency,
+		})
+	}
+	return gains, nil
+}
+
+// LastTradePrices는 자산별 가장 최근 매수/매도 체결 가격을 반환합니다
+// 가장 최근 매매 이후의 분할·병합은 가격에 반영합니다
+func LastTradePrices(transactions []*Transaction) map[uuid.UUID]valueobjects.Money {
+	prices := make(map[uuid.UUID]valueobjects.Money)
+	latest := make(map[uuid.UUID]time.Time)
+	for _, t := range transactions {
+		if !t.Type.IsTrade() {
+			continue
+		}
+		if at, ok := latest[t.AssetID]; ok && t.ExecutedAt.Before(at) {
+			continue
+		}
+		latest[t.AssetID] = t.ExecutedAt
+		prices[t.AssetID] = t.ExecutedPrice
+	}
+	for _, split := range Splits(transactions) {
+		price, ok := prices[split.AssetID]
+		if !ok || split.ExecutedAt.Before(latest[split.AssetID]) {
+			continue
+		}
+		price.Amount /= split.Factor
+		prices[split.AssetID] = price
+	}
+	return prices
+}
+
+// replayOrder는 같은 시각에 체결된 거래의 반영 순서입니다
+func replayOrder(t TransactionType) int {
+	switch t {
+	case Buy:
+		return 0
+	case Sell:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func (b *LotBook) buy(t *Transaction) error {
+	// 매수 수수료와 세금은 취득 원가에 포함합니다
+	unitCost, err := t.CostBasis().Divide(t.Quantity)
+	if err != nil {
+		return err
+	}
+	if lots := b.lots[t.AssetID]; len(lots) > 0 && lots[0].UnitCost.Currency != unitCost.Currency {
+		return fmt.Errorf("currency mismatch for asset %s: %s != %s", t.AssetID, unitCost.Currency, lots[0].UnitCost.Currency)
+	}
+
+	b.lots[t.AssetID] = append(b.lots[t.AssetID], &Lot{
+		ID:          t.ID,
+		PortfolioID: t.PortfolioID,
+		AssetID:     t.AssetID,
+		AcquiredAt:  t.ExecutedAt,
+		Quantity:    t.Quantity,
+		Remaining:   t.Quantity,
+		UnitCost:    unitCost,
+	})
+	return nil
+}
+
+func (b *LotBook) sell(t *Transaction) error {
+	lots := b.lots[t.AssetID]
+	open := openQuantity(lots)
+	if t.Quantity > open+quantityEpsilon {
+		return fmt.Errorf("%w: sale %s sells %g of %g", ErrInsufficientLots, t.ID, t.Quantity, open)
+	}
+	if len(lots) > 0 && lots[0].UnitCost.Currency != t.Amount.Currency {
+		return fmt.Errorf("currency mismatch for asset %s: %s != %s", t.AssetID, t.Amount.Currency, lots[0].UnitCost.Currency)
+	}
+
+	var matches []LotMatch
+	var err error
+	switch {
+	case b.method == SpecificLot && len(t.Lots) > 0:
+		matches, err = consumeSelected(lots, t.Lots, t.Quantity)
+	case b.method == LIFO:
+		matches = consumeInOrder(lots, t.Quantity, true)
+	case b.method == AverageCost:
+		averageLots(lots)
+		matches = consumeInOrder(lots, t.Quantity, false)
+	default:
+		matches = consumeInOrder(lots, t.Quantity, false)
+	}
+	if err != nil {
+		return fmt.Errorf("sale %s: %w", t.ID, err)
+	}
+
+	var costBasis float64
+	for _, match := range matches {
+		costBasis += match.Quantity * match.UnitCost
+	}
+	// 매도 수수료와 세금을 뺀 순매도 대금으로 손익을 계산합니다
+	proceeds := t.NetProceeds().Amount
+	b.realized = append(b.realized, RealizedGain{
+		SaleID:    t.ID,
+		AssetID:   t.AssetID,
+		SoldAt:    t.ExecutedAt,
+		Quantity:  t.Quantity,
+		Proceeds:  proceeds,
+		CostBasis: costBasis,
+		Gain:      proceeds - costBasis,
+		Currency:  t.Amount.Currency,
+		Matches:   matches,
+	})
+	b.lots[t.AssetID] = removeClosed(lots)
+	return nil
+}
+
+// consumeInOrder는 로트를 매수 순(또는 역순)으로 quantity만큼 소진합니다
+func consumeInOrder(lots []*Lot, quantity float64, reverse bool) []LotMatch {
+	var matches []LotMatch
+	for i := range lots {
+		if quantity <= quantityEpsilon {
+			break
+		}
+		lot := lots[i]
+		if reverse {
+			lot = lots[len(lots)-1-i]
+		}
+		take := math.Min(lot.Remaining, quantity)
+		lot.Remaining -= take
+		quantity -= take
+		matches = append(matches, LotMatch{LotID: lot.ID, Quantity: take, UnitCost: lot.UnitCost.Amount})
+	}
+	return matches
+}
+
+// consumeSelected는 매도가 지정한 로트를 지정한 수량만큼 소진합니다
+// 지정 수량의 합은 매도 수량과 같아야 합니다
+func consumeSelected(lots []*Lot, selections []LotSelection, quantity float64) ([]LotMatch, error) {
+	if err := validateSelections(selections, quantity); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*Lot, len(lots))
+	for _, lot := range lots {
+		byID[lot.ID] = lot
+	}
+
+	matches := make([]LotMatch, 0, len(selections))
+	for _, selection := range selections {
+		lot, ok := byID[selection.LotID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrLotNotFound, selection.LotID)
+		}
+		if selection.Quantity > lot.Remaining+quantityEpsilon {
+			return nil, fmt.Errorf("%w: lot %s has %g remaining", ErrInvalidLotSelection, lot.ID, lot.Remaining)
+		}
+		take := math.Min(lot.Remaining, selection.Quantity)
+		lot.Remaining -= take
+		matches = append(matches, LotMatch{LotID: lot.ID, Quantity: take, UnitCost: lot.UnitCost.Amount})
+	}
+	return matches, nil
+}
+
+// validateSelections는 로트 지정 수량이 양수이고 합이 매도 수량과 같은지 검증합니다
+func validateSelections(selections []LotSelection, quantity float64) error {
+	var total float64
+	for _, selection := range selections {
+		if selection.LotID == uuid.Nil || selection.Quantity <= 0 {
+			return fmt.Errorf("%w: lot ID and positive quantity are required", ErrInvalidLotSelection)
+		}
+		total += selection.Quantity
+	}
+	if math.Abs(total-quantity) > quantityEpsilon {
+		return fmt.Errorf("%w: selected %g of %g", ErrInvalidLotSelection, total, quantity)
+	}
+	return nil
+}
+
+// averageLots는 보유 로트의 단가를 수량 가중 평균 단가로 맞춥니다
+func averageLots(lots []*Lot) {
+	var quantity, cost float64
+	for _, lot := range lots {
+		quantity += lot.Remaining
+		cost += lot.CostBasis()
+	}
+	if quantity <= quantityEpsilon {
+		return
+	}
+	for _, lot := range lots {
+		lot.UnitCost.Amount = cost / quantity
+	}
+}
+
+// removeClosed는 모두 소진된 로트를 제외합니다
+func removeClosed(lots []*Lot) []*Lot {
+	open := lots[:0]
+	for _, lot := range lots {
+		if lot.Remaining > quantityEpsilon {
+			open = append(open, lot)
+		}
+	}
+	return open
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lotFixture는 한 포트폴리오의 한 자산에 대한 거래를 만듭니다.
+type lotFixture struct {
+	t           *testing.T
+	userID      uuid.UUID
+	portfolioID uuid.UUID
+	assetID     uuid.UUID
+	start       time.Time
+}
+
+func newLotFixture(t *testing.T) *lotFixture {
+	return &lotFixture{
+		t:           t,
+		userID:      uuid.New(),
+		portfolioID: uuid.New(),
+		assetID:     uuid.New(),
+		start:       time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+// trade는 day일째에 quantity를 price 단가로 체결한 거래를 만듭니다.
+func (f *lotFixture) trade(transactionType TransactionType, day int, quantity, price float64) *Transaction {
+	amount, err := valueobjects.NewMoney(quantity*price, "USD")
+	require.NoError(f.t, err)
+	executedPrice, err := valueobjects.NewMoney(price, "USD")
+	require.NoError(f.t, err)
+	transaction, err := NewTransaction(f.userID, f.portfolioID, f.assetID, transactionType, amount, quantity, executedPrice, f.start.AddDate(0, 0, day))
+	require.NoError(f.t, err)
+	return transaction
+}
+
+func TestBuildLotBook(t *testing.T) {
+	f := newLotFixture(t)
+	first := f.trade(Buy, 0, 10, 100)
+	second := f.trade(Buy, 1, 10, 200)
+	sale := f.trade(Sell, 2, 15, 300)
+	transactions := []*Transaction{sale, second, first}
+
+	tests := []struct {
+		name          string
+		method        CostBasisMethod
+		wantCostBasis float64
+		wantOpenLot   uuid.UUID
+		wantUnitCost  float64
+	}{
+		{name: "FIFO는 먼저 매수한 로트부터 소진한다", method: FIFO, wantCostBasis: 10*100 + 5*200, wantOpenLot: second.ID, wantUnitCost: 200},
+		{name: "LIFO는 나중에 매수한 로트부터 소진한다", method: LIFO, wantCostBasis: 10*200 + 5*100, wantOpenLot: first.ID, wantUnitCost: 100},
+		{name: "평균 단가는 보유 로트의 가중 평균을 사용한다", method: AverageCost, wantCostBasis: 15 * 150, wantOpenLot: second.ID, wantUnitCost: 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book, err := BuildLotBook(transactions, tt.method)
+			require.NoError(t, err)
+
+			realized := book.Realized()
+			require.Len(t, realized, 1)
+			assert.Equal(t, sale.ID, realized[0].SaleID)
+			assert.InDelta(t, 4500.0, realized[0].Proceeds, 1e-9)
+			assert.InDelta(t, tt.wantCostBasis, realized[0].CostBasis, 1e-9)
+			assert.InDelta(t, 4500-tt.wantCostBasis, realized[0].Gain, 1e-9)
+
+			open := book.OpenLots()
+			require.Len(t, open, 1)
+			assert.Equal(t, tt.wantOpenLot, open[0].ID)
+			assert.InDelta(t, 5.0, open[0].Remaining, 1e-9)
+			assert.InDelta(t, tt.wantUnitCost, open[0].UnitCost.Amount, 1e-9)
+		})
+	}
+}
+
+func TestBuildLotBook_SpecificLot(t *testing.T) {
+	f := newLotFixture(t)
+	first := f.trade(Buy, 0, 10, 100)
+	second := f.trade(Buy, 1, 10, 200)
+	sale := f.trade(Sell, 2, 6, 300)
+	require.NoError(t, sale.SelectLots([]LotSelection{
+		{LotID: second.ID, Quantity: 4},
+		{LotID: first.ID, Quantity: 2},
+	}))
+	// 로트를 지정하지 않은 매도는 FIFO로 대응됩니다
+	unspecified := f.trade(Sell, 3, 3, 300)
+
+	book, err := BuildLotBook([]*Transaction{first, second, sale, unspecified}, SpecificLot)
+	require.NoError(t, err)
+
+	realized := book.Realized()
+	require.Len(t, realized, 2)
+	assert.InDelta(t, 4*200+2*100, realized[0].CostBasis, 1e-9)
+	require.Len(t, realized[0].Matches, 2)
+	assert.Equal(t, second.ID, realized[0].Matches[0].LotID)
+	assert.InDelta(t, 3*100, realized[1].CostBasis, 1e-9)
+
+	open := book.OpenLots()
+	require.Len(t, open, 2)
+	assert.InDelta(t, 5.0, open[0].Remaining, 1e-9)
+	assert.InDelta(t, 6.0, open[1].Remaining, 1e-9)
+}
+
+func TestBuildLotBook_Errors(t *testing.T) {
+	f := newLotFixture(t)
+	buy := f.trade(Buy, 0, 10, 100)
+
+	_, err := BuildLotBook([]*Transaction{buy, f.trade(Sell, 1, 11, 100)}, FIFO)
+	assert.ErrorIs(t, err, ErrInsufficientLots)
+
+	sale := f.trade(Sell, 1, 5, 100)
+	require.NoError(t, sale.SelectLots([]LotSelection{{LotID: uuid.New(), Quantity: 5}}))
+	_, err = BuildLotBook([]*Transaction{buy, sale}, SpecificLot)
+	assert.ErrorIs(t, err, ErrLotNotFound)
+
+	// 다른 방식에서는 로트 지정을 무시합니다
+	_, err = BuildLotBook([]*Transaction{buy, sale}, FIFO)
+	assert.NoError(t, err)
+}
+
+func TestTransaction_SelectLots(t *testing.T) {
+	f := newLotFixture(t)
+	buy := f.trade(Buy, 0, 10, 100)
+	sale := f.trade(Sell, 1, 5, 100)
+
+	assert.ErrorIs(t, buy.SelectLots([]LotSelection{{LotID: uuid.New(), Quantity: 10}}), ErrInvalidLotSelection)
+	assert.ErrorIs(t, sale.SelectLots([]LotSelection{{LotID: buy.ID, Quantity: 4}}), ErrInvalidLotSelection)
+	assert.NoError(t, sale.SelectLots([]LotSelection{{LotID: buy.ID, Quantity: 5}}))
+	assert.Len(t, sale.Lots, 1)
+
+	assert.NoError(t, sale.SelectLots(nil))
+	assert.Nil(t, sale.Lots)
+}
+
+func TestLotBook_Unrealized(t *testing.T) {
+	f := newLotFixture(t)
+	buy := f.trade(Buy, 0, 10, 100)
+	partial := f.trade(Sell, 1, 2.5, 120)
+
+	book, err := BuildLotBook([]*Transaction{buy, partial}, FIFO)
+	require.NoError(t, err)
+
+	prices := LastTradePrices([]*Transaction{buy, partial})
+	assert.Equal(t, 120.0, prices[f.assetID].Amount)
+
+	gains, err := book.Unrealized(prices)
+	require.NoError(t, err)
+	require.Len(t, gains, 1)
+	assert.InDelta(t, 7.5, gains[0].Quantity, 1e-9)
+	assert.InDelta(t, 7.5*120, gains[0].MarketValue, 1e-9)
+	assert.InDelta(t, 7.5*20, gains[0].Gain, 1e-9)
+
+	_, err = book.Unrealized(map[uuid.UUID]valueobjects.Money{f.assetID: {Amount: 1, Currency: "KRW"}})
+	assert.Error(t, err)
+}
+
+func TestParseCostBasisMethod(t *testing.T) {
+	method, err := ParseCostBasisMethod("")
+	assert.NoError(t, err)
+	assert.Equal(t, FIFO, method)
+
+	method, err = ParseCostBasisMethod("average")
+	assert.NoError(t, err)
+	assert.Equal(t, AverageCost, method)
+
+	_, err = ParseCostBasisMethod("HIFO")
+	assert.Error(t, err)
+}
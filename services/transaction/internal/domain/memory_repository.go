@@ -36,7 +36,11 @@ func (r *MemoryTransactionRepository) Save(ctx context.Context, transaction *Tra
 		return fmt.Errorf("transaction already exists: %s", transaction.ID)
 	}
 
-	r.transactions[transaction.ID.String()] = transaction
+	if transaction.Version == 0 {
+		transaction.Version = 1
+	}
+	// 호출자가 Update 없이 변경한 내용이 반영되지 않도록 복사본을 보관합니다
+	r.transactions[transaction.ID.String()] = transaction.Clone()
 	return nil
 }
 
@@ -46,7 +50,7 @@ func (r *MemoryTransactionRepository) FindByID(ctx context.Context, id uuid.UUID
 	defer r.mu.RUnlock()
 
 	if transaction, exists := r.transactions[id.String()]; exists {
-		return transaction, nil
+		return transaction.Clone(), nil
 	}
 
 	return nil, fmt.Errorf("transaction not found: %s", id)
@@ -60,7 +64,7 @@ func (r *MemoryTransactionRepository) FindByUserID(ctx context.Context, userID u
 	var transactions []*Transaction
 	for _, transaction := range r.transactions {
 		if transaction.UserID.String() == userID.String() {
-			transactions = append(transactions, transaction)
+			transactions = append(transactions, transaction.Clone())
 		}
 	}
 
@@ -75,7 +79,7 @@ func (r *MemoryTransactionRepository) FindByPortfolioID(ctx context.Context, por
 	var transactions []*Transaction
 	for _, transaction := range r.transactions {
 		if transaction.PortfolioID.String() == portfolioID.String() {
-			transactions = append(transactions, transaction)
+			transactions = append(transactions, transaction.Clone())
 		}
 	}
 
@@ -90,7 +94,7 @@ func (r *MemoryTransactionRepository) FindByAssetID(ctx context.Context, assetID
 	var transactions []*Transaction
 	for _, transaction := range r.transactions {
 		if transaction.AssetID.String() == assetID.String() {
-			transactions = append(transactions, transaction)
+			transactions = append(transactions, transaction.Clone())
 		}
 	}
 
@@ -98,15 +102,21 @@ func (r *MemoryTransactionRepository) FindByAssetID(ctx context.Context, assetID
 }
 
 // Update는 기존 거래를 업데이트합니다
+// 저장된 버전이 다르면 버전 충돌 에러를 반환하고, 성공하면 버전을 1 증가시킵니다
 func (r *MemoryTransactionRepository) Update(ctx context.Context, transaction *Transaction) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.transactions[transaction.ID.String()]; !exists {
+	stored, exists := r.transactions[transaction.ID.String()]
+	if !exists {
 		return fmt.Errorf("transaction not found: %s", transaction.ID)
 	}
+	if stored.Version != transaction.Version {
+		return NewTransactionVersionConflictError(transaction.ID, transaction.Version, stored.Version)
+	}
 
-	r.transactions[transaction.ID.String()] = transaction
+	transaction.Version++
+	r.transactions[transaction.ID.String()] = transaction.Clone()
 	return nil
 }
 
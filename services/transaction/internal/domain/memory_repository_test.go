@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	commonerrors "github.com/aske/go_fi_chart/internal/common/errors"
 	"github.com/aske/go_fi_chart/pkg/domain/events"
 	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
 	"github.com/google/uuid"
@@ -166,6 +167,31 @@ func TestMemoryTransactionRepository_Update(t *testing.T) {
 	assert.Equal(t, transaction, updated)
 }
 
+func TestMemoryTransactionRepository_Update_RejectsStaleVersion(t *testing.T) {
+	// Given
+	eventBus := events.NewSimplePublisher()
+	repo := NewMemoryTransactionRepository(eventBus)
+	transaction := createTestTransaction()
+	err := repo.Save(context.Background(), transaction)
+	assert.NoError(t, err)
+	first, _ := repo.FindByID(context.Background(), transaction.ID)
+	second, _ := repo.FindByID(context.Background(), transaction.ID)
+
+	// When
+	first.Update(Sell, first.Amount, 3.0, first.ExecutedPrice, first.ExecutedAt)
+	firstErr := repo.Update(context.Background(), first)
+	second.Update(Buy, second.Amount, 5.0, second.ExecutedPrice, second.ExecutedAt)
+	secondErr := repo.Update(context.Background(), second)
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.Equal(t, int64(2), first.Version)
+	assert.True(t, commonerrors.IsVersionConflict(secondErr))
+	stored, err := repo.FindByID(context.Background(), transaction.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, stored.Quantity)
+}
+
 func TestMemoryTransactionRepository_Delete(t *testing.T) {
 	// Given
 	eventBus := events.NewSimplePublisher()
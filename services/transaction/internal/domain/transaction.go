@@ -3,8 +3,10 @@ package domain
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	commonerrors "github.com/aske/go_fi_chart/internal/common/errors"
 	"github.com/aske/go_fi_chart/pkg/domain/events"
 	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
 	"github.com/google/uuid"
@@ -29,9 +31,17 @@ type Transaction struct {
 	Quantity      float64
 	ExecutedPrice valueobjects.Money
 	ExecutedAt    time.Time
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	events        []events.Event
+	Lots          []LotSelection // SPECIFIC 방식에서 매도가 소진할 매수 로트, 비어 있으면 FIFO로 대응시킵니다
+	Charges       []Charge       // 수수료와 세금 항목, 통화는 Amount와 같습니다
+	// 기업 활동 거래에서만 사용합니다. CorporateAction을 참고하세요
+	Ratio           float64
+	RelatedAssetID  uuid.UUID
+	BasisAllocation float64
+	Reconciled      bool // 은행/증권사 거래 내역과 대사가 끝난 거래입니다
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Version         int64 // 낙관적 잠금 버전, 저장소가 저장/수정할 때 관리합니다
+	events          []events.Event
 }
 
 // NewTransaction은 새로운 거래를 생성합니다
@@ -86,6 +96,9 @@ func NewTransaction(
 
 // Validate는 거래의 유효성을 검증합니다
 func (t *Transaction) Validate() error {
+	if t.Type.IsCorporateAction() {
+		return t.validateCorporateAction()
+	}
 	if t.Quantity <= 0 {
 		return errors.New("quantity must be positive")
 	}
@@ -95,9 +108,13 @@ func (t *Transaction) Validate() error {
 	return nil
 }
 
-// CalculateTotalAmount는 거래의 총 금액을 계산합니다
+// CalculateTotalAmount는 수수료와 세금을 반영한 결제 금액을 계산합니다
+// 매수는 지급액(CostBasis), 그 외 거래는 순수령액(NetProceeds)입니다
 func (t *Transaction) CalculateTotalAmount() valueobjects.Money {
-	return t.Amount
+	if t.Type == Buy {
+		return t.CostBasis()
+	}
+	return t.NetProceeds()
 }
 
 // Update는 거래 정보를 업데이트합니다
@@ -121,11 +138,48 @@ func (t *Transaction) Update(
 	t.events = append(t.events, NewTransactionUpdatedEvent(t, prevAmount, prevQuantity))
 }
 
+// SetReconciled는 거래의 대사 완료 여부를 표시합니다
+func (t *Transaction) SetReconciled(reconciled bool) {
+	t.Reconciled = reconciled
+	t.UpdatedAt = time.Now()
+}
+
+// SelectLots는 매도가 소진할 매수 로트를 지정합니다. nil이면 지정을 해제합니다
+// 지정 수량의 합은 매도 수량과 같아야 합니다
+func (t *Transaction) SelectLots(selections []LotSelection) error {
+	if len(selections) == 0 {
+		t.Lots = nil
+		return nil
+	}
+	if t.Type != Sell {
+		return fmt.Errorf("%w: only sell transactions can select lots", ErrInvalidLotSelection)
+	}
+	if err := validateSelections(selections, t.Quantity); err != nil {
+		return err
+	}
+	t.Lots = append([]LotSelection(nil), selections...)
+	return nil
+}
+
 // MarkAsDeleted는 거래를 삭제 상태로 표시합니다
 func (t *Transaction) MarkAsDeleted() {
 	t.events = append(t.events, NewTransactionDeletedEvent(t))
 }
 
+// Clone은 이벤트 목록을 포함한 거래의 복사본을 반환합니다
+func (t *Transaction) Clone() *Transaction {
+	clone := *t
+	if t.Lots != nil {
+		clone.Lots = append([]LotSelection(nil), t.Lots...)
+	}
+	if t.Charges != nil {
+		clone.Charges = append([]Charge(nil), t.Charges...)
+	}
+	clone.events = make([]events.Event, len(t.events))
+	copy(clone.events, t.events)
+	return &clone
+}
+
 // Events는 발생한 이벤트 목록을 반환합니다
 func (t *Transaction) Events() []events.Event {
 	return t.events
@@ -136,7 +190,13 @@ func (t *Transaction) ClearEvents() {
 	t.events = make([]events.Event, 0)
 }
 
+// NewTransactionVersionConflictError는 거래의 요청 버전이 저장된 버전과 다른 에러를 생성합니다
+func NewTransactionVersionConflictError(id uuid.UUID, expected, actual int64) error {
+	return commonerrors.NewVersionConflictError("transaction", id.String(), expected, actual)
+}
+
 // TransactionRepository는 거래 저장소 인터페이스를 정의합니다
+// Update는 저장된 버전이 transaction.Version과 다르면 버전 충돌 에러를 반환합니다
 type TransactionRepository interface {
 	Save(ctx context.Context, transaction *Transaction) error
 	FindByID(ctx context.Context, id uuid.UUID) (*Transaction, error)
@@ -0,0 +1,334 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/aske/go_fi_chart/internal/common/repository"
+	"github.com/aske/go_fi_chart/pkg/domain/events"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/aske/go_fi_chart/services/transaction/internal/domain"
+)
+
+// TransactionRepository MongoDB 기반 거래 저장소 구현체입니다.
+type TransactionRepository struct {
+	collection *mongo.Collection
+	eventBus   events.EventBus
+}
+
+// 거래 문서 구조체
+type transactionDocument struct {
+	ID              string                 `bson:"_id"`
+	UserID          string                 `bson:"user_id"`
+	PortfolioID     string                 `bson:"portfolio_id"`
+	AssetID         string                 `bson:"asset_id"`
+	Type            domain.TransactionType `bson:"type"`
+	Amount          moneyDocument          `bson:"amount"`
+	Quantity        float64                `bson:"quantity"`
+	ExecutedPrice   moneyDocument          `bson:"executed_price"`
+	ExecutedAt      primitive.DateTime     `bson:"executed_at"`
+	Lots            []lotSelectionDocument `bson:"lots,omitempty"`
+	Charges         []chargeDocument       `bson:"charges,omitempty"`
+	Ratio           float64                `bson:"ratio,omitempty"`
+	RelatedAssetID  string                 `bson:"related_asset_id,omitempty"`
+	BasisAllocation float64                `bson:"basis_allocation,omitempty"`
+	Reconciled      bool                   `bson:"reconciled,omitempty"`
+	CreatedAt       primitive.DateTime     `bson:"created_at"`
+	UpdatedAt       primitive.DateTime     `bson:"updated_at"`
+	Version         int64                  `bson:"version"`
+}
+
+type lotSelectionDocument struct {
+	LotID    string  `bson:"lot_id"`
+	Quantity float64 `bson:"quantity"`
+}
+
+type chargeDocument struct {
+	Type   domain.ChargeType `bson:"type"`
+	Amount moneyDocument     `bson:"amount"`
+}
+
+type moneyDocument struct {
+	Amount   float64 `bson:"amount"`
+	Currency string  `bson:"currency"`
+}
+
+// NewTransactionRepository는 새로운 MongoDB 거래 저장소를 생성합니다.
+// eventBus가 nil이면 도메인 이벤트를 발행하지 않습니다.
+func NewTransactionRepository(db *mongo.Database, eventBus events.EventBus) *TransactionRepository {
+	return &TransactionRepository{
+		collection: db.Collection("transactions"),
+		eventBus:   eventBus,
+	}
+}
+
+// Init MongoDB 컬렉션에 필요한 인덱스를 초기화합니다.
+func (r *TransactionRepository) Init(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// 사용자별 거래 내역 조회
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "executed_at", Value: -1}}},
+		// 포트폴리오별 거래 내역 조회
+		{Keys: bson.D{{Key: "portfolio_id", Value: 1}, {Key: "executed_at", Value: -1}}},
+		// 자산별 거래 내역 조회
+		{Keys: bson.D{{Key: "asset_id", Value: 1}, {Key: "executed_at", Value: -1}}},
+	})
+	return err
+}
+
+// toDocument는 Transaction 엔티티를 MongoDB 문서로 변환합니다.
+func toDocument(t *domain.Transaction) transactionDocument {
+	var lots []lotSelectionDocument
+	for _, lot := range t.Lots {
+		lots = append(lots, lotSelectionDocument{LotID: lot.LotID.String(), Quantity: lot.Quantity})
+	}
+	var charges []chargeDocument
+	for _, charge := range t.Charges {
+		charges = append(charges, chargeDocument{
+			Type:   charge.Type,
+			Amount: moneyDocument{Amount: charge.Amount.Amount, Currency: charge.Amount.Currency},
+		})
+	}
+	var relatedAssetID string
+	if t.RelatedAssetID != uuid.Nil {
+		relatedAssetID = t.RelatedAssetID.String()
+	}
+	return transactionDocument{
+		ID:              t.ID.String(),
+		UserID:          t.UserID.String(),
+		PortfolioID:     t.PortfolioID.String(),
+		AssetID:         t.AssetID.String(),
+		Type:            t.Type,
+		Amount:          moneyDocument{Amount: t.Amount.Amount, Currency: t.Amount.Currency},
+		Quantity:        t.Quantity,
+		ExecutedPrice:   moneyDocument{Amount: t.ExecutedPrice.Amount, Currency: t.ExecutedPrice.Currency},
+		ExecutedAt:      primitive.NewDateTimeFromTime(t.ExecutedAt),
+		Lots:            lots,
+		Charges:         charges,
+		Ratio:           t.Ratio,
+		RelatedAssetID:  relatedAssetID,
+		BasisAllocation: t.BasisAllocation,
+		Reconciled:      t.Reconciled,
+		CreatedAt:       primitive.NewDateTimeFromTime(t.CreatedAt),
+		UpdatedAt:       primitive.NewDateTimeFromTime(t.UpdatedAt),
+		Version:         t.Version,
+	}
+}
+
+// fromDocument는 MongoDB 문서를 Transaction 엔티티로 변환합니다.
+func fromDocument(doc transactionDocument) (*domain.Transaction, error) {
+	ids := make([]uuid.UUID, 4)
+	for i, raw := range []string{doc.ID, doc.UserID, doc.PortfolioID, doc.AssetID} {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q: %w", raw, err)
+		}
+		ids[i] = id
+	}
+
+	var lots []domain.LotSelection
+	for _, lot := range doc.Lots {
+		lotID, err := uuid.Parse(lot.LotID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lot id %q: %w", lot.LotID, err)
+		}
+		lots = append(lots, domain.LotSelection{LotID: lotID, Quantity: lot.Quantity})
+	}
+
+	var charges []domain.Charge
+	for _, charge := range doc.Charges {
+		charges = append(charges, domain.Charge{
+			Type:   charge.Type,
+			Amount: valueobjects.Money{Amount: charge.Amount.Amount, Currency: charge.Amount.Currency},
+		})
+	}
+
+	var relatedAssetID uuid.UUID
+	if doc.RelatedAssetID != "" {
+		id, err := uuid.Parse(doc.RelatedAssetID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid related asset id %q: %w", doc.RelatedAssetID, err)
+		}
+		relatedAssetID = id
+	}
+
+	return &domain.Transaction{
+		ID:              ids[0],
+		UserID:          ids[1],
+		PortfolioID:     ids[2],
+		AssetID:         ids[3],
+		Type:            doc.Type,
+		Amount:          valueobjects.Money{Amount: doc.Amount.Amount, Currency: doc.Amount.Currency},
+		Quantity:        doc.Quantity,
+		ExecutedPrice:   valueobjects.Money{Amount: doc.ExecutedPrice.Amount, Currency: doc.ExecutedPrice.Currency},
+		ExecutedAt:      doc.ExecutedAt.Time(),
+		Lots:            lots,
+		Charges:         charges,
+		Ratio:           doc.Ratio,
+		RelatedAssetID:  relatedAssetID,
+		BasisAllocation: doc.BasisAllocation,
+		Reconciled:      doc.Reconciled,
+		CreatedAt:       doc.CreatedAt.Time(),
+		UpdatedAt:       doc.UpdatedAt.Time(),
+		Version:         doc.Version,
+	}, nil
+}
+
+// Save는 새로운 거래를 저장합니다.
+func (r *TransactionRepository) Save(ctx context.Context, transaction *domain.Transaction) error {
+	doc := toDocument(transaction)
+	if doc.Version == 0 {
+		doc.Version = 1
+	}
+	_, err := r.collection.InsertOne(ctx, doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("transaction already exists: %s", transaction.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+	transaction.Version = doc.Version
+	return r.publish(ctx, transaction)
+}
+
+// FindByID는 ID로 거래를 조회합니다.
+func (r *TransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	var doc transactionDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTransactionNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transaction: %w", err)
+	}
+	return fromDocument(doc)
+}
+
+// FindByUserID는 사용자 ID로 거래 목록을 체결 시각 역순으로 조회합니다.
+func (r *TransactionRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Transaction, error) {
+	return r.Find(ctx, repository.WithFilter("user_id", userID.String()), repository.WithLimit(0),
+		repository.WithSort("executed_at", repository.SortDescending))
+}
+
+// FindByPortfolioID는 포트폴리오 ID로 거래 목록을 체결 시각 역순으로 조회합니다.
+func (r *TransactionRepository) FindByPortfolioID(ctx context.Context, portfolioID uuid.UUID) ([]*domain.Transaction, error) {
+	return r.Find(ctx, repository.WithFilter("portfolio_id", portfolioID.String()), repository.WithLimit(0),
+		repository.WithSort("executed_at", repository.SortDescending))
+}
+
+// FindByAssetID는 자산 ID로 거래 목록을 체결 시각 역순으로 조회합니다.
+func (r *TransactionRepository) FindByAssetID(ctx context.Context, assetID uuid.UUID) ([]*domain.Transaction, error) {
+	return r.Find(ctx, repository.WithFilter("asset_id", assetID.String()), repository.WithLimit(0),
+		repository.WithSort("executed_at", repository.SortDescending))
+}
+
+// Find는 조회 옵션의 필터, 정렬, 페이지네이션을 적용해 거래 목록을 조회합니다.
+// 필드 이름은 문서의 bson 필드 이름(예: "amount.amount", "executed_at")을 사용합니다.
+func (r *TransactionRepository) Find(ctx context.Context, opts ...repository.FindOption) ([]*domain.Transaction, error) {
+	options := findOptions(opts)
+	filter, err := options.ToMongoFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.ToMongoOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transactions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	transactions := []*domain.Transaction{}
+	for cursor.Next(ctx) {
+		var doc transactionDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode transaction: %w", err)
+		}
+		transaction, err := fromDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+	return transactions, nil
+}
+
+// Count는 조회 옵션의 필터에 맞는 거래 개수를 반환합니다.
+func (r *TransactionRepository) Count(ctx context.Context, opts ...repository.FindOption) (int64, error) {
+	filter, err := findOptions(opts).ToMongoFilter()
+	if err != nil {
+		return 0, err
+	}
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+	return count, nil
+}
+
+// Update는 기존 거래를 업데이트합니다.
+// 저장된 버전이 transaction.Version과 같을 때만 교체하며, 성공하면 버전을 1 증가시킵니다.
+func (r *TransactionRepository) Update(ctx context.Context, transaction *domain.Transaction) error {
+	doc := toDocument(transaction)
+	doc.Version = transaction.Version + 1
+
+	filter := bson.M{"_id": doc.ID, "version": transaction.Version}
+	result, err := r.collection.ReplaceOne(ctx, filter, doc)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		// 문서가 없으면 FindByID가 not found 에러를 반환합니다
+		current, err := r.FindByID(ctx, transaction.ID)
+		if err != nil {
+			return err
+		}
+		return domain.NewTransactionVersionConflictError(transaction.ID, transaction.Version, current.Version)
+	}
+	transaction.Version = doc.Version
+	return r.publish(ctx, transaction)
+}
+
+// Delete는 거래를 삭제합니다.
+func (r *TransactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	transaction, err := r.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()}); err != nil {
+		return fmt.Errorf("failed to delete transaction: %w", err)
+	}
+
+	transaction.MarkAsDeleted()
+	return r.publish(ctx, transaction)
+}
+
+// publish는 거래에 쌓인 도메인 이벤트를 발행하고 비웁니다.
+func (r *TransactionRepository) publish(ctx context.Context, transaction *domain.Transaction) error {
+	if r.eventBus == nil {
+		return nil
+	}
+	for _, event := range transaction.Events() {
+		if err := r.eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	transaction.ClearEvents()
+	return nil
+}
+
+func findOptions(opts []repository.FindOption) *repository.FindOptions {
+	options := repository.NewFindOptions()
+	for _, opt := range opts {
+		opt.Apply(options)
+	}
+	return options
+}
@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/aske/go_fi_chart/internal/common/repository"
+	"github.com/aske/go_fi_chart/pkg/domain/valueobjects"
+	"github.com/aske/go_fi_chart/services/transaction/internal/domain"
+)
+
+// setupMongoDBTest MONGODB_TEST_URI가 가리키는 로컬 mongod에 테스트 DB를 새로 만들어 저장소를 반환합니다.
+// 환경 변수가 없으면 테스트를 건너뜁니다.
+func setupMongoDBTest(t *testing.T) *TransactionRepository {
+	t.Helper()
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI가 설정되지 않아 MongoDB 통합 테스트를 건너뜁니다")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	db := client.Database("transaction_test_db")
+	require.NoError(t, db.Drop(ctx))
+	t.Cleanup(func() {
+		_ = db.Drop(context.Background())
+		_ = client.Disconnect(context.Background())
+	})
+
+	repo := NewTransactionRepository(db, nil)
+	require.NoError(t, repo.Init(ctx))
+	return repo
+}
+
+func newTestTransaction(t *testing.T, userID, portfolioID uuid.UUID, amount float64, executedAt time.Time) *domain.Transaction {
+	t.Helper()
+	money, err := valueobjects.NewMoney(amount, "KRW")
+	require.NoError(t, err)
+	transaction, err := domain.NewTransaction(userID, portfolioID, uuid.New(), domain.Buy, money, 1, money, executedAt)
+	require.NoError(t, err)
+	return transaction
+}
+
+func TestTransactionRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := setupMongoDBTest(t)
+
+	transaction := newTestTransaction(t, uuid.New(), uuid.New(), 1000, time.Now().UTC())
+	require.NoError(t, repo.Save(ctx, transaction))
+	assert.Error(t, repo.Save(ctx, transaction))
+
+	found, err := repo.FindByID(ctx, transaction.ID)
+	require.NoError(t, err)
+	assert.Equal(t, transaction.Amount, found.Amount)
+	assert.Equal(t, transaction.AssetID, found.AssetID)
+
+	transaction.Quantity = 3
+	require.NoError(t, repo.Update(ctx, transaction))
+	found, err = repo.FindByID(ctx, transaction.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, found.Quantity)
+
+	require.NoError(t, repo.Delete(ctx, transaction.ID))
+	_, err = repo.FindByID(ctx, transaction.ID)
+	assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+}
+
+func TestTransactionRepository_FindWithOptions(t *testing.T) {
+	ctx := context.Background()
+	repo := setupMongoDBTest(t)
+
+	userID, portfolioID := uuid.New(), uuid.New()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, amount := range []float64{1000, 2000, 3000, 4000} {
+		require.NoError(t, repo.Save(ctx, newTestTransaction(t, userID, portfolioID, amount, base.Add(time.Duration(i)*time.Hour))))
+	}
+	require.NoError(t, repo.Save(ctx, newTestTransaction(t, uuid.New(), uuid.New(), 5000, base)))
+
+	byUser, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, byUser, 4)
+	assert.Equal(t, 4000.0, byUser[0].Amount.Amount)
+
+	page, err := repo.Find(ctx,
+		repository.WithFilter("portfolio_id", portfolioID.String()),
+		repository.WithComplexFilter("amount.amount", "gte", 2000),
+		repository.WithSort("amount.amount", repository.SortAscending),
+		repository.WithPagination(2, 2),
+	)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, 4000.0, page[0].Amount.Amount)
+
+	count, err := repo.Count(ctx, repository.WithComplexFilter("amount.amount", "in", []float64{1000, 5000}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}